@@ -0,0 +1,1784 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(&NodeConfig{}, &NodeConfigList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+
+// NodeConfig is the primary configuration object for `nodeadm`.
+type NodeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              NodeConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type NodeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeConfig `json:"items"`
+}
+
+type NodeConfigSpec struct {
+	Cluster    ClusterDetails    `json:"cluster,omitempty"`
+	Containerd ContainerdOptions `json:"containerd,omitempty"`
+	Instance   InstanceOptions   `json:"instance,omitempty"`
+	Kubelet    KubeletOptions    `json:"kubelet,omitempty"`
+	// FeatureGates holds key-value pairs to enable or disable application features.
+	FeatureGates map[Feature]bool `json:"featureGates,omitempty"`
+	// NodeProvider is the environment the node is being bootstrapped in.
+	// Defaults to `ec2`.
+	NodeProvider NodeProvider `json:"nodeProvider,omitempty"`
+	// Hybrid contains the details of a node that is not an EC2 instance. It
+	// is required when NodeProvider is `hybrid`.
+	Hybrid HybridOptions `json:"hybrid,omitempty"`
+	// Debug holds options for collecting diagnostics about the node.
+	Debug DebugOptions `json:"debug,omitempty"`
+	// Proxy holds the HTTP proxy that is used to reach your cluster and AWS
+	// services.
+	Proxy ProxyOptions `json:"proxy,omitempty"`
+	// Security holds options that harden the node.
+	Security SecurityOptions `json:"security,omitempty"`
+	// Hooks are commands that `nodeadm init` runs at points of the bootstrap,
+	// to extend it without building a custom AMI.
+	Hooks HooksOptions `json:"hooks,omitempty"`
+	// Components pins the versions of the binaries of the node, which
+	// `nodeadm upgrade components` installs from a repository.
+	Components ComponentsOptions `json:"components,omitempty"`
+	// Networking holds options for the network interfaces of the node.
+	Networking NetworkingOptions `json:"networking,omitempty"`
+	// Monitoring holds options for the telemetry that the node publishes.
+	Monitoring MonitoringOptions `json:"monitoring,omitempty"`
+	// Offline bootstraps the node without calling AWS APIs, for isolated regions and disconnected networks. The
+	// details that `nodeadm` would look up must be set instead: the `cidr` of the cluster, `kubelet.clusterDNS`, and
+	// a `containerd.sandboxImage` with a registry unless the pause image of the AMI is used. `maxPods` defaults to the
+	// limit of the instance type when it is known to `nodeadm`, and must be set in `kubelet.config` otherwise. The
+	// node name is the instance ID, so the `InstanceIdNodeName` feature gate must be enabled. The instance metadata
+	// service is only called when `instance.identity` is not set. Any call of an AWS API fails with an error, rather
+	// than waiting for an endpoint that cannot be reached.
+	Offline bool `json:"offline,omitempty"`
+	// System configures the operating system of the node.
+	System SystemOptions `json:"system,omitempty"`
+	// Systemd holds drop-ins for the systemd units of the node, which are written before any of the units that
+	// `nodeadm` manages are started.
+	Systemd SystemdOptions `json:"systemd,omitempty"`
+}
+
+// SystemOptions configure the operating system of the node.
+type SystemOptions struct {
+	// Sysctl are kernel parameters that are written to `/etc/sysctl.d/99-nodeadm-sysctl.conf` and set when the
+	// node is initialized, such as `net.core.somaxconn`. They take precedence over the parameters of the AMI and of the
+	// hardening profile. Parameters of the `kernel` subsystem and those that the node depends on, such as
+	// `net.ipv4.ip_forward`, cannot be set, and the inotify limits cannot be lowered below those that `kubelet` and
+	// `containerd` need.
+	Sysctl map[string]string `json:"sysctl,omitempty"`
+}
+
+// SystemdOptions configure the systemd units of the node.
+type SystemdOptions struct {
+	// Units are the units that drop-ins are written for.
+	Units []SystemdUnit `json:"units,omitempty"`
+}
+
+// SystemdUnit is a systemd unit and the drop-ins written for it to `/etc/systemd/system/<name>.d/`. Services that
+// are running when their drop-ins change are restarted after the systemd configuration is reloaded.
+type SystemdUnit struct {
+	// Name is the name of the unit, including its type suffix, such as `containerd.service`.
+	Name string `json:"name"`
+
+	// DropIns are the drop-ins of the unit.
+	DropIns []SystemdDropIn `json:"dropIns,omitempty"`
+}
+
+// SystemdDropIn is a drop-in file that overrides or extends the configuration of a unit. Drop-ins that are no longer
+// in the configuration are removed by the next `nodeadm init`.
+type SystemdDropIn struct {
+	// Name is the name of the drop-in file, which must end in `.conf`. Names containing `-nodeadm-` are reserved.
+	Name string `json:"name"`
+
+	// Content is the content of the drop-in file, in the format of a systemd unit file.
+	Content string `json:"content"`
+}
+
+// ComponentsOptions pin the versions of the components of the node. `nodeadm upgrade components` verifies the versions
+// that are installed, and replaces the components whose versions differ with the artifacts of the pinned versions, which
+// are downloaded from `<repository>/<component>/<version>/<arch>/<artifact>`, where `<arch>` is `amd64` or `arm64`. Each
+// artifact must be signed by the private key of PublicKey, with the base64-encoded signature at the URL of the artifact
+// with a `.sig` suffix, as `cosign sign-blob` writes it. Components whose version is not set are left as they are.
+type ComponentsOptions struct {
+	// Repository is the `s3://` or `https://` URL that the artifacts are downloaded from.
+	Repository string `json:"repository,omitempty"`
+
+	// PublicKey is the PEM-encoded ECDSA, Ed25519 or RSA public key that verifies the signatures of the artifacts.
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Kubelet is the version of `kubelet`, whose artifact is `kubelet`.
+	Kubelet string `json:"kubelet,omitempty"`
+
+	// Containerd is the version of `containerd`, whose artifacts are `containerd` and `containerd-shim-runc-v2`.
+	Containerd string `json:"containerd,omitempty"`
+
+	// Runc is the version of `runc`, whose artifact is `runc`.
+	Runc string `json:"runc,omitempty"`
+
+	// CNIPlugins is the version of the CNI plugins in `/opt/cni/bin`, whose artifact is the gzipped tarball
+	// `cni-plugins.tgz`.
+	CNIPlugins string `json:"cniPlugins,omitempty"`
+}
+
+// MonitoringOptions are options for the telemetry that the node publishes.
+type MonitoringOptions struct {
+	// CloudWatchAgent configures the CloudWatch agent of the node to publish its metrics and the logs of `kubelet`
+	// and `containerd`.
+	CloudWatchAgent CloudWatchAgentOptions `json:"cloudwatchAgent,omitempty"`
+}
+
+// CloudWatchAgentOptions configure the [CloudWatch agent](https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/Install-CloudWatch-Agent.html),
+// which must be installed on the AMI. `nodeadm` writes the config of the agent to its `amazon-cloudwatch-agent.d`
+// directory and restarts the `amazon-cloudwatch-agent` unit. The journals of `kubelet` and `containerd` are exported to
+// `/var/log/nodeadm/journal` for the agent to publish. The role of the instance must allow the agent to put metrics
+// and logs, such as with the `CloudWatchAgentServerPolicy` managed policy.
+type CloudWatchAgentOptions struct {
+	// Enabled configures and starts the CloudWatch agent.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespace is the CloudWatch namespace of the metrics. Defaults to `CWAgent`.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Metrics are the sets of metrics that are published, with the `InstanceId`, `InstanceType` and `ClusterName`
+	// dimensions. Defaults to `disk` and `mem`.
+	Metrics []CloudWatchAgentMetric `json:"metrics,omitempty"`
+
+	// MetricsCollectionInterval is how often the metrics are collected. Defaults to `60s`.
+	MetricsCollectionInterval *metav1.Duration `json:"metricsCollectionInterval,omitempty"`
+
+	// LogGroupName is the log group of the logs of `kubelet` and `containerd`, whose log streams are
+	// `<instance-id>/kubelet` and `<instance-id>/containerd`. Defaults to `/aws/eks/<cluster-name>/nodes`.
+	LogGroupName string `json:"logGroupName,omitempty"`
+}
+
+// CloudWatchAgentMetric is a set of metrics of the CloudWatch agent.
+//
+// * `cpu` is the usage of the CPUs of the node.
+// * `disk` is the usage of its filesystems.
+// * `diskio` is the IO of its disks.
+// * `mem` is the usage of its memory.
+// * `net` is the traffic of its network interfaces.
+// +kubebuilder:validation:Enum={cpu, disk, diskio, mem, net}
+type CloudWatchAgentMetric string
+
+const (
+	CloudWatchAgentMetricCPU    CloudWatchAgentMetric = "cpu"
+	CloudWatchAgentMetricDisk   CloudWatchAgentMetric = "disk"
+	CloudWatchAgentMetricDiskIO CloudWatchAgentMetric = "diskio"
+	CloudWatchAgentMetricMem    CloudWatchAgentMetric = "mem"
+	CloudWatchAgentMetricNet    CloudWatchAgentMetric = "net"
+)
+
+// NetworkingOptions are options for the network interfaces of the node.
+type NetworkingOptions struct {
+	// ExcludeInterfaces are glob patterns of the network interfaces that `nodeadm` does not consider for the node IP
+	// of `kubelet`, such as secondary interfaces that carry storage or appliance traffic. A pattern matches the name
+	// of an interface on the node, such as `eth2` or `ens*`, or its MAC address, such as `0a:1b:*`. The primary
+	// interface can only be excluded when the node IP is given with the `Explicit` policy. The pods of the
+	// interfaces are subtracted from the `maxPods` that `nodeadm` calculates when `kubelet.config` does not set it.
+	ExcludeInterfaces []string `json:"excludeInterfaces,omitempty"`
+}
+
+// HooksOptions are the commands that `nodeadm init` runs at each point of the bootstrap. The hooks of a
+// point are run one at a time, in order, and their output is logged by `nodeadm` with the name of the hook.
+type HooksOptions struct {
+	// PreInit hooks are run once the configuration is validated, before `nodeadm init` changes the node.
+	PreInit []Hook `json:"preInit,omitempty"`
+
+	// PostContainerd hooks are run once `containerd` is running, before `kubelet` is started.
+	PostContainerd []Hook `json:"postContainerd,omitempty"`
+
+	// PostKubelet hooks are run once `kubelet` is running.
+	PostKubelet []Hook `json:"postKubelet,omitempty"`
+}
+
+// Hook is a command that is run by `nodeadm init`, with `NODEADM_HOOK_POINT` and `NODEADM_HOOK_NAME` set in its
+// environment. Exactly one of `command`, `script` and `url` must be set.
+type Hook struct {
+	// Name identifies the hook in the logs of `nodeadm`. Defaults to the position of the hook, such as `preInit[0]`.
+	Name string `json:"name,omitempty"`
+
+	// Command is an executable and its arguments, which are run without a shell.
+	Command []string `json:"command,omitempty"`
+
+	// Script is run with `/bin/sh`, or with PowerShell on Windows.
+	Script string `json:"script,omitempty"`
+
+	// URL is the `s3://` or `https://` URL of a script that is downloaded when the hook is run, and run
+	// with `/bin/sh`, or with PowerShell on Windows. Objects in S3 are downloaded with the credentials of the instance.
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the script at `url`, which is verified before it is run.
+	// It is required with `url`.
+	SHA256 string `json:"sha256,omitempty"`
+
+	// Timeout is the maximum amount of time the hook may run before it is killed. Defaults to `5m`.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailurePolicy determines what `nodeadm init` does when the hook fails or times out. Defaults to `Fail`.
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// HookFailurePolicy is what `nodeadm init` does when a hook fails.
+//
+// * `Fail` fails `nodeadm init`, which rolls back the daemons it started.
+// * `Ignore` logs the failure and continues the bootstrap.
+// +kubebuilder:validation:Enum={Fail, Ignore}
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyFail   HookFailurePolicy = "Fail"
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+// ProxyOptions configure an HTTP proxy for `nodeadm`, `containerd`, and
+// `kubelet`.
+type ProxyOptions struct {
+	// HTTPProxy is the URL of the proxy used for HTTP requests.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy used for HTTPS requests.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a list of hostnames, domains, IP addresses, and CIDR blocks
+	// that are reached directly. `localhost`, the instance metadata service,
+	// the VPC's CIDR blocks, the cluster's service CIDR, and VPC endpoints are
+	// always reached directly.
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// SecurityOptions harden the node against the recommendations of the
+// [CIS Amazon EKS Benchmark](https://www.cisecurity.org/benchmark/kubernetes).
+type SecurityOptions struct {
+	// HardeningProfile applies the recommendations of a level of the benchmark to the configuration of `kubelet`,
+	// the permissions of its files, and the kernel parameters of the node. Recommendations that conflict with
+	// Kubernetes, or that are overridden by `kubelet.config`, are skipped. The applied and skipped controls are
+	// reported in `/var/lib/nodeadm/hardening-report.json`. Defaults to `none`.
+	HardeningProfile HardeningProfile `json:"hardeningProfile,omitempty"`
+
+	// UserNamespaces allows pods with `hostUsers: false` to run in their own user namespaces, which map the root
+	// user of their containers to an unprivileged user of the node.
+	UserNamespaces UserNamespacesOptions `json:"userNamespaces,omitempty"`
+
+	// SeccompDefault runs the containers that do not set a seccomp profile with a default profile, rather than
+	// unconfined, and installs seccomp profiles that pods can use.
+	SeccompDefault SeccompDefaultOptions `json:"seccompDefault,omitempty"`
+}
+
+// UserNamespacesOptions configure containerd and `kubelet` for pods in user namespaces. The files of such pods are
+// mapped to their users with idmap mounts, so `nodeadm init` checks that the node supports them, which requires
+// Linux 6.3 or later, containerd 2.0 or later, an OCI runtime with idmap mounts such as runc 1.2 or later, and
+// `kubelet` 1.30 or later.
+type UserNamespacesOptions struct {
+	// Enabled sets the `UserNamespacesSupport` feature gate of `kubelet`, and configures containerd to use idmap mounts.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// SeccompDefaultOptions manage the seccomp profiles of the node. Profiles are written to `/var/lib/kubelet/seccomp/profiles`,
+// where pods use them with a `localhostProfile` such as `profiles/audit.json`.
+type SeccompDefaultOptions struct {
+	// Enabled sets `seccompDefault` in the config of `kubelet`, which runs the containers that do not set a seccomp
+	// profile with the `RuntimeDefault` profile of containerd.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// DefaultProfile is the name of one of the Profiles, which containerd runs the containers that reach it without a
+	// seccomp profile with, such as those created with `crictl`, instead of its `RuntimeDefault` profile. Pods still
+	// run with `RuntimeDefault` when they do not set a profile, since `seccompDefault` is always set in the config of
+	// `kubelet` when Enabled. Requires Enabled.
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+
+	// Profiles are the seccomp profiles that are installed on the node.
+	Profiles []SeccompProfile `json:"profiles,omitempty"`
+}
+
+// SeccompProfile is a seccomp profile in the JSON format of the OCI runtime spec, which is either inline or
+// downloaded. Exactly one of `profile` and `url` must be set.
+type SeccompProfile struct {
+	// Name is the name of the file of the profile in `/var/lib/kubelet/seccomp/profiles`, without its `.json` extension.
+	Name string `json:"name"`
+
+	// Profile is the JSON of the profile.
+	Profile string `json:"profile,omitempty"`
+
+	// URL is the `s3://` or `https://` URL of the profile. Objects in S3 are downloaded with the credentials
+	// of the instance, and HTTPS requests use the proxy and trust store of the `NodeConfig`.
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the profile, which is verified before the profile is
+	// written. It is required with `url`.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// HardeningProfile is a level of the CIS Amazon EKS Benchmark.
+//
+// * `none` leaves the node as configured by nodeadm.
+// * `cis-level1` applies the recommendations that do not limit the functionality of the node.
+// * `cis-level2` additionally applies the recommendations for environments that need defense in depth, which
+// limit the rate of events recorded by `kubelet` and restrict the permissions of its kubeconfig and configuration.
+// +kubebuilder:validation:Enum={none, cis-level1, cis-level2}
+type HardeningProfile string
+
+const (
+	HardeningProfileNone      HardeningProfile = "none"
+	HardeningProfileCISLevel1 HardeningProfile = "cis-level1"
+	HardeningProfileCISLevel2 HardeningProfile = "cis-level2"
+)
+
+// DebugOptions control diagnostics that are collected to troubleshoot the node.
+type DebugOptions struct {
+	NetworkCapture NetworkCaptureOptions `json:"networkCapture,omitempty"`
+	Tracing        TracingOptions        `json:"tracing,omitempty"`
+	Logging        LoggingOptions        `json:"logging,omitempty"`
+}
+
+// LoggingOptions stream the logs of the node while it is bootstrapped, so that a node that failed to join the cluster
+// can be troubleshot after its instance is terminated.
+type LoggingOptions struct {
+	// CloudWatch streams the logs to CloudWatch Logs.
+	CloudWatch CloudWatchLoggingOptions `json:"cloudWatch,omitempty"`
+}
+
+// CloudWatchLoggingOptions stream the logs of `nodeadm init` to the `<instance-id>` log stream of a log group, from
+// the start of `init` until it exits, whether or not it succeeded. The journals of Units are streamed to the
+// `<instance-id>/<unit>` log streams by the `nodeadm-log-stream` service. The role of the instance must allow
+// `logs:CreateLogGroup`, `logs:CreateLogStream` and `logs:PutLogEvents`. This is not supported on hybrid nodes or in
+// offline mode.
+type CloudWatchLoggingOptions struct {
+	// Enabled streams the logs to CloudWatch Logs.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// LogGroupName is the log group of the log streams, which is created if it does not exist. Defaults to
+	// `/aws/eks/<cluster-name>/nodeadm`.
+	LogGroupName string `json:"logGroupName,omitempty"`
+
+	// Units are the systemd units whose journals are streamed, such as `kubelet` and `containerd`.
+	Units []string `json:"units,omitempty"`
+
+	// Duration is how long the journals of Units are streamed for after the node boots. Defaults to `10m`.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// NetworkCaptureOptions control a packet capture of the node's traffic to the
+// cluster endpoint and DNS while the node joins the cluster. The capture is
+// written to `/var/log/nodeadm/network-capture` and requires `tcpdump`.
+type NetworkCaptureOptions struct {
+	// Enabled starts the capture before `kubelet` is started.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Duration is how long the capture runs for. Defaults to `2m`.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// TracingOptions export the spans of `nodeadm init`, such as its phases, the AWS API calls it makes and the start of
+// each daemon, as an [OpenTelemetry](https://opentelemetry.io) trace. The trace is exported when `init` finishes,
+// whether or not it succeeded.
+type TracingOptions struct {
+	// Endpoint is the URL of an OTLP/HTTP collector that the trace is sent to, such as `http://localhost:4318`.
+	// The trace is sent to the `/v1/traces` path of the endpoint in the JSON encoding.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// File is the absolute path of a file that the trace is appended to, as a line of OTLP JSON.
+	File string `json:"file,omitempty"`
+}
+
+// NodeProvider specifies the environment the node is being bootstrapped in.
+//
+// * `ec2` discovers the node's details from the EC2 instance metadata service.
+// * `hybrid` takes the node's details from `spec.hybrid`, for on-premises machines.
+// +kubebuilder:validation:Enum={ec2, hybrid}
+type NodeProvider string
+
+const (
+	NodeProviderEC2    NodeProvider = "ec2"
+	NodeProviderHybrid NodeProvider = "hybrid"
+)
+
+// HybridOptions contains the details of a hybrid node, which would otherwise
+// be discovered from the EC2 instance metadata service.
+type HybridOptions struct {
+	// NodeName is the name of the Node object.
+	NodeName string `json:"nodeName,omitempty"`
+
+	// NodeIP is the IP address advertised by the node. When omitted, `kubelet`
+	// will detect the address of the node's default interface.
+	NodeIP string `json:"nodeIP,omitempty"`
+
+	// Region is the AWS region of your EKS cluster.
+	Region string `json:"region,omitempty"`
+
+	// SSM provides credentials using an [AWS Systems Manager hybrid activation](https://docs.aws.amazon.com/systems-manager/latest/userguide/activations.html).
+	SSM SSMOptions `json:"ssm,omitempty"`
+
+	// IAMRolesAnywhere provides credentials using [IAM Roles Anywhere](https://docs.aws.amazon.com/rolesanywhere/latest/userguide/introduction.html).
+	IAMRolesAnywhere IAMRolesAnywhereOptions `json:"iamRolesAnywhere,omitempty"`
+}
+
+// SSMOptions are the details of an AWS Systems Manager hybrid activation.
+type SSMOptions struct {
+	// ActivationCode is the code returned when the activation was created.
+	ActivationCode string `json:"activationCode,omitempty"`
+
+	// ActivationID is the ID of the activation.
+	ActivationID string `json:"activationID,omitempty"`
+}
+
+// IAMRolesAnywhereOptions are the details used to obtain credentials from IAM Roles Anywhere.
+type IAMRolesAnywhereOptions struct {
+	// TrustAnchorARN is the ARN of the trust anchor.
+	TrustAnchorARN string `json:"trustAnchorARN,omitempty"`
+
+	// ProfileARN is the ARN of the profile.
+	ProfileARN string `json:"profileARN,omitempty"`
+
+	// RoleARN is the ARN of the role to assume.
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// CertificatePath is the path to the node's X.509 certificate.
+	CertificatePath string `json:"certificatePath,omitempty"`
+
+	// PrivateKeyPath is the path to the private key of the node's certificate.
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+}
+
+// ClusterDetails contains the coordinates of your EKS cluster.
+// These details can be found using the [DescribeCluster API](https://docs.aws.amazon.com/eks/latest/APIReference/API_DescribeCluster.html).
+type ClusterDetails struct {
+	// Name is the name of your EKS cluster
+	Name string `json:"name,omitempty"`
+
+	// APIServerEndpoint is the URL of your EKS cluster's kube-apiserver.
+	APIServerEndpoint string `json:"apiServerEndpoint,omitempty"`
+
+	// CertificateAuthority is a base64-encoded string of your cluster's certificate authority chain.
+	CertificateAuthority []byte `json:"certificateAuthority,omitempty"`
+
+	// CertificateAuthorityFile is the path to a PEM-encoded file containing your cluster's certificate
+	// authority chain, which can be provided instead of CertificateAuthority.
+	CertificateAuthorityFile string `json:"certificateAuthorityFile,omitempty"`
+
+	// CertificatePins are the SHA-256 hashes of the Subject Public Key Info of certificates that your
+	// cluster's kube-apiserver must present, formatted as `sha256:<hex>`. When set, `nodeadm` connects to
+	// the APIServerEndpoint before writing the kubeconfig of `kubelet`, and fails unless a certificate of the
+	// verified chain matches one of the hashes. This protects nodes from a hijacked DNS name of the endpoint.
+	CertificatePins []string `json:"certificatePins,omitempty"`
+
+	// CIDR is your cluster's service CIDR block. This value is used to infer your cluster's DNS address.
+	// The IP family of the block determines the address that `kubelet` registers the node with. For a
+	// dual-stack cluster, provide a block of each IP family separated by a comma, starting with the
+	// primary IP family of your services, such as `172.20.0.0/16,fd30:1c53:5f8a::/108`.
+	CIDR string `json:"cidr,omitempty"`
+
+	// DNSDomain is the DNS domain of your cluster's services, which is used as `kubelet`'s cluster domain.
+	// This is only needed when your cluster's DNS is configured with a domain other than `cluster.local`.
+	DNSDomain string `json:"dnsDomain,omitempty"`
+
+	// Discovery resolves the cluster from a tag of the instance or an SSM parameter, instead of the details above.
+	Discovery ClusterDiscoveryOptions `json:"discovery,omitempty"`
+
+	// EndpointOverrides are the endpoints of AWS services that the node calls instead of the public endpoints
+	// of its region.
+	EndpointOverrides EndpointOverrides `json:"endpointOverrides,omitempty"`
+
+	// Outpost configures your node for a local cluster on an AWS Outpost.
+	Outpost OutpostOptions `json:"outpost,omitempty"`
+}
+
+// EndpointOverrides are the URLs of the endpoints of AWS services that `nodeadm`, the credential plugins of `kubelet`
+// and the ECR credential provider call, such as the interface VPC endpoints of a VPC without private DNS, or the
+// endpoints of a custom partition. They are passed to the AWS SDKs and CLI with the `AWS_ENDPOINT_URL_<SERVICE>`
+// environment variables. The default endpoint of the region is used for each service that is not set.
+type EndpointOverrides struct {
+	// EKS is the endpoint of the EKS API, which is called to discover the details of the cluster.
+	EKS string `json:"eks,omitempty"`
+
+	// EC2 is the endpoint of the EC2 API, which is called for the details and the tags of the instance.
+	EC2 string `json:"ec2,omitempty"`
+
+	// STS is the endpoint of STS, whose requests are signed as the tokens that `kubelet` authenticates to the
+	// cluster with. The cluster must accept tokens that are signed for the endpoint.
+	STS string `json:"sts,omitempty"`
+
+	// ECR is the endpoint of the ECR API, which the ECR credential provider gets the credentials of registries from.
+	ECR string `json:"ecr,omitempty"`
+}
+
+// ClusterDiscoveryOptions resolve the cluster of the node from a rule instead of its details, so that one launch
+// template can bootstrap the nodes of many clusters. The name of the cluster is read from the Source, unless Name is
+// set, and the APIServerEndpoint, CertificateAuthority and CIDR that are not set are read with the `eks:DescribeCluster`
+// API, which the role of the node must be allowed to call.
+type ClusterDiscoveryOptions struct {
+	// Source is where the name of the cluster is read from.
+	Source ClusterDiscoverySource `json:"source,omitempty"`
+
+	// TagKey is the key of the tag of the instance whose value is the name of the cluster, when Source is `tag`.
+	// Defaults to `eks:cluster-name`, which is set by EKS managed node groups and Karpenter.
+	TagKey string `json:"tagKey,omitempty"`
+
+	// SSMParameter is the name of the SSM parameter whose value is the name of the cluster, when Source is `ssm`.
+	SSMParameter string `json:"ssmParameter,omitempty"`
+
+	// MinDelay is the delay before the cluster is described again while it is not ready, which backs off up to
+	// MaxDelay. Defaults to `5s`.
+	MinDelay *metav1.Duration `json:"minDelay,omitempty"`
+
+	// MaxDelay is the maximum delay between two descriptions of the cluster. Defaults to `30s`.
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+
+	// Timeout is how long the cluster is waited for to be ready, such as while it is being created. Defaults to
+	// `10m`.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ClusterDiscoverySource is where the name of the cluster is read from.
+//
+// * `tag` reads a tag of the instance with the `ec2:DescribeTags` API.
+// * `ssm` reads an SSM parameter with the `ssm:GetParameter` API.
+// +kubebuilder:validation:Enum={tag, ssm}
+type ClusterDiscoverySource string
+
+const (
+	ClusterDiscoverySourceTag ClusterDiscoverySource = "tag"
+	ClusterDiscoverySourceSSM ClusterDiscoverySource = "ssm"
+)
+
+// OutpostOptions configure a node of a [local cluster](https://docs.aws.amazon.com/eks/latest/userguide/eks-outposts-local-cluster-overview.html)
+// on an AWS Outpost.
+type OutpostOptions struct {
+	// Enabled determines how your node is configured when running on an AWS Outpost.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ID is an identifier for your cluster.
+	ID string `json:"id,omitempty"`
+}
+
+// KubeletOptions are additional parameters passed to `kubelet`.
+type KubeletOptions struct {
+	// Config is a [`KubeletConfiguration`](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/)
+	// that will be merged with the defaults.
+	Config map[string]runtime.RawExtension `json:"config,omitempty"`
+
+	// Flags are [command-line `kubelet` arguments](https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/).
+	// that will be appended to the defaults. Flags take precedence over the fields of Config that they also set, which are left out of
+	// the config with a warning, as `kubelet` would ignore them.
+	Flags []string `json:"flags,omitempty"`
+
+	// FeatureGates are [`kubelet` feature gates](https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/)
+	// that will be merged with the defaults. Feature gates that have been removed
+	// from the installed version of `kubelet` are rejected.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// TokenCache pre-signs the token that `kubelet` uses to authenticate to your cluster before `kubelet`
+	// is started, and caches it until it is about to expire.
+	TokenCache TokenCacheOptions `json:"tokenCache,omitempty"`
+
+	// NodeLabels are labels that `kubelet` adds to the node when it registers. Values may be
+	// [templates](https://pkg.go.dev/text/template) that are expanded with the details of the instance,
+	// such as `{{ .InstanceType }}`, `{{ .AvailabilityZone }}`, `{{ .InstanceID }}`, `{{ .Region }}`
+	// and `{{ .AccountID }}`. These details are empty on hybrid nodes.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are taints that `kubelet` adds to the node when it registers. Values may be templates,
+	// like those of `nodeLabels`.
+	NodeTaints []Taint `json:"nodeTaints,omitempty"`
+
+	// ServingCertificate controls how `nodeadm` waits for the certificate that `kubelet` serves its API with.
+	ServingCertificate ServingCertificateOptions `json:"servingCertificate,omitempty"`
+
+	// ClusterDNS are the addresses of the DNS servers that `kubelet` configures pods with. When it is not set,
+	// the tenth address of `cluster.cidr` is used. If the `NodeLocalDNSCache` feature gate is enabled in an IPv4
+	// cluster, the link-local address of [NodeLocal DNSCache](https://kubernetes.io/docs/tasks/administer-cluster/nodelocaldns/),
+	// `169.254.20.10`, is used first, so that pods fall back to the tenth address when the cache is not running.
+	ClusterDNS []string `json:"clusterDNS,omitempty"`
+
+	// NodeIP selects the IP addresses that `kubelet` advertises for the node, for instances with several
+	// network interfaces or addresses. It is not used on hybrid nodes, which advertise `hybrid.nodeIP`.
+	NodeIP NodeIPOptions `json:"nodeIP,omitempty"`
+
+	// StaticPods are the manifests of [static pods](https://kubernetes.io/docs/tasks/configure-pod-container/static-pod/)
+	// that `nodeadm` writes to `/etc/kubernetes/manifests` before `kubelet` is started, so that node-critical
+	// agents run before the CNI is ready.
+	StaticPods []StaticPod `json:"staticPods,omitempty"`
+
+	// AuthenticationMode selects how `kubelet` authenticates to your cluster. Defaults to `aws-cli`.
+	AuthenticationMode KubeletAuthenticationMode `json:"authenticationMode,omitempty"`
+
+	// Authentication configures the credentials of the selected authentication mode.
+	Authentication KubeletAuthenticationOptions `json:"authentication,omitempty"`
+
+	// Readiness controls whether `nodeadm init` waits for the node to be `Ready` once `kubelet` is started.
+	Readiness ReadinessOptions `json:"readiness,omitempty"`
+
+	// AutoLabels adds labels with the topology and capacity of the instance to the node.
+	AutoLabels AutoLabelsOptions `json:"autoLabels,omitempty"`
+
+	// StatusAnnotations adds the status of the bootstrap to the node as annotations once it is registered.
+	StatusAnnotations StatusAnnotationsOptions `json:"statusAnnotations,omitempty"`
+
+	// RemoteConfig keeps a `kubelet` config overlay from a URL applied to the node, so that `kubelet` can be tuned
+	// across a fleet without replacing its instances.
+	RemoteConfig KubeletRemoteConfigOptions `json:"remoteConfig,omitempty"`
+
+	// CPUManagerPolicy is the policy of the `kubelet` CPU manager. With the `static` policy, when `reservedCPUs` is not
+	// set, `nodeadm` reserves whole cores of the first NUMA node for system daemons and `kubelet`, enough to cover the CPU
+	// that is reserved for them, so that the exclusive CPUs of pods are not shared with their hyperthreads.
+	CPUManagerPolicy CPUManagerPolicy `json:"cpuManagerPolicy,omitempty"`
+
+	// TopologyManagerPolicy is the policy of the `kubelet` topology manager, which aligns the CPUs, memory and devices
+	// of pods on NUMA nodes. The `restricted` and `single-numa-node` policies require the `static` CPU manager policy or
+	// the `Static` memory manager policy, which provide the alignment.
+	TopologyManagerPolicy TopologyManagerPolicy `json:"topologyManagerPolicy,omitempty"`
+
+	// MemoryManagerPolicy is the policy of the `kubelet` memory manager. With the `Static` policy, `nodeadm` reserves
+	// the memory that is reserved for system daemons and `kubelet`, and for hard eviction, on the first NUMA node, as
+	// the memory manager requires. If `config` changes the reserved memory or the hard eviction threshold of memory, it
+	// must also set `reservedMemory`.
+	MemoryManagerPolicy MemoryManagerPolicy `json:"memoryManagerPolicy,omitempty"`
+
+	// ReservedCPUs is the list of CPUs that are reserved for system daemons and `kubelet`, such as `0-1,48-49`,
+	// which are never given to pods. It requires the `static` CPU manager policy.
+	ReservedCPUs string `json:"reservedCPUs,omitempty"`
+
+	// ReservedResourcesPolicy is the formula of the CPU and memory that are reserved for `kubelet` and the container
+	// runtime from the capacity of the node, which are logged by `nodeadm init`. The `kubeReserved` of `config` takes
+	// precedence over the computed values. Defaults to `eks`.
+	ReservedResourcesPolicy ReservedResourcesPolicy `json:"reservedResourcesPolicy,omitempty"`
+
+	// DiskCheck checks that the filesystem of `kubelet` has room for the disk usage that `kubelet` is configured for.
+	DiskCheck DiskCheckOptions `json:"diskCheck,omitempty"`
+}
+
+// Taint is a [taint](https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/) of the node.
+type Taint struct {
+	// Key is the key of the taint.
+	Key string `json:"key"`
+
+	// Value is the value of the taint, which may be a template.
+	Value string `json:"value,omitempty"`
+
+	// Effect is the effect of the taint on pods that do not tolerate it.
+	Effect TaintEffect `json:"effect"`
+}
+
+// TaintEffect is the effect of a taint.
+// +kubebuilder:validation:Enum={NoSchedule, PreferNoSchedule, NoExecute}
+type TaintEffect string
+
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	TaintEffectNoExecute        TaintEffect = "NoExecute"
+)
+
+// ServingCertificateOptions control how `nodeadm` waits for the serving certificate of `kubelet`. `kubelet`
+// requests its serving certificate with a CertificateSigningRequest for the `kubernetes.io/kubelet-serving`
+// signer once it is started, and `kubectl logs` and `kubectl exec` fail for the pods of the node until the
+// request is approved.
+type ServingCertificateOptions struct {
+	// WaitForIssuance holds `nodeadm init` until the serving certificate of `kubelet` is issued, and fails it
+	// if the request is denied or is not approved in time.
+	WaitForIssuance bool `json:"waitForIssuance,omitempty"`
+
+	// Timeout is the maximum amount of time to wait for the serving certificate to be issued. Defaults to `5m`.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AutoLabelsOptions control the labels that are added to the node from the metadata of the instance, in addition
+// to `nodeLabels`, which take precedence over them:
+//
+// * `topology.k8s.aws/zone-id` is the ID of the availability zone, which is the same zone in every account.
+// * `eks.amazonaws.com/capacityType` is `ON_DEMAND`, `SPOT` or `CAPACITY_BLOCK`, like the nodes of managed node groups.
+// * `node.eks.aws/placement-group` and `node.eks.aws/placement-partition` are the placement group of the instance
+// and its partition, if any.
+// * `node.eks.aws/capacity-reservation-id` is the capacity reservation that the instance runs in, if any, which is
+// read with the `ec2:DescribeInstances` API.
+//
+// Auto labels are not supported on hybrid nodes.
+type AutoLabelsOptions struct {
+	// Enabled determines whether the labels are added.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// KubeletRemoteConfigOptions configure the `nodeadm-kubelet-config` unit, which fetches a `KubeletConfiguration`
+// overlay from a URL on an interval and writes it as the `50-nodeadm-remote.conf` drop-in of the `kubelet` config,
+// which takes precedence over the `config` of the NodeConfig. `kubelet` is only restarted when the overlay changed,
+// and at most once per `minRestartInterval`. When `kubelet` is not running steadily after a restart, the previous
+// overlay is restored, and the overlay that was rolled back is not applied again until it changes. Requires
+// `kubelet` v1.29 or later, which reads the drop-ins of its config.
+type KubeletRemoteConfigOptions struct {
+	// Source is the `s3://` or `https://` URL of the overlay, which is a YAML or JSON `KubeletConfiguration`.
+	// The signature of the overlay is not verified.
+	Source string `json:"source,omitempty"`
+
+	// Interval is how often the overlay is fetched. Defaults to `5m`.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MinRestartInterval is the minimum amount of time between two restarts of `kubelet` for changes of the
+	// overlay. A change is applied once the interval has passed. Defaults to `15m`.
+	MinRestartInterval *metav1.Duration `json:"minRestartInterval,omitempty"`
+}
+
+// StatusAnnotationsOptions control the annotations that `nodeadm init` adds to the node with the status of the
+// bootstrap, which is also written to `/run/nodeadm/status.json` on the node: `bootstrap.node.eks.aws/phases` lists the
+// result of each phase, such as `config=succeeded,run=succeeded`, along with `bootstrap.node.eks.aws/kubelet-version`,
+// `bootstrap.node.eks.aws/containerd-version`, `bootstrap.node.eks.aws/config-hash`,
+// `bootstrap.node.eks.aws/start-time` and `bootstrap.node.eks.aws/end-time`. The node is annotated with the
+// credentials of `kubelet` once the bootstrap succeeded, and a failure to annotate it is logged without failing
+// the bootstrap.
+type StatusAnnotationsOptions struct {
+	// Enabled determines whether the node is annotated.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// KubeletAuthenticationMode is how `kubelet` authenticates to your cluster.
+//
+// * `aws-cli` runs `aws eks get-token` for every token, or `nodeadm credentials token` when `tokenCache` is enabled.
+// * `aws-iam-authenticator` runs `aws-iam-authenticator token`, which must be installed on the node.
+// * `client-certificate` presents a client certificate that is provisioned on the node ahead of `nodeadm init`.
+// +kubebuilder:validation:Enum={aws-cli, aws-iam-authenticator, client-certificate}
+type KubeletAuthenticationMode string
+
+const (
+	KubeletAuthenticationModeAWSCLI              KubeletAuthenticationMode = "aws-cli"
+	KubeletAuthenticationModeAWSIAMAuthenticator KubeletAuthenticationMode = "aws-iam-authenticator"
+	KubeletAuthenticationModeClientCertificate   KubeletAuthenticationMode = "client-certificate"
+)
+
+// KubeletAuthenticationOptions configure the credentials that `kubelet` authenticates to your cluster with.
+type KubeletAuthenticationOptions struct {
+	// RoleARN is an IAM role that is assumed to sign the tokens of `kubelet`, rather than the role of the node.
+	// It must be in the partition of the region of the node. It is not supported with `client-certificate`,
+	// or when `tokenCache` is enabled.
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// SessionName is the name of the session of the assumed role, which is recorded in CloudTrail. It requires
+	// `roleARN` and is only supported with `aws-iam-authenticator`, as `aws eks get-token` names its own sessions.
+	SessionName string `json:"sessionName,omitempty"`
+
+	// ClientCertificate is the path of the PEM-encoded client certificate, when the mode is `client-certificate`.
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+
+	// ClientKey is the path of the PEM-encoded private key of the client certificate, when the mode is
+	// `client-certificate`.
+	ClientKey string `json:"clientKey,omitempty"`
+}
+
+// ReadinessOptions control whether `nodeadm init` waits for the node to register with your cluster and report
+// `Ready`, so that its exit code confirms that the node joined, such as for the lifecycle hooks of an Auto Scaling
+// group. A node only reports `Ready` once its CNI is running.
+type ReadinessOptions struct {
+	// WaitForReady holds `nodeadm init` until the node is registered and `Ready`, and fails it if the node is
+	// not `Ready` in time.
+	WaitForReady bool `json:"waitForReady,omitempty"`
+
+	// Timeout is the maximum amount of time to wait for the node to be `Ready`. Defaults to `10m`.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// TokenCacheOptions control how `kubelet` obtains the token it uses to authenticate to your cluster. By default,
+// `kubelet` runs `aws eks get-token` for every token. When enabled, `kubelet` runs `nodeadm credentials token`
+// instead, which pre-signs tokens ahead of their expiry and caches them in `/var/lib/nodeadm/token`.
+type TokenCacheOptions struct {
+	// Enabled determines whether tokens are cached.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// LeadTime is how long before a cached token expires that a new token is pre-signed. Tokens are
+	// valid for 14 minutes. Defaults to `5m`, and must be at most `10m`.
+	LeadTime *metav1.Duration `json:"leadTime,omitempty"`
+
+	// MaxAttempts is the number of attempts to pre-sign a token, which are retried with exponential
+	// backoff and jitter so that nodes launched together do not retry in lockstep. Defaults to `10`.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// StaticPod is the manifest of a static pod, which is either inline or downloaded. Exactly one of `manifest`
+// and `url` must be set.
+type StaticPod struct {
+	// Name is the name of the file of the manifest in `/etc/kubernetes/manifests`, without its `.yaml` extension.
+	Name string `json:"name"`
+
+	// Manifest is the YAML or JSON manifest of the pod.
+	Manifest string `json:"manifest,omitempty"`
+
+	// URL is the `s3://` or `https://` URL of the manifest. Objects in S3 are downloaded with the credentials
+	// of the instance, and HTTPS requests use the proxy and trust store of the `NodeConfig`.
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the manifest, which is verified before the manifest is
+	// written. It is required with `url`.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// NodeIPOptions select the IP addresses that `kubelet` advertises for the node with `--node-ip`. By default,
+// these are the primary private addresses of the primary network interface, of the IP families of the cluster.
+type NodeIPOptions struct {
+	// Policy determines how the addresses are selected.
+	Policy NodeIPPolicy `json:"policy,omitempty"`
+
+	// Addresses are the addresses that are advertised with the `Explicit` policy, at most one of each IP
+	// family. The family of the first address is the primary IP family of the node.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// NodeIPPolicy determines how the IP addresses of the node are selected from the network interfaces
+// of the instance, which are listed by the instance metadata service.
+//
+// * `PrimaryPrivate` selects the primary private addresses of the primary network interface.
+// * `SecondaryENI` selects the primary private addresses of the network interface with the lowest device
+// number after the primary, such as an interface attached for the traffic of the node.
+// * `IPv6` selects the IPv6 address of the primary network interface, followed by its IPv4 address in
+// dual-stack clusters, so that the node is IPv6-primary.
+// * `Explicit` advertises `addresses`.
+// +kubebuilder:validation:Enum={PrimaryPrivate, SecondaryENI, IPv6, Explicit}
+type NodeIPPolicy string
+
+const (
+	NodeIPPolicyPrimaryPrivate NodeIPPolicy = "PrimaryPrivate"
+	NodeIPPolicySecondaryENI   NodeIPPolicy = "SecondaryENI"
+	NodeIPPolicyIPv6           NodeIPPolicy = "IPv6"
+	NodeIPPolicyExplicit       NodeIPPolicy = "Explicit"
+)
+
+// SwapBehavior is the [swap behavior](https://kubernetes.io/docs/concepts/cluster-administration/swap-memory-management/) of `kubelet`.
+//
+// * `NoSwap` prevents pods from using swap, while the rest of the node can.
+// * `LimitedSwap` lets the containers of Burstable pods use swap in proportion to their memory request,
+// and requires the `v2` cgroup hierarchy.
+// +kubebuilder:validation:Enum={NoSwap, LimitedSwap}
+type SwapBehavior string
+
+const (
+	SwapBehaviorNoSwap      SwapBehavior = "NoSwap"
+	SwapBehaviorLimitedSwap SwapBehavior = "LimitedSwap"
+)
+
+// CPUManagerPolicy is the policy of the `kubelet` [CPU manager](https://kubernetes.io/docs/tasks/administer-cluster/cpu-management-policies/).
+//
+// * `none` shares the CPUs of the node between the containers of all pods.
+// * `static` gives the containers of Guaranteed pods with integer CPU requests exclusive CPUs.
+// +kubebuilder:validation:Enum={none, static}
+type CPUManagerPolicy string
+
+const (
+	CPUManagerPolicyNone   CPUManagerPolicy = "none"
+	CPUManagerPolicyStatic CPUManagerPolicy = "static"
+)
+
+// TopologyManagerPolicy is the policy of the `kubelet` [topology manager](https://kubernetes.io/docs/tasks/administer-cluster/topology-manager/).
+//
+// * `none` does not align the resources of pods.
+// * `best-effort` prefers to align the resources of pods on NUMA nodes.
+// * `restricted` rejects pods whose resources cannot be aligned on their preferred NUMA nodes.
+// * `single-numa-node` rejects pods whose resources cannot be aligned on a single NUMA node.
+// +kubebuilder:validation:Enum={none, best-effort, restricted, single-numa-node}
+type TopologyManagerPolicy string
+
+const (
+	TopologyManagerPolicyNone           TopologyManagerPolicy = "none"
+	TopologyManagerPolicyBestEffort     TopologyManagerPolicy = "best-effort"
+	TopologyManagerPolicyRestricted     TopologyManagerPolicy = "restricted"
+	TopologyManagerPolicySingleNUMANode TopologyManagerPolicy = "single-numa-node"
+)
+
+// MemoryManagerPolicy is the policy of the `kubelet` [memory manager](https://kubernetes.io/docs/tasks/administer-cluster/memory-manager/).
+//
+// * `None` does not pin the memory of pods.
+// * `Static` pins the memory of the containers of Guaranteed pods to NUMA nodes.
+// +kubebuilder:validation:Enum={None, Static}
+type MemoryManagerPolicy string
+
+const (
+	MemoryManagerPolicyNone   MemoryManagerPolicy = "None"
+	MemoryManagerPolicyStatic MemoryManagerPolicy = "Static"
+)
+
+// ReservedResourcesPolicy is a formula of the resources that are reserved for `kubelet` and the container runtime.
+// Both formulas reserve 6% of the first CPU, 1% of the second, 0.5% of the third and fourth, and 0.25% of the others.
+//
+// * `eks` reserves 255Mi of memory and 11Mi for each pod of the maximum number of pods of the node.
+// * `gke` reserves 25% of the first 4Gi of memory, 20% of the next 4Gi, 10% of the next 8Gi, 6% of the next 112Gi
+// and 2% of the rest, or 255Mi on nodes with less than 1Gi of memory.
+// +kubebuilder:validation:Enum={eks, gke}
+type ReservedResourcesPolicy string
+
+const (
+	ReservedResourcesPolicyEKS ReservedResourcesPolicy = "eks"
+	ReservedResourcesPolicyGKE ReservedResourcesPolicy = "gke"
+)
+
+// DiskCheckOptions check, when `nodeadm init` configures `kubelet`, that the filesystem of `kubelet` is large enough for
+// the thresholds of image garbage collection and eviction, the ephemeral storage that is reserved for `kubelet` and system
+// daemons, and the DaemonSets of the cluster, so that a node whose volume is too small is caught before it joins the
+// cluster rather than reporting `DiskPressure` soon after.
+type DiskCheckOptions struct {
+	// DaemonSetFootprint is the disk space that the DaemonSets of the cluster use on each node, with the images and ephemeral
+	// storage of their pods, such as `5Gi`.
+	DaemonSetFootprint *resource.Quantity `json:"daemonSetFootprint,omitempty"`
+
+	// FailurePolicy is what `nodeadm init` does when the filesystem is too small. Defaults to `Warn`.
+	FailurePolicy DiskCheckFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// DiskCheckFailurePolicy is what `nodeadm init` does when the filesystem of `kubelet` is too small.
+//
+// * `Warn` logs the problems and continues the bootstrap.
+// * `Fail` fails `nodeadm init`.
+// +kubebuilder:validation:Enum={Warn, Fail}
+type DiskCheckFailurePolicy string
+
+const (
+	DiskCheckFailurePolicyWarn DiskCheckFailurePolicy = "Warn"
+	DiskCheckFailurePolicyFail DiskCheckFailurePolicy = "Fail"
+)
+
+// ContainerdOptions are additional parameters passed to `containerd`.
+type ContainerdOptions struct {
+	// Config is an inline [`containerd` configuration TOML](https://github.com/containerd/containerd/blob/main/docs/man/containerd-config.toml.5.md)
+	// that will be merged with the defaults.
+	Config string `json:"config,omitempty"`
+
+	// BaseRuntimeSpec is the OCI runtime specification upon which all containers will be based.
+	// The provided spec will be merged with the default spec; so that a partial spec may be provided.
+	// For more information, see: https://github.com/opencontainers/runtime-spec
+	BaseRuntimeSpec map[string]runtime.RawExtension `json:"baseRuntimeSpec,omitempty"`
+
+	// BaseRuntimeSpecOverrides are merged with the base runtime specification of the containers of a single
+	// runtime, keyed by the name of its handler, such as `runc` or `runsc`. This lets each RuntimeClass have
+	// its own OCI defaults, such as rlimits or masked paths. The handler must be a runtime of `containerd`,
+	// either one that is configured by `nodeadm` or one that is added in `config`.
+	BaseRuntimeSpecOverrides map[string]map[string]runtime.RawExtension `json:"baseRuntimeSpecOverrides,omitempty"`
+
+	// DefaultRuntimeBinary is the OCI runtime used by the default runtime of `containerd`.
+	// Defaults to `runc`. The NVIDIA container runtime is used instead on instances where it is installed.
+	DefaultRuntimeBinary RuntimeBinary `json:"defaultRuntimeBinary,omitempty"`
+
+	// SandboxImage is the reference of the pause image used for each pod's sandbox container.
+	// An image without a registry, such as `eks/pause:3.10`, is pulled from the EKS registry in the node's region.
+	// The image may be pinned by digest, such as `eks/pause@sha256:...`.
+	// Defaults to the pause image that is cached on the AMI.
+	SandboxImage string `json:"sandboxImage,omitempty"`
+
+	// Runsc adds a `runsc` runtime to `containerd`, which runs containers in a [gVisor](https://gvisor.dev) sandbox.
+	// Pods use the runtime through a RuntimeClass with the `runsc` handler.
+	// `runsc` and `containerd-shim-runsc-v1` must be installed in `/usr/local/bin`.
+	Runsc *RunscOptions `json:"runsc,omitempty"`
+
+	// Runtimes are added to `containerd` as runtime handlers, such as those of [Kata Containers](https://katacontainers.io)
+	// or other sandboxed runtimes. Pods use a runtime through a RuntimeClass whose handler is the name of the runtime.
+	// The shim of each runtime must be installed on the node.
+	Runtimes []ContainerdRuntime `json:"runtimes,omitempty"`
+
+	// DefaultRuntime is the runtime handler of the pods that do not have a RuntimeClass, such as one of `runtimes` or
+	// `runsc`. Defaults to the runtime that `nodeadm` configures for the instance, such as `runc` or `nvidia`.
+	DefaultRuntime string `json:"defaultRuntime,omitempty"`
+
+	// UntrustedWorkloadRuntime is the runtime handler of the pods that are annotated with
+	// `io.kubernetes.cri.untrusted-workload: "true"`, such as one of `runtimes` or `runsc`.
+	// This requires `containerd` 1.x, since `containerd` 2.0 removed untrusted workloads in favor of RuntimeClasses.
+	UntrustedWorkloadRuntime string `json:"untrustedWorkloadRuntime,omitempty"`
+
+	// SOCI tunes the soci-snapshotter, which is used when the `FastContainerImagePull` feature gate is enabled.
+	SOCI SOCIOptions `json:"soci,omitempty"`
+
+	// PrePullImages are pulled after `containerd` is started and before `kubelet` registers the node, so
+	// that the pods of critical DaemonSets do not wait for their images.
+	PrePullImages PrePullImagesOptions `json:"prePullImages,omitempty"`
+
+	// SmokeTest runs a sandbox through the CRI of `containerd` once it is started, so that a broken runtime
+	// fails `nodeadm init` instead of the node registering and failing to run pods.
+	SmokeTest SmokeTestOptions `json:"smokeTest,omitempty"`
+
+	// DiscardUnpackedLayers removes the compressed layers of an image from the content store of `containerd` once
+	// the image is unpacked, which saves disk space, but the layers must be pulled again to push or export the image.
+	// Defaults to `true`.
+	DiscardUnpackedLayers *bool `json:"discardUnpackedLayers,omitempty"`
+
+	// GarbageCollection controls how often `containerd` removes the content and snapshots that are no longer
+	// referenced by an image or container.
+	GarbageCollection ContainerdGarbageCollectionOptions `json:"garbageCollection,omitempty"`
+
+	// ImageRetention controls which images are removed by the image garbage collection of `kubelet`.
+	ImageRetention ImageRetentionOptions `json:"imageRetention,omitempty"`
+
+	// RegistryCredentials authenticate `containerd` to private registries outside of ECR with secrets in
+	// AWS Secrets Manager. The secrets are fetched by `nodeadm init`, and again every 6 hours by the
+	// `nodeadm-credentials-refresh` timer, so that rotated credentials are picked up.
+	RegistryCredentials []RegistryCredential `json:"registryCredentials,omitempty"`
+
+	// Metrics serves the Prometheus metrics of `containerd`, so that they can be scraped by the observability
+	// stack of the cluster.
+	Metrics ContainerdMetricsOptions `json:"metrics,omitempty"`
+
+	// Debug configures the debug socket and the log level of `containerd`.
+	Debug ContainerdDebugOptions `json:"debug,omitempty"`
+
+	// NRI configures the Node Resource Interface of `containerd`, which NRI plugins use to adjust the resources
+	// of pods and containers as they are created.
+	NRI ContainerdNRIOptions `json:"nri,omitempty"`
+	// ImagePulls limits the concurrency and the stalls of the image pulls of `containerd`, which saturate the network
+	// of large nodes that start many pods at once. The concurrency cannot be limited per registry, since `containerd`
+	// has no such limit.
+	ImagePulls ContainerdImagePullOptions `json:"imagePulls,omitempty"`
+}
+
+// RegistryCredential is the secret that `containerd` authenticates to a registry with. The value of the
+// secret is a JSON object with `username` and `password` fields, and the node's role must be allowed to
+// call `secretsmanager:GetSecretValue` on it.
+type RegistryCredential struct {
+	// Registry is the host of the registry, such as `registry.example.com` or `registry.example.com:5000`.
+	Registry string `json:"registry"`
+
+	// SecretARN is the ARN of the secret, which is fetched from the region of the ARN.
+	SecretARN string `json:"secretArn"`
+}
+
+// PrePullImagesOptions list the images that are pulled while the node is bootstrapped.
+type PrePullImagesOptions struct {
+	// Images are the references of the images, such as `public.ecr.aws/eks-distro/kubernetes/pause:3.9`.
+	// Images in private ECR registries are pulled with the credentials of the ECR credential provider.
+	Images []string `json:"images,omitempty"`
+
+	// ImageListURL is the `s3://` or `https://` URL of a list of more images to pull, with one reference
+	// per line. Blank lines and lines that start with `#` are ignored.
+	ImageListURL string `json:"imageListURL,omitempty"`
+
+	// Concurrency is the number of images that are pulled at once. Defaults to `2`.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// FailOpen determines whether the node is bootstrapped when an image cannot be pulled. When it is not
+	// set, `nodeadm init` fails if any image cannot be pulled.
+	FailOpen bool `json:"failOpen,omitempty"`
+}
+
+// SmokeTestOptions configure the smoke test of the container runtime. Once `containerd` is started, `nodeadm`
+// uses `crictl` to pull the sandbox image if it is not cached, then runs a pod sandbox in the network of the node
+// with the default runtime handler, stops it and removes it. When a step fails, `nodeadm init` fails with the
+// output of the step and the conditions of the runtime that are not met, before `kubelet` registers the node.
+type SmokeTestOptions struct {
+	// Enabled runs the smoke test.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Timeout is the maximum amount of time that each step of the smoke test may take. Defaults to `2m`.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ContainerdGarbageCollectionOptions configure the [garbage collection scheduler](https://github.com/containerd/containerd/blob/main/docs/garbage-collection.md)
+// of `containerd`. Options that are not set use the defaults of `containerd`.
+type ContainerdGarbageCollectionOptions struct {
+	// PauseThresholdPercent is the maximum percentage of time that garbage collection may lock the metadata
+	// of `containerd`, from 1 to 50. Defaults to `2`.
+	PauseThresholdPercent int `json:"pauseThresholdPercent,omitempty"`
+
+	// DeletionThreshold is the number of deletions after which garbage collection is scheduled. When it is
+	// not set, deletions do not schedule garbage collection.
+	DeletionThreshold int `json:"deletionThreshold,omitempty"`
+
+	// MutationThreshold is the number of changes to the database after which garbage collection is
+	// scheduled. Defaults to `100`.
+	MutationThreshold int `json:"mutationThreshold,omitempty"`
+
+	// ScheduleDelay is how long garbage collection is delayed once it is scheduled by a threshold.
+	ScheduleDelay *metav1.Duration `json:"scheduleDelay,omitempty"`
+}
+
+// ContainerdImagePullOptions configure how `containerd` pulls the images of pods. They are written to the CRI
+// plugin, and to the transfer service that the CRI plugin pulls images with since `containerd` 2, depending on
+// the version of `containerd` on the node. They apply to the pulls from every registry, since `containerd` has no
+// limits per registry.
+type ContainerdImagePullOptions struct {
+	// MaxConcurrentDownloads is the number of layers that are downloaded at once for each image. Defaults to `3`.
+	MaxConcurrentDownloads int `json:"maxConcurrentDownloads,omitempty"`
+
+	// ProgressTimeout is how long a pull may go without progress before it is cancelled. Defaults to `5m`.
+	ProgressTimeout *metav1.Duration `json:"progressTimeout,omitempty"`
+}
+
+// ContainerdMetricsOptions configure the metrics endpoint of `containerd`, which serves the Prometheus metrics of
+// the runtime, its containers and its snapshotters at `/v1/metrics`.
+type ContainerdMetricsOptions struct {
+	// Address is the `host:port` that the metrics are served on, such as `127.0.0.1:1338`. The host `0.0.0.0`
+	// serves the metrics on every interface of the node. The metrics are not served when it is not set.
+	Address string `json:"address,omitempty"`
+
+	// GRPCHistogram adds histograms of the latency of the gRPC requests of `containerd` to the metrics.
+	GRPCHistogram bool `json:"grpcHistogram,omitempty"`
+}
+
+// ContainerdDebugOptions configure the debug socket of `containerd`, which serves the `pprof` profiles of
+// `containerd` to `ctr pprof`.
+type ContainerdDebugOptions struct {
+	// Address is the path of the debug socket, such as `/run/containerd/debug.sock`. The debug socket is not
+	// served when it is not set.
+	Address string `json:"address,omitempty"`
+
+	// Level is the log level of `containerd`. Defaults to `info`.
+	Level ContainerdLogLevel `json:"level,omitempty"`
+}
+
+// ContainerdNRIOptions configure the [Node Resource Interface](https://github.com/containerd/nri) of `containerd`.
+// NRI plugins, such as the resource policies of topology-aware or balloon CPU allocation, are run as DaemonSets
+// that connect to the socket of NRI and are called by `containerd` whenever a pod or a container is created,
+// updated or removed.
+type ContainerdNRIOptions struct {
+	// Enabled enables NRI when it is `true`, and disables it when it is `false`. When it is not set, the default
+	// of `containerd` is used, which disables NRI in `containerd` 1.7 and enables it in `containerd` 2.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// SocketPath is the path of the socket that NRI plugins connect to. Defaults to `/var/run/nri/nri.sock`.
+	SocketPath string `json:"socketPath,omitempty"`
+
+	// PluginRegistrationTimeout is the maximum amount of time that a plugin may take to register once it has
+	// connected. Defaults to `5s`.
+	PluginRegistrationTimeout *metav1.Duration `json:"pluginRegistrationTimeout,omitempty"`
+
+	// PluginRequestTimeout is the maximum amount of time that a plugin may take to handle a request, after
+	// which the plugin is disconnected. Defaults to `2s`.
+	PluginRequestTimeout *metav1.Duration `json:"pluginRequestTimeout,omitempty"`
+}
+
+// ContainerdLogLevel is the level of the logs of `containerd`.
+// +kubebuilder:validation:Enum={trace, debug, info, warn, error, fatal, panic}
+type ContainerdLogLevel string
+
+const (
+	ContainerdLogLevelTrace ContainerdLogLevel = "trace"
+	ContainerdLogLevelDebug ContainerdLogLevel = "debug"
+	ContainerdLogLevelInfo  ContainerdLogLevel = "info"
+	ContainerdLogLevelWarn  ContainerdLogLevel = "warn"
+	ContainerdLogLevelError ContainerdLogLevel = "error"
+	ContainerdLogLevelFatal ContainerdLogLevel = "fatal"
+	ContainerdLogLevelPanic ContainerdLogLevel = "panic"
+)
+
+// ImageRetentionOptions control the [image garbage collection](https://kubernetes.io/docs/concepts/architecture/garbage-collection/#containers-images)
+// of `kubelet`, which removes unused images when the disk of the images is too full or when they have been
+// unused for too long. Images that are retained are pinned by a unit of `nodeadm`, and are never removed by
+// `kubelet`.
+type ImageRetentionOptions struct {
+	// HighThresholdPercent is the disk usage of the images above which `kubelet` removes unused images.
+	// Defaults to `85`.
+	HighThresholdPercent int `json:"highThresholdPercent,omitempty"`
+
+	// LowThresholdPercent is the disk usage of the images that `kubelet` removes unused images down to.
+	// Defaults to `80`.
+	LowThresholdPercent int `json:"lowThresholdPercent,omitempty"`
+
+	// MinimumAge is how long an image must be unused before it may be removed. Defaults to `2m`.
+	MinimumAge *metav1.Duration `json:"minimumAge,omitempty"`
+
+	// MaximumAge is how long an image may be unused before it is removed, regardless of the disk usage.
+	// Requires `kubelet` 1.30 or later. Images are not removed by age when it is not set.
+	MaximumAge *metav1.Duration `json:"maximumAge,omitempty"`
+
+	// PinnedImages are the references of images that are never removed, such as
+	// `public.ecr.aws/eks-distro/kubernetes/pause:3.9`.
+	PinnedImages []string `json:"pinnedImages,omitempty"`
+
+	// KeepLast is the number of images most recently pulled to the node that are never removed, so that
+	// the previous versions of an application are not removed while it is rolled out.
+	KeepLast int `json:"keepLast,omitempty"`
+}
+
+// RuntimeBinary is an OCI runtime that is invoked by `containerd` to run containers.
+//
+// * `runc` is the reference implementation of the OCI runtime specification.
+// * `crun` is a lower-overhead implementation written in C, which must be installed at `/usr/bin/crun`.
+// +kubebuilder:validation:Enum={runc, crun}
+type RuntimeBinary string
+
+const (
+	RuntimeBinaryRunc RuntimeBinary = "runc"
+	RuntimeBinaryCrun RuntimeBinary = "crun"
+)
+
+// SOCIOptions tune how the soci-snapshotter pulls and stores images. Options that are not specified
+// use the defaults of the installed soci-snapshotter.
+type SOCIOptions struct {
+	// MaxConcurrentDownloads is the maximum number of layers that are downloaded at once across all images.
+	// Setting any of the download or unpack options pulls images in parallel, and unpacks their layers as they are downloaded.
+	MaxConcurrentDownloads int `json:"maxConcurrentDownloads,omitempty"`
+
+	// MaxConcurrentDownloadsPerImage is the maximum number of layers of an image that are downloaded at once.
+	MaxConcurrentDownloadsPerImage int `json:"maxConcurrentDownloadsPerImage,omitempty"`
+
+	// MaxConcurrentUnpacksPerImage is the maximum number of layers of an image that are unpacked at once.
+	MaxConcurrentUnpacksPerImage int `json:"maxConcurrentUnpacksPerImage,omitempty"`
+
+	// ContentStore is where the snapshotter keeps the content of the images it pulls.
+	ContentStore SOCIContentStore `json:"contentStore,omitempty"`
+
+	// Fallback is how the snapshotter pulls images that do not have a SOCI index, which cannot be lazily pulled.
+	// Defaults to `parallel` when any of the download or unpack options are set, and to `sequential` otherwise.
+	Fallback SOCIFallback `json:"fallback,omitempty"`
+}
+
+// SOCIContentStore is where the soci-snapshotter keeps the content of images.
+//
+// * `soci` is the snapshotter's own content store.
+// * `containerd` is the content store of `containerd`, which lets images be shared with other snapshotters.
+// +kubebuilder:validation:Enum={soci, containerd}
+type SOCIContentStore string
+
+const (
+	SOCIContentStoreSOCI       SOCIContentStore = "soci"
+	SOCIContentStoreContainerd SOCIContentStore = "containerd"
+)
+
+// SOCIFallback is how the soci-snapshotter pulls the images that do not have a SOCI index.
+//
+// * `sequential` downloads and unpacks the layers of the image one after another, as `containerd` does.
+// * `parallel` downloads the layers of the image at once, and unpacks them as they are downloaded.
+// +kubebuilder:validation:Enum={sequential, parallel}
+type SOCIFallback string
+
+const (
+	SOCIFallbackSequential SOCIFallback = "sequential"
+	SOCIFallbackParallel   SOCIFallback = "parallel"
+)
+
+// ContainerdRuntime is a runtime handler of the CRI plugin of `containerd`.
+type ContainerdRuntime struct {
+	// Name is the handler of the runtime, which RuntimeClasses refer to, such as `kata-qemu`.
+	// It must not be the name of a runtime that is configured by `nodeadm`.
+	Name string `json:"name"`
+
+	// RuntimeType is the shim that runs the containers of the runtime, such as `io.containerd.kata.v2`.
+	RuntimeType string `json:"runtimeType"`
+
+	// Options are passed to the shim of the runtime, such as `ConfigPath`. The options that are supported depend on the shim.
+	Options map[string]runtime.RawExtension `json:"options,omitempty"`
+
+	// Snapshotter is used for the images of the containers of the runtime, such as `devmapper` for runtimes that
+	// run containers in virtual machines. Defaults to the snapshotter of `containerd`.
+	Snapshotter string `json:"snapshotter,omitempty"`
+
+	// PrivilegedWithoutHostDevices keeps the devices of the host from the privileged containers of the runtime,
+	// which is recommended for sandboxed runtimes.
+	PrivilegedWithoutHostDevices bool `json:"privilegedWithoutHostDevices,omitempty"`
+}
+
+// RunscOptions are the flags of the `runsc` runtime. Flags that are not specified use the defaults
+// of the installed `runsc`, and each flag is checked against the version of `runsc` that is installed.
+type RunscOptions struct {
+	// Platform is how the sandbox intercepts the system calls of containers.
+	Platform RunscPlatform `json:"platform,omitempty"`
+
+	// Network is the network stack used by containers.
+	Network RunscNetwork `json:"network,omitempty"`
+
+	// Overlay is where the changes that containers make to their root filesystem are kept.
+	Overlay RunscOverlay `json:"overlay,omitempty"`
+}
+
+// RunscPlatform is how the `runsc` sandbox intercepts system calls.
+//
+// * `systrap` uses seccomp, and works on any instance.
+// * `kvm` uses hardware virtualization, and requires `/dev/kvm`, which is only available on bare metal instances.
+// +kubebuilder:validation:Enum={systrap, kvm}
+type RunscPlatform string
+
+const (
+	RunscPlatformSystrap RunscPlatform = "systrap"
+	RunscPlatformKVM     RunscPlatform = "kvm"
+)
+
+// RunscNetwork is the network stack used by the containers in a `runsc` sandbox.
+//
+// * `sandbox` uses the network stack of gVisor.
+// * `host` uses the network stack of the host, which is faster but less isolated.
+// * `none` only provides a loopback device.
+// +kubebuilder:validation:Enum={sandbox, host, none}
+type RunscNetwork string
+
+const (
+	RunscNetworkSandbox RunscNetwork = "sandbox"
+	RunscNetworkHost    RunscNetwork = "host"
+	RunscNetworkNone    RunscNetwork = "none"
+)
+
+// RunscOverlay is where the changes that the containers in a `runsc` sandbox make to their root filesystem are kept.
+//
+// * `root:memory` keeps them in memory, which counts towards the memory usage of the pod.
+// * `root:self` keeps them in a file on the host's filesystem next to the container's root filesystem.
+// * `none` writes them directly to the container's root filesystem on the host.
+// +kubebuilder:validation:Enum={"root:memory", "root:self", none}
+type RunscOverlay string
+
+const (
+	RunscOverlayRootMemory RunscOverlay = "root:memory"
+	RunscOverlayRootSelf   RunscOverlay = "root:self"
+	RunscOverlayNone       RunscOverlay = "none"
+)
+
+// InstanceOptions determines how the node's operating system and devices are configured.
+type InstanceOptions struct {
+	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+
+	// Shutdown determines how the node leaves the cluster when the instance is shut down.
+	Shutdown ShutdownOptions `json:"shutdown,omitempty"`
+
+	// TrustStore holds certificate authorities that the node should trust.
+	TrustStore TrustStoreOptions `json:"trustStore,omitempty"`
+
+	// PodLogs determines where the logs of containers are stored, and whether they are forwarded off the node.
+	PodLogs PodLogsOptions `json:"podLogs,omitempty"`
+
+	// Journald configures the rate limits and disk usage of `systemd-journald`, which stores the logs of
+	// `kubelet`, `containerd` and the other services of the node.
+	Journald JournaldOptions `json:"journald,omitempty"`
+
+	// Cgroup determines how `kubelet` and `containerd` manage the cgroups of pods.
+	Cgroup CgroupOptions `json:"cgroup,omitempty"`
+
+	// Swap creates and enables swap space when the node boots.
+	Swap SwapOptions `json:"swap,omitempty"`
+
+	// PressureMonitor watches the node for memory pressure and OOM kills, so that the node can act before
+	// it runs out of memory.
+	PressureMonitor PressureMonitorOptions `json:"pressureMonitor,omitempty"`
+
+	// TimeSync configures the time sources of `chronyd`, which keeps the clock of the node in sync.
+	TimeSync TimeSyncOptions `json:"timeSync,omitempty"`
+
+	// EFA configures the node for the Elastic Fabric Adapter interfaces of the instance.
+	EFA EFAOptions `json:"efa,omitempty"`
+
+	// Neuron configures the node for the AWS Neuron devices of Inferentia and Trainium instances. It is not supported
+	// on `arm64` nodes, since these instances are `x86_64`.
+	Neuron NeuronOptions `json:"neuron,omitempty"`
+
+	// BootstrapProfile tunes the retries and concurrency of `nodeadm init` for how many nodes are launched
+	// at once. Defaults to `default`.
+	BootstrapProfile BootstrapProfile `json:"bootstrapProfile,omitempty"`
+
+	// Tags maps tags of the instance into the NodeConfig, so that individual instances can be customized from the
+	// console or infrastructure as code without changing their user data.
+	Tags InstanceTagsOptions `json:"tags,omitempty"`
+
+	// Identity is the identity of the instance in offline mode, which is otherwise read from the instance metadata
+	// service. When it is set, the instance metadata service is not called, so the node can be bootstrapped
+	// where it cannot be reached. It can only be set in offline mode.
+	Identity InstanceIdentityOptions `json:"identity,omitempty"`
+}
+
+// InstanceIdentityOptions are the details of the instance identity document that the node is bootstrapped with.
+type InstanceIdentityOptions struct {
+	// InstanceID is the ID of the instance, which is the name of the node.
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// InstanceType is the type of the instance, whose limit of pods is the default of `maxPods` when it is known
+	// to `nodeadm`. `maxPods` must be set in `kubelet.config` otherwise.
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Region is the region of the instance.
+	Region string `json:"region,omitempty"`
+
+	// AvailabilityZone is the availability zone of the instance, which is part of the provider ID of the node.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+// InstanceTagsOptions map the tags of the instance whose keys start with a prefix into the NodeConfig. The rest of
+// the key is the path of a field beneath `spec`, with a `/` or `.` between its parts, and the value of the tag is the
+// value of the field. The key of a map is the rest of the path, such as `team` in `eks:nodeconfig/kubelet/nodeLabels/team`.
+// Only `kubelet.nodeLabels` and `kubelet.nodeTaints` can be set, the latter as `key=value:Effect` items separated by
+// commas. Tags take precedence over the NodeConfig, and are rejected when NodeConfigs must be signed.
+type InstanceTagsOptions struct {
+	// Enabled reads the tags of the instance before the NodeConfig is used.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Prefix is the prefix of the keys of the tags that are mapped, which is followed by a `/` or `.` in each key.
+	// Defaults to `eks:nodeconfig`.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Source is where the tags are read from. Defaults to `imds`.
+	Source InstanceTagsSource `json:"source,omitempty"`
+}
+
+// InstanceTagsSource is where the tags of the instance are read from.
+//
+// * `imds` reads the tags from the instance metadata service, which requires access to tags to be allowed in the
+// metadata options of the instance. The keys of such tags cannot contain `/`, so their parts are separated by `.`.
+// * `ec2` reads the tags with the `ec2:DescribeTags` API, which the role of the node must be allowed to call.
+// +kubebuilder:validation:Enum={imds, ec2}
+type InstanceTagsSource string
+
+const (
+	InstanceTagsSourceIMDS InstanceTagsSource = "imds"
+	InstanceTagsSourceEC2  InstanceTagsSource = "ec2"
+)
+
+// BootstrapProfile is a set of retry, backoff and concurrency settings of `nodeadm init`.
+//
+// * `default` suits nodes that are launched at the usual pace of an autoscaler.
+// * `massive-scaleup` suits events where thousands of nodes are launched in a minute, such as a failover.
+// Calls to AWS APIs are retried more times and are rate limited on the node when they are throttled, the EC2
+// API is first polled after a random delay, and fewer images are pulled at once with more attempts.
+// +kubebuilder:validation:Enum={default, massive-scaleup}
+type BootstrapProfile string
+
+const (
+	BootstrapProfileDefault        BootstrapProfile = "default"
+	BootstrapProfileMassiveScaleUp BootstrapProfile = "massive-scaleup"
+)
+
+// PressureMonitorOptions control a monitor that reads the [pressure stall information](https://docs.kernel.org/accounting/psi.html)
+// of the node and counts the processes killed by the kernel's OOM killer. The monitor reports memory pressure
+// with the `MemoryPressureStall` Node condition before `kubelet` reports `MemoryPressure`, which is based on
+// the memory that is available rather than on how much work is stalled.
+type PressureMonitorOptions struct {
+	// Enabled runs the monitor.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MemoryThreshold is the percentage of the last 10 seconds in which some processes were stalled waiting
+	// for memory, above which the node is under memory pressure. Defaults to `20`.
+	MemoryThreshold int `json:"memoryThreshold,omitempty"`
+
+	// Action is what the monitor does while the node is under memory pressure. Defaults to `Report`.
+	Action PressureAction `json:"action,omitempty"`
+
+	// MetricsPort is the port on which the monitor serves Prometheus metrics at `/metrics`.
+	// Metrics are not served when the port is not set.
+	MetricsPort int `json:"metricsPort,omitempty"`
+}
+
+// PressureAction is what the pressure monitor does while the node is under memory pressure.
+//
+// * `Report` only sets the `MemoryPressureStall` condition.
+// * `Cordon` also cordons the node, so that no more pods are scheduled to it, and uncordons it once the pressure has passed.
+// * `Evict` also cordons the node, and evicts one pod at a time, starting with the BestEffort pods of the lowest priority.
+// Guaranteed pods, static pods and the pods of DaemonSets are never evicted.
+// +kubebuilder:validation:Enum={Report, Cordon, Evict}
+type PressureAction string
+
+const (
+	PressureActionReport PressureAction = "Report"
+	PressureActionCordon PressureAction = "Cordon"
+	PressureActionEvict  PressureAction = "Evict"
+)
+
+// EFAOptions prepare the node for workloads that use the [Elastic Fabric Adapter](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/efa.html),
+// such as distributed training and HPC. On an instance with EFA interfaces, `nodeadm` loads the `efa` and `ib_uverbs`
+// kernel modules, which create the devices that the EFA device plugin advertises, allocates huge pages for libfabric,
+// and allows containers to lock unlimited memory. Instances without EFA interfaces are left as they are, so that the
+// same configuration can be used across instance types.
+type EFAOptions struct {
+	// Enabled configures the node for EFA.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// HugePages is the number of 2 MiB huge pages that are allocated, which `kubelet` excludes from the memory
+	// that is allocatable to pods. Defaults to `5128`.
+	HugePages *int32 `json:"hugePages,omitempty"`
+
+	// ReservedMemory is added to the memory that `kubelet` reserves from pods, for the memory that the EFA
+	// driver pins outside of the cgroups of pods.
+	ReservedMemory *resource.Quantity `json:"reservedMemory,omitempty"`
+}
+
+// NeuronOptions prepare the node for workloads that use the [AWS Neuron](https://awsdocs-neuron.readthedocs-hosted.com/)
+// devices of Inferentia and Trainium instances. On an instance with Neuron devices, `nodeadm` loads the `neuron` kernel
+// module, allocates huge pages for the Neuron runtime, and, when the Neuron OCI hook is installed, makes the `neuron`
+// runtime the default runtime of `containerd`, so that a container is given the devices listed in its
+// `AWS_NEURON_VISIBLE_DEVICES` environment variable. Instances without Neuron devices are left as they are, so that the
+// same configuration can be used across instance types.
+type NeuronOptions struct {
+	// Enabled configures the node for Neuron.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// HugePages is the number of 2 MiB huge pages that are allocated, which `kubelet` excludes from the memory
+	// that is allocatable to pods. Defaults to `256` for each Neuron device. They are allocated in addition to
+	// the huge pages of EFA on instances with both.
+	HugePages *int32 `json:"hugePages,omitempty"`
+
+	// ReservedMemory is added to the memory that `kubelet` reserves from pods, for the memory that the Neuron
+	// driver allocates outside of the cgroups of pods.
+	ReservedMemory *resource.Quantity `json:"reservedMemory,omitempty"`
+}
+
+// TimeSyncOptions configure the sources of `chronyd`. By default, the node synchronizes its clock with the
+// [Amazon Time Sync Service](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/set-time.html) at its IPv4 and
+// IPv6 link-local endpoints, and `nodeadm init` waits for the clock to be synchronized before `kubelet` is started,
+// as the credentials and certificates of the node are rejected by a clock that is off. The sources of hybrid nodes
+// are only configured when servers are set.
+type TimeSyncOptions struct {
+	// Servers are the NTP servers that are used instead of the Amazon Time Sync Service, such as those of an
+	// air-gapped network or of the data center of a hybrid node.
+	Servers []string `json:"servers,omitempty"`
+
+	// SyncTimeout is how long `nodeadm init` waits for the clock to be synchronized, after which it fails.
+	// Defaults to `2m`.
+	SyncTimeout *metav1.Duration `json:"syncTimeout,omitempty"`
+}
+
+// SwapOptions control the swap space of the node. Swap space is not created when Device is not set.
+type SwapOptions struct {
+	// Device is the kind of swap space that is created.
+	Device SwapDevice `json:"device,omitempty"`
+
+	// Size is the size of the swap space. For `Zram`, it is the uncompressed size of the device.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// Behavior determines whether pods may use the node's swap, and requires `kubelet` 1.30 or later.
+	// When it is not set, `kubelet` is only configured to tolerate swap if Device is set.
+	Behavior SwapBehavior `json:"behavior,omitempty"`
+}
+
+// SwapDevice is a kind of swap space.
+//
+// * `File` creates a swap file at `/swapfile` on the root volume. The file is kept across reboots.
+// * `Zram` creates a compressed block device in memory, which requires the `zram` kernel module.
+// +kubebuilder:validation:Enum={File, Zram}
+type SwapDevice string
+
+const (
+	SwapDeviceFile SwapDevice = "File"
+	SwapDeviceZram SwapDevice = "Zram"
+)
+
+// CgroupOptions control the cgroup driver shared by `kubelet` and `containerd`, which must agree
+// with each other and with the cgroup hierarchy that the node was booted with.
+type CgroupOptions struct {
+	// Version is the cgroup hierarchy that the node is expected to be booted with. `nodeadm init`
+	// fails when the booted hierarchy is different. By default, the booted hierarchy is used.
+	Version CgroupVersion `json:"version,omitempty"`
+
+	// Driver is the cgroup driver of `kubelet` and `containerd`. Defaults to `systemd`.
+	Driver CgroupDriver `json:"driver,omitempty"`
+
+	// IO isolates the IO of `containerd` and `kubelet` from that of pods.
+	IO CgroupIOOptions `json:"io,omitempty"`
+}
+
+// CgroupIOOptions set the IO weights of `runtime.slice`, which contains `containerd` and `kubelet`, and of
+// `kubepods.slice`, which contains pods, so that pods with heavy IO cannot starve the container runtime of
+// the root volume. Weights are relative to each other and to `system.slice`, and only take effect while
+// the device is contended. Isolation is configured when either weight is set, and requires the `v2`
+// cgroup hierarchy and the `systemd` cgroup driver. Weights that are not set use the default of 100.
+type CgroupIOOptions struct {
+	// RuntimeWeight is the IO weight of `runtime.slice`, from 1 to 10000.
+	RuntimeWeight int `json:"runtimeWeight,omitempty"`
+
+	// PodsWeight is the IO weight of `kubepods.slice`, from 1 to 10000.
+	PodsWeight int `json:"podsWeight,omitempty"`
+}
+
+// CgroupVersion is a cgroup hierarchy.
+//
+// * `v1` is the legacy hierarchy, with a separate tree for each controller.
+// * `v2` is the unified hierarchy, which is the default on AL2023.
+// +kubebuilder:validation:Enum={v1, v2}
+type CgroupVersion string
+
+const (
+	CgroupVersionV1 CgroupVersion = "v1"
+	CgroupVersionV2 CgroupVersion = "v2"
+)
+
+// CgroupDriver is the component that creates the cgroups of pods and containers.
+//
+// * `systemd` creates them as systemd units, so that systemd remains the only manager of the hierarchy.
+// * `cgroupfs` writes to `/sys/fs/cgroup` directly, which is only supported on the `v1` hierarchy.
+// +kubebuilder:validation:Enum={systemd, cgroupfs}
+type CgroupDriver string
+
+const (
+	CgroupDriverSystemd  CgroupDriver = "systemd"
+	CgroupDriverCgroupfs CgroupDriver = "cgroupfs"
+)
+
+// PodLogsOptions control the storage of the logs in `/var/log/pods`, which are written by `containerd`
+// and rotated by `kubelet`.
+type PodLogsOptions struct {
+	// Storage is where the logs are stored. Defaults to `Disk`.
+	Storage PodLogsStorage `json:"storage,omitempty"`
+
+	// MemoryLimit is the size of the `tmpfs` that holds the logs when Storage is `Memory`.
+	// The limit is reserved from the node's allocatable memory, and `kubelet` rotates the log
+	// of each container so that the logs of every pod fit within it. Defaults to `512Mi`.
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
+
+	// MaxFileSize is the size at which `kubelet` rotates the log of a container, its `containerLogMaxSize`.
+	// Defaults to `10Mi`, or to a size at which the logs of every pod fit within MemoryLimit when Storage
+	// is `Memory`.
+	MaxFileSize *resource.Quantity `json:"maxFileSize,omitempty"`
+
+	// MaxFiles is the number of log files that `kubelet` keeps for each container, its `containerLogMaxFiles`,
+	// which must be at least `2`. Defaults to `5`, or to `2` when Storage is `Memory`.
+	MaxFiles *int32 `json:"maxFiles,omitempty"`
+
+	// Forwarder ships the logs off the node as they are written.
+	Forwarder PodLogsForwarderOptions `json:"forwarder,omitempty"`
+}
+
+// JournaldOptions are written to the `systemd-journald` drop-in `/etc/systemd/journald.conf.d/99-nodeadm.conf`,
+// which protects small root volumes from the logs of a service that logs too much. The defaults of the AMI are kept
+// for the options that are not set.
+type JournaldOptions struct {
+	// RateLimitInterval is the interval within which a service may log RateLimitBurst messages, beyond which
+	// its messages are dropped until the interval ends. `0s` disables rate limiting.
+	RateLimitInterval *metav1.Duration `json:"rateLimitInterval,omitempty"`
+
+	// RateLimitBurst is the number of messages that a service may log within RateLimitInterval.
+	RateLimitBurst *int32 `json:"rateLimitBurst,omitempty"`
+
+	// SystemMaxUse is the disk space that the journal may use, beyond which its oldest files are removed.
+	SystemMaxUse *resource.Quantity `json:"systemMaxUse,omitempty"`
+}
+
+// PodLogsStorage is where the logs of containers are stored.
+//
+// * `Disk` stores logs on the root volume, or on instance storage when `localStorage` is configured.
+// * `Memory` stores logs on a memory-backed `tmpfs`, which avoids disk IO on nodes with many short-lived
+// containers. Logs do not persist across reboots.
+// +kubebuilder:validation:Enum={Disk, Memory}
+type PodLogsStorage string
+
+const (
+	PodLogsStorageDisk   PodLogsStorage = "Disk"
+	PodLogsStorageMemory PodLogsStorage = "Memory"
+)
+
+// PodLogsForwarderOptions configure a [Fluent Bit](https://fluentbit.io/) forwarder managed by `nodeadm`,
+// which must be installed.
+type PodLogsForwarderOptions struct {
+	// CloudWatchLogGroup is the Amazon CloudWatch Logs log group that the logs are forwarded to.
+	// The log group is created if it does not exist. Logs are not forwarded when this is not set.
+	CloudWatchLogGroup string `json:"cloudWatchLogGroup,omitempty"`
+}
+
+// TrustStoreOptions control the certificate authorities trusted by the node, such as those of a
+// TLS-intercepting proxy or a private registry.
+type TrustStoreOptions struct {
+	// CertificateAuthorities is a PEM-encoded bundle of certificate authorities that will be added to
+	// the operating system's trust store, and trusted when connecting to your cluster's kube-apiserver.
+	CertificateAuthorities string `json:"certificateAuthorities,omitempty"`
+}
+
+// ShutdownOptions control what `nodeadm` does when the instance is shut down.
+type ShutdownOptions struct {
+	// DeregisterNode cordons and drains the node, then deletes its Node object,
+	// when the instance is shut down. This does not occur when the instance is rebooted.
+	DeregisterNode bool `json:"deregisterNode,omitempty"`
+
+	// DrainTimeout is the maximum amount of time to wait for pods to be evicted
+	// before the Node object is deleted. Defaults to `1m`.
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// GracePeriod enables the [graceful node shutdown](https://kubernetes.io/docs/concepts/cluster-administration/node-shutdown/#graceful-node-shutdown)
+	// of `kubelet`, which delays the shutdown of the instance by up to this long while it terminates the pods
+	// on the node. `systemd-logind` is configured to allow the delay.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// CriticalPodsGracePeriod is the part of GracePeriod that is reserved for terminating critical pods,
+	// once the other pods have been terminated. It must not be longer than GracePeriod.
+	CriticalPodsGracePeriod *metav1.Duration `json:"criticalPodsGracePeriod,omitempty"`
+
+	// DrainOnSpotInterruption cordons and drains the node as soon as a [Spot Instance interruption notice](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/spot-instance-termination-notices.html)
+	// is issued, two minutes before the instance is interrupted, waiting for at most DrainTimeout. The notice is
+	// watched by the termination handler, as if `SpotInterruption` were one of its events. This is not supported
+	// on hybrid nodes.
+	DrainOnSpotInterruption bool `json:"drainOnSpotInterruption,omitempty"`
+
+	// TerminationHandler cordons and drains the node when the instance is about to be terminated or stopped, as
+	// soon as the instance metadata service reports it. This is not supported on hybrid nodes.
+	TerminationHandler TerminationHandlerOptions `json:"terminationHandler,omitempty"`
+}
+
+// TerminationHandlerOptions configure the `nodeadm-termination-handler` service, which watches the instance metadata
+// service for the events that terminate or stop the instance, and cordons and drains the node with the credentials of
+// `kubelet`, waiting for at most DrainTimeout.
+type TerminationHandlerOptions struct {
+	// Events are the events that the node is drained on. The handler is not run when it is empty, unless DrainOnSpotInterruption is enabled.
+	Events []TerminationEvent `json:"events,omitempty"`
+
+	// PollInterval is how often the instance metadata service is checked for the events. Defaults to `5s`.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// TerminationEvent is an event that terminates or stops the instance.
+//
+// * `SpotInterruption` is a [Spot Instance interruption notice](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/spot-instance-termination-notices.html).
+// * `LifecycleTermination` is the termination of the instance by its Auto Scaling group, once its target lifecycle state is `Terminated`.
+// * `ScheduledEvent` is an active [scheduled event](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/monitoring-instances-status-check_sched.html) that stops, reboots or retires the instance.
+// +kubebuilder:validation:Enum={SpotInterruption, LifecycleTermination, ScheduledEvent}
+type TerminationEvent string
+
+const (
+	TerminationEventSpotInterruption     TerminationEvent = "SpotInterruption"
+	TerminationEventLifecycleTermination TerminationEvent = "LifecycleTermination"
+	TerminationEventScheduledEvent       TerminationEvent = "ScheduledEvent"
+)
+
+// LocalStorageOptions control how [EC2 instance stores](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/InstanceStorage.html)
+// are used when available.
+type LocalStorageOptions struct {
+	Strategy LocalStorageStrategy `json:"strategy,omitempty"`
+
+	// MountPath is the path where the filesystem will be mounted.
+	// Defaults to `/mnt/k8s-disks/`.
+	MountPath string `json:"mountPath,omitempty"`
+
+	// List of directories that will not be mounted to LocalStorage. By default,
+	// all mounts are enabled.
+	DisabledMounts []DisabledMount `json:"disabledMounts,omitempty"`
+}
+
+// LocalStorageStrategy specifies how to handle an instance's local storage devices.
+// +kubebuilder:validation:Enum={RAID0, RAID10, Mount, None}
+type LocalStorageStrategy string
+
+const (
+	// LocalStorageRAID0 will create a single raid0 volume from any local disks
+	LocalStorageRAID0 LocalStorageStrategy = "RAID0"
+
+	// LocalStorageRAID10 will create a single raid10 volume from any local disks. Minimum of 4.
+	LocalStorageRAID10 LocalStorageStrategy = "RAID10"
+
+	// LocalStorageMount will mount each local disk individually
+	LocalStorageMount LocalStorageStrategy = "Mount"
+
+	// LocalStorageNone will leave any local disks unused, which is the same as not
+	// specifying a strategy.
+	LocalStorageNone LocalStorageStrategy = "None"
+)
+
+// DisabledMount specifies a directory that should not be mounted onto local storage
+//
+// * `Containerd` refers to `/var/lib/containerd`
+// * `PodLogs` refers to `/var/log/pods`
+// +kubebuilder:validation:Enum={Containerd, PodLogs}
+type DisabledMount string
+
+const (
+	DisabledMountContainerd DisabledMount = "Containerd"
+	DisabledMountPodLogs    DisabledMount = "PodLogs"
+)
+
+// Feature specifies which feature gate should be toggled
+// +kubebuilder:validation:Enum={InstanceIdNodeName, FastContainerImagePull, NodeLocalDNSCache, APIServerEndpointCheck}
+type Feature string
+
+const (
+	// InstanceIdNodeName will use EC2 instance ID as node name
+	InstanceIdNodeName Feature = "InstanceIdNodeName"
+	// FastContainerImagePull will lazily pull images with the soci-snapshotter
+	FastContainerImagePull Feature = "FastContainerImagePull"
+	// NodeLocalDNSCache will point pods at the link-local address of NodeLocal DNSCache
+	NodeLocalDNSCache Feature = "NodeLocalDNSCache"
+	// APIServerEndpointCheck will check that the node can use the kube-apiserver before kubelet is started
+	APIServerEndpointCheck Feature = "APIServerEndpointCheck"
+)
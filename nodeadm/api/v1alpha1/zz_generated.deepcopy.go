@@ -5,9 +5,106 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoLabelsOptions) DeepCopyInto(out *AutoLabelsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoLabelsOptions.
+func (in *AutoLabelsOptions) DeepCopy() *AutoLabelsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoLabelsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CgroupIOOptions) DeepCopyInto(out *CgroupIOOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CgroupIOOptions.
+func (in *CgroupIOOptions) DeepCopy() *CgroupIOOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CgroupIOOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out, which must be non-nil.
+func (in *CgroupOptions) DeepCopyInto(out *CgroupOptions) {
+	*out = *in
+	out.IO = in.IO
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CgroupOptions.
+func (in *CgroupOptions) DeepCopy() *CgroupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CgroupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudWatchAgentOptions) DeepCopyInto(out *CloudWatchAgentOptions) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]CloudWatchAgentMetric, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricsCollectionInterval != nil {
+		in, out := &in.MetricsCollectionInterval, &out.MetricsCollectionInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudWatchAgentOptions.
+func (in *CloudWatchAgentOptions) DeepCopy() *CloudWatchAgentOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudWatchAgentOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudWatchLoggingOptions) DeepCopyInto(out *CloudWatchLoggingOptions) {
+	*out = *in
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudWatchLoggingOptions.
+func (in *CloudWatchLoggingOptions) DeepCopy() *CloudWatchLoggingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudWatchLoggingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDetails) DeepCopyInto(out *ClusterDetails) {
 	*out = *in
@@ -16,6 +113,13 @@ func (in *ClusterDetails) DeepCopyInto(out *ClusterDetails) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertificatePins != nil {
+		in, out := &in.CertificatePins, &out.CertificatePins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Discovery.DeepCopyInto(&out.Discovery)
+	out.EndpointOverrides = in.EndpointOverrides
 	if in.EnableOutpost != nil {
 		in, out := &in.EnableOutpost, &out.EnableOutpost
 		*out = new(bool)
@@ -34,170 +138,1421 @@ func (in *ClusterDetails) DeepCopy() *ClusterDetails {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerdOptions) DeepCopyInto(out *ContainerdOptions) {
+func (in *ClusterDiscoveryOptions) DeepCopyInto(out *ClusterDiscoveryOptions) {
 	*out = *in
-	if in.BaseRuntimeSpec != nil {
-		in, out := &in.BaseRuntimeSpec, &out.BaseRuntimeSpec
-		*out = make(map[string]runtime.RawExtension, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.MinDelay != nil {
+		in, out := &in.MinDelay, &out.MinDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdOptions.
-func (in *ContainerdOptions) DeepCopy() *ContainerdOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscoveryOptions.
+func (in *ClusterDiscoveryOptions) DeepCopy() *ClusterDiscoveryOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerdOptions)
+	out := new(ClusterDiscoveryOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstanceOptions) DeepCopyInto(out *InstanceOptions) {
+func (in *ComponentsOptions) DeepCopyInto(out *ComponentsOptions) {
 	*out = *in
-	in.LocalStorage.DeepCopyInto(&out.LocalStorage)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceOptions.
-func (in *InstanceOptions) DeepCopy() *InstanceOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsOptions.
+func (in *ComponentsOptions) DeepCopy() *ComponentsOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(InstanceOptions)
+	out := new(ComponentsOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
+func (in *ContainerdDebugOptions) DeepCopyInto(out *ContainerdDebugOptions) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdDebugOptions.
+func (in *ContainerdDebugOptions) DeepCopy() *ContainerdDebugOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdDebugOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdGarbageCollectionOptions) DeepCopyInto(out *ContainerdGarbageCollectionOptions) {
+	*out = *in
+	if in.ScheduleDelay != nil {
+		in, out := &in.ScheduleDelay, &out.ScheduleDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdGarbageCollectionOptions.
+func (in *ContainerdGarbageCollectionOptions) DeepCopy() *ContainerdGarbageCollectionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdGarbageCollectionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdImagePullOptions) DeepCopyInto(out *ContainerdImagePullOptions) {
+	*out = *in
+	if in.ProgressTimeout != nil {
+		in, out := &in.ProgressTimeout, &out.ProgressTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdImagePullOptions.
+func (in *ContainerdImagePullOptions) DeepCopy() *ContainerdImagePullOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdImagePullOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdMetricsOptions) DeepCopyInto(out *ContainerdMetricsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdMetricsOptions.
+func (in *ContainerdMetricsOptions) DeepCopy() *ContainerdMetricsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdMetricsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdNRIOptions) DeepCopyInto(out *ContainerdNRIOptions) {
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PluginRegistrationTimeout != nil {
+		in, out := &in.PluginRegistrationTimeout, &out.PluginRegistrationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PluginRequestTimeout != nil {
+		in, out := &in.PluginRequestTimeout, &out.PluginRequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdNRIOptions.
+func (in *ContainerdNRIOptions) DeepCopy() *ContainerdNRIOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdNRIOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdOptions) DeepCopyInto(out *ContainerdOptions) {
+	*out = *in
+	if in.BaseRuntimeSpec != nil {
+		in, out := &in.BaseRuntimeSpec, &out.BaseRuntimeSpec
 		*out = make(map[string]runtime.RawExtension, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
-	if in.Flags != nil {
-		in, out := &in.Flags, &out.Flags
-		*out = make([]string, len(*in))
+	if in.BaseRuntimeSpecOverrides != nil {
+		in, out := &in.BaseRuntimeSpecOverrides, &out.BaseRuntimeSpecOverrides
+		*out = make(map[string]map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			var outVal map[string]runtime.RawExtension
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]runtime.RawExtension, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Runsc != nil {
+		in, out := &in.Runsc, &out.Runsc
+		*out = new(RunscOptions)
+		**out = **in
+	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make([]ContainerdRuntime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.SOCI = in.SOCI
+	in.PrePullImages.DeepCopyInto(&out.PrePullImages)
+	in.SmokeTest.DeepCopyInto(&out.SmokeTest)
+	if in.DiscardUnpackedLayers != nil {
+		in, out := &in.DiscardUnpackedLayers, &out.DiscardUnpackedLayers
+		*out = new(bool)
+		**out = **in
+	}
+	in.GarbageCollection.DeepCopyInto(&out.GarbageCollection)
+	in.ImageRetention.DeepCopyInto(&out.ImageRetention)
+	if in.RegistryCredentials != nil {
+		in, out := &in.RegistryCredentials, &out.RegistryCredentials
+		*out = make([]RegistryCredential, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	out.Debug = in.Debug
+	in.NRI.DeepCopyInto(&out.NRI)
+	in.ImagePulls.DeepCopyInto(&out.ImagePulls)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletOptions.
-func (in *KubeletOptions) DeepCopy() *KubeletOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdOptions.
+func (in *ContainerdOptions) DeepCopy() *ContainerdOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeletOptions)
+	out := new(ContainerdOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalStorageOptions) DeepCopyInto(out *LocalStorageOptions) {
+func (in *ContainerdRuntime) DeepCopyInto(out *ContainerdRuntime) {
 	*out = *in
-	if in.DisabledMounts != nil {
-		in, out := &in.DisabledMounts, &out.DisabledMounts
-		*out = make([]DisabledMount, len(*in))
-		copy(*out, *in)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalStorageOptions.
-func (in *LocalStorageOptions) DeepCopy() *LocalStorageOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRuntime.
+func (in *ContainerdRuntime) DeepCopy() *ContainerdRuntime {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalStorageOptions)
+	out := new(ContainerdRuntime)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
+func (in *DebugOptions) DeepCopyInto(out *DebugOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	in.NetworkCapture.DeepCopyInto(&out.NetworkCapture)
+	out.Tracing = in.Tracing
+	in.Logging.DeepCopyInto(&out.Logging)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfig.
-func (in *NodeConfig) DeepCopy() *NodeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugOptions.
+func (in *DebugOptions) DeepCopy() *DebugOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeConfig)
+	out := new(DebugOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskCheckOptions) DeepCopyInto(out *DiskCheckOptions) {
+	*out = *in
+	if in.DaemonSetFootprint != nil {
+		in, out := &in.DaemonSetFootprint, &out.DaemonSetFootprint
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskCheckOptions.
+func (in *DiskCheckOptions) DeepCopy() *DiskCheckOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskCheckOptions)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeConfigList) DeepCopyInto(out *NodeConfigList) {
+func (in *EFAOptions) DeepCopyInto(out *EFAOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NodeConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReservedMemory != nil {
+		in, out := &in.ReservedMemory, &out.ReservedMemory
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigList.
-func (in *NodeConfigList) DeepCopy() *NodeConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EFAOptions.
+func (in *EFAOptions) DeepCopy() *EFAOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeConfigList)
+	out := new(EFAOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointOverrides) DeepCopyInto(out *EndpointOverrides) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointOverrides.
+func (in *EndpointOverrides) DeepCopy() *EndpointOverrides {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(EndpointOverrides)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeConfigSpec) DeepCopyInto(out *NodeConfigSpec) {
+func (in *Hook) DeepCopyInto(out *Hook) {
 	*out = *in
-	in.Cluster.DeepCopyInto(&out.Cluster)
-	in.Containerd.DeepCopyInto(&out.Containerd)
-	in.Instance.DeepCopyInto(&out.Instance)
-	in.Kubelet.DeepCopyInto(&out.Kubelet)
-	if in.FeatureGates != nil {
-		in, out := &in.FeatureGates, &out.FeatureGates
-		*out = make(map[Feature]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigSpec.
-func (in *NodeConfigSpec) DeepCopy() *NodeConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeConfigSpec)
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HooksOptions) DeepCopyInto(out *HooksOptions) {
+	*out = *in
+	if in.PreInit != nil {
+		in, out := &in.PreInit, &out.PreInit
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostContainerd != nil {
+		in, out := &in.PostContainerd, &out.PostContainerd
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostKubelet != nil {
+		in, out := &in.PostKubelet, &out.PostKubelet
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HooksOptions.
+func (in *HooksOptions) DeepCopy() *HooksOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HooksOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HybridOptions) DeepCopyInto(out *HybridOptions) {
+	*out = *in
+	out.SSM = in.SSM
+	out.IAMRolesAnywhere = in.IAMRolesAnywhere
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HybridOptions.
+func (in *HybridOptions) DeepCopy() *HybridOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HybridOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMRolesAnywhereOptions) DeepCopyInto(out *IAMRolesAnywhereOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMRolesAnywhereOptions.
+func (in *IAMRolesAnywhereOptions) DeepCopy() *IAMRolesAnywhereOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMRolesAnywhereOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRetentionOptions) DeepCopyInto(out *ImageRetentionOptions) {
+	*out = *in
+	if in.MinimumAge != nil {
+		in, out := &in.MinimumAge, &out.MinimumAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaximumAge != nil {
+		in, out := &in.MaximumAge, &out.MaximumAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PinnedImages != nil {
+		in, out := &in.PinnedImages, &out.PinnedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRetentionOptions.
+func (in *ImageRetentionOptions) DeepCopy() *ImageRetentionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRetentionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceIdentityOptions) DeepCopyInto(out *InstanceIdentityOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceIdentityOptions.
+func (in *InstanceIdentityOptions) DeepCopy() *InstanceIdentityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceIdentityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceOptions) DeepCopyInto(out *InstanceOptions) {
+	*out = *in
+	in.LocalStorage.DeepCopyInto(&out.LocalStorage)
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	out.TrustStore = in.TrustStore
+	in.PodLogs.DeepCopyInto(&out.PodLogs)
+	in.Journald.DeepCopyInto(&out.Journald)
+	out.Cgroup = in.Cgroup
+	in.Swap.DeepCopyInto(&out.Swap)
+	out.PressureMonitor = in.PressureMonitor
+	in.TimeSync.DeepCopyInto(&out.TimeSync)
+	in.EFA.DeepCopyInto(&out.EFA)
+	in.Neuron.DeepCopyInto(&out.Neuron)
+	out.Tags = in.Tags
+	out.Identity = in.Identity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceOptions.
+func (in *InstanceOptions) DeepCopy() *InstanceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceTagsOptions) DeepCopyInto(out *InstanceTagsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceTagsOptions.
+func (in *InstanceTagsOptions) DeepCopy() *InstanceTagsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceTagsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JournaldOptions) DeepCopyInto(out *JournaldOptions) {
+	*out = *in
+	if in.RateLimitInterval != nil {
+		in, out := &in.RateLimitInterval, &out.RateLimitInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimitBurst != nil {
+		in, out := &in.RateLimitBurst, &out.RateLimitBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SystemMaxUse != nil {
+		in, out := &in.SystemMaxUse, &out.SystemMaxUse
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JournaldOptions.
+func (in *JournaldOptions) DeepCopy() *JournaldOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(JournaldOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletAuthenticationOptions) DeepCopyInto(out *KubeletAuthenticationOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletAuthenticationOptions.
+func (in *KubeletAuthenticationOptions) DeepCopy() *KubeletAuthenticationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletAuthenticationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.TokenCache.DeepCopyInto(&out.TokenCache)
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]Taint, len(*in))
+		copy(*out, *in)
+	}
+	in.ServingCertificate.DeepCopyInto(&out.ServingCertificate)
+	if in.ClusterDNS != nil {
+		in, out := &in.ClusterDNS, &out.ClusterDNS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NodeIP.DeepCopyInto(&out.NodeIP)
+	if in.StaticPods != nil {
+		in, out := &in.StaticPods, &out.StaticPods
+		*out = make([]StaticPod, len(*in))
+		copy(*out, *in)
+	}
+	out.Authentication = in.Authentication
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	out.AutoLabels = in.AutoLabels
+	out.StatusAnnotations = in.StatusAnnotations
+	in.RemoteConfig.DeepCopyInto(&out.RemoteConfig)
+	in.DiskCheck.DeepCopyInto(&out.DiskCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletOptions.
+func (in *KubeletOptions) DeepCopy() *KubeletOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletRemoteConfigOptions) DeepCopyInto(out *KubeletRemoteConfigOptions) {
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRestartInterval != nil {
+		in, out := &in.MinRestartInterval, &out.MinRestartInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletRemoteConfigOptions.
+func (in *KubeletRemoteConfigOptions) DeepCopy() *KubeletRemoteConfigOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletRemoteConfigOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalStorageOptions) DeepCopyInto(out *LocalStorageOptions) {
+	*out = *in
+	if in.DisabledMounts != nil {
+		in, out := &in.DisabledMounts, &out.DisabledMounts
+		*out = make([]DisabledMount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalStorageOptions.
+func (in *LocalStorageOptions) DeepCopy() *LocalStorageOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalStorageOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingOptions) DeepCopyInto(out *LoggingOptions) {
+	*out = *in
+	in.CloudWatch.DeepCopyInto(&out.CloudWatch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingOptions.
+func (in *LoggingOptions) DeepCopy() *LoggingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringOptions) DeepCopyInto(out *MonitoringOptions) {
+	*out = *in
+	in.CloudWatchAgent.DeepCopyInto(&out.CloudWatchAgent)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringOptions.
+func (in *MonitoringOptions) DeepCopy() *MonitoringOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkCaptureOptions) DeepCopyInto(out *NetworkCaptureOptions) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkCaptureOptions.
+func (in *NetworkCaptureOptions) DeepCopy() *NetworkCaptureOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkCaptureOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NeuronOptions) DeepCopyInto(out *NeuronOptions) {
+	*out = *in
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReservedMemory != nil {
+		in, out := &in.ReservedMemory, &out.ReservedMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeuronOptions.
+func (in *NeuronOptions) DeepCopy() *NeuronOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NeuronOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingOptions) DeepCopyInto(out *NetworkingOptions) {
+	if in.ExcludeInterfaces != nil {
+		in, out := &in.ExcludeInterfaces, &out.ExcludeInterfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingOptions.
+func (in *NetworkingOptions) DeepCopy() *NetworkingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfig.
+func (in *NodeConfig) DeepCopy() *NodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigList) DeepCopyInto(out *NodeConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigList.
+func (in *NodeConfigList) DeepCopy() *NodeConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigSpec) DeepCopyInto(out *NodeConfigSpec) {
+	*out = *in
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Containerd.DeepCopyInto(&out.Containerd)
+	in.Instance.DeepCopyInto(&out.Instance)
+	in.Kubelet.DeepCopyInto(&out.Kubelet)
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[Feature]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Hybrid = in.Hybrid
+	in.Debug.DeepCopyInto(&out.Debug)
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	in.Security.DeepCopyInto(&out.Security)
+	in.Hooks.DeepCopyInto(&out.Hooks)
+	out.Components = in.Components
+	in.Networking.DeepCopyInto(&out.Networking)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.System.DeepCopyInto(&out.System)
+	in.Systemd.DeepCopyInto(&out.Systemd)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigSpec.
+func (in *NodeConfigSpec) DeepCopy() *NodeConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeIPOptions) DeepCopyInto(out *NodeIPOptions) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeIPOptions.
+func (in *NodeIPOptions) DeepCopy() *NodeIPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeIPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLogsForwarderOptions) DeepCopyInto(out *PodLogsForwarderOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLogsForwarderOptions.
+func (in *PodLogsForwarderOptions) DeepCopy() *PodLogsForwarderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLogsForwarderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLogsOptions) DeepCopyInto(out *PodLogsOptions) {
+	*out = *in
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFiles != nil {
+		in, out := &in.MaxFiles, &out.MaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	out.Forwarder = in.Forwarder
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLogsOptions.
+func (in *PodLogsOptions) DeepCopy() *PodLogsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLogsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrePullImagesOptions) DeepCopyInto(out *PrePullImagesOptions) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrePullImagesOptions.
+func (in *PrePullImagesOptions) DeepCopy() *PrePullImagesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PrePullImagesOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PressureMonitorOptions) DeepCopyInto(out *PressureMonitorOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PressureMonitorOptions.
+func (in *PressureMonitorOptions) DeepCopy() *PressureMonitorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PressureMonitorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyOptions) DeepCopyInto(out *ProxyOptions) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyOptions.
+func (in *ProxyOptions) DeepCopy() *ProxyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessOptions) DeepCopyInto(out *ReadinessOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessOptions.
+func (in *ReadinessOptions) DeepCopy() *ReadinessOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCredential) DeepCopyInto(out *RegistryCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCredential.
+func (in *RegistryCredential) DeepCopy() *RegistryCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunscOptions) DeepCopyInto(out *RunscOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunscOptions.
+func (in *RunscOptions) DeepCopy() *RunscOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RunscOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOCIOptions) DeepCopyInto(out *SOCIOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOCIOptions.
+func (in *SOCIOptions) DeepCopy() *SOCIOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SOCIOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSMOptions) DeepCopyInto(out *SSMOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSMOptions.
+func (in *SSMOptions) DeepCopy() *SSMOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SSMOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompDefaultOptions) DeepCopyInto(out *SeccompDefaultOptions) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]SeccompProfile, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompDefaultOptions.
+func (in *SeccompDefaultOptions) DeepCopy() *SeccompDefaultOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompDefaultOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfile) DeepCopyInto(out *SeccompProfile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfile.
+func (in *SeccompProfile) DeepCopy() *SeccompProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityOptions) DeepCopyInto(out *SecurityOptions) {
+	*out = *in
+	in.SeccompDefault.DeepCopyInto(&out.SeccompDefault)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityOptions.
+func (in *SecurityOptions) DeepCopy() *SecurityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingCertificateOptions) DeepCopyInto(out *ServingCertificateOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingCertificateOptions.
+func (in *ServingCertificateOptions) DeepCopy() *ServingCertificateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingCertificateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShutdownOptions) DeepCopyInto(out *ShutdownOptions) {
+	*out = *in
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CriticalPodsGracePeriod != nil {
+		in, out := &in.CriticalPodsGracePeriod, &out.CriticalPodsGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.TerminationHandler.DeepCopyInto(&out.TerminationHandler)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShutdownOptions.
+func (in *ShutdownOptions) DeepCopy() *ShutdownOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ShutdownOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestOptions) DeepCopyInto(out *SmokeTestOptions) {
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestOptions.
+func (in *SmokeTestOptions) DeepCopy() *SmokeTestOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticPod) DeepCopyInto(out *StaticPod) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticPod.
+func (in *StaticPod) DeepCopy() *StaticPod {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusAnnotationsOptions) DeepCopyInto(out *StatusAnnotationsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusAnnotationsOptions.
+func (in *StatusAnnotationsOptions) DeepCopy() *StatusAnnotationsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusAnnotationsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwapOptions) DeepCopyInto(out *SwapOptions) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwapOptions.
+func (in *SwapOptions) DeepCopy() *SwapOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SwapOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemOptions) DeepCopyInto(out *SystemOptions) {
+	*out = *in
+	if in.Sysctl != nil {
+		in, out := &in.Sysctl, &out.Sysctl
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemOptions.
+func (in *SystemOptions) DeepCopy() *SystemOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdDropIn) DeepCopyInto(out *SystemdDropIn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdDropIn.
+func (in *SystemdDropIn) DeepCopy() *SystemdDropIn {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdDropIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdOptions) DeepCopyInto(out *SystemdOptions) {
+	*out = *in
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]SystemdUnit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdOptions.
+func (in *SystemdOptions) DeepCopy() *SystemdOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdUnit) DeepCopyInto(out *SystemdUnit) {
+	*out = *in
+	if in.DropIns != nil {
+		in, out := &in.DropIns, &out.DropIns
+		*out = make([]SystemdDropIn, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdUnit.
+func (in *SystemdUnit) DeepCopy() *SystemdUnit {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Taint) DeepCopyInto(out *Taint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taint.
+func (in *Taint) DeepCopy() *Taint {
+	if in == nil {
+		return nil
+	}
+	out := new(Taint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationHandlerOptions) DeepCopyInto(out *TerminationHandlerOptions) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]TerminationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationHandlerOptions.
+func (in *TerminationHandlerOptions) DeepCopy() *TerminationHandlerOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationHandlerOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSyncOptions) DeepCopyInto(out *TimeSyncOptions) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncTimeout != nil {
+		in, out := &in.SyncTimeout, &out.SyncTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSyncOptions.
+func (in *TimeSyncOptions) DeepCopy() *TimeSyncOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSyncOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenCacheOptions) DeepCopyInto(out *TokenCacheOptions) {
+	*out = *in
+	if in.LeadTime != nil {
+		in, out := &in.LeadTime, &out.LeadTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenCacheOptions.
+func (in *TokenCacheOptions) DeepCopy() *TokenCacheOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenCacheOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingOptions) DeepCopyInto(out *TracingOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingOptions.
+func (in *TracingOptions) DeepCopy() *TracingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustStoreOptions) DeepCopyInto(out *TrustStoreOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustStoreOptions.
+func (in *TrustStoreOptions) DeepCopy() *TrustStoreOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustStoreOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserNamespacesOptions) DeepCopyInto(out *UserNamespacesOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserNamespacesOptions.
+func (in *UserNamespacesOptions) DeepCopy() *UserNamespacesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UserNamespacesOptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,276 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/control"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/drift"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+func NewAgentCommand() cli.Command {
+	agent := agentCmd{
+		socket:     control.DefaultSocketPath,
+		kubeconfig: kubelet.KubeconfigPath,
+		reload:     make(chan struct{}, 1),
+	}
+	agent.cmd = flaggy.NewSubcommand("agent")
+	agent.cmd.Bool(&agent.restore, "r", "restore", "restore managed files to the content rendered by nodeadm when they are modified.")
+	agent.cmd.Bool(&agent.force, "f", "force", "restore managed files even when they were rendered again since the agent loaded them, from their latest rendering.")
+	agent.cmd.String(&agent.socket, "s", "socket", "path of the unix socket the control API is served on. The API is not served when empty.")
+	agent.cmd.String(&agent.nodeName, "n", "node-name", "name of the Node object that drift is reported on. Defaults to the --hostname-override of the running kubelet.")
+	agent.cmd.String(&agent.kubeconfig, "k", "kubeconfig", "kubeconfig used to report drift on the Node object.")
+	agent.cmd.Description = "Run as a long-lived agent that watches the files managed by nodeadm and serves a control API"
+	return &agent
+}
+
+type agentCmd struct {
+	cmd     *flaggy.Subcommand
+	restore bool
+	force   bool
+	socket  string
+	// nodeName and kubeconfig identify the Node object that drift is
+	// reported on as a condition
+	nodeName   string
+	kubeconfig string
+	client     kubernetes.Interface
+	// reload is signaled when the node was reconfigured through the control
+	// API, so that the manifest of managed files is loaded again.
+	reload chan struct{}
+
+	mu            sync.Mutex
+	reconfiguring bool
+	// generation is the generation of the manifest being watched
+	generation int64
+	// modifiedFiles are the managed files of the manifest being watched that
+	// were modified and not restored
+	modifiedFiles []string
+	result        agentResult
+}
+
+// agentResult summarizes the drift that was detected before the agent exited.
+type agentResult struct {
+	WatchedFiles  int      `json:"watchedFiles"`
+	DriftedFiles  []string `json:"driftedFiles,omitempty"`
+	RestoredFiles []string `json:"restoredFiles,omitempty"`
+}
+
+func (c *agentCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *agentCmd) Result() any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &agentResult{
+		WatchedFiles:  c.result.WatchedFiles,
+		DriftedFiles:  slices.Clone(c.result.DriftedFiles),
+		RestoredFiles: slices.Clone(c.result.RestoredFiles),
+	}
+}
+
+func (c *agentCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	if c.socket != "" {
+		server := control.NewServer(opts.ConfigSource, kubelet.KubeconfigPath, control.Hooks{
+			Status:        c.Result,
+			Reconfiguring: c.setReconfiguring,
+		})
+		log.Info("Serving control API..", zap.String("socket", c.socket))
+		go func() {
+			serveErr <- server.Serve(ctx, c.socket)
+			// the agent exits when the API cannot be served
+			stop()
+		}()
+	}
+
+	for ctx.Err() == nil {
+		if err := c.watch(ctx, log); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		watchedFiles := c.result.WatchedFiles
+		c.mu.Unlock()
+		if c.socket == "" && watchedFiles == 0 {
+			return nil
+		}
+	}
+	if c.socket != "" {
+		return <-serveErr
+	}
+	return nil
+}
+
+// watch watches the managed files for drift until the context is done or the
+// node is reconfigured.
+func (c *agentCmd) watch(ctx context.Context, log *zap.Logger) error {
+	log.Info("Loading manifest of managed files..", zap.String("path", manifest.ManifestPath))
+	m, err := manifest.Load(manifest.ManifestPath)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.result.WatchedFiles = len(m.Files)
+	c.generation = m.Generation
+	c.modifiedFiles = nil
+	c.mu.Unlock()
+
+	watchCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// report drift that occurred while the agent was not running
+	for i := range m.Files {
+		drifted, err := m.Files[i].Drifted()
+		if err != nil {
+			return err
+		}
+		if drifted {
+			if err := c.onDrift(log, &m.Files[i]); err != nil {
+				return err
+			}
+		}
+	}
+	c.reportDrift(ctx, log)
+
+	go func() {
+		select {
+		case <-c.reload:
+			cancel(nil)
+		case <-watchCtx.Done():
+		}
+	}()
+	if len(m.Files) == 0 {
+		log.Info("No managed files in manifest, nothing to watch")
+		if c.socket != "" {
+			<-watchCtx.Done()
+		}
+		return nil
+	}
+	log.Info("Watching managed files for drift..", zap.Int("files", len(m.Files)), zap.Bool("restore", c.restore), zap.Int64("generation", m.Generation))
+	err = manifest.Watch(watchCtx, m, func(file *manifest.ManagedFile) {
+		if err := c.onDrift(log, file); err != nil {
+			cancel(err)
+			return
+		}
+		c.reportDrift(watchCtx, log)
+	})
+	if cause := context.Cause(watchCtx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return cause
+	}
+	return err
+}
+
+// onDrift reports a file that was modified outside of nodeadm, and restores
+// it when enabled. A file is only restored from the generation of the
+// manifest that the agent loaded; when files were rendered again since, an
+// error is returned, unless forced, in which case the manifest is reloaded and
+// the file is restored from its latest rendering.
+func (c *agentCmd) onDrift(log *zap.Logger, file *manifest.ManagedFile) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.reconfiguring {
+		// the files are being rewritten by nodeadm itself
+		return nil
+	}
+	c.result.DriftedFiles = append(c.result.DriftedFiles, file.Path)
+	log.Warn("Managed file was modified outside of nodeadm",
+		zap.String("path", file.Path),
+		zap.Int("driftCount", len(c.result.DriftedFiles)),
+	)
+	if !slices.Contains(c.modifiedFiles, file.Path) {
+		c.modifiedFiles = append(c.modifiedFiles, file.Path)
+	}
+	if !c.restore {
+		return nil
+	}
+	current, err := manifest.Load(manifest.ManifestPath)
+	if err != nil {
+		return err
+	}
+	if current.Generation != c.generation {
+		if !c.force {
+			return fmt.Errorf("managed files were rendered again since generation %d of the manifest, now %d, so %s was not restored", c.generation, current.Generation, file.Path)
+		}
+		log.Info("Reloading manifest of managed files rendered again..", zap.Int64("generation", current.Generation))
+		select {
+		case c.reload <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	if err := file.Restore(); err != nil {
+		log.Error("Failed to restore managed file", zap.String("path", file.Path), zap.Error(err))
+	} else {
+		log.Info("Restored managed file", zap.String("path", file.Path))
+		c.result.RestoredFiles = append(c.result.RestoredFiles, file.Path)
+		c.modifiedFiles = slices.DeleteFunc(c.modifiedFiles, func(path string) bool { return path == file.Path })
+	}
+	return nil
+}
+
+// reportDrift sets the drift condition of the Node from the managed files
+// that are modified and were not restored. Failures are only logged, since the
+// node may not be registered, or the cluster reachable, yet.
+func (c *agentCmd) reportDrift(ctx context.Context, log *zap.Logger) {
+	if c.nodeName == "" {
+		nodeName, err := kubelet.GetRunningNodeName()
+		if err != nil {
+			log.Warn("Failed to resolve the name of the node to report drift on, pass --node-name", zap.Error(err))
+			return
+		}
+		c.nodeName = nodeName
+	}
+	if c.client == nil {
+		client, err := node.NewClient(c.kubeconfig)
+		if err != nil {
+			log.Warn("Failed to build client to report drift on the node", zap.Error(err))
+			return
+		}
+		c.client = client
+	}
+	c.mu.Lock()
+	modifiedFiles := slices.Clone(c.modifiedFiles)
+	c.mu.Unlock()
+	if err := drift.ReportModifiedFiles(ctx, c.client, c.nodeName, modifiedFiles); err != nil {
+		log.Warn("Failed to report drift on the node", zap.String("node", c.nodeName), zap.Error(err))
+	}
+}
+
+// setReconfiguring ignores drift while the node is reconfigured, and reloads
+// the manifest once it is done.
+func (c *agentCmd) setReconfiguring(reconfiguring bool) {
+	c.mu.Lock()
+	c.reconfiguring = reconfiguring
+	c.mu.Unlock()
+	if !reconfiguring {
+		select {
+		case c.reload <- struct{}{}:
+		default:
+		}
+	}
+}
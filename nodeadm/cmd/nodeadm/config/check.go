@@ -4,12 +4,22 @@ import (
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 )
 
 type fileCmd struct {
-	cmd *flaggy.Subcommand
+	cmd    *flaggy.Subcommand
+	result checkResult
+}
+
+type checkResult struct {
+	Source string `json:"source"`
+	Valid  bool   `json:"valid"`
+	// Warnings are problems that do not make the configuration invalid, such
+	// as fields of kubelet.config that are overridden by kubelet.flags
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func NewCheckCommand() cli.Command {
@@ -24,7 +34,12 @@ func (c *fileCmd) Flaggy() *flaggy.Subcommand {
 	return c.cmd
 }
 
+func (c *fileCmd) Result() any {
+	return &c.result
+}
+
 func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	c.result.Source = opts.ConfigSource
 	log.Info("Checking configuration", zap.String("source", opts.ConfigSource))
 	provider, err := configprovider.BuildConfigProvider(opts.ConfigSource)
 	if err != nil {
@@ -37,6 +52,38 @@ func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	if err := api.ValidateNodeConfig(nodeConfig); err != nil {
 		return err
 	}
+	deprecations, err := getFlagDeprecations(log, nodeConfig)
+	if err != nil {
+		return err
+	}
+	c.result.Valid = true
+	for _, deprecation := range deprecations {
+		log.Warn("Kubelet flag is deprecated", zap.String("flag", deprecation.Flag), zap.String("deprecated", deprecation.Deprecated))
+		c.result.Warnings = append(c.result.Warnings, deprecation.String())
+	}
+	conflicts, err := kubelet.GetFlagConflicts(&nodeConfig.Spec.Kubelet)
+	if err != nil {
+		return err
+	}
+	for _, conflict := range conflicts {
+		log.Warn("Field of kubelet config is overridden by a kubelet flag", zap.String("field", conflict.Field), zap.String("flag", conflict.Flag))
+		c.result.Warnings = append(c.result.Warnings, conflict.String())
+	}
 	log.Info("Configuration is valid")
 	return nil
 }
+
+// getFlagDeprecations returns the flags of kubelet.flags that are deprecated
+// in the kubelet of the node, or an error if any of them were removed. The
+// flags are not checked where kubelet is not installed.
+func getFlagDeprecations(log *zap.Logger, nodeConfig *api.NodeConfig) ([]kubelet.FlagDeprecation, error) {
+	if len(nodeConfig.Spec.Kubelet.Flags) == 0 {
+		return nil, nil
+	}
+	kubeletVersion, err := kubelet.GetKubeletVersion()
+	if err != nil || kubeletVersion == "" {
+		log.Info("Skipping the check of kubelet flags, since the version of kubelet is not known", zap.Error(err))
+		return nil, nil
+	}
+	return kubelet.CheckFlags(kubeletVersion, nodeConfig.Spec.Kubelet.Flags)
+}
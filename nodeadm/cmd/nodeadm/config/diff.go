@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+)
+
+// defaultVersionsDir holds the other versions of nodeadm that the configs of
+// a NodeConfig are rendered with, or the configs they rendered.
+const defaultVersionsDir = "/opt/nodeadm/versions"
+
+type diffCmd struct {
+	cmd         *flaggy.Subcommand
+	from        string
+	to          string
+	fromVersion string
+	toVersion   string
+	nodeConfig  string
+	versionsDir string
+	result      diffResult
+}
+
+type diffResult struct {
+	From  string              `json:"from"`
+	To    string              `json:"to"`
+	Files []manifest.FileDiff `json:"files"`
+}
+
+func NewDiffCommand() cli.Command {
+	cmd := diffCmd{
+		to:          manifest.ManifestPath,
+		versionsDir: defaultVersionsDir,
+	}
+	cmd.cmd = flaggy.NewSubcommand("diff")
+	cmd.cmd.String(&cmd.from, "f", "from", "manifest of the files rendered by a previous version of nodeadm.")
+	cmd.cmd.String(&cmd.to, "t", "to", "manifest of the files rendered by the version of nodeadm to compare against.")
+	cmd.cmd.String(&cmd.fromVersion, "", "from-version", "version of nodeadm to render the configs of --node-config with, instead of --from.")
+	cmd.cmd.String(&cmd.toVersion, "", "to-version", "version of nodeadm to compare the configs of --from-version against. This nodeadm renders them when it is not set.")
+	cmd.cmd.String(&cmd.nodeConfig, "n", "node-config", "path of the NodeConfig that is rendered by both versions.")
+	cmd.cmd.String(&cmd.versionsDir, "", "versions-dir", "directory of the versions of nodeadm, as <version>/nodeadm, or of the configs they rendered, as <version>.json.")
+	cmd.cmd.Description = "Compare the files rendered by two versions of nodeadm"
+	return &cmd
+}
+
+func (c *diffCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *diffCmd) Result() any {
+	return &c.result
+}
+
+func (c *diffCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	var from, to *manifest.Manifest
+	var err error
+	if c.fromVersion != "" {
+		if c.nodeConfig == "" {
+			flaggy.ShowHelpAndExit("--node-config is required with --from-version")
+		}
+		c.result.From = c.fromVersion
+		if from, err = c.renderVersion(log, c.fromVersion); err != nil {
+			return err
+		}
+		c.result.To = c.toVersion
+		if c.toVersion == "" {
+			c.result.To = "current"
+			to, err = renderConfigs(context.Background(), log, "file://"+c.nodeConfig)
+		} else {
+			to, err = c.renderVersion(log, c.toVersion)
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		if c.from == "" {
+			flaggy.ShowHelpAndExit("--from or --from-version is required")
+		}
+		c.result.From = c.from
+		c.result.To = c.to
+		if from, err = loadManifest(c.from); err != nil {
+			return err
+		}
+		if to, err = loadManifest(c.to); err != nil {
+			return err
+		}
+	}
+	c.result.Files = manifest.Diff(from, to)
+	log.Info("Compared rendered files", zap.Int("files", len(to.Files)), zap.Int("differences", len(c.result.Files)))
+	if opts.Output == cli.OutputText {
+		// the result already contains the differences when it is written as JSON
+		return writeDiff(os.Stdout, c.result.Files)
+	}
+	return nil
+}
+
+// renderVersion returns the configs that a version of nodeadm renders for the
+// NodeConfig, which are read from the golden set of the version when it has
+// one, or rendered by running the version otherwise.
+func (c *diffCmd) renderVersion(log *zap.Logger, version string) (*manifest.Manifest, error) {
+	goldenPath := filepath.Join(c.versionsDir, version+".json")
+	if data, err := os.ReadFile(goldenPath); err == nil {
+		log.Info("Reading configs rendered by version..", zap.String("version", version), zap.String("path", goldenPath))
+		return parseRenderResult(version, data)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	binaryPath := filepath.Join(c.versionsDir, version, "nodeadm")
+	if _, err := os.Stat(binaryPath); err != nil {
+		return nil, fmt.Errorf("version %s has neither configs at %s nor nodeadm at %s", version, goldenPath, binaryPath)
+	}
+	log.Info("Rendering configs with version..", zap.String("version", version), zap.String("path", binaryPath))
+	var stdout bytes.Buffer
+	cmd := exec.Command(binaryPath, "config", "render", "--node-config", c.nodeConfig, "--output", string(cli.OutputJSON))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to render configs with version %s: %w", version, err)
+	}
+	return parseRenderResult(version, stdout.Bytes())
+}
+
+// parseRenderResult returns the configs of the JSON result of
+// `nodeadm config render`.
+func parseRenderResult(version string, data []byte) (*manifest.Manifest, error) {
+	var result struct {
+		Details manifest.Manifest `json:"details"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse configs rendered by version %s: %w", version, err)
+	}
+	return &result.Details, nil
+}
+
+// loadManifest loads a manifest that must exist, since manifest.Load treats a
+// missing manifest as one with no files.
+func loadManifest(path string) (*manifest.Manifest, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return manifest.Load(path)
+}
+
+func writeDiff(w io.Writer, diffs []manifest.FileDiff) error {
+	for _, diff := range diffs {
+		if _, err := fmt.Fprintf(w, "%s %s\n", diff.Status, diff.Path); err != nil {
+			return err
+		}
+		for _, change := range diff.Changes {
+			var line string
+			switch {
+			case change.Key != "":
+				line = fmt.Sprintf("  %s: %s -> %s", change.Key, formatValue(change.From), formatValue(change.To))
+			case change.From != "":
+				line = "  - " + change.From
+			default:
+				line = "  + " + change.To
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/pkg/bootstrap"
+)
+
+// the names of the rendered configs, which are compared by their extension
+const (
+	renderedKubeletConfigName    = "kubelet/config.json"
+	renderedContainerdConfigName = "containerd/config.toml"
+	renderedFilePerm             = 0644
+)
+
+type renderCmd struct {
+	cmd        *flaggy.Subcommand
+	nodeConfig string
+	result     manifest.Manifest
+}
+
+func NewRenderCommand() cli.Command {
+	cmd := renderCmd{}
+	cmd.cmd = flaggy.NewSubcommand("render")
+	cmd.cmd.String(&cmd.nodeConfig, "n", "node-config", "path of the NodeConfig to render, instead of the config source.")
+	cmd.cmd.Description = "Render the configs of kubelet and containerd for a NodeConfig on this node"
+	return &cmd
+}
+
+func (c *renderCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *renderCmd) Result() any {
+	return &c.result
+}
+
+func (c *renderCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	source := opts.ConfigSource
+	if c.nodeConfig != "" {
+		source = "file://" + c.nodeConfig
+	}
+	rendered, err := renderConfigs(context.Background(), log, source)
+	if err != nil {
+		return err
+	}
+	c.result = *rendered
+	if opts.Output == cli.OutputText {
+		// the result already contains the configs when it is written as JSON
+		for _, file := range rendered.Files {
+			if _, err := fmt.Fprintf(os.Stdout, "# %s\n%s\n", file.Path, file.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderConfigs renders the configs of kubelet and containerd that init
+// writes for a NodeConfig, which is enriched with the details of this node
+// like init does.
+func renderConfigs(ctx context.Context, log *zap.Logger, source string) (*manifest.Manifest, error) {
+	log.Info("Loading configuration..", zap.String("source", source))
+	nodeConfig, err := bootstrap.LoadConfig(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := nodeConfig.Enrich(ctx, log); err != nil {
+		return nil, err
+	}
+	if err := nodeConfig.Validate(); err != nil {
+		return nil, err
+	}
+	kubeletConfig, err := nodeConfig.KubeletConfig()
+	if err != nil {
+		return nil, err
+	}
+	containerdConfig, err := nodeConfig.ContainerdConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &manifest.Manifest{Files: []manifest.ManagedFile{
+		manifest.NewManagedFile(renderedContainerdConfigName, renderedFilePerm, containerdConfig),
+		manifest.NewManagedFile(renderedKubeletConfigName, renderedFilePerm, kubeletConfig),
+	}}, nil
+}
@@ -7,5 +7,7 @@ import (
 func NewConfigCommand() cli.Command {
 	container := cli.NewCommandContainer("config", "Manage configuration")
 	container.AddCommand(NewCheckCommand())
+	container.AddCommand(NewDiffCommand())
+	container.AddCommand(NewRenderCommand())
 	return container.AsCommand()
 }
@@ -0,0 +1,108 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/soci"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+func NewRefreshCommand() cli.Command {
+	cmd := refreshCmd{}
+	cmd.cmd = flaggy.NewSubcommand("refresh")
+	cmd.cmd.Description = "Refresh the ECR credentials used by the soci-snapshotter and the registry credentials of containerd"
+	return &cmd
+}
+
+type refreshCmd struct {
+	cmd    *flaggy.Subcommand
+	result refreshResult
+}
+
+type refreshResult struct {
+	Path      string `json:"path"`
+	Refreshed bool   `json:"refreshed"`
+	// ContainerdRestarted is whether containerd was restarted because the
+	// secrets of its registry credentials changed
+	ContainerdRestarted bool `json:"containerdRestarted"`
+}
+
+func (c *refreshCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *refreshCmd) Result() any {
+	return &c.result
+}
+
+func (c *refreshCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+	c.result.Path = soci.DockerConfigPath
+	sociCached, err := util.IsFilePathExists(soci.DockerConfigPath)
+	if err != nil {
+		return err
+	}
+	registryCredentialsConfigured, err := util.IsFilePathExists(containerd.RegistryCredentialsStatePath)
+	if err != nil {
+		return err
+	}
+	if !sociCached && !registryCredentialsConfigured {
+		log.Info("No credentials are cached, fast container image pull and registry credentials are not enabled")
+		return nil
+	}
+	if sociCached {
+		kubeletVersion, err := kubelet.GetKubeletVersion()
+		if err != nil {
+			return err
+		}
+		log.Info("Refreshing credentials..", zap.String("path", soci.DockerConfigPath))
+		if err := soci.RefreshCredentials(kubeletVersion); err != nil {
+			return err
+		}
+	}
+	if registryCredentialsConfigured {
+		log.Info("Refreshing registry credentials..", zap.String("path", containerd.RegistryCredentialsStatePath))
+		changed, err := containerd.RefreshRegistryCredentials(context.Background())
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := restartContainerd(log); err != nil {
+				return err
+			}
+			c.result.ContainerdRestarted = true
+		}
+	}
+	// the credentials are expected to change, so they are not drift
+	if err := manifest.RecordWrittenFiles(); err != nil {
+		return err
+	}
+	c.result.Refreshed = true
+	log.Info("Refreshed credentials")
+	return nil
+}
+
+// restartContainerd restarts containerd to load the registry credentials,
+// which does not affect the running containers.
+func restartContainerd(log *zap.Logger) error {
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+	log.Info("Restarting daemon to load changed registry credentials..", zap.String("name", containerd.ContainerdDaemonName))
+	return daemonManager.RestartDaemon(containerd.ContainerdDaemonName)
+}
@@ -0,0 +1,12 @@
+package credentials
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewCredentialsCommand() cli.Command {
+	container := cli.NewCommandContainer("credentials", "Manage the credentials cached on the node")
+	container.AddCommand(NewRefreshCommand())
+	container.AddCommand(NewTokenCommand())
+	return container.AsCommand()
+}
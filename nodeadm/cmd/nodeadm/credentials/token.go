@@ -0,0 +1,95 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthenticationv1beta1 "k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/token"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewTokenCommand() cli.Command {
+	cmd := tokenCmd{
+		opts: token.Options{
+			LeadTime:    token.DefaultLeadTime,
+			MaxAttempts: token.DefaultMaxAttempts,
+		},
+	}
+	cmd.cmd = flaggy.NewSubcommand("token")
+	cmd.cmd.String(&cmd.opts.ClusterName, "c", "cluster-name", "name of the cluster, or its ID for a local cluster on an Outpost.")
+	cmd.cmd.String(&cmd.opts.Region, "r", "region", "region of the cluster.")
+	cmd.cmd.Duration(&cmd.opts.LeadTime, "l", "lead-time", "how long before the cached token expires that a new token is pre-signed.")
+	cmd.cmd.Int(&cmd.opts.MaxAttempts, "m", "max-attempts", "number of attempts to pre-sign a token.")
+	cmd.cmd.Description = "Write the cached token that kubelet uses to authenticate to the cluster as an ExecCredential"
+	return &cmd
+}
+
+type tokenCmd struct {
+	cmd    *flaggy.Subcommand
+	opts   token.Options
+	result tokenResult
+}
+
+type tokenResult struct {
+	Path       string `json:"path"`
+	Cached     bool   `json:"cached"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+func (c *tokenCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *tokenCmd) Result() any {
+	return &c.result
+}
+
+func (c *tokenCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.opts.ClusterName == "" || c.opts.Region == "" {
+		flaggy.ShowHelpAndExit("--cluster-name and --region are required")
+	}
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+	c.result.Path = token.CachePath
+	t, cached, err := token.Get(context.Background(), c.opts)
+	if err != nil {
+		return err
+	}
+	c.result.Cached = cached
+	c.result.Expiration = t.Expiration.Format(metav1.RFC3339Micro)
+	if !cached {
+		log.Info("Pre-signed token", zap.String("path", token.CachePath), zap.Time("expiration", t.Expiration))
+	}
+	if opts.Output == cli.OutputJSON {
+		// the result takes the place of the ExecCredential on stdout
+		return nil
+	}
+	expiration := metav1.NewTime(t.Expiration)
+	credential := clientauthenticationv1beta1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clientauthenticationv1beta1.SchemeGroupVersion.String(),
+			Kind:       "ExecCredential",
+		},
+		Status: &clientauthenticationv1beta1.ExecCredentialStatus{
+			ExpirationTimestamp: &expiration,
+			Token:               t.Token,
+		},
+	}
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
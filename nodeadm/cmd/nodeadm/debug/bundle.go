@@ -0,0 +1,150 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/bundle"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+const bundlePerm = 0600
+
+func NewBundleCommand() cli.Command {
+	cmd := bundleCmd{
+		outputDir:            "/var/log",
+		serverSideEncryption: string(types.ServerSideEncryptionAes256),
+	}
+	cmd.cmd = flaggy.NewSubcommand("bundle")
+	cmd.cmd.String(&cmd.outputDir, "o", "output-dir", "directory the bundle is written to.")
+	cmd.cmd.String(&cmd.s3Bucket, "b", "s3-bucket", "S3 bucket the bundle is uploaded to.")
+	cmd.cmd.String(&cmd.s3Prefix, "p", "s3-prefix", "prefix of the S3 object key the bundle is uploaded to.")
+	cmd.cmd.String(&cmd.serverSideEncryption, "", "sse", "server-side encryption of the uploaded bundle, either AES256 or aws:kms.")
+	cmd.cmd.String(&cmd.kmsKeyID, "", "sse-kms-key-id", "KMS key used to encrypt the uploaded bundle when --sse is aws:kms.")
+	cmd.cmd.Bool(&cmd.skipIMDS, "", "skip-imds", "do not collect instance metadata, such as on hybrid nodes.")
+	cmd.cmd.Description = "Collect logs and configuration from the node into a tarball for troubleshooting"
+	return &cmd
+}
+
+type bundleCmd struct {
+	cmd                  *flaggy.Subcommand
+	outputDir            string
+	s3Bucket             string
+	s3Prefix             string
+	serverSideEncryption string
+	kmsKeyID             string
+	skipIMDS             bool
+	result               bundleResult
+}
+
+type bundleResult struct {
+	Path  string `json:"path,omitempty"`
+	S3URI string `json:"s3Uri,omitempty"`
+}
+
+func (c *bundleCmd) Result() any {
+	return &c.result
+}
+
+func (c *bundleCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *bundleCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+	sse := types.ServerSideEncryption(c.serverSideEncryption)
+	if sse != types.ServerSideEncryptionAes256 && sse != types.ServerSideEncryptionAwsKms {
+		flaggy.ShowHelpAndExit(fmt.Sprintf("--sse must be one of %v", []types.ServerSideEncryption{types.ServerSideEncryptionAes256, types.ServerSideEncryptionAwsKms}))
+	}
+
+	ctx := context.Background()
+	var imdsClient *imds.Client
+	if !c.skipIMDS {
+		imdsClient = imds.New(imds.Options{})
+	}
+
+	bundleName := fmt.Sprintf("eks_%s_%s.tar.gz", getNodeIdentifier(ctx, imdsClient), time.Now().UTC().Format("2006-01-02_1504-UTC"))
+	bundlePath := filepath.Join(c.outputDir, bundleName)
+	if err := os.MkdirAll(c.outputDir, 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, bundlePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	log.Info("Collecting diagnostics..", zap.String("path", bundlePath))
+	b := bundle.New(file)
+	if err := bundle.Collect(ctx, b, imdsClient); err != nil {
+		return err
+	}
+	if err := b.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	c.result.Path = bundlePath
+	log.Info("Wrote bundle", zap.String("path", bundlePath))
+
+	if c.s3Bucket == "" {
+		return nil
+	}
+	var awsConfigOpts []func(*config.LoadOptions) error
+	if imdsClient != nil {
+		awsConfigOpts = append(awsConfigOpts, config.WithEC2IMDSRegion(func(o *config.UseEC2IMDSRegion) {
+			o.Client = imdsClient
+		}))
+	}
+	awsConfig, err := awsconfig.Load(ctx, awsConfigOpts...)
+	if err != nil {
+		return err
+	}
+	key := path.Join(c.s3Prefix, bundleName)
+	log.Info("Uploading bundle..", zap.String("bucket", c.s3Bucket), zap.String("key", key), zap.String("sse", c.serverSideEncryption))
+	if err := bundle.Upload(ctx, s3.NewFromConfig(awsConfig), bundlePath, bundle.UploadOptions{
+		Bucket:               c.s3Bucket,
+		Key:                  key,
+		ServerSideEncryption: sse,
+		KMSKeyID:             c.kmsKeyID,
+	}); err != nil {
+		return err
+	}
+	c.result.S3URI = fmt.Sprintf("s3://%s/%s", c.s3Bucket, key)
+	log.Info("Uploaded bundle", zap.String("uri", c.result.S3URI))
+	return nil
+}
+
+// getNodeIdentifier returns the ID of the instance for the name of the
+// bundle, falling back to the hostname when the instance metadata service is
+// not available.
+func getNodeIdentifier(ctx context.Context, imdsClient *imds.Client) string {
+	if imdsClient != nil {
+		if res, err := imdsClient.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{}); err == nil {
+			return res.InstanceID
+		}
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return "unknown"
+}
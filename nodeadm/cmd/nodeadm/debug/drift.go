@@ -0,0 +1,84 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/offline"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/drift"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+)
+
+func NewDriftCommand() cli.Command {
+	cmd := driftCmd{}
+	cmd.cmd = flaggy.NewSubcommand("drift")
+	cmd.cmd.String(&cmd.releaseManifest, "m", "release-manifest", "release of the AMI the node is compared against, such as ssm:///aws/service/eks/optimized-ami/1.33/amazon-linux-2023/x86_64/standard/recommended.")
+	cmd.cmd.Description = "Report the components of the node that drifted from the configuration and the release of the AMI"
+	return &cmd
+}
+
+type driftCmd struct {
+	cmd             *flaggy.Subcommand
+	releaseManifest string
+	result          driftResult
+}
+
+type driftResult struct {
+	Drifted    bool          `json:"drifted"`
+	Components []drift.Drift `json:"components"`
+}
+
+func (c *driftCmd) Result() any {
+	return &c.result
+}
+
+func (c *driftCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *driftCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	log.Info("Loading configuration..", zap.String("source", opts.ConfigSource))
+	provider, err := configprovider.BuildConfigProvider(opts.ConfigSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+	if nodeConfig.Spec.Offline {
+		log.Info("Offline mode is enabled, AWS APIs will not be called")
+		offline.Enable()
+	}
+	var release *drift.ReleaseManifest
+	if c.releaseManifest != "" {
+		log.Info("Loading release manifest..", zap.String("source", c.releaseManifest))
+		if release, err = drift.LoadReleaseManifest(ctx, c.releaseManifest); err != nil {
+			return err
+		}
+	}
+	log.Info("Collecting state of node..")
+	node, err := drift.GetNode(ctx, nodeConfig)
+	if err != nil {
+		return err
+	}
+	files, err := manifest.Load(manifest.ManifestPath)
+	if err != nil {
+		return err
+	}
+	drifts, err := drift.Detect(nodeConfig, release, node, files)
+	if err != nil {
+		return err
+	}
+	for _, d := range drifts {
+		log.Warn("Component drifted", zap.String("component", d.Component), zap.String("expected", d.Expected), zap.String("actual", d.Actual), zap.String("source", d.Source))
+	}
+	c.result.Drifted = len(drifts) > 0
+	c.result.Components = drifts
+	return nil
+}
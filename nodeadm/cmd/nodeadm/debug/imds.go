@@ -0,0 +1,56 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	imdsextra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewIMDSCommand() cli.Command {
+	cmd := imdsCmd{}
+	cmd.cmd = flaggy.NewSubcommand("imds")
+	cmd.cmd.Description = "Print the instance metadata that nodeadm reads, such as the identity document, network interfaces and tags"
+	return &cmd
+}
+
+type imdsCmd struct {
+	cmd    *flaggy.Subcommand
+	result *imdsextra.Snapshot
+}
+
+func (c *imdsCmd) Result() any {
+	return c.result
+}
+
+func (c *imdsCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *imdsCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	log.Info("Reading instance metadata..")
+	// the default retries of the SDK, rather than those of bootstrap, so that
+	// the command does not wait for the service on hosts that are not EC2
+	// instances
+	c.result = imdsextra.GetSnapshot(context.Background(), imds.New(imds.Options{}))
+	for path, err := range c.result.Errors {
+		log.Warn("Failed to read instance metadata", zap.String("path", path), zap.String("error", err))
+	}
+	if opts.Output == cli.OutputText {
+		// the result already contains the snapshot when it is written as JSON
+		data, err := json.MarshalIndent(c.result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(data))
+		return err
+	}
+	return nil
+}
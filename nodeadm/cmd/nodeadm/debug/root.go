@@ -0,0 +1,14 @@
+package debug
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewDebugCommand() cli.Command {
+	container := cli.NewCommandContainer("debug", "Collect diagnostics about the node")
+	container.AddCommand(NewBundleCommand())
+	container.AddCommand(NewDriftCommand())
+	container.AddCommand(NewIMDSCommand())
+	container.AddCommand(NewStreamLogsCommand())
+	return container.AsCommand()
+}
@@ -0,0 +1,93 @@
+package debug
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/logstream"
+)
+
+func NewStreamLogsCommand() cli.Command {
+	cmd := streamLogsCmd{
+		duration: logstream.DefaultDuration,
+	}
+	cmd.cmd = flaggy.NewSubcommand("stream-logs")
+	cmd.cmd.String(&cmd.region, "r", "region", "region of the CloudWatch Logs endpoint.")
+	cmd.cmd.String(&cmd.logGroup, "g", "log-group", "log group that the journals are streamed to.")
+	cmd.cmd.String(&cmd.instanceID, "i", "instance-id", "ID of the instance, which prefixes the name of the log stream of each unit.")
+	cmd.cmd.StringSlice(&cmd.units, "u", "units", "systemd units whose journals are streamed, such as kubelet.")
+	cmd.cmd.Duration(&cmd.duration, "d", "duration", "how long the journals are streamed for after the node boots.")
+	cmd.cmd.Description = "Stream the journals of units to CloudWatch Logs for a while after the node boots"
+	return &cmd
+}
+
+type streamLogsCmd struct {
+	cmd        *flaggy.Subcommand
+	region     string
+	logGroup   string
+	instanceID string
+	units      []string
+	duration   time.Duration
+	result     streamLogsResult
+}
+
+type streamLogsResult struct {
+	LogGroup string `json:"logGroup"`
+	// Streams are the log streams of the units
+	Streams []string `json:"streams"`
+}
+
+func (c *streamLogsCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *streamLogsCmd) Result() any {
+	return &c.result
+}
+
+func (c *streamLogsCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.region == "" || c.logGroup == "" || c.instanceID == "" {
+		flaggy.ShowHelpAndExit("--region, --log-group and --instance-id are required")
+	}
+	if len(c.units) == 0 {
+		flaggy.ShowHelpAndExit("--units is required")
+	}
+	bootTime, err := logstream.GetBootTime()
+	if err != nil {
+		return err
+	}
+	deadline := bootTime.Add(c.duration)
+	if time.Now().After(deadline) {
+		log.Info("Journals are no longer streamed", zap.Time("deadline", deadline))
+		return nil
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	awsConfig, err := awsconfig.Load(ctx, config.WithRegion(c.region))
+	if err != nil {
+		return err
+	}
+	client := logs.NewClient(ctx, awsConfig)
+	c.result.LogGroup = c.logGroup
+	c.result.Streams = []string{}
+	streams := map[string]*logstream.Stream{}
+	for _, unit := range c.units {
+		name := c.instanceID + "/" + unit
+		stream, err := logstream.NewStream(ctx, client, c.logGroup, name)
+		if err != nil {
+			return err
+		}
+		streams[logstream.GetUnitName(unit)] = stream
+		c.result.Streams = append(c.result.Streams, name)
+	}
+	log.Info("Streaming journals to CloudWatch Logs..", zap.String("logGroup", c.logGroup), zap.Strings("units", c.units), zap.Time("until", deadline))
+	return logstream.FollowJournal(ctx, streams)
+}
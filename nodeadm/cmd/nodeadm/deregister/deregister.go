@@ -0,0 +1,118 @@
+package deregister
+
+import (
+	"context"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+func NewDeregisterCommand() cli.Command {
+	cmd := deregisterCmd{
+		kubeconfig:   kubelet.KubeconfigPath,
+		drainTimeout: deregister.DefaultDrainTimeout,
+	}
+	cmd.cmd = flaggy.NewSubcommand("deregister")
+	cmd.cmd.String(&cmd.nodeName, "n", "node-name", "name of the Node object to remove from the cluster.")
+	cmd.cmd.Duration(&cmd.drainTimeout, "t", "drain-timeout", "maximum amount of time to wait for pods to be evicted from the node.")
+	cmd.cmd.String(&cmd.kubeconfig, "k", "kubeconfig", "kubeconfig used to authenticate to the cluster.")
+	cmd.cmd.Bool(&cmd.force, "f", "force", "deregister the node even if the system is rebooting.")
+	cmd.cmd.Bool(&cmd.ifEnabled, "", "if-enabled", "only deregister the node if deregistration on shutdown is still enabled.")
+	cmd.cmd.Description = "Cordon, drain, and delete this node from an EKS cluster"
+	return &cmd
+}
+
+type deregisterCmd struct {
+	cmd          *flaggy.Subcommand
+	nodeName     string
+	drainTimeout time.Duration
+	kubeconfig   string
+	force        bool
+	ifEnabled    bool
+	result       deregisterResult
+}
+
+type deregisterResult struct {
+	Node string `json:"node"`
+	// Skipped is whether the node was left in the cluster because the system
+	// is rebooting, or because deregistration on shutdown was disabled
+	Skipped      bool `json:"skipped"`
+	Cordoned     bool `json:"cordoned"`
+	Drained      bool `json:"drained"`
+	Deregistered bool `json:"deregistered"`
+}
+
+func (c *deregisterCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *deregisterCmd) Result() any {
+	return &c.result
+}
+
+func (c *deregisterCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.nodeName == "" {
+		flaggy.ShowHelpAndExit("--node-name is required")
+	}
+	c.result.Node = c.nodeName
+	if c.ifEnabled {
+		enabled, err := deregister.IsEnabled()
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			log.Info("Deregistration on shutdown is disabled, not deregistering node", zap.String("node", c.nodeName))
+			c.result.Skipped = true
+			return nil
+		}
+	}
+	if !c.force {
+		rebooting, err := deregister.IsRebooting()
+		if err != nil {
+			return err
+		}
+		if rebooting {
+			log.Info("System is rebooting, not deregistering node", zap.String("node", c.nodeName))
+			c.result.Skipped = true
+			return nil
+		}
+	}
+
+	client, err := node.NewClient(c.kubeconfig)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	nodeField := zap.String("node", c.nodeName)
+
+	log.Info("Cordoning node..", nodeField)
+	if err := node.Cordon(ctx, client, c.nodeName); err != nil {
+		log.Warn("Failed to cordon node", nodeField, zap.Error(err))
+	} else {
+		c.result.Cordoned = true
+	}
+
+	log.Info("Draining node..", nodeField, zap.Duration("timeout", c.drainTimeout))
+	drainCtx, cancel := context.WithTimeout(ctx, c.drainTimeout)
+	defer cancel()
+	if err := node.Drain(drainCtx, client, c.nodeName); err != nil {
+		// the node is going away regardless, so continue and delete it
+		log.Warn("Failed to drain node", nodeField, zap.Error(err))
+	} else {
+		c.result.Drained = true
+	}
+
+	log.Info("Deleting node..", nodeField)
+	if err := node.Delete(ctx, client, c.nodeName); err != nil {
+		return err
+	}
+	c.result.Deregistered = true
+	log.Info("Deregistered node", nodeField)
+	return nil
+}
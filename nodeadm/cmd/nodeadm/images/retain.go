@@ -0,0 +1,62 @@
+package images
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/imageretention"
+)
+
+func NewRetainCommand() cli.Command {
+	cmd := retainCmd{
+		interval: imageretention.DefaultInterval,
+	}
+	cmd.cmd = flaggy.NewSubcommand("retain")
+	cmd.cmd.StringSlice(&cmd.pinnedImages, "p", "pinned-image", "reference of an image that is never garbage collected by kubelet.")
+	cmd.cmd.Int(&cmd.keepLast, "k", "keep-last", "number of the most recently pulled images that are never garbage collected by kubelet.")
+	cmd.cmd.Duration(&cmd.interval, "i", "interval", "how often to update the images that are retained.")
+	cmd.cmd.Description = "Protect images from the image garbage collection of kubelet"
+	return &cmd
+}
+
+type retainCmd struct {
+	cmd          *flaggy.Subcommand
+	pinnedImages []string
+	keepLast     int
+	interval     time.Duration
+}
+
+func (c *retainCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *retainCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.keepLast < 0 {
+		flaggy.ShowHelpAndExit("--keep-last must not be negative")
+	}
+
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	config := imageretention.Config{
+		PinnedImages: c.pinnedImages,
+		KeepLast:     c.keepLast,
+	}
+	log.Info("Retaining images..", zap.Strings("pinnedImages", config.PinnedImages), zap.Int("keepLast", config.KeepLast))
+	return imageretention.NewRetainer(config).Run(ctx, c.interval)
+}
@@ -0,0 +1,11 @@
+package images
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewImagesCommand() cli.Command {
+	container := cli.NewCommandContainer("images", "Manage the container images of the node")
+	container.AddCommand(NewRetainCommand())
+	return container.AsCommand()
+}
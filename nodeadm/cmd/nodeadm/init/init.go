@@ -2,28 +2,17 @@ package init
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
-	"k8s.io/utils/strings/slices"
 
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
-)
-
-const (
-	configPhase = "config"
-	runPhase    = "run"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/logstream"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/tracing"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/pkg/bootstrap"
 )
 
 func NewInitCommand() cli.Command {
@@ -31,6 +20,10 @@ func NewInitCommand() cli.Command {
 	init.cmd = flaggy.NewSubcommand("init")
 	init.cmd.StringSlice(&init.daemons, "d", "daemon", "specify one or more of `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
 	init.cmd.StringSlice(&init.skipPhases, "s", "skip", "phases of the bootstrap you want to skip")
+	init.cmd.Bool(&init.force, "", "force", "set up every system aspect again, including those that converged during an earlier init since the last boot.")
+	init.cmd.Bool(&init.progress, "", "progress", "stream a record to stderr when each phase begins, ends or is skipped, in the format of --output.")
+	init.progressFD = progressDefaultFD
+	init.cmd.Int(&init.progressFD, "", "progress-fd", "file descriptor that --progress streams records to, which must already be open.")
 	init.cmd.Description = "Initialize this instance as a node in an EKS cluster"
 	return &init
 }
@@ -39,14 +32,106 @@ type initCmd struct {
 	cmd        *flaggy.Subcommand
 	skipPhases []string
 	daemons    []string
+	force      bool
+	progress   bool
+	progressFD int
+	// result is the structured result of init, describing the changes it
+	// applied to the node.
+	result bootstrap.Result
+	// config is the loaded configuration, whose tracing options the trace is
+	// exported with
+	config *bootstrap.Config
 }
 
 func (c *initCmd) Flaggy() *flaggy.Subcommand {
 	return c.cmd
 }
 
+func (c *initCmd) Result() any {
+	return &c.result
+}
+
 func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	// the logs are buffered until the config tells where they are streamed
+	log = logstream.Tee(log)
+	zap.ReplaceGlobals(log)
+	ctx, span := tracing.Start(context.Background(), "init")
+	err := c.run(ctx, log, opts)
+	span.End(err)
+	// the trace is exported even when init failed, since those are the
+	// launches that most need to be analyzed
+	if c.config != nil {
+		if exportErr := c.config.ExportTrace(context.Background()); exportErr != nil {
+			log.Warn("Failed to export trace", zap.Error(exportErr))
+		}
+	}
+	// and so are the logs
+	if closeErr := logstream.Close(context.Background(), err); closeErr != nil {
+		log.Warn("Failed to stream logs to CloudWatch Logs", zap.Error(closeErr))
+	}
+	return err
+}
+
+const (
+	// phaseLoadConfig and phaseEnrichConfig precede the phases of the
+	// bootstrap, and are not skipped.
+	phaseLoadConfig   = "load-config"
+	phaseEnrichConfig = "enrich-config"
+)
+
+// progressDefaultFD is stderr, since stdout is reserved for the result of
+// --output json.
+const progressDefaultFD = 2
+
+// openProgressFD returns the file of a descriptor that was opened by the
+// caller of nodeadm, such as with `3>/run/progress` in a shell.
+func openProgressFD(fd int, output cli.OutputFormat) (*os.File, error) {
+	if fd == 1 && output == cli.OutputJSON {
+		return nil, fmt.Errorf("--progress-fd cannot be stdout with --output json, since the result is written to stdout")
+	}
+	if fd < 0 {
+		return nil, fmt.Errorf("invalid --progress-fd %d", fd)
+	}
+	f := os.NewFile(uintptr(fd), "progress")
+	if f == nil {
+		return nil, fmt.Errorf("invalid --progress-fd %d", fd)
+	}
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("--progress-fd %d is not open: %w", fd, err)
+	}
+	return f, nil
+}
+
+func (c *initCmd) run(ctx context.Context, log *zap.Logger, opts *cli.GlobalOptions) error {
 	start := time.Now()
+	report := func(bootstrap.PhaseEvent) {}
+	if c.progress {
+		w, err := openProgressFD(c.progressFD, opts.Output)
+		if err != nil {
+			return err
+		}
+		progress := cli.NewProgressWriter(w, opts.Output)
+		report = func(event bootstrap.PhaseEvent) {
+			record := cli.NewProgressRecord(event.Phase, cli.ProgressEvent(event.Type), event.Duration, event.Err)
+			if err := progress.Write(record); err != nil {
+				log.Warn("Failed to write progress", zap.Error(err))
+			}
+		}
+	}
+	// runPhase reports the progress of a phase of init that is not a phase of
+	// the bootstrap
+	runPhase := func(phase string, fn func() error) error {
+		phaseStart := time.Now()
+		report(bootstrap.PhaseEvent{Phase: phase, Type: bootstrap.PhaseBegin})
+		err := fn()
+		report(bootstrap.PhaseEvent{Phase: phase, Type: bootstrap.PhaseEnd, Duration: time.Since(phaseStart), Err: err})
+		return err
+	}
+	c.result.Phases = []string{}
+	defer func() {
+		// the duration of init includes loading the configuration
+		c.result.Duration = time.Since(start).String()
+	}()
 
 	log.Info("Checking user is root..")
 	root, err := cli.IsRunningAsRoot()
@@ -57,127 +142,48 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	log.Info("Loading configuration..", zap.String("configSource", opts.ConfigSource))
-	provider, err := configprovider.BuildConfigProvider(opts.ConfigSource)
-	if err != nil {
+	var nodeConfig *bootstrap.Config
+	err = runPhase(phaseLoadConfig, func() error {
+		_, span := tracing.Start(ctx, "load config", tracing.String("nodeadm.config_source", opts.ConfigSource))
+		var err error
+		nodeConfig, err = bootstrap.LoadConfig(opts.ConfigSource)
+		span.End(err)
 		return err
-	}
-	nodeConfig, err := provider.Provide()
+	})
 	if err != nil {
 		return err
 	}
-	log.Info("Loaded configuration", zap.Reflect("config", nodeConfig))
+	// redacted, since the logs of init may be streamed to CloudWatch Logs
+	log.Info("Loaded configuration", zap.Reflect("config", nodeConfig.Redacted()))
+	c.config = nodeConfig
 
 	log.Info("Enriching configuration..")
-	if err := enrichConfig(log, nodeConfig); err != nil {
-		return err
-	}
-
-	zap.L().Info("Validating configuration..")
-	if err := api.ValidateNodeConfig(nodeConfig); err != nil {
+	err = runPhase(phaseEnrichConfig, func() error {
+		enrichCtx, span := tracing.Start(ctx, "enrich config")
+		err := nodeConfig.Enrich(enrichCtx, log)
+		span.End(err)
 		return err
-	}
-
-	log.Info("Creating daemon manager..")
-	daemonManager, err := daemon.NewDaemonManager()
+	})
 	if err != nil {
 		return err
 	}
-	defer daemonManager.Close()
-
-	aspects := []system.SystemAspect{
-		system.NewLocalDiskAspect(),
-		system.NewNetworkingAspect(),
-	}
-
-	daemons := []daemon.Daemon{
-		containerd.NewContainerdDaemon(daemonManager),
-		kubelet.NewKubeletDaemon(daemonManager),
-	}
-
-	if !slices.Contains(c.skipPhases, configPhase) {
-		log.Info("Configuring daemons...")
-		for _, daemon := range daemons {
-			if len(c.daemons) > 0 && !slices.Contains(c.daemons, daemon.Name()) {
-				continue
-			}
-			nameField := zap.String("name", daemon.Name())
-
-			log.Info("Configuring daemon...", nameField)
-			if err := daemon.Configure(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Configured daemon", nameField)
-		}
+	// a failure to stream the logs does not fail the bootstrap
+	if err := nodeConfig.StreamLogs(ctx); err != nil {
+		log.Warn("Failed to stream logs to CloudWatch Logs", zap.Error(err))
 	}
 
-	if !slices.Contains(c.skipPhases, runPhase) {
-		log.Info("Setting up system aspects...")
-		for _, aspect := range aspects {
-			nameField := zap.String("name", aspect.Name())
-			log.Info("Setting up system aspect..", nameField)
-			if err := aspect.Setup(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Set up system aspect", nameField)
-		}
-		for _, daemon := range daemons {
-			if len(c.daemons) > 0 && !slices.Contains(c.daemons, daemon.Name()) {
-				continue
-			}
-
-			nameField := zap.String("name", daemon.Name())
-
-			log.Info("Ensuring daemon is running..", nameField)
-			if err := daemon.EnsureRunning(); err != nil {
-				return err
-			}
-			log.Info("Daemon is running", nameField)
-
-			log.Info("Running post-launch tasks..", nameField)
-			if err := daemon.PostLaunch(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Finished post-launch tasks", nameField)
-		}
+	result, err := bootstrap.Init(ctx, log, nodeConfig, bootstrap.Options{
+		Daemons:    c.daemons,
+		SkipPhases: c.skipPhases,
+		Force:      c.force,
+		Progress:   report,
+	})
+	c.result = *result
+	if err != nil {
+		return err
 	}
 
 	log.Info("done!", zap.Duration("duration", time.Since(start)))
 
 	return nil
 }
-
-// Various initializations and verifications of the NodeConfig and
-// perform in-place updates when allowed by the user
-func enrichConfig(log *zap.Logger, cfg *api.NodeConfig) error {
-	log.Info("Fetching kubelet version..")
-	kubeletVersion, err := kubelet.GetKubeletVersion()
-	if err != nil {
-		return err
-	}
-	cfg.Status.KubeletVersion = kubeletVersion
-	log.Info("Fetched kubelet version", zap.String("version", kubeletVersion))
-	log.Info("Fetching instance details..")
-	awsConfig, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithClientLogMode(aws.LogRetries),
-		config.WithEC2IMDSRegion(func(o *config.UseEC2IMDSRegion) {
-			// Use our pre-configured IMDS client to avoid hitting common retry
-			// issues with the default config.
-			o.Client = imds.Client
-		}),
-	)
-	if err != nil {
-		return err
-	}
-	instanceDetails, err := api.GetInstanceDetails(context.TODO(), cfg.Spec.FeatureGates, ec2.NewFromConfig(awsConfig))
-	if err != nil {
-		return err
-	}
-	cfg.Status.Instance = *instanceDetails
-	log.Info("Instance details populated", zap.Reflect("details", instanceDetails))
-	log.Info("Fetching default options...")
-	cfg.Status.Defaults = api.DefaultOptions{
-		SandboxImage: "localhost/kubernetes/pause",
-	}
-	log.Info("Default options populated", zap.Reflect("defaults", cfg.Status.Defaults))
-	return nil
-}
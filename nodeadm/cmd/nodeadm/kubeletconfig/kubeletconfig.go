@@ -0,0 +1,79 @@
+package kubeletconfig
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubeletconfig"
+)
+
+func NewKubeletConfigCommand() cli.Command {
+	cmd := kubeletConfigCmd{
+		interval:           kubeletconfig.DefaultInterval,
+		minRestartInterval: kubeletconfig.DefaultMinRestartInterval,
+	}
+	cmd.cmd = flaggy.NewSubcommand("kubelet-config")
+	cmd.cmd.String(&cmd.source, "s", "source", "s3:// or https:// URL of a KubeletConfiguration overlay.")
+	cmd.cmd.Duration(&cmd.interval, "i", "interval", "how often to fetch the overlay.")
+	cmd.cmd.Duration(&cmd.minRestartInterval, "r", "min-restart-interval", "minimum amount of time between two restarts of kubelet for changes of the overlay.")
+	cmd.cmd.Description = "Keep a kubelet config overlay from a URL applied to this node"
+	return &cmd
+}
+
+type kubeletConfigCmd struct {
+	cmd                *flaggy.Subcommand
+	source             string
+	interval           time.Duration
+	minRestartInterval time.Duration
+	reconciler         *kubeletconfig.Reconciler
+}
+
+func (c *kubeletConfigCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *kubeletConfigCmd) Result() any {
+	if c.reconciler == nil {
+		return nil
+	}
+	status := c.reconciler.Status()
+	return &status
+}
+
+func (c *kubeletConfigCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.source == "" {
+		flaggy.ShowHelpAndExit("--source is required")
+	}
+
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c.reconciler = kubeletconfig.NewReconciler(daemonManager, kubeletconfig.Config{
+		Source:             c.source,
+		MinRestartInterval: c.minRestartInterval,
+	})
+	log.Info("Reconciling kubelet config overlay..", zap.String("source", c.source), zap.Duration("interval", c.interval), zap.Duration("minRestartInterval", c.minRestartInterval))
+	return c.reconciler.Run(ctx, c.interval)
+}
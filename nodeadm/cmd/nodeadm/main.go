@@ -1,11 +1,24 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/agent"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/config"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/credentials"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/debug"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/images"
 	initcmd "github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/init"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/kubeletconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/monitor"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/reset"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/terminationhandler"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/cmd/nodeadm/upgrade"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
 )
 
@@ -18,8 +31,18 @@ func main() {
 	opts := cli.NewGlobalOptions()
 
 	cmds := []cli.Command{
+		agent.NewAgentCommand(),
 		config.NewConfigCommand(),
+		credentials.NewCredentialsCommand(),
+		debug.NewDebugCommand(),
+		deregister.NewDeregisterCommand(),
+		images.NewImagesCommand(),
 		initcmd.NewInitCommand(),
+		kubeletconfig.NewKubeletConfigCommand(),
+		monitor.NewMonitorCommand(),
+		reset.NewResetCommand(),
+		terminationhandler.NewTerminationHandlerCommand(),
+		upgrade.NewUpgradeCommand(),
 	}
 
 	for _, cmd := range cmds {
@@ -27,12 +50,26 @@ func main() {
 	}
 	flaggy.Parse()
 
+	if opts.Output != cli.OutputText && opts.Output != cli.OutputJSON {
+		flaggy.ShowHelpAndExit(fmt.Sprintf("--output must be one of %v", []cli.OutputFormat{cli.OutputText, cli.OutputJSON}))
+	}
+
 	log := cli.NewLogger(opts)
 
 	for _, cmd := range cmds {
 		if cmd.Flaggy().Used {
 			err := cmd.Run(log, opts)
+			if opts.Output == cli.OutputJSON {
+				if writeErr := cli.WriteResult(os.Stdout, cli.NewResult(cmd, err)); writeErr != nil {
+					log.Error("Failed to write result", zap.Error(writeErr))
+				}
+			}
 			if err != nil {
+				if code := cli.ExitCode(err); code != 1 {
+					// the exit code classifies the cause of the failure
+					log.Error("Command failed", zap.Error(err), zap.Int("exitCode", code))
+					os.Exit(code)
+				}
 				log.Fatal("Command failed", zap.Error(err))
 			}
 			return
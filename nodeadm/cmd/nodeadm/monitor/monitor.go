@@ -0,0 +1,95 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/pressure"
+)
+
+func NewMonitorCommand() cli.Command {
+	cmd := monitorCmd{
+		kubeconfig:      kubelet.KubeconfigPath,
+		memoryThreshold: pressure.DefaultMemoryThreshold,
+		action:          string(api.PressureActionReport),
+		interval:        pressure.DefaultInterval,
+	}
+	cmd.cmd = flaggy.NewSubcommand("monitor")
+	cmd.cmd.String(&cmd.nodeName, "n", "node-name", "name of the Node object to report pressure on.")
+	cmd.cmd.String(&cmd.kubeconfig, "k", "kubeconfig", "kubeconfig used to authenticate to the cluster.")
+	cmd.cmd.Int(&cmd.memoryThreshold, "t", "memory-threshold", "percentage of time in which processes are stalled waiting for memory, above which the node is under pressure.")
+	cmd.cmd.String(&cmd.action, "a", "action", fmt.Sprintf("what to do while the node is under memory pressure, one of %v.", []api.PressureAction{api.PressureActionReport, api.PressureActionCordon, api.PressureActionEvict}))
+	cmd.cmd.Int(&cmd.metricsPort, "p", "metrics-port", "port on which to serve Prometheus metrics, which are not served when 0.")
+	cmd.cmd.Duration(&cmd.interval, "i", "interval", "how often to check the pressure of the node.")
+	cmd.cmd.Description = "Report memory pressure and OOM kills of this node to the cluster"
+	return &cmd
+}
+
+type monitorCmd struct {
+	cmd             *flaggy.Subcommand
+	nodeName        string
+	kubeconfig      string
+	memoryThreshold int
+	action          string
+	metricsPort     int
+	interval        time.Duration
+}
+
+func (c *monitorCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *monitorCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.nodeName == "" {
+		flaggy.ShowHelpAndExit("--node-name is required")
+	}
+	config := pressure.Config{
+		NodeName:        c.nodeName,
+		MemoryThreshold: c.memoryThreshold,
+		Action:          api.PressureAction(c.action),
+	}
+	switch config.Action {
+	case api.PressureActionReport, api.PressureActionCordon, api.PressureActionEvict:
+	default:
+		flaggy.ShowHelpAndExit(fmt.Sprintf("--action must be one of %v", []api.PressureAction{api.PressureActionReport, api.PressureActionCordon, api.PressureActionEvict}))
+	}
+
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	client, err := node.NewClient(c.kubeconfig)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	metrics := pressure.NewMetrics()
+	if c.metricsPort != 0 {
+		log.Info("Serving metrics..", zap.Int("port", c.metricsPort))
+		go func() {
+			if err := metrics.Serve(ctx, c.metricsPort); err != nil {
+				log.Error("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+	}
+
+	log.Info("Monitoring node pressure..", zap.String("node", c.nodeName), zap.Int("memoryThreshold", config.MemoryThreshold), zap.String("action", string(config.Action)))
+	return pressure.NewMonitor(client, config, metrics).Run(ctx, c.interval)
+}
@@ -0,0 +1,206 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/status"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/pkg/bootstrap"
+)
+
+const (
+	// nodeadmStateDir holds the manifest, the checkpoints and the caches of
+	// nodeadm.
+	nodeadmStateDir = "/var/lib/nodeadm"
+	cniStateDir     = "/var/lib/cni"
+	cniConfDir      = "/etc/cni/net.d"
+)
+
+func NewResetCommand() cli.Command {
+	cmd := resetCmd{
+		kubeconfig:   kubelet.KubeconfigPath,
+		drainTimeout: deregister.DefaultDrainTimeout,
+	}
+	cmd.cmd = flaggy.NewSubcommand("reset")
+	cmd.cmd.Bool(&cmd.deregister, "d", "deregister", "cordon, drain, and delete the Node object before resetting the node.")
+	cmd.cmd.String(&cmd.nodeName, "n", "node-name", "name of the Node object to remove from the cluster, required with --deregister.")
+	cmd.cmd.Duration(&cmd.drainTimeout, "t", "drain-timeout", "maximum amount of time to wait for pods to be evicted from the node.")
+	cmd.cmd.Description = "Stop the daemons and remove the configuration, CNI state, and certificates of this node, so that it can be initialized again"
+	return &cmd
+}
+
+type resetCmd struct {
+	cmd          *flaggy.Subcommand
+	deregister   bool
+	nodeName     string
+	drainTimeout time.Duration
+	kubeconfig   string
+	result       resetResult
+}
+
+type resetResult struct {
+	Node         string `json:"node,omitempty"`
+	Deregistered bool   `json:"deregistered"`
+	// StoppedDaemons are the daemons that were running when the node was reset
+	StoppedDaemons []string `json:"stoppedDaemons"`
+	RemovedPaths   []string `json:"removedPaths"`
+}
+
+func (c *resetCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *resetCmd) Result() any {
+	return &c.result
+}
+
+func (c *resetCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.deregister && c.nodeName == "" {
+		flaggy.ShowHelpAndExit("--node-name is required with --deregister")
+	}
+
+	log.Info("Checking user is root..")
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	if c.deregister {
+		// while kubelet is still running to terminate the evicted pods
+		if err := c.deregisterNode(log); err != nil {
+			return err
+		}
+	}
+	// otherwise the deregistration unit deletes the Node object as it is
+	// stopped
+	log.Info("Disabling node deregistration on shutdown..")
+	if err := deregister.Disable(); err != nil {
+		return err
+	}
+
+	log.Info("Creating daemon manager..")
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	// in the reverse order they are started by init, so that each daemon is
+	// stopped before the daemons it depends on
+	daemonNames := bootstrap.UnitDaemonNames()
+	slices.Reverse(daemonNames)
+	for _, name := range daemonNames {
+		status, err := daemonManager.GetDaemonStatus(name)
+		if err != nil {
+			return err
+		}
+		if status != daemon.DaemonStatusRunning {
+			continue
+		}
+		log.Info("Stopping daemon..", zap.String("name", name))
+		if err := daemonManager.StopDaemon(name); err != nil {
+			return err
+		}
+		c.result.StoppedDaemons = append(c.result.StoppedDaemons, name)
+	}
+
+	log.Info("Loading manifest of managed files..", zap.String("path", manifest.ManifestPath))
+	m, err := manifest.Load(manifest.ManifestPath)
+	if err != nil {
+		return err
+	}
+	paths := []string{kubelet.KubeconfigPath, kubelet.CertificateDir, cniStateDir, status.Path}
+	for _, file := range m.Files {
+		// the files that nodeadm rendered over, such as the configuration of
+		// containerd shipped with the AMI, are left in place
+		if !file.Created {
+			log.Info("Leaving file that nodeadm did not create", zap.String("path", file.Path))
+			continue
+		}
+		paths = append(paths, file.Path)
+	}
+	cniConfigs, err := filepath.Glob(filepath.Join(cniConfDir, "*"))
+	if err != nil {
+		return err
+	}
+	// the directory of the CNI configuration is left in place, since
+	// containerd watches it for the configuration of the next CNI plugin
+	paths = append(paths, cniConfigs...)
+	// after the manifest has been loaded from it
+	paths = append(paths, nodeadmStateDir)
+	for _, path := range paths {
+		removed, err := removePath(path)
+		if err != nil {
+			return err
+		}
+		if removed {
+			log.Info("Removed path", zap.String("path", path))
+			c.result.RemovedPaths = append(c.result.RemovedPaths, path)
+		}
+	}
+
+	// the drop-ins of units rendered by nodeadm may have been removed
+	log.Info("Reloading systemd units..")
+	if err := daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	log.Info("Reset node")
+	return nil
+}
+
+func (c *resetCmd) deregisterNode(log *zap.Logger) error {
+	c.result.Node = c.nodeName
+	client, err := node.NewClient(c.kubeconfig)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	nodeField := zap.String("node", c.nodeName)
+
+	log.Info("Cordoning node..", nodeField)
+	if err := node.Cordon(ctx, client, c.nodeName); err != nil {
+		log.Warn("Failed to cordon node", nodeField, zap.Error(err))
+	}
+
+	log.Info("Draining node..", nodeField, zap.Duration("timeout", c.drainTimeout))
+	drainCtx, cancel := context.WithTimeout(ctx, c.drainTimeout)
+	defer cancel()
+	if err := node.Drain(drainCtx, client, c.nodeName); err != nil {
+		// the node is leaving the cluster regardless, so continue and delete it
+		log.Warn("Failed to drain node", nodeField, zap.Error(err))
+	}
+
+	log.Info("Deleting node..", nodeField)
+	if err := node.Delete(ctx, client, c.nodeName); err != nil {
+		return err
+	}
+	c.result.Deregistered = true
+	log.Info("Deregistered node", nodeField)
+	return nil
+}
+
+// removePath removes the file or directory at the path, and returns whether
+// it existed.
+func removePath(path string) (bool, error) {
+	if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, os.RemoveAll(path)
+}
@@ -0,0 +1,86 @@
+package terminationhandler
+
+import (
+	"context"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/drain"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/termination"
+)
+
+func NewTerminationHandlerCommand() cli.Command {
+	cmd := terminationHandlerCmd{
+		kubeconfig:   kubelet.KubeconfigPath,
+		drainTimeout: deregister.DefaultDrainTimeout,
+		pollInterval: termination.DefaultPollInterval,
+	}
+	cmd.cmd = flaggy.NewSubcommand("termination-handler")
+	cmd.cmd.String(&cmd.nodeName, "n", "node-name", "name of the Node object to drain when the instance is terminated.")
+	cmd.cmd.StringSlice(&cmd.events, "e", "events", "events to drain the node on, of SpotInterruption, LifecycleTermination and ScheduledEvent.")
+	cmd.cmd.Duration(&cmd.drainTimeout, "t", "drain-timeout", "maximum amount of time to wait for pods to be evicted from the node.")
+	cmd.cmd.Duration(&cmd.pollInterval, "i", "poll-interval", "how often the instance metadata service is checked for the events.")
+	cmd.cmd.String(&cmd.kubeconfig, "k", "kubeconfig", "kubeconfig used to authenticate to the cluster.")
+	cmd.cmd.Description = "Wait for an event that terminates or stops the instance, then cordon and drain this node"
+	return &cmd
+}
+
+type terminationHandlerCmd struct {
+	cmd          *flaggy.Subcommand
+	nodeName     string
+	events       []string
+	drainTimeout time.Duration
+	pollInterval time.Duration
+	kubeconfig   string
+	result       terminationHandlerResult
+}
+
+type terminationHandlerResult struct {
+	Node string `json:"node"`
+	// Event is the event that terminates the instance, such as
+	// LifecycleTermination
+	Event  string `json:"event"`
+	Reason string `json:"reason"`
+	drain.Result
+}
+
+func (c *terminationHandlerCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *terminationHandlerCmd) Result() any {
+	return &c.result
+}
+
+func (c *terminationHandlerCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if c.nodeName == "" {
+		flaggy.ShowHelpAndExit("--node-name is required")
+	}
+	if len(c.events) == 0 {
+		flaggy.ShowHelpAndExit("--events is required")
+	}
+	c.result.Node = c.nodeName
+	ctx := context.Background()
+
+	var events []api.TerminationEvent
+	for _, event := range c.events {
+		events = append(events, api.TerminationEvent(event))
+	}
+	log.Info("Waiting for a termination event..", zap.Strings("events", c.events), zap.Duration("interval", c.pollInterval))
+	notice, err := termination.WaitForNotice(ctx, events, c.pollInterval)
+	if err != nil {
+		return err
+	}
+	c.result.Event = string(notice.Event)
+	c.result.Reason = notice.Reason
+	log.Info("Instance is being terminated", zap.String("event", string(notice.Event)), zap.String("reason", notice.Reason))
+
+	c.result.Result, err = drain.Node(ctx, c.kubeconfig, c.nodeName, c.drainTimeout)
+	return err
+}
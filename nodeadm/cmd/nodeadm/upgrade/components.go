@@ -0,0 +1,91 @@
+package upgrade
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/components"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+func NewComponentsCommand() cli.Command {
+	cmd := componentsCmd{}
+	cmd.cmd = flaggy.NewSubcommand("components")
+	cmd.cmd.Bool(&cmd.dryRun, "d", "dry-run", "only verify the installed versions against the pinned versions.")
+	cmd.cmd.Description = "Install the versions of the components that are pinned in the configuration"
+	return &cmd
+}
+
+type componentsCmd struct {
+	cmd    *flaggy.Subcommand
+	dryRun bool
+	result componentsResult
+}
+
+type componentsResult struct {
+	Components []components.Status `json:"components"`
+}
+
+func (c *componentsCmd) Result() any {
+	return &c.result
+}
+
+func (c *componentsCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *componentsCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if !c.dryRun {
+		log.Info("Checking user is root..")
+		root, err := cli.IsRunningAsRoot()
+		if err != nil {
+			return err
+		} else if !root {
+			return cli.ErrMustRunAsRoot
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Loading configuration..", zap.String("source", opts.ConfigSource))
+	provider, err := configprovider.BuildConfigProvider(opts.ConfigSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+
+	upgrader := components.NewUpgrader()
+	if c.dryRun {
+		statuses, err := upgrader.Verify(&nodeConfig.Spec.Components)
+		c.result.Components = statuses
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if !status.Current() {
+				log.Warn("Component is not at its pinned version", zap.String("name", status.Name), zap.String("installed", status.Installed), zap.String("pinned", status.Pinned))
+			}
+		}
+		return nil
+	}
+
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+	statuses, err := upgrader.Upgrade(ctx, &nodeConfig.Spec.Components, daemonManager)
+	c.result.Components = statuses
+	return err
+}
@@ -0,0 +1,11 @@
+package upgrade
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewUpgradeCommand() cli.Command {
+	container := cli.NewCommandContainer("upgrade", "Upgrade the components of the node")
+	container.AddCommand(NewComponentsCommand())
+	return container.AsCommand()
+}
@@ -0,0 +1,62 @@
+package api
+
+import "time"
+
+// BootstrapTuning are the retry, backoff and concurrency settings of a
+// bootstrap profile.
+type BootstrapTuning struct {
+	// AWSMaxAttempts is the number of attempts of a call to an AWS API.
+	AWSMaxAttempts int
+	// AWSMaxBackoff is the longest delay between attempts of a call to an AWS
+	// API.
+	AWSMaxBackoff time.Duration
+	// AWSAdaptiveRetry rate limits calls to AWS APIs on the node once they
+	// are throttled.
+	AWSAdaptiveRetry bool
+	// WaiterMinDelay and WaiterMaxDelay bound the delay between polls of the
+	// EC2 API while waiting for the instance.
+	WaiterMinDelay time.Duration
+	WaiterMaxDelay time.Duration
+	// WaiterTimeout is how long the EC2 API is polled for the instance.
+	WaiterTimeout time.Duration
+	// WaiterInitialJitter is the longest random delay before the EC2 API is
+	// first polled, which spreads the polls of nodes launched at once.
+	WaiterInitialJitter time.Duration
+	// PullConcurrency is the number of images pre-pulled at once when the
+	// options do not set one.
+	PullConcurrency int
+	// PullAttempts is the number of attempts to pre-pull an image.
+	PullAttempts int
+}
+
+var bootstrapTunings = map[BootstrapProfile]BootstrapTuning{
+	BootstrapProfileDefault: {
+		AWSMaxAttempts:  3,
+		AWSMaxBackoff:   20 * time.Second,
+		WaiterMinDelay:  15 * time.Second,
+		WaiterMaxDelay:  120 * time.Second,
+		WaiterTimeout:   3 * time.Minute,
+		PullConcurrency: 2,
+		PullAttempts:    3,
+	},
+	BootstrapProfileMassiveScaleUp: {
+		AWSMaxAttempts:      10,
+		AWSMaxBackoff:       60 * time.Second,
+		AWSAdaptiveRetry:    true,
+		WaiterMinDelay:      15 * time.Second,
+		WaiterMaxDelay:      120 * time.Second,
+		WaiterTimeout:       10 * time.Minute,
+		WaiterInitialJitter: 10 * time.Second,
+		PullConcurrency:     1,
+		PullAttempts:        6,
+	},
+}
+
+// GetBootstrapTuning returns the settings of the bootstrap profile of the
+// node.
+func (cfg *NodeConfig) GetBootstrapTuning() BootstrapTuning {
+	if tuning, ok := bootstrapTunings[cfg.Spec.Instance.BootstrapProfile]; ok {
+		return tuning
+	}
+	return bootstrapTunings[BootstrapProfileDefault]
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBootstrapTuning(t *testing.T) {
+	var cfg NodeConfig
+	assert.Equal(t, bootstrapTunings[BootstrapProfileDefault], cfg.GetBootstrapTuning())
+
+	cfg.Spec.Instance.BootstrapProfile = BootstrapProfileMassiveScaleUp
+	tuning := cfg.GetBootstrapTuning()
+	assert.True(t, tuning.AWSAdaptiveRetry)
+	assert.Greater(t, tuning.AWSMaxAttempts, bootstrapTunings[BootstrapProfileDefault].AWSMaxAttempts)
+	assert.Less(t, tuning.PullConcurrency, bootstrapTunings[BootstrapProfileDefault].PullConcurrency)
+}
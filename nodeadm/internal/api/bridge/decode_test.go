@@ -0,0 +1,82 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestDecodeNodeConfig(t *testing.T) {
+	expectedSpec := api.NodeConfigSpec{
+		Cluster: api.ClusterDetails{
+			Name:          "my-cluster",
+			EnableOutpost: ptr.To(true),
+			ID:            "my-cluster-id",
+		},
+		Kubelet: api.KubeletOptions{
+			SwapBehavior: api.SwapBehaviorLimitedSwap,
+		},
+		Instance: api.InstanceOptions{
+			Swap: api.SwapOptions{
+				Device: api.SwapDeviceZram,
+			},
+		},
+	}
+	var tests = []struct {
+		name string
+		data string
+	}{
+		{
+			name: "v1alpha1",
+			data: `
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    enableOutpost: true
+    id: my-cluster-id
+  kubelet:
+    swapBehavior: LimitedSwap
+  instance:
+    swap:
+      device: Zram
+`,
+		},
+		{
+			name: "v1",
+			data: `
+apiVersion: node.eks.aws/v1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    outpost:
+      enabled: true
+      id: my-cluster-id
+  instance:
+    swap:
+      device: Zram
+      behavior: LimitedSwap
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := DecodeNodeConfig([]byte(test.data))
+			assert.NoError(t, err)
+			assert.Equal(t, expectedSpec, config.Spec)
+		})
+	}
+}
+
+func TestDecodeNodeConfigUnknownVersion(t *testing.T) {
+	_, err := DecodeNodeConfig([]byte(`
+apiVersion: node.eks.aws/v2
+kind: NodeConfig
+`))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/awslabs/amazon-eks-ami/nodeadm/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
 	internalapi "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	bridgev1 "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -11,6 +12,7 @@ import (
 var (
 	localSchemeBuilder = runtime.NewSchemeBuilder(
 		v1alpha1.AddToScheme,
+		bridgev1.AddToScheme,
 		addInternalTypes,
 	)
 )
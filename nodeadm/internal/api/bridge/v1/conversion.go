@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	api "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// the outpost options are grouped under cluster.outpost in v1.
+func Convert_v1_ClusterDetails_To_api_ClusterDetails(in *v1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
+	if err := autoConvert_v1_ClusterDetails_To_api_ClusterDetails(in, out, s); err != nil {
+		return err
+	}
+	if in.Outpost.Enabled {
+		out.EnableOutpost = ptr.To(true)
+	}
+	out.ID = in.Outpost.ID
+	return nil
+}
+
+func Convert_api_ClusterDetails_To_v1_ClusterDetails(in *api.ClusterDetails, out *v1.ClusterDetails, s conversion.Scope) error {
+	if err := autoConvert_api_ClusterDetails_To_v1_ClusterDetails(in, out, s); err != nil {
+		return err
+	}
+	out.Outpost.Enabled = ptr.Deref(in.EnableOutpost, false)
+	out.Outpost.ID = in.ID
+	return nil
+}
+
+// the swap behavior of kubelet is configured along with the swap space of the
+// instance under instance.swap.behavior in v1, so it is converted with the
+// whole spec.
+func Convert_v1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1.NodeConfigSpec, out *api.NodeConfigSpec, s conversion.Scope) error {
+	if err := autoConvert_v1_NodeConfigSpec_To_api_NodeConfigSpec(in, out, s); err != nil {
+		return err
+	}
+	out.Kubelet.SwapBehavior = api.SwapBehavior(in.Instance.Swap.Behavior)
+	return nil
+}
+
+func Convert_api_NodeConfigSpec_To_v1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1.NodeConfigSpec, s conversion.Scope) error {
+	if err := autoConvert_api_NodeConfigSpec_To_v1_NodeConfigSpec(in, out, s); err != nil {
+		return err
+	}
+	out.Instance.Swap.Behavior = v1.SwapBehavior(in.Kubelet.SwapBehavior)
+	return nil
+}
+
+// kubelet.swapBehavior and instance.swap.behavior are converted with the spec.
+func Convert_api_KubeletOptions_To_v1_KubeletOptions(in *api.KubeletOptions, out *v1.KubeletOptions, s conversion.Scope) error {
+	return autoConvert_api_KubeletOptions_To_v1_KubeletOptions(in, out, s)
+}
+
+func Convert_v1_SwapOptions_To_api_SwapOptions(in *v1.SwapOptions, out *api.SwapOptions, s conversion.Scope) error {
+	return autoConvert_v1_SwapOptions_To_api_SwapOptions(in, out, s)
+}
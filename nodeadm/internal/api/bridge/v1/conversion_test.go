@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	api "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestNodeConfigSpecRoundTrip(t *testing.T) {
+	spec := api.NodeConfigSpec{
+		Cluster: api.ClusterDetails{
+			Name:          "my-cluster",
+			EnableOutpost: ptr.To(true),
+			ID:            "my-cluster-id",
+		},
+		Kubelet: api.KubeletOptions{
+			SwapBehavior: api.SwapBehaviorNoSwap,
+		},
+	}
+	var external v1.NodeConfigSpec
+	assert.NoError(t, Convert_api_NodeConfigSpec_To_v1_NodeConfigSpec(&spec, &external, nil))
+	assert.Equal(t, v1.OutpostOptions{Enabled: true, ID: "my-cluster-id"}, external.Cluster.Outpost)
+	assert.Equal(t, v1.SwapBehaviorNoSwap, external.Instance.Swap.Behavior)
+
+	var internal api.NodeConfigSpec
+	assert.NoError(t, Convert_v1_NodeConfigSpec_To_api_NodeConfigSpec(&external, &internal, nil))
+	assert.Equal(t, spec, internal)
+}
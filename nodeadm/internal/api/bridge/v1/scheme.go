@@ -0,0 +1,15 @@
+package v1
+
+import (
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	localSchemeBuilder = runtime.NewSchemeBuilder(
+		v1.AddToScheme,
+	)
+	// AddToScheme adds the v1 types, and their conversions to and from the
+	// internal types, to a scheme.
+	AddToScheme = localSchemeBuilder.AddToScheme
+)
@@ -10,6 +10,8 @@ import (
 
 	v1alpha1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
 	api "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -21,6 +23,56 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.AutoLabelsOptions)(nil), (*api.AutoLabelsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions(a.(*v1alpha1.AutoLabelsOptions), b.(*api.AutoLabelsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.AutoLabelsOptions)(nil), (*v1alpha1.AutoLabelsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions(a.(*api.AutoLabelsOptions), b.(*v1alpha1.AutoLabelsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.CgroupIOOptions)(nil), (*api.CgroupIOOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions(a.(*v1alpha1.CgroupIOOptions), b.(*api.CgroupIOOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CgroupIOOptions)(nil), (*v1alpha1.CgroupIOOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions(a.(*api.CgroupIOOptions), b.(*v1alpha1.CgroupIOOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.CgroupOptions)(nil), (*api.CgroupOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CgroupOptions_To_api_CgroupOptions(a.(*v1alpha1.CgroupOptions), b.(*api.CgroupOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CgroupOptions)(nil), (*v1alpha1.CgroupOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CgroupOptions_To_v1alpha1_CgroupOptions(a.(*api.CgroupOptions), b.(*v1alpha1.CgroupOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.CloudWatchAgentOptions)(nil), (*api.CloudWatchAgentOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions(a.(*v1alpha1.CloudWatchAgentOptions), b.(*api.CloudWatchAgentOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CloudWatchAgentOptions)(nil), (*v1alpha1.CloudWatchAgentOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions(a.(*api.CloudWatchAgentOptions), b.(*v1alpha1.CloudWatchAgentOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.CloudWatchLoggingOptions)(nil), (*api.CloudWatchLoggingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions(a.(*v1alpha1.CloudWatchLoggingOptions), b.(*api.CloudWatchLoggingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CloudWatchLoggingOptions)(nil), (*v1alpha1.CloudWatchLoggingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions(a.(*api.CloudWatchLoggingOptions), b.(*v1alpha1.CloudWatchLoggingOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterDetails)(nil), (*api.ClusterDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(a.(*v1alpha1.ClusterDetails), b.(*api.ClusterDetails), scope)
 	}); err != nil {
@@ -31,6 +83,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterDiscoveryOptions)(nil), (*api.ClusterDiscoveryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions(a.(*v1alpha1.ClusterDiscoveryOptions), b.(*api.ClusterDiscoveryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ClusterDiscoveryOptions)(nil), (*v1alpha1.ClusterDiscoveryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions(a.(*api.ClusterDiscoveryOptions), b.(*v1alpha1.ClusterDiscoveryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ComponentsOptions)(nil), (*api.ComponentsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions(a.(*v1alpha1.ComponentsOptions), b.(*api.ComponentsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ComponentsOptions)(nil), (*v1alpha1.ComponentsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions(a.(*api.ComponentsOptions), b.(*v1alpha1.ComponentsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdDebugOptions)(nil), (*api.ContainerdDebugOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions(a.(*v1alpha1.ContainerdDebugOptions), b.(*api.ContainerdDebugOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdDebugOptions)(nil), (*v1alpha1.ContainerdDebugOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions(a.(*api.ContainerdDebugOptions), b.(*v1alpha1.ContainerdDebugOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdGarbageCollectionOptions)(nil), (*api.ContainerdGarbageCollectionOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions(a.(*v1alpha1.ContainerdGarbageCollectionOptions), b.(*api.ContainerdGarbageCollectionOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdGarbageCollectionOptions)(nil), (*v1alpha1.ContainerdGarbageCollectionOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions(a.(*api.ContainerdGarbageCollectionOptions), b.(*v1alpha1.ContainerdGarbageCollectionOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdImagePullOptions)(nil), (*api.ContainerdImagePullOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions(a.(*v1alpha1.ContainerdImagePullOptions), b.(*api.ContainerdImagePullOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdImagePullOptions)(nil), (*v1alpha1.ContainerdImagePullOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions(a.(*api.ContainerdImagePullOptions), b.(*v1alpha1.ContainerdImagePullOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdMetricsOptions)(nil), (*api.ContainerdMetricsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions(a.(*v1alpha1.ContainerdMetricsOptions), b.(*api.ContainerdMetricsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdMetricsOptions)(nil), (*v1alpha1.ContainerdMetricsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions(a.(*api.ContainerdMetricsOptions), b.(*v1alpha1.ContainerdMetricsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdNRIOptions)(nil), (*api.ContainerdNRIOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions(a.(*v1alpha1.ContainerdNRIOptions), b.(*api.ContainerdNRIOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdNRIOptions)(nil), (*v1alpha1.ContainerdNRIOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions(a.(*api.ContainerdNRIOptions), b.(*v1alpha1.ContainerdNRIOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdOptions)(nil), (*api.ContainerdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(a.(*v1alpha1.ContainerdOptions), b.(*api.ContainerdOptions), scope)
 	}); err != nil {
@@ -41,6 +163,116 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdRuntime)(nil), (*api.ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(a.(*v1alpha1.ContainerdRuntime), b.(*api.ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdRuntime)(nil), (*v1alpha1.ContainerdRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(a.(*api.ContainerdRuntime), b.(*v1alpha1.ContainerdRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.DebugOptions)(nil), (*api.DebugOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DebugOptions_To_api_DebugOptions(a.(*v1alpha1.DebugOptions), b.(*api.DebugOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DebugOptions)(nil), (*v1alpha1.DebugOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DebugOptions_To_v1alpha1_DebugOptions(a.(*api.DebugOptions), b.(*v1alpha1.DebugOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.DiskCheckOptions)(nil), (*api.DiskCheckOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions(a.(*v1alpha1.DiskCheckOptions), b.(*api.DiskCheckOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DiskCheckOptions)(nil), (*v1alpha1.DiskCheckOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions(a.(*api.DiskCheckOptions), b.(*v1alpha1.DiskCheckOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.EFAOptions)(nil), (*api.EFAOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EFAOptions_To_api_EFAOptions(a.(*v1alpha1.EFAOptions), b.(*api.EFAOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EFAOptions)(nil), (*v1alpha1.EFAOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EFAOptions_To_v1alpha1_EFAOptions(a.(*api.EFAOptions), b.(*v1alpha1.EFAOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.EndpointOverrides)(nil), (*api.EndpointOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides(a.(*v1alpha1.EndpointOverrides), b.(*api.EndpointOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EndpointOverrides)(nil), (*v1alpha1.EndpointOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides(a.(*api.EndpointOverrides), b.(*v1alpha1.EndpointOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Hook)(nil), (*api.Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Hook_To_api_Hook(a.(*v1alpha1.Hook), b.(*api.Hook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Hook)(nil), (*v1alpha1.Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Hook_To_v1alpha1_Hook(a.(*api.Hook), b.(*v1alpha1.Hook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.HooksOptions)(nil), (*api.HooksOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HooksOptions_To_api_HooksOptions(a.(*v1alpha1.HooksOptions), b.(*api.HooksOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HooksOptions)(nil), (*v1alpha1.HooksOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HooksOptions_To_v1alpha1_HooksOptions(a.(*api.HooksOptions), b.(*v1alpha1.HooksOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.HybridOptions)(nil), (*api.HybridOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HybridOptions_To_api_HybridOptions(a.(*v1alpha1.HybridOptions), b.(*api.HybridOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HybridOptions)(nil), (*v1alpha1.HybridOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HybridOptions_To_v1alpha1_HybridOptions(a.(*api.HybridOptions), b.(*v1alpha1.HybridOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.IAMRolesAnywhereOptions)(nil), (*api.IAMRolesAnywhereOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions(a.(*v1alpha1.IAMRolesAnywhereOptions), b.(*api.IAMRolesAnywhereOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.IAMRolesAnywhereOptions)(nil), (*v1alpha1.IAMRolesAnywhereOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions(a.(*api.IAMRolesAnywhereOptions), b.(*v1alpha1.IAMRolesAnywhereOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ImageRetentionOptions)(nil), (*api.ImageRetentionOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions(a.(*v1alpha1.ImageRetentionOptions), b.(*api.ImageRetentionOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ImageRetentionOptions)(nil), (*v1alpha1.ImageRetentionOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions(a.(*api.ImageRetentionOptions), b.(*v1alpha1.ImageRetentionOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.InstanceIdentityOptions)(nil), (*api.InstanceIdentityOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions(a.(*v1alpha1.InstanceIdentityOptions), b.(*api.InstanceIdentityOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.InstanceIdentityOptions)(nil), (*v1alpha1.InstanceIdentityOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions(a.(*api.InstanceIdentityOptions), b.(*v1alpha1.InstanceIdentityOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.InstanceOptions)(nil), (*api.InstanceOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(a.(*v1alpha1.InstanceOptions), b.(*api.InstanceOptions), scope)
 	}); err != nil {
@@ -51,6 +283,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.InstanceTagsOptions)(nil), (*api.InstanceTagsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions(a.(*v1alpha1.InstanceTagsOptions), b.(*api.InstanceTagsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.InstanceTagsOptions)(nil), (*v1alpha1.InstanceTagsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions(a.(*api.InstanceTagsOptions), b.(*v1alpha1.InstanceTagsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.JournaldOptions)(nil), (*api.JournaldOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_JournaldOptions_To_api_JournaldOptions(a.(*v1alpha1.JournaldOptions), b.(*api.JournaldOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.JournaldOptions)(nil), (*v1alpha1.JournaldOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_JournaldOptions_To_v1alpha1_JournaldOptions(a.(*api.JournaldOptions), b.(*v1alpha1.JournaldOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletAuthenticationOptions)(nil), (*api.KubeletAuthenticationOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions(a.(*v1alpha1.KubeletAuthenticationOptions), b.(*api.KubeletAuthenticationOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.KubeletAuthenticationOptions)(nil), (*v1alpha1.KubeletAuthenticationOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions(a.(*api.KubeletAuthenticationOptions), b.(*v1alpha1.KubeletAuthenticationOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletOptions)(nil), (*api.KubeletOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(a.(*v1alpha1.KubeletOptions), b.(*api.KubeletOptions), scope)
 	}); err != nil {
@@ -61,6 +323,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletRemoteConfigOptions)(nil), (*api.KubeletRemoteConfigOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions(a.(*v1alpha1.KubeletRemoteConfigOptions), b.(*api.KubeletRemoteConfigOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.KubeletRemoteConfigOptions)(nil), (*v1alpha1.KubeletRemoteConfigOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions(a.(*api.KubeletRemoteConfigOptions), b.(*v1alpha1.KubeletRemoteConfigOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.LocalStorageOptions)(nil), (*api.LocalStorageOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(a.(*v1alpha1.LocalStorageOptions), b.(*api.LocalStorageOptions), scope)
 	}); err != nil {
@@ -71,6 +343,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.LoggingOptions)(nil), (*api.LoggingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoggingOptions_To_api_LoggingOptions(a.(*v1alpha1.LoggingOptions), b.(*api.LoggingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.LoggingOptions)(nil), (*v1alpha1.LoggingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_LoggingOptions_To_v1alpha1_LoggingOptions(a.(*api.LoggingOptions), b.(*v1alpha1.LoggingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MonitoringOptions)(nil), (*api.MonitoringOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions(a.(*v1alpha1.MonitoringOptions), b.(*api.MonitoringOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.MonitoringOptions)(nil), (*v1alpha1.MonitoringOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions(a.(*api.MonitoringOptions), b.(*v1alpha1.MonitoringOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NetworkCaptureOptions)(nil), (*api.NetworkCaptureOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions(a.(*v1alpha1.NetworkCaptureOptions), b.(*api.NetworkCaptureOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NetworkCaptureOptions)(nil), (*v1alpha1.NetworkCaptureOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions(a.(*api.NetworkCaptureOptions), b.(*v1alpha1.NetworkCaptureOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NeuronOptions)(nil), (*api.NeuronOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NeuronOptions_To_api_NeuronOptions(a.(*v1alpha1.NeuronOptions), b.(*api.NeuronOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NeuronOptions)(nil), (*v1alpha1.NeuronOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NeuronOptions_To_v1alpha1_NeuronOptions(a.(*api.NeuronOptions), b.(*v1alpha1.NeuronOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NetworkingOptions)(nil), (*api.NetworkingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions(a.(*v1alpha1.NetworkingOptions), b.(*api.NetworkingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NetworkingOptions)(nil), (*v1alpha1.NetworkingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions(a.(*api.NetworkingOptions), b.(*v1alpha1.NetworkingOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeConfig)(nil), (*api.NodeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NodeConfig_To_api_NodeConfig(a.(*v1alpha1.NodeConfig), b.(*api.NodeConfig), scope)
 	}); err != nil {
@@ -91,250 +413,2426 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeConfigSpec)(nil), (*api.NodeConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(a.(*v1alpha1.NodeConfigSpec), b.(*api.NodeConfigSpec), scope)
-	}); err != nil {
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeConfigSpec)(nil), (*api.NodeConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(a.(*v1alpha1.NodeConfigSpec), b.(*api.NodeConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeConfigSpec)(nil), (*v1alpha1.NodeConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(a.(*api.NodeConfigSpec), b.(*v1alpha1.NodeConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeIPOptions)(nil), (*api.NodeIPOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(a.(*v1alpha1.NodeIPOptions), b.(*api.NodeIPOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeIPOptions)(nil), (*v1alpha1.NodeIPOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(a.(*api.NodeIPOptions), b.(*v1alpha1.NodeIPOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PodLogsForwarderOptions)(nil), (*api.PodLogsForwarderOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions(a.(*v1alpha1.PodLogsForwarderOptions), b.(*api.PodLogsForwarderOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodLogsForwarderOptions)(nil), (*v1alpha1.PodLogsForwarderOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions(a.(*api.PodLogsForwarderOptions), b.(*v1alpha1.PodLogsForwarderOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PodLogsOptions)(nil), (*api.PodLogsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions(a.(*v1alpha1.PodLogsOptions), b.(*api.PodLogsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodLogsOptions)(nil), (*v1alpha1.PodLogsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions(a.(*api.PodLogsOptions), b.(*v1alpha1.PodLogsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PrePullImagesOptions)(nil), (*api.PrePullImagesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions(a.(*v1alpha1.PrePullImagesOptions), b.(*api.PrePullImagesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PrePullImagesOptions)(nil), (*v1alpha1.PrePullImagesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions(a.(*api.PrePullImagesOptions), b.(*v1alpha1.PrePullImagesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PressureMonitorOptions)(nil), (*api.PressureMonitorOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions(a.(*v1alpha1.PressureMonitorOptions), b.(*api.PressureMonitorOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PressureMonitorOptions)(nil), (*v1alpha1.PressureMonitorOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions(a.(*api.PressureMonitorOptions), b.(*v1alpha1.PressureMonitorOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ProxyOptions)(nil), (*api.ProxyOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProxyOptions_To_api_ProxyOptions(a.(*v1alpha1.ProxyOptions), b.(*api.ProxyOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ProxyOptions)(nil), (*v1alpha1.ProxyOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ProxyOptions_To_v1alpha1_ProxyOptions(a.(*api.ProxyOptions), b.(*v1alpha1.ProxyOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ReadinessOptions)(nil), (*api.ReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions(a.(*v1alpha1.ReadinessOptions), b.(*api.ReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ReadinessOptions)(nil), (*v1alpha1.ReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions(a.(*api.ReadinessOptions), b.(*v1alpha1.ReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryCredential)(nil), (*api.RegistryCredential)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryCredential_To_api_RegistryCredential(a.(*v1alpha1.RegistryCredential), b.(*api.RegistryCredential), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryCredential)(nil), (*v1alpha1.RegistryCredential)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryCredential_To_v1alpha1_RegistryCredential(a.(*api.RegistryCredential), b.(*v1alpha1.RegistryCredential), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RunscOptions)(nil), (*api.RunscOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RunscOptions_To_api_RunscOptions(a.(*v1alpha1.RunscOptions), b.(*api.RunscOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RunscOptions)(nil), (*v1alpha1.RunscOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RunscOptions_To_v1alpha1_RunscOptions(a.(*api.RunscOptions), b.(*v1alpha1.RunscOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SOCIOptions)(nil), (*api.SOCIOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SOCIOptions_To_api_SOCIOptions(a.(*v1alpha1.SOCIOptions), b.(*api.SOCIOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SOCIOptions)(nil), (*v1alpha1.SOCIOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SOCIOptions_To_v1alpha1_SOCIOptions(a.(*api.SOCIOptions), b.(*v1alpha1.SOCIOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SSMOptions)(nil), (*api.SSMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SSMOptions_To_api_SSMOptions(a.(*v1alpha1.SSMOptions), b.(*api.SSMOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SSMOptions)(nil), (*v1alpha1.SSMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SSMOptions_To_v1alpha1_SSMOptions(a.(*api.SSMOptions), b.(*v1alpha1.SSMOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SeccompDefaultOptions)(nil), (*api.SeccompDefaultOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions(a.(*v1alpha1.SeccompDefaultOptions), b.(*api.SeccompDefaultOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SeccompDefaultOptions)(nil), (*v1alpha1.SeccompDefaultOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions(a.(*api.SeccompDefaultOptions), b.(*v1alpha1.SeccompDefaultOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SeccompProfile)(nil), (*api.SeccompProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SeccompProfile_To_api_SeccompProfile(a.(*v1alpha1.SeccompProfile), b.(*api.SeccompProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SeccompProfile)(nil), (*v1alpha1.SeccompProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SeccompProfile_To_v1alpha1_SeccompProfile(a.(*api.SeccompProfile), b.(*v1alpha1.SeccompProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SecurityOptions)(nil), (*api.SecurityOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecurityOptions_To_api_SecurityOptions(a.(*v1alpha1.SecurityOptions), b.(*api.SecurityOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SecurityOptions)(nil), (*v1alpha1.SecurityOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SecurityOptions_To_v1alpha1_SecurityOptions(a.(*api.SecurityOptions), b.(*v1alpha1.SecurityOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ServingCertificateOptions)(nil), (*api.ServingCertificateOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions(a.(*v1alpha1.ServingCertificateOptions), b.(*api.ServingCertificateOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ServingCertificateOptions)(nil), (*v1alpha1.ServingCertificateOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions(a.(*api.ServingCertificateOptions), b.(*v1alpha1.ServingCertificateOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ShutdownOptions)(nil), (*api.ShutdownOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions(a.(*v1alpha1.ShutdownOptions), b.(*api.ShutdownOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ShutdownOptions)(nil), (*v1alpha1.ShutdownOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions(a.(*api.ShutdownOptions), b.(*v1alpha1.ShutdownOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SmokeTestOptions)(nil), (*api.SmokeTestOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions(a.(*v1alpha1.SmokeTestOptions), b.(*api.SmokeTestOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SmokeTestOptions)(nil), (*v1alpha1.SmokeTestOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions(a.(*api.SmokeTestOptions), b.(*v1alpha1.SmokeTestOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StaticPod)(nil), (*api.StaticPod)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StaticPod_To_api_StaticPod(a.(*v1alpha1.StaticPod), b.(*api.StaticPod), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StaticPod)(nil), (*v1alpha1.StaticPod)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StaticPod_To_v1alpha1_StaticPod(a.(*api.StaticPod), b.(*v1alpha1.StaticPod), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SwapOptions)(nil), (*api.SwapOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SwapOptions_To_api_SwapOptions(a.(*v1alpha1.SwapOptions), b.(*api.SwapOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SwapOptions)(nil), (*v1alpha1.SwapOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SwapOptions_To_v1alpha1_SwapOptions(a.(*api.SwapOptions), b.(*v1alpha1.SwapOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StatusAnnotationsOptions)(nil), (*api.StatusAnnotationsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions(a.(*v1alpha1.StatusAnnotationsOptions), b.(*api.StatusAnnotationsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StatusAnnotationsOptions)(nil), (*v1alpha1.StatusAnnotationsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions(a.(*api.StatusAnnotationsOptions), b.(*v1alpha1.StatusAnnotationsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SystemOptions)(nil), (*api.SystemOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SystemOptions_To_api_SystemOptions(a.(*v1alpha1.SystemOptions), b.(*api.SystemOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SystemOptions)(nil), (*v1alpha1.SystemOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SystemOptions_To_v1alpha1_SystemOptions(a.(*api.SystemOptions), b.(*v1alpha1.SystemOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SystemdDropIn)(nil), (*api.SystemdDropIn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SystemdDropIn_To_api_SystemdDropIn(a.(*v1alpha1.SystemdDropIn), b.(*api.SystemdDropIn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SystemdDropIn)(nil), (*v1alpha1.SystemdDropIn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SystemdDropIn_To_v1alpha1_SystemdDropIn(a.(*api.SystemdDropIn), b.(*v1alpha1.SystemdDropIn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SystemdOptions)(nil), (*api.SystemdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(a.(*v1alpha1.SystemdOptions), b.(*api.SystemdOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SystemdOptions)(nil), (*v1alpha1.SystemdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(a.(*api.SystemdOptions), b.(*v1alpha1.SystemdOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SystemdUnit)(nil), (*api.SystemdUnit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SystemdUnit_To_api_SystemdUnit(a.(*v1alpha1.SystemdUnit), b.(*api.SystemdUnit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SystemdUnit)(nil), (*v1alpha1.SystemdUnit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SystemdUnit_To_v1alpha1_SystemdUnit(a.(*api.SystemdUnit), b.(*v1alpha1.SystemdUnit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Taint)(nil), (*api.Taint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Taint_To_api_Taint(a.(*v1alpha1.Taint), b.(*api.Taint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Taint)(nil), (*v1alpha1.Taint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Taint_To_v1alpha1_Taint(a.(*api.Taint), b.(*v1alpha1.Taint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TerminationHandlerOptions)(nil), (*api.TerminationHandlerOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions(a.(*v1alpha1.TerminationHandlerOptions), b.(*api.TerminationHandlerOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TerminationHandlerOptions)(nil), (*v1alpha1.TerminationHandlerOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions(a.(*api.TerminationHandlerOptions), b.(*v1alpha1.TerminationHandlerOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TimeSyncOptions)(nil), (*api.TimeSyncOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions(a.(*v1alpha1.TimeSyncOptions), b.(*api.TimeSyncOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TimeSyncOptions)(nil), (*v1alpha1.TimeSyncOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions(a.(*api.TimeSyncOptions), b.(*v1alpha1.TimeSyncOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TokenCacheOptions)(nil), (*api.TokenCacheOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions(a.(*v1alpha1.TokenCacheOptions), b.(*api.TokenCacheOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TokenCacheOptions)(nil), (*v1alpha1.TokenCacheOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions(a.(*api.TokenCacheOptions), b.(*v1alpha1.TokenCacheOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TracingOptions)(nil), (*api.TracingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TracingOptions_To_api_TracingOptions(a.(*v1alpha1.TracingOptions), b.(*api.TracingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TracingOptions)(nil), (*v1alpha1.TracingOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TracingOptions_To_v1alpha1_TracingOptions(a.(*api.TracingOptions), b.(*v1alpha1.TracingOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.TrustStoreOptions)(nil), (*api.TrustStoreOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions(a.(*v1alpha1.TrustStoreOptions), b.(*api.TrustStoreOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TrustStoreOptions)(nil), (*v1alpha1.TrustStoreOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions(a.(*api.TrustStoreOptions), b.(*v1alpha1.TrustStoreOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.UserNamespacesOptions)(nil), (*api.UserNamespacesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions(a.(*v1alpha1.UserNamespacesOptions), b.(*api.UserNamespacesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.UserNamespacesOptions)(nil), (*v1alpha1.UserNamespacesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions(a.(*api.UserNamespacesOptions), b.(*v1alpha1.UserNamespacesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions(in *v1alpha1.AutoLabelsOptions, out *api.AutoLabelsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions(in *v1alpha1.AutoLabelsOptions, out *api.AutoLabelsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions(in, out, s)
+}
+
+func autoConvert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions(in *api.AutoLabelsOptions, out *v1alpha1.AutoLabelsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions is an autogenerated conversion function.
+func Convert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions(in *api.AutoLabelsOptions, out *v1alpha1.AutoLabelsOptions, s conversion.Scope) error {
+	return autoConvert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions(in *v1alpha1.CgroupIOOptions, out *api.CgroupIOOptions, s conversion.Scope) error {
+	out.RuntimeWeight = in.RuntimeWeight
+	out.PodsWeight = in.PodsWeight
+	return nil
+}
+
+// Convert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions is an autogenerated conversion function.
+func Convert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions(in *v1alpha1.CgroupIOOptions, out *api.CgroupIOOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions(in, out, s)
+}
+
+func autoConvert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions(in *api.CgroupIOOptions, out *v1alpha1.CgroupIOOptions, s conversion.Scope) error {
+	out.RuntimeWeight = in.RuntimeWeight
+	out.PodsWeight = in.PodsWeight
+	return nil
+}
+
+// Convert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions is an autogenerated conversion function.
+func Convert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions(in *api.CgroupIOOptions, out *v1alpha1.CgroupIOOptions, s conversion.Scope) error {
+	return autoConvert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_CgroupOptions_To_api_CgroupOptions(in *v1alpha1.CgroupOptions, out *api.CgroupOptions, s conversion.Scope) error {
+	out.Version = api.CgroupVersion(in.Version)
+	out.Driver = api.CgroupDriver(in.Driver)
+	if err := Convert_v1alpha1_CgroupIOOptions_To_api_CgroupIOOptions(&in.IO, &out.IO, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_CgroupOptions_To_api_CgroupOptions is an autogenerated conversion function.
+func Convert_v1alpha1_CgroupOptions_To_api_CgroupOptions(in *v1alpha1.CgroupOptions, out *api.CgroupOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CgroupOptions_To_api_CgroupOptions(in, out, s)
+}
+
+func autoConvert_api_CgroupOptions_To_v1alpha1_CgroupOptions(in *api.CgroupOptions, out *v1alpha1.CgroupOptions, s conversion.Scope) error {
+	out.Version = v1alpha1.CgroupVersion(in.Version)
+	out.Driver = v1alpha1.CgroupDriver(in.Driver)
+	if err := Convert_api_CgroupIOOptions_To_v1alpha1_CgroupIOOptions(&in.IO, &out.IO, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_CgroupOptions_To_v1alpha1_CgroupOptions is an autogenerated conversion function.
+func Convert_api_CgroupOptions_To_v1alpha1_CgroupOptions(in *api.CgroupOptions, out *v1alpha1.CgroupOptions, s conversion.Scope) error {
+	return autoConvert_api_CgroupOptions_To_v1alpha1_CgroupOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions(in *v1alpha1.CloudWatchAgentOptions, out *api.CloudWatchAgentOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Namespace = in.Namespace
+	out.Metrics = *(*[]api.CloudWatchAgentMetric)(unsafe.Pointer(&in.Metrics))
+	out.MetricsCollectionInterval = (*v1.Duration)(unsafe.Pointer(in.MetricsCollectionInterval))
+	out.LogGroupName = in.LogGroupName
+	return nil
+}
+
+// Convert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions is an autogenerated conversion function.
+func Convert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions(in *v1alpha1.CloudWatchAgentOptions, out *api.CloudWatchAgentOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions(in, out, s)
+}
+
+func autoConvert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions(in *api.CloudWatchAgentOptions, out *v1alpha1.CloudWatchAgentOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Namespace = in.Namespace
+	out.Metrics = *(*[]v1alpha1.CloudWatchAgentMetric)(unsafe.Pointer(&in.Metrics))
+	out.MetricsCollectionInterval = (*v1.Duration)(unsafe.Pointer(in.MetricsCollectionInterval))
+	out.LogGroupName = in.LogGroupName
+	return nil
+}
+
+// Convert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions is an autogenerated conversion function.
+func Convert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions(in *api.CloudWatchAgentOptions, out *v1alpha1.CloudWatchAgentOptions, s conversion.Scope) error {
+	return autoConvert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions(in *v1alpha1.CloudWatchLoggingOptions, out *api.CloudWatchLoggingOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.LogGroupName = in.LogGroupName
+	out.Units = *(*[]string)(unsafe.Pointer(&in.Units))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions is an autogenerated conversion function.
+func Convert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions(in *v1alpha1.CloudWatchLoggingOptions, out *api.CloudWatchLoggingOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions(in, out, s)
+}
+
+func autoConvert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions(in *api.CloudWatchLoggingOptions, out *v1alpha1.CloudWatchLoggingOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.LogGroupName = in.LogGroupName
+	out.Units = *(*[]string)(unsafe.Pointer(&in.Units))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions is an autogenerated conversion function.
+func Convert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions(in *api.CloudWatchLoggingOptions, out *v1alpha1.CloudWatchLoggingOptions, s conversion.Scope) error {
+	return autoConvert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
+	out.Name = in.Name
+	out.APIServerEndpoint = in.APIServerEndpoint
+	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
+	out.CertificateAuthorityFile = in.CertificateAuthorityFile
+	out.CertificatePins = *(*[]string)(unsafe.Pointer(&in.CertificatePins))
+	out.CIDR = in.CIDR
+	out.DNSDomain = in.DNSDomain
+	if err := Convert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions(&in.Discovery, &out.Discovery, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides(&in.EndpointOverrides, &out.EndpointOverrides, s); err != nil {
+		return err
+	}
+	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in, out, s)
+}
+
+func autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetails, out *v1alpha1.ClusterDetails, s conversion.Scope) error {
+	out.Name = in.Name
+	out.APIServerEndpoint = in.APIServerEndpoint
+	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
+	out.CertificateAuthorityFile = in.CertificateAuthorityFile
+	out.CertificatePins = *(*[]string)(unsafe.Pointer(&in.CertificatePins))
+	out.CIDR = in.CIDR
+	out.DNSDomain = in.DNSDomain
+	if err := Convert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions(&in.Discovery, &out.Discovery, s); err != nil {
+		return err
+	}
+	if err := Convert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides(&in.EndpointOverrides, &out.EndpointOverrides, s); err != nil {
+		return err
+	}
+	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails is an autogenerated conversion function.
+func Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetails, out *v1alpha1.ClusterDetails, s conversion.Scope) error {
+	return autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions(in *v1alpha1.ClusterDiscoveryOptions, out *api.ClusterDiscoveryOptions, s conversion.Scope) error {
+	out.Source = api.ClusterDiscoverySource(in.Source)
+	out.TagKey = in.TagKey
+	out.SSMParameter = in.SSMParameter
+	out.MinDelay = (*v1.Duration)(unsafe.Pointer(in.MinDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions(in *v1alpha1.ClusterDiscoveryOptions, out *api.ClusterDiscoveryOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterDiscoveryOptions_To_api_ClusterDiscoveryOptions(in, out, s)
+}
+
+func autoConvert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions(in *api.ClusterDiscoveryOptions, out *v1alpha1.ClusterDiscoveryOptions, s conversion.Scope) error {
+	out.Source = v1alpha1.ClusterDiscoverySource(in.Source)
+	out.TagKey = in.TagKey
+	out.SSMParameter = in.SSMParameter
+	out.MinDelay = (*v1.Duration)(unsafe.Pointer(in.MinDelay))
+	out.MaxDelay = (*v1.Duration)(unsafe.Pointer(in.MaxDelay))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions is an autogenerated conversion function.
+func Convert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions(in *api.ClusterDiscoveryOptions, out *v1alpha1.ClusterDiscoveryOptions, s conversion.Scope) error {
+	return autoConvert_api_ClusterDiscoveryOptions_To_v1alpha1_ClusterDiscoveryOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions(in *v1alpha1.ComponentsOptions, out *api.ComponentsOptions, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.PublicKey = in.PublicKey
+	out.Kubelet = in.Kubelet
+	out.Containerd = in.Containerd
+	out.Runc = in.Runc
+	out.CNIPlugins = in.CNIPlugins
+	return nil
+}
+
+// Convert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions(in *v1alpha1.ComponentsOptions, out *api.ComponentsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions(in, out, s)
+}
+
+func autoConvert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions(in *api.ComponentsOptions, out *v1alpha1.ComponentsOptions, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.PublicKey = in.PublicKey
+	out.Kubelet = in.Kubelet
+	out.Containerd = in.Containerd
+	out.Runc = in.Runc
+	out.CNIPlugins = in.CNIPlugins
+	return nil
+}
+
+// Convert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions is an autogenerated conversion function.
+func Convert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions(in *api.ComponentsOptions, out *v1alpha1.ComponentsOptions, s conversion.Scope) error {
+	return autoConvert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions(in *v1alpha1.ContainerdDebugOptions, out *api.ContainerdDebugOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.Level = api.ContainerdLogLevel(in.Level)
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions(in *v1alpha1.ContainerdDebugOptions, out *api.ContainerdDebugOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions(in *api.ContainerdDebugOptions, out *v1alpha1.ContainerdDebugOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.Level = v1alpha1.ContainerdLogLevel(in.Level)
+	return nil
+}
+
+// Convert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions is an autogenerated conversion function.
+func Convert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions(in *api.ContainerdDebugOptions, out *v1alpha1.ContainerdDebugOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions(in *v1alpha1.ContainerdGarbageCollectionOptions, out *api.ContainerdGarbageCollectionOptions, s conversion.Scope) error {
+	out.PauseThresholdPercent = in.PauseThresholdPercent
+	out.DeletionThreshold = in.DeletionThreshold
+	out.MutationThreshold = in.MutationThreshold
+	out.ScheduleDelay = (*v1.Duration)(unsafe.Pointer(in.ScheduleDelay))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions(in *v1alpha1.ContainerdGarbageCollectionOptions, out *api.ContainerdGarbageCollectionOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions(in *api.ContainerdGarbageCollectionOptions, out *v1alpha1.ContainerdGarbageCollectionOptions, s conversion.Scope) error {
+	out.PauseThresholdPercent = in.PauseThresholdPercent
+	out.DeletionThreshold = in.DeletionThreshold
+	out.MutationThreshold = in.MutationThreshold
+	out.ScheduleDelay = (*v1.Duration)(unsafe.Pointer(in.ScheduleDelay))
+	return nil
+}
+
+// Convert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions is an autogenerated conversion function.
+func Convert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions(in *api.ContainerdGarbageCollectionOptions, out *v1alpha1.ContainerdGarbageCollectionOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions(in *v1alpha1.ContainerdImagePullOptions, out *api.ContainerdImagePullOptions, s conversion.Scope) error {
+	out.MaxConcurrentDownloads = in.MaxConcurrentDownloads
+	out.ProgressTimeout = (*v1.Duration)(unsafe.Pointer(in.ProgressTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions(in *v1alpha1.ContainerdImagePullOptions, out *api.ContainerdImagePullOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions(in *api.ContainerdImagePullOptions, out *v1alpha1.ContainerdImagePullOptions, s conversion.Scope) error {
+	out.MaxConcurrentDownloads = in.MaxConcurrentDownloads
+	out.ProgressTimeout = (*v1.Duration)(unsafe.Pointer(in.ProgressTimeout))
+	return nil
+}
+
+// Convert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions is an autogenerated conversion function.
+func Convert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions(in *api.ContainerdImagePullOptions, out *v1alpha1.ContainerdImagePullOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions(in *v1alpha1.ContainerdMetricsOptions, out *api.ContainerdMetricsOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.GRPCHistogram = in.GRPCHistogram
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions(in *v1alpha1.ContainerdMetricsOptions, out *api.ContainerdMetricsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions(in *api.ContainerdMetricsOptions, out *v1alpha1.ContainerdMetricsOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.GRPCHistogram = in.GRPCHistogram
+	return nil
+}
+
+// Convert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions is an autogenerated conversion function.
+func Convert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions(in *api.ContainerdMetricsOptions, out *v1alpha1.ContainerdMetricsOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions(in *v1alpha1.ContainerdNRIOptions, out *api.ContainerdNRIOptions, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.SocketPath = in.SocketPath
+	out.PluginRegistrationTimeout = (*v1.Duration)(unsafe.Pointer(in.PluginRegistrationTimeout))
+	out.PluginRequestTimeout = (*v1.Duration)(unsafe.Pointer(in.PluginRequestTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions(in *v1alpha1.ContainerdNRIOptions, out *api.ContainerdNRIOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions(in *api.ContainerdNRIOptions, out *v1alpha1.ContainerdNRIOptions, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.SocketPath = in.SocketPath
+	out.PluginRegistrationTimeout = (*v1.Duration)(unsafe.Pointer(in.PluginRegistrationTimeout))
+	out.PluginRequestTimeout = (*v1.Duration)(unsafe.Pointer(in.PluginRequestTimeout))
+	return nil
+}
+
+// Convert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions is an autogenerated conversion function.
+func Convert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions(in *api.ContainerdNRIOptions, out *v1alpha1.ContainerdNRIOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
+	out.Config = api.ContainerdConfig(in.Config)
+	out.BaseRuntimeSpec = *(*api.InlineDocument)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	out.BaseRuntimeSpecOverrides = *(*map[string]api.InlineDocument)(unsafe.Pointer(&in.BaseRuntimeSpecOverrides))
+	out.DefaultRuntimeBinary = api.RuntimeBinary(in.DefaultRuntimeBinary)
+	out.SandboxImage = in.SandboxImage
+	out.Runsc = (*api.RunscOptions)(unsafe.Pointer(in.Runsc))
+	out.Runtimes = *(*[]api.ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
+	out.DefaultRuntime = in.DefaultRuntime
+	out.UntrustedWorkloadRuntime = in.UntrustedWorkloadRuntime
+	if err := Convert_v1alpha1_SOCIOptions_To_api_SOCIOptions(&in.SOCI, &out.SOCI, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions(&in.PrePullImages, &out.PrePullImages, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions(&in.SmokeTest, &out.SmokeTest, s); err != nil {
+		return err
+	}
+	out.DiscardUnpackedLayers = (*bool)(unsafe.Pointer(in.DiscardUnpackedLayers))
+	if err := Convert_v1alpha1_ContainerdGarbageCollectionOptions_To_api_ContainerdGarbageCollectionOptions(&in.GarbageCollection, &out.GarbageCollection, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions(&in.ImageRetention, &out.ImageRetention, s); err != nil {
+		return err
+	}
+	out.RegistryCredentials = *(*[]api.RegistryCredential)(unsafe.Pointer(&in.RegistryCredentials))
+	if err := Convert_v1alpha1_ContainerdMetricsOptions_To_api_ContainerdMetricsOptions(&in.Metrics, &out.Metrics, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ContainerdDebugOptions_To_api_ContainerdDebugOptions(&in.Debug, &out.Debug, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ContainerdNRIOptions_To_api_ContainerdNRIOptions(&in.NRI, &out.NRI, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ContainerdImagePullOptions_To_api_ContainerdImagePullOptions(&in.ImagePulls, &out.ImagePulls, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
+	out.Config = string(in.Config)
+	out.BaseRuntimeSpec = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	out.BaseRuntimeSpecOverrides = *(*map[string]map[string]runtime.RawExtension)(unsafe.Pointer(&in.BaseRuntimeSpecOverrides))
+	out.DefaultRuntimeBinary = v1alpha1.RuntimeBinary(in.DefaultRuntimeBinary)
+	out.SandboxImage = in.SandboxImage
+	out.Runsc = (*v1alpha1.RunscOptions)(unsafe.Pointer(in.Runsc))
+	out.Runtimes = *(*[]v1alpha1.ContainerdRuntime)(unsafe.Pointer(&in.Runtimes))
+	out.DefaultRuntime = in.DefaultRuntime
+	out.UntrustedWorkloadRuntime = in.UntrustedWorkloadRuntime
+	if err := Convert_api_SOCIOptions_To_v1alpha1_SOCIOptions(&in.SOCI, &out.SOCI, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions(&in.PrePullImages, &out.PrePullImages, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions(&in.SmokeTest, &out.SmokeTest, s); err != nil {
+		return err
+	}
+	out.DiscardUnpackedLayers = (*bool)(unsafe.Pointer(in.DiscardUnpackedLayers))
+	if err := Convert_api_ContainerdGarbageCollectionOptions_To_v1alpha1_ContainerdGarbageCollectionOptions(&in.GarbageCollection, &out.GarbageCollection, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions(&in.ImageRetention, &out.ImageRetention, s); err != nil {
+		return err
+	}
+	out.RegistryCredentials = *(*[]v1alpha1.RegistryCredential)(unsafe.Pointer(&in.RegistryCredentials))
+	if err := Convert_api_ContainerdMetricsOptions_To_v1alpha1_ContainerdMetricsOptions(&in.Metrics, &out.Metrics, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ContainerdDebugOptions_To_v1alpha1_ContainerdDebugOptions(&in.Debug, &out.Debug, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ContainerdNRIOptions_To_v1alpha1_ContainerdNRIOptions(&in.NRI, &out.NRI, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ContainerdImagePullOptions_To_v1alpha1_ContainerdImagePullOptions(&in.ImagePulls, &out.ImagePulls, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions is an autogenerated conversion function.
+func Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in *v1alpha1.ContainerdRuntime, out *api.ContainerdRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RuntimeType = in.RuntimeType
+	out.Options = *(*api.InlineDocument)(unsafe.Pointer(&in.Options))
+	out.Snapshotter = in.Snapshotter
+	out.PrivilegedWithoutHostDevices = in.PrivilegedWithoutHostDevices
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in *v1alpha1.ContainerdRuntime, out *api.ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdRuntime_To_api_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in *api.ContainerdRuntime, out *v1alpha1.ContainerdRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RuntimeType = in.RuntimeType
+	out.Options = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Options))
+	out.Snapshotter = in.Snapshotter
+	out.PrivilegedWithoutHostDevices = in.PrivilegedWithoutHostDevices
+	return nil
+}
+
+// Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime is an autogenerated conversion function.
+func Convert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in *api.ContainerdRuntime, out *v1alpha1.ContainerdRuntime, s conversion.Scope) error {
+	return autoConvert_api_ContainerdRuntime_To_v1alpha1_ContainerdRuntime(in, out, s)
+}
+
+func autoConvert_v1alpha1_DebugOptions_To_api_DebugOptions(in *v1alpha1.DebugOptions, out *api.DebugOptions, s conversion.Scope) error {
+	if err := Convert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions(&in.NetworkCapture, &out.NetworkCapture, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_TracingOptions_To_api_TracingOptions(&in.Tracing, &out.Tracing, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_LoggingOptions_To_api_LoggingOptions(&in.Logging, &out.Logging, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_DebugOptions_To_api_DebugOptions is an autogenerated conversion function.
+func Convert_v1alpha1_DebugOptions_To_api_DebugOptions(in *v1alpha1.DebugOptions, out *api.DebugOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DebugOptions_To_api_DebugOptions(in, out, s)
+}
+
+func autoConvert_api_DebugOptions_To_v1alpha1_DebugOptions(in *api.DebugOptions, out *v1alpha1.DebugOptions, s conversion.Scope) error {
+	if err := Convert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions(&in.NetworkCapture, &out.NetworkCapture, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TracingOptions_To_v1alpha1_TracingOptions(&in.Tracing, &out.Tracing, s); err != nil {
+		return err
+	}
+	if err := Convert_api_LoggingOptions_To_v1alpha1_LoggingOptions(&in.Logging, &out.Logging, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_DebugOptions_To_v1alpha1_DebugOptions is an autogenerated conversion function.
+func Convert_api_DebugOptions_To_v1alpha1_DebugOptions(in *api.DebugOptions, out *v1alpha1.DebugOptions, s conversion.Scope) error {
+	return autoConvert_api_DebugOptions_To_v1alpha1_DebugOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions(in *v1alpha1.DiskCheckOptions, out *api.DiskCheckOptions, s conversion.Scope) error {
+	out.DaemonSetFootprint = (*resource.Quantity)(unsafe.Pointer(in.DaemonSetFootprint))
+	out.FailurePolicy = api.DiskCheckFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions is an autogenerated conversion function.
+func Convert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions(in *v1alpha1.DiskCheckOptions, out *api.DiskCheckOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions(in, out, s)
+}
+
+func autoConvert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions(in *api.DiskCheckOptions, out *v1alpha1.DiskCheckOptions, s conversion.Scope) error {
+	out.DaemonSetFootprint = (*resource.Quantity)(unsafe.Pointer(in.DaemonSetFootprint))
+	out.FailurePolicy = v1alpha1.DiskCheckFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions is an autogenerated conversion function.
+func Convert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions(in *api.DiskCheckOptions, out *v1alpha1.DiskCheckOptions, s conversion.Scope) error {
+	return autoConvert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_EFAOptions_To_api_EFAOptions(in *v1alpha1.EFAOptions, out *api.EFAOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.HugePages = (*int32)(unsafe.Pointer(in.HugePages))
+	out.ReservedMemory = (*resource.Quantity)(unsafe.Pointer(in.ReservedMemory))
+	return nil
+}
+
+// Convert_v1alpha1_EFAOptions_To_api_EFAOptions is an autogenerated conversion function.
+func Convert_v1alpha1_EFAOptions_To_api_EFAOptions(in *v1alpha1.EFAOptions, out *api.EFAOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EFAOptions_To_api_EFAOptions(in, out, s)
+}
+
+func autoConvert_api_EFAOptions_To_v1alpha1_EFAOptions(in *api.EFAOptions, out *v1alpha1.EFAOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.HugePages = (*int32)(unsafe.Pointer(in.HugePages))
+	out.ReservedMemory = (*resource.Quantity)(unsafe.Pointer(in.ReservedMemory))
+	return nil
+}
+
+// Convert_api_EFAOptions_To_v1alpha1_EFAOptions is an autogenerated conversion function.
+func Convert_api_EFAOptions_To_v1alpha1_EFAOptions(in *api.EFAOptions, out *v1alpha1.EFAOptions, s conversion.Scope) error {
+	return autoConvert_api_EFAOptions_To_v1alpha1_EFAOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides(in *v1alpha1.EndpointOverrides, out *api.EndpointOverrides, s conversion.Scope) error {
+	out.EKS = in.EKS
+	out.EC2 = in.EC2
+	out.STS = in.STS
+	out.ECR = in.ECR
+	return nil
+}
+
+// Convert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides is an autogenerated conversion function.
+func Convert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides(in *v1alpha1.EndpointOverrides, out *api.EndpointOverrides, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EndpointOverrides_To_api_EndpointOverrides(in, out, s)
+}
+
+func autoConvert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides(in *api.EndpointOverrides, out *v1alpha1.EndpointOverrides, s conversion.Scope) error {
+	out.EKS = in.EKS
+	out.EC2 = in.EC2
+	out.STS = in.STS
+	out.ECR = in.ECR
+	return nil
+}
+
+// Convert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides is an autogenerated conversion function.
+func Convert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides(in *api.EndpointOverrides, out *v1alpha1.EndpointOverrides, s conversion.Scope) error {
+	return autoConvert_api_EndpointOverrides_To_v1alpha1_EndpointOverrides(in, out, s)
+}
+
+func autoConvert_v1alpha1_Hook_To_api_Hook(in *v1alpha1.Hook, out *api.Hook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Script = in.Script
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = api.HookFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_v1alpha1_Hook_To_api_Hook is an autogenerated conversion function.
+func Convert_v1alpha1_Hook_To_api_Hook(in *v1alpha1.Hook, out *api.Hook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Hook_To_api_Hook(in, out, s)
+}
+
+func autoConvert_api_Hook_To_v1alpha1_Hook(in *api.Hook, out *v1alpha1.Hook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Script = in.Script
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = v1alpha1.HookFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_api_Hook_To_v1alpha1_Hook is an autogenerated conversion function.
+func Convert_api_Hook_To_v1alpha1_Hook(in *api.Hook, out *v1alpha1.Hook, s conversion.Scope) error {
+	return autoConvert_api_Hook_To_v1alpha1_Hook(in, out, s)
+}
+
+func autoConvert_v1alpha1_HooksOptions_To_api_HooksOptions(in *v1alpha1.HooksOptions, out *api.HooksOptions, s conversion.Scope) error {
+	out.PreInit = *(*[]api.Hook)(unsafe.Pointer(&in.PreInit))
+	out.PostContainerd = *(*[]api.Hook)(unsafe.Pointer(&in.PostContainerd))
+	out.PostKubelet = *(*[]api.Hook)(unsafe.Pointer(&in.PostKubelet))
+	return nil
+}
+
+// Convert_v1alpha1_HooksOptions_To_api_HooksOptions is an autogenerated conversion function.
+func Convert_v1alpha1_HooksOptions_To_api_HooksOptions(in *v1alpha1.HooksOptions, out *api.HooksOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HooksOptions_To_api_HooksOptions(in, out, s)
+}
+
+func autoConvert_api_HooksOptions_To_v1alpha1_HooksOptions(in *api.HooksOptions, out *v1alpha1.HooksOptions, s conversion.Scope) error {
+	out.PreInit = *(*[]v1alpha1.Hook)(unsafe.Pointer(&in.PreInit))
+	out.PostContainerd = *(*[]v1alpha1.Hook)(unsafe.Pointer(&in.PostContainerd))
+	out.PostKubelet = *(*[]v1alpha1.Hook)(unsafe.Pointer(&in.PostKubelet))
+	return nil
+}
+
+// Convert_api_HooksOptions_To_v1alpha1_HooksOptions is an autogenerated conversion function.
+func Convert_api_HooksOptions_To_v1alpha1_HooksOptions(in *api.HooksOptions, out *v1alpha1.HooksOptions, s conversion.Scope) error {
+	return autoConvert_api_HooksOptions_To_v1alpha1_HooksOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_HybridOptions_To_api_HybridOptions(in *v1alpha1.HybridOptions, out *api.HybridOptions, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.NodeIP = in.NodeIP
+	out.Region = in.Region
+	if err := Convert_v1alpha1_SSMOptions_To_api_SSMOptions(&in.SSM, &out.SSM, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions(&in.IAMRolesAnywhere, &out.IAMRolesAnywhere, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_HybridOptions_To_api_HybridOptions is an autogenerated conversion function.
+func Convert_v1alpha1_HybridOptions_To_api_HybridOptions(in *v1alpha1.HybridOptions, out *api.HybridOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HybridOptions_To_api_HybridOptions(in, out, s)
+}
+
+func autoConvert_api_HybridOptions_To_v1alpha1_HybridOptions(in *api.HybridOptions, out *v1alpha1.HybridOptions, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.NodeIP = in.NodeIP
+	out.Region = in.Region
+	if err := Convert_api_SSMOptions_To_v1alpha1_SSMOptions(&in.SSM, &out.SSM, s); err != nil {
+		return err
+	}
+	if err := Convert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions(&in.IAMRolesAnywhere, &out.IAMRolesAnywhere, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_HybridOptions_To_v1alpha1_HybridOptions is an autogenerated conversion function.
+func Convert_api_HybridOptions_To_v1alpha1_HybridOptions(in *api.HybridOptions, out *v1alpha1.HybridOptions, s conversion.Scope) error {
+	return autoConvert_api_HybridOptions_To_v1alpha1_HybridOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions(in *v1alpha1.IAMRolesAnywhereOptions, out *api.IAMRolesAnywhereOptions, s conversion.Scope) error {
+	out.TrustAnchorARN = in.TrustAnchorARN
+	out.ProfileARN = in.ProfileARN
+	out.RoleARN = in.RoleARN
+	out.CertificatePath = in.CertificatePath
+	out.PrivateKeyPath = in.PrivateKeyPath
+	return nil
+}
+
+// Convert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions is an autogenerated conversion function.
+func Convert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions(in *v1alpha1.IAMRolesAnywhereOptions, out *api.IAMRolesAnywhereOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_IAMRolesAnywhereOptions_To_api_IAMRolesAnywhereOptions(in, out, s)
+}
+
+func autoConvert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions(in *api.IAMRolesAnywhereOptions, out *v1alpha1.IAMRolesAnywhereOptions, s conversion.Scope) error {
+	out.TrustAnchorARN = in.TrustAnchorARN
+	out.ProfileARN = in.ProfileARN
+	out.RoleARN = in.RoleARN
+	out.CertificatePath = in.CertificatePath
+	out.PrivateKeyPath = in.PrivateKeyPath
+	return nil
+}
+
+// Convert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions is an autogenerated conversion function.
+func Convert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions(in *api.IAMRolesAnywhereOptions, out *v1alpha1.IAMRolesAnywhereOptions, s conversion.Scope) error {
+	return autoConvert_api_IAMRolesAnywhereOptions_To_v1alpha1_IAMRolesAnywhereOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions(in *v1alpha1.ImageRetentionOptions, out *api.ImageRetentionOptions, s conversion.Scope) error {
+	out.HighThresholdPercent = in.HighThresholdPercent
+	out.LowThresholdPercent = in.LowThresholdPercent
+	out.MinimumAge = (*v1.Duration)(unsafe.Pointer(in.MinimumAge))
+	out.MaximumAge = (*v1.Duration)(unsafe.Pointer(in.MaximumAge))
+	out.PinnedImages = *(*[]string)(unsafe.Pointer(&in.PinnedImages))
+	out.KeepLast = in.KeepLast
+	return nil
+}
+
+// Convert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions(in *v1alpha1.ImageRetentionOptions, out *api.ImageRetentionOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageRetentionOptions_To_api_ImageRetentionOptions(in, out, s)
+}
+
+func autoConvert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions(in *api.ImageRetentionOptions, out *v1alpha1.ImageRetentionOptions, s conversion.Scope) error {
+	out.HighThresholdPercent = in.HighThresholdPercent
+	out.LowThresholdPercent = in.LowThresholdPercent
+	out.MinimumAge = (*v1.Duration)(unsafe.Pointer(in.MinimumAge))
+	out.MaximumAge = (*v1.Duration)(unsafe.Pointer(in.MaximumAge))
+	out.PinnedImages = *(*[]string)(unsafe.Pointer(&in.PinnedImages))
+	out.KeepLast = in.KeepLast
+	return nil
+}
+
+// Convert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions is an autogenerated conversion function.
+func Convert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions(in *api.ImageRetentionOptions, out *v1alpha1.ImageRetentionOptions, s conversion.Scope) error {
+	return autoConvert_api_ImageRetentionOptions_To_v1alpha1_ImageRetentionOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions(in *v1alpha1.InstanceIdentityOptions, out *api.InstanceIdentityOptions, s conversion.Scope) error {
+	out.InstanceID = in.InstanceID
+	out.InstanceType = in.InstanceType
+	out.Region = in.Region
+	out.AvailabilityZone = in.AvailabilityZone
+	return nil
+}
+
+// Convert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions(in *v1alpha1.InstanceIdentityOptions, out *api.InstanceIdentityOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions(in, out, s)
+}
+
+func autoConvert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions(in *api.InstanceIdentityOptions, out *v1alpha1.InstanceIdentityOptions, s conversion.Scope) error {
+	out.InstanceID = in.InstanceID
+	out.InstanceType = in.InstanceType
+	out.Region = in.Region
+	out.AvailabilityZone = in.AvailabilityZone
+	return nil
+}
+
+// Convert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions is an autogenerated conversion function.
+func Convert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions(in *api.InstanceIdentityOptions, out *v1alpha1.InstanceIdentityOptions, s conversion.Scope) error {
+	return autoConvert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.InstanceOptions, out *api.InstanceOptions, s conversion.Scope) error {
+	if err := Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions(&in.Shutdown, &out.Shutdown, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions(&in.TrustStore, &out.TrustStore, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions(&in.PodLogs, &out.PodLogs, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_JournaldOptions_To_api_JournaldOptions(&in.Journald, &out.Journald, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_CgroupOptions_To_api_CgroupOptions(&in.Cgroup, &out.Cgroup, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SwapOptions_To_api_SwapOptions(&in.Swap, &out.Swap, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions(&in.PressureMonitor, &out.PressureMonitor, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions(&in.TimeSync, &out.TimeSync, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_EFAOptions_To_api_EFAOptions(&in.EFA, &out.EFA, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NeuronOptions_To_api_NeuronOptions(&in.Neuron, &out.Neuron, s); err != nil {
+		return err
+	}
+	out.BootstrapProfile = api.BootstrapProfile(in.BootstrapProfile)
+	if err := Convert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions(&in.Tags, &out.Tags, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_InstanceIdentityOptions_To_api_InstanceIdentityOptions(&in.Identity, &out.Identity, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.InstanceOptions, out *api.InstanceOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in, out, s)
+}
+
+func autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOptions, out *v1alpha1.InstanceOptions, s conversion.Scope) error {
+	if err := Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions(&in.Shutdown, &out.Shutdown, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions(&in.TrustStore, &out.TrustStore, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions(&in.PodLogs, &out.PodLogs, s); err != nil {
+		return err
+	}
+	if err := Convert_api_JournaldOptions_To_v1alpha1_JournaldOptions(&in.Journald, &out.Journald, s); err != nil {
+		return err
+	}
+	if err := Convert_api_CgroupOptions_To_v1alpha1_CgroupOptions(&in.Cgroup, &out.Cgroup, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SwapOptions_To_v1alpha1_SwapOptions(&in.Swap, &out.Swap, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions(&in.PressureMonitor, &out.PressureMonitor, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions(&in.TimeSync, &out.TimeSync, s); err != nil {
+		return err
+	}
+	if err := Convert_api_EFAOptions_To_v1alpha1_EFAOptions(&in.EFA, &out.EFA, s); err != nil {
+		return err
+	}
+	if err := Convert_api_NeuronOptions_To_v1alpha1_NeuronOptions(&in.Neuron, &out.Neuron, s); err != nil {
+		return err
+	}
+	out.BootstrapProfile = v1alpha1.BootstrapProfile(in.BootstrapProfile)
+	if err := Convert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions(&in.Tags, &out.Tags, s); err != nil {
+		return err
+	}
+	if err := Convert_api_InstanceIdentityOptions_To_v1alpha1_InstanceIdentityOptions(&in.Identity, &out.Identity, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions is an autogenerated conversion function.
+func Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOptions, out *v1alpha1.InstanceOptions, s conversion.Scope) error {
+	return autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions(in *v1alpha1.InstanceTagsOptions, out *api.InstanceTagsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Prefix = in.Prefix
+	out.Source = api.InstanceTagsSource(in.Source)
+	return nil
+}
+
+// Convert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions(in *v1alpha1.InstanceTagsOptions, out *api.InstanceTagsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InstanceTagsOptions_To_api_InstanceTagsOptions(in, out, s)
+}
+
+func autoConvert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions(in *api.InstanceTagsOptions, out *v1alpha1.InstanceTagsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Prefix = in.Prefix
+	out.Source = v1alpha1.InstanceTagsSource(in.Source)
+	return nil
+}
+
+// Convert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions is an autogenerated conversion function.
+func Convert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions(in *api.InstanceTagsOptions, out *v1alpha1.InstanceTagsOptions, s conversion.Scope) error {
+	return autoConvert_api_InstanceTagsOptions_To_v1alpha1_InstanceTagsOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_JournaldOptions_To_api_JournaldOptions(in *v1alpha1.JournaldOptions, out *api.JournaldOptions, s conversion.Scope) error {
+	out.RateLimitInterval = (*v1.Duration)(unsafe.Pointer(in.RateLimitInterval))
+	out.RateLimitBurst = (*int32)(unsafe.Pointer(in.RateLimitBurst))
+	out.SystemMaxUse = (*resource.Quantity)(unsafe.Pointer(in.SystemMaxUse))
+	return nil
+}
+
+// Convert_v1alpha1_JournaldOptions_To_api_JournaldOptions is an autogenerated conversion function.
+func Convert_v1alpha1_JournaldOptions_To_api_JournaldOptions(in *v1alpha1.JournaldOptions, out *api.JournaldOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_JournaldOptions_To_api_JournaldOptions(in, out, s)
+}
+
+func autoConvert_api_JournaldOptions_To_v1alpha1_JournaldOptions(in *api.JournaldOptions, out *v1alpha1.JournaldOptions, s conversion.Scope) error {
+	out.RateLimitInterval = (*v1.Duration)(unsafe.Pointer(in.RateLimitInterval))
+	out.RateLimitBurst = (*int32)(unsafe.Pointer(in.RateLimitBurst))
+	out.SystemMaxUse = (*resource.Quantity)(unsafe.Pointer(in.SystemMaxUse))
+	return nil
+}
+
+// Convert_api_JournaldOptions_To_v1alpha1_JournaldOptions is an autogenerated conversion function.
+func Convert_api_JournaldOptions_To_v1alpha1_JournaldOptions(in *api.JournaldOptions, out *v1alpha1.JournaldOptions, s conversion.Scope) error {
+	return autoConvert_api_JournaldOptions_To_v1alpha1_JournaldOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions(in *v1alpha1.KubeletAuthenticationOptions, out *api.KubeletAuthenticationOptions, s conversion.Scope) error {
+	out.RoleARN = in.RoleARN
+	out.SessionName = in.SessionName
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions is an autogenerated conversion function.
+func Convert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions(in *v1alpha1.KubeletAuthenticationOptions, out *api.KubeletAuthenticationOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions(in, out, s)
+}
+
+func autoConvert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions(in *api.KubeletAuthenticationOptions, out *v1alpha1.KubeletAuthenticationOptions, s conversion.Scope) error {
+	out.RoleARN = in.RoleARN
+	out.SessionName = in.SessionName
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions is an autogenerated conversion function.
+func Convert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions(in *api.KubeletAuthenticationOptions, out *v1alpha1.KubeletAuthenticationOptions, s conversion.Scope) error {
+	return autoConvert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
+	out.Config = *(*api.InlineDocument)(unsafe.Pointer(&in.Config))
+	out.Flags = *(*api.KubeletFlags)(unsafe.Pointer(&in.Flags))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.SwapBehavior = api.SwapBehavior(in.SwapBehavior)
+	if err := Convert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions(&in.TokenCache, &out.TokenCache, s); err != nil {
+		return err
+	}
+	out.NodeLabels = *(*map[string]string)(unsafe.Pointer(&in.NodeLabels))
+	out.NodeTaints = *(*[]api.Taint)(unsafe.Pointer(&in.NodeTaints))
+	if err := Convert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions(&in.ServingCertificate, &out.ServingCertificate, s); err != nil {
+		return err
+	}
+	out.ClusterDNS = *(*[]string)(unsafe.Pointer(&in.ClusterDNS))
+	if err := Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(&in.NodeIP, &out.NodeIP, s); err != nil {
+		return err
+	}
+	out.StaticPods = *(*[]api.StaticPod)(unsafe.Pointer(&in.StaticPods))
+	out.AuthenticationMode = api.KubeletAuthenticationMode(in.AuthenticationMode)
+	if err := Convert_v1alpha1_KubeletAuthenticationOptions_To_api_KubeletAuthenticationOptions(&in.Authentication, &out.Authentication, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_AutoLabelsOptions_To_api_AutoLabelsOptions(&in.AutoLabels, &out.AutoLabels, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions(&in.StatusAnnotations, &out.StatusAnnotations, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions(&in.RemoteConfig, &out.RemoteConfig, s); err != nil {
+		return err
+	}
+	out.CPUManagerPolicy = api.CPUManagerPolicy(in.CPUManagerPolicy)
+	out.TopologyManagerPolicy = api.TopologyManagerPolicy(in.TopologyManagerPolicy)
+	out.MemoryManagerPolicy = api.MemoryManagerPolicy(in.MemoryManagerPolicy)
+	out.ReservedCPUs = in.ReservedCPUs
+	out.ReservedResourcesPolicy = api.ReservedResourcesPolicy(in.ReservedResourcesPolicy)
+	if err := Convert_v1alpha1_DiskCheckOptions_To_api_DiskCheckOptions(&in.DiskCheck, &out.DiskCheck, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions is an autogenerated conversion function.
+func Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in, out, s)
+}
+
+func autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
+	out.Config = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Config))
+	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.SwapBehavior = v1alpha1.SwapBehavior(in.SwapBehavior)
+	if err := Convert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions(&in.TokenCache, &out.TokenCache, s); err != nil {
+		return err
+	}
+	out.NodeLabels = *(*map[string]string)(unsafe.Pointer(&in.NodeLabels))
+	out.NodeTaints = *(*[]v1alpha1.Taint)(unsafe.Pointer(&in.NodeTaints))
+	if err := Convert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions(&in.ServingCertificate, &out.ServingCertificate, s); err != nil {
+		return err
+	}
+	out.ClusterDNS = *(*[]string)(unsafe.Pointer(&in.ClusterDNS))
+	if err := Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(&in.NodeIP, &out.NodeIP, s); err != nil {
+		return err
+	}
+	out.StaticPods = *(*[]v1alpha1.StaticPod)(unsafe.Pointer(&in.StaticPods))
+	out.AuthenticationMode = v1alpha1.KubeletAuthenticationMode(in.AuthenticationMode)
+	if err := Convert_api_KubeletAuthenticationOptions_To_v1alpha1_KubeletAuthenticationOptions(&in.Authentication, &out.Authentication, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_api_AutoLabelsOptions_To_v1alpha1_AutoLabelsOptions(&in.AutoLabels, &out.AutoLabels, s); err != nil {
+		return err
+	}
+	if err := Convert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions(&in.StatusAnnotations, &out.StatusAnnotations, s); err != nil {
+		return err
+	}
+	if err := Convert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions(&in.RemoteConfig, &out.RemoteConfig, s); err != nil {
+		return err
+	}
+	out.CPUManagerPolicy = v1alpha1.CPUManagerPolicy(in.CPUManagerPolicy)
+	out.TopologyManagerPolicy = v1alpha1.TopologyManagerPolicy(in.TopologyManagerPolicy)
+	out.MemoryManagerPolicy = v1alpha1.MemoryManagerPolicy(in.MemoryManagerPolicy)
+	out.ReservedCPUs = in.ReservedCPUs
+	out.ReservedResourcesPolicy = v1alpha1.ReservedResourcesPolicy(in.ReservedResourcesPolicy)
+	if err := Convert_api_DiskCheckOptions_To_v1alpha1_DiskCheckOptions(&in.DiskCheck, &out.DiskCheck, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions is an autogenerated conversion function.
+func Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
+	return autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions(in *v1alpha1.KubeletRemoteConfigOptions, out *api.KubeletRemoteConfigOptions, s conversion.Scope) error {
+	out.Source = in.Source
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.MinRestartInterval = (*v1.Duration)(unsafe.Pointer(in.MinRestartInterval))
+	return nil
+}
+
+// Convert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions is an autogenerated conversion function.
+func Convert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions(in *v1alpha1.KubeletRemoteConfigOptions, out *api.KubeletRemoteConfigOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeletRemoteConfigOptions_To_api_KubeletRemoteConfigOptions(in, out, s)
+}
+
+func autoConvert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions(in *api.KubeletRemoteConfigOptions, out *v1alpha1.KubeletRemoteConfigOptions, s conversion.Scope) error {
+	out.Source = in.Source
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.MinRestartInterval = (*v1.Duration)(unsafe.Pointer(in.MinRestartInterval))
+	return nil
+}
+
+// Convert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions is an autogenerated conversion function.
+func Convert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions(in *api.KubeletRemoteConfigOptions, out *v1alpha1.KubeletRemoteConfigOptions, s conversion.Scope) error {
+	return autoConvert_api_KubeletRemoteConfigOptions_To_v1alpha1_KubeletRemoteConfigOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in *v1alpha1.LocalStorageOptions, out *api.LocalStorageOptions, s conversion.Scope) error {
+	out.Strategy = api.LocalStorageStrategy(in.Strategy)
+	out.MountPath = in.MountPath
+	out.DisabledMounts = *(*[]api.DisabledMount)(unsafe.Pointer(&in.DisabledMounts))
+	return nil
+}
+
+// Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions is an autogenerated conversion function.
+func Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in *v1alpha1.LocalStorageOptions, out *api.LocalStorageOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in, out, s)
+}
+
+func autoConvert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in *api.LocalStorageOptions, out *v1alpha1.LocalStorageOptions, s conversion.Scope) error {
+	out.Strategy = v1alpha1.LocalStorageStrategy(in.Strategy)
+	out.MountPath = in.MountPath
+	out.DisabledMounts = *(*[]v1alpha1.DisabledMount)(unsafe.Pointer(&in.DisabledMounts))
+	return nil
+}
+
+// Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions is an autogenerated conversion function.
+func Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in *api.LocalStorageOptions, out *v1alpha1.LocalStorageOptions, s conversion.Scope) error {
+	return autoConvert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoggingOptions_To_api_LoggingOptions(in *v1alpha1.LoggingOptions, out *api.LoggingOptions, s conversion.Scope) error {
+	if err := Convert_v1alpha1_CloudWatchLoggingOptions_To_api_CloudWatchLoggingOptions(&in.CloudWatch, &out.CloudWatch, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_LoggingOptions_To_api_LoggingOptions is an autogenerated conversion function.
+func Convert_v1alpha1_LoggingOptions_To_api_LoggingOptions(in *v1alpha1.LoggingOptions, out *api.LoggingOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoggingOptions_To_api_LoggingOptions(in, out, s)
+}
+
+func autoConvert_api_LoggingOptions_To_v1alpha1_LoggingOptions(in *api.LoggingOptions, out *v1alpha1.LoggingOptions, s conversion.Scope) error {
+	if err := Convert_api_CloudWatchLoggingOptions_To_v1alpha1_CloudWatchLoggingOptions(&in.CloudWatch, &out.CloudWatch, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_LoggingOptions_To_v1alpha1_LoggingOptions is an autogenerated conversion function.
+func Convert_api_LoggingOptions_To_v1alpha1_LoggingOptions(in *api.LoggingOptions, out *v1alpha1.LoggingOptions, s conversion.Scope) error {
+	return autoConvert_api_LoggingOptions_To_v1alpha1_LoggingOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions(in *v1alpha1.MonitoringOptions, out *api.MonitoringOptions, s conversion.Scope) error {
+	if err := Convert_v1alpha1_CloudWatchAgentOptions_To_api_CloudWatchAgentOptions(&in.CloudWatchAgent, &out.CloudWatchAgent, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions is an autogenerated conversion function.
+func Convert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions(in *v1alpha1.MonitoringOptions, out *api.MonitoringOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions(in, out, s)
+}
+
+func autoConvert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions(in *api.MonitoringOptions, out *v1alpha1.MonitoringOptions, s conversion.Scope) error {
+	if err := Convert_api_CloudWatchAgentOptions_To_v1alpha1_CloudWatchAgentOptions(&in.CloudWatchAgent, &out.CloudWatchAgent, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions is an autogenerated conversion function.
+func Convert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions(in *api.MonitoringOptions, out *v1alpha1.MonitoringOptions, s conversion.Scope) error {
+	return autoConvert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions(in *v1alpha1.NetworkCaptureOptions, out *api.NetworkCaptureOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions(in *v1alpha1.NetworkCaptureOptions, out *api.NetworkCaptureOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkCaptureOptions_To_api_NetworkCaptureOptions(in, out, s)
+}
+
+func autoConvert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions(in *api.NetworkCaptureOptions, out *v1alpha1.NetworkCaptureOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions is an autogenerated conversion function.
+func Convert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions(in *api.NetworkCaptureOptions, out *v1alpha1.NetworkCaptureOptions, s conversion.Scope) error {
+	return autoConvert_api_NetworkCaptureOptions_To_v1alpha1_NetworkCaptureOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_NeuronOptions_To_api_NeuronOptions(in *v1alpha1.NeuronOptions, out *api.NeuronOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.HugePages = (*int32)(unsafe.Pointer(in.HugePages))
+	out.ReservedMemory = (*resource.Quantity)(unsafe.Pointer(in.ReservedMemory))
+	return nil
+}
+
+// Convert_v1alpha1_NeuronOptions_To_api_NeuronOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NeuronOptions_To_api_NeuronOptions(in *v1alpha1.NeuronOptions, out *api.NeuronOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NeuronOptions_To_api_NeuronOptions(in, out, s)
+}
+
+func autoConvert_api_NeuronOptions_To_v1alpha1_NeuronOptions(in *api.NeuronOptions, out *v1alpha1.NeuronOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.HugePages = (*int32)(unsafe.Pointer(in.HugePages))
+	out.ReservedMemory = (*resource.Quantity)(unsafe.Pointer(in.ReservedMemory))
+	return nil
+}
+
+// Convert_api_NeuronOptions_To_v1alpha1_NeuronOptions is an autogenerated conversion function.
+func Convert_api_NeuronOptions_To_v1alpha1_NeuronOptions(in *api.NeuronOptions, out *v1alpha1.NeuronOptions, s conversion.Scope) error {
+	return autoConvert_api_NeuronOptions_To_v1alpha1_NeuronOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions(in *v1alpha1.NetworkingOptions, out *api.NetworkingOptions, s conversion.Scope) error {
+	out.ExcludeInterfaces = *(*[]string)(unsafe.Pointer(&in.ExcludeInterfaces))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions(in *v1alpha1.NetworkingOptions, out *api.NetworkingOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions(in, out, s)
+}
+
+func autoConvert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions(in *api.NetworkingOptions, out *v1alpha1.NetworkingOptions, s conversion.Scope) error {
+	out.ExcludeInterfaces = *(*[]string)(unsafe.Pointer(&in.ExcludeInterfaces))
+	return nil
+}
+
+// Convert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions is an autogenerated conversion function.
+func Convert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions(in *api.NetworkingOptions, out *v1alpha1.NetworkingOptions, s conversion.Scope) error {
+	return autoConvert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeConfig_To_api_NodeConfig(in *v1alpha1.NodeConfig, out *api.NodeConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_NodeConfig_To_api_NodeConfig is an autogenerated conversion function.
+func Convert_v1alpha1_NodeConfig_To_api_NodeConfig(in *v1alpha1.NodeConfig, out *api.NodeConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeConfig_To_api_NodeConfig(in, out, s)
+}
+
+func autoConvert_api_NodeConfig_To_v1alpha1_NodeConfig(in *api.NodeConfig, out *v1alpha1.NodeConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	// INFO: in.Status opted out of conversion generation
+	return nil
+}
+
+// Convert_api_NodeConfig_To_v1alpha1_NodeConfig is an autogenerated conversion function.
+func Convert_api_NodeConfig_To_v1alpha1_NodeConfig(in *api.NodeConfig, out *v1alpha1.NodeConfig, s conversion.Scope) error {
+	return autoConvert_api_NodeConfig_To_v1alpha1_NodeConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in *v1alpha1.NodeConfigList, out *api.NodeConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]api.NodeConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_NodeConfig_To_api_NodeConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_NodeConfigList_To_api_NodeConfigList is an autogenerated conversion function.
+func Convert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in *v1alpha1.NodeConfigList, out *api.NodeConfigList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in, out, s)
+}
+
+func autoConvert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in *api.NodeConfigList, out *v1alpha1.NodeConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha1.NodeConfig, len(*in))
+		for i := range *in {
+			if err := Convert_api_NodeConfig_To_v1alpha1_NodeConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_api_NodeConfigList_To_v1alpha1_NodeConfigList is an autogenerated conversion function.
+func Convert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in *api.NodeConfigList, out *v1alpha1.NodeConfigList, s conversion.Scope) error {
+	return autoConvert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1alpha1.NodeConfigSpec, out *api.NodeConfigSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(&in.Cluster, &out.Cluster, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(&in.Containerd, &out.Containerd, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(&in.Instance, &out.Instance, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
+		return err
+	}
+	out.FeatureGates = *(*map[api.Feature]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.NodeProvider = api.NodeProvider(in.NodeProvider)
+	if err := Convert_v1alpha1_HybridOptions_To_api_HybridOptions(&in.Hybrid, &out.Hybrid, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_DebugOptions_To_api_DebugOptions(&in.Debug, &out.Debug, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ProxyOptions_To_api_ProxyOptions(&in.Proxy, &out.Proxy, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SecurityOptions_To_api_SecurityOptions(&in.Security, &out.Security, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_HooksOptions_To_api_HooksOptions(&in.Hooks, &out.Hooks, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ComponentsOptions_To_api_ComponentsOptions(&in.Components, &out.Components, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NetworkingOptions_To_api_NetworkingOptions(&in.Networking, &out.Networking, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_MonitoringOptions_To_api_MonitoringOptions(&in.Monitoring, &out.Monitoring, s); err != nil {
+		return err
+	}
+	out.Offline = in.Offline
+	if err := Convert_v1alpha1_SystemOptions_To_api_SystemOptions(&in.System, &out.System, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1alpha1.NodeConfigSpec, out *api.NodeConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in, out, s)
+}
+
+func autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1alpha1.NodeConfigSpec, s conversion.Scope) error {
+	if err := Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(&in.Cluster, &out.Cluster, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(&in.Containerd, &out.Containerd, s); err != nil {
+		return err
+	}
+	if err := Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(&in.Instance, &out.Instance, s); err != nil {
+		return err
+	}
+	if err := Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
+		return err
+	}
+	out.FeatureGates = *(*map[v1alpha1.Feature]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.NodeProvider = v1alpha1.NodeProvider(in.NodeProvider)
+	if err := Convert_api_HybridOptions_To_v1alpha1_HybridOptions(&in.Hybrid, &out.Hybrid, s); err != nil {
+		return err
+	}
+	if err := Convert_api_DebugOptions_To_v1alpha1_DebugOptions(&in.Debug, &out.Debug, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ProxyOptions_To_v1alpha1_ProxyOptions(&in.Proxy, &out.Proxy, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SecurityOptions_To_v1alpha1_SecurityOptions(&in.Security, &out.Security, s); err != nil {
+		return err
+	}
+	if err := Convert_api_HooksOptions_To_v1alpha1_HooksOptions(&in.Hooks, &out.Hooks, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ComponentsOptions_To_v1alpha1_ComponentsOptions(&in.Components, &out.Components, s); err != nil {
+		return err
+	}
+	if err := Convert_api_NetworkingOptions_To_v1alpha1_NetworkingOptions(&in.Networking, &out.Networking, s); err != nil {
+		return err
+	}
+	if err := Convert_api_MonitoringOptions_To_v1alpha1_MonitoringOptions(&in.Monitoring, &out.Monitoring, s); err != nil {
+		return err
+	}
+	out.Offline = in.Offline
+	if err := Convert_api_SystemOptions_To_v1alpha1_SystemOptions(&in.System, &out.System, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec is an autogenerated conversion function.
+func Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1alpha1.NodeConfigSpec, s conversion.Scope) error {
+	return autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in *v1alpha1.NodeIPOptions, out *api.NodeIPOptions, s conversion.Scope) error {
+	out.Policy = api.NodeIPPolicy(in.Policy)
+	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	return nil
+}
+
+// Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in *v1alpha1.NodeIPOptions, out *api.NodeIPOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in, out, s)
+}
+
+func autoConvert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in *api.NodeIPOptions, out *v1alpha1.NodeIPOptions, s conversion.Scope) error {
+	out.Policy = v1alpha1.NodeIPPolicy(in.Policy)
+	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	return nil
+}
+
+// Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions is an autogenerated conversion function.
+func Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in *api.NodeIPOptions, out *v1alpha1.NodeIPOptions, s conversion.Scope) error {
+	return autoConvert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions(in *v1alpha1.PodLogsForwarderOptions, out *api.PodLogsForwarderOptions, s conversion.Scope) error {
+	out.CloudWatchLogGroup = in.CloudWatchLogGroup
+	return nil
+}
+
+// Convert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions(in *v1alpha1.PodLogsForwarderOptions, out *api.PodLogsForwarderOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions(in, out, s)
+}
+
+func autoConvert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions(in *api.PodLogsForwarderOptions, out *v1alpha1.PodLogsForwarderOptions, s conversion.Scope) error {
+	out.CloudWatchLogGroup = in.CloudWatchLogGroup
+	return nil
+}
+
+// Convert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions is an autogenerated conversion function.
+func Convert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions(in *api.PodLogsForwarderOptions, out *v1alpha1.PodLogsForwarderOptions, s conversion.Scope) error {
+	return autoConvert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions(in *v1alpha1.PodLogsOptions, out *api.PodLogsOptions, s conversion.Scope) error {
+	out.Storage = api.PodLogsStorage(in.Storage)
+	out.MemoryLimit = (*resource.Quantity)(unsafe.Pointer(in.MemoryLimit))
+	out.MaxFileSize = (*resource.Quantity)(unsafe.Pointer(in.MaxFileSize))
+	out.MaxFiles = (*int32)(unsafe.Pointer(in.MaxFiles))
+	if err := Convert_v1alpha1_PodLogsForwarderOptions_To_api_PodLogsForwarderOptions(&in.Forwarder, &out.Forwarder, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions(in *v1alpha1.PodLogsOptions, out *api.PodLogsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PodLogsOptions_To_api_PodLogsOptions(in, out, s)
+}
+
+func autoConvert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions(in *api.PodLogsOptions, out *v1alpha1.PodLogsOptions, s conversion.Scope) error {
+	out.Storage = v1alpha1.PodLogsStorage(in.Storage)
+	out.MemoryLimit = (*resource.Quantity)(unsafe.Pointer(in.MemoryLimit))
+	out.MaxFileSize = (*resource.Quantity)(unsafe.Pointer(in.MaxFileSize))
+	out.MaxFiles = (*int32)(unsafe.Pointer(in.MaxFiles))
+	if err := Convert_api_PodLogsForwarderOptions_To_v1alpha1_PodLogsForwarderOptions(&in.Forwarder, &out.Forwarder, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions is an autogenerated conversion function.
+func Convert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions(in *api.PodLogsOptions, out *v1alpha1.PodLogsOptions, s conversion.Scope) error {
+	return autoConvert_api_PodLogsOptions_To_v1alpha1_PodLogsOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions(in *v1alpha1.PrePullImagesOptions, out *api.PrePullImagesOptions, s conversion.Scope) error {
+	out.Images = *(*[]string)(unsafe.Pointer(&in.Images))
+	out.ImageListURL = in.ImageListURL
+	out.Concurrency = in.Concurrency
+	out.FailOpen = in.FailOpen
+	return nil
+}
+
+// Convert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions(in *v1alpha1.PrePullImagesOptions, out *api.PrePullImagesOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrePullImagesOptions_To_api_PrePullImagesOptions(in, out, s)
+}
+
+func autoConvert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions(in *api.PrePullImagesOptions, out *v1alpha1.PrePullImagesOptions, s conversion.Scope) error {
+	out.Images = *(*[]string)(unsafe.Pointer(&in.Images))
+	out.ImageListURL = in.ImageListURL
+	out.Concurrency = in.Concurrency
+	out.FailOpen = in.FailOpen
+	return nil
+}
+
+// Convert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions is an autogenerated conversion function.
+func Convert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions(in *api.PrePullImagesOptions, out *v1alpha1.PrePullImagesOptions, s conversion.Scope) error {
+	return autoConvert_api_PrePullImagesOptions_To_v1alpha1_PrePullImagesOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions(in *v1alpha1.PressureMonitorOptions, out *api.PressureMonitorOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MemoryThreshold = in.MemoryThreshold
+	out.Action = api.PressureAction(in.Action)
+	out.MetricsPort = in.MetricsPort
+	return nil
+}
+
+// Convert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions(in *v1alpha1.PressureMonitorOptions, out *api.PressureMonitorOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PressureMonitorOptions_To_api_PressureMonitorOptions(in, out, s)
+}
+
+func autoConvert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions(in *api.PressureMonitorOptions, out *v1alpha1.PressureMonitorOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MemoryThreshold = in.MemoryThreshold
+	out.Action = v1alpha1.PressureAction(in.Action)
+	out.MetricsPort = in.MetricsPort
+	return nil
+}
+
+// Convert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions is an autogenerated conversion function.
+func Convert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions(in *api.PressureMonitorOptions, out *v1alpha1.PressureMonitorOptions, s conversion.Scope) error {
+	return autoConvert_api_PressureMonitorOptions_To_v1alpha1_PressureMonitorOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProxyOptions_To_api_ProxyOptions(in *v1alpha1.ProxyOptions, out *api.ProxyOptions, s conversion.Scope) error {
+	out.HTTPProxy = in.HTTPProxy
+	out.HTTPSProxy = in.HTTPSProxy
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	return nil
+}
+
+// Convert_v1alpha1_ProxyOptions_To_api_ProxyOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ProxyOptions_To_api_ProxyOptions(in *v1alpha1.ProxyOptions, out *api.ProxyOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProxyOptions_To_api_ProxyOptions(in, out, s)
+}
+
+func autoConvert_api_ProxyOptions_To_v1alpha1_ProxyOptions(in *api.ProxyOptions, out *v1alpha1.ProxyOptions, s conversion.Scope) error {
+	out.HTTPProxy = in.HTTPProxy
+	out.HTTPSProxy = in.HTTPSProxy
+	out.NoProxy = *(*[]string)(unsafe.Pointer(&in.NoProxy))
+	return nil
+}
+
+// Convert_api_ProxyOptions_To_v1alpha1_ProxyOptions is an autogenerated conversion function.
+func Convert_api_ProxyOptions_To_v1alpha1_ProxyOptions(in *api.ProxyOptions, out *v1alpha1.ProxyOptions, s conversion.Scope) error {
+	return autoConvert_api_ProxyOptions_To_v1alpha1_ProxyOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions(in *v1alpha1.ReadinessOptions, out *api.ReadinessOptions, s conversion.Scope) error {
+	out.WaitForReady = in.WaitForReady
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions(in *v1alpha1.ReadinessOptions, out *api.ReadinessOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReadinessOptions_To_api_ReadinessOptions(in, out, s)
+}
+
+func autoConvert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions(in *api.ReadinessOptions, out *v1alpha1.ReadinessOptions, s conversion.Scope) error {
+	out.WaitForReady = in.WaitForReady
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions is an autogenerated conversion function.
+func Convert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions(in *api.ReadinessOptions, out *v1alpha1.ReadinessOptions, s conversion.Scope) error {
+	return autoConvert_api_ReadinessOptions_To_v1alpha1_ReadinessOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryCredential_To_api_RegistryCredential(in *v1alpha1.RegistryCredential, out *api.RegistryCredential, s conversion.Scope) error {
+	out.Registry = in.Registry
+	out.SecretARN = in.SecretARN
+	return nil
+}
+
+// Convert_v1alpha1_RegistryCredential_To_api_RegistryCredential is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryCredential_To_api_RegistryCredential(in *v1alpha1.RegistryCredential, out *api.RegistryCredential, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryCredential_To_api_RegistryCredential(in, out, s)
+}
+
+func autoConvert_api_RegistryCredential_To_v1alpha1_RegistryCredential(in *api.RegistryCredential, out *v1alpha1.RegistryCredential, s conversion.Scope) error {
+	out.Registry = in.Registry
+	out.SecretARN = in.SecretARN
+	return nil
+}
+
+// Convert_api_RegistryCredential_To_v1alpha1_RegistryCredential is an autogenerated conversion function.
+func Convert_api_RegistryCredential_To_v1alpha1_RegistryCredential(in *api.RegistryCredential, out *v1alpha1.RegistryCredential, s conversion.Scope) error {
+	return autoConvert_api_RegistryCredential_To_v1alpha1_RegistryCredential(in, out, s)
+}
+
+func autoConvert_v1alpha1_RunscOptions_To_api_RunscOptions(in *v1alpha1.RunscOptions, out *api.RunscOptions, s conversion.Scope) error {
+	out.Platform = api.RunscPlatform(in.Platform)
+	out.Network = api.RunscNetwork(in.Network)
+	out.Overlay = api.RunscOverlay(in.Overlay)
+	return nil
+}
+
+// Convert_v1alpha1_RunscOptions_To_api_RunscOptions is an autogenerated conversion function.
+func Convert_v1alpha1_RunscOptions_To_api_RunscOptions(in *v1alpha1.RunscOptions, out *api.RunscOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RunscOptions_To_api_RunscOptions(in, out, s)
+}
+
+func autoConvert_api_RunscOptions_To_v1alpha1_RunscOptions(in *api.RunscOptions, out *v1alpha1.RunscOptions, s conversion.Scope) error {
+	out.Platform = v1alpha1.RunscPlatform(in.Platform)
+	out.Network = v1alpha1.RunscNetwork(in.Network)
+	out.Overlay = v1alpha1.RunscOverlay(in.Overlay)
+	return nil
+}
+
+// Convert_api_RunscOptions_To_v1alpha1_RunscOptions is an autogenerated conversion function.
+func Convert_api_RunscOptions_To_v1alpha1_RunscOptions(in *api.RunscOptions, out *v1alpha1.RunscOptions, s conversion.Scope) error {
+	return autoConvert_api_RunscOptions_To_v1alpha1_RunscOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SOCIOptions_To_api_SOCIOptions(in *v1alpha1.SOCIOptions, out *api.SOCIOptions, s conversion.Scope) error {
+	out.MaxConcurrentDownloads = in.MaxConcurrentDownloads
+	out.MaxConcurrentDownloadsPerImage = in.MaxConcurrentDownloadsPerImage
+	out.MaxConcurrentUnpacksPerImage = in.MaxConcurrentUnpacksPerImage
+	out.ContentStore = api.SOCIContentStore(in.ContentStore)
+	out.Fallback = api.SOCIFallback(in.Fallback)
+	return nil
+}
+
+// Convert_v1alpha1_SOCIOptions_To_api_SOCIOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SOCIOptions_To_api_SOCIOptions(in *v1alpha1.SOCIOptions, out *api.SOCIOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SOCIOptions_To_api_SOCIOptions(in, out, s)
+}
+
+func autoConvert_api_SOCIOptions_To_v1alpha1_SOCIOptions(in *api.SOCIOptions, out *v1alpha1.SOCIOptions, s conversion.Scope) error {
+	out.MaxConcurrentDownloads = in.MaxConcurrentDownloads
+	out.MaxConcurrentDownloadsPerImage = in.MaxConcurrentDownloadsPerImage
+	out.MaxConcurrentUnpacksPerImage = in.MaxConcurrentUnpacksPerImage
+	out.ContentStore = v1alpha1.SOCIContentStore(in.ContentStore)
+	out.Fallback = v1alpha1.SOCIFallback(in.Fallback)
+	return nil
+}
+
+// Convert_api_SOCIOptions_To_v1alpha1_SOCIOptions is an autogenerated conversion function.
+func Convert_api_SOCIOptions_To_v1alpha1_SOCIOptions(in *api.SOCIOptions, out *v1alpha1.SOCIOptions, s conversion.Scope) error {
+	return autoConvert_api_SOCIOptions_To_v1alpha1_SOCIOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SSMOptions_To_api_SSMOptions(in *v1alpha1.SSMOptions, out *api.SSMOptions, s conversion.Scope) error {
+	out.ActivationCode = in.ActivationCode
+	out.ActivationID = in.ActivationID
+	return nil
+}
+
+// Convert_v1alpha1_SSMOptions_To_api_SSMOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SSMOptions_To_api_SSMOptions(in *v1alpha1.SSMOptions, out *api.SSMOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SSMOptions_To_api_SSMOptions(in, out, s)
+}
+
+func autoConvert_api_SSMOptions_To_v1alpha1_SSMOptions(in *api.SSMOptions, out *v1alpha1.SSMOptions, s conversion.Scope) error {
+	out.ActivationCode = in.ActivationCode
+	out.ActivationID = in.ActivationID
+	return nil
+}
+
+// Convert_api_SSMOptions_To_v1alpha1_SSMOptions is an autogenerated conversion function.
+func Convert_api_SSMOptions_To_v1alpha1_SSMOptions(in *api.SSMOptions, out *v1alpha1.SSMOptions, s conversion.Scope) error {
+	return autoConvert_api_SSMOptions_To_v1alpha1_SSMOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions(in *v1alpha1.SeccompDefaultOptions, out *api.SeccompDefaultOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.DefaultProfile = in.DefaultProfile
+	out.Profiles = *(*[]api.SeccompProfile)(unsafe.Pointer(&in.Profiles))
+	return nil
+}
+
+// Convert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions(in *v1alpha1.SeccompDefaultOptions, out *api.SeccompDefaultOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions(in, out, s)
+}
+
+func autoConvert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions(in *api.SeccompDefaultOptions, out *v1alpha1.SeccompDefaultOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.DefaultProfile = in.DefaultProfile
+	out.Profiles = *(*[]v1alpha1.SeccompProfile)(unsafe.Pointer(&in.Profiles))
+	return nil
+}
+
+// Convert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions is an autogenerated conversion function.
+func Convert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions(in *api.SeccompDefaultOptions, out *v1alpha1.SeccompDefaultOptions, s conversion.Scope) error {
+	return autoConvert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SeccompProfile_To_api_SeccompProfile(in *v1alpha1.SeccompProfile, out *api.SeccompProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Profile = in.Profile
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	return nil
+}
+
+// Convert_v1alpha1_SeccompProfile_To_api_SeccompProfile is an autogenerated conversion function.
+func Convert_v1alpha1_SeccompProfile_To_api_SeccompProfile(in *v1alpha1.SeccompProfile, out *api.SeccompProfile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SeccompProfile_To_api_SeccompProfile(in, out, s)
+}
+
+func autoConvert_api_SeccompProfile_To_v1alpha1_SeccompProfile(in *api.SeccompProfile, out *v1alpha1.SeccompProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Profile = in.Profile
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	return nil
+}
+
+// Convert_api_SeccompProfile_To_v1alpha1_SeccompProfile is an autogenerated conversion function.
+func Convert_api_SeccompProfile_To_v1alpha1_SeccompProfile(in *api.SeccompProfile, out *v1alpha1.SeccompProfile, s conversion.Scope) error {
+	return autoConvert_api_SeccompProfile_To_v1alpha1_SeccompProfile(in, out, s)
+}
+
+func autoConvert_v1alpha1_SecurityOptions_To_api_SecurityOptions(in *v1alpha1.SecurityOptions, out *api.SecurityOptions, s conversion.Scope) error {
+	out.HardeningProfile = api.HardeningProfile(in.HardeningProfile)
+	if err := Convert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions(&in.UserNamespaces, &out.UserNamespaces, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SeccompDefaultOptions_To_api_SeccompDefaultOptions(&in.SeccompDefault, &out.SeccompDefault, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SecurityOptions_To_api_SecurityOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SecurityOptions_To_api_SecurityOptions(in *v1alpha1.SecurityOptions, out *api.SecurityOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecurityOptions_To_api_SecurityOptions(in, out, s)
+}
+
+func autoConvert_api_SecurityOptions_To_v1alpha1_SecurityOptions(in *api.SecurityOptions, out *v1alpha1.SecurityOptions, s conversion.Scope) error {
+	out.HardeningProfile = v1alpha1.HardeningProfile(in.HardeningProfile)
+	if err := Convert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions(&in.UserNamespaces, &out.UserNamespaces, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SeccompDefaultOptions_To_v1alpha1_SeccompDefaultOptions(&in.SeccompDefault, &out.SeccompDefault, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_SecurityOptions_To_v1alpha1_SecurityOptions is an autogenerated conversion function.
+func Convert_api_SecurityOptions_To_v1alpha1_SecurityOptions(in *api.SecurityOptions, out *v1alpha1.SecurityOptions, s conversion.Scope) error {
+	return autoConvert_api_SecurityOptions_To_v1alpha1_SecurityOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions(in *v1alpha1.ServingCertificateOptions, out *api.ServingCertificateOptions, s conversion.Scope) error {
+	out.WaitForIssuance = in.WaitForIssuance
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions(in *v1alpha1.ServingCertificateOptions, out *api.ServingCertificateOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServingCertificateOptions_To_api_ServingCertificateOptions(in, out, s)
+}
+
+func autoConvert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions(in *api.ServingCertificateOptions, out *v1alpha1.ServingCertificateOptions, s conversion.Scope) error {
+	out.WaitForIssuance = in.WaitForIssuance
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions is an autogenerated conversion function.
+func Convert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions(in *api.ServingCertificateOptions, out *v1alpha1.ServingCertificateOptions, s conversion.Scope) error {
+	return autoConvert_api_ServingCertificateOptions_To_v1alpha1_ServingCertificateOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions(in *v1alpha1.ShutdownOptions, out *api.ShutdownOptions, s conversion.Scope) error {
+	out.DeregisterNode = in.DeregisterNode
+	out.DrainTimeout = (*v1.Duration)(unsafe.Pointer(in.DrainTimeout))
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.CriticalPodsGracePeriod = (*v1.Duration)(unsafe.Pointer(in.CriticalPodsGracePeriod))
+	out.DrainOnSpotInterruption = in.DrainOnSpotInterruption
+	if err := Convert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions(&in.TerminationHandler, &out.TerminationHandler, s); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*api.NodeConfigSpec)(nil), (*v1alpha1.NodeConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(a.(*api.NodeConfigSpec), b.(*v1alpha1.NodeConfigSpec), scope)
-	}); err != nil {
+	return nil
+}
+
+// Convert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions(in *v1alpha1.ShutdownOptions, out *api.ShutdownOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ShutdownOptions_To_api_ShutdownOptions(in, out, s)
+}
+
+func autoConvert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions(in *api.ShutdownOptions, out *v1alpha1.ShutdownOptions, s conversion.Scope) error {
+	out.DeregisterNode = in.DeregisterNode
+	out.DrainTimeout = (*v1.Duration)(unsafe.Pointer(in.DrainTimeout))
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.CriticalPodsGracePeriod = (*v1.Duration)(unsafe.Pointer(in.CriticalPodsGracePeriod))
+	out.DrainOnSpotInterruption = in.DrainOnSpotInterruption
+	if err := Convert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions(&in.TerminationHandler, &out.TerminationHandler, s); err != nil {
 		return err
 	}
 	return nil
 }
 
-func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
+// Convert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions is an autogenerated conversion function.
+func Convert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions(in *api.ShutdownOptions, out *v1alpha1.ShutdownOptions, s conversion.Scope) error {
+	return autoConvert_api_ShutdownOptions_To_v1alpha1_ShutdownOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions(in *v1alpha1.SmokeTestOptions, out *api.SmokeTestOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions(in *v1alpha1.SmokeTestOptions, out *api.SmokeTestOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SmokeTestOptions_To_api_SmokeTestOptions(in, out, s)
+}
+
+func autoConvert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions(in *api.SmokeTestOptions, out *v1alpha1.SmokeTestOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions is an autogenerated conversion function.
+func Convert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions(in *api.SmokeTestOptions, out *v1alpha1.SmokeTestOptions, s conversion.Scope) error {
+	return autoConvert_api_SmokeTestOptions_To_v1alpha1_SmokeTestOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_StaticPod_To_api_StaticPod(in *v1alpha1.StaticPod, out *api.StaticPod, s conversion.Scope) error {
 	out.Name = in.Name
-	out.APIServerEndpoint = in.APIServerEndpoint
-	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
-	out.CIDR = in.CIDR
-	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
-	out.ID = in.ID
+	out.Manifest = in.Manifest
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
 	return nil
 }
 
-// Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails is an autogenerated conversion function.
-func Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
-	return autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in, out, s)
+// Convert_v1alpha1_StaticPod_To_api_StaticPod is an autogenerated conversion function.
+func Convert_v1alpha1_StaticPod_To_api_StaticPod(in *v1alpha1.StaticPod, out *api.StaticPod, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StaticPod_To_api_StaticPod(in, out, s)
 }
 
-func autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetails, out *v1alpha1.ClusterDetails, s conversion.Scope) error {
+func autoConvert_api_StaticPod_To_v1alpha1_StaticPod(in *api.StaticPod, out *v1alpha1.StaticPod, s conversion.Scope) error {
 	out.Name = in.Name
-	out.APIServerEndpoint = in.APIServerEndpoint
-	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
-	out.CIDR = in.CIDR
-	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
-	out.ID = in.ID
+	out.Manifest = in.Manifest
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
 	return nil
 }
 
-// Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails is an autogenerated conversion function.
-func Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetails, out *v1alpha1.ClusterDetails, s conversion.Scope) error {
-	return autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in, out, s)
+// Convert_api_StaticPod_To_v1alpha1_StaticPod is an autogenerated conversion function.
+func Convert_api_StaticPod_To_v1alpha1_StaticPod(in *api.StaticPod, out *v1alpha1.StaticPod, s conversion.Scope) error {
+	return autoConvert_api_StaticPod_To_v1alpha1_StaticPod(in, out, s)
 }
 
-func autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
-	out.Config = api.ContainerdConfig(in.Config)
-	out.BaseRuntimeSpec = *(*api.InlineDocument)(unsafe.Pointer(&in.BaseRuntimeSpec))
+func autoConvert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions(in *v1alpha1.StatusAnnotationsOptions, out *api.StatusAnnotationsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
 	return nil
 }
 
-// Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions is an autogenerated conversion function.
-func Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
-	return autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in, out, s)
+// Convert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions(in *v1alpha1.StatusAnnotationsOptions, out *api.StatusAnnotationsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StatusAnnotationsOptions_To_api_StatusAnnotationsOptions(in, out, s)
 }
 
-func autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
-	out.Config = string(in.Config)
-	out.BaseRuntimeSpec = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.BaseRuntimeSpec))
+func autoConvert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions(in *api.StatusAnnotationsOptions, out *v1alpha1.StatusAnnotationsOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
 	return nil
 }
 
-// Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions is an autogenerated conversion function.
-func Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
-	return autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in, out, s)
+// Convert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions is an autogenerated conversion function.
+func Convert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions(in *api.StatusAnnotationsOptions, out *v1alpha1.StatusAnnotationsOptions, s conversion.Scope) error {
+	return autoConvert_api_StatusAnnotationsOptions_To_v1alpha1_StatusAnnotationsOptions(in, out, s)
 }
 
-func autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.InstanceOptions, out *api.InstanceOptions, s conversion.Scope) error {
-	if err := Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
-		return err
-	}
+func autoConvert_v1alpha1_SwapOptions_To_api_SwapOptions(in *v1alpha1.SwapOptions, out *api.SwapOptions, s conversion.Scope) error {
+	out.Device = api.SwapDevice(in.Device)
+	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
 	return nil
 }
 
-// Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions is an autogenerated conversion function.
-func Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.InstanceOptions, out *api.InstanceOptions, s conversion.Scope) error {
-	return autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in, out, s)
+// Convert_v1alpha1_SwapOptions_To_api_SwapOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SwapOptions_To_api_SwapOptions(in *v1alpha1.SwapOptions, out *api.SwapOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SwapOptions_To_api_SwapOptions(in, out, s)
 }
 
-func autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOptions, out *v1alpha1.InstanceOptions, s conversion.Scope) error {
-	if err := Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
-		return err
-	}
+func autoConvert_api_SwapOptions_To_v1alpha1_SwapOptions(in *api.SwapOptions, out *v1alpha1.SwapOptions, s conversion.Scope) error {
+	out.Device = v1alpha1.SwapDevice(in.Device)
+	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
 	return nil
 }
 
-// Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions is an autogenerated conversion function.
-func Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOptions, out *v1alpha1.InstanceOptions, s conversion.Scope) error {
-	return autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in, out, s)
+// Convert_api_SwapOptions_To_v1alpha1_SwapOptions is an autogenerated conversion function.
+func Convert_api_SwapOptions_To_v1alpha1_SwapOptions(in *api.SwapOptions, out *v1alpha1.SwapOptions, s conversion.Scope) error {
+	return autoConvert_api_SwapOptions_To_v1alpha1_SwapOptions(in, out, s)
 }
 
-func autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
-	out.Config = *(*api.InlineDocument)(unsafe.Pointer(&in.Config))
-	out.Flags = *(*api.KubeletFlags)(unsafe.Pointer(&in.Flags))
+func autoConvert_v1alpha1_SystemOptions_To_api_SystemOptions(in *v1alpha1.SystemOptions, out *api.SystemOptions, s conversion.Scope) error {
+	out.Sysctl = *(*map[string]string)(unsafe.Pointer(&in.Sysctl))
 	return nil
 }
 
-// Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions is an autogenerated conversion function.
-func Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
-	return autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in, out, s)
+// Convert_v1alpha1_SystemOptions_To_api_SystemOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SystemOptions_To_api_SystemOptions(in *v1alpha1.SystemOptions, out *api.SystemOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SystemOptions_To_api_SystemOptions(in, out, s)
 }
 
-func autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
-	out.Config = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Config))
-	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+func autoConvert_api_SystemOptions_To_v1alpha1_SystemOptions(in *api.SystemOptions, out *v1alpha1.SystemOptions, s conversion.Scope) error {
+	out.Sysctl = *(*map[string]string)(unsafe.Pointer(&in.Sysctl))
 	return nil
 }
 
-// Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions is an autogenerated conversion function.
-func Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
-	return autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in, out, s)
+// Convert_api_SystemOptions_To_v1alpha1_SystemOptions is an autogenerated conversion function.
+func Convert_api_SystemOptions_To_v1alpha1_SystemOptions(in *api.SystemOptions, out *v1alpha1.SystemOptions, s conversion.Scope) error {
+	return autoConvert_api_SystemOptions_To_v1alpha1_SystemOptions(in, out, s)
 }
 
-func autoConvert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in *v1alpha1.LocalStorageOptions, out *api.LocalStorageOptions, s conversion.Scope) error {
-	out.Strategy = api.LocalStorageStrategy(in.Strategy)
-	out.MountPath = in.MountPath
-	out.DisabledMounts = *(*[]api.DisabledMount)(unsafe.Pointer(&in.DisabledMounts))
+func autoConvert_v1alpha1_SystemdDropIn_To_api_SystemdDropIn(in *v1alpha1.SystemdDropIn, out *api.SystemdDropIn, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Content = in.Content
 	return nil
 }
 
-// Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions is an autogenerated conversion function.
-func Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in *v1alpha1.LocalStorageOptions, out *api.LocalStorageOptions, s conversion.Scope) error {
-	return autoConvert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in, out, s)
+// Convert_v1alpha1_SystemdDropIn_To_api_SystemdDropIn is an autogenerated conversion function.
+func Convert_v1alpha1_SystemdDropIn_To_api_SystemdDropIn(in *v1alpha1.SystemdDropIn, out *api.SystemdDropIn, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SystemdDropIn_To_api_SystemdDropIn(in, out, s)
 }
 
-func autoConvert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in *api.LocalStorageOptions, out *v1alpha1.LocalStorageOptions, s conversion.Scope) error {
-	out.Strategy = v1alpha1.LocalStorageStrategy(in.Strategy)
-	out.MountPath = in.MountPath
-	out.DisabledMounts = *(*[]v1alpha1.DisabledMount)(unsafe.Pointer(&in.DisabledMounts))
+func autoConvert_api_SystemdDropIn_To_v1alpha1_SystemdDropIn(in *api.SystemdDropIn, out *v1alpha1.SystemdDropIn, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Content = in.Content
 	return nil
 }
 
-// Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions is an autogenerated conversion function.
-func Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in *api.LocalStorageOptions, out *v1alpha1.LocalStorageOptions, s conversion.Scope) error {
-	return autoConvert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in, out, s)
+// Convert_api_SystemdDropIn_To_v1alpha1_SystemdDropIn is an autogenerated conversion function.
+func Convert_api_SystemdDropIn_To_v1alpha1_SystemdDropIn(in *api.SystemdDropIn, out *v1alpha1.SystemdDropIn, s conversion.Scope) error {
+	return autoConvert_api_SystemdDropIn_To_v1alpha1_SystemdDropIn(in, out, s)
 }
 
-func autoConvert_v1alpha1_NodeConfig_To_api_NodeConfig(in *v1alpha1.NodeConfig, out *api.NodeConfig, s conversion.Scope) error {
-	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(&in.Spec, &out.Spec, s); err != nil {
-		return err
-	}
+func autoConvert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in *v1alpha1.SystemdOptions, out *api.SystemdOptions, s conversion.Scope) error {
+	out.Units = *(*[]api.SystemdUnit)(unsafe.Pointer(&in.Units))
 	return nil
 }
 
-// Convert_v1alpha1_NodeConfig_To_api_NodeConfig is an autogenerated conversion function.
-func Convert_v1alpha1_NodeConfig_To_api_NodeConfig(in *v1alpha1.NodeConfig, out *api.NodeConfig, s conversion.Scope) error {
-	return autoConvert_v1alpha1_NodeConfig_To_api_NodeConfig(in, out, s)
+// Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in *v1alpha1.SystemdOptions, out *api.SystemdOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in, out, s)
 }
 
-func autoConvert_api_NodeConfig_To_v1alpha1_NodeConfig(in *api.NodeConfig, out *v1alpha1.NodeConfig, s conversion.Scope) error {
-	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(&in.Spec, &out.Spec, s); err != nil {
-		return err
-	}
-	// INFO: in.Status opted out of conversion generation
+func autoConvert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in *api.SystemdOptions, out *v1alpha1.SystemdOptions, s conversion.Scope) error {
+	out.Units = *(*[]v1alpha1.SystemdUnit)(unsafe.Pointer(&in.Units))
 	return nil
 }
 
-// Convert_api_NodeConfig_To_v1alpha1_NodeConfig is an autogenerated conversion function.
-func Convert_api_NodeConfig_To_v1alpha1_NodeConfig(in *api.NodeConfig, out *v1alpha1.NodeConfig, s conversion.Scope) error {
-	return autoConvert_api_NodeConfig_To_v1alpha1_NodeConfig(in, out, s)
+// Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions is an autogenerated conversion function.
+func Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in *api.SystemdOptions, out *v1alpha1.SystemdOptions, s conversion.Scope) error {
+	return autoConvert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in, out, s)
 }
 
-func autoConvert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in *v1alpha1.NodeConfigList, out *api.NodeConfigList, s conversion.Scope) error {
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]api.NodeConfig, len(*in))
-		for i := range *in {
-			if err := Convert_v1alpha1_NodeConfig_To_api_NodeConfig(&(*in)[i], &(*out)[i], s); err != nil {
-				return err
-			}
-		}
-	} else {
-		out.Items = nil
-	}
+func autoConvert_v1alpha1_SystemdUnit_To_api_SystemdUnit(in *v1alpha1.SystemdUnit, out *api.SystemdUnit, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DropIns = *(*[]api.SystemdDropIn)(unsafe.Pointer(&in.DropIns))
 	return nil
 }
 
-// Convert_v1alpha1_NodeConfigList_To_api_NodeConfigList is an autogenerated conversion function.
-func Convert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in *v1alpha1.NodeConfigList, out *api.NodeConfigList, s conversion.Scope) error {
-	return autoConvert_v1alpha1_NodeConfigList_To_api_NodeConfigList(in, out, s)
+// Convert_v1alpha1_SystemdUnit_To_api_SystemdUnit is an autogenerated conversion function.
+func Convert_v1alpha1_SystemdUnit_To_api_SystemdUnit(in *v1alpha1.SystemdUnit, out *api.SystemdUnit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SystemdUnit_To_api_SystemdUnit(in, out, s)
 }
 
-func autoConvert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in *api.NodeConfigList, out *v1alpha1.NodeConfigList, s conversion.Scope) error {
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]v1alpha1.NodeConfig, len(*in))
-		for i := range *in {
-			if err := Convert_api_NodeConfig_To_v1alpha1_NodeConfig(&(*in)[i], &(*out)[i], s); err != nil {
-				return err
-			}
-		}
-	} else {
-		out.Items = nil
-	}
+func autoConvert_api_SystemdUnit_To_v1alpha1_SystemdUnit(in *api.SystemdUnit, out *v1alpha1.SystemdUnit, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DropIns = *(*[]v1alpha1.SystemdDropIn)(unsafe.Pointer(&in.DropIns))
 	return nil
 }
 
-// Convert_api_NodeConfigList_To_v1alpha1_NodeConfigList is an autogenerated conversion function.
-func Convert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in *api.NodeConfigList, out *v1alpha1.NodeConfigList, s conversion.Scope) error {
-	return autoConvert_api_NodeConfigList_To_v1alpha1_NodeConfigList(in, out, s)
+// Convert_api_SystemdUnit_To_v1alpha1_SystemdUnit is an autogenerated conversion function.
+func Convert_api_SystemdUnit_To_v1alpha1_SystemdUnit(in *api.SystemdUnit, out *v1alpha1.SystemdUnit, s conversion.Scope) error {
+	return autoConvert_api_SystemdUnit_To_v1alpha1_SystemdUnit(in, out, s)
 }
 
-func autoConvert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1alpha1.NodeConfigSpec, out *api.NodeConfigSpec, s conversion.Scope) error {
-	if err := Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(&in.Cluster, &out.Cluster, s); err != nil {
-		return err
-	}
-	if err := Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(&in.Containerd, &out.Containerd, s); err != nil {
-		return err
-	}
-	if err := Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(&in.Instance, &out.Instance, s); err != nil {
-		return err
-	}
-	if err := Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
-		return err
-	}
-	out.FeatureGates = *(*map[api.Feature]bool)(unsafe.Pointer(&in.FeatureGates))
+func autoConvert_v1alpha1_Taint_To_api_Taint(in *v1alpha1.Taint, out *api.Taint, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Effect = api.TaintEffect(in.Effect)
 	return nil
 }
 
-// Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec is an autogenerated conversion function.
-func Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1alpha1.NodeConfigSpec, out *api.NodeConfigSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in, out, s)
+// Convert_v1alpha1_Taint_To_api_Taint is an autogenerated conversion function.
+func Convert_v1alpha1_Taint_To_api_Taint(in *v1alpha1.Taint, out *api.Taint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Taint_To_api_Taint(in, out, s)
 }
 
-func autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1alpha1.NodeConfigSpec, s conversion.Scope) error {
-	if err := Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(&in.Cluster, &out.Cluster, s); err != nil {
-		return err
-	}
-	if err := Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(&in.Containerd, &out.Containerd, s); err != nil {
-		return err
-	}
-	if err := Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(&in.Instance, &out.Instance, s); err != nil {
-		return err
-	}
-	if err := Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
-		return err
-	}
-	out.FeatureGates = *(*map[v1alpha1.Feature]bool)(unsafe.Pointer(&in.FeatureGates))
+func autoConvert_api_Taint_To_v1alpha1_Taint(in *api.Taint, out *v1alpha1.Taint, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Effect = v1alpha1.TaintEffect(in.Effect)
 	return nil
 }
 
-// Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec is an autogenerated conversion function.
-func Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1alpha1.NodeConfigSpec, s conversion.Scope) error {
-	return autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in, out, s)
+// Convert_api_Taint_To_v1alpha1_Taint is an autogenerated conversion function.
+func Convert_api_Taint_To_v1alpha1_Taint(in *api.Taint, out *v1alpha1.Taint, s conversion.Scope) error {
+	return autoConvert_api_Taint_To_v1alpha1_Taint(in, out, s)
+}
+
+func autoConvert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions(in *v1alpha1.TerminationHandlerOptions, out *api.TerminationHandlerOptions, s conversion.Scope) error {
+	out.Events = *(*[]api.TerminationEvent)(unsafe.Pointer(&in.Events))
+	out.PollInterval = (*v1.Duration)(unsafe.Pointer(in.PollInterval))
+	return nil
+}
+
+// Convert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions is an autogenerated conversion function.
+func Convert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions(in *v1alpha1.TerminationHandlerOptions, out *api.TerminationHandlerOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TerminationHandlerOptions_To_api_TerminationHandlerOptions(in, out, s)
+}
+
+func autoConvert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions(in *api.TerminationHandlerOptions, out *v1alpha1.TerminationHandlerOptions, s conversion.Scope) error {
+	out.Events = *(*[]v1alpha1.TerminationEvent)(unsafe.Pointer(&in.Events))
+	out.PollInterval = (*v1.Duration)(unsafe.Pointer(in.PollInterval))
+	return nil
+}
+
+// Convert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions is an autogenerated conversion function.
+func Convert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions(in *api.TerminationHandlerOptions, out *v1alpha1.TerminationHandlerOptions, s conversion.Scope) error {
+	return autoConvert_api_TerminationHandlerOptions_To_v1alpha1_TerminationHandlerOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions(in *v1alpha1.TimeSyncOptions, out *api.TimeSyncOptions, s conversion.Scope) error {
+	out.Servers = *(*[]string)(unsafe.Pointer(&in.Servers))
+	out.SyncTimeout = (*v1.Duration)(unsafe.Pointer(in.SyncTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions is an autogenerated conversion function.
+func Convert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions(in *v1alpha1.TimeSyncOptions, out *api.TimeSyncOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TimeSyncOptions_To_api_TimeSyncOptions(in, out, s)
+}
+
+func autoConvert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions(in *api.TimeSyncOptions, out *v1alpha1.TimeSyncOptions, s conversion.Scope) error {
+	out.Servers = *(*[]string)(unsafe.Pointer(&in.Servers))
+	out.SyncTimeout = (*v1.Duration)(unsafe.Pointer(in.SyncTimeout))
+	return nil
+}
+
+// Convert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions is an autogenerated conversion function.
+func Convert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions(in *api.TimeSyncOptions, out *v1alpha1.TimeSyncOptions, s conversion.Scope) error {
+	return autoConvert_api_TimeSyncOptions_To_v1alpha1_TimeSyncOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions(in *v1alpha1.TokenCacheOptions, out *api.TokenCacheOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.LeadTime = (*v1.Duration)(unsafe.Pointer(in.LeadTime))
+	out.MaxAttempts = in.MaxAttempts
+	return nil
+}
+
+// Convert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions is an autogenerated conversion function.
+func Convert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions(in *v1alpha1.TokenCacheOptions, out *api.TokenCacheOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TokenCacheOptions_To_api_TokenCacheOptions(in, out, s)
+}
+
+func autoConvert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions(in *api.TokenCacheOptions, out *v1alpha1.TokenCacheOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.LeadTime = (*v1.Duration)(unsafe.Pointer(in.LeadTime))
+	out.MaxAttempts = in.MaxAttempts
+	return nil
+}
+
+// Convert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions is an autogenerated conversion function.
+func Convert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions(in *api.TokenCacheOptions, out *v1alpha1.TokenCacheOptions, s conversion.Scope) error {
+	return autoConvert_api_TokenCacheOptions_To_v1alpha1_TokenCacheOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_TracingOptions_To_api_TracingOptions(in *v1alpha1.TracingOptions, out *api.TracingOptions, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.File = in.File
+	return nil
+}
+
+// Convert_v1alpha1_TracingOptions_To_api_TracingOptions is an autogenerated conversion function.
+func Convert_v1alpha1_TracingOptions_To_api_TracingOptions(in *v1alpha1.TracingOptions, out *api.TracingOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TracingOptions_To_api_TracingOptions(in, out, s)
+}
+
+func autoConvert_api_TracingOptions_To_v1alpha1_TracingOptions(in *api.TracingOptions, out *v1alpha1.TracingOptions, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.File = in.File
+	return nil
+}
+
+// Convert_api_TracingOptions_To_v1alpha1_TracingOptions is an autogenerated conversion function.
+func Convert_api_TracingOptions_To_v1alpha1_TracingOptions(in *api.TracingOptions, out *v1alpha1.TracingOptions, s conversion.Scope) error {
+	return autoConvert_api_TracingOptions_To_v1alpha1_TracingOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions(in *v1alpha1.TrustStoreOptions, out *api.TrustStoreOptions, s conversion.Scope) error {
+	out.CertificateAuthorities = in.CertificateAuthorities
+	return nil
+}
+
+// Convert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions is an autogenerated conversion function.
+func Convert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions(in *v1alpha1.TrustStoreOptions, out *api.TrustStoreOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TrustStoreOptions_To_api_TrustStoreOptions(in, out, s)
+}
+
+func autoConvert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions(in *api.TrustStoreOptions, out *v1alpha1.TrustStoreOptions, s conversion.Scope) error {
+	out.CertificateAuthorities = in.CertificateAuthorities
+	return nil
+}
+
+// Convert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions is an autogenerated conversion function.
+func Convert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions(in *api.TrustStoreOptions, out *v1alpha1.TrustStoreOptions, s conversion.Scope) error {
+	return autoConvert_api_TrustStoreOptions_To_v1alpha1_TrustStoreOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions(in *v1alpha1.UserNamespacesOptions, out *api.UserNamespacesOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions is an autogenerated conversion function.
+func Convert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions(in *v1alpha1.UserNamespacesOptions, out *api.UserNamespacesOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_UserNamespacesOptions_To_api_UserNamespacesOptions(in, out, s)
+}
+
+func autoConvert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions(in *api.UserNamespacesOptions, out *v1alpha1.UserNamespacesOptions, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions is an autogenerated conversion function.
+func Convert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions(in *api.UserNamespacesOptions, out *v1alpha1.UserNamespacesOptions, s conversion.Scope) error {
+	return autoConvert_api_UserNamespacesOptions_To_v1alpha1_UserNamespacesOptions(in, out, s)
 }
@@ -0,0 +1,38 @@
+package api
+
+// GetCgroupDriver returns the cgroup driver that kubelet and containerd are
+// configured with, which must be the same for both.
+func (cfg *NodeConfig) GetCgroupDriver() CgroupDriver {
+	if driver := cfg.Spec.Instance.Cgroup.Driver; driver != "" {
+		return driver
+	}
+	return CgroupDriverSystemd
+}
+
+const (
+	// DefaultIOWeight is the IO weight of a systemd unit that does not set one.
+	DefaultIOWeight = 100
+	MaxIOWeight     = 10000
+)
+
+// IsEnabled returns whether the IO of the container runtime is isolated from
+// that of pods.
+func (o *CgroupIOOptions) IsEnabled() bool {
+	return o.RuntimeWeight != 0 || o.PodsWeight != 0
+}
+
+// GetRuntimeWeight returns the IO weight of runtime.slice.
+func (o *CgroupIOOptions) GetRuntimeWeight() int {
+	if o.RuntimeWeight != 0 {
+		return o.RuntimeWeight
+	}
+	return DefaultIOWeight
+}
+
+// GetPodsWeight returns the IO weight of kubepods.slice.
+func (o *CgroupIOOptions) GetPodsWeight() int {
+	if o.PodsWeight != 0 {
+		return o.PodsWeight
+	}
+	return DefaultIOWeight
+}
@@ -0,0 +1,37 @@
+package api
+
+import "time"
+
+// the delays between descriptions of a discovered cluster that is not ready,
+// and how long it is waited for, unless they are set
+const (
+	DefaultClusterDiscoveryMinDelay = 5 * time.Second
+	DefaultClusterDiscoveryMaxDelay = 30 * time.Second
+	DefaultClusterDiscoveryTimeout  = 10 * time.Minute
+)
+
+// GetMinDelay returns the delay before the cluster is described again while
+// it is not ready.
+func (o *ClusterDiscoveryOptions) GetMinDelay() time.Duration {
+	if o.MinDelay != nil {
+		return o.MinDelay.Duration
+	}
+	return DefaultClusterDiscoveryMinDelay
+}
+
+// GetMaxDelay returns the maximum delay between two descriptions of the
+// cluster.
+func (o *ClusterDiscoveryOptions) GetMaxDelay() time.Duration {
+	if o.MaxDelay != nil {
+		return o.MaxDelay.Duration
+	}
+	return DefaultClusterDiscoveryMaxDelay
+}
+
+// GetTimeout returns how long the cluster is waited for to be ready.
+func (o *ClusterDiscoveryOptions) GetTimeout() time.Duration {
+	if o.Timeout != nil {
+		return o.Timeout.Duration
+	}
+	return DefaultClusterDiscoveryTimeout
+}
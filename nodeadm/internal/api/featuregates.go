@@ -14,6 +14,16 @@ var featureVerifiers = map[Feature]func(Feature, map[Feature]bool) bool{
 	// InstanceIdNodeNameGate controls whether to use instance ID as the node's name.
 	// By default, this feature is disabled, and the private DNS Name will be used.
 	InstanceIdNodeName: DefaultFalse,
+	// FastContainerImagePull controls whether images are lazily pulled by the
+	// soci-snapshotter. By default, this feature is disabled.
+	FastContainerImagePull: DefaultFalse,
+	// NodeLocalDNSCache controls whether pods resolve names through the
+	// NodeLocal DNSCache of the node. By default, this feature is disabled.
+	NodeLocalDNSCache: DefaultFalse,
+	// APIServerEndpointCheck controls whether the node checks that it can use
+	// the kube-apiserver before kubelet is started. By default, this feature
+	// is disabled.
+	APIServerEndpointCheck: DefaultFalse,
 }
 
 func IsFeatureEnabled(feature Feature, featureGates map[Feature]bool) bool {
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// instanceTemplateData are the details of the instance that node labels and
+// taints can be templated with.
+type instanceTemplateData struct {
+	InstanceID       string
+	InstanceType     string
+	AvailabilityZone string
+	Region           string
+	AccountID        string
+}
+
+// ExpandTemplate expands a value of a node label or taint with the details of
+// the instance, such as `{{ .InstanceType }}`.
+func (cfg *NodeConfig) ExpandTemplate(value string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	tmpl, err := template.New("value").Parse(value)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, instanceTemplateData{
+		InstanceID:       cfg.Status.Instance.ID,
+		InstanceType:     cfg.Status.Instance.Type,
+		AvailabilityZone: cfg.Status.Instance.AvailabilityZone,
+		Region:           cfg.Status.Instance.Region,
+		AccountID:        cfg.Status.Instance.AccountID,
+	}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+const (
+	ZoneIDLabel                = "topology.k8s.aws/zone-id"
+	CapacityTypeLabel          = "eks.amazonaws.com/capacityType"
+	PlacementGroupLabel        = "node.eks.aws/placement-group"
+	PlacementPartitionLabel    = "node.eks.aws/placement-partition"
+	CapacityReservationIDLabel = "node.eks.aws/capacity-reservation-id"
+)
+
+// GetAutoLabels returns the labels of the topology and capacity of the
+// instance when auto labels are enabled. Details that are unknown, or are not
+// valid label values, are left out.
+func (cfg *NodeConfig) GetAutoLabels() map[string]string {
+	if !cfg.Spec.Kubelet.AutoLabels.Enabled {
+		return nil
+	}
+	topology := cfg.Status.Instance.Topology
+	labels := make(map[string]string)
+	for key, value := range map[string]string{
+		ZoneIDLabel: topology.AvailabilityZoneID,
+		// like managed node groups, on-demand is ON_DEMAND and
+		// capacity-block is CAPACITY_BLOCK
+		CapacityTypeLabel:          strings.ToUpper(strings.ReplaceAll(topology.Lifecycle, "-", "_")),
+		PlacementGroupLabel:        topology.PlacementGroup,
+		PlacementPartitionLabel:    topology.PlacementPartition,
+		CapacityReservationIDLabel: topology.CapacityReservationID,
+	} {
+		if value == "" || len(validation.IsValidLabelValue(value)) > 0 {
+			continue
+		}
+		labels[key] = value
+	}
+	return labels
+}
+
+// GetNodeLabels returns the node labels with their values expanded, along
+// with the auto labels, which the node labels take precedence over.
+func (cfg *NodeConfig) GetNodeLabels() (map[string]string, error) {
+	labels := cfg.GetAutoLabels()
+	if len(cfg.Spec.Kubelet.NodeLabels) == 0 {
+		return labels, nil
+	}
+	if labels == nil {
+		labels = make(map[string]string, len(cfg.Spec.Kubelet.NodeLabels))
+	}
+	for key, value := range cfg.Spec.Kubelet.NodeLabels {
+		expanded, err := cfg.ExpandTemplate(value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to expand the value of node label %q: %w", key, err)
+		}
+		if errs := validation.IsValidLabelValue(expanded); len(errs) > 0 {
+			return nil, fmt.Errorf("Value %q of node label %q is invalid: %s", expanded, key, strings.Join(errs, ", "))
+		}
+		labels[key] = expanded
+	}
+	return labels, nil
+}
+
+// GetNodeTaints returns the node taints with their values expanded.
+func (cfg *NodeConfig) GetNodeTaints() ([]Taint, error) {
+	var taints []Taint
+	for _, taint := range cfg.Spec.Kubelet.NodeTaints {
+		expanded, err := cfg.ExpandTemplate(taint.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to expand the value of node taint %q: %w", taint.Key, err)
+		}
+		if errs := validation.IsValidLabelValue(expanded); len(errs) > 0 {
+			return nil, fmt.Errorf("Value %q of node taint %q is invalid: %s", expanded, taint.Key, strings.Join(errs, ", "))
+		}
+		taints = append(taints, Taint{Key: taint.Key, Value: expanded, Effect: taint.Effect})
+	}
+	return taints, nil
+}
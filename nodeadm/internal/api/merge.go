@@ -25,6 +25,8 @@ func (t nodeConfigTransformer) Transformer(typ reflect.Type) func(dst, src refle
 		return t.mergeKubeletFlags
 	case reflect.TypeOf(InlineDocument{}):
 		return t.mergeInlineDocument
+	case reflect.TypeOf(map[string]InlineDocument{}):
+		return t.mergeInlineDocumentMap
 	}
 	return nil
 }
@@ -91,6 +93,27 @@ func (t nodeConfigTransformer) mergeInlineDocument(dst, src reflect.Value) error
 	return nil
 }
 
+// mergeInlineDocumentMap merges the documents with the same key, rather than
+// replacing the document of the destination.
+func (t nodeConfigTransformer) mergeInlineDocumentMap(dst, src reflect.Value) error {
+	if dst.CanSet() && src.Len() > 0 {
+		merged := make(map[string]InlineDocument)
+		for key, document := range dst.Interface().(map[string]InlineDocument) {
+			merged[key] = document
+		}
+		for key, document := range src.Interface().(map[string]InlineDocument) {
+			mergedDocument := reflect.New(reflect.TypeOf(InlineDocument{})).Elem()
+			mergedDocument.Set(reflect.ValueOf(merged[key]))
+			if err := t.mergeInlineDocument(mergedDocument, reflect.ValueOf(document)); err != nil {
+				return err
+			}
+			merged[key] = mergedDocument.Interface().(InlineDocument)
+		}
+		dst.Set(reflect.ValueOf(merged))
+	}
+	return nil
+}
+
 func toInlineDocument(m map[string]interface{}) (InlineDocument, error) {
 	var rawMap = make(InlineDocument)
 	for key, value := range m {
@@ -253,6 +253,43 @@ conf_dir = "/etc/cni/net.d"`)),
 				},
 			},
 		},
+		{
+			name: "containerd baseRuntimeSpecOverrides are merged by handler",
+			baseSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					BaseRuntimeSpecOverrides: map[string]InlineDocument{
+						"runc": toInlineDocumentMust(map[string]interface{}{
+							"process": map[string]interface{}{"noNewPrivileges": true},
+						}),
+						"runsc": toInlineDocumentMust(map[string]interface{}{
+							"process": map[string]interface{}{"noNewPrivileges": true},
+						}),
+					},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					BaseRuntimeSpecOverrides: map[string]InlineDocument{
+						"runsc": toInlineDocumentMust(map[string]interface{}{
+							"ociVersion": "1.1.0",
+						}),
+					},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					BaseRuntimeSpecOverrides: map[string]InlineDocument{
+						"runc": toInlineDocumentMust(map[string]interface{}{
+							"process": map[string]interface{}{"noNewPrivileges": true},
+						}),
+						"runsc": toInlineDocumentMust(map[string]interface{}{
+							"process":    map[string]interface{}{"noNewPrivileges": true},
+							"ociVersion": "1.1.0",
+						}),
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
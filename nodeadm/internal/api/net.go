@@ -3,25 +3,67 @@ package api
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"strings"
 )
 
-// Derive the default ClusterIP of the kube-dns service from EKS built-in CoreDNS addon
+// clusterDNSOffset is the position of the ClusterIP of the kube-dns service in
+// the service CIDR block, which EKS assigns to the built-in CoreDNS addon.
+const clusterDNSOffset = 10
+
+// GetServiceCIDRs returns the service CIDR blocks of the cluster. Dual-stack
+// clusters have a block of each IP family separated by a comma, of which the
+// first is the primary IP family of services.
+func (details *ClusterDetails) GetServiceCIDRs() ([]netip.Prefix, error) {
+	var cidrs []netip.Prefix
+	for _, cidr := range strings.Split(details.CIDR, ",") {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid CIDR block: %w", cidr, err)
+		}
+		cidrs = append(cidrs, prefix.Masked())
+	}
+	if len(cidrs) > 2 {
+		return nil, fmt.Errorf("%s has more than two CIDR blocks", details.CIDR)
+	} else if len(cidrs) == 2 && cidrs[0].Addr().Is4() == cidrs[1].Addr().Is4() {
+		return nil, fmt.Errorf("%s must have a CIDR block of each IP family to be dual-stack", details.CIDR)
+	}
+	return cidrs, nil
+}
+
+// GetIPFamilies returns the IP families of the cluster's services, starting
+// with the primary IP family.
+func (details *ClusterDetails) GetIPFamilies() ([]IPFamily, error) {
+	cidrs, err := details.GetServiceCIDRs()
+	if err != nil {
+		return nil, err
+	}
+	var families []IPFamily
+	for _, cidr := range cidrs {
+		if cidr.Addr().Is4() {
+			families = append(families, IPFamilyIPv4)
+		} else {
+			families = append(families, IPFamilyIPv6)
+		}
+	}
+	return families, nil
+}
+
+// Derive the default ClusterIP of the kube-dns service from EKS built-in
+// CoreDNS addon, which is in the CIDR block of the primary IP family.
 func (details *ClusterDetails) GetClusterDns() (string, error) {
-	ipFamily, err := GetCIDRIpFamily(details.CIDR)
+	cidrs, err := details.GetServiceCIDRs()
 	if err != nil {
 		return "", err
 	}
-	switch ipFamily {
-	case IPFamilyIPv4:
-		dnsAddress := fmt.Sprintf("%s.10", details.CIDR[:strings.LastIndex(details.CIDR, ".")])
-		return dnsAddress, nil
-	case IPFamilyIPv6:
-		dnsAddress := fmt.Sprintf("%sa", strings.Split(details.CIDR, "/")[0])
-		return dnsAddress, nil
-	default:
-		return "", fmt.Errorf("%s was not a valid IP family", ipFamily)
+	dnsAddress := cidrs[0].Addr()
+	for range clusterDNSOffset {
+		dnsAddress = dnsAddress.Next()
+	}
+	if !cidrs[0].Contains(dnsAddress) {
+		return "", fmt.Errorf("%s is too small to hold the cluster DNS address", cidrs[0])
 	}
+	return dnsAddress.String(), nil
 }
 
 func GetCIDRIpFamily(cidr string) (IPFamily, error) {
@@ -19,6 +19,22 @@ func TestGetClusterDNS(t *testing.T) {
 			clusterCIDR:        "fc00::/7",
 			expectedClusterDns: "fc00::a",
 		},
+		{
+			clusterCIDR:        "fd30:1c53:5f8a::/108",
+			expectedClusterDns: "fd30:1c53:5f8a::a",
+		},
+		{
+			clusterCIDR:        "fd30:1c53:5f8a::1:0/112",
+			expectedClusterDns: "fd30:1c53:5f8a::1:a",
+		},
+		{
+			clusterCIDR:        "172.20.0.0/16,fd30:1c53:5f8a::/108",
+			expectedClusterDns: "172.20.0.10",
+		},
+		{
+			clusterCIDR:        "fd30:1c53:5f8a::/108,172.20.0.0/16",
+			expectedClusterDns: "fd30:1c53:5f8a::a",
+		},
 	}
 
 	for _, test := range tests {
@@ -30,3 +46,32 @@ func TestGetClusterDNS(t *testing.T) {
 		assert.Equal(t, test.expectedClusterDns, clusterDns)
 	}
 }
+
+func TestGetIPFamilies(t *testing.T) {
+	tests := []struct {
+		clusterCIDR      string
+		expectedFamilies []IPFamily
+		expectErr        bool
+	}{
+		{clusterCIDR: "10.100.0.0/16", expectedFamilies: []IPFamily{IPFamilyIPv4}},
+		{clusterCIDR: "fd30:1c53:5f8a::/108", expectedFamilies: []IPFamily{IPFamilyIPv6}},
+		{clusterCIDR: "10.100.0.0/16, fd30:1c53:5f8a::/108", expectedFamilies: []IPFamily{IPFamilyIPv4, IPFamilyIPv6}},
+		{clusterCIDR: "fd30:1c53:5f8a::/108,10.100.0.0/16", expectedFamilies: []IPFamily{IPFamilyIPv6, IPFamilyIPv4}},
+		{clusterCIDR: "10.100.0.0/16,172.20.0.0/16", expectErr: true},
+		{clusterCIDR: "10.100.0.0/16,fd30:1c53:5f8a::/108,172.20.0.0/16", expectErr: true},
+		{clusterCIDR: "10.100.0.0", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.clusterCIDR, func(t *testing.T) {
+			details := ClusterDetails{CIDR: test.clusterCIDR}
+			families, err := details.GetIPFamilies()
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedFamilies, families)
+			}
+		})
+	}
+}
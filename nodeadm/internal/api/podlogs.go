@@ -0,0 +1,20 @@
+package api
+
+import "k8s.io/apimachinery/pkg/api/resource"
+
+// DefaultPodLogsMemoryLimit is the size of the tmpfs that holds pod logs when
+// a memory limit is not configured.
+var DefaultPodLogsMemoryLimit = resource.MustParse("512Mi")
+
+// IsMemoryBacked returns whether pod logs are stored on a tmpfs.
+func (o *PodLogsOptions) IsMemoryBacked() bool {
+	return o.Storage == PodLogsStorageMemory
+}
+
+// GetMemoryLimit returns the size of the tmpfs that holds pod logs.
+func (o *PodLogsOptions) GetMemoryLimit() resource.Quantity {
+	if o.MemoryLimit != nil {
+		return *o.MemoryLimit
+	}
+	return DefaultPodLogsMemoryLimit
+}
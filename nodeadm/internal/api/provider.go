@@ -0,0 +1,14 @@
+package api
+
+// IsHybrid returns whether the node is being bootstrapped outside of EC2, in
+// which case the instance metadata service is not available.
+func (cfg *NodeConfig) IsHybrid() bool {
+	return cfg.Spec.NodeProvider == NodeProviderHybrid
+}
+
+// HasInstanceMetadata returns whether the details of the instance are read from
+// the instance metadata service, which is not called on hybrid nodes, nor in
+// offline mode when the identity of the instance is set in the config.
+func (cfg *NodeConfig) HasInstanceMetadata() bool {
+	return !cfg.IsHybrid() && cfg.Spec.Instance.Identity == (InstanceIdentityOptions{})
+}
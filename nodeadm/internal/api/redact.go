@@ -0,0 +1,36 @@
+package api
+
+import "net/url"
+
+// RedactedValue replaces the values of the NodeConfig that may be secrets.
+const RedactedValue = "<redacted>"
+
+// Redacted returns a copy of the NodeConfig that can be logged, without the
+// activation code of SSM, the scripts of hooks, and the passwords of the
+// proxies. Its logs are streamed to CloudWatch Logs when that is enabled.
+func (n *NodeConfig) Redacted() *NodeConfig {
+	redacted := n.DeepCopy()
+	if redacted.Spec.Hybrid.SSM.ActivationCode != "" {
+		redacted.Spec.Hybrid.SSM.ActivationCode = RedactedValue
+	}
+	for _, hooks := range [][]Hook{redacted.Spec.Hooks.PreInit, redacted.Spec.Hooks.PostContainerd, redacted.Spec.Hooks.PostKubelet} {
+		for i := range hooks {
+			if hooks[i].Script != "" {
+				hooks[i].Script = RedactedValue
+			}
+		}
+	}
+	redacted.Spec.Proxy.HTTPProxy = redactURLPassword(redacted.Spec.Proxy.HTTPProxy)
+	redacted.Spec.Proxy.HTTPSProxy = redactURLPassword(redacted.Spec.Proxy.HTTPSProxy)
+	return redacted
+}
+
+// redactURLPassword returns a URL with its password, if any, replaced by
+// "xxxxx".
+func redactURLPassword(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
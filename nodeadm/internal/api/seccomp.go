@@ -0,0 +1,24 @@
+package api
+
+import "path"
+
+// SeccompProfileDir is the directory of the seccomp profiles of the NodeConfig,
+// beneath the seccomp directory of kubelet that the localhostProfile of a pod
+// is relative to.
+const SeccompProfileDir = "/var/lib/kubelet/seccomp/profiles"
+
+// GetPath returns the path that the profile is written to.
+func (p *SeccompProfile) GetPath() string {
+	return path.Join(SeccompProfileDir, p.Name+".json")
+}
+
+// GetDefaultProfilePath returns the path of the profile that containerd runs
+// containers without a seccomp profile with, or an empty string when the
+// default profile of containerd is used.
+func (o *SeccompDefaultOptions) GetDefaultProfilePath() string {
+	if !o.Enabled || o.DefaultProfile == "" {
+		return ""
+	}
+	profile := SeccompProfile{Name: o.DefaultProfile}
+	return profile.GetPath()
+}
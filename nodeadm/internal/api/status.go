@@ -3,10 +3,12 @@ package api
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	ec2extra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
 )
@@ -14,7 +16,7 @@ import (
 // Fetch information about the ec2 instance using IMDS data.
 // This information is stored into the internal config to avoid redundant calls
 // to IMDS when looking for instance metadata
-func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, ec2Client *ec2.Client) (*InstanceDetails, error) {
+func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, tuning BootstrapTuning, ec2Client *ec2.Client) (*InstanceDetails, error) {
 	instanceIdenitityDocument, err := imds.GetInstanceIdentityDocument(ctx)
 	if err != nil {
 		return nil, err
@@ -27,7 +29,7 @@ func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, ec2C
 
 	var privateDNSName string
 	if !IsFeatureEnabled(InstanceIdNodeName, featureGates) {
-		privateDNSName, err = getPrivateDNSName(ec2Client, instanceIdenitityDocument.InstanceID)
+		privateDNSName, err = getPrivateDNSName(ctx, ec2Client, instanceIdenitityDocument.InstanceID, tuning)
 		if err != nil {
 			return nil, err
 		}
@@ -35,6 +37,7 @@ func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, ec2C
 
 	return &InstanceDetails{
 		ID:               instanceIdenitityDocument.InstanceID,
+		AccountID:        instanceIdenitityDocument.AccountID,
 		Region:           instanceIdenitityDocument.Region,
 		Type:             instanceIdenitityDocument.InstanceType,
 		AvailabilityZone: instanceIdenitityDocument.AvailabilityZone,
@@ -43,14 +46,18 @@ func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, ec2C
 	}, nil
 }
 
-const privateDNSNameAvailableTimeout = 3 * time.Minute
-
 // GetPrivateDNSName returns this instance's private DNS name as reported by the EC2 API, waiting until it's available if necessary.
-func getPrivateDNSName(ec2Client *ec2.Client, instanceID string) (string, error) {
+func getPrivateDNSName(ctx context.Context, ec2Client ec2.DescribeInstancesAPIClient, instanceID string, tuning BootstrapTuning) (string, error) {
+	if tuning.WaiterInitialJitter > 0 {
+		// spread the first polls of nodes that are launched at once
+		time.Sleep(rand.N(tuning.WaiterInitialJitter))
+	}
 	w := ec2extra.NewInstanceConditionWaiter(ec2Client, privateDNSNameAvailable, func(opts *ec2extra.InstanceConditionWaiterOptions) {
 		opts.LogWaitAttempts = true
+		opts.MinDelay = tuning.WaiterMinDelay
+		opts.MaxDelay = tuning.WaiterMaxDelay
 	})
-	out, err := w.WaitForOutput(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}}, privateDNSNameAvailableTimeout)
+	out, err := w.WaitForOutput(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}}, tuning.WaiterTimeout)
 	if err != nil {
 		return "", err
 	}
@@ -64,3 +71,54 @@ func privateDNSNameAvailable(out *ec2.DescribeInstancesOutput) (bool, error) {
 	}
 	return aws.ToString(out.Reservations[0].Instances[0].PrivateDnsName) != "", nil
 }
+
+// GetInstanceTopology reads the topology and capacity of the instance, which
+// the node is labeled with when auto labels are enabled. The capacity
+// reservation is not in the instance metadata, so it is read from the EC2 API.
+func GetInstanceTopology(ctx context.Context, ec2Client ec2.DescribeInstancesAPIClient, instanceID string) (*InstanceTopology, error) {
+	zoneID, err := imds.GetProperty(ctx, imds.AvailabilityZoneID)
+	if err != nil {
+		return nil, err
+	}
+	lifecycle, err := imds.GetProperty(ctx, imds.InstanceLifeCycle)
+	if err != nil {
+		return nil, err
+	}
+	placementGroup, err := imds.GetOptionalProperty(ctx, imds.PlacementGroupName)
+	if err != nil {
+		return nil, err
+	}
+	placementPartition, err := imds.GetOptionalProperty(ctx, imds.PlacementPartitionNumber)
+	if err != nil {
+		return nil, err
+	}
+	topology := &InstanceTopology{
+		AvailabilityZoneID: zoneID,
+		Lifecycle:          lifecycle,
+		PlacementGroup:     placementGroup,
+		PlacementPartition: placementPartition,
+	}
+	if err := describeCapacity(ctx, ec2Client, instanceID, topology); err != nil {
+		return nil, err
+	}
+	return topology, nil
+}
+
+// describeCapacity sets the capacity reservation of the instance, and its
+// lifecycle when it runs in a Capacity Block, which the instance metadata
+// reports as on-demand.
+func describeCapacity(ctx context.Context, ec2Client ec2.DescribeInstancesAPIClient, instanceID string, topology *InstanceTopology) error {
+	out, err := ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
+	if err != nil {
+		return err
+	}
+	if len(out.Reservations) != 1 || len(out.Reservations[0].Instances) != 1 {
+		return fmt.Errorf("reservation or instance not found")
+	}
+	instance := out.Reservations[0].Instances[0]
+	topology.CapacityReservationID = aws.ToString(instance.CapacityReservationId)
+	if instance.InstanceLifecycle == types.InstanceLifecycleTypeCapacityBlock {
+		topology.Lifecycle = string(instance.InstanceLifecycle)
+	}
+	return nil
+}
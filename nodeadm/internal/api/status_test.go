@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2/ec2test"
+)
+
+func instanceWithPrivateDNSName(name string) *ec2.DescribeInstancesOutput {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{{Instances: []types.Instance{{PrivateDnsName: aws.String(name)}}}},
+	}
+}
+
+func TestGetPrivateDNSName(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: instanceWithPrivateDNSName("")},
+		ec2test.DescribeInstancesResponse{Output: instanceWithPrivateDNSName("ip-10-0-1-10.us-west-2.compute.internal")},
+	)
+	tuning := BootstrapTuning{WaiterMinDelay: time.Millisecond, WaiterMaxDelay: time.Millisecond, WaiterTimeout: time.Second}
+	name, err := getPrivateDNSName(context.Background(), client, "i-1234567890abcdef0", tuning)
+	assert.NoError(t, err)
+	assert.Equal(t, "ip-10-0-1-10.us-west-2.compute.internal", name)
+	assert.Len(t, client.Inputs(), 2)
+	assert.Equal(t, []string{"i-1234567890abcdef0"}, client.Inputs()[0].InstanceIds)
+}
+
+func TestGetPrivateDNSNameInstanceNotFound(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: &ec2.DescribeInstancesOutput{}},
+	)
+	tuning := BootstrapTuning{WaiterMinDelay: time.Millisecond, WaiterMaxDelay: time.Millisecond, WaiterTimeout: time.Second}
+	_, err := getPrivateDNSName(context.Background(), client, "i-1234567890abcdef0", tuning)
+	assert.ErrorContains(t, err, "reservation or instance not found")
+}
+
+func TestDescribeCapacity(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: &ec2.DescribeInstancesOutput{
+			Reservations: []types.Reservation{{Instances: []types.Instance{{
+				CapacityReservationId: aws.String("cr-1234567890abcdef0"),
+				InstanceLifecycle:     types.InstanceLifecycleTypeCapacityBlock,
+			}}}},
+		}},
+	)
+	topology := InstanceTopology{AvailabilityZoneID: "usw2-az1", Lifecycle: "on-demand"}
+	assert.NoError(t, describeCapacity(context.Background(), client, "i-1234567890abcdef0", &topology))
+	assert.Equal(t, InstanceTopology{AvailabilityZoneID: "usw2-az1", Lifecycle: "capacity-block", CapacityReservationID: "cr-1234567890abcdef0"}, topology)
+	assert.Equal(t, []string{"i-1234567890abcdef0"}, client.Inputs()[0].InstanceIds)
+}
+
+func TestDescribeCapacitySpot(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: &ec2.DescribeInstancesOutput{
+			Reservations: []types.Reservation{{Instances: []types.Instance{{InstanceLifecycle: types.InstanceLifecycleTypeSpot}}}},
+		}},
+	)
+	topology := InstanceTopology{Lifecycle: "spot"}
+	assert.NoError(t, describeCapacity(context.Background(), client, "i-1234567890abcdef0", &topology))
+	assert.Equal(t, InstanceTopology{Lifecycle: "spot"}, topology)
+}
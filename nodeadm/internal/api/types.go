@@ -3,6 +3,7 @@
 package api
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -33,21 +34,191 @@ type NodeConfigSpec struct {
 	Instance     InstanceOptions   `json:"instance,omitempty"`
 	Kubelet      KubeletOptions    `json:"kubelet,omitempty"`
 	FeatureGates map[Feature]bool  `json:"featureGates,omitempty"`
+	NodeProvider NodeProvider      `json:"nodeProvider,omitempty"`
+	Hybrid       HybridOptions     `json:"hybrid,omitempty"`
+	Debug        DebugOptions      `json:"debug,omitempty"`
+	Proxy        ProxyOptions      `json:"proxy,omitempty"`
+	Security     SecurityOptions   `json:"security,omitempty"`
+	Hooks        HooksOptions      `json:"hooks,omitempty"`
+	Components   ComponentsOptions `json:"components,omitempty"`
+	Networking   NetworkingOptions `json:"networking,omitempty"`
+	Monitoring   MonitoringOptions `json:"monitoring,omitempty"`
+	Offline      bool              `json:"offline,omitempty"`
+	System       SystemOptions     `json:"system,omitempty"`
+	Systemd      SystemdOptions    `json:"systemd,omitempty"`
+}
+
+type SystemOptions struct {
+	Sysctl map[string]string `json:"sysctl,omitempty"`
+}
+
+type SystemdOptions struct {
+	Units []SystemdUnit `json:"units,omitempty"`
+}
+
+type SystemdUnit struct {
+	Name    string          `json:"name"`
+	DropIns []SystemdDropIn `json:"dropIns,omitempty"`
+}
+
+type SystemdDropIn struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+type MonitoringOptions struct {
+	CloudWatchAgent CloudWatchAgentOptions `json:"cloudwatchAgent,omitempty"`
+}
+
+type CloudWatchAgentOptions struct {
+	Enabled                   bool                    `json:"enabled,omitempty"`
+	Namespace                 string                  `json:"namespace,omitempty"`
+	Metrics                   []CloudWatchAgentMetric `json:"metrics,omitempty"`
+	MetricsCollectionInterval *metav1.Duration        `json:"metricsCollectionInterval,omitempty"`
+	LogGroupName              string                  `json:"logGroupName,omitempty"`
+}
+
+type CloudWatchAgentMetric string
+
+const (
+	CloudWatchAgentMetricCPU    CloudWatchAgentMetric = "cpu"
+	CloudWatchAgentMetricDisk   CloudWatchAgentMetric = "disk"
+	CloudWatchAgentMetricDiskIO CloudWatchAgentMetric = "diskio"
+	CloudWatchAgentMetricMem    CloudWatchAgentMetric = "mem"
+	CloudWatchAgentMetricNet    CloudWatchAgentMetric = "net"
+)
+
+type NetworkingOptions struct {
+	ExcludeInterfaces []string `json:"excludeInterfaces,omitempty"`
+}
+
+type ComponentsOptions struct {
+	Repository string `json:"repository,omitempty"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	Kubelet    string `json:"kubelet,omitempty"`
+	Containerd string `json:"containerd,omitempty"`
+	Runc       string `json:"runc,omitempty"`
+	CNIPlugins string `json:"cniPlugins,omitempty"`
+}
+
+type HooksOptions struct {
+	PreInit        []Hook `json:"preInit,omitempty"`
+	PostContainerd []Hook `json:"postContainerd,omitempty"`
+	PostKubelet    []Hook `json:"postKubelet,omitempty"`
+}
+
+type Hook struct {
+	Name          string            `json:"name,omitempty"`
+	Command       []string          `json:"command,omitempty"`
+	Script        string            `json:"script,omitempty"`
+	URL           string            `json:"url,omitempty"`
+	SHA256        string            `json:"sha256,omitempty"`
+	Timeout       *metav1.Duration  `json:"timeout,omitempty"`
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyFail   HookFailurePolicy = "Fail"
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+type ProxyOptions struct {
+	HTTPProxy  string   `json:"httpProxy,omitempty"`
+	HTTPSProxy string   `json:"httpsProxy,omitempty"`
+	NoProxy    []string `json:"noProxy,omitempty"`
+}
+
+type SecurityOptions struct {
+	HardeningProfile HardeningProfile      `json:"hardeningProfile,omitempty"`
+	UserNamespaces   UserNamespacesOptions `json:"userNamespaces,omitempty"`
+	SeccompDefault   SeccompDefaultOptions `json:"seccompDefault,omitempty"`
+}
+
+type UserNamespacesOptions struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type SeccompDefaultOptions struct {
+	Enabled        bool             `json:"enabled,omitempty"`
+	DefaultProfile string           `json:"defaultProfile,omitempty"`
+	Profiles       []SeccompProfile `json:"profiles,omitempty"`
+}
+
+type SeccompProfile struct {
+	Name    string `json:"name"`
+	Profile string `json:"profile,omitempty"`
+	URL     string `json:"url,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+}
+
+type HardeningProfile string
+
+const (
+	HardeningProfileNone      HardeningProfile = "none"
+	HardeningProfileCISLevel1 HardeningProfile = "cis-level1"
+	HardeningProfileCISLevel2 HardeningProfile = "cis-level2"
+)
+
+type DebugOptions struct {
+	NetworkCapture NetworkCaptureOptions `json:"networkCapture,omitempty"`
+	Tracing        TracingOptions        `json:"tracing,omitempty"`
+	Logging        LoggingOptions        `json:"logging,omitempty"`
+}
+
+type LoggingOptions struct {
+	CloudWatch CloudWatchLoggingOptions `json:"cloudWatch,omitempty"`
+}
+
+type CloudWatchLoggingOptions struct {
+	Enabled      bool             `json:"enabled,omitempty"`
+	LogGroupName string           `json:"logGroupName,omitempty"`
+	Units        []string         `json:"units,omitempty"`
+	Duration     *metav1.Duration `json:"duration,omitempty"`
+}
+
+type NetworkCaptureOptions struct {
+	Enabled  bool             `json:"enabled,omitempty"`
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+type TracingOptions struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	File     string `json:"file,omitempty"`
 }
 
 type NodeConfigStatus struct {
 	Instance       InstanceDetails `json:"instance,omitempty"`
 	Defaults       DefaultOptions  `json:"default,omitempty"`
 	KubeletVersion string          `json:"kubeletVersion,omitempty"`
+	// CgroupVersion is the cgroup hierarchy that the node was booted with
+	CgroupVersion CgroupVersion `json:"cgroupVersion,omitempty"`
+	// Architecture is the architecture of the node, which is that of nodeadm
+	Architecture Architecture `json:"architecture,omitempty"`
+	// ContainerdVersion is the version of the installed containerd, or empty
+	// when it could not be detected
+	ContainerdVersion string `json:"containerdVersion,omitempty"`
 }
 
 type InstanceDetails struct {
 	ID               string `json:"id,omitempty"`
+	AccountID        string `json:"accountId,omitempty"`
 	Region           string `json:"region,omitempty"`
 	Type             string `json:"type,omitempty"`
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 	MAC              string `json:"mac,omitempty"`
 	PrivateDNSName   string `json:"privateDnsName,omitempty"`
+	// Topology is only read when auto labels are enabled
+	Topology InstanceTopology `json:"topology,omitempty"`
+}
+
+type InstanceTopology struct {
+	AvailabilityZoneID    string `json:"availabilityZoneId,omitempty"`
+	Lifecycle             string `json:"lifecycle,omitempty"`
+	PlacementGroup        string `json:"placementGroup,omitempty"`
+	PlacementPartition    string `json:"placementPartition,omitempty"`
+	CapacityReservationID string `json:"capacityReservationId,omitempty"`
 }
 
 type DefaultOptions struct {
@@ -55,14 +226,42 @@ type DefaultOptions struct {
 }
 
 type ClusterDetails struct {
-	Name                 string `json:"name,omitempty"`
-	APIServerEndpoint    string `json:"apiServerEndpoint,omitempty"`
-	CertificateAuthority []byte `json:"certificateAuthority,omitempty"`
-	CIDR                 string `json:"cidr,omitempty"`
-	EnableOutpost        *bool  `json:"enableOutpost,omitempty"`
-	ID                   string `json:"id,omitempty"`
+	Name                     string                  `json:"name,omitempty"`
+	APIServerEndpoint        string                  `json:"apiServerEndpoint,omitempty"`
+	CertificateAuthority     []byte                  `json:"certificateAuthority,omitempty"`
+	CertificateAuthorityFile string                  `json:"certificateAuthorityFile,omitempty"`
+	CertificatePins          []string                `json:"certificatePins,omitempty"`
+	CIDR                     string                  `json:"cidr,omitempty"`
+	DNSDomain                string                  `json:"dnsDomain,omitempty"`
+	Discovery                ClusterDiscoveryOptions `json:"discovery,omitempty"`
+	EndpointOverrides        EndpointOverrides       `json:"endpointOverrides,omitempty"`
+	EnableOutpost            *bool                   `json:"enableOutpost,omitempty"`
+	ID                       string                  `json:"id,omitempty"`
+}
+
+type EndpointOverrides struct {
+	EKS string `json:"eks,omitempty"`
+	EC2 string `json:"ec2,omitempty"`
+	STS string `json:"sts,omitempty"`
+	ECR string `json:"ecr,omitempty"`
 }
 
+type ClusterDiscoveryOptions struct {
+	Source       ClusterDiscoverySource `json:"source,omitempty"`
+	TagKey       string                 `json:"tagKey,omitempty"`
+	SSMParameter string                 `json:"ssmParameter,omitempty"`
+	MinDelay     *metav1.Duration       `json:"minDelay,omitempty"`
+	MaxDelay     *metav1.Duration       `json:"maxDelay,omitempty"`
+	Timeout      *metav1.Duration       `json:"timeout,omitempty"`
+}
+
+type ClusterDiscoverySource string
+
+const (
+	ClusterDiscoverySourceTag ClusterDiscoverySource = "tag"
+	ClusterDiscoverySourceSSM ClusterDiscoverySource = "ssm"
+)
+
 type KubeletFlags []string
 type KubeletOptions struct {
 	// Config is a kubelet config that can be provided by the user to override
@@ -73,16 +272,348 @@ type KubeletOptions struct {
 	// amended to the generated defaults, and therefore will act as overrides
 	// https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/
 	Flags KubeletFlags `json:"flags,omitempty"`
+	// FeatureGates are kubelet feature gates that are merged with the generated
+	// defaults, after being checked against the kubelet version
+	FeatureGates       map[string]bool              `json:"featureGates,omitempty"`
+	SwapBehavior       SwapBehavior                 `json:"swapBehavior,omitempty"`
+	TokenCache         TokenCacheOptions            `json:"tokenCache,omitempty"`
+	NodeLabels         map[string]string            `json:"nodeLabels,omitempty"`
+	NodeTaints         []Taint                      `json:"nodeTaints,omitempty"`
+	ServingCertificate ServingCertificateOptions    `json:"servingCertificate,omitempty"`
+	ClusterDNS         []string                     `json:"clusterDNS,omitempty"`
+	NodeIP             NodeIPOptions                `json:"nodeIP,omitempty"`
+	StaticPods         []StaticPod                  `json:"staticPods,omitempty"`
+	AuthenticationMode KubeletAuthenticationMode    `json:"authenticationMode,omitempty"`
+	Authentication     KubeletAuthenticationOptions `json:"authentication,omitempty"`
+
+	Readiness               ReadinessOptions           `json:"readiness,omitempty"`
+	AutoLabels              AutoLabelsOptions          `json:"autoLabels,omitempty"`
+	StatusAnnotations       StatusAnnotationsOptions   `json:"statusAnnotations,omitempty"`
+	RemoteConfig            KubeletRemoteConfigOptions `json:"remoteConfig,omitempty"`
+	CPUManagerPolicy        CPUManagerPolicy           `json:"cpuManagerPolicy,omitempty"`
+	TopologyManagerPolicy   TopologyManagerPolicy      `json:"topologyManagerPolicy,omitempty"`
+	MemoryManagerPolicy     MemoryManagerPolicy        `json:"memoryManagerPolicy,omitempty"`
+	ReservedCPUs            string                     `json:"reservedCPUs,omitempty"`
+	ReservedResourcesPolicy ReservedResourcesPolicy    `json:"reservedResourcesPolicy,omitempty"`
+	DiskCheck               DiskCheckOptions           `json:"diskCheck,omitempty"`
 }
 
+type KubeletRemoteConfigOptions struct {
+	Source             string           `json:"source,omitempty"`
+	Interval           *metav1.Duration `json:"interval,omitempty"`
+	MinRestartInterval *metav1.Duration `json:"minRestartInterval,omitempty"`
+}
+
+type StatusAnnotationsOptions struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type AutoLabelsOptions struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type ReadinessOptions struct {
+	WaitForReady bool             `json:"waitForReady,omitempty"`
+	Timeout      *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type KubeletAuthenticationMode string
+
+const (
+	KubeletAuthenticationModeAWSCLI              KubeletAuthenticationMode = "aws-cli"
+	KubeletAuthenticationModeAWSIAMAuthenticator KubeletAuthenticationMode = "aws-iam-authenticator"
+	KubeletAuthenticationModeClientCertificate   KubeletAuthenticationMode = "client-certificate"
+)
+
+type KubeletAuthenticationOptions struct {
+	RoleARN           string `json:"roleARN,omitempty"`
+	SessionName       string `json:"sessionName,omitempty"`
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	ClientKey         string `json:"clientKey,omitempty"`
+}
+
+type StaticPod struct {
+	Name     string `json:"name"`
+	Manifest string `json:"manifest,omitempty"`
+	URL      string `json:"url,omitempty"`
+	SHA256   string `json:"sha256,omitempty"`
+}
+
+type NodeIPOptions struct {
+	Policy    NodeIPPolicy `json:"policy,omitempty"`
+	Addresses []string     `json:"addresses,omitempty"`
+}
+
+type NodeIPPolicy string
+
+const (
+	NodeIPPolicyPrimaryPrivate NodeIPPolicy = "PrimaryPrivate"
+	NodeIPPolicySecondaryENI   NodeIPPolicy = "SecondaryENI"
+	NodeIPPolicyIPv6           NodeIPPolicy = "IPv6"
+	NodeIPPolicyExplicit       NodeIPPolicy = "Explicit"
+)
+
+type Taint struct {
+	Key    string      `json:"key"`
+	Value  string      `json:"value,omitempty"`
+	Effect TaintEffect `json:"effect"`
+}
+
+type TaintEffect string
+
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	TaintEffectNoExecute        TaintEffect = "NoExecute"
+)
+
+type ServingCertificateOptions struct {
+	WaitForIssuance bool             `json:"waitForIssuance,omitempty"`
+	Timeout         *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type TokenCacheOptions struct {
+	Enabled     bool             `json:"enabled,omitempty"`
+	LeadTime    *metav1.Duration `json:"leadTime,omitempty"`
+	MaxAttempts int              `json:"maxAttempts,omitempty"`
+}
+
+type SwapBehavior string
+
+const (
+	SwapBehaviorNoSwap      SwapBehavior = "NoSwap"
+	SwapBehaviorLimitedSwap SwapBehavior = "LimitedSwap"
+)
+
+type CPUManagerPolicy string
+
+const (
+	CPUManagerPolicyNone   CPUManagerPolicy = "none"
+	CPUManagerPolicyStatic CPUManagerPolicy = "static"
+)
+
+type TopologyManagerPolicy string
+
+const (
+	TopologyManagerPolicyNone           TopologyManagerPolicy = "none"
+	TopologyManagerPolicyBestEffort     TopologyManagerPolicy = "best-effort"
+	TopologyManagerPolicyRestricted     TopologyManagerPolicy = "restricted"
+	TopologyManagerPolicySingleNUMANode TopologyManagerPolicy = "single-numa-node"
+)
+
+type MemoryManagerPolicy string
+
+const (
+	MemoryManagerPolicyNone   MemoryManagerPolicy = "None"
+	MemoryManagerPolicyStatic MemoryManagerPolicy = "Static"
+)
+
+type ReservedResourcesPolicy string
+
+const (
+	ReservedResourcesPolicyEKS ReservedResourcesPolicy = "eks"
+	ReservedResourcesPolicyGKE ReservedResourcesPolicy = "gke"
+)
+
+type DiskCheckOptions struct {
+	DaemonSetFootprint *resource.Quantity     `json:"daemonSetFootprint,omitempty"`
+	FailurePolicy      DiskCheckFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+type DiskCheckFailurePolicy string
+
+const (
+	DiskCheckFailurePolicyWarn DiskCheckFailurePolicy = "Warn"
+	DiskCheckFailurePolicyFail DiskCheckFailurePolicy = "Fail"
+)
+
 // InlineDocument is an alias to a dynamically typed map. This allows using
 // embedded YAML and JSON types within the parent yaml config.
 type InlineDocument map[string]runtime.RawExtension
 
 type ContainerdConfig string
 type ContainerdOptions struct {
-	Config          ContainerdConfig `json:"config,omitempty"`
-	BaseRuntimeSpec InlineDocument   `json:"baseRuntimeSpec,omitempty"`
+	Config                   ContainerdConfig                   `json:"config,omitempty"`
+	BaseRuntimeSpec          InlineDocument                     `json:"baseRuntimeSpec,omitempty"`
+	BaseRuntimeSpecOverrides map[string]InlineDocument          `json:"baseRuntimeSpecOverrides,omitempty"`
+	DefaultRuntimeBinary     RuntimeBinary                      `json:"defaultRuntimeBinary,omitempty"`
+	SandboxImage             string                             `json:"sandboxImage,omitempty"`
+	Runsc                    *RunscOptions                      `json:"runsc,omitempty"`
+	Runtimes                 []ContainerdRuntime                `json:"runtimes,omitempty"`
+	DefaultRuntime           string                             `json:"defaultRuntime,omitempty"`
+	UntrustedWorkloadRuntime string                             `json:"untrustedWorkloadRuntime,omitempty"`
+	SOCI                     SOCIOptions                        `json:"soci,omitempty"`
+	PrePullImages            PrePullImagesOptions               `json:"prePullImages,omitempty"`
+	SmokeTest                SmokeTestOptions                   `json:"smokeTest,omitempty"`
+	DiscardUnpackedLayers    *bool                              `json:"discardUnpackedLayers,omitempty"`
+	GarbageCollection        ContainerdGarbageCollectionOptions `json:"garbageCollection,omitempty"`
+	ImageRetention           ImageRetentionOptions              `json:"imageRetention,omitempty"`
+	RegistryCredentials      []RegistryCredential               `json:"registryCredentials,omitempty"`
+	Metrics                  ContainerdMetricsOptions           `json:"metrics,omitempty"`
+	Debug                    ContainerdDebugOptions             `json:"debug,omitempty"`
+	NRI                      ContainerdNRIOptions               `json:"nri,omitempty"`
+	ImagePulls               ContainerdImagePullOptions         `json:"imagePulls,omitempty"`
+}
+
+type RegistryCredential struct {
+	Registry  string `json:"registry"`
+	SecretARN string `json:"secretArn"`
+}
+
+type PrePullImagesOptions struct {
+	Images       []string `json:"images,omitempty"`
+	ImageListURL string   `json:"imageListURL,omitempty"`
+	Concurrency  int      `json:"concurrency,omitempty"`
+	FailOpen     bool     `json:"failOpen,omitempty"`
+}
+
+type SmokeTestOptions struct {
+	Enabled bool             `json:"enabled,omitempty"`
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type ContainerdGarbageCollectionOptions struct {
+	PauseThresholdPercent int              `json:"pauseThresholdPercent,omitempty"`
+	DeletionThreshold     int              `json:"deletionThreshold,omitempty"`
+	MutationThreshold     int              `json:"mutationThreshold,omitempty"`
+	ScheduleDelay         *metav1.Duration `json:"scheduleDelay,omitempty"`
+}
+
+type ContainerdImagePullOptions struct {
+	MaxConcurrentDownloads int              `json:"maxConcurrentDownloads,omitempty"`
+	ProgressTimeout        *metav1.Duration `json:"progressTimeout,omitempty"`
+}
+
+type ContainerdMetricsOptions struct {
+	Address       string `json:"address,omitempty"`
+	GRPCHistogram bool   `json:"grpcHistogram,omitempty"`
+}
+
+type ContainerdDebugOptions struct {
+	Address string             `json:"address,omitempty"`
+	Level   ContainerdLogLevel `json:"level,omitempty"`
+}
+
+type ContainerdNRIOptions struct {
+	Enabled                   *bool            `json:"enabled,omitempty"`
+	SocketPath                string           `json:"socketPath,omitempty"`
+	PluginRegistrationTimeout *metav1.Duration `json:"pluginRegistrationTimeout,omitempty"`
+	PluginRequestTimeout      *metav1.Duration `json:"pluginRequestTimeout,omitempty"`
+}
+
+type ContainerdLogLevel string
+
+const (
+	ContainerdLogLevelTrace ContainerdLogLevel = "trace"
+	ContainerdLogLevelDebug ContainerdLogLevel = "debug"
+	ContainerdLogLevelInfo  ContainerdLogLevel = "info"
+	ContainerdLogLevelWarn  ContainerdLogLevel = "warn"
+	ContainerdLogLevelError ContainerdLogLevel = "error"
+	ContainerdLogLevelFatal ContainerdLogLevel = "fatal"
+	ContainerdLogLevelPanic ContainerdLogLevel = "panic"
+)
+
+type ImageRetentionOptions struct {
+	HighThresholdPercent int              `json:"highThresholdPercent,omitempty"`
+	LowThresholdPercent  int              `json:"lowThresholdPercent,omitempty"`
+	MinimumAge           *metav1.Duration `json:"minimumAge,omitempty"`
+	MaximumAge           *metav1.Duration `json:"maximumAge,omitempty"`
+	PinnedImages         []string         `json:"pinnedImages,omitempty"`
+	KeepLast             int              `json:"keepLast,omitempty"`
+}
+
+type SOCIOptions struct {
+	MaxConcurrentDownloads         int              `json:"maxConcurrentDownloads,omitempty"`
+	MaxConcurrentDownloadsPerImage int              `json:"maxConcurrentDownloadsPerImage,omitempty"`
+	MaxConcurrentUnpacksPerImage   int              `json:"maxConcurrentUnpacksPerImage,omitempty"`
+	ContentStore                   SOCIContentStore `json:"contentStore,omitempty"`
+	Fallback                       SOCIFallback     `json:"fallback,omitempty"`
+}
+
+type SOCIContentStore string
+
+const (
+	SOCIContentStoreSOCI       SOCIContentStore = "soci"
+	SOCIContentStoreContainerd SOCIContentStore = "containerd"
+)
+
+type SOCIFallback string
+
+const (
+	SOCIFallbackSequential SOCIFallback = "sequential"
+	SOCIFallbackParallel   SOCIFallback = "parallel"
+)
+
+type RuntimeBinary string
+
+const (
+	RuntimeBinaryRunc RuntimeBinary = "runc"
+	RuntimeBinaryCrun RuntimeBinary = "crun"
+)
+
+type ContainerdRuntime struct {
+	Name                         string         `json:"name"`
+	RuntimeType                  string         `json:"runtimeType"`
+	Options                      InlineDocument `json:"options,omitempty"`
+	Snapshotter                  string         `json:"snapshotter,omitempty"`
+	PrivilegedWithoutHostDevices bool           `json:"privilegedWithoutHostDevices,omitempty"`
+}
+
+type RunscOptions struct {
+	Platform RunscPlatform `json:"platform,omitempty"`
+	Network  RunscNetwork  `json:"network,omitempty"`
+	Overlay  RunscOverlay  `json:"overlay,omitempty"`
+}
+
+type RunscPlatform string
+
+const (
+	RunscPlatformSystrap RunscPlatform = "systrap"
+	RunscPlatformKVM     RunscPlatform = "kvm"
+)
+
+type RunscNetwork string
+
+const (
+	RunscNetworkSandbox RunscNetwork = "sandbox"
+	RunscNetworkHost    RunscNetwork = "host"
+	RunscNetworkNone    RunscNetwork = "none"
+)
+
+type RunscOverlay string
+
+const (
+	RunscOverlayRootMemory RunscOverlay = "root:memory"
+	RunscOverlayRootSelf   RunscOverlay = "root:self"
+	RunscOverlayNone       RunscOverlay = "none"
+)
+
+type NodeProvider string
+
+const (
+	NodeProviderEC2    NodeProvider = "ec2"
+	NodeProviderHybrid NodeProvider = "hybrid"
+)
+
+type HybridOptions struct {
+	NodeName         string                  `json:"nodeName,omitempty"`
+	NodeIP           string                  `json:"nodeIP,omitempty"`
+	Region           string                  `json:"region,omitempty"`
+	SSM              SSMOptions              `json:"ssm,omitempty"`
+	IAMRolesAnywhere IAMRolesAnywhereOptions `json:"iamRolesAnywhere,omitempty"`
+}
+
+type SSMOptions struct {
+	ActivationCode string `json:"activationCode,omitempty"`
+	ActivationID   string `json:"activationID,omitempty"`
+}
+
+type IAMRolesAnywhereOptions struct {
+	TrustAnchorARN  string `json:"trustAnchorARN,omitempty"`
+	ProfileARN      string `json:"profileARN,omitempty"`
+	RoleARN         string `json:"roleARN,omitempty"`
+	CertificatePath string `json:"certificatePath,omitempty"`
+	PrivateKeyPath  string `json:"privateKeyPath,omitempty"`
 }
 
 type IPFamily string
@@ -93,9 +624,189 @@ const (
 )
 
 type InstanceOptions struct {
-	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+	LocalStorage     LocalStorageOptions     `json:"localStorage,omitempty"`
+	Shutdown         ShutdownOptions         `json:"shutdown,omitempty"`
+	TrustStore       TrustStoreOptions       `json:"trustStore,omitempty"`
+	PodLogs          PodLogsOptions          `json:"podLogs,omitempty"`
+	Journald         JournaldOptions         `json:"journald,omitempty"`
+	Cgroup           CgroupOptions           `json:"cgroup,omitempty"`
+	Swap             SwapOptions             `json:"swap,omitempty"`
+	PressureMonitor  PressureMonitorOptions  `json:"pressureMonitor,omitempty"`
+	TimeSync         TimeSyncOptions         `json:"timeSync,omitempty"`
+	EFA              EFAOptions              `json:"efa,omitempty"`
+	Neuron           NeuronOptions           `json:"neuron,omitempty"`
+	BootstrapProfile BootstrapProfile        `json:"bootstrapProfile,omitempty"`
+	Tags             InstanceTagsOptions     `json:"tags,omitempty"`
+	Identity         InstanceIdentityOptions `json:"identity,omitempty"`
+}
+
+type InstanceIdentityOptions struct {
+	InstanceID       string `json:"instanceID,omitempty"`
+	InstanceType     string `json:"instanceType,omitempty"`
+	Region           string `json:"region,omitempty"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+type InstanceTagsOptions struct {
+	Enabled bool               `json:"enabled,omitempty"`
+	Prefix  string             `json:"prefix,omitempty"`
+	Source  InstanceTagsSource `json:"source,omitempty"`
+}
+
+type InstanceTagsSource string
+
+const (
+	InstanceTagsSourceIMDS InstanceTagsSource = "imds"
+	InstanceTagsSourceEC2  InstanceTagsSource = "ec2"
+)
+
+type BootstrapProfile string
+
+const (
+	BootstrapProfileDefault        BootstrapProfile = "default"
+	BootstrapProfileMassiveScaleUp BootstrapProfile = "massive-scaleup"
+)
+
+type EFAOptions struct {
+	Enabled        bool               `json:"enabled,omitempty"`
+	HugePages      *int32             `json:"hugePages,omitempty"`
+	ReservedMemory *resource.Quantity `json:"reservedMemory,omitempty"`
+}
+
+type NeuronOptions struct {
+	Enabled        bool               `json:"enabled,omitempty"`
+	HugePages      *int32             `json:"hugePages,omitempty"`
+	ReservedMemory *resource.Quantity `json:"reservedMemory,omitempty"`
+}
+
+type TimeSyncOptions struct {
+	Servers     []string         `json:"servers,omitempty"`
+	SyncTimeout *metav1.Duration `json:"syncTimeout,omitempty"`
+}
+
+type PressureMonitorOptions struct {
+	Enabled         bool           `json:"enabled,omitempty"`
+	MemoryThreshold int            `json:"memoryThreshold,omitempty"`
+	Action          PressureAction `json:"action,omitempty"`
+	MetricsPort     int            `json:"metricsPort,omitempty"`
+}
+
+type PressureAction string
+
+const (
+	PressureActionReport PressureAction = "Report"
+	PressureActionCordon PressureAction = "Cordon"
+	PressureActionEvict  PressureAction = "Evict"
+)
+
+type SwapOptions struct {
+	Device SwapDevice         `json:"device,omitempty"`
+	Size   *resource.Quantity `json:"size,omitempty"`
+}
+
+type SwapDevice string
+
+const (
+	SwapDeviceFile SwapDevice = "File"
+	SwapDeviceZram SwapDevice = "Zram"
+)
+
+type CgroupOptions struct {
+	Version CgroupVersion   `json:"version,omitempty"`
+	Driver  CgroupDriver    `json:"driver,omitempty"`
+	IO      CgroupIOOptions `json:"io,omitempty"`
+}
+
+type CgroupIOOptions struct {
+	RuntimeWeight int `json:"runtimeWeight,omitempty"`
+	PodsWeight    int `json:"podsWeight,omitempty"`
 }
 
+type CgroupVersion string
+
+const (
+	CgroupVersionV1 CgroupVersion = "v1"
+	CgroupVersionV2 CgroupVersion = "v2"
+)
+
+// Architecture is named as in Go and in the platforms of container images.
+type Architecture string
+
+const (
+	ArchitectureAMD64 Architecture = "amd64"
+	ArchitectureARM64 Architecture = "arm64"
+)
+
+// Machine returns the name of the architecture as reported by `uname -m`, and
+// as used in the names of the EKS optimized AMIs.
+func (a Architecture) Machine() string {
+	switch a {
+	case ArchitectureAMD64:
+		return "x86_64"
+	case ArchitectureARM64:
+		return "aarch64"
+	}
+	return string(a)
+}
+
+type CgroupDriver string
+
+const (
+	CgroupDriverSystemd  CgroupDriver = "systemd"
+	CgroupDriverCgroupfs CgroupDriver = "cgroupfs"
+)
+
+type PodLogsOptions struct {
+	Storage     PodLogsStorage          `json:"storage,omitempty"`
+	MemoryLimit *resource.Quantity      `json:"memoryLimit,omitempty"`
+	MaxFileSize *resource.Quantity      `json:"maxFileSize,omitempty"`
+	MaxFiles    *int32                  `json:"maxFiles,omitempty"`
+	Forwarder   PodLogsForwarderOptions `json:"forwarder,omitempty"`
+}
+
+type JournaldOptions struct {
+	RateLimitInterval *metav1.Duration   `json:"rateLimitInterval,omitempty"`
+	RateLimitBurst    *int32             `json:"rateLimitBurst,omitempty"`
+	SystemMaxUse      *resource.Quantity `json:"systemMaxUse,omitempty"`
+}
+
+type PodLogsStorage string
+
+const (
+	PodLogsStorageDisk   PodLogsStorage = "Disk"
+	PodLogsStorageMemory PodLogsStorage = "Memory"
+)
+
+type PodLogsForwarderOptions struct {
+	CloudWatchLogGroup string `json:"cloudWatchLogGroup,omitempty"`
+}
+
+type TrustStoreOptions struct {
+	CertificateAuthorities string `json:"certificateAuthorities,omitempty"`
+}
+
+type ShutdownOptions struct {
+	DeregisterNode          bool                      `json:"deregisterNode,omitempty"`
+	DrainTimeout            *metav1.Duration          `json:"drainTimeout,omitempty"`
+	GracePeriod             *metav1.Duration          `json:"gracePeriod,omitempty"`
+	CriticalPodsGracePeriod *metav1.Duration          `json:"criticalPodsGracePeriod,omitempty"`
+	DrainOnSpotInterruption bool                      `json:"drainOnSpotInterruption,omitempty"`
+	TerminationHandler      TerminationHandlerOptions `json:"terminationHandler,omitempty"`
+}
+
+type TerminationHandlerOptions struct {
+	Events       []TerminationEvent `json:"events,omitempty"`
+	PollInterval *metav1.Duration   `json:"pollInterval,omitempty"`
+}
+
+type TerminationEvent string
+
+const (
+	TerminationEventSpotInterruption     TerminationEvent = "SpotInterruption"
+	TerminationEventLifecycleTermination TerminationEvent = "LifecycleTermination"
+	TerminationEventScheduledEvent       TerminationEvent = "ScheduledEvent"
+)
+
 type LocalStorageOptions struct {
 	Strategy       LocalStorageStrategy `json:"strategy,omitempty"`
 	MountPath      string               `json:"mountPath,omitempty"`
@@ -108,6 +819,7 @@ const (
 	LocalStorageRAID0  LocalStorageStrategy = "RAID0"
 	LocalStorageRAID10 LocalStorageStrategy = "RAID10"
 	LocalStorageMount  LocalStorageStrategy = "Mount"
+	LocalStorageNone   LocalStorageStrategy = "None"
 )
 
 type DisabledMount string
@@ -122,4 +834,10 @@ type Feature string
 const (
 	// InstanceIdNodeName will use EC2 instance ID as node name
 	InstanceIdNodeName Feature = "InstanceIdNodeName"
+	// FastContainerImagePull will lazily pull images with the soci-snapshotter
+	FastContainerImagePull Feature = "FastContainerImagePull"
+	// NodeLocalDNSCache will point pods at the link-local address of NodeLocal DNSCache
+	NodeLocalDNSCache Feature = "NodeLocalDNSCache"
+	// APIServerEndpointCheck will check that the node can use the kube-apiserver before kubelet is started
+	APIServerEndpointCheck Feature = "APIServerEndpointCheck"
 )
@@ -1,23 +1,1513 @@
 package api
 
-import "fmt"
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"golang.org/x/mod/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/cpuset"
+)
+
+// maxTokenLeadTime leaves time for a token to be used before it is replaced,
+// since tokens are valid for 14 minutes.
+const maxTokenLeadTime = 10 * time.Minute
+
+// certificatePinPattern matches the hashes of public keys, in the format used
+// by kubeadm to pin the cluster certificate authority.
+var certificatePinPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// maxDrainTimeout leaves time for the Node to be deleted before the
+// deregistration unit is stopped by systemd during shutdown.
+const maxDrainTimeout = 4 * time.Minute
 
 func ValidateNodeConfig(cfg *NodeConfig) error {
-	if cfg.Spec.Cluster.Name == "" {
+	// the details of a discovered cluster are read while the config is
+	// enriched, which validates them once they are
+	if cfg.Spec.Cluster.Discovery.Source == "" {
+		if err := ValidateClusterDetails(&cfg.Spec.Cluster); err != nil {
+			return err
+		}
+	}
+	if cfg.Spec.Cluster.CIDR != "" {
+		if _, err := cfg.Spec.Cluster.GetServiceCIDRs(); err != nil {
+			return fmt.Errorf("CIDR is invalid in cluster configuration: %w", err)
+		}
+	}
+	if err := validateClusterDiscoveryOptions(&cfg.Spec.Cluster.Discovery, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if err := validateEndpointOverrides(&cfg.Spec.Cluster.EndpointOverrides); err != nil {
+		return err
+	}
+	if err := validateOfflineMode(cfg); err != nil {
+		return err
+	}
+	if dnsDomain := cfg.Spec.Cluster.DNSDomain; dnsDomain != "" {
+		if errs := validation.IsDNS1123Subdomain(dnsDomain); len(errs) > 0 {
+			return fmt.Errorf("DNS domain %q is invalid in cluster configuration: %s", dnsDomain, strings.Join(errs, ", "))
+		}
+	}
+	for _, pin := range cfg.Spec.Cluster.CertificatePins {
+		if !certificatePinPattern.MatchString(pin) {
+			return fmt.Errorf("Certificate pin %q in cluster configuration must be formatted as sha256:<hex>", pin)
+		}
+	}
+	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
+		if cfg.Spec.Cluster.ID == "" {
+			return fmt.Errorf("CIDR is missing in cluster configuration")
+		}
+	}
+	if err := validateProxyOptions(&cfg.Spec.Proxy); err != nil {
+		return err
+	}
+	if cas := cfg.Spec.Instance.TrustStore.CertificateAuthorities; cas != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(cas)) {
+		return fmt.Errorf("No PEM-encoded certificates found in trust store configuration")
+	}
+	if err := validateInstanceTagsOptions(&cfg.Spec.Instance.Tags, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if err := validateShutdownOptions(&cfg.Spec.Instance.Shutdown, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if duration := cfg.Spec.Debug.NetworkCapture.Duration; duration != nil && duration.Duration < time.Second {
+		return fmt.Errorf("Duration in network capture configuration must be at least 1s")
+	}
+	if err := validateTracingOptions(&cfg.Spec.Debug.Tracing); err != nil {
+		return err
+	}
+	if err := validateCloudWatchLoggingOptions(&cfg.Spec.Debug.Logging.CloudWatch, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	switch cfg.Spec.Containerd.DefaultRuntimeBinary {
+	case "", RuntimeBinaryRunc, RuntimeBinaryCrun:
+	default:
+		return fmt.Errorf("Default runtime binary %q is not one of %v", cfg.Spec.Containerd.DefaultRuntimeBinary, []RuntimeBinary{RuntimeBinaryRunc, RuntimeBinaryCrun})
+	}
+	if err := validateSandboxImage(cfg.Spec.Containerd.SandboxImage); err != nil {
+		return err
+	}
+	for handler := range cfg.Spec.Containerd.BaseRuntimeSpecOverrides {
+		// handlers are referenced by RuntimeClasses, which require a DNS label
+		if errs := validation.IsDNS1123Label(handler); len(errs) > 0 {
+			return fmt.Errorf("Runtime handler %q is invalid in base runtime spec overrides: %s", handler, strings.Join(errs, ", "))
+		}
+	}
+	if err := validateSOCIOptions(&cfg.Spec.Containerd.SOCI, IsFeatureEnabled(FastContainerImagePull, cfg.Spec.FeatureGates)); err != nil {
+		return err
+	}
+	if err := validatePrePullImagesOptions(&cfg.Spec.Containerd.PrePullImages); err != nil {
+		return err
+	}
+	if timeout := cfg.Spec.Containerd.SmokeTest.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Timeout in smoke test configuration must be greater than 0")
+	}
+	if err := validateContainerdGarbageCollectionOptions(&cfg.Spec.Containerd.GarbageCollection); err != nil {
+		return err
+	}
+	if err := validateImageRetentionOptions(&cfg.Spec.Containerd.ImageRetention); err != nil {
+		return err
+	}
+	if err := validateContainerdMetricsOptions(&cfg.Spec.Containerd.Metrics); err != nil {
+		return err
+	}
+	if err := validateContainerdDebugOptions(&cfg.Spec.Containerd.Debug); err != nil {
+		return err
+	}
+	if err := validateContainerdNRIOptions(&cfg.Spec.Containerd.NRI); err != nil {
+		return err
+	}
+	if err := validateContainerdImagePullOptions(&cfg.Spec.Containerd.ImagePulls); err != nil {
+		return err
+	}
+	if runsc := cfg.Spec.Containerd.Runsc; runsc != nil {
+		if err := validateRunscOptions(runsc); err != nil {
+			return err
+		}
+	}
+	if err := validateContainerdRuntimes(cfg.Spec.Containerd.Runtimes); err != nil {
+		return err
+	}
+	if err := validateRuntimeHandlers(&cfg.Spec.Containerd); err != nil {
+		return err
+	}
+	if err := validateRegistryCredentials(cfg.Spec.Containerd.RegistryCredentials); err != nil {
+		return err
+	}
+	if err := validatePodLogsOptions(&cfg.Spec.Instance.PodLogs); err != nil {
+		return err
+	}
+	if err := validateJournaldOptions(&cfg.Spec.Instance.Journald); err != nil {
+		return err
+	}
+	if err := validateCgroupOptions(&cfg.Spec.Instance.Cgroup, cfg.Status.CgroupVersion); err != nil {
+		return err
+	}
+	if err := validateArchitecture(cfg); err != nil {
+		return err
+	}
+	if err := validateTokenCacheOptions(&cfg.Spec.Kubelet.TokenCache); err != nil {
+		return err
+	}
+	if err := validateServingCertificateOptions(&cfg.Spec.Kubelet); err != nil {
+		return err
+	}
+	if err := validateKubeletAuthentication(&cfg.Spec.Kubelet); err != nil {
+		return err
+	}
+	if timeout := cfg.Spec.Kubelet.Readiness.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Timeout in readiness configuration must be greater than 0")
+	}
+	if cfg.Spec.Kubelet.AutoLabels.Enabled && cfg.IsHybrid() {
+		return fmt.Errorf("Auto labels cannot be enabled for hybrid nodes, which are not EC2 instances")
+	}
+	if err := validateNodeIPOptions(&cfg.Spec.Kubelet.NodeIP, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if err := validateKubeletRemoteConfigOptions(&cfg.Spec.Kubelet.RemoteConfig); err != nil {
+		return err
+	}
+	if err := validateResourceManagerOptions(&cfg.Spec.Kubelet); err != nil {
+		return err
+	}
+	if err := validateDiskCheckOptions(&cfg.Spec.Kubelet.DiskCheck); err != nil {
+		return err
+	}
+	if err := validateNetworkingOptions(&cfg.Spec.Networking, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if err := validateCloudWatchAgentOptions(&cfg.Spec.Monitoring.CloudWatchAgent, cfg.IsHybrid()); err != nil {
+		return err
+	}
+	if err := validateStaticPods(cfg.Spec.Kubelet.StaticPods); err != nil {
+		return err
+	}
+	for _, address := range cfg.Spec.Kubelet.ClusterDNS {
+		if net.ParseIP(address) == nil {
+			return fmt.Errorf("Cluster DNS address %q in kubelet configuration is not a valid IP address", address)
+		}
+	}
+	if err := validateNodeLabelsAndTaints(cfg); err != nil {
+		return err
+	}
+	if err := validateSwapOptions(&cfg.Spec.Instance.Swap); err != nil {
+		return err
+	}
+	if err := validatePressureMonitorOptions(&cfg.Spec.Instance.PressureMonitor); err != nil {
+		return err
+	}
+	if err := validateTimeSyncOptions(&cfg.Spec.Instance.TimeSync); err != nil {
+		return err
+	}
+	if err := validateEFAOptions(&cfg.Spec.Instance.EFA); err != nil {
+		return err
+	}
+	if err := validateNeuronOptions(&cfg.Spec.Instance.Neuron); err != nil {
+		return err
+	}
+	if err := validateHooksOptions(&cfg.Spec.Hooks); err != nil {
+		return err
+	}
+	if err := validateComponentsOptions(&cfg.Spec.Components); err != nil {
+		return err
+	}
+	if err := validateSysctl(cfg.Spec.System.Sysctl); err != nil {
+		return err
+	}
+	if err := validateSystemdOptions(&cfg.Spec.Systemd); err != nil {
+		return err
+	}
+	switch cfg.Spec.Instance.BootstrapProfile {
+	case "", BootstrapProfileDefault, BootstrapProfileMassiveScaleUp:
+	default:
+		return fmt.Errorf("Bootstrap profile %q is not one of %v", cfg.Spec.Instance.BootstrapProfile, []BootstrapProfile{BootstrapProfileDefault, BootstrapProfileMassiveScaleUp})
+	}
+	switch cfg.Spec.Kubelet.SwapBehavior {
+	case "", SwapBehaviorNoSwap:
+	case SwapBehaviorLimitedSwap:
+		if cfg.Status.CgroupVersion == CgroupVersionV1 {
+			return fmt.Errorf("Swap behavior %q requires the cgroup %s hierarchy", SwapBehaviorLimitedSwap, CgroupVersionV2)
+		}
+	default:
+		return fmt.Errorf("Swap behavior %q is not one of %v", cfg.Spec.Kubelet.SwapBehavior, []SwapBehavior{SwapBehaviorNoSwap, SwapBehaviorLimitedSwap})
+	}
+	switch cfg.Spec.Security.HardeningProfile {
+	case "", HardeningProfileNone, HardeningProfileCISLevel1, HardeningProfileCISLevel2:
+	default:
+		return fmt.Errorf("Hardening profile %q is not one of %v", cfg.Spec.Security.HardeningProfile, []HardeningProfile{HardeningProfileNone, HardeningProfileCISLevel1, HardeningProfileCISLevel2})
+	}
+	if err := validateSeccompDefaultOptions(&cfg.Spec.Security.SeccompDefault); err != nil {
+		return err
+	}
+	switch cfg.Spec.NodeProvider {
+	case "", NodeProviderEC2:
+	case NodeProviderHybrid:
+		if err := validateHybridOptions(&cfg.Spec.Hybrid); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Node provider %q is not one of %v", cfg.Spec.NodeProvider, []NodeProvider{NodeProviderEC2, NodeProviderHybrid})
+	}
+	return nil
+}
+
+func validateHybridOptions(hybrid *HybridOptions) error {
+	if hybrid.NodeName == "" {
+		return fmt.Errorf("NodeName is missing in hybrid configuration")
+	}
+	if hybrid.Region == "" {
+		return fmt.Errorf("Region is missing in hybrid configuration")
+	}
+	if hybrid.NodeIP != "" && net.ParseIP(hybrid.NodeIP) == nil {
+		return fmt.Errorf("NodeIP %q in hybrid configuration is not a valid IP address", hybrid.NodeIP)
+	}
+	ssm := hybrid.SSM != SSMOptions{}
+	rolesAnywhere := hybrid.IAMRolesAnywhere != IAMRolesAnywhereOptions{}
+	if ssm == rolesAnywhere {
+		return fmt.Errorf("Exactly one of SSM or IAMRolesAnywhere must be provided in hybrid configuration")
+	}
+	if ssm && (hybrid.SSM.ActivationCode == "" || hybrid.SSM.ActivationID == "") {
+		return fmt.Errorf("ActivationCode and ActivationID are required in SSM configuration")
+	}
+	if rolesAnywhere {
+		ra := hybrid.IAMRolesAnywhere
+		if ra.TrustAnchorARN == "" || ra.ProfileARN == "" || ra.RoleARN == "" || ra.CertificatePath == "" || ra.PrivateKeyPath == "" {
+			return fmt.Errorf("TrustAnchorARN, ProfileARN, RoleARN, CertificatePath and PrivateKeyPath are required in IAMRolesAnywhere configuration")
+		}
+	}
+	return nil
+}
+
+func validateProxyOptions(proxy *ProxyOptions) error {
+	if err := validateProxyURL("httpProxy", proxy.HTTPProxy); err != nil {
+		return err
+	}
+	return validateProxyURL("httpsProxy", proxy.HTTPSProxy)
+}
+
+func validateProxyURL(name string, proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid URL in proxy configuration: %w", name, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL in proxy configuration: %s", name, proxyURL)
+	}
+	return nil
+}
+
+// sandboxImageDigestPattern matches the digests that containerd accepts for
+// pinning an image.
+var sandboxImageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+func validateSandboxImage(image string) error {
+	if image == "" {
+		return nil
+	}
+	if strings.ContainsAny(image, " \t\n\"") {
+		return fmt.Errorf("SandboxImage %q in containerd configuration is not a valid image reference", image)
+	}
+	if _, digest, found := strings.Cut(image, "@"); found && !sandboxImageDigestPattern.MatchString(digest) {
+		return fmt.Errorf("SandboxImage %q in containerd configuration must be pinned with a sha256 digest", image)
+	}
+	return nil
+}
+
+// cloudWatchLogGroupPattern matches the names that CloudWatch Logs accepts for
+// a log group.
+var cloudWatchLogGroupPattern = regexp.MustCompile(`^[\.\-_/#A-Za-z0-9]{1,512}$`)
+
+func validatePodLogsOptions(podLogs *PodLogsOptions) error {
+	switch podLogs.Storage {
+	case "", PodLogsStorageDisk:
+		if podLogs.MemoryLimit != nil {
+			return fmt.Errorf("MemoryLimit in pod logs configuration requires storage %q", PodLogsStorageMemory)
+		}
+	case PodLogsStorageMemory:
+		if podLogs.MemoryLimit != nil && podLogs.MemoryLimit.Sign() <= 0 {
+			return fmt.Errorf("MemoryLimit in pod logs configuration must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("Storage %q in pod logs configuration is not one of %v", podLogs.Storage, []PodLogsStorage{PodLogsStorageDisk, PodLogsStorageMemory})
+	}
+	if podLogs.MaxFileSize != nil && podLogs.MaxFileSize.Sign() <= 0 {
+		return fmt.Errorf("MaxFileSize in pod logs configuration must be greater than 0")
+	}
+	if podLogs.MaxFiles != nil && *podLogs.MaxFiles < 2 {
+		return fmt.Errorf("MaxFiles in pod logs configuration must be at least 2")
+	}
+	// a single container must be able to keep its files within the tmpfs
+	if podLogs.IsMemoryBacked() && podLogs.MaxFileSize != nil {
+		maxFiles := int64(2)
+		if podLogs.MaxFiles != nil {
+			maxFiles = int64(*podLogs.MaxFiles)
+		}
+		if limit := podLogs.GetMemoryLimit(); podLogs.MaxFileSize.Value()*maxFiles > limit.Value() {
+			return fmt.Errorf("%d files of MaxFileSize %s in pod logs configuration exceed MemoryLimit %s", maxFiles, podLogs.MaxFileSize.String(), limit.String())
+		}
+	}
+	if logGroup := podLogs.Forwarder.CloudWatchLogGroup; logGroup != "" && !cloudWatchLogGroupPattern.MatchString(logGroup) {
+		return fmt.Errorf("CloudWatchLogGroup %q in pod logs configuration is not a valid log group name", logGroup)
+	}
+	return nil
+}
+
+func validateJournaldOptions(journald *JournaldOptions) error {
+	if journald.RateLimitInterval != nil && journald.RateLimitInterval.Duration < 0 {
+		return fmt.Errorf("RateLimitInterval in journald configuration must not be negative")
+	}
+	if journald.RateLimitBurst != nil && *journald.RateLimitBurst < 0 {
+		return fmt.Errorf("RateLimitBurst in journald configuration must not be negative")
+	}
+	if journald.SystemMaxUse != nil && journald.SystemMaxUse.Sign() <= 0 {
+		return fmt.Errorf("SystemMaxUse in journald configuration must be greater than 0")
+	}
+	return nil
+}
+
+// validateArchitecture checks the options that are only supported on some
+// architectures against that of the node, once it is known.
+func validateArchitecture(cfg *NodeConfig) error {
+	switch cfg.Status.Architecture {
+	case "":
+	case ArchitectureAMD64:
+	case ArchitectureARM64:
+		// Inferentia and Trainium accelerators are only on x86_64 instances
+		if cfg.Spec.Instance.Neuron.Enabled {
+			return fmt.Errorf("Neuron is not supported on %s nodes", ArchitectureARM64)
+		}
+	default:
+		return fmt.Errorf("Architecture %q of the node is not one of %v", cfg.Status.Architecture, []Architecture{ArchitectureAMD64, ArchitectureARM64})
+	}
+	return nil
+}
+
+// validateCgroupOptions checks the options against the cgroup hierarchy that
+// the node was booted with, when it is known.
+func validateCgroupOptions(cgroup *CgroupOptions, bootedVersion CgroupVersion) error {
+	switch cgroup.Version {
+	case "", CgroupVersionV1, CgroupVersionV2:
+	default:
+		return fmt.Errorf("Version %q in cgroup configuration is not one of %v", cgroup.Version, []CgroupVersion{CgroupVersionV1, CgroupVersionV2})
+	}
+	switch cgroup.Driver {
+	case "", CgroupDriverSystemd, CgroupDriverCgroupfs:
+	default:
+		return fmt.Errorf("Driver %q in cgroup configuration is not one of %v", cgroup.Driver, []CgroupDriver{CgroupDriverSystemd, CgroupDriverCgroupfs})
+	}
+	if cgroup.Version == CgroupVersionV2 && cgroup.Driver == CgroupDriverCgroupfs {
+		return fmt.Errorf("Driver %q in cgroup configuration is not supported with cgroup %s", cgroup.Driver, cgroup.Version)
+	}
+	if weight := cgroup.IO.RuntimeWeight; weight < 0 || weight > MaxIOWeight {
+		return fmt.Errorf("RuntimeWeight in cgroup IO configuration must be between 1 and %d", MaxIOWeight)
+	}
+	if weight := cgroup.IO.PodsWeight; weight < 0 || weight > MaxIOWeight {
+		return fmt.Errorf("PodsWeight in cgroup IO configuration must be between 1 and %d", MaxIOWeight)
+	}
+	if cgroup.IO.IsEnabled() {
+		// the weights are set on the slices that the systemd driver creates
+		if cgroup.Driver == CgroupDriverCgroupfs {
+			return fmt.Errorf("IO isolation in cgroup configuration requires the %s driver", CgroupDriverSystemd)
+		}
+		if cgroup.Version == CgroupVersionV1 || bootedVersion == CgroupVersionV1 {
+			return fmt.Errorf("IO isolation in cgroup configuration requires the cgroup %s hierarchy", CgroupVersionV2)
+		}
+	}
+	if bootedVersion == "" {
+		return nil
+	}
+	if cgroup.Version != "" && cgroup.Version != bootedVersion {
+		return fmt.Errorf("Version %s in cgroup configuration conflicts with the booted cgroup %s hierarchy", cgroup.Version, bootedVersion)
+	}
+	if bootedVersion == CgroupVersionV2 && cgroup.Driver == CgroupDriverCgroupfs {
+		return fmt.Errorf("Driver %q in cgroup configuration conflicts with the booted cgroup %s hierarchy", cgroup.Driver, bootedVersion)
+	}
+	return nil
+}
+
+func validateSwapOptions(swap *SwapOptions) error {
+	switch swap.Device {
+	case "":
+		if swap.Size != nil {
+			return fmt.Errorf("Size in swap configuration requires a device")
+		}
+		return nil
+	case SwapDeviceFile, SwapDeviceZram:
+	default:
+		return fmt.Errorf("Device %q in swap configuration is not one of %v", swap.Device, []SwapDevice{SwapDeviceFile, SwapDeviceZram})
+	}
+	if swap.Size == nil || swap.Size.Sign() <= 0 {
+		return fmt.Errorf("Size in swap configuration must be greater than 0")
+	}
+	return nil
+}
+
+func validateSOCIOptions(soci *SOCIOptions, enabled bool) error {
+	if *soci == (SOCIOptions{}) {
+		return nil
+	}
+	if !enabled {
+		return fmt.Errorf("SOCI configuration requires the %s feature gate", FastContainerImagePull)
+	}
+	if soci.MaxConcurrentDownloads < 0 || soci.MaxConcurrentDownloadsPerImage < 0 || soci.MaxConcurrentUnpacksPerImage < 0 {
+		return fmt.Errorf("MaxConcurrentDownloads, MaxConcurrentDownloadsPerImage and MaxConcurrentUnpacksPerImage in SOCI configuration must not be negative")
+	}
+	switch soci.ContentStore {
+	case "", SOCIContentStoreSOCI, SOCIContentStoreContainerd:
+	default:
+		return fmt.Errorf("Content store %q in SOCI configuration is not one of %v", soci.ContentStore, []SOCIContentStore{SOCIContentStoreSOCI, SOCIContentStoreContainerd})
+	}
+	switch soci.Fallback {
+	case "", SOCIFallbackParallel:
+	case SOCIFallbackSequential:
+		if soci.MaxConcurrentDownloads > 0 || soci.MaxConcurrentDownloadsPerImage > 0 || soci.MaxConcurrentUnpacksPerImage > 0 {
+			return fmt.Errorf("MaxConcurrentDownloads, MaxConcurrentDownloadsPerImage and MaxConcurrentUnpacksPerImage in SOCI configuration require the %s fallback", SOCIFallbackParallel)
+		}
+	default:
+		return fmt.Errorf("Fallback %q in SOCI configuration is not one of %v", soci.Fallback, []SOCIFallback{SOCIFallbackSequential, SOCIFallbackParallel})
+	}
+	return nil
+}
+
+func validatePrePullImagesOptions(prePull *PrePullImagesOptions) error {
+	for _, image := range prePull.Images {
+		if image == "" || strings.HasPrefix(image, "-") || strings.ContainsAny(image, " \t\n") {
+			return fmt.Errorf("Image %q in pre-pull images configuration is not a valid reference", image)
+		}
+	}
+	if prePull.ImageListURL != "" && !isArtifactURL(prePull.ImageListURL) {
+		return fmt.Errorf("Image list URL %q in pre-pull images configuration must be an s3:// or https:// URL", prePull.ImageListURL)
+	}
+	if prePull.Concurrency < 0 {
+		return fmt.Errorf("Concurrency in pre-pull images configuration must not be negative")
+	}
+	return nil
+}
+
+func validateComponentsOptions(components *ComponentsOptions) error {
+	var pinned bool
+	for _, component := range []struct{ name, version string }{
+		{"Kubelet", components.Kubelet},
+		{"Containerd", components.Containerd},
+		{"Runc", components.Runc},
+		{"CNIPlugins", components.CNIPlugins},
+	} {
+		if component.version == "" {
+			continue
+		}
+		// the shorthands of semver, such as v1.33, are not versions of artifacts
+		version, _, _ := strings.Cut("v"+strings.TrimPrefix(component.version, "v"), "+")
+		if !semver.IsValid(version) || semver.Canonical(version) != version {
+			return fmt.Errorf("%s %q in components configuration is not a semantic version", component.name, component.version)
+		}
+		pinned = true
+	}
+	if !pinned {
+		return nil
+	}
+	if !isArtifactURL(components.Repository) {
+		return fmt.Errorf("Repository %q in components configuration must be an s3:// or https:// URL", components.Repository)
+	}
+	block, _ := pem.Decode([]byte(components.PublicKey))
+	if block == nil {
+		return fmt.Errorf("PublicKey in components configuration must be a PEM-encoded public key")
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return fmt.Errorf("PublicKey in components configuration is not valid: %w", err)
+	}
+	return nil
+}
+
+// isArtifactURL returns whether an artifact can be downloaded from the URL.
+func isArtifactURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "s3" || u.Scheme == "https") && u.Host != ""
+}
+
+func validateContainerdGarbageCollectionOptions(gc *ContainerdGarbageCollectionOptions) error {
+	// containerd rejects a pause threshold above 50% of the time
+	if gc.PauseThresholdPercent < 0 || gc.PauseThresholdPercent > 50 {
+		return fmt.Errorf("PauseThresholdPercent in garbage collection configuration must be between 1 and 50 when set")
+	}
+	if gc.DeletionThreshold < 0 {
+		return fmt.Errorf("DeletionThreshold in garbage collection configuration must not be negative")
+	}
+	if gc.MutationThreshold < 0 {
+		return fmt.Errorf("MutationThreshold in garbage collection configuration must not be negative")
+	}
+	if gc.ScheduleDelay != nil && gc.ScheduleDelay.Duration < 0 {
+		return fmt.Errorf("ScheduleDelay in garbage collection configuration must not be negative")
+	}
+	return nil
+}
+
+func validateContainerdMetricsOptions(metrics *ContainerdMetricsOptions) error {
+	if metrics.Address == "" {
+		if metrics.GRPCHistogram {
+			return fmt.Errorf("GRPCHistogram in containerd metrics configuration requires an address")
+		}
+		return nil
+	}
+	_, port, err := net.SplitHostPort(metrics.Address)
+	if err != nil {
+		return fmt.Errorf("Address in containerd metrics configuration must be a host and port: %w", err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("Address in containerd metrics configuration has an invalid port %q", port)
+	}
+	return nil
+}
+
+var containerdLogLevels = []ContainerdLogLevel{
+	ContainerdLogLevelTrace,
+	ContainerdLogLevelDebug,
+	ContainerdLogLevelInfo,
+	ContainerdLogLevelWarn,
+	ContainerdLogLevelError,
+	ContainerdLogLevelFatal,
+	ContainerdLogLevelPanic,
+}
+
+func validateContainerdDebugOptions(debug *ContainerdDebugOptions) error {
+	if debug.Address != "" && !path.IsAbs(debug.Address) {
+		return fmt.Errorf("Address in containerd debug configuration must be an absolute path: %s", debug.Address)
+	}
+	if debug.Level != "" && !slices.Contains(containerdLogLevels, debug.Level) {
+		return fmt.Errorf("Level in containerd debug configuration %q is not one of %v", debug.Level, containerdLogLevels)
+	}
+	return nil
+}
+
+func validateContainerdNRIOptions(nri *ContainerdNRIOptions) error {
+	if nri.Enabled != nil && !*nri.Enabled && (nri.SocketPath != "" || nri.PluginRegistrationTimeout != nil || nri.PluginRequestTimeout != nil) {
+		return fmt.Errorf("NRI in containerd configuration is disabled, but its socket path or timeouts are set")
+	}
+	if nri.SocketPath != "" && !path.IsAbs(nri.SocketPath) {
+		return fmt.Errorf("SocketPath in containerd NRI configuration must be an absolute path: %s", nri.SocketPath)
+	}
+	if timeout := nri.PluginRegistrationTimeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("PluginRegistrationTimeout in containerd NRI configuration must be positive: %s", timeout.Duration)
+	}
+	if timeout := nri.PluginRequestTimeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("PluginRequestTimeout in containerd NRI configuration must be positive: %s", timeout.Duration)
+	}
+	return nil
+}
+
+func validateContainerdImagePullOptions(pulls *ContainerdImagePullOptions) error {
+	if pulls.MaxConcurrentDownloads < 0 {
+		return fmt.Errorf("MaxConcurrentDownloads in containerd image pull configuration must not be negative")
+	}
+	if timeout := pulls.ProgressTimeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("ProgressTimeout in containerd image pull configuration must be positive: %s", timeout.Duration)
+	}
+	return nil
+}
+
+func validateImageRetentionOptions(retention *ImageRetentionOptions) error {
+	if retention.HighThresholdPercent < 0 || retention.HighThresholdPercent > 100 {
+		return fmt.Errorf("HighThresholdPercent in image retention configuration must be between 1 and 100 when set")
+	}
+	if retention.LowThresholdPercent < 0 || retention.LowThresholdPercent > 100 {
+		return fmt.Errorf("LowThresholdPercent in image retention configuration must be between 1 and 100 when set")
+	}
+	// kubelet defaults the unset threshold to 85 or 80
+	high, low := retention.HighThresholdPercent, retention.LowThresholdPercent
+	if high == 0 {
+		high = 85
+	}
+	if low == 0 {
+		low = 80
+	}
+	if low >= high {
+		return fmt.Errorf("LowThresholdPercent in image retention configuration must be less than HighThresholdPercent")
+	}
+	if minimumAge := retention.MinimumAge; minimumAge != nil && minimumAge.Duration < 0 {
+		return fmt.Errorf("MinimumAge in image retention configuration must not be negative")
+	}
+	if maximumAge := retention.MaximumAge; maximumAge != nil {
+		// kubelet defaults the minimum age to 2m
+		minimumAge := 2 * time.Minute
+		if retention.MinimumAge != nil {
+			minimumAge = retention.MinimumAge.Duration
+		}
+		if maximumAge.Duration <= minimumAge {
+			return fmt.Errorf("MaximumAge in image retention configuration must be greater than MinimumAge")
+		}
+	}
+	for _, image := range retention.PinnedImages {
+		if image == "" || strings.HasPrefix(image, "-") || strings.ContainsAny(image, " \t\n") {
+			return fmt.Errorf("Image %q in image retention configuration is not a valid reference", image)
+		}
+	}
+	if retention.KeepLast < 0 {
+		return fmt.Errorf("KeepLast in image retention configuration must not be negative")
+	}
+	return nil
+}
+
+func validateRunscOptions(runsc *RunscOptions) error {
+	switch runsc.Platform {
+	case "", RunscPlatformSystrap, RunscPlatformKVM:
+	default:
+		return fmt.Errorf("Platform %q in runsc configuration is not one of %v", runsc.Platform, []RunscPlatform{RunscPlatformSystrap, RunscPlatformKVM})
+	}
+	switch runsc.Network {
+	case "", RunscNetworkSandbox, RunscNetworkHost, RunscNetworkNone:
+	default:
+		return fmt.Errorf("Network %q in runsc configuration is not one of %v", runsc.Network, []RunscNetwork{RunscNetworkSandbox, RunscNetworkHost, RunscNetworkNone})
+	}
+	switch runsc.Overlay {
+	case "", RunscOverlayRootMemory, RunscOverlayRootSelf, RunscOverlayNone:
+	default:
+		return fmt.Errorf("Overlay %q in runsc configuration is not one of %v", runsc.Overlay, []RunscOverlay{RunscOverlayRootMemory, RunscOverlayRootSelf, RunscOverlayNone})
+	}
+	return nil
+}
+
+// ValidateClusterDetails returns an error if a detail of the cluster that
+// kubelet is configured with is missing, which it is until the cluster is
+// discovered.
+func ValidateClusterDetails(cluster *ClusterDetails) error {
+	if cluster.Name == "" {
 		return fmt.Errorf("Name is missing in cluster configuration")
 	}
-	if cfg.Spec.Cluster.APIServerEndpoint == "" {
+	if cluster.APIServerEndpoint == "" {
 		return fmt.Errorf("Apiserver endpoint is missing in cluster configuration")
 	}
-	if cfg.Spec.Cluster.CertificateAuthority == nil {
+	if cluster.CertificateAuthority == nil && cluster.CertificateAuthorityFile == "" {
 		return fmt.Errorf("Certificate authority is missing in cluster configuration")
 	}
-	if cfg.Spec.Cluster.CIDR == "" {
+	if cluster.CIDR == "" {
 		return fmt.Errorf("CIDR is missing in cluster configuration")
 	}
-	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
-		if cfg.Spec.Cluster.ID == "" {
-			return fmt.Errorf("CIDR is missing in cluster configuration")
+	if _, err := cluster.GetServiceCIDRs(); err != nil {
+		return fmt.Errorf("CIDR is invalid in cluster configuration: %w", err)
+	}
+	return nil
+}
+
+func validateClusterDiscoveryOptions(discovery *ClusterDiscoveryOptions, hybrid bool) error {
+	switch discovery.Source {
+	case "":
+		if discovery.TagKey != "" || discovery.SSMParameter != "" || discovery.MinDelay != nil || discovery.MaxDelay != nil || discovery.Timeout != nil {
+			return fmt.Errorf("Source is missing in cluster discovery configuration")
+		}
+		return nil
+	case ClusterDiscoverySourceTag:
+		if discovery.SSMParameter != "" {
+			return fmt.Errorf("SSMParameter in cluster discovery configuration requires Source %q", ClusterDiscoverySourceSSM)
+		}
+	case ClusterDiscoverySourceSSM:
+		if discovery.SSMParameter == "" {
+			return fmt.Errorf("SSMParameter is missing in cluster discovery configuration")
+		}
+		if discovery.TagKey != "" {
+			return fmt.Errorf("TagKey in cluster discovery configuration requires Source %q", ClusterDiscoverySourceTag)
+		}
+	default:
+		return fmt.Errorf("Source %q in cluster discovery configuration is not one of %v", discovery.Source, []ClusterDiscoverySource{ClusterDiscoverySourceTag, ClusterDiscoverySourceSSM})
+	}
+	if hybrid {
+		return fmt.Errorf("Cluster discovery cannot be enabled for hybrid nodes, which are not EC2 instances")
+	}
+	for _, duration := range []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{"MinDelay", discovery.MinDelay},
+		{"MaxDelay", discovery.MaxDelay},
+		{"Timeout", discovery.Timeout},
+	} {
+		if duration.value != nil && duration.value.Duration <= 0 {
+			return fmt.Errorf("%s in cluster discovery configuration must be positive", duration.name)
+		}
+	}
+	if discovery.GetMaxDelay() < discovery.GetMinDelay() {
+		return fmt.Errorf("MaxDelay in cluster discovery configuration must not be less than MinDelay")
+	}
+	return nil
+}
+
+func validateEndpointOverrides(overrides *EndpointOverrides) error {
+	for _, override := range []struct{ name, endpoint string }{
+		{"EKS", overrides.EKS},
+		{"EC2", overrides.EC2},
+		{"STS", overrides.STS},
+		{"ECR", overrides.ECR},
+	} {
+		if override.endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(override.endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%s in endpoint overrides configuration must be an https URL: %s", override.name, override.endpoint)
+		}
+	}
+	return nil
+}
+
+// validateOfflineMode checks that the details that are looked up with AWS APIs
+// are set, and that no option that calls an AWS API is enabled.
+func validateOfflineMode(cfg *NodeConfig) error {
+	hasIdentity := cfg.Spec.Instance.Identity != (InstanceIdentityOptions{})
+	if !cfg.Spec.Offline {
+		if hasIdentity {
+			return fmt.Errorf("Instance identity can only be set in offline mode")
+		}
+		return nil
+	}
+	if cfg.IsHybrid() {
+		return fmt.Errorf("Offline mode is not supported on hybrid nodes, which are registered with AWS Systems Manager or IAM Roles Anywhere")
+	}
+	if cfg.Spec.Cluster.Discovery.Source != "" {
+		return fmt.Errorf("Cluster discovery cannot be enabled in offline mode, since it reads the cluster from AWS APIs")
+	}
+	if !IsFeatureEnabled(InstanceIdNodeName, cfg.Spec.FeatureGates) {
+		return fmt.Errorf("Feature gate %s must be enabled in offline mode, since the private DNS name of the instance is read from the EC2 API", InstanceIdNodeName)
+	}
+	if len(cfg.Spec.Kubelet.ClusterDNS) == 0 {
+		return fmt.Errorf("Cluster DNS in kubelet configuration is required in offline mode")
+	}
+	if cfg.Spec.Instance.Tags.Enabled && cfg.Spec.Instance.Tags.Source == InstanceTagsSourceEC2 {
+		return fmt.Errorf("Instance tags cannot be read from the EC2 API in offline mode, use the %s source instead", InstanceTagsSourceIMDS)
+	}
+	if cfg.Spec.Kubelet.AutoLabels.Enabled {
+		return fmt.Errorf("Auto labels cannot be enabled in offline mode, since the capacity reservation is read from the EC2 API")
+	}
+	if len(cfg.Spec.Containerd.RegistryCredentials) > 0 {
+		return fmt.Errorf("Registry credentials cannot be used in offline mode, since they are read from AWS Secrets Manager")
+	}
+	if cfg.Spec.Debug.Logging.CloudWatch.Enabled {
+		return fmt.Errorf("Logs cannot be streamed to CloudWatch Logs in offline mode")
+	}
+	if hasIdentity {
+		return validateInstanceIdentity(cfg)
+	}
+	return nil
+}
+
+// validateInstanceIdentity checks that the identity of the instance has the
+// details that are otherwise read from the instance metadata service, and that
+// no option that reads the instance metadata service is enabled.
+func validateInstanceIdentity(cfg *NodeConfig) error {
+	identity := cfg.Spec.Instance.Identity
+	if identity.InstanceID == "" {
+		return fmt.Errorf("Instance ID is missing in instance identity configuration")
+	}
+	if identity.Region == "" {
+		return fmt.Errorf("Region is missing in instance identity configuration")
+	}
+	if identity.AvailabilityZone == "" {
+		return fmt.Errorf("Availability zone is missing in instance identity configuration")
+	}
+	if cfg.Spec.Kubelet.NodeIP.Policy != NodeIPPolicyExplicit {
+		return fmt.Errorf("Node IP policy in kubelet configuration must be %s when the instance identity is set, since the addresses of the instance are read from the instance metadata service", NodeIPPolicyExplicit)
+	}
+	if cfg.Spec.Instance.Tags.Enabled {
+		return fmt.Errorf("Instance tags cannot be enabled when the instance identity is set, since they are read from the instance metadata service")
+	}
+	shutdown := cfg.Spec.Instance.Shutdown
+	if shutdown.DrainOnSpotInterruption || len(shutdown.TerminationHandler.Events) > 0 {
+		return fmt.Errorf("Spot interruptions and termination events cannot be handled when the instance identity is set, since they are read from the instance metadata service")
+	}
+	return nil
+}
+
+func validateInstanceTagsOptions(tags *InstanceTagsOptions, hybrid bool) error {
+	switch tags.Source {
+	case "", InstanceTagsSourceIMDS, InstanceTagsSourceEC2:
+	default:
+		return fmt.Errorf("Source %q in instance tags configuration is not one of %v", tags.Source, []InstanceTagsSource{InstanceTagsSourceIMDS, InstanceTagsSourceEC2})
+	}
+	if tags.Enabled && hybrid {
+		return fmt.Errorf("Instance tags cannot be enabled for hybrid nodes, which are not EC2 instances")
+	}
+	return nil
+}
+
+func validateShutdownOptions(shutdown *ShutdownOptions, hybrid bool) error {
+	if drainTimeout := shutdown.DrainTimeout; drainTimeout != nil {
+		if drainTimeout.Duration <= 0 || drainTimeout.Duration > maxDrainTimeout {
+			return fmt.Errorf("DrainTimeout in shutdown configuration must be greater than 0 and at most %s", maxDrainTimeout)
+		}
+	}
+	if gracePeriod := shutdown.GracePeriod; gracePeriod != nil && gracePeriod.Duration <= 0 {
+		return fmt.Errorf("GracePeriod in shutdown configuration must be greater than 0")
+	}
+	if criticalPodsGracePeriod := shutdown.CriticalPodsGracePeriod; criticalPodsGracePeriod != nil {
+		if shutdown.GracePeriod == nil {
+			return fmt.Errorf("CriticalPodsGracePeriod in shutdown configuration requires GracePeriod")
+		}
+		if criticalPodsGracePeriod.Duration <= 0 || criticalPodsGracePeriod.Duration > shutdown.GracePeriod.Duration {
+			return fmt.Errorf("CriticalPodsGracePeriod in shutdown configuration must be greater than 0 and at most GracePeriod")
+		}
+	}
+	if shutdown.DrainOnSpotInterruption && hybrid {
+		return fmt.Errorf("DrainOnSpotInterruption cannot be enabled for hybrid nodes, which are not EC2 instances")
+	}
+	return validateTerminationHandlerOptions(&shutdown.TerminationHandler, hybrid)
+}
+
+func validateTerminationHandlerOptions(handler *TerminationHandlerOptions, hybrid bool) error {
+	for _, event := range handler.Events {
+		switch event {
+		case TerminationEventSpotInterruption, TerminationEventLifecycleTermination, TerminationEventScheduledEvent:
+		default:
+			return fmt.Errorf("Unknown event of the termination handler %q", event)
+		}
+	}
+	if len(handler.Events) > 0 && hybrid {
+		return fmt.Errorf("TerminationHandler cannot be enabled for hybrid nodes, which are not EC2 instances")
+	}
+	if pollInterval := handler.PollInterval; pollInterval != nil && pollInterval.Duration <= 0 {
+		return fmt.Errorf("PollInterval of the termination handler must be greater than 0")
+	}
+	return nil
+}
+
+func validateTracingOptions(tracing *TracingOptions) error {
+	if tracing.Endpoint != "" {
+		u, err := url.Parse(tracing.Endpoint)
+		if err != nil {
+			return fmt.Errorf("Endpoint is not a valid URL in tracing configuration: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("Endpoint must be an http or https URL in tracing configuration: %s", tracing.Endpoint)
+		}
+	}
+	if tracing.File != "" && !path.IsAbs(tracing.File) {
+		return fmt.Errorf("File must be an absolute path in tracing configuration: %s", tracing.File)
+	}
+	return nil
+}
+
+// journalUnitPattern matches the names of systemd units, with or without the
+// type suffix, which journalctl assumes to be .service.
+var journalUnitPattern = regexp.MustCompile(`^[A-Za-z0-9:_.\\@-]+$`)
+
+func validateCloudWatchLoggingOptions(logging *CloudWatchLoggingOptions, hybrid bool) error {
+	if !logging.Enabled {
+		return nil
+	}
+	if hybrid {
+		return fmt.Errorf("Streaming logs to CloudWatch Logs is not supported on hybrid nodes, whose log streams are named by instance ID")
+	}
+	if logging.LogGroupName != "" && !cloudWatchLogGroupPattern.MatchString(logging.LogGroupName) {
+		return fmt.Errorf("LogGroupName %q in CloudWatch logging configuration is not a valid log group name", logging.LogGroupName)
+	}
+	for _, unit := range logging.Units {
+		if !journalUnitPattern.MatchString(unit) {
+			return fmt.Errorf("Unit %q in CloudWatch logging configuration is not a valid systemd unit name", unit)
+		}
+	}
+	if logging.Duration != nil && logging.Duration.Duration < time.Minute {
+		return fmt.Errorf("Duration in CloudWatch logging configuration must be at least 1m")
+	}
+	return nil
+}
+
+func validateContainerdRuntimes(runtimes []ContainerdRuntime) error {
+	names := map[string]bool{}
+	for _, runtime := range runtimes {
+		// handlers are referenced by RuntimeClasses, which require a DNS label
+		if errs := validation.IsDNS1123Label(runtime.Name); len(errs) > 0 {
+			return fmt.Errorf("Name %q of containerd runtime is invalid: %s", runtime.Name, strings.Join(errs, ", "))
+		}
+		if names[runtime.Name] {
+			return fmt.Errorf("Containerd runtime %s is defined more than once", runtime.Name)
+		}
+		names[runtime.Name] = true
+		if runtime.RuntimeType == "" {
+			return fmt.Errorf("RuntimeType of containerd runtime %s is required", runtime.Name)
+		}
+	}
+	return nil
+}
+
+// validateRuntimeHandlers validates the handlers that pods are routed to by
+// default. Whether a handler exists is only known once the config of
+// containerd is generated for the instance.
+func validateRuntimeHandlers(containerd *ContainerdOptions) error {
+	for field, handler := range map[string]string{
+		"DefaultRuntime":           containerd.DefaultRuntime,
+		"UntrustedWorkloadRuntime": containerd.UntrustedWorkloadRuntime,
+	} {
+		if handler == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(handler); len(errs) > 0 {
+			return fmt.Errorf("%s %q of containerd is invalid: %s", field, handler, strings.Join(errs, ", "))
+		}
+	}
+	if containerd.UntrustedWorkloadRuntime != "" && containerd.UntrustedWorkloadRuntime == containerd.DefaultRuntime {
+		return fmt.Errorf("UntrustedWorkloadRuntime of containerd must not be its DefaultRuntime %s", containerd.DefaultRuntime)
+	}
+	return nil
+}
+
+func validateRegistryCredentials(credentials []RegistryCredential) error {
+	registries := map[string]bool{}
+	for _, credential := range credentials {
+		if credential.Registry == "" {
+			return fmt.Errorf("Registry of registry credential is required")
+		}
+		if strings.Contains(credential.Registry, "/") {
+			return fmt.Errorf("Registry %q of registry credential must be a host, without a scheme or path", credential.Registry)
+		}
+		if registries[credential.Registry] {
+			return fmt.Errorf("Registry credential for %s is defined more than once", credential.Registry)
+		}
+		registries[credential.Registry] = true
+		secretARN, err := arn.Parse(credential.SecretARN)
+		if err != nil {
+			return fmt.Errorf("SecretARN of registry credential for %s is invalid: %w", credential.Registry, err)
+		}
+		if secretARN.Service != "secretsmanager" || secretARN.Region == "" {
+			return fmt.Errorf("SecretARN of registry credential for %s must be the ARN of a secret in AWS Secrets Manager: %s", credential.Registry, credential.SecretARN)
+		}
+	}
+	return nil
+}
+
+func validatePressureMonitorOptions(monitor *PressureMonitorOptions) error {
+	if monitor.MemoryThreshold < 0 || monitor.MemoryThreshold > 100 {
+		return fmt.Errorf("MemoryThreshold in pressure monitor configuration must be between 1 and 100")
+	}
+	if monitor.MetricsPort < 0 || monitor.MetricsPort > 65535 {
+		return fmt.Errorf("MetricsPort in pressure monitor configuration must be between 1 and 65535")
+	}
+	switch monitor.Action {
+	case "", PressureActionReport, PressureActionCordon, PressureActionEvict:
+	default:
+		return fmt.Errorf("Action %q in pressure monitor configuration is not one of %v", monitor.Action, []PressureAction{PressureActionReport, PressureActionCordon, PressureActionEvict})
+	}
+	return nil
+}
+
+func validateTimeSyncOptions(timeSync *TimeSyncOptions) error {
+	for _, server := range timeSync.Servers {
+		// servers are written to the sources of chronyd, so only addresses
+		// and host names are allowed
+		if net.ParseIP(server) == nil && len(validation.IsDNS1123Subdomain(server)) > 0 {
+			return fmt.Errorf("Server %q in time sync configuration is not a valid IP address or host name", server)
+		}
+	}
+	if timeSync.SyncTimeout != nil && timeSync.SyncTimeout.Duration <= 0 {
+		return fmt.Errorf("SyncTimeout in time sync configuration must be greater than zero")
+	}
+	return nil
+}
+
+// the keys of kernel parameters, whose parts are separated by dots rather than
+// slashes so that they are compared with the denied parameters as written.
+var sysctlKeyPattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[A-Za-z0-9_-]+)+$`)
+
+// the subsystems whose kernel parameters can be set, which leave out those
+// of the kernel itself, such as its security controls and panic behavior
+var allowedSysctlPrefixes = []string{"fs.", "net.", "user.", "vm."}
+
+// the kernel parameters that the node depends on, with the reason they
+// cannot be set
+var deniedSysctls = map[string]string{
+	"net.ipv4.ip_forward":                 "the traffic of pods is forwarded by the node",
+	"net.ipv4.conf.all.forwarding":        "the traffic of pods is forwarded by the node",
+	"net.ipv6.conf.all.forwarding":        "the traffic of pods is forwarded by the node",
+	"net.ipv4.conf.default.forwarding":    "the traffic of pods is forwarded by the node",
+	"net.ipv6.conf.default.forwarding":    "the traffic of pods is forwarded by the node",
+	"net.bridge.bridge-nf-call-iptables":  "the traffic of services is filtered by iptables",
+	"net.bridge.bridge-nf-call-ip6tables": "the traffic of services is filtered by iptables",
+}
+
+// the lowest values of the kernel parameters that kubelet and containerd
+// need, which are the defaults of the AMI
+var minimumSysctls = map[string]int64{
+	"fs.inotify.max_user_watches":   524288,
+	"fs.inotify.max_user_instances": 8192,
+}
+
+func validateSysctl(sysctl map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(sysctl)) {
+		value := sysctl[key]
+		if !sysctlKeyPattern.MatchString(key) {
+			return fmt.Errorf("Kernel parameter %q in system configuration is not a valid key", key)
+		}
+		if !slices.ContainsFunc(allowedSysctlPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) }) {
+			return fmt.Errorf("Kernel parameter %s in system configuration is not in one of %v", key, allowedSysctlPrefixes)
+		}
+		if reason, ok := deniedSysctls[key]; ok {
+			return fmt.Errorf("Kernel parameter %s in system configuration cannot be set, since %s", key, reason)
+		}
+		if strings.TrimSpace(value) == "" || strings.ContainsAny(value, "\n\r") {
+			return fmt.Errorf("Value of kernel parameter %s in system configuration must be a single line", key)
+		}
+		if minimum, ok := minimumSysctls[key]; ok {
+			if n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil || n < minimum {
+				return fmt.Errorf("Kernel parameter %s in system configuration must be at least %d, which kubelet and containerd need", key, minimum)
+			}
+		}
+	}
+	return nil
+}
+
+func validateEFAOptions(efa *EFAOptions) error {
+	if efa.HugePages != nil && *efa.HugePages < 0 {
+		return fmt.Errorf("HugePages in EFA configuration must not be negative")
+	}
+	if efa.ReservedMemory != nil && efa.ReservedMemory.Sign() < 0 {
+		return fmt.Errorf("ReservedMemory in EFA configuration must not be negative")
+	}
+	return nil
+}
+
+func validateNeuronOptions(neuron *NeuronOptions) error {
+	if neuron.HugePages != nil && *neuron.HugePages < 0 {
+		return fmt.Errorf("HugePages in Neuron configuration must not be negative")
+	}
+	if neuron.ReservedMemory != nil && neuron.ReservedMemory.Sign() < 0 {
+		return fmt.Errorf("ReservedMemory in Neuron configuration must not be negative")
+	}
+	return nil
+}
+
+func validateNetworkingOptions(networking *NetworkingOptions, hybrid bool) error {
+	if hybrid && len(networking.ExcludeInterfaces) > 0 {
+		return fmt.Errorf("Excluded interfaces in networking configuration are not supported on hybrid nodes, whose address is set by the node IP in hybrid configuration")
+	}
+	for _, pattern := range networking.ExcludeInterfaces {
+		if pattern == "" {
+			return fmt.Errorf("Excluded interfaces in networking configuration must not be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Excluded interface %q in networking configuration is not a valid glob pattern: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func validateCloudWatchAgentOptions(agent *CloudWatchAgentOptions, hybrid bool) error {
+	if !agent.Enabled {
+		return nil
+	}
+	if hybrid {
+		return fmt.Errorf("CloudWatch agent is not supported on hybrid nodes, whose agent must be configured with credentials outside of EC2")
+	}
+	metrics := map[CloudWatchAgentMetric]bool{}
+	for _, metric := range agent.Metrics {
+		switch metric {
+		case CloudWatchAgentMetricCPU, CloudWatchAgentMetricDisk, CloudWatchAgentMetricDiskIO, CloudWatchAgentMetricMem, CloudWatchAgentMetricNet:
+		default:
+			return fmt.Errorf("Metrics %q in CloudWatch agent configuration is not one of %v", metric, []CloudWatchAgentMetric{CloudWatchAgentMetricCPU, CloudWatchAgentMetricDisk, CloudWatchAgentMetricDiskIO, CloudWatchAgentMetricMem, CloudWatchAgentMetricNet})
+		}
+		if metrics[metric] {
+			return fmt.Errorf("Metrics %q in CloudWatch agent configuration is listed more than once", metric)
+		}
+		metrics[metric] = true
+	}
+	if interval := agent.MetricsCollectionInterval; interval != nil && interval.Duration < time.Second {
+		return fmt.Errorf("MetricsCollectionInterval in CloudWatch agent configuration must be at least 1s")
+	}
+	return nil
+}
+
+func validateKubeletRemoteConfigOptions(remoteConfig *KubeletRemoteConfigOptions) error {
+	if remoteConfig.Source == "" {
+		if remoteConfig.Interval != nil || remoteConfig.MinRestartInterval != nil {
+			return fmt.Errorf("Source in kubelet remote config configuration is required")
+		}
+		return nil
+	}
+	if !isArtifactURL(remoteConfig.Source) {
+		return fmt.Errorf("Source %q in kubelet remote config configuration must be an s3:// or https:// URL", remoteConfig.Source)
+	}
+	if interval := remoteConfig.Interval; interval != nil && interval.Duration < 10*time.Second {
+		return fmt.Errorf("Interval in kubelet remote config configuration must be at least 10s")
+	}
+	if interval := remoteConfig.MinRestartInterval; interval != nil && interval.Duration < 0 {
+		return fmt.Errorf("MinRestartInterval in kubelet remote config configuration must not be negative")
+	}
+	return nil
+}
+
+func validateResourceManagerOptions(kubelet *KubeletOptions) error {
+	switch kubelet.CPUManagerPolicy {
+	case "", CPUManagerPolicyNone, CPUManagerPolicyStatic:
+	default:
+		return fmt.Errorf("CPU manager policy %q is not one of %v", kubelet.CPUManagerPolicy, []CPUManagerPolicy{CPUManagerPolicyNone, CPUManagerPolicyStatic})
+	}
+	switch kubelet.MemoryManagerPolicy {
+	case "", MemoryManagerPolicyNone, MemoryManagerPolicyStatic:
+	default:
+		return fmt.Errorf("Memory manager policy %q is not one of %v", kubelet.MemoryManagerPolicy, []MemoryManagerPolicy{MemoryManagerPolicyNone, MemoryManagerPolicyStatic})
+	}
+	switch kubelet.TopologyManagerPolicy {
+	case "", TopologyManagerPolicyNone, TopologyManagerPolicyBestEffort:
+	case TopologyManagerPolicyRestricted, TopologyManagerPolicySingleNUMANode:
+		// without a static policy, no resources are aligned on NUMA nodes
+		if kubelet.CPUManagerPolicy != CPUManagerPolicyStatic && kubelet.MemoryManagerPolicy != MemoryManagerPolicyStatic {
+			return fmt.Errorf("Topology manager policy %q requires CPU manager policy %q or memory manager policy %q", kubelet.TopologyManagerPolicy, CPUManagerPolicyStatic, MemoryManagerPolicyStatic)
+		}
+	default:
+		return fmt.Errorf("Topology manager policy %q is not one of %v", kubelet.TopologyManagerPolicy, []TopologyManagerPolicy{TopologyManagerPolicyNone, TopologyManagerPolicyBestEffort, TopologyManagerPolicyRestricted, TopologyManagerPolicySingleNUMANode})
+	}
+	switch kubelet.ReservedResourcesPolicy {
+	case "", ReservedResourcesPolicyEKS, ReservedResourcesPolicyGKE:
+	default:
+		return fmt.Errorf("Reserved resources policy %q is not one of %v", kubelet.ReservedResourcesPolicy, []ReservedResourcesPolicy{ReservedResourcesPolicyEKS, ReservedResourcesPolicyGKE})
+	}
+	if kubelet.ReservedCPUs != "" {
+		if kubelet.CPUManagerPolicy != CPUManagerPolicyStatic {
+			return fmt.Errorf("Reserved CPUs in kubelet configuration require CPU manager policy %q", CPUManagerPolicyStatic)
+		}
+		if _, err := cpuset.Parse(kubelet.ReservedCPUs); err != nil {
+			return fmt.Errorf("Reserved CPUs %q in kubelet configuration are not a list of CPUs: %w", kubelet.ReservedCPUs, err)
+		}
+	}
+	return nil
+}
+
+func validateDiskCheckOptions(diskCheck *DiskCheckOptions) error {
+	if footprint := diskCheck.DaemonSetFootprint; footprint != nil && footprint.Sign() < 0 {
+		return fmt.Errorf("DaemonSet footprint in disk check configuration must not be negative")
+	}
+	switch diskCheck.FailurePolicy {
+	case "", DiskCheckFailurePolicyWarn, DiskCheckFailurePolicyFail:
+	default:
+		return fmt.Errorf("Failure policy %q in disk check configuration is not one of %v", diskCheck.FailurePolicy, []DiskCheckFailurePolicy{DiskCheckFailurePolicyWarn, DiskCheckFailurePolicyFail})
+	}
+	return nil
+}
+
+func validateNodeIPOptions(nodeIP *NodeIPOptions, hybrid bool) error {
+	if hybrid && (nodeIP.Policy != "" || len(nodeIP.Addresses) > 0) {
+		return fmt.Errorf("Node IP in kubelet configuration is not supported on hybrid nodes, whose address is set by the node IP in hybrid configuration")
+	}
+	switch nodeIP.Policy {
+	case "", NodeIPPolicyPrimaryPrivate, NodeIPPolicySecondaryENI, NodeIPPolicyIPv6:
+		if len(nodeIP.Addresses) > 0 {
+			return fmt.Errorf("Addresses in node IP configuration require the %s policy", NodeIPPolicyExplicit)
+		}
+	case NodeIPPolicyExplicit:
+		if len(nodeIP.Addresses) == 0 || len(nodeIP.Addresses) > 2 {
+			return fmt.Errorf("Addresses in node IP configuration must have one or two addresses with the %s policy", NodeIPPolicyExplicit)
+		}
+		families := map[bool]bool{}
+		for _, address := range nodeIP.Addresses {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				return fmt.Errorf("Address %q in node IP configuration is not a valid IP address", address)
+			}
+			isIPv4 := ip.To4() != nil
+			if families[isIPv4] {
+				return fmt.Errorf("Addresses in node IP configuration must have at most one address of each IP family")
+			}
+			families[isIPv4] = true
+		}
+	default:
+		return fmt.Errorf("Policy %q in node IP configuration is not one of %v", nodeIP.Policy, []NodeIPPolicy{NodeIPPolicyPrimaryPrivate, NodeIPPolicySecondaryENI, NodeIPPolicyIPv6, NodeIPPolicyExplicit})
+	}
+	return nil
+}
+
+var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+func validateStaticPods(pods []StaticPod) error {
+	names := map[string]bool{}
+	for _, pod := range pods {
+		if errs := validation.IsDNS1123Subdomain(pod.Name); len(errs) > 0 {
+			return fmt.Errorf("Name %q of static pod in kubelet configuration is invalid: %s", pod.Name, strings.Join(errs, ", "))
+		}
+		if names[pod.Name] {
+			return fmt.Errorf("Name %q of static pod in kubelet configuration is not unique", pod.Name)
+		}
+		names[pod.Name] = true
+		if (pod.Manifest == "") == (pod.URL == "") {
+			return fmt.Errorf("Static pod %s in kubelet configuration must have exactly one of manifest and url", pod.Name)
+		}
+		if pod.URL != "" {
+			if !isArtifactURL(pod.URL) {
+				return fmt.Errorf("URL %q of static pod %s in kubelet configuration must be an s3:// or https:// URL", pod.URL, pod.Name)
+			}
+			if pod.SHA256 == "" {
+				return fmt.Errorf("Static pod %s in kubelet configuration must have a sha256 checksum with url", pod.Name)
+			}
+		}
+		if pod.SHA256 != "" && !sha256Pattern.MatchString(pod.SHA256) {
+			return fmt.Errorf("Checksum of static pod %s in kubelet configuration is not a hex-encoded SHA-256 digest", pod.Name)
+		}
+	}
+	return nil
+}
+
+func validateSeccompDefaultOptions(seccomp *SeccompDefaultOptions) error {
+	names := map[string]bool{}
+	for _, profile := range seccomp.Profiles {
+		if errs := validation.IsDNS1123Subdomain(profile.Name); len(errs) > 0 {
+			return fmt.Errorf("Name %q of seccomp profile in security configuration is invalid: %s", profile.Name, strings.Join(errs, ", "))
+		}
+		if names[profile.Name] {
+			return fmt.Errorf("Name %q of seccomp profile in security configuration is not unique", profile.Name)
+		}
+		names[profile.Name] = true
+		if (profile.Profile == "") == (profile.URL == "") {
+			return fmt.Errorf("Seccomp profile %s in security configuration must have exactly one of profile and url", profile.Name)
+		}
+		if profile.URL != "" {
+			if !isArtifactURL(profile.URL) {
+				return fmt.Errorf("URL %q of seccomp profile %s in security configuration must be an s3:// or https:// URL", profile.URL, profile.Name)
+			}
+			if profile.SHA256 == "" {
+				return fmt.Errorf("Seccomp profile %s in security configuration must have a sha256 checksum with url", profile.Name)
+			}
+		}
+		if profile.SHA256 != "" && !sha256Pattern.MatchString(profile.SHA256) {
+			return fmt.Errorf("Checksum of seccomp profile %s in security configuration is not a hex-encoded SHA-256 digest", profile.Name)
+		}
+	}
+	if seccomp.DefaultProfile != "" {
+		if !seccomp.Enabled {
+			return fmt.Errorf("DefaultProfile in seccomp default configuration requires Enabled")
+		}
+		if !names[seccomp.DefaultProfile] {
+			return fmt.Errorf("DefaultProfile %q in seccomp default configuration is not one of its profiles", seccomp.DefaultProfile)
+		}
+	}
+	return nil
+}
+
+func validateHooksOptions(hooks *HooksOptions) error {
+	points := []struct {
+		name  string
+		hooks []Hook
+	}{
+		{"preInit", hooks.PreInit},
+		{"postContainerd", hooks.PostContainerd},
+		{"postKubelet", hooks.PostKubelet},
+	}
+	for _, point := range points {
+		for i, hook := range point.hooks {
+			set := 0
+			for _, present := range []bool{len(hook.Command) > 0, hook.Script != "", hook.URL != ""} {
+				if present {
+					set++
+				}
+			}
+			if set != 1 {
+				return fmt.Errorf("Hook %s[%d] in hooks configuration must have exactly one of command, script and url", point.name, i)
+			}
+			if hook.URL != "" {
+				if !isArtifactURL(hook.URL) {
+					return fmt.Errorf("URL %q of hook %s[%d] in hooks configuration must be an s3:// or https:// URL", hook.URL, point.name, i)
+				}
+				if !sha256Pattern.MatchString(hook.SHA256) {
+					return fmt.Errorf("Hook %s[%d] in hooks configuration must have a hex-encoded SHA-256 checksum with url", point.name, i)
+				}
+			} else if hook.SHA256 != "" {
+				return fmt.Errorf("Hook %s[%d] in hooks configuration can only have a sha256 checksum with url", point.name, i)
+			}
+			if len(hook.Command) > 0 && hook.Command[0] == "" {
+				return fmt.Errorf("Hook %s[%d] in hooks configuration has an empty command", point.name, i)
+			}
+			if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+				return fmt.Errorf("Timeout of hook %s[%d] in hooks configuration must be greater than 0", point.name, i)
+			}
+			switch hook.FailurePolicy {
+			case "", HookFailurePolicyFail, HookFailurePolicyIgnore:
+			default:
+				return fmt.Errorf("Failure policy %q of hook %s[%d] in hooks configuration is not one of %v", hook.FailurePolicy, point.name, i, []HookFailurePolicy{HookFailurePolicyFail, HookFailurePolicyIgnore})
+			}
+		}
+	}
+	return nil
+}
+
+var systemdUnitNamePattern = regexp.MustCompile(`^[A-Za-z0-9:_.\\@-]+\.(service|socket|device|mount|automount|swap|target|path|timer|slice|scope)$`)
+
+// reservedDropInInfix is in the names of the drop-ins that nodeadm writes
+// itself, such as 10-nodeadm-proxy.conf and 20-nodeadm-efa.conf, which must
+// not be overwritten by the drop-ins of the configuration.
+const reservedDropInInfix = "-nodeadm-"
+
+func validateSystemdOptions(systemd *SystemdOptions) error {
+	units := map[string]bool{}
+	for _, unit := range systemd.Units {
+		if !systemdUnitNamePattern.MatchString(unit.Name) {
+			return fmt.Errorf("Name %q of systemd unit must be the name of a unit with its type suffix, such as containerd.service", unit.Name)
+		}
+		if units[unit.Name] {
+			return fmt.Errorf("Systemd unit %s is defined more than once", unit.Name)
+		}
+		units[unit.Name] = true
+		dropIns := map[string]bool{}
+		for _, dropIn := range unit.DropIns {
+			if dropIn.Name == "" || strings.Contains(dropIn.Name, "/") || strings.HasPrefix(dropIn.Name, ".") || !strings.HasSuffix(dropIn.Name, ".conf") {
+				return fmt.Errorf("Name %q of drop-in for systemd unit %s must be a file name ending in .conf", dropIn.Name, unit.Name)
+			}
+			if strings.Contains(dropIn.Name, reservedDropInInfix) {
+				return fmt.Errorf("Name %q of drop-in for systemd unit %s must not contain %s, which is reserved for nodeadm", dropIn.Name, unit.Name, reservedDropInInfix)
+			}
+			if dropIns[dropIn.Name] {
+				return fmt.Errorf("Drop-in %s for systemd unit %s is defined more than once", dropIn.Name, unit.Name)
+			}
+			dropIns[dropIn.Name] = true
+			if err := validateSystemdDropInContent(dropIn.Content); err != nil {
+				return fmt.Errorf("Content of drop-in %s for systemd unit %s is invalid: %w", dropIn.Name, unit.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSystemdDropInContent checks that the content is made of sections of
+// key=value assignments, as systemd would read it.
+func validateSystemdDropInContent(content string) error {
+	section := ""
+	continued := false
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case continued:
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") || len(line) < 3 {
+				return fmt.Errorf("line %d is not a section header: %s", i+1, line)
+			}
+			section = line
+		case section == "":
+			return fmt.Errorf("line %d is not in a section: %s", i+1, line)
+		case !strings.Contains(line, "="):
+			return fmt.Errorf("line %d is not an assignment: %s", i+1, line)
+		}
+		continued = strings.HasSuffix(line, "\\") && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, ";")
+	}
+	if section == "" {
+		return fmt.Errorf("no section is defined")
+	}
+	return nil
+}
+
+// validateNodeLabelsAndTaints checks the keys of node labels and taints, and
+// that their values expand to valid values with the details of the instance.
+func validateNodeLabelsAndTaints(cfg *NodeConfig) error {
+	for key := range cfg.Spec.Kubelet.NodeLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("Key %q of node label is invalid: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	if _, err := cfg.GetNodeLabels(); err != nil {
+		return err
+	}
+	for _, taint := range cfg.Spec.Kubelet.NodeTaints {
+		if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+			return fmt.Errorf("Key %q of node taint is invalid: %s", taint.Key, strings.Join(errs, ", "))
+		}
+		switch taint.Effect {
+		case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("Effect %q of node taint %q is not one of %v", taint.Effect, taint.Key, []TaintEffect{TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute})
+		}
+	}
+	if _, err := cfg.GetNodeTaints(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateTokenCacheOptions(tokenCache *TokenCacheOptions) error {
+	if leadTime := tokenCache.LeadTime; leadTime != nil && (leadTime.Duration <= 0 || leadTime.Duration > maxTokenLeadTime) {
+		return fmt.Errorf("LeadTime in token cache configuration must be greater than 0 and at most %s", maxTokenLeadTime)
+	}
+	if tokenCache.MaxAttempts < 0 {
+		return fmt.Errorf("MaxAttempts in token cache configuration must not be negative")
+	}
+	return nil
+}
+
+// roleSessionNamePattern matches the names that STS accepts for the sessions of
+// assumed roles.
+var roleSessionNamePattern = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+func validateKubeletAuthentication(kubelet *KubeletOptions) error {
+	mode := kubelet.AuthenticationMode
+	authentication := &kubelet.Authentication
+	switch mode {
+	case "", KubeletAuthenticationModeAWSCLI, KubeletAuthenticationModeAWSIAMAuthenticator:
+		if mode == KubeletAuthenticationModeAWSIAMAuthenticator && kubelet.TokenCache.Enabled {
+			return fmt.Errorf("Token cache in kubelet configuration is only supported by authentication mode %s", KubeletAuthenticationModeAWSCLI)
+		}
+		if authentication.ClientCertificate != "" || authentication.ClientKey != "" {
+			return fmt.Errorf("ClientCertificate and ClientKey in kubelet authentication configuration require authentication mode %s", KubeletAuthenticationModeClientCertificate)
+		}
+	case KubeletAuthenticationModeClientCertificate:
+		if kubelet.TokenCache.Enabled {
+			return fmt.Errorf("Token cache in kubelet configuration is only supported by authentication mode %s", KubeletAuthenticationModeAWSCLI)
+		}
+		if authentication.RoleARN != "" {
+			return fmt.Errorf("RoleARN in kubelet authentication configuration is not supported by authentication mode %s", mode)
+		}
+		if !path.IsAbs(authentication.ClientCertificate) {
+			return fmt.Errorf("ClientCertificate in kubelet authentication configuration must be an absolute path with authentication mode %s", mode)
+		}
+		if !path.IsAbs(authentication.ClientKey) {
+			return fmt.Errorf("ClientKey in kubelet authentication configuration must be an absolute path with authentication mode %s", mode)
+		}
+	default:
+		return fmt.Errorf("Authentication mode %q in kubelet configuration is not one of %v", mode, []KubeletAuthenticationMode{KubeletAuthenticationModeAWSCLI, KubeletAuthenticationModeAWSIAMAuthenticator, KubeletAuthenticationModeClientCertificate})
+	}
+	if authentication.RoleARN != "" {
+		if kubelet.TokenCache.Enabled {
+			return fmt.Errorf("RoleARN in kubelet authentication configuration is not supported when the token cache is enabled")
+		}
+		roleARN, err := arn.Parse(authentication.RoleARN)
+		if err != nil {
+			return fmt.Errorf("RoleARN in kubelet authentication configuration is invalid: %w", err)
+		}
+		if roleARN.Service != "iam" || !strings.HasPrefix(roleARN.Resource, "role/") {
+			return fmt.Errorf("RoleARN in kubelet authentication configuration must be the ARN of an IAM role: %s", authentication.RoleARN)
+		}
+	}
+	if authentication.SessionName != "" {
+		if authentication.RoleARN == "" {
+			return fmt.Errorf("SessionName in kubelet authentication configuration requires RoleARN")
+		}
+		if mode != KubeletAuthenticationModeAWSIAMAuthenticator {
+			return fmt.Errorf("SessionName in kubelet authentication configuration is only supported by authentication mode %s", KubeletAuthenticationModeAWSIAMAuthenticator)
+		}
+		if !roleSessionNamePattern.MatchString(authentication.SessionName) {
+			return fmt.Errorf("SessionName %q in kubelet authentication configuration must be 2 to 64 letters, digits or any of +=,.@_-", authentication.SessionName)
+		}
+	}
+	return nil
+}
+
+func validateServingCertificateOptions(kubelet *KubeletOptions) error {
+	servingCertificate := &kubelet.ServingCertificate
+	if timeout := servingCertificate.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Timeout in serving certificate configuration must be greater than 0")
+	}
+	if !servingCertificate.WaitForIssuance {
+		return nil
+	}
+	// kubelet only requests a serving certificate with TLS bootstrapping
+	if raw, ok := kubelet.Config["serverTLSBootstrap"]; ok {
+		var serverTLSBootstrap bool
+		if err := json.Unmarshal(raw.Raw, &serverTLSBootstrap); err == nil && !serverTLSBootstrap {
+			return fmt.Errorf("Waiting for the serving certificate requires serverTLSBootstrap, which is disabled in the kubelet config")
 		}
 	}
 	return nil
@@ -0,0 +1,122 @@
+// Package awsconfig loads the config of the clients of AWS APIs for every
+// command and daemon of nodeadm, so that their calls are logged, traced and
+// guarded in offline mode the same way, and resolve their region, endpoints
+// and FIPS and dual-stack variants from the environment and shared config
+// like the AWS SDKs.
+package awsconfig
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/offline"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/partitions"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/tracing"
+)
+
+const endpointEnvPrefix = "AWS_ENDPOINT_URL_"
+
+var bootstrapTuning atomic.Pointer[api.BootstrapTuning]
+
+// SetBootstrapTuning makes the calls of the configs that are loaded from here
+// on retry and back off with the settings of a bootstrap profile, for the
+// rest of the process.
+func SetBootstrapTuning(tuning api.BootstrapTuning) {
+	bootstrapTuning.Store(&tuning)
+}
+
+// Load returns the default config of the clients of AWS APIs with the
+// options of nodeadm, followed by those of the caller.
+func Load(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	// until the bootstrap profile is known, that of the default profile is used
+	tuning := (&api.NodeConfig{}).GetBootstrapTuning()
+	if t := bootstrapTuning.Load(); t != nil {
+		tuning = *t
+	}
+	opts := []func(*config.LoadOptions) error{
+		WithBootstrapTuning(tuning),
+		config.WithClientLogMode(aws.LogRetries),
+		config.WithAPIOptions([]func(*middleware.Stack) error{tracing.AddAWSMiddleware}),
+		offline.WithOfflineGuard(),
+		config.WithEC2IMDSRegion(func(o *config.UseEC2IMDSRegion) {
+			// Use our pre-configured IMDS client to avoid hitting common retry
+			// issues with the default config.
+			o.Client = imds.Client
+		}),
+	}
+	return config.LoadDefaultConfig(ctx, append(opts, optFns...)...)
+}
+
+// WithBootstrapTuning sets the retryer of calls to AWS APIs for a bootstrap
+// profile. The adaptive mode rate limits calls on the node once they are
+// throttled, instead of only backing off each call.
+func WithBootstrapTuning(tuning api.BootstrapTuning) func(*config.LoadOptions) error {
+	return config.WithRetryer(func() aws.Retryer {
+		standard := func(o *retry.StandardOptions) {
+			o.MaxAttempts = tuning.AWSMaxAttempts
+			o.MaxBackoff = tuning.AWSMaxBackoff
+		}
+		if tuning.AWSAdaptiveRetry {
+			return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+				o.StandardOptions = append(o.StandardOptions, standard)
+			})
+		}
+		return retry.NewStandard(standard)
+	})
+}
+
+type serviceEndpointSource interface {
+	GetServiceBaseEndpoint(ctx context.Context, sdkID string) (string, bool, error)
+}
+
+type fipsEndpointSource interface {
+	GetUseFIPSEndpoint(ctx context.Context) (aws.FIPSEndpointState, bool, error)
+}
+
+type dualStackEndpointSource interface {
+	GetUseDualStackEndpoint(ctx context.Context) (aws.DualStackEndpointState, bool, error)
+}
+
+// ServiceEndpoint returns the endpoint of a service whose client is not part
+// of the AWS SDK of nodeadm, such as CloudWatch Logs, as the SDK resolves
+// those of its clients: the AWS_ENDPOINT_URL_<SERVICE> environment variable,
+// the endpoint of the service in the shared config, AWS_ENDPOINT_URL, or the
+// regional endpoint of the partition, in its FIPS and dual-stack variants
+// when the environment or shared config asks for them.
+func ServiceEndpoint(ctx context.Context, cfg aws.Config, sdkID string, service string) string {
+	if endpoint := os.Getenv(endpointEnvPrefix + strings.ReplaceAll(strings.ToUpper(sdkID), " ", "_")); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	var fips, dualStack bool
+	fipsFound, dualStackFound := false, false
+	for _, source := range cfg.ConfigSources {
+		if s, ok := source.(serviceEndpointSource); ok {
+			if endpoint, found, err := s.GetServiceBaseEndpoint(ctx, sdkID); err == nil && found {
+				return strings.TrimSuffix(endpoint, "/")
+			}
+		}
+		if s, ok := source.(fipsEndpointSource); ok && !fipsFound {
+			if state, found, err := s.GetUseFIPSEndpoint(ctx); err == nil && found {
+				fips, fipsFound = state == aws.FIPSEndpointStateEnabled, true
+			}
+		}
+		if s, ok := source.(dualStackEndpointSource); ok && !dualStackFound {
+			if state, found, err := s.GetUseDualStackEndpoint(ctx); err == nil && found {
+				dualStack, dualStackFound = state == aws.DualStackEndpointStateEnabled, true
+			}
+		}
+	}
+	if cfg.BaseEndpoint != nil {
+		return strings.TrimSuffix(*cfg.BaseEndpoint, "/")
+	}
+	return partitions.ForRegion(cfg.Region).ServiceEndpointVariant(service, cfg.Region, fips, dualStack)
+}
@@ -0,0 +1,50 @@
+package awsconfig
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestServiceEndpoint(t *testing.T) {
+	var tests = []struct {
+		name             string
+		env              map[string]string
+		expectedEndpoint string
+	}{
+		{name: "regional", expectedEndpoint: "https://logs.us-west-2.amazonaws.com"},
+		{name: "fips", env: map[string]string{"AWS_USE_FIPS_ENDPOINT": "true"}, expectedEndpoint: "https://logs-fips.us-west-2.amazonaws.com"},
+		{name: "dual-stack", env: map[string]string{"AWS_USE_DUALSTACK_ENDPOINT": "true"}, expectedEndpoint: "https://logs.us-west-2.api.aws"},
+		{name: "base endpoint", env: map[string]string{"AWS_ENDPOINT_URL": "https://vpce.example.com/"}, expectedEndpoint: "https://vpce.example.com"},
+		{name: "service endpoint", env: map[string]string{"AWS_ENDPOINT_URL": "https://vpce.example.com", "AWS_ENDPOINT_URL_CLOUDWATCH_LOGS": "https://logs.example.com"}, expectedEndpoint: "https://logs.example.com"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+			for _, name := range []string{"AWS_USE_FIPS_ENDPOINT", "AWS_USE_DUALSTACK_ENDPOINT", "AWS_ENDPOINT_URL", "AWS_ENDPOINT_URL_CLOUDWATCH_LOGS"} {
+				t.Setenv(name, test.env[name])
+			}
+			cfg, err := Load(context.TODO(), config.WithRegion("us-west-2"), config.WithCredentialsProvider(aws.AnonymousCredentials{}))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEndpoint, ServiceEndpoint(context.TODO(), cfg, "CloudWatch Logs", "logs"))
+		})
+	}
+}
+
+func TestLoadBootstrapTuning(t *testing.T) {
+	t.Cleanup(func() { bootstrapTuning.Store(nil) })
+	awsConfig, err := Load(context.TODO(), config.WithRegion("us-west-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, awsConfig.Retryer().MaxAttempts())
+
+	SetBootstrapTuning((&api.NodeConfig{Spec: api.NodeConfigSpec{Instance: api.InstanceOptions{BootstrapProfile: api.BootstrapProfileMassiveScaleUp}}}).GetBootstrapTuning())
+	awsConfig, err = Load(context.TODO(), config.WithRegion("us-west-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, awsConfig.Retryer().MaxAttempts())
+}
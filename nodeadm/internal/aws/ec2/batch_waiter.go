@@ -0,0 +1,214 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go/middleware"
+	smithywaiter "github.com/aws/smithy-go/waiter"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// MaxBatchInstances is the maximum number of instances that a
+// BatchInstanceWaiter waits for at once.
+const MaxBatchInstances = 1000
+
+// describeInstancesBatchSize is the number of instances that are described by
+// each of the concurrent DescribeInstances paginations of an attempt.
+var describeInstancesBatchSize = 200
+
+// describeInstancesPageSize is the maximum number of instances in each page of
+// a DescribeInstances pagination.
+var describeInstancesPageSize int32 = 1000
+
+// PerInstanceCondition returns whether a single instance met a condition. An
+// error marks the instance as failed, and it is no longer polled.
+type PerInstanceCondition func(instance types.Instance) (bool, error)
+
+// BatchInstanceResult is the outcome of a BatchInstanceWaiter for each of its
+// instances, in the order that they were given to the waiter.
+type BatchInstanceResult struct {
+	// Met are the instances that met the condition
+	Met []string
+	// Pending are the instances that have not met the condition, including the
+	// instances that were not found
+	Pending []string
+	// Failed are the instances whose condition returned an error
+	Failed map[string]error
+}
+
+// BatchInstanceWaiter waits for many instances to meet a condition, describing
+// them in concurrent batches.
+type BatchInstanceWaiter struct {
+	client    ec2.DescribeInstancesAPIClient
+	condition PerInstanceCondition
+	options   InstanceConditionWaiterOptions
+}
+
+// NewBatchInstanceWaiter constructs a BatchInstanceWaiter.
+func NewBatchInstanceWaiter(client ec2.DescribeInstancesAPIClient, condition PerInstanceCondition, optFns ...func(*InstanceConditionWaiterOptions)) *BatchInstanceWaiter {
+	options := InstanceConditionWaiterOptions{}
+	options.MinDelay = 15 * time.Second
+	options.MaxDelay = 120 * time.Second
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return &BatchInstanceWaiter{
+		client:    client,
+		condition: condition,
+		options:   options,
+	}
+}
+
+// Wait polls the instances until each of them met the condition or failed it,
+// and only polls the instances that are still pending. The maxWaitDur is the
+// maximum wait duration the waiter will wait. The maxWaitDur is required and
+// must be greater than zero.
+//
+// The result is returned along with any error, so that callers can tell which
+// instances met the condition before the waiter timed out or a request failed.
+func (w *BatchInstanceWaiter) Wait(ctx context.Context, instanceIDs []string, maxWaitDur time.Duration, optFns ...func(*InstanceConditionWaiterOptions)) (*BatchInstanceResult, error) {
+	ids := dedupe(instanceIDs)
+	if len(ids) > MaxBatchInstances {
+		return nil, fmt.Errorf("cannot wait for %d instances, the maximum is %d", len(ids), MaxBatchInstances)
+	}
+
+	options := w.options
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.MaxDelay <= 0 {
+		options.MaxDelay = 120 * time.Second
+	}
+
+	logger := smithywaiter.Logger{}
+
+	met := map[string]bool{}
+	failed := map[string]error{}
+	err := util.Wait(ctx, func(ctx context.Context) (bool, error) {
+		apiOptions := options.APIOptions
+
+		if options.LogWaitAttempts {
+			logger.Attempt++
+			apiOptions = append([]func(*middleware.Stack) error{}, options.APIOptions...)
+			apiOptions = append(apiOptions, logger.AddLogger)
+		}
+
+		var pending []string
+		for _, id := range ids {
+			if _, ok := failed[id]; !ok && !met[id] {
+				pending = append(pending, id)
+			}
+		}
+		if len(pending) == 0 {
+			return true, nil
+		}
+
+		instances, err := w.describeInstances(ctx, pending, func(o *ec2.Options) {
+			o.APIOptions = append(o.APIOptions, apiOptions...)
+			for _, opt := range options.ClientOptions {
+				opt(o)
+			}
+		})
+		if err != nil {
+			return false, checkRetryable(err)
+		}
+
+		done := true
+		for _, id := range pending {
+			instance, ok := instances[id]
+			if !ok {
+				done = false
+				continue
+			}
+			conditionMet, err := w.condition(instance)
+			if err != nil {
+				failed[id] = err
+			} else if conditionMet {
+				met[id] = true
+			} else {
+				done = false
+			}
+		}
+		return done, nil
+	}, options.MinDelay, options.MaxDelay, maxWaitDur)
+
+	result := &BatchInstanceResult{Failed: failed}
+	for _, id := range ids {
+		if met[id] {
+			result.Met = append(result.Met, id)
+		} else if _, ok := failed[id]; !ok {
+			result.Pending = append(result.Pending, id)
+		}
+	}
+	if errors.Is(err, util.ErrWaiterTimeout) {
+		return result, fmt.Errorf("%w for BatchInstance waiter, %d of %d instances are pending", err, len(result.Pending), len(ids))
+	}
+	return result, err
+}
+
+// describeInstances describes the instances in concurrent batches, and returns
+// the instances that were found by their IDs. Instances that do not exist are
+// filtered out rather than failing their batch.
+func (w *BatchInstanceWaiter) describeInstances(ctx context.Context, ids []string, optFns ...func(*ec2.Options)) (map[string]types.Instance, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		errs      []error
+		instances = map[string]types.Instance{}
+	)
+	for start := 0; start < len(ids); start += describeInstancesBatchSize {
+		batch := ids[start:min(start+describeInstancesBatchSize, len(ids))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			paginator := ec2.NewDescribeInstancesPaginator(w.client, &ec2.DescribeInstancesInput{
+				Filters:    []types.Filter{{Name: aws.String("instance-id"), Values: batch}},
+				MaxResults: aws.Int32(describeInstancesPageSize),
+			})
+			for paginator.HasMorePages() {
+				out, err := paginator.NextPage(ctx, optFns...)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					return
+				}
+				mu.Lock()
+				for _, reservation := range out.Reservations {
+					for _, instance := range reservation.Instances {
+						instances[aws.ToString(instance.InstanceId)] = instance
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+func dedupe(ids []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,156 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2/ec2test"
+)
+
+// instanceStates returns the response of a poll that describes each instance
+// with its state, keyed by the instance ID.
+func instanceStates(states map[string]types.InstanceStateName) ec2test.DescribeInstancesResponse {
+	out := &ec2.DescribeInstancesOutput{}
+	for _, id := range slices.Sorted(maps.Keys(states)) {
+		out.Reservations = append(out.Reservations, types.Reservation{
+			Instances: []types.Instance{{InstanceId: aws.String(id), State: &types.InstanceState{Name: states[id]}}},
+		})
+	}
+	return ec2test.DescribeInstancesResponse{Output: out}
+}
+
+// describedInstances returns the instance IDs of the filter of each call.
+func describedInstances(client *ec2test.FakeDescribeInstancesClient) [][]string {
+	var described [][]string
+	for _, input := range client.Inputs() {
+		var ids []string
+		for _, filter := range input.Filters {
+			if aws.ToString(filter.Name) == "instance-id" {
+				ids = append(ids, filter.Values...)
+			}
+		}
+		described = append(described, ids)
+	}
+	return described
+}
+
+func isRunning(instance types.Instance) (bool, error) {
+	switch instance.State.Name {
+	case types.InstanceStateNameRunning:
+		return true, nil
+	case types.InstanceStateNameTerminated:
+		return false, errors.New("instance terminated")
+	}
+	return false, nil
+}
+
+// withSmallBatches splits the instances of each attempt into batches of two,
+// described in pages of one instance.
+func withSmallBatches(t *testing.T) {
+	batchSize, pageSize := describeInstancesBatchSize, describeInstancesPageSize
+	describeInstancesBatchSize, describeInstancesPageSize = 2, 1
+	t.Cleanup(func() {
+		describeInstancesBatchSize, describeInstancesPageSize = batchSize, pageSize
+	})
+}
+
+func TestBatchInstanceWaiter(t *testing.T) {
+	pending, running := types.InstanceStateNamePending, types.InstanceStateNameRunning
+	client := ec2test.NewFakeDescribeInstancesClient(
+		instanceStates(map[string]types.InstanceStateName{"i-1": running, "i-2": pending, "i-3": pending, "i-4": running, "i-5": pending}),
+		instanceStates(map[string]types.InstanceStateName{"i-2": running, "i-3": pending, "i-5": running}),
+		instanceStates(map[string]types.InstanceStateName{"i-3": running}),
+	)
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	result, err := w.Wait(context.Background(), []string{"i-1", "i-2", "i-3", "i-4", "i-5", "i-1"}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, &BatchInstanceResult{Met: []string{"i-1", "i-2", "i-3", "i-4", "i-5"}, Failed: map[string]error{}}, result)
+	// instances are no longer described once they met the condition
+	assert.Equal(t, [][]string{
+		{"i-1", "i-2", "i-3", "i-4", "i-5"},
+		{"i-2", "i-3", "i-5"},
+		{"i-3"},
+	}, describedInstances(client))
+}
+
+func TestBatchInstanceWaiterBatches(t *testing.T) {
+	withSmallBatches(t)
+	running := types.InstanceStateNameRunning
+	client := ec2test.NewFakeDescribeInstancesClient(
+		instanceStates(map[string]types.InstanceStateName{"i-1": running, "i-2": running, "i-3": running, "i-4": running, "i-5": running}),
+	)
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	result, err := w.Wait(context.Background(), []string{"i-1", "i-2", "i-3", "i-4", "i-5"}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"i-1", "i-2", "i-3", "i-4", "i-5"}, result.Met)
+	// the batches are described concurrently, so in any order
+	described := describedInstances(client)
+	slices.SortFunc(described, slices.Compare)
+	assert.Equal(t, [][]string{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}}, described)
+	for _, input := range client.Inputs() {
+		assert.Equal(t, int32(1), aws.ToInt32(input.MaxResults))
+	}
+}
+
+func TestBatchInstanceWaiterFailed(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		instanceStates(map[string]types.InstanceStateName{"i-1": types.InstanceStateNamePending, "i-2": types.InstanceStateNameTerminated}),
+		instanceStates(map[string]types.InstanceStateName{"i-1": types.InstanceStateNameRunning}),
+	)
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	result, err := w.Wait(context.Background(), []string{"i-1", "i-2"}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"i-1"}, result.Met)
+	assert.Empty(t, result.Pending)
+	assert.EqualError(t, result.Failed["i-2"], "instance terminated")
+	assert.Equal(t, [][]string{{"i-1", "i-2"}, {"i-1"}}, describedInstances(client))
+}
+
+func TestBatchInstanceWaiterTimeout(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		instanceStates(map[string]types.InstanceStateName{"i-1": types.InstanceStateNameRunning, "i-2": types.InstanceStateNamePending}),
+	)
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	result, err := w.Wait(context.Background(), []string{"i-1", "i-2", "i-3"}, 50*time.Millisecond)
+	// the waiter either runs out of time between polls or is cancelled while
+	// waiting, depending on how long each poll took
+	assert.Error(t, err)
+	assert.Equal(t, []string{"i-1"}, result.Met)
+	assert.Equal(t, []string{"i-2", "i-3"}, result.Pending)
+	described := describedInstances(client)
+	assert.Greater(t, len(described), 1)
+	assert.Equal(t, []string{"i-2", "i-3"}, described[len(described)-1])
+}
+
+func TestBatchInstanceWaiterError(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Err: errors.New("connection refused")},
+	)
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	result, err := w.Wait(context.Background(), []string{"i-1"}, time.Second)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, []string{"i-1"}, result.Pending)
+	assert.Len(t, client.Inputs(), 1)
+}
+
+func TestBatchInstanceWaiterTooManyInstances(t *testing.T) {
+	var ids []string
+	for i := 0; i <= MaxBatchInstances; i++ {
+		ids = append(ids, fmt.Sprintf("i-%d", i))
+	}
+	client := ec2test.NewFakeDescribeInstancesClient()
+	w := NewBatchInstanceWaiter(client, isRunning, withShortDelays)
+	_, err := w.Wait(context.Background(), ids, time.Second)
+	assert.ErrorContains(t, err, "maximum is 1000")
+	assert.Empty(t, client.Inputs())
+}
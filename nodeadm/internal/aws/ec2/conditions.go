@@ -0,0 +1,101 @@
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ForAllInstances returns an InstanceCondition that is met once every instance
+// of the output meets the condition. It is not met while the output has no
+// instances, since instances may not be described right after they launch.
+func ForAllInstances(condition PerInstanceCondition) InstanceCondition {
+	return func(out *ec2.DescribeInstancesOutput) (bool, error) {
+		var found bool
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				found = true
+				met, err := condition(instance)
+				if err != nil {
+					return false, fmt.Errorf("instance %s: %w", aws.ToString(instance.InstanceId), err)
+				}
+				if !met {
+					return false, nil
+				}
+			}
+		}
+		return found, nil
+	}
+}
+
+// AllRunning is met once every instance is running. It fails if an instance is
+// shutting down or terminated, since it can no longer run.
+func AllRunning(out *ec2.DescribeInstancesOutput) (bool, error) {
+	return ForAllInstances(instanceIsRunning)(out)
+}
+
+// HasPrivateDNS is met once every instance has a private DNS name.
+func HasPrivateDNS(out *ec2.DescribeInstancesOutput) (bool, error) {
+	return ForAllInstances(instanceHasPrivateDNS)(out)
+}
+
+// SourceDestCheckDisabled is met once the source/destination check of every
+// instance is disabled, which instances that route traffic, such as NAT
+// instances, require.
+func SourceDestCheckDisabled(out *ec2.DescribeInstancesOutput) (bool, error) {
+	return ForAllInstances(instanceSourceDestCheckDisabled)(out)
+}
+
+// AllENIsAttached is met once every network interface of every instance is
+// attached.
+func AllENIsAttached(out *ec2.DescribeInstancesOutput) (bool, error) {
+	return ForAllInstances(instanceENIsAttached)(out)
+}
+
+// HasTag returns an InstanceCondition that is met once every instance has the
+// tag with the value.
+func HasTag(key string, value string) InstanceCondition {
+	return ForAllInstances(func(instance types.Instance) (bool, error) {
+		for _, tag := range instance.Tags {
+			if aws.ToString(tag.Key) == key {
+				return aws.ToString(tag.Value) == value, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+func instanceIsRunning(instance types.Instance) (bool, error) {
+	if instance.State == nil {
+		return false, nil
+	}
+	switch instance.State.Name {
+	case types.InstanceStateNameRunning:
+		return true, nil
+	case types.InstanceStateNameShuttingDown, types.InstanceStateNameTerminated:
+		return false, fmt.Errorf("instance is %s", instance.State.Name)
+	}
+	return false, nil
+}
+
+func instanceHasPrivateDNS(instance types.Instance) (bool, error) {
+	return aws.ToString(instance.PrivateDnsName) != "", nil
+}
+
+func instanceSourceDestCheckDisabled(instance types.Instance) (bool, error) {
+	return instance.SourceDestCheck != nil && !*instance.SourceDestCheck, nil
+}
+
+func instanceENIsAttached(instance types.Instance) (bool, error) {
+	if len(instance.NetworkInterfaces) == 0 {
+		return false, nil
+	}
+	for _, eni := range instance.NetworkInterfaces {
+		if eni.Attachment == nil || eni.Attachment.Status != types.AttachmentStatusAttached {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func describeOutput(instances ...types.Instance) *ec2.DescribeInstancesOutput {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{{Instances: instances}},
+	}
+}
+
+func withState(id string, state types.InstanceStateName) types.Instance {
+	return types.Instance{InstanceId: aws.String(id), State: &types.InstanceState{Name: state}}
+}
+
+func TestAllRunning(t *testing.T) {
+	var tests = []struct {
+		name      string
+		output    *ec2.DescribeInstancesOutput
+		expected  bool
+		expectErr bool
+	}{
+		{name: "not found", output: &ec2.DescribeInstancesOutput{}},
+		{name: "running", output: describeOutput(withState("i-1", types.InstanceStateNameRunning), withState("i-2", types.InstanceStateNameRunning)), expected: true},
+		{name: "pending", output: describeOutput(withState("i-1", types.InstanceStateNameRunning), withState("i-2", types.InstanceStateNamePending))},
+		{name: "no state", output: describeOutput(types.Instance{InstanceId: aws.String("i-1")})},
+		{name: "terminated", output: describeOutput(withState("i-1", types.InstanceStateNameTerminated)), expectErr: true},
+		{name: "shutting down", output: describeOutput(withState("i-1", types.InstanceStateNameShuttingDown)), expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			met, err := AllRunning(test.output)
+			if test.expectErr {
+				assert.ErrorContains(t, err, "instance i-1")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, met)
+		})
+	}
+}
+
+func TestAllRunningAcrossReservations(t *testing.T) {
+	out := &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{
+			{Instances: []types.Instance{withState("i-1", types.InstanceStateNameRunning)}},
+			{Instances: []types.Instance{withState("i-2", types.InstanceStateNamePending)}},
+		},
+	}
+	met, err := AllRunning(out)
+	assert.NoError(t, err)
+	assert.False(t, met)
+}
+
+func TestHasPrivateDNS(t *testing.T) {
+	met, err := HasPrivateDNS(describeOutput(types.Instance{PrivateDnsName: aws.String("ip-10-0-0-1.ec2.internal")}))
+	assert.NoError(t, err)
+	assert.True(t, met)
+
+	met, err = HasPrivateDNS(describeOutput(types.Instance{PrivateDnsName: aws.String("ip-10-0-0-1.ec2.internal")}, types.Instance{PrivateDnsName: aws.String("")}))
+	assert.NoError(t, err)
+	assert.False(t, met)
+}
+
+func TestSourceDestCheckDisabled(t *testing.T) {
+	met, err := SourceDestCheckDisabled(describeOutput(types.Instance{SourceDestCheck: aws.Bool(false)}))
+	assert.NoError(t, err)
+	assert.True(t, met)
+
+	met, err = SourceDestCheckDisabled(describeOutput(types.Instance{SourceDestCheck: aws.Bool(true)}))
+	assert.NoError(t, err)
+	assert.False(t, met)
+
+	met, err = SourceDestCheckDisabled(describeOutput(types.Instance{}))
+	assert.NoError(t, err)
+	assert.False(t, met)
+}
+
+func TestAllENIsAttached(t *testing.T) {
+	eni := func(status types.AttachmentStatus) types.InstanceNetworkInterface {
+		return types.InstanceNetworkInterface{Attachment: &types.InstanceNetworkInterfaceAttachment{Status: status}}
+	}
+	var tests = []struct {
+		name     string
+		enis     []types.InstanceNetworkInterface
+		expected bool
+	}{
+		{name: "none"},
+		{name: "attached", enis: []types.InstanceNetworkInterface{eni(types.AttachmentStatusAttached), eni(types.AttachmentStatusAttached)}, expected: true},
+		{name: "attaching", enis: []types.InstanceNetworkInterface{eni(types.AttachmentStatusAttached), eni(types.AttachmentStatusAttaching)}},
+		{name: "no attachment", enis: []types.InstanceNetworkInterface{{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			met, err := AllENIsAttached(describeOutput(types.Instance{NetworkInterfaces: test.enis}))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, met)
+		})
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	tagged := func(tags ...types.Tag) types.Instance {
+		return types.Instance{Tags: tags}
+	}
+	condition := HasTag("node.k8s.aws/ready", "true")
+
+	met, err := condition(describeOutput(tagged(types.Tag{Key: aws.String("node.k8s.aws/ready"), Value: aws.String("true")})))
+	assert.NoError(t, err)
+	assert.True(t, met)
+
+	met, err = condition(describeOutput(tagged(types.Tag{Key: aws.String("node.k8s.aws/ready"), Value: aws.String("false")})))
+	assert.NoError(t, err)
+	assert.False(t, met)
+
+	met, err = condition(describeOutput(tagged(types.Tag{Key: aws.String("Name"), Value: aws.String("true")})))
+	assert.NoError(t, err)
+	assert.False(t, met)
+}
@@ -0,0 +1,54 @@
+// Package ec2test provides fake clients of the EC2 API, so that the callers
+// of the API and their retries can be tested without AWS.
+package ec2test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// DescribeInstancesResponse is the response of a single call to
+// DescribeInstances.
+type DescribeInstancesResponse struct {
+	Output *ec2.DescribeInstancesOutput
+	Err    error
+}
+
+// FakeDescribeInstancesClient returns its scripted responses in order, one
+// per call, and repeats the last response once they are exhausted.
+type FakeDescribeInstancesClient struct {
+	mu        sync.Mutex
+	responses []DescribeInstancesResponse
+	inputs    []*ec2.DescribeInstancesInput
+}
+
+var _ ec2.DescribeInstancesAPIClient = &FakeDescribeInstancesClient{}
+
+// NewFakeDescribeInstancesClient returns a client that responds with the
+// given responses. A client without responses returns empty outputs.
+func NewFakeDescribeInstancesClient(responses ...DescribeInstancesResponse) *FakeDescribeInstancesClient {
+	return &FakeDescribeInstancesClient{responses: responses}
+}
+
+func (c *FakeDescribeInstancesClient) DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.inputs = append(c.inputs, params)
+	if len(c.responses) == 0 {
+		return &ec2.DescribeInstancesOutput{}, nil
+	}
+	i := min(len(c.inputs), len(c.responses)) - 1
+	return c.responses[i].Output, c.responses[i].Err
+}
+
+// Inputs returns the input of each call that was made, in order.
+func (c *FakeDescribeInstancesClient) Inputs() []*ec2.DescribeInstancesInput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*ec2.DescribeInstancesInput{}, c.inputs...)
+}
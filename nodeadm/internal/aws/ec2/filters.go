@@ -0,0 +1,29 @@
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// DescribeInstancesByTag returns the params of DescribeInstances for the
+// instances with a tag of the key and any of the values, which may contain the
+// wildcards of EC2 filters. The instances are described in pages, which
+// InstanceConditionWaiter gathers before its condition is evaluated.
+func DescribeInstancesByTag(key string, values ...string) *ec2.DescribeInstancesInput {
+	return describeInstancesByFilter("tag:"+key, values)
+}
+
+// DescribeInstancesByPrivateDNSName returns the params of DescribeInstances
+// for the instances with any of the private DNS names, such as
+// ip-10-0-0-1.ec2.internal.
+func DescribeInstancesByPrivateDNSName(names ...string) *ec2.DescribeInstancesInput {
+	return describeInstancesByFilter("private-dns-name", names)
+}
+
+func describeInstancesByFilter(name string, values []string) *ec2.DescribeInstancesInput {
+	return &ec2.DescribeInstancesInput{
+		Filters:    []types.Filter{{Name: aws.String(name), Values: values}},
+		MaxResults: aws.Int32(describeInstancesPageSize),
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
-	smithytime "github.com/aws/smithy-go/time"
 	smithywaiter "github.com/aws/smithy-go/waiter"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
 type InstanceCondition func(output *ec2.DescribeInstancesOutput) (bool, error)
@@ -83,10 +85,6 @@ func (w *InstanceConditionWaiter) Wait(ctx context.Context, params *ec2.Describe
 // duration the waiter will wait. The maxWaitDur is required and must be greater
 // than zero.
 func (w *InstanceConditionWaiter) WaitForOutput(ctx context.Context, params *ec2.DescribeInstancesInput, maxWaitDur time.Duration, optFns ...func(*InstanceConditionWaiterOptions)) (*ec2.DescribeInstancesOutput, error) {
-	if maxWaitDur <= 0 {
-		return nil, fmt.Errorf("maximum wait time for waiter must be greater than zero")
-	}
-
 	options := w.options
 	for _, fn := range optFns {
 		fn(&options)
@@ -96,29 +94,20 @@ func (w *InstanceConditionWaiter) WaitForOutput(ctx context.Context, params *ec2
 		options.MaxDelay = 120 * time.Second
 	}
 
-	if options.MinDelay > options.MaxDelay {
-		return nil, fmt.Errorf("minimum waiter delay %v must be lesser than or equal to maximum waiter delay of %v.", options.MinDelay, options.MaxDelay)
-	}
-
-	ctx, cancelFn := context.WithTimeout(ctx, maxWaitDur)
-	defer cancelFn()
-
 	logger := smithywaiter.Logger{}
-	remainingTime := maxWaitDur
 
-	var attempt int64
-	for {
-		attempt++
+	var out *ec2.DescribeInstancesOutput
+	err := util.Wait(ctx, func(ctx context.Context) (bool, error) {
 		apiOptions := options.APIOptions
-		start := time.Now()
 
 		if options.LogWaitAttempts {
-			logger.Attempt = attempt
+			logger.Attempt++
 			apiOptions = append([]func(*middleware.Stack) error{}, options.APIOptions...)
 			apiOptions = append(apiOptions, logger.AddLogger)
 		}
 
-		out, err := w.client.DescribeInstances(ctx, params, func(o *ec2.Options) {
+		var err error
+		out, err = describeAllInstances(ctx, w.client, params, func(o *ec2.Options) {
 			o.APIOptions = append(o.APIOptions, apiOptions...)
 			for _, opt := range options.ClientOptions {
 				opt(o)
@@ -126,57 +115,55 @@ func (w *InstanceConditionWaiter) WaitForOutput(ctx context.Context, params *ec2
 		})
 
 		if err != nil {
-			retryable, err := instanceRetryable(err)
-			if err != nil {
-				return nil, err
-			}
-			if !retryable {
-				return out, nil
-			}
-		} else {
-			conditionMet, err := w.condition(out)
-			if err != nil {
-				return nil, err
-			}
-			if conditionMet {
-				return out, nil
-			}
-		}
-
-		remainingTime -= time.Since(start)
-		if remainingTime < options.MinDelay || remainingTime <= 0 {
-			break
+			return false, checkRetryable(err)
 		}
+		return w.condition(out)
+	}, options.MinDelay, options.MaxDelay, maxWaitDur)
+	if errors.Is(err, util.ErrWaiterTimeout) {
+		return nil, fmt.Errorf("%w for InstanceCondition waiter", err)
+	} else if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-		// compute exponential backoff between waiter retries
-		delay, err := smithywaiter.ComputeDelay(
-			attempt, options.MinDelay, options.MaxDelay, remainingTime,
-		)
+// describeAllInstances describes the instances of every page of the output,
+// so that a condition sees all the instances that match the params rather
+// than those of the first page. The reservations of the pages are returned as
+// a single output without a next token.
+func describeAllInstances(ctx context.Context, client ec2.DescribeInstancesAPIClient, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	var all *ec2.DescribeInstancesOutput
+	paginator := ec2.NewDescribeInstancesPaginator(client, params)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, optFns...)
 		if err != nil {
-			return nil, fmt.Errorf("error computing waiter delay, %w", err)
+			return nil, err
 		}
-
-		remainingTime -= delay
-		// sleep for the delay amount before invoking a request
-		if err := smithytime.SleepWithContext(ctx, delay); err != nil {
-			return nil, fmt.Errorf("request cancelled while waiting, %w", err)
+		if all == nil {
+			all = page
+		} else {
+			all.Reservations = append(all.Reservations, page.Reservations...)
 		}
 	}
-	return nil, fmt.Errorf("exceeded max wait time for InstanceCondition waiter")
+	if all != nil {
+		all.NextToken = nil
+	}
+	return all, nil
 }
 
-func instanceRetryable(err error) (bool, error) {
-	if err != nil {
-		var apiErr smithy.APIError
-		ok := errors.As(err, &apiErr)
-		if !ok {
-			return false, fmt.Errorf("expected err to be of type smithy.APIError, got %w", err)
-		}
-
-		if "InvalidInstanceID.NotFound" == apiErr.ErrorCode() {
-			return true, nil
-		}
+// checkRetryable returns nil when the waiter polls again after the error of a
+// call, such as an instance that is not found until it is eventually
+// consistent. A call that was throttled after the retries of the client is
+// retried too, but after the maximum delay of the waiter. Errors that are not
+// from the API, such as network errors, stop the wait.
+func checkRetryable(err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("expected err to be of type smithy.APIError, got %w", err)
 	}
-
-	return true, nil
+	if IsThrottlingError(err) {
+		zap.L().Warn("EC2 API throttled the waiter, polling again after the maximum delay", zap.String("code", apiErr.ErrorCode()))
+		return fmt.Errorf("%w: %w", util.ErrThrottled, err)
+	}
+	return nil
 }
@@ -0,0 +1,119 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2/ec2test"
+)
+
+func instanceRunning(out *ec2.DescribeInstancesOutput) (bool, error) {
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil && instance.State.Name == types.InstanceStateNameRunning {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func instanceWithState(state types.InstanceStateName) *ec2.DescribeInstancesOutput {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{{
+			Instances: []types.Instance{{InstanceId: aws.String("i-1234567890abcdef0"), State: &types.InstanceState{Name: state}}},
+		}},
+	}
+}
+
+func withShortDelays(opts *InstanceConditionWaiterOptions) {
+	opts.MinDelay = time.Millisecond
+	opts.MaxDelay = time.Millisecond
+}
+
+func TestInstanceConditionWaiter(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Err: &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}},
+		ec2test.DescribeInstancesResponse{Output: instanceWithState(types.InstanceStateNamePending)},
+		ec2test.DescribeInstancesResponse{Output: instanceWithState(types.InstanceStateNameRunning)},
+	)
+	w := NewInstanceConditionWaiter(client, instanceRunning, withShortDelays)
+	input := &ec2.DescribeInstancesInput{InstanceIds: []string{"i-1234567890abcdef0"}}
+	out, err := w.WaitForOutput(context.Background(), input, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, instanceWithState(types.InstanceStateNameRunning), out)
+	assert.Equal(t, []*ec2.DescribeInstancesInput{input, input, input}, client.Inputs())
+}
+
+func TestInstanceConditionWaiterError(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Err: errors.New("connection refused")},
+	)
+	w := NewInstanceConditionWaiter(client, instanceRunning, withShortDelays)
+	err := w.Wait(context.Background(), &ec2.DescribeInstancesInput{}, time.Second)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Len(t, client.Inputs(), 1)
+}
+
+func TestInstanceConditionWaiterThrottled(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Err: &smithy.GenericAPIError{Code: "RequestLimitExceeded"}},
+		ec2test.DescribeInstancesResponse{Output: instanceWithState(types.InstanceStateNameRunning)},
+	)
+	w := NewInstanceConditionWaiter(client, instanceRunning, withShortDelays)
+	assert.NoError(t, w.Wait(context.Background(), &ec2.DescribeInstancesInput{}, time.Second))
+	assert.Len(t, client.Inputs(), 2)
+}
+
+func TestInstanceConditionWaiterTimeout(t *testing.T) {
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: instanceWithState(types.InstanceStateNamePending)},
+	)
+	w := NewInstanceConditionWaiter(client, instanceRunning, withShortDelays)
+	err := w.Wait(context.Background(), &ec2.DescribeInstancesInput{}, 50*time.Millisecond)
+	// the waiter either runs out of time between polls or is cancelled while
+	// waiting, depending on how long each poll took
+	assert.Error(t, err)
+	assert.Greater(t, len(client.Inputs()), 1)
+}
+
+func TestInstanceConditionWaiterPages(t *testing.T) {
+	firstPage := instanceWithState(types.InstanceStateNamePending)
+	firstPage.NextToken = aws.String("next")
+	client := ec2test.NewFakeDescribeInstancesClient(
+		ec2test.DescribeInstancesResponse{Output: firstPage},
+		ec2test.DescribeInstancesResponse{Output: instanceWithState(types.InstanceStateNameRunning)},
+	)
+	w := NewInstanceConditionWaiter(client, instanceRunning, withShortDelays)
+	input := DescribeInstancesByTag("eks:cluster-name", "my-cluster")
+	out, err := w.WaitForOutput(context.Background(), input, time.Second)
+	assert.NoError(t, err)
+	// the instance that is running is only on the second page
+	assert.Len(t, out.Reservations, 2)
+	assert.Nil(t, out.NextToken)
+	inputs := client.Inputs()
+	if assert.Len(t, inputs, 2) {
+		assert.Nil(t, inputs[0].NextToken)
+		assert.Equal(t, "next", aws.ToString(inputs[1].NextToken))
+		assert.Equal(t, input.Filters, inputs[1].Filters)
+	}
+	// the params of the caller are not changed by the pagination
+	assert.Nil(t, input.NextToken)
+}
+
+func TestDescribeInstancesFilters(t *testing.T) {
+	byTag := DescribeInstancesByTag("eks:nodegroup-name", "ng-1", "ng-2")
+	assert.Equal(t, []types.Filter{{Name: aws.String("tag:eks:nodegroup-name"), Values: []string{"ng-1", "ng-2"}}}, byTag.Filters)
+	assert.Equal(t, describeInstancesPageSize, aws.ToInt32(byTag.MaxResults))
+	byName := DescribeInstancesByPrivateDNSName("ip-10-0-0-1.ec2.internal")
+	assert.Equal(t, []types.Filter{{Name: aws.String("private-dns-name"), Values: []string{"ip-10-0-0-1.ec2.internal"}}}, byName.Filters)
+	assert.Empty(t, byName.InstanceIds)
+}
@@ -0,0 +1,177 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+const (
+	// once a call is throttled, calls are limited to rateLimiterInitialRate
+	// per second, which is halved by each throttled call down to
+	// rateLimiterMinRate, and raised by rateLimiterIncrease for each call that
+	// succeeds. Calls are no longer limited once the rate is raised back to
+	// rateLimiterMaxRate.
+	rateLimiterInitialRate = 2.0
+	rateLimiterMinRate     = 0.2
+	rateLimiterMaxRate     = 10.0
+	rateLimiterDecrease    = 0.5
+	rateLimiterIncrease    = 0.1
+	// rateLimiterBurst is the capacity of the bucket, so that calls are spread
+	// evenly rather than sent in bursts after an idle period.
+	rateLimiterBurst = 1.0
+)
+
+// throttlingErrorCodes are the error codes of the EC2 API when the rate of
+// calls of the account exceeds its limits.
+var throttlingErrorCodes = []string{"RequestLimitExceeded", "Throttling"}
+
+// IsThrottlingError returns whether the error is a call that the EC2 API
+// throttled.
+func IsThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && slices.Contains(throttlingErrorCodes, apiErr.ErrorCode())
+}
+
+// sharedRateLimiter limits the clients of NewClient, so that all the calls of
+// a bootstrap to the EC2 API slow down together once any of them is throttled.
+var sharedRateLimiter = NewRateLimiter()
+
+// NewClient returns a client of the EC2 API whose calls are limited by the
+// rate limiter that is shared by the clients of the process.
+func NewClient(cfg aws.Config, optFns ...func(*ec2.Options)) *ec2.Client {
+	return ec2.NewFromConfig(cfg, append([]func(*ec2.Options){WithRateLimiter(sharedRateLimiter)}, optFns...)...)
+}
+
+// WithRateLimiter limits each attempt of the calls of a client by the rate
+// limiter, including the attempts that the retryer of the client makes.
+func WithRateLimiter(limiter *RateLimiter) func(*ec2.Options) {
+	return func(o *ec2.Options) {
+		o.APIOptions = append(o.APIOptions, limiter.AddToStack)
+	}
+}
+
+// RateLimiter is an adaptive token bucket of calls to the EC2 API. Calls are
+// not limited until one is throttled, after which the rate of calls is
+// decreased multiplicatively by each throttled call and increased additively
+// by each call that succeeds, so that many nodes that are launched at once
+// back off from the limits of their account rather than amplify throttling by
+// retrying in step.
+type RateLimiter struct {
+	mu      sync.Mutex
+	enabled bool
+	// rate is the number of tokens that are added to the bucket per second.
+	rate   float64
+	tokens float64
+	last   time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter constructs a RateLimiter that does not limit calls until
+// one is throttled.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{now: time.Now}
+}
+
+// Acquire takes a token from the bucket, and waits for one if it is empty.
+func (l *RateLimiter) Acquire(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if err := smithytime.SleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token from the bucket, or returns how long it will take for
+// a token to be added to the bucket when it is empty.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return 0
+	}
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Throttled decreases the rate of calls after a call was throttled.
+func (l *RateLimiter) Throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		l.enabled = true
+		l.rate = rateLimiterInitialRate
+		l.tokens = 0
+		l.last = l.now()
+		return
+	}
+	l.refill()
+	l.rate = max(rateLimiterMinRate, l.rate*rateLimiterDecrease)
+}
+
+// Succeeded increases the rate of calls after a call succeeded.
+func (l *RateLimiter) Succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return
+	}
+	l.refill()
+	l.rate += rateLimiterIncrease
+	if l.rate >= rateLimiterMaxRate {
+		l.enabled = false
+	}
+}
+
+// Rate returns the number of calls per second that are allowed, which is zero
+// when calls are not limited.
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return 0
+	}
+	return l.rate
+}
+
+// refill adds the tokens of the time since the last refill to the bucket, at
+// the rate before it is changed.
+func (l *RateLimiter) refill() {
+	now := l.now()
+	l.tokens = min(rateLimiterBurst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// AddToStack adds the rate limiter to the stack of a call, after the retry
+// middleware so that every attempt of the call takes a token.
+func (l *RateLimiter) AddToStack(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("NodeadmRateLimiter", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if err := l.Acquire(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+		out, metadata, err := next.HandleFinalize(ctx, in)
+		if IsThrottlingError(err) {
+			l.Throttled()
+		} else if err == nil {
+			l.Succeeded()
+		}
+		return out, metadata, err
+	}), "Retry", middleware.After)
+}
@@ -0,0 +1,95 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	assert.True(t, IsThrottlingError(&smithy.GenericAPIError{Code: "RequestLimitExceeded"}))
+	assert.True(t, IsThrottlingError(fmt.Errorf("operation error: %w", &smithy.GenericAPIError{Code: "Throttling"})))
+	assert.False(t, IsThrottlingError(&smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}))
+	assert.False(t, IsThrottlingError(errors.New("connection refused")))
+	assert.False(t, IsThrottlingError(nil))
+}
+
+func newTestRateLimiter() (*RateLimiter, *time.Time) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter, now := newTestRateLimiter()
+	// calls are not limited until one is throttled
+	for range 100 {
+		assert.Zero(t, limiter.reserve())
+	}
+	assert.Zero(t, limiter.Rate())
+
+	limiter.Throttled()
+	assert.Equal(t, rateLimiterInitialRate, limiter.Rate())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	*now = now.Add(500 * time.Millisecond)
+	assert.Zero(t, limiter.reserve())
+	// the bucket holds a single token, however long it was idle
+	*now = now.Add(time.Minute)
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+
+	limiter.Throttled()
+	assert.Equal(t, 1.0, limiter.Rate())
+	for range 10 {
+		limiter.Throttled()
+	}
+	assert.Equal(t, rateLimiterMinRate, limiter.Rate())
+
+	limiter.Succeeded()
+	assert.InDelta(t, rateLimiterMinRate+rateLimiterIncrease, limiter.Rate(), 1e-9)
+	// calls are no longer limited once the rate is back to the maximum
+	for limiter.Rate() > 0 {
+		limiter.Succeeded()
+	}
+	assert.Zero(t, limiter.reserve())
+}
+
+func TestRateLimiterAcquireCancelled(t *testing.T) {
+	limiter, _ := newTestRateLimiter()
+	limiter.Throttled()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, limiter.Acquire(ctx))
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	limiter, _ := newTestRateLimiter()
+	stack := middleware.NewStack("test", func() interface{} { return nil })
+	// the retry middleware of the clients, which the rate limiter is added after
+	assert.NoError(t, stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("Retry", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		return next.HandleFinalize(ctx, in)
+	}), middleware.After))
+	assert.NoError(t, limiter.AddToStack(stack))
+
+	var err error
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+		return nil, middleware.Metadata{}, err
+	}), stack)
+	err = &smithy.GenericAPIError{Code: "RequestLimitExceeded"}
+	_, _, callErr := handler.Handle(context.Background(), nil)
+	assert.Equal(t, err, callErr)
+	assert.Equal(t, rateLimiterInitialRate, limiter.Rate())
+
+	limiter.tokens = 1
+	err = nil
+	_, _, callErr = handler.Handle(context.Background(), nil)
+	assert.NoError(t, callErr)
+	assert.InDelta(t, rateLimiterInitialRate+rateLimiterIncrease, limiter.Rate(), 1e-9)
+}
@@ -0,0 +1,31 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// GetInstanceTags returns the tags of the instance whose keys match any of the
+// patterns, which may contain the wildcards of EC2 filters.
+func GetInstanceTags(ctx context.Context, client ec2.DescribeTagsAPIClient, instanceID string, keyPatterns []string) (map[string]string, error) {
+	tags := make(map[string]string)
+	paginator := ec2.NewDescribeTagsPaginator(client, &ec2.DescribeTagsInput{
+		Filters: []types.Filter{
+			{Name: aws.String("resource-id"), Values: []string{instanceID}},
+			{Name: aws.String("key"), Values: keyPatterns},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range page.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,184 @@
+package ecr
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/partitions"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+// More details about the mappings in this file can be found here https://docs.aws.amazon.com/eks/latest/userguide/add-ons-images.html
+
+// defaultAccount serves the commercial regions that are not opt-in.
+const defaultAccount = "602401143452"
+
+var accountsByRegion = map[string]string{
+	"ap-east-1":       "800184023465",
+	"ap-east-2":       "533267051163",
+	"me-south-1":      "558608220178",
+	"cn-north-1":      "918309763551",
+	"cn-northwest-1":  "961992271922",
+	"us-gov-west-1":   "013241004608",
+	"us-gov-east-1":   "151742754352",
+	"us-iso-west-1":   "608367168043",
+	"us-iso-east-1":   "725322719131",
+	"us-isob-east-1":  "187977181151",
+	"eu-isoe-west-1":  "249663109785",
+	"us-isof-south-1": "676585237158",
+	"af-south-1":      "877085696533",
+	"ap-southeast-3":  "296578399912",
+	"me-central-1":    "759879836304",
+	"eu-south-1":      "590381155156",
+	"eu-south-2":      "455263428931",
+	"eu-central-2":    "900612956339",
+	"ap-south-2":      "900889452093",
+	"ap-southeast-4":  "491585149902",
+	"il-central-1":    "066635153087",
+	"ca-west-1":       "761377655185",
+	"ap-southeast-5":  "151610086707",
+	"ap-southeast-6":  "333609536671",
+	"ap-southeast-7":  "121268973566",
+	"mx-central-1":    "730335286997",
+
+	"ap-northeast-1": defaultAccount,
+	"ap-northeast-2": defaultAccount,
+	"ap-northeast-3": defaultAccount,
+	"ap-south-1":     defaultAccount,
+	"ap-southeast-1": defaultAccount,
+	"ap-southeast-2": defaultAccount,
+	"ca-central-1":   defaultAccount,
+	"eu-central-1":   defaultAccount,
+	"eu-north-1":     defaultAccount,
+	"eu-west-1":      defaultAccount,
+	"eu-west-2":      defaultAccount,
+	"eu-west-3":      defaultAccount,
+	"sa-east-1":      defaultAccount,
+	"us-east-1":      defaultAccount,
+	"us-east-2":      defaultAccount,
+	"us-west-1":      defaultAccount,
+	"us-west-2":      defaultAccount,
+}
+
+// fallbackRegions serve the regions of each partition that have no registry
+// of their own.
+var fallbackRegions = map[string]string{
+	"aws-iso-b":  "us-isob-east-1",
+	"aws-iso-f":  "us-isof-south-1",
+	"aws-iso":    "us-iso-east-1",
+	"aws-iso-e":  "eu-isoe-west-1",
+	"aws-us-gov": "us-gov-west-1",
+	"aws-cn":     "cn-northwest-1",
+	"aws":        "us-west-2",
+}
+
+// ECRRegistry is the domain name of an ECR registry, such as
+// 602401143452.dkr.ecr.us-west-2.amazonaws.com
+type ECRRegistry string
+
+func (r ECRRegistry) String() string {
+	return string(r)
+}
+
+// GetImageReference joins the registry with a repository and either a tag or
+// a digest, such as `eks/pause:3.10` or `eks/pause@sha256:...`.
+func (r ECRRegistry) GetImageReference(repository string) string {
+	return fmt.Sprintf("%s/%s", r, repository)
+}
+
+// GetEKSRegistry returns the registry that hosts the images EKS provides
+// for nodes in the given region, such as the pause image. The FIPS endpoint
+// of the registry is used if FIPS is enabled and the endpoint exists.
+func GetEKSRegistry(region string) (ECRRegistry, error) {
+	if region == "" {
+		return "", fmt.Errorf("region must be known to resolve the EKS registry")
+	}
+	account, registryRegion := getEKSRegistryCoordinates(region)
+	return getRegistry(account, registryRegion)
+}
+
+// GetAccountRegistry returns the private registry of an AWS account in the
+// given region.
+func GetAccountRegistry(account string, region string) (ECRRegistry, error) {
+	if account == "" || region == "" {
+		return "", fmt.Errorf("account and region must be known to resolve the registry of an account")
+	}
+	return getRegistry(account, region)
+}
+
+// registryHostPattern matches the domain names of private ECR registries in
+// every partition, with or without the FIPS endpoint.
+var registryHostPattern = regexp.MustCompile(`^[0-9]{12}\.dkr\.ecr(-fips)?\.[a-z0-9-]+\.(` + strings.Join(dnsSuffixPatterns(), "|") + `)$`)
+
+func dnsSuffixPatterns() []string {
+	var suffixes []string
+	for _, p := range partitions.All() {
+		if suffix := regexp.QuoteMeta(p.DNSSuffix); !slices.Contains(suffixes, suffix) {
+			suffixes = append(suffixes, suffix)
+		}
+	}
+	return suffixes
+}
+
+// RegistryHostGlobs returns the globs of the domain names of private ECR
+// registries in every partition, including their dual-stack and FIPS
+// endpoints, such as `*.dkr.ecr.*.amazonaws.com`. The commercial partition
+// comes first.
+func RegistryHostGlobs() []string {
+	var globs []string
+	all := partitions.All()
+	slices.Reverse(all)
+	for _, p := range all {
+		hosts := []string{"*.dkr.ecr.*." + p.DNSSuffix}
+		if p.DualStackDNSSuffix != "" {
+			hosts = append(hosts, "*.dkr-ecr.*."+p.DualStackDNSSuffix)
+		}
+		if p.FIPS {
+			hosts = append(hosts, "*.dkr.ecr-fips.*."+p.DNSSuffix)
+			if p.DualStackDNSSuffix != "" {
+				hosts = append(hosts, "*.dkr-ecr-fips.*."+p.DualStackDNSSuffix)
+			}
+		}
+		for _, host := range hosts {
+			if !slices.Contains(globs, host) {
+				globs = append(globs, host)
+			}
+		}
+	}
+	return globs
+}
+
+// IsECRRegistry returns whether the host of an image reference is a private
+// ECR registry, whose credentials are provided by the ECR credential provider.
+func IsECRRegistry(host string) bool {
+	return registryHostPattern.MatchString(host)
+}
+
+func getRegistry(account string, registryRegion string) (ECRRegistry, error) {
+	partition := partitions.ForRegion(registryRegion)
+	_, fipsEnabled, err := system.GetFipsInfo()
+	if err != nil {
+		return "", err
+	}
+	if fipsEnabled {
+		fipsRegistry := partition.ECRRegistryHost(account, registryRegion, true)
+		if addrs, err := net.LookupHost(fipsRegistry); err == nil && len(addrs) > 0 {
+			return ECRRegistry(fipsRegistry), nil
+		}
+	}
+	return ECRRegistry(partition.ECRRegistryHost(account, registryRegion, false)), nil
+}
+
+// getEKSRegistryCoordinates returns the account and region of the EKS
+// registry. Regions without a registry of their own use another registry in
+// the same partition.
+func getEKSRegistryCoordinates(region string) (string, string) {
+	if account, ok := accountsByRegion[region]; ok {
+		return account, region
+	}
+	fallbackRegion := fallbackRegions[partitions.ForRegion(region).ID]
+	return accountsByRegion[fallbackRegion], fallbackRegion
+}
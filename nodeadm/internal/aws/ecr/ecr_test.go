@@ -0,0 +1,62 @@
+package ecr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEKSRegistryCoordinates(t *testing.T) {
+	var tests = []struct {
+		region          string
+		expectedAccount string
+		expectedRegion  string
+	}{
+		{region: "us-west-2", expectedAccount: "602401143452", expectedRegion: "us-west-2"},
+		{region: "ap-east-1", expectedAccount: "800184023465", expectedRegion: "ap-east-1"},
+		{region: "cn-north-1", expectedAccount: "918309763551", expectedRegion: "cn-north-1"},
+		{region: "us-gov-east-1", expectedAccount: "151742754352", expectedRegion: "us-gov-east-1"},
+		{region: "us-iso-west-1", expectedAccount: "608367168043", expectedRegion: "us-iso-west-1"},
+		{region: "us-isob-east-1", expectedAccount: "187977181151", expectedRegion: "us-isob-east-1"},
+		{region: "eu-isoe-west-1", expectedAccount: "249663109785", expectedRegion: "eu-isoe-west-1"},
+		{region: "us-isof-south-1", expectedAccount: "676585237158", expectedRegion: "us-isof-south-1"},
+		// regions without a registry fall back to another region in the partition
+		{region: "cn-south-1", expectedAccount: "961992271922", expectedRegion: "cn-northwest-1"},
+		{region: "us-gov-north-1", expectedAccount: "013241004608", expectedRegion: "us-gov-west-1"},
+		{region: "us-isob-west-1", expectedAccount: "187977181151", expectedRegion: "us-isob-east-1"},
+		{region: "xx-new-1", expectedAccount: "602401143452", expectedRegion: "us-west-2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.region, func(t *testing.T) {
+			account, region := getEKSRegistryCoordinates(test.region)
+			assert.Equal(t, test.expectedAccount, account)
+			assert.Equal(t, test.expectedRegion, region)
+		})
+	}
+}
+
+func TestRegistryHostGlobs(t *testing.T) {
+	assert.Equal(t, []string{
+		"*.dkr.ecr.*.amazonaws.com",
+		"*.dkr-ecr.*.on.aws",
+		"*.dkr.ecr-fips.*.amazonaws.com",
+		"*.dkr-ecr-fips.*.on.aws",
+		"*.dkr.ecr.*.amazonaws.com.cn",
+		"*.dkr-ecr.*.on.amazonwebservices.com.cn",
+		"*.dkr.ecr.*.cloud.adc-e.uk",
+		"*.dkr.ecr.*.c2s.ic.gov",
+		"*.dkr.ecr.*.csp.hci.ic.gov",
+		"*.dkr.ecr.*.sc2s.sgov.gov",
+	}, RegistryHostGlobs())
+}
+
+func TestIsECRRegistry(t *testing.T) {
+	assert.True(t, IsECRRegistry("602401143452.dkr.ecr.us-west-2.amazonaws.com"))
+	assert.True(t, IsECRRegistry("123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com"))
+	assert.True(t, IsECRRegistry("918309763551.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+	assert.True(t, IsECRRegistry("187977181151.dkr.ecr.us-isob-east-1.sc2s.sgov.gov"))
+	assert.False(t, IsECRRegistry("public.ecr.aws"))
+	assert.False(t, IsECRRegistry("docker.io"))
+	assert.False(t, IsECRRegistry("602401143452.dkr.ecr.us-west-2.amazonaws.com.example.com"))
+}
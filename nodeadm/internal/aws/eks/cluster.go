@@ -0,0 +1,64 @@
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// ErrClusterNotReady is wrapped by the error of EnrichClusterDetails when the
+// cluster is still being created, so that its details may be read again.
+var ErrClusterNotReady = errors.New("cluster is not ready")
+
+// EnrichClusterDetails sets the details of the cluster that are not already
+// set from the DescribeCluster API.
+func EnrichClusterDetails(ctx context.Context, client eks.DescribeClusterAPIClient, details *api.ClusterDetails) error {
+	output, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(details.Name)})
+	if err != nil {
+		return fmt.Errorf("failed to describe cluster %s: %w", details.Name, err)
+	}
+	cluster := output.Cluster
+	if cluster == nil {
+		return fmt.Errorf("cluster %s was not described", details.Name)
+	}
+	if cluster.Status == types.ClusterStatusCreating || cluster.Status == types.ClusterStatusPending {
+		return fmt.Errorf("%w: cluster %s is %s", ErrClusterNotReady, details.Name, cluster.Status)
+	}
+	if cluster.Status != types.ClusterStatusActive && cluster.Status != types.ClusterStatusUpdating {
+		return fmt.Errorf("cluster %s is %s", details.Name, cluster.Status)
+	}
+	if details.APIServerEndpoint == "" {
+		details.APIServerEndpoint = aws.ToString(cluster.Endpoint)
+	}
+	if len(details.CertificateAuthority) == 0 && details.CertificateAuthorityFile == "" && cluster.CertificateAuthority != nil {
+		ca, err := base64.StdEncoding.DecodeString(aws.ToString(cluster.CertificateAuthority.Data))
+		if err != nil {
+			return fmt.Errorf("certificate authority of cluster %s is not base64-encoded: %w", details.Name, err)
+		}
+		details.CertificateAuthority = ca
+	}
+	if details.CIDR == "" && cluster.KubernetesNetworkConfig != nil {
+		if cluster.KubernetesNetworkConfig.IpFamily == types.IpFamilyIpv6 {
+			details.CIDR = aws.ToString(cluster.KubernetesNetworkConfig.ServiceIpv6Cidr)
+		} else {
+			details.CIDR = aws.ToString(cluster.KubernetesNetworkConfig.ServiceIpv4Cidr)
+		}
+	}
+	// the nodes of a local cluster on an Outpost are identified by the ID of
+	// the cluster rather than its name
+	if details.EnableOutpost == nil && cluster.OutpostConfig != nil {
+		details.EnableOutpost = ptr.To(true)
+		if details.ID == "" {
+			details.ID = aws.ToString(cluster.Id)
+		}
+	}
+	return nil
+}
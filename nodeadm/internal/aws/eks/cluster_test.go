@@ -0,0 +1,121 @@
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+type fakeDescribeClusterClient struct {
+	cluster *types.Cluster
+	err     error
+}
+
+func (c *fakeDescribeClusterClient) DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if aws.ToString(params.Name) != aws.ToString(c.cluster.Name) {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	return &eks.DescribeClusterOutput{Cluster: c.cluster}, nil
+}
+
+func TestEnrichClusterDetails(t *testing.T) {
+	ca := []byte("-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----\n")
+	cluster := func(mutate func(*types.Cluster)) *types.Cluster {
+		c := &types.Cluster{
+			Name:                 aws.String("my-cluster"),
+			Id:                   aws.String("b5bc4a7e-9a0c-4e8a-9b73-8f5c1c4a2c3d"),
+			Status:               types.ClusterStatusActive,
+			Endpoint:             aws.String("https://example.com"),
+			CertificateAuthority: &types.Certificate{Data: aws.String(base64.StdEncoding.EncodeToString(ca))},
+			KubernetesNetworkConfig: &types.KubernetesNetworkConfigResponse{
+				IpFamily:        types.IpFamilyIpv4,
+				ServiceIpv4Cidr: aws.String("10.100.0.0/16"),
+			},
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+	var tests = []struct {
+		name            string
+		cluster         *types.Cluster
+		details         api.ClusterDetails
+		expectedDetails api.ClusterDetails
+		expectErr       bool
+		expectNotReady  bool
+	}{
+		{
+			name:            "discovered",
+			cluster:         cluster(nil),
+			details:         api.ClusterDetails{Name: "my-cluster"},
+			expectedDetails: api.ClusterDetails{Name: "my-cluster", APIServerEndpoint: "https://example.com", CertificateAuthority: ca, CIDR: "10.100.0.0/16"},
+		},
+		{
+			name:            "set details are kept",
+			cluster:         cluster(nil),
+			details:         api.ClusterDetails{Name: "my-cluster", CertificateAuthorityFile: "/etc/eks/ca.crt", CIDR: "172.20.0.0/16"},
+			expectedDetails: api.ClusterDetails{Name: "my-cluster", APIServerEndpoint: "https://example.com", CertificateAuthorityFile: "/etc/eks/ca.crt", CIDR: "172.20.0.0/16"},
+		},
+		{
+			name: "ipv6",
+			cluster: cluster(func(c *types.Cluster) {
+				c.KubernetesNetworkConfig = &types.KubernetesNetworkConfigResponse{IpFamily: types.IpFamilyIpv6, ServiceIpv6Cidr: aws.String("fd30:1c53:5f8a::/108")}
+			}),
+			details:         api.ClusterDetails{Name: "my-cluster"},
+			expectedDetails: api.ClusterDetails{Name: "my-cluster", APIServerEndpoint: "https://example.com", CertificateAuthority: ca, CIDR: "fd30:1c53:5f8a::/108"},
+		},
+		{
+			name: "outpost",
+			cluster: cluster(func(c *types.Cluster) {
+				c.OutpostConfig = &types.OutpostConfigResponse{OutpostArns: []string{"arn:aws:outposts:us-west-2:111122223333:outpost/op-0123456789abcdef0"}}
+			}),
+			details:         api.ClusterDetails{Name: "my-cluster"},
+			expectedDetails: api.ClusterDetails{Name: "my-cluster", APIServerEndpoint: "https://example.com", CertificateAuthority: ca, CIDR: "10.100.0.0/16", EnableOutpost: ptr.To(true), ID: "b5bc4a7e-9a0c-4e8a-9b73-8f5c1c4a2c3d"},
+		},
+		{
+			name:      "not found",
+			cluster:   cluster(nil),
+			details:   api.ClusterDetails{Name: "another-cluster"},
+			expectErr: true,
+		},
+		{
+			name:           "creating",
+			cluster:        cluster(func(c *types.Cluster) { c.Status = types.ClusterStatusCreating }),
+			details:        api.ClusterDetails{Name: "my-cluster"},
+			expectErr:      true,
+			expectNotReady: true,
+		},
+		{
+			name:      "failed",
+			cluster:   cluster(func(c *types.Cluster) { c.Status = types.ClusterStatusFailed }),
+			details:   api.ClusterDetails{Name: "my-cluster"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := EnrichClusterDetails(context.Background(), &fakeDescribeClusterClient{cluster: test.cluster}, &test.details)
+			if test.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectNotReady, errors.Is(err, ErrClusterNotReady))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedDetails, test.details)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package endpoints overrides the endpoints of AWS services with the
+// `AWS_ENDPOINT_URL_<SERVICE>` environment variables, which the AWS SDKs and
+// CLI read the endpoint of each service from. The clients of nodeadm and the
+// commands that it and kubelet run, such as the credential plugins, call the
+// same endpoints that way.
+package endpoints
+
+import (
+	"os"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// GetEnvironment returns the environment variables of the endpoints that are
+// overridden, keyed by their name.
+func GetEnvironment(overrides api.EndpointOverrides) map[string]string {
+	environment := make(map[string]string)
+	for serviceID, endpoint := range map[string]string{
+		"EKS": overrides.EKS,
+		"EC2": overrides.EC2,
+		"STS": overrides.STS,
+		"ECR": overrides.ECR,
+	} {
+		if endpoint != "" {
+			environment["AWS_ENDPOINT_URL_"+serviceID] = endpoint
+		}
+	}
+	return environment
+}
+
+// SetEnvironment sets the environment variables of the endpoints that are
+// overridden for the current process, which are inherited by the commands it
+// runs.
+func SetEnvironment(overrides api.EndpointOverrides) error {
+	for key, value := range GetEnvironment(overrides) {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
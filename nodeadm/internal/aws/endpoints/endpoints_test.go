@@ -0,0 +1,24 @@
+package endpoints
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetEnvironment(t *testing.T) {
+	assert.Empty(t, GetEnvironment(api.EndpointOverrides{}))
+	assert.Equal(t, map[string]string{
+		"AWS_ENDPOINT_URL_EKS": "https://eks.example.com",
+		"AWS_ENDPOINT_URL_STS": "https://sts.example.com",
+	}, GetEnvironment(api.EndpointOverrides{EKS: "https://eks.example.com", STS: "https://sts.example.com"}))
+}
+
+func TestSetEnvironment(t *testing.T) {
+	t.Setenv("AWS_ENDPOINT_URL_EC2", "")
+	assert.NoError(t, SetEnvironment(api.EndpointOverrides{EC2: "https://ec2.example.com"}))
+	assert.Equal(t, "https://ec2.example.com", os.Getenv("AWS_ENDPOINT_URL_EC2"))
+}
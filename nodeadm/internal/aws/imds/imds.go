@@ -2,10 +2,16 @@ package imds
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 )
 
@@ -35,8 +41,54 @@ type IMDSProperty string
 
 const (
 	ServicesDomain IMDSProperty = "services/domain"
+	// TargetLifecycleState is the state of the instance in its Auto Scaling
+	// group that the instance is transitioning to.
+	TargetLifecycleState IMDSProperty = "autoscaling/target-lifecycle-state"
+	// InstanceTags lists the keys of the tags of the instance, when access to
+	// tags is allowed in its metadata options.
+	InstanceTags IMDSProperty = "tags/instance"
+	// SpotInstanceAction is the action that is taken when the Spot Instance
+	// is interrupted, which is only present once it has been issued.
+	SpotInstanceAction IMDSProperty = "spot/instance-action"
+	// ScheduledEvents are the scheduled events of the instance, such as its
+	// retirement or a reboot for maintenance.
+	ScheduledEvents IMDSProperty = "events/maintenance/scheduled"
+	// AvailabilityZoneID is the ID of the availability zone of the instance,
+	// which is the same zone in every account, unlike its name.
+	AvailabilityZoneID IMDSProperty = "placement/availability-zone-id"
+	// InstanceLifeCycle is the purchasing option of the instance, such as
+	// on-demand or spot.
+	InstanceLifeCycle IMDSProperty = "instance-life-cycle"
+	// PlacementGroupName and PlacementPartitionNumber are only present when
+	// the instance is launched in a placement group, or a partition of one.
+	PlacementGroupName       IMDSProperty = "placement/group-name"
+	PlacementPartitionNumber IMDSProperty = "placement/partition-number"
 )
 
+// SpotInterruption is the interruption notice of a Spot Instance.
+type SpotInterruption struct {
+	// Action is one of stop, terminate or hibernate.
+	Action string    `json:"action"`
+	Time   time.Time `json:"time"`
+}
+
+// ScheduledEvent is a scheduled event of the instance.
+type ScheduledEvent struct {
+	// Code is one of instance-reboot, system-reboot, system-maintenance,
+	// instance-retirement or instance-stop.
+	Code        string `json:"Code"`
+	Description string `json:"Description"`
+	EventID     string `json:"EventId"`
+	// State is active until the event is completed or canceled.
+	State     string `json:"State"`
+	NotBefore string `json:"NotBefore"`
+	NotAfter  string `json:"NotAfter,omitempty"`
+}
+
+// ErrInstanceTagsNotAllowed is returned by GetInstanceTags when the metadata
+// options of the instance do not allow access to its tags.
+var ErrInstanceTagsNotAllowed = errors.New("access to tags is not allowed in the metadata options of the instance")
+
 func GetInstanceIdentityDocument(ctx context.Context) (*imds.GetInstanceIdentityDocumentOutput, error) {
 	return Client.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{})
 }
@@ -64,3 +116,113 @@ func GetPropertyBytes(ctx context.Context, prop IMDSProperty) ([]byte, error) {
 	}
 	return io.ReadAll(res.Content)
 }
+
+// GetTargetLifecycleState returns the lifecycle state that the instance is
+// transitioning to in its Auto Scaling group, or an empty string if the
+// instance is not in one. Unlike other properties, a 404 is not retried, since
+// it is expected of instances outside of an Auto Scaling group.
+func GetTargetLifecycleState(ctx context.Context) (string, error) {
+	res, err := Client.GetMetadata(ctx, &imds.GetMetadataInput{Path: string(TargetLifecycleState)}, func(o *imds.Options) {
+		o.Retryer = retry.NewStandard()
+	})
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	state, err := io.ReadAll(res.Content)
+	if err != nil {
+		return "", err
+	}
+	return string(state), nil
+}
+
+// GetOptionalProperty returns a property that is not present on every
+// instance, or an empty string if it is not present on this one. Like the
+// target lifecycle state, a 404 is not retried.
+func GetOptionalProperty(ctx context.Context, prop IMDSProperty) (string, error) {
+	res, err := Client.GetMetadata(ctx, &imds.GetMetadataInput{Path: string(prop)}, func(o *imds.Options) {
+		o.Retryer = retry.NewStandard()
+	})
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	value, err := io.ReadAll(res.Content)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// GetSpotInterruption returns the interruption notice of the instance, or nil
+// if none has been issued. Like the target lifecycle state, a 404 is not
+// retried, since it is expected until the instance is interrupted.
+func GetSpotInterruption(ctx context.Context) (*SpotInterruption, error) {
+	res, err := Client.GetMetadata(ctx, &imds.GetMetadataInput{Path: string(SpotInstanceAction)}, func(o *imds.Options) {
+		o.Retryer = retry.NewStandard()
+	})
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var interruption SpotInterruption
+	if err := json.NewDecoder(res.Content).Decode(&interruption); err != nil {
+		return nil, err
+	}
+	return &interruption, nil
+}
+
+// GetScheduledEvents returns the scheduled events of the instance, including
+// those that were completed or canceled. Like the target lifecycle state, a
+// 404 is not retried.
+func GetScheduledEvents(ctx context.Context) ([]ScheduledEvent, error) {
+	res, err := Client.GetMetadata(ctx, &imds.GetMetadataInput{Path: string(ScheduledEvents)}, func(o *imds.Options) {
+		o.Retryer = retry.NewStandard()
+	})
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var events []ScheduledEvent
+	if err := json.NewDecoder(res.Content).Decode(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetInstanceTags returns the tags of the instance. Like the target lifecycle
+// state, a 404 is not retried, since it is expected of instances that do not
+// allow access to their tags.
+func GetInstanceTags(ctx context.Context) (map[string]string, error) {
+	noRetry := func(o *imds.Options) {
+		o.Retryer = retry.NewStandard()
+	}
+	res, err := Client.GetMetadata(ctx, &imds.GetMetadataInput{Path: string(InstanceTags)}, noRetry)
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return nil, ErrInstanceTagsNotAllowed
+	} else if err != nil {
+		return nil, err
+	}
+	keys, err := io.ReadAll(res.Content)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, key := range strings.Fields(string(keys)) {
+		value, err := GetProperty(ctx, IMDSProperty(path.Join(string(InstanceTags), key)))
+		if err != nil {
+			return nil, err
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
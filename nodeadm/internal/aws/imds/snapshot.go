@@ -0,0 +1,161 @@
+package imds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// RedactedValue replaces the values of the snapshot that may be secrets.
+const RedactedValue = "<redacted>"
+
+var (
+	// snapshotProperties are the properties of the instance that nodeadm
+	// reads while it bootstraps the node.
+	snapshotProperties = []IMDSProperty{
+		"ami-id",
+		"instance-id",
+		"instance-type",
+		InstanceLifeCycle,
+		"local-hostname",
+		"local-ipv4",
+		"mac",
+		"placement/availability-zone",
+		AvailabilityZoneID,
+		"placement/region",
+		ServicesDomain,
+	}
+	// optionalSnapshotProperties are not present on every instance, so they
+	// are left out of the snapshot rather than reported as errors.
+	optionalSnapshotProperties = []IMDSProperty{
+		PlacementGroupName,
+		PlacementPartitionNumber,
+		TargetLifecycleState,
+		"iam/info",
+	}
+	// interfaceProperties are the properties of each network interface, from
+	// which the node IP and the VPC CIDR blocks are taken.
+	interfaceProperties = []string{
+		"device-number",
+		"interface-id",
+		"subnet-id",
+		"vpc-id",
+		"local-ipv4s",
+		"subnet-ipv4-cidr-block",
+		"vpc-ipv4-cidr-blocks",
+		"security-group-ids",
+	}
+	optionalInterfaceProperties = []string{
+		"ipv6s",
+		"subnet-ipv6-cidr-blocks",
+		"vpc-ipv6-cidr-blocks",
+	}
+	// secretTagKeyPattern matches the keys of tags whose values are redacted,
+	// since tags are sometimes used to pass secrets to instances.
+	secretTagKeyPattern = regexp.MustCompile(`(?i)password|passwd|secret|token|credential|private.?key|api.?key`)
+)
+
+// Snapshot is the instance metadata that nodeadm reads, to diagnose a node
+// that was bootstrapped with the wrong region, node IP or VPC CIDR blocks.
+// Credentials and user data are never read into it.
+type Snapshot struct {
+	IdentityDocument *imds.InstanceIdentityDocument `json:"identityDocument,omitempty"`
+	MetaData         map[string]string              `json:"metaData"`
+	// NetworkInterfaces are the properties of each network interface, keyed
+	// by its MAC address.
+	NetworkInterfaces map[string]map[string]string `json:"networkInterfaces"`
+	// Tags are nil when access to tags is not allowed in the metadata
+	// options of the instance.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Errors are the errors of the paths that could not be read, which are
+	// left out of the rest of the snapshot.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// GetSnapshot reads the snapshot with a client of the instance metadata
+// service, recording the paths that fail rather than failing on them.
+func GetSnapshot(ctx context.Context, client *imds.Client) *Snapshot {
+	snapshot := Snapshot{
+		MetaData:          make(map[string]string),
+		NetworkInterfaces: make(map[string]map[string]string),
+		Errors:            make(map[string]string),
+	}
+	if res, err := client.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{}); err != nil {
+		snapshot.Errors["dynamic/instance-identity/document"] = err.Error()
+	} else {
+		snapshot.IdentityDocument = &res.InstanceIdentityDocument
+	}
+	for _, property := range snapshotProperties {
+		snapshot.get(ctx, client, string(property), false, snapshot.MetaData, string(property))
+	}
+	for _, property := range optionalSnapshotProperties {
+		snapshot.get(ctx, client, string(property), true, snapshot.MetaData, string(property))
+	}
+
+	macs := make(map[string]string)
+	snapshot.get(ctx, client, "network/interfaces/macs/", false, macs, "macs")
+	for _, mac := range strings.Fields(macs["macs"]) {
+		mac = strings.TrimSuffix(mac, "/")
+		properties := make(map[string]string)
+		for _, property := range interfaceProperties {
+			snapshot.get(ctx, client, path.Join("network/interfaces/macs", mac, property), false, properties, property)
+		}
+		for _, property := range optionalInterfaceProperties {
+			snapshot.get(ctx, client, path.Join("network/interfaces/macs", mac, property), true, properties, property)
+		}
+		snapshot.NetworkInterfaces[mac] = properties
+	}
+
+	tagKeys := make(map[string]string)
+	if found := snapshot.get(ctx, client, string(InstanceTags), true, tagKeys, "keys"); !found {
+		// the path is only missing when access to tags is not allowed, and
+		// other errors are recorded as they are
+		if _, failed := snapshot.Errors[string(InstanceTags)]; !failed {
+			snapshot.Errors[string(InstanceTags)] = ErrInstanceTagsNotAllowed.Error()
+		}
+	} else {
+		snapshot.Tags = make(map[string]string)
+		for _, key := range strings.Fields(tagKeys["keys"]) {
+			if secretTagKeyPattern.MatchString(key) {
+				snapshot.Tags[key] = RedactedValue
+				continue
+			}
+			snapshot.get(ctx, client, path.Join(string(InstanceTags), key), false, snapshot.Tags, key)
+		}
+	}
+	if len(snapshot.Errors) == 0 {
+		snapshot.Errors = nil
+	}
+	return &snapshot
+}
+
+// get reads a path into a key of values, and returns whether it was found.
+// The errors of optional paths that are not found are not recorded.
+func (s *Snapshot) get(ctx context.Context, client *imds.Client, metadataPath string, optional bool, values map[string]string, key string) bool {
+	res, err := client.GetMetadata(ctx, &imds.GetMetadataInput{Path: metadataPath})
+	// the client of the instance metadata service returns the errors of
+	// smithy, rather than those of the AWS APIs
+	var respErr *smithyhttp.ResponseError
+	if optional && errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return false
+	} else if err != nil {
+		s.Errors[metadataPath] = err.Error()
+		return false
+	}
+	defer res.Content.Close()
+	value, err := io.ReadAll(res.Content)
+	if err != nil {
+		s.Errors[metadataPath] = fmt.Sprintf("failed to read: %v", err)
+		return false
+	}
+	values[key] = string(value)
+	return true
+}
@@ -0,0 +1,91 @@
+package imds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, metadata map[string]string) *imds.Client {
+	return newTestClientWithStatuses(t, metadata, nil)
+}
+
+// newTestClientWithStatuses serves the metadata, and fails the paths of
+// statuses with their status code.
+func newTestClientWithStatuses(t *testing.T, metadata map[string]string, statuses map[string]int) *imds.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/latest/api/token" {
+			w.Header().Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "21600")
+			w.Write([]byte("token"))
+			return
+		}
+		metadataPath := strings.TrimPrefix(r.URL.Path, "/latest/")
+		if status, ok := statuses[metadataPath]; ok {
+			w.WriteHeader(status)
+			return
+		}
+		value, ok := metadata[metadataPath]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(value))
+	}))
+	t.Cleanup(server.Close)
+	return imds.New(imds.Options{Endpoint: server.URL})
+}
+
+func TestGetSnapshot(t *testing.T) {
+	metadata := map[string]string{
+		"dynamic/instance-identity/document":                                       `{"instanceId":"i-1234567890abcdef0","region":"us-west-2","accountId":"123456789012"}`,
+		"meta-data/instance-id":                                                    "i-1234567890abcdef0",
+		"meta-data/placement/region":                                               "us-west-2",
+		"meta-data/network/interfaces/macs/":                                       "0a:00:00:00:00:01/\n0a:00:00:00:00:02/",
+		"meta-data/network/interfaces/macs/0a:00:00:00:00:01/vpc-ipv4-cidr-blocks": "10.0.0.0/16\n100.64.0.0/16",
+		"meta-data/network/interfaces/macs/0a:00:00:00:00:02/local-ipv4s":          "10.0.1.20",
+		"meta-data/tags/instance":                                                  "Name\ndb-password",
+		"meta-data/tags/instance/Name":                                             "node",
+		"meta-data/tags/instance/db-password":                                      "hunter2",
+	}
+	snapshot := GetSnapshot(context.TODO(), newTestClient(t, metadata))
+
+	assert.Equal(t, "us-west-2", snapshot.IdentityDocument.Region)
+	assert.Equal(t, "i-1234567890abcdef0", snapshot.MetaData["instance-id"])
+	assert.Equal(t, "us-west-2", snapshot.MetaData["placement/region"])
+	assert.Len(t, snapshot.NetworkInterfaces, 2)
+	assert.Equal(t, "10.0.0.0/16\n100.64.0.0/16", snapshot.NetworkInterfaces["0a:00:00:00:00:01"]["vpc-ipv4-cidr-blocks"])
+	assert.Equal(t, "10.0.1.20", snapshot.NetworkInterfaces["0a:00:00:00:00:02"]["local-ipv4s"])
+	assert.Equal(t, map[string]string{"Name": "node", "db-password": RedactedValue}, snapshot.Tags)
+
+	// required properties that are missing are errors, optional ones are not
+	assert.Contains(t, snapshot.Errors, "mac")
+	assert.Contains(t, snapshot.Errors, "network/interfaces/macs/0a:00:00:00:00:01/subnet-id")
+	assert.NotContains(t, snapshot.Errors, string(PlacementGroupName))
+	assert.NotContains(t, snapshot.Errors, "network/interfaces/macs/0a:00:00:00:00:01/ipv6s")
+	assert.NotContains(t, snapshot.Errors, string(InstanceTags))
+}
+
+func TestGetSnapshotTagsNotAllowed(t *testing.T) {
+	snapshot := GetSnapshot(context.TODO(), newTestClient(t, map[string]string{
+		"meta-data/instance-id": "i-1234567890abcdef0",
+	}))
+	assert.Nil(t, snapshot.Tags)
+	assert.Equal(t, ErrInstanceTagsNotAllowed.Error(), snapshot.Errors[string(InstanceTags)])
+}
+
+func TestGetSnapshotTagsError(t *testing.T) {
+	snapshot := GetSnapshot(context.TODO(), newTestClientWithStatuses(t, map[string]string{
+		"meta-data/instance-id": "i-1234567890abcdef0",
+	}, map[string]int{
+		"meta-data/tags/instance": http.StatusForbidden,
+	}))
+	assert.Nil(t, snapshot.Tags)
+	// only a missing path means that access to tags is not allowed
+	assert.Contains(t, snapshot.Errors, string(InstanceTags))
+	assert.NotEqual(t, ErrInstanceTagsNotAllowed.Error(), snapshot.Errors[string(InstanceTags)])
+}
@@ -0,0 +1,157 @@
+// Package logs calls the few operations of the CloudWatch Logs API that
+// nodeadm streams its logs with. The SDK of CloudWatch Logs is not a
+// dependency of nodeadm, so the requests of its JSON protocol are signed with
+// SigV4 here.
+package logs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+)
+
+const (
+	signingName   = "logs"
+	targetPrefix  = "Logs_20140328."
+	contentType   = "application/x-amz-json-1.1"
+	serviceID     = "CloudWatch Logs"
+	alreadyExists = "ResourceAlreadyExistsException"
+)
+
+// Event is a log event, whose timestamp is kept in milliseconds.
+type Event struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// APIError is an error returned by the CloudWatch Logs API.
+type APIError struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s (status code %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// Client calls the CloudWatch Logs API in the region of its AWS config.
+type Client struct {
+	config   aws.Config
+	endpoint string
+	signer   *v4.Signer
+	now      func() time.Time
+}
+
+// NewClient returns a client of the endpoint of CloudWatch Logs that the AWS
+// SDKs would resolve for the config, such as that of the
+// AWS_ENDPOINT_URL_CLOUDWATCH_LOGS environment variable or its FIPS variant.
+func NewClient(ctx context.Context, cfg aws.Config) *Client {
+	return &Client{
+		config:   cfg,
+		endpoint: awsconfig.ServiceEndpoint(ctx, cfg, serviceID, signingName),
+		signer:   v4.NewSigner(),
+		now:      time.Now,
+	}
+}
+
+// CreateLogGroup creates a log group, unless it already exists.
+func (c *Client) CreateLogGroup(ctx context.Context, group string) error {
+	return ignoreAlreadyExists(c.call(ctx, "CreateLogGroup", map[string]any{"logGroupName": group}))
+}
+
+// CreateLogStream creates a log stream in a log group, unless it already
+// exists.
+func (c *Client) CreateLogStream(ctx context.Context, group string, stream string) error {
+	return ignoreAlreadyExists(c.call(ctx, "CreateLogStream", map[string]any{"logGroupName": group, "logStreamName": stream}))
+}
+
+// PutLogEvents puts a batch of events to a log stream. The events must be in
+// chronological order, and within the limits of a batch of the API.
+func (c *Client) PutLogEvents(ctx context.Context, group string, stream string, events []Event) error {
+	type inputLogEvent struct {
+		Timestamp int64  `json:"timestamp"`
+		Message   string `json:"message"`
+	}
+	logEvents := make([]inputLogEvent, 0, len(events))
+	for _, event := range events {
+		logEvents = append(logEvents, inputLogEvent{Timestamp: event.Timestamp.UnixMilli(), Message: event.Message})
+	}
+	return c.call(ctx, "PutLogEvents", map[string]any{"logGroupName": group, "logStreamName": stream, "logEvents": logEvents})
+}
+
+func (c *Client) call(ctx context.Context, operation string, input any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Target", targetPrefix+operation)
+	if c.config.Credentials == nil {
+		return fmt.Errorf("no credentials to call %s with", operation)
+	}
+	credentials, err := c.config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(sum[:]), signingName, c.config.Region, c.now()); err != nil {
+		return err
+	}
+	httpClient := c.config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return parseError(resp.StatusCode, data)
+}
+
+// parseError returns the error of a response of the JSON protocol, whose type
+// may be qualified by the namespace of the service.
+func parseError(statusCode int, data []byte) error {
+	var body struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Type == "" {
+		return &APIError{Code: http.StatusText(statusCode), Message: strings.TrimSpace(string(data)), StatusCode: statusCode}
+	}
+	code := body.Type
+	if i := strings.LastIndex(code, "#"); i >= 0 {
+		code = code[i+1:]
+	}
+	return &APIError{Code: code, Message: body.Message, StatusCode: statusCode}
+}
+
+func ignoreAlreadyExists(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == alreadyExists {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("AWS_ENDPOINT_URL_CLOUDWATCH_LOGS", server.URL)
+	return NewClient(context.TODO(), aws.Config{
+		Region:      "us-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("AWS_ENDPOINT_URL_CLOUDWATCH_LOGS", "")
+	assert.Equal(t, "https://logs.cn-north-1.amazonaws.com.cn", NewClient(context.TODO(), aws.Config{Region: "cn-north-1"}).endpoint)
+	t.Setenv("AWS_ENDPOINT_URL_CLOUDWATCH_LOGS", "https://logs.example.com/")
+	assert.Equal(t, "https://logs.example.com", NewClient(context.TODO(), aws.Config{Region: "us-west-2"}).endpoint)
+}
+
+func TestPutLogEvents(t *testing.T) {
+	var target string
+	var input struct {
+		LogGroupName  string `json:"logGroupName"`
+		LogStreamName string `json:"logStreamName"`
+		LogEvents     []struct {
+			Timestamp int64  `json:"timestamp"`
+			Message   string `json:"message"`
+		} `json:"logEvents"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-west-2/logs/aws4_request")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		w.Write([]byte("{}"))
+	})
+	timestamp := time.UnixMilli(1735689600123)
+	err := client.PutLogEvents(context.TODO(), "/aws/eks/test/nodeadm", "i-1234567890abcdef0", []Event{{Timestamp: timestamp, Message: "started"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Logs_20140328.PutLogEvents", target)
+	assert.Equal(t, "/aws/eks/test/nodeadm", input.LogGroupName)
+	assert.Equal(t, "i-1234567890abcdef0", input.LogStreamName)
+	assert.Len(t, input.LogEvents, 1)
+	assert.Equal(t, int64(1735689600123), input.LogEvents[0].Timestamp)
+	assert.Equal(t, "started", input.LogEvents[0].Message)
+}
+
+func TestCreateLogStreamAlreadyExists(t *testing.T) {
+	status := http.StatusBadRequest
+	body := `{"__type":"com.amazonaws.logs#ResourceAlreadyExistsException","message":"The specified log stream already exists"}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+	assert.NoError(t, client.CreateLogStream(context.TODO(), "group", "stream"))
+
+	body = `{"__type":"AccessDeniedException","message":"not authorized to perform logs:CreateLogStream"}`
+	err := client.CreateLogStream(context.TODO(), "group", "stream")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "AccessDeniedException", apiErr.Code)
+
+	status = http.StatusServiceUnavailable
+	body = "unavailable"
+	err = client.CreateLogGroup(context.TODO(), "group")
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+}
@@ -0,0 +1,49 @@
+// Package offline fails the calls of AWS APIs when the node is bootstrapped in
+// offline mode, so that a call that slipped through is reported at once
+// instead of being retried against an endpoint that cannot be reached.
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ErrAPICall is returned by every call of an AWS API in offline mode.
+var ErrAPICall = errors.New("AWS API calls are not allowed in offline mode")
+
+var enabled atomic.Bool
+
+// Enable makes every call of an AWS API whose client was configured with
+// WithOfflineGuard fail, for the rest of the process.
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled returns whether offline mode is enabled.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// WithOfflineGuard adds the middleware that fails the calls of the clients of
+// a config in offline mode.
+func WithOfflineGuard() func(*config.LoadOptions) error {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{AddMiddleware})
+}
+
+// AddMiddleware adds the middleware that fails calls in offline mode to a
+// stack. It runs after the metadata of the operation is registered, so that
+// the error names the API that was called.
+func AddMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OfflineGuard", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		if enabled.Load() {
+			return middleware.InitializeOutput{}, middleware.Metadata{}, fmt.Errorf("%w: %s %s", ErrAPICall, awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx))
+		}
+		return next.HandleInitialize(ctx, in)
+	}), middleware.After)
+}
@@ -0,0 +1,28 @@
+package offline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineGuard(t *testing.T) {
+	t.Cleanup(func() { enabled.Store(false) })
+	awsConfig, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("us-west-2"),
+		config.WithCredentialsProvider(aws.AnonymousCredentials{}),
+		WithOfflineGuard(),
+	)
+	assert.NoError(t, err)
+	client := ec2.NewFromConfig(awsConfig)
+
+	Enable()
+	assert.True(t, Enabled())
+	_, err = client.DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{})
+	assert.ErrorIs(t, err, ErrAPICall)
+	assert.ErrorContains(t, err, "EC2 DescribeInstances")
+}
@@ -0,0 +1,87 @@
+// Package partitions resolves the AWS partition of a region, such as `aws-cn`
+// for `cn-north-1`, and the names that differ between partitions: the
+// partition in ARNs, and the domains of the endpoints and ECR registries.
+package partitions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Partition is a group of regions that share a domain and their own ARNs.
+type Partition struct {
+	// ID is the name of the partition as it appears in ARNs.
+	ID string
+	// RegionPrefix is the prefix of the names of the regions of the
+	// partition.
+	RegionPrefix string
+	// DNSSuffix is the domain of the endpoints of the partition.
+	DNSSuffix string
+	// DualStackDNSSuffix is the domain of the dual-stack endpoints of the
+	// partition, if it has any.
+	DualStackDNSSuffix string
+	// APIDualStackDNSSuffix is the domain of the dual-stack endpoints of the
+	// AWS APIs of the partition, which differs from that of ECR registries.
+	APIDualStackDNSSuffix string
+	// FIPS is whether the partition has FIPS endpoints.
+	FIPS bool
+}
+
+// partitions are ordered so that the longest matching prefix is found first.
+var partitions = []Partition{
+	{ID: "aws-iso-b", RegionPrefix: "us-isob-", DNSSuffix: "sc2s.sgov.gov"},
+	{ID: "aws-iso-f", RegionPrefix: "us-isof-", DNSSuffix: "csp.hci.ic.gov"},
+	{ID: "aws-iso", RegionPrefix: "us-iso-", DNSSuffix: "c2s.ic.gov"},
+	{ID: "aws-iso-e", RegionPrefix: "eu-isoe-", DNSSuffix: "cloud.adc-e.uk"},
+	{ID: "aws-us-gov", RegionPrefix: "us-gov-", DNSSuffix: "amazonaws.com", DualStackDNSSuffix: "on.aws", APIDualStackDNSSuffix: "api.aws", FIPS: true},
+	{ID: "aws-cn", RegionPrefix: "cn-", DNSSuffix: "amazonaws.com.cn", DualStackDNSSuffix: "on.amazonwebservices.com.cn", APIDualStackDNSSuffix: "api.amazonwebservices.com.cn"},
+	{ID: "aws", RegionPrefix: "", DNSSuffix: "amazonaws.com", DualStackDNSSuffix: "on.aws", APIDualStackDNSSuffix: "api.aws", FIPS: true},
+}
+
+// All returns every partition. Regions of unknown prefixes belong to the last
+// one, `aws`.
+func All() []Partition {
+	return append([]Partition{}, partitions...)
+}
+
+// ForRegion returns the partition of a region. Regions of unknown prefixes,
+// such as new commercial regions, are in the `aws` partition.
+func ForRegion(region string) Partition {
+	for _, p := range partitions {
+		if strings.HasPrefix(region, p.RegionPrefix) {
+			return p
+		}
+	}
+	// unreachable, since the last partition matches every region
+	return partitions[len(partitions)-1]
+}
+
+// ServiceEndpoint returns the regional endpoint of a service, such as
+// https://sts.cn-north-1.amazonaws.com.cn for `sts` in `cn-north-1`.
+func (p Partition) ServiceEndpoint(service string, region string) string {
+	return fmt.Sprintf("https://%s.%s.%s", service, region, p.DNSSuffix)
+}
+
+// ServiceEndpointVariant returns the regional endpoint of a service, or its
+// FIPS or dual-stack variant when they are asked for and the partition has
+// them, such as https://logs-fips.us-west-2.api.aws.
+func (p Partition) ServiceEndpointVariant(service string, region string, fips bool, dualStack bool) string {
+	if fips && p.FIPS {
+		service += "-fips"
+	}
+	if dualStack && p.APIDualStackDNSSuffix != "" {
+		return fmt.Sprintf("https://%s.%s.%s", service, region, p.APIDualStackDNSSuffix)
+	}
+	return p.ServiceEndpoint(service, region)
+}
+
+// ECRRegistryHost returns the domain name of the private ECR registry of an
+// account, such as 602401143452.dkr.ecr.us-west-2.amazonaws.com, or of its
+// FIPS endpoint.
+func (p Partition) ECRRegistryHost(account string, region string, fips bool) string {
+	service := "ecr"
+	if fips {
+		service = "ecr-fips"
+	}
+	return fmt.Sprintf("%s.dkr.%s.%s.%s", account, service, region, p.DNSSuffix)
+}
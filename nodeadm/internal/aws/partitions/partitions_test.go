@@ -0,0 +1,45 @@
+package partitions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForRegion(t *testing.T) {
+	var tests = []struct {
+		region            string
+		expectedID        string
+		expectedDNSSuffix string
+	}{
+		{region: "us-west-2", expectedID: "aws", expectedDNSSuffix: "amazonaws.com"},
+		{region: "cn-north-1", expectedID: "aws-cn", expectedDNSSuffix: "amazonaws.com.cn"},
+		{region: "us-gov-east-1", expectedID: "aws-us-gov", expectedDNSSuffix: "amazonaws.com"},
+		{region: "us-iso-east-1", expectedID: "aws-iso", expectedDNSSuffix: "c2s.ic.gov"},
+		{region: "us-isob-east-1", expectedID: "aws-iso-b", expectedDNSSuffix: "sc2s.sgov.gov"},
+		{region: "us-isof-south-1", expectedID: "aws-iso-f", expectedDNSSuffix: "csp.hci.ic.gov"},
+		{region: "eu-isoe-west-1", expectedID: "aws-iso-e", expectedDNSSuffix: "cloud.adc-e.uk"},
+		// regions of unknown prefixes are commercial
+		{region: "xx-new-1", expectedID: "aws", expectedDNSSuffix: "amazonaws.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.region, func(t *testing.T) {
+			p := ForRegion(test.region)
+			assert.Equal(t, test.expectedID, p.ID)
+			assert.Equal(t, test.expectedDNSSuffix, p.DNSSuffix)
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	assert.Equal(t, "https://sts.us-west-2.amazonaws.com", ForRegion("us-west-2").ServiceEndpoint("sts", "us-west-2"))
+	assert.Equal(t, "https://sts.cn-north-1.amazonaws.com.cn", ForRegion("cn-north-1").ServiceEndpoint("sts", "cn-north-1"))
+	assert.Equal(t, "https://sts.us-iso-east-1.c2s.ic.gov", ForRegion("us-iso-east-1").ServiceEndpoint("sts", "us-iso-east-1"))
+	assert.Equal(t, "https://logs-fips.us-west-2.amazonaws.com", ForRegion("us-west-2").ServiceEndpointVariant("logs", "us-west-2", true, false))
+	assert.Equal(t, "https://logs-fips.us-gov-west-1.api.aws", ForRegion("us-gov-west-1").ServiceEndpointVariant("logs", "us-gov-west-1", true, true))
+	assert.Equal(t, "https://logs.cn-north-1.api.amazonwebservices.com.cn", ForRegion("cn-north-1").ServiceEndpointVariant("logs", "cn-north-1", true, true))
+	assert.Equal(t, "https://logs.us-iso-east-1.c2s.ic.gov", ForRegion("us-iso-east-1").ServiceEndpointVariant("logs", "us-iso-east-1", true, true))
+	assert.Equal(t, "918309763551.dkr.ecr.cn-north-1.amazonaws.com.cn", ForRegion("cn-north-1").ECRRegistryHost("918309763551", "cn-north-1", false))
+	assert.Equal(t, "013241004608.dkr.ecr-fips.us-gov-west-1.amazonaws.com", ForRegion("us-gov-west-1").ECRRegistryHost("013241004608", "us-gov-west-1", true))
+}
@@ -0,0 +1,274 @@
+// Package rolesanywhere sources AWS credentials from IAM Roles Anywhere with
+// the certificate and private key of a hybrid node, so that nodeadm can call
+// AWS APIs while it bootstraps the node without long-lived keys. The SDK of
+// IAM Roles Anywhere is not a dependency of nodeadm, so CreateSession is
+// signed with the X.509 variant of SigV4 here, as aws_signing_helper does.
+package rolesanywhere
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/partitions"
+)
+
+const (
+	signingName     = "rolesanywhere"
+	endpointEnv     = "AWS_ENDPOINT_URL_ROLESANYWHERE"
+	sessionsPath    = "/sessions"
+	contentType     = "application/json"
+	amzDateFormat   = "20060102T150405Z"
+	scopeDateFormat = "20060102"
+	// durationSeconds is the longest session that IAM Roles Anywhere vends
+	// by default, which the profile may shorten
+	durationSeconds = 3600
+)
+
+// Provider retrieves credentials by creating a session of IAM Roles Anywhere
+// for every call, so it is wrapped in an aws.CredentialsCache.
+type Provider struct {
+	options    api.IAMRolesAnywhereOptions
+	region     string
+	endpoint   string
+	httpClient aws.HTTPClient
+	now        func() time.Time
+}
+
+var _ aws.CredentialsProvider = &Provider{}
+
+// NewProvider returns a provider of the regional endpoint of IAM Roles
+// Anywhere, or of the endpoint of the AWS_ENDPOINT_URL_ROLESANYWHERE
+// environment variable like the AWS SDKs. The certificate and private key are
+// read on every retrieval, so that those that are rotated on disk are used.
+func NewProvider(options api.IAMRolesAnywhereOptions, region string, httpClient aws.HTTPClient) *Provider {
+	endpoint := os.Getenv(endpointEnv)
+	if endpoint == "" {
+		endpoint = partitions.ForRegion(region).ServiceEndpoint(signingName, region)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Provider{
+		options:    options,
+		region:     region,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	chain, err := readCertificates(p.options.CertificatePath)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	key, err := readPrivateKey(p.options.PrivateKeyPath)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	body, err := json.Marshal(map[string]any{
+		"durationSeconds": durationSeconds,
+		"profileArn":      p.options.ProfileARN,
+		"roleArn":         p.options.RoleARN,
+		"trustAnchorArn":  p.options.TrustAnchorARN,
+	})
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+sessionsPath, bytes.NewReader(body))
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if err := signRequest(req, body, chain, key, p.region, p.now().UTC()); err != nil {
+		return aws.Credentials{}, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("failed to create session of IAM Roles Anywhere: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return aws.Credentials{}, fmt.Errorf("failed to create session of IAM Roles Anywhere (status code %d): %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return parseSession(data)
+}
+
+// parseSession returns the credentials of the response of CreateSession.
+func parseSession(data []byte) (aws.Credentials, error) {
+	var session struct {
+		CredentialSet []struct {
+			Credentials struct {
+				AccessKeyID     string `json:"accessKeyId"`
+				SecretAccessKey string `json:"secretAccessKey"`
+				SessionToken    string `json:"sessionToken"`
+				Expiration      string `json:"expiration"`
+			} `json:"credentials"`
+		} `json:"credentialSet"`
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return aws.Credentials{}, fmt.Errorf("failed to parse session of IAM Roles Anywhere: %w", err)
+	}
+	if len(session.CredentialSet) == 0 {
+		return aws.Credentials{}, fmt.Errorf("session of IAM Roles Anywhere has no credentials")
+	}
+	credentials := session.CredentialSet[0].Credentials
+	expires, err := time.Parse(time.RFC3339, credentials.Expiration)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("invalid expiration of session of IAM Roles Anywhere: %w", err)
+	}
+	return aws.Credentials{
+		AccessKeyID:     credentials.AccessKeyID,
+		SecretAccessKey: credentials.SecretAccessKey,
+		SessionToken:    credentials.SessionToken,
+		Source:          "IAMRolesAnywhere",
+		CanExpire:       true,
+		Expires:         expires,
+	}, nil
+}
+
+// signRequest signs a request with the private key of the certificate that
+// leads the chain, whose serial number identifies it in the credential scope
+// of the signature.
+func signRequest(req *http.Request, body []byte, chain []*x509.Certificate, key crypto.Signer, region string, now time.Time) error {
+	var algorithm string
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		algorithm = "AWS4-X509-RSA-SHA256"
+	case *ecdsa.PublicKey:
+		algorithm = "AWS4-X509-ECDSA-SHA256"
+	default:
+		return fmt.Errorf("unsupported type of private key %T, which must be RSA or ECDSA", key.Public())
+	}
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-X509", base64.StdEncoding.EncodeToString(chain[0].Raw))
+	if len(chain) > 1 {
+		var intermediates []string
+		for _, cert := range chain[1:] {
+			intermediates = append(intermediates, base64.StdEncoding.EncodeToString(cert.Raw))
+		}
+		req.Header.Set("X-Amz-X509-Chain", strings.Join(intermediates, ","))
+	}
+	canonicalRequest, signedHeaders := getCanonicalRequest(req, body)
+	scope := strings.Join([]string{now.Format(scopeDateFormat), region, signingName, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{algorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+	digest := sha256.Sum256([]byte(stringToSign))
+	signature, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("failed to sign request with private key: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, chain[0].SerialNumber.String(), scope, signedHeaders, hex.EncodeToString(signature)))
+	return nil
+}
+
+// getCanonicalRequest returns the canonical request of SigV4, which signs
+// every header of the request, and the names of the signed headers.
+func getCanonicalRequest(req *http.Request, body []byte) (string, string) {
+	var names []string
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var headers strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&headers, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return strings.Join([]string{req.Method, path, req.URL.RawQuery, headers.String(), signedHeaders, hashHex(body)}, "\n"), signedHeaders
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// readCertificates returns the certificates of a PEM file, the first of which
+// is that of the node and the rest its intermediate certificate authorities.
+func readCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %s: %w", path, err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no certificate in %s", path)
+	}
+	return chain, nil
+}
+
+// readPrivateKey returns the RSA or ECDSA private key of a PEM file, in the
+// PKCS #1, SEC 1 or PKCS #8 format.
+func readPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no private key in %s", path)
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type of private key %T in %s", key, path)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q in %s", block.Type, path)
+	}
+}
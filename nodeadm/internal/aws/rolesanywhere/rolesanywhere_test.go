@@ -0,0 +1,184 @@
+package rolesanywhere
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// writeCertificate writes a self-signed certificate of a key and the key
+// itself in PKCS #8, and returns their paths.
+func writeCertificate(t *testing.T, key crypto.Signer) (string, string) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1234567890),
+		Subject:      pkix.Name{CommonName: "hybrid-node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "server.pem")
+	keyPath := filepath.Join(dir, "server.key")
+	assert.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+func TestSignRequest(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	var tests = []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+	}{
+		{name: "rsa", key: rsaKey, algorithm: "AWS4-X509-RSA-SHA256"},
+		{name: "ecdsa", key: ecdsaKey, algorithm: "AWS4-X509-ECDSA-SHA256"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			certPath, keyPath := writeCertificate(t, test.key)
+			chain, err := readCertificates(certPath)
+			assert.NoError(t, err)
+			key, err := readPrivateKey(keyPath)
+			assert.NoError(t, err)
+			body := []byte(`{}`)
+			req, err := http.NewRequest(http.MethodPost, "https://rolesanywhere.us-west-2.amazonaws.com/sessions", bytes.NewReader(body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+			now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			assert.NoError(t, signRequest(req, body, chain, key, "us-west-2", now))
+
+			assert.Equal(t, "20250101T120000Z", req.Header.Get("X-Amz-Date"))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(chain[0].Raw), req.Header.Get("X-Amz-X509"))
+			prefix := test.algorithm + " Credential=1234567890/20250101/us-west-2/rolesanywhere/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-x509, Signature="
+			authorization := req.Header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(authorization, prefix), authorization)
+
+			req.Header.Del("Authorization")
+			canonicalRequest, _ := getCanonicalRequest(req, body)
+			stringToSign := strings.Join([]string{test.algorithm, "20250101T120000Z", "20250101/us-west-2/rolesanywhere/aws4_request", hashHex([]byte(canonicalRequest))}, "\n")
+			digest := sha256.Sum256([]byte(stringToSign))
+			signature, err := hex.DecodeString(strings.TrimPrefix(authorization, prefix))
+			assert.NoError(t, err)
+			switch public := test.key.Public().(type) {
+			case *rsa.PublicKey:
+				assert.NoError(t, rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature))
+			case *ecdsa.PublicKey:
+				assert.True(t, ecdsa.VerifyASN1(public, digest[:], signature))
+			}
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPath, keyPath := writeCertificate(t, key)
+	var input map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, sessionsPath, r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-X509-ECDSA-SHA256 Credential=1234567890/"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		w.Write([]byte(`{"credentialSet":[{"credentials":{"accessKeyId":"ASIA","secretAccessKey":"SECRET","sessionToken":"TOKEN","expiration":"2025-01-01T13:00:00Z"}}],"subjectArn":"arn:aws:rolesanywhere:us-west-2:123456789012:subject/abc"}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(endpointEnv, server.URL)
+	provider := NewProvider(api.IAMRolesAnywhereOptions{
+		TrustAnchorARN:  "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/abc",
+		ProfileARN:      "arn:aws:rolesanywhere:us-west-2:123456789012:profile/abc",
+		RoleARN:         "arn:aws:iam::123456789012:role/hybrid-node",
+		CertificatePath: certPath,
+		PrivateKeyPath:  keyPath,
+	}, "us-west-2", nil)
+	credentials, err := provider.Retrieve(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "ASIA", credentials.AccessKeyID)
+	assert.Equal(t, "SECRET", credentials.SecretAccessKey)
+	assert.Equal(t, "TOKEN", credentials.SessionToken)
+	assert.True(t, credentials.CanExpire)
+	assert.Equal(t, time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC), credentials.Expires)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/hybrid-node", input["roleArn"])
+	assert.Equal(t, float64(durationSeconds), input["durationSeconds"])
+}
+
+func TestRetrieveError(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	certPath, keyPath := writeCertificate(t, key)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Untrusted certificate. Insufficient certificate"}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(endpointEnv, server.URL)
+	provider := NewProvider(api.IAMRolesAnywhereOptions{CertificatePath: certPath, PrivateKeyPath: keyPath}, "us-west-2", nil)
+	_, err = provider.Retrieve(context.TODO())
+	assert.ErrorContains(t, err, "Untrusted certificate")
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Setenv(endpointEnv, "")
+	assert.Equal(t, "https://rolesanywhere.cn-north-1.amazonaws.com.cn", NewProvider(api.IAMRolesAnywhereOptions{}, "cn-north-1", nil).endpoint)
+	t.Setenv(endpointEnv, "https://rolesanywhere.example.com/")
+	assert.Equal(t, "https://rolesanywhere.example.com", NewProvider(api.IAMRolesAnywhereOptions{}, "us-west-2", nil).endpoint)
+}
+
+func TestReadPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	assert.NoError(t, err)
+	var tests = []struct {
+		name      string
+		block     *pem.Block
+		expectErr bool
+	}{
+		{name: "pkcs1", block: &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}},
+		{name: "sec1", block: &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}},
+		{name: "certificate", block: &pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a key")}, expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name)
+			assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(test.block), 0600))
+			_, err := readPrivateKey(path)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
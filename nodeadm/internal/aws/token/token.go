@@ -0,0 +1,194 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// CachePath is where the token that kubelet uses to authenticate to the
+	// cluster is cached.
+	// #nosec G101 //constant path, not credential
+	CachePath    = "/var/lib/nodeadm/token/cache.json"
+	cachePerm    = 0600
+	cacheDirPerm = 0700
+
+	// DefaultLeadTime is used when a lead time is not configured.
+	DefaultLeadTime = 5 * time.Minute
+	// DefaultMaxAttempts is used when a maximum number of attempts is not
+	// configured.
+	DefaultMaxAttempts = 10
+
+	// the cluster accepts a token for 15 minutes after it is signed, and the
+	// expiration reported to kubelet is a minute earlier to allow for clock
+	// skew, as with `aws eks get-token`.
+	tokenLifetime   = 14 * time.Minute
+	tokenPrefix     = "k8s-aws-v1."
+	clusterIDHeader = "x-k8s-aws-id"
+
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 20 * time.Second
+)
+
+// Options determine how a token is obtained.
+type Options struct {
+	// ClusterName is the name of the cluster, or its ID on a local cluster on
+	// an Outpost.
+	ClusterName string
+	Region      string
+	LeadTime    time.Duration
+	MaxAttempts int
+}
+
+// NewOptions returns the options of the token used by kubelet.
+func NewOptions(cfg *api.NodeConfig) Options {
+	opts := Options{
+		ClusterName: cfg.Spec.Cluster.Name,
+		Region:      cfg.Status.Instance.Region,
+		LeadTime:    DefaultLeadTime,
+		MaxAttempts: DefaultMaxAttempts,
+	}
+	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
+		opts.ClusterName = cfg.Spec.Cluster.ID
+	}
+	tokenCache := cfg.Spec.Kubelet.TokenCache
+	if tokenCache.LeadTime != nil {
+		opts.LeadTime = tokenCache.LeadTime.Duration
+	}
+	if tokenCache.MaxAttempts > 0 {
+		opts.MaxAttempts = tokenCache.MaxAttempts
+	}
+	return opts
+}
+
+// Token is a bearer token for the cluster.
+type Token struct {
+	ClusterName string    `json:"clusterName"`
+	Token       string    `json:"token"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// presignFunc returns a token for the cluster.
+type presignFunc func(ctx context.Context, opts Options) (string, error)
+
+// Get returns the cached token for the cluster, unless it expires within the
+// lead time, in which case a new token is pre-signed and cached.
+func Get(ctx context.Context, opts Options) (*Token, bool, error) {
+	return get(ctx, CachePath, opts, Presign, time.Now)
+}
+
+func get(ctx context.Context, cachePath string, opts Options, presign presignFunc, now func() time.Time) (*Token, bool, error) {
+	if cached, err := readCache(cachePath); err != nil {
+		// a corrupt cache is replaced rather than failing authentication
+		zap.L().Warn("Failed to read cached token", zap.String("path", cachePath), zap.Error(err))
+	} else if cached != nil && cached.ClusterName == opts.ClusterName && cached.Expiration.Sub(now()) > opts.LeadTime {
+		return cached, true, nil
+	}
+	var token string
+	err := util.NewRetrier(
+		util.WithRetryCount(opts.MaxAttempts),
+		util.WithBackoffExponentialJitter(initialBackoff, maxBackoff),
+	).Retry(ctx, func() error {
+		var err error
+		token, err = presign(ctx, opts)
+		if err != nil {
+			zap.L().Warn("Failed to pre-sign token", zap.Error(err))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to pre-sign token for cluster %s: %w", opts.ClusterName, err)
+	}
+	t := &Token{
+		ClusterName: opts.ClusterName,
+		Token:       token,
+		Expiration:  now().Add(tokenLifetime).UTC().Truncate(time.Second),
+	}
+	if err := writeCache(cachePath, t); err != nil {
+		return nil, false, err
+	}
+	return t, false, nil
+}
+
+// Presign signs a request to the GetCallerIdentity API of STS with the
+// node's credentials, which the cluster makes to authenticate the node. The
+// request is signed without being sent, so STS is not called.
+func Presign(ctx context.Context, opts Options) (string, error) {
+	awsConfig, err := awsconfig.Load(ctx, config.WithRegion(opts.Region))
+	if err != nil {
+		return "", err
+	}
+	// the endpoint is resolved by the client, which signs for the regional
+	// endpoint of STS in the partition of the region, or its FIPS or
+	// dual-stack variant, unless it is overridden
+	presignClient := sts.NewPresignClient(sts.NewFromConfig(awsConfig))
+	request, err := presignClient.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(po *sts.PresignOptions) {
+		po.ClientOptions = append(po.ClientOptions, sts.WithAPIOptions(
+			smithyhttp.AddHeaderValue(clusterIDHeader, opts.ClusterName),
+			smithyhttp.AddHeaderValue("X-Amz-Expires", "60"),
+		))
+	})
+	if err != nil {
+		return "", err
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(request.URL)), nil
+}
+
+func readCache(cachePath string) (*Token, error) {
+	data, err := os.ReadFile(cachePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// writeCache replaces the cache atomically, since kubelet may request tokens
+// concurrently. The cache is not recorded in the manifest, as it changes
+// every few minutes.
+func writeCache(cachePath string, token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), cacheDirPerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(cachePerm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cachePath)
+}
@@ -0,0 +1,91 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts := Options{ClusterName: "my-cluster", Region: "us-west-2", LeadTime: 5 * time.Minute, MaxAttempts: 3}
+
+	var tests = []struct {
+		name           string
+		cached         *Token
+		presignErrors  int
+		expectedToken  string
+		expectedCached bool
+		expectErr      bool
+	}{
+		{
+			name:          "empty cache",
+			expectedToken: "k8s-aws-v1.new",
+		},
+		{
+			name:           "valid token",
+			cached:         &Token{ClusterName: "my-cluster", Token: "k8s-aws-v1.cached", Expiration: now.Add(6 * time.Minute)},
+			expectedToken:  "k8s-aws-v1.cached",
+			expectedCached: true,
+		},
+		{
+			name:          "token within lead time",
+			cached:        &Token{ClusterName: "my-cluster", Token: "k8s-aws-v1.cached", Expiration: now.Add(4 * time.Minute)},
+			expectedToken: "k8s-aws-v1.new",
+		},
+		{
+			name:          "token of another cluster",
+			cached:        &Token{ClusterName: "other-cluster", Token: "k8s-aws-v1.cached", Expiration: now.Add(10 * time.Minute)},
+			expectedToken: "k8s-aws-v1.new",
+		},
+		{
+			name:          "retried",
+			presignErrors: 2,
+			expectedToken: "k8s-aws-v1.new",
+		},
+		{
+			name:          "attempts exhausted",
+			presignErrors: 3,
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cachePath := filepath.Join(t.TempDir(), "token", "cache.json")
+			if test.cached != nil {
+				assert.NoError(t, writeCache(cachePath, test.cached))
+			}
+			attempts := 0
+			presign := func(ctx context.Context, opts Options) (string, error) {
+				attempts++
+				if attempts <= test.presignErrors {
+					return "", fmt.Errorf("throttled")
+				}
+				return "k8s-aws-v1.new", nil
+			}
+			token, cached, err := get(context.Background(), cachePath, opts, presign, func() time.Time { return now })
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedToken, token.Token)
+			assert.Equal(t, test.expectedCached, cached)
+			if !test.expectedCached {
+				assert.Equal(t, now.Add(tokenLifetime), token.Expiration)
+				stored, err := readCache(cachePath)
+				assert.NoError(t, err)
+				assert.Equal(t, token, stored)
+				info, err := os.Stat(cachePath)
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(cachePerm), info.Mode().Perm())
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	bundleFilePerm = 0644
+	// collectionErrorsName is the file in the bundle that lists the
+	// diagnostics which could not be collected.
+	collectionErrorsName = "errors.txt"
+)
+
+// passwordPattern matches the passwords of TOML configs, such as the registry
+// credentials in the config of containerd, which are redacted from the bundle.
+var passwordPattern = regexp.MustCompile(`(?m)^(\s*password\s*=\s*).*$`)
+
+// Bundle is a gzipped tarball of diagnostics collected from the node. The
+// failure to collect a single diagnostic is recorded in the bundle, rather
+// than returned, so that the remaining diagnostics are still collected.
+type Bundle struct {
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	modTime time.Time
+	errs    []string
+}
+
+// New creates a Bundle that is written to w.
+func New(w io.Writer) *Bundle {
+	gz := gzip.NewWriter(w)
+	return &Bundle{
+		gz:      gz,
+		tw:      tar.NewWriter(gz),
+		modTime: time.Now(),
+	}
+}
+
+// AddFile adds a file with the given content to the bundle, with any
+// passwords redacted.
+func (b *Bundle) AddFile(name string, content []byte) error {
+	content = passwordPattern.ReplaceAll(content, []byte(`${1}"REDACTED"`))
+	header := &tar.Header{
+		Name:    name,
+		Mode:    bundleFilePerm,
+		Size:    int64(len(content)),
+		ModTime: b.modTime,
+	}
+	if err := b.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(content)
+	return err
+}
+
+// AddPath adds a file, or every regular file beneath a directory, from the
+// filesystem to the bundle under name. Paths that do not exist are skipped.
+func (b *Bundle) AddPath(name string, srcPath string) error {
+	err := filepath.WalkDir(srcPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(srcPath, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			b.recordError(path.Join(name, rel), err)
+			return nil
+		}
+		return b.AddFile(path.Join(name, filepath.ToSlash(rel)), content)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		zap.L().Debug("Skipping path that does not exist", zap.String("path", srcPath))
+		return nil
+	}
+	return err
+}
+
+// AddCommand adds the combined output of a command to the bundle under name.
+// The command is killed once the timeout has elapsed, and a command that
+// fails or is not installed is recorded as an error.
+func (b *Bundle) AddCommand(ctx context.Context, name string, timeout time.Duration, command string, args ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		b.recordError(name, fmt.Errorf("%s: %w", strings.Join(cmd.Args, " "), err))
+		if output.Len() == 0 {
+			return nil
+		}
+	}
+	return b.AddFile(name, output.Bytes())
+}
+
+func (b *Bundle) recordError(name string, err error) {
+	zap.L().Warn("Failed to collect diagnostic", zap.String("name", name), zap.Error(err))
+	b.errs = append(b.errs, fmt.Sprintf("%s: %v", name, err))
+}
+
+// Close writes the collection errors to the bundle and flushes it.
+func (b *Bundle) Close() error {
+	if len(b.errs) > 0 {
+		if err := b.AddFile(collectionErrorsName, []byte(strings.Join(b.errs, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return b.gz.Close()
+}
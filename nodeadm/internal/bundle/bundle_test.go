@@ -0,0 +1,59 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readBundle(t *testing.T, data []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		assert.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "config", "config.d"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config", "config.toml"), []byte("version = 2\n\n[plugins.'io.containerd.grpc.v1.cri'.registry.configs.'registry.example.com'.auth]\nusername = 'robot'\npassword = 'hunter2'\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config", "config.d", "00-override.toml"), []byte("[grpc]"), 0644))
+
+	var buf bytes.Buffer
+	b := New(&buf)
+	ctx := context.Background()
+	assert.NoError(t, b.AddFile("file.txt", []byte("content")))
+	assert.NoError(t, b.AddPath("containerd", filepath.Join(dir, "config")))
+	assert.NoError(t, b.AddPath("missing", filepath.Join(dir, "missing")))
+	assert.NoError(t, b.AddCommand(ctx, "echo.txt", time.Second, "echo", "hello"))
+	assert.NoError(t, b.AddCommand(ctx, "not-installed.txt", time.Second, filepath.Join(dir, "not-installed")))
+	assert.NoError(t, b.Close())
+
+	files := readBundle(t, buf.Bytes())
+	assert.Equal(t, "content", files["file.txt"])
+	assert.Equal(t, "version = 2\n\n[plugins.'io.containerd.grpc.v1.cri'.registry.configs.'registry.example.com'.auth]\nusername = 'robot'\npassword = \"REDACTED\"\n", files["containerd/config.toml"])
+	assert.Equal(t, "[grpc]", files["containerd/config.d/00-override.toml"])
+	assert.Equal(t, "hello\n", files["echo.txt"])
+	assert.NotContains(t, files, "not-installed.txt")
+	assert.Contains(t, files[collectionErrorsName], "not-installed.txt")
+	assert.Len(t, files, 5)
+}
@@ -0,0 +1,176 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+const (
+	commandTimeout = 75 * time.Second
+	// journal entries older than this are not collected
+	journalSince = "-10d"
+)
+
+// the units whose journals are collected
+var journalUnits = []string{
+	"nodeadm-config",
+	"nodeadm-run",
+	"nodeadm-agent",
+	"nodeadm-deregister",
+	"nodeadm-pressure-monitor",
+	"nodeadm-image-retention",
+	"nodeadm-kubelet-config",
+	"soci-snapshotter",
+	"nodeadm-credentials-refresh",
+	"pod-log-forwarder",
+	"containerd",
+	"kubelet",
+	"chronyd",
+	"cloud-final",
+}
+
+// the files and directories that are collected, which are configuration
+// files written by nodeadm or logs of the components on the node. user data is
+// not collected, as it may contain credentials.
+var paths = []struct {
+	name string
+	path string
+}{
+	{name: "nodeadm/manifest.json", path: manifest.ManifestPath},
+	{name: "nodeadm/network-capture", path: system.NetworkCaptureDir},
+	{name: "nodeadm/etc", path: "/etc/eks/nodeadm"},
+	{name: "kubelet/config", path: "/etc/kubernetes/kubelet"},
+	{name: "kubelet/kubeconfig", path: kubelet.KubeconfigPath},
+	{name: "kubelet/environment", path: "/etc/eks/kubelet/environment"},
+	{name: "kubelet/image-credential-provider", path: "/etc/eks/image-credential-provider"},
+	{name: "containerd/config", path: "/etc/containerd"},
+	{name: "cni/net.d", path: "/etc/cni/net.d"},
+	{name: "networking/resolv.conf", path: "/etc/resolv.conf"},
+	{name: "var_log/aws-routed-eni", path: "/var/log/aws-routed-eni"},
+	{name: "var_log/cloud-init.log", path: "/var/log/cloud-init.log"},
+	{name: "var_log/cloud-init-output.log", path: "/var/log/cloud-init-output.log"},
+}
+
+// the output of these commands is collected
+var commands = []struct {
+	name    string
+	command []string
+}{
+	{name: "kernel/uname.txt", command: []string{"uname", "-a"}},
+	{name: "kernel/dmesg.txt", command: []string{"dmesg", "--ctime"}},
+	{name: "kernel/modules.txt", command: []string{"lsmod"}},
+	{name: "system/services.txt", command: []string{"systemctl", "list-units", "--all", "--no-pager"}},
+	{name: "system/ps.txt", command: []string{"ps", "fauxwww", "--headers"}},
+	{name: "system/memory.txt", command: []string{"free", "--mebi"}},
+	{name: "system/chrony-tracking.txt", command: []string{"chronyc", "tracking"}},
+	{name: "system/chrony-sources.txt", command: []string{"chronyc", "sources", "-v"}},
+	{name: "storage/mounts.txt", command: []string{"findmnt", "--list"}},
+	{name: "storage/lsblk.txt", command: []string{"lsblk", "--output", "NAME,KNAME,SIZE,TYPE,FSTYPE,MOUNTPOINT"}},
+	{name: "storage/df.txt", command: []string{"df", "--human-readable"}},
+	{name: "storage/df-inodes.txt", command: []string{"df", "--inodes"}},
+	{name: "networking/addresses.txt", command: []string{"ip", "address", "show"}},
+	{name: "networking/routes.txt", command: []string{"ip", "route", "show", "table", "all"}},
+	{name: "networking/routes-ipv6.txt", command: []string{"ip", "-6", "route", "show", "table", "all"}},
+	{name: "networking/rules.txt", command: []string{"ip", "rule", "show"}},
+	{name: "networking/sockets.txt", command: []string{"ss", "--all", "--numeric", "--processes"}},
+	{name: "networking/iptables-save.txt", command: []string{"iptables-save"}},
+	{name: "networking/ip6tables-save.txt", command: []string{"ip6tables-save"}},
+	{name: "networking/nft-ruleset.txt", command: []string{"nft", "list", "ruleset"}},
+	{name: "containerd/config-dump.toml", command: []string{"containerd", "config", "dump"}},
+	{name: "containerd/version.txt", command: []string{"ctr", "version"}},
+	{name: "containerd/images.txt", command: []string{"ctr", "--namespace", "k8s.io", "images", "list"}},
+	{name: "containerd/containers.txt", command: []string{"ctr", "--namespace", "k8s.io", "containers", "list"}},
+	{name: "containerd/tasks.txt", command: []string{"ctr", "--namespace", "k8s.io", "tasks", "list"}},
+	{name: "kubelet/version.txt", command: []string{"kubelet", "--version"}},
+}
+
+// the instance metadata that is collected, which excludes credentials and
+// user data.
+var imdsProperties = []string{
+	"ami-id",
+	"instance-id",
+	"instance-type",
+	"local-hostname",
+	"local-ipv4",
+	"mac",
+	"placement/availability-zone",
+	"placement/region",
+	"services/domain",
+}
+
+// Collect gathers the diagnostics of the node into the bundle. The instance
+// metadata is only collected when imdsClient is not nil.
+func Collect(ctx context.Context, b *Bundle, imdsClient *imds.Client) error {
+	for _, unit := range journalUnits {
+		zap.L().Info("Collecting journal..", zap.String("unit", unit))
+		if err := b.AddCommand(ctx, fmt.Sprintf("journal/%s.log", unit), commandTimeout, "journalctl", "--no-pager", "--unit", unit, "--since", journalSince); err != nil {
+			return err
+		}
+	}
+	for _, p := range paths {
+		zap.L().Info("Collecting files..", zap.String("path", p.path))
+		if err := b.AddPath(p.name, p.path); err != nil {
+			return err
+		}
+	}
+	for _, c := range commands {
+		zap.L().Info("Collecting command output..", zap.Strings("command", c.command))
+		if err := b.AddCommand(ctx, c.name, commandTimeout, c.command[0], c.command[1:]...); err != nil {
+			return err
+		}
+	}
+	if imdsClient != nil {
+		zap.L().Info("Collecting instance metadata..")
+		return collectInstanceMetadata(ctx, b, imdsClient)
+	}
+	return nil
+}
+
+func collectInstanceMetadata(ctx context.Context, b *Bundle, client *imds.Client) error {
+	if doc, err := client.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{}); err != nil {
+		b.recordError("imds/instance-identity-document.json", err)
+	} else if err := addJSON(b, "imds/instance-identity-document.json", doc.InstanceIdentityDocument); err != nil {
+		return err
+	}
+	metadata := make(map[string]string)
+	for _, property := range imdsProperties {
+		value, err := getMetadata(ctx, client, property)
+		if err != nil {
+			b.recordError("imds/meta-data/"+property, err)
+			continue
+		}
+		metadata[property] = value
+	}
+	return addJSON(b, "imds/meta-data.json", metadata)
+}
+
+func getMetadata(ctx context.Context, client *imds.Client, property string) (string, error) {
+	res, err := client.GetMetadata(ctx, &imds.GetMetadataInput{Path: property})
+	if err != nil {
+		return "", err
+	}
+	defer res.Content.Close()
+	value, err := io.ReadAll(res.Content)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func addJSON(b *Bundle, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	return b.AddFile(name, data)
+}
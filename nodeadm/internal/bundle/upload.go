@@ -0,0 +1,43 @@
+package bundle
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// UploadOptions determine where a bundle is uploaded in S3, and how it is
+// encrypted at rest.
+type UploadOptions struct {
+	Bucket string
+	Key    string
+	// ServerSideEncryption is either `AES256` or `aws:kms`.
+	ServerSideEncryption types.ServerSideEncryption
+	// KMSKeyID is the key used when ServerSideEncryption is `aws:kms`. The
+	// default key of the account is used when it is empty.
+	KMSKeyID string
+}
+
+// Upload puts the bundle at bundlePath into S3.
+func Upload(ctx context.Context, client *s3.Client, bundlePath string, opts UploadOptions) error {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	input := &s3.PutObjectInput{
+		Bucket:               aws.String(opts.Bucket),
+		Key:                  aws.String(opts.Key),
+		Body:                 file,
+		ContentType:          aws.String("application/gzip"),
+		ServerSideEncryption: opts.ServerSideEncryption,
+	}
+	if opts.ServerSideEncryption == types.ServerSideEncryptionAwsKms && opts.KMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(opts.KMSKeyID)
+	}
+	_, err = client.PutObject(ctx, input)
+	return err
+}
@@ -12,7 +12,10 @@ type CommandContainer interface {
 	AsCommand() Command
 }
 
-var _ Command = &cmdContainer{}
+var (
+	_ Command  = &cmdContainer{}
+	_ Reporter = &cmdContainer{}
+)
 
 type cmdContainer struct {
 	cmd  *flaggy.Subcommand
@@ -46,6 +49,16 @@ func (c *cmdContainer) Run(log *zap.Logger, opts *GlobalOptions) error {
 	return nil
 }
 
+// Result returns the details of the subcommand that was run, if it has any.
+func (c *cmdContainer) Result() any {
+	for _, cmd := range c.cmds {
+		if reporter, ok := cmd.(Reporter); ok && cmd.Flaggy().Used {
+			return reporter.Result()
+		}
+	}
+	return nil
+}
+
 func (c *cmdContainer) AsCommand() Command {
 	return c
 }
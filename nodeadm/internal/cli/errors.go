@@ -1,6 +1,27 @@
 package cli
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrMustRunAsRoot is returned when a command must be run as root.
 var ErrMustRunAsRoot = fmt.Errorf("must run as root")
+
+// ExitCoder is implemented by errors that classify the failure of a command
+// with an exit code, so that automation can act on the cause of a failure
+// without parsing logs.
+type ExitCoder interface {
+	error
+	ExitCode() int
+}
+
+// ExitCode returns the exit code of a command that failed with err, which is
+// that of the first ExitCoder in the chain of err, or 1 otherwise.
+func ExitCode(err error) int {
+	var exitCoder ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+	return 1
+}
@@ -5,14 +5,17 @@ import "github.com/integrii/flaggy"
 type GlobalOptions struct {
 	ConfigSource    string
 	DevelopmentMode bool
+	Output          OutputFormat
 }
 
 func NewGlobalOptions() *GlobalOptions {
 	opts := GlobalOptions{
 		ConfigSource:    "imds://user-data",
 		DevelopmentMode: false,
+		Output:          OutputText,
 	}
-	flaggy.String(&opts.ConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [imds, file].")
+	flaggy.String(&opts.ConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [imds, file, s3, https].")
 	flaggy.Bool(&opts.DevelopmentMode, "d", "development", "Enable development mode for logging.")
+	flaggy.String((*string)(&opts.Output), "", "output", "Format of the command's result, either text or json. The json result is written to stdout.")
 	return &opts
 }
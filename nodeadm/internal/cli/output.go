@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/integrii/flaggy"
+)
+
+type OutputFormat string
+
+const (
+	// OutputText only reports the progress and result of a command in its
+	// logs, which are meant to be read by people.
+	OutputText OutputFormat = "text"
+	// OutputJSON additionally writes the result of a command to stdout as a
+	// single JSON document, which is meant to be read by automation. Logs,
+	// and the output of the commands that nodeadm runs, are still written to
+	// stderr.
+	OutputJSON OutputFormat = "json"
+)
+
+// ResultVersion is the version of the schema of Result. Fields may be added
+// to a version, but are never removed or changed.
+const ResultVersion = "v1"
+
+type ResultStatus string
+
+const (
+	ResultStatusSuccess ResultStatus = "success"
+	ResultStatusFailure ResultStatus = "failure"
+)
+
+// Result is the structured outcome of a command.
+type Result struct {
+	Version string       `json:"version"`
+	Command string       `json:"command"`
+	Status  ResultStatus `json:"status"`
+	Error   string       `json:"error,omitempty"`
+	// ExitCode is the exit code of a command that failed, which classifies
+	// the cause of some failures.
+	ExitCode int `json:"exitCode,omitempty"`
+	// Details are specific to the command, such as the changes applied by
+	// init. Details may be present when a command fails, to describe how far
+	// it got.
+	Details any `json:"details,omitempty"`
+}
+
+// Reporter is implemented by commands that have details to include in their
+// Result.
+type Reporter interface {
+	Result() any
+}
+
+// NewResult builds the Result of running a command.
+func NewResult(cmd Command, err error) Result {
+	result := Result{
+		Version: ResultVersion,
+		Command: commandName(cmd.Flaggy()),
+		Status:  ResultStatusSuccess,
+	}
+	if err != nil {
+		result.Status = ResultStatusFailure
+		result.Error = err.Error()
+		result.ExitCode = ExitCode(err)
+	}
+	if reporter, ok := cmd.(Reporter); ok {
+		result.Details = reporter.Result()
+	}
+	return result
+}
+
+// WriteResult writes the result as an indented JSON document.
+func WriteResult(w io.Writer, result Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// commandName joins the names of the subcommands that were used, such as
+// `debug bundle`.
+func commandName(cmd *flaggy.Subcommand) string {
+	names := []string{cmd.Name}
+	for sub := cmd; sub != nil; {
+		var used *flaggy.Subcommand
+		for _, child := range sub.Subcommands {
+			if child.Used {
+				used = child
+				names = append(names, child.Name)
+				break
+			}
+		}
+		sub = used
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeCommand struct {
+	cmd     *flaggy.Subcommand
+	details any
+}
+
+func (c *fakeCommand) Run(*zap.Logger, *GlobalOptions) error { return nil }
+func (c *fakeCommand) Flaggy() *flaggy.Subcommand            { return c.cmd }
+func (c *fakeCommand) Result() any                           { return c.details }
+
+func TestNewResult(t *testing.T) {
+	container := NewCommandContainer("debug", "")
+	bundle := &fakeCommand{cmd: flaggy.NewSubcommand("bundle"), details: map[string]string{"path": "/var/log/bundle.tar.gz"}}
+	container.AddCommand(bundle)
+	bundle.cmd.Used = true
+
+	result := NewResult(container.AsCommand(), nil)
+	assert.Equal(t, Result{
+		Version: ResultVersion,
+		Command: "debug bundle",
+		Status:  ResultStatusSuccess,
+		Details: map[string]string{"path": "/var/log/bundle.tar.gz"},
+	}, result)
+
+	result = NewResult(bundle, errors.New("must run as root"))
+	assert.Equal(t, ResultStatusFailure, result.Status)
+	assert.Equal(t, "must run as root", result.Error)
+	assert.Equal(t, 1, result.ExitCode)
+
+	result = NewResult(bundle, fmt.Errorf("failed to run daemon kubelet: %w", exitCodeError{}))
+	assert.Equal(t, 13, result.ExitCode)
+}
+
+type exitCodeError struct{}
+
+func (exitCodeError) Error() string { return "unauthorized" }
+func (exitCodeError) ExitCode() int { return 13 }
+
+func TestWriteResult(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteResult(&buf, Result{Version: ResultVersion, Command: "config check", Status: ResultStatusSuccess}))
+	assert.JSONEq(t, `{"version":"v1","command":"config check","status":"success"}`, buf.String())
+}
+
+func TestProgressWriter(t *testing.T) {
+	var buf bytes.Buffer
+	progress := NewProgressWriter(&buf, OutputJSON)
+	begin := NewProgressRecord("config", ProgressEventBegin, 0, nil)
+	assert.Empty(t, begin.Duration)
+	assert.NoError(t, progress.Write(begin))
+	end := NewProgressRecord("config", ProgressEventEnd, 1500*time.Millisecond, errors.New("kubelet failed"))
+	assert.NoError(t, progress.Write(end))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		var record ProgressRecord
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+		assert.Equal(t, "config", record.Phase)
+		assert.Equal(t, ProgressEventEnd, record.Event)
+		assert.Equal(t, "1.5s", record.Duration)
+		assert.Equal(t, "kubelet failed", record.Error)
+	}
+
+	buf.Reset()
+	progress = NewProgressWriter(&buf, OutputText)
+	assert.NoError(t, progress.Write(end))
+	assert.Contains(t, buf.String(), ` phase=config event=end duration=1.5s error="kubelet failed"`)
+}
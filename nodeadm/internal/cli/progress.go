@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type ProgressEvent string
+
+const (
+	ProgressEventBegin ProgressEvent = "begin"
+	ProgressEventEnd   ProgressEvent = "end"
+	ProgressEventSkip  ProgressEvent = "skip"
+)
+
+// ProgressRecord is a phase of a command that began, ended or was skipped.
+// Its schema is versioned along with Result.
+type ProgressRecord struct {
+	Version string        `json:"version"`
+	Phase   string        `json:"phase"`
+	Event   ProgressEvent `json:"event"`
+	Time    time.Time     `json:"time"`
+	// Duration is how long a phase that ended took.
+	Duration string `json:"duration,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// NewProgressRecord builds the record of an event of a phase. The duration
+// and error are only recorded when the phase ended.
+func NewProgressRecord(phase string, event ProgressEvent, duration time.Duration, err error) ProgressRecord {
+	record := ProgressRecord{
+		Version: ResultVersion,
+		Phase:   phase,
+		Event:   event,
+		Time:    time.Now().UTC(),
+	}
+	if event == ProgressEventEnd {
+		record.Duration = duration.String()
+		if err != nil {
+			record.Error = err.Error()
+		}
+	}
+	return record
+}
+
+// ProgressWriter streams the progress of a command as it runs, one record per
+// line, so that automation can follow it before the command exits. In the
+// json format each line is a JSON document. Records are not written to stdout
+// with the Result, so that the Result can be parsed on its own.
+type ProgressWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format OutputFormat
+}
+
+// NewProgressWriter constructs a ProgressWriter that writes records in the
+// format of the output of the command.
+func NewProgressWriter(w io.Writer, format OutputFormat) *ProgressWriter {
+	return &ProgressWriter{w: w, format: format}
+}
+
+// Write writes a record as a single line.
+func (p *ProgressWriter) Write(record ProgressRecord) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.format == OutputJSON {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(data))
+		return err
+	}
+	line := fmt.Sprintf("%s phase=%s event=%s", record.Time.Format(time.RFC3339), record.Phase, record.Event)
+	if record.Duration != "" {
+		line += " duration=" + record.Duration
+	}
+	if record.Error != "" {
+		line += fmt.Sprintf(" error=%q", record.Error)
+	}
+	_, err := fmt.Fprintln(p.w, line)
+	return err
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// IsRunningAsRoot returns true if the current process runs with the elevated
+// token of an administrator, since Windows has no user with UID 0.
+func IsRunningAsRoot() (bool, error) {
+	return windows.GetCurrentProcessToken().IsElevated(), nil
+}
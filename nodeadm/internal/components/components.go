@@ -0,0 +1,338 @@
+// Package components verifies the versions of the components of the node,
+// such as kubelet and containerd, and upgrades them to the versions that are
+// pinned in the NodeConfig.
+package components
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/environment"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/signature"
+)
+
+const signatureSuffix = ".sig"
+
+// ErrInvalidSignature is returned when an artifact is not signed by the public
+// key of the configuration.
+var ErrInvalidSignature = signature.ErrInvalidSignature
+
+// component is a set of artifacts that are installed together.
+type component struct {
+	name      string
+	artifacts []artifact
+	// versionCommand prints the installed version, and may exit with an error
+	// once it has
+	versionCommand []string
+	// daemon is restarted once the component is upgraded
+	daemon string
+	pinned func(*api.ComponentsOptions) string
+}
+
+// artifact is a file of the repository, which is installed at path. The files
+// of an archive are extracted into the directory at path.
+type artifact struct {
+	name    string
+	path    string
+	archive bool
+}
+
+var defaultComponents = []component{
+	{
+		name:           "kubelet",
+		artifacts:      []artifact{{name: "kubelet", path: "/usr/bin/kubelet"}},
+		versionCommand: []string{"/usr/bin/kubelet", "--version"},
+		daemon:         "kubelet",
+		pinned:         func(c *api.ComponentsOptions) string { return c.Kubelet },
+	},
+	{
+		name: "containerd",
+		artifacts: []artifact{
+			{name: "containerd", path: "/usr/bin/containerd"},
+			{name: "containerd-shim-runc-v2", path: "/usr/bin/containerd-shim-runc-v2"},
+		},
+		versionCommand: []string{"/usr/bin/containerd", "--version"},
+		daemon:         "containerd",
+		pinned:         func(c *api.ComponentsOptions) string { return c.Containerd },
+	},
+	{
+		name:           "runc",
+		artifacts:      []artifact{{name: "runc", path: "/usr/sbin/runc"}},
+		versionCommand: []string{"/usr/sbin/runc", "--version"},
+		pinned:         func(c *api.ComponentsOptions) string { return c.Runc },
+	},
+	{
+		name:      "cni-plugins",
+		artifacts: []artifact{{name: "cni-plugins.tgz", path: "/opt/cni/bin", archive: true}},
+		// a plugin prints its version and exits with an error when it is run
+		// without a CNI command
+		versionCommand: []string{"/opt/cni/bin/loopback"},
+		pinned:         func(c *api.ComponentsOptions) string { return c.CNIPlugins },
+	},
+}
+
+var versionPattern = regexp.MustCompile(`v?[0-9]+\.[0-9]+\.[0-9]+`)
+
+// Status is the version of a pinned component.
+type Status struct {
+	Name   string `json:"name"`
+	Pinned string `json:"pinned"`
+	// Installed is empty when the component is not installed
+	Installed string `json:"installed,omitempty"`
+	Upgraded  bool   `json:"upgraded"`
+}
+
+// Current returns whether the installed version is the pinned version.
+func (s *Status) Current() bool {
+	return s.Installed != "" && coreVersion(s.Installed) == coreVersion(s.Pinned)
+}
+
+// Upgrader installs the pinned versions of the components.
+type Upgrader struct {
+	components []component
+	arch       string
+	fetch      func(ctx context.Context, url string) ([]byte, error)
+}
+
+func NewUpgrader() *Upgrader {
+	return &Upgrader{
+		components: defaultComponents,
+		arch:       string(environment.Current().Architecture),
+		fetch: func(ctx context.Context, url string) ([]byte, error) {
+			return download.Fetch(ctx, url)
+		},
+	}
+}
+
+// Verify returns the status of the components whose versions are pinned.
+func (u *Upgrader) Verify(cfg *api.ComponentsOptions) ([]Status, error) {
+	statuses := []Status{}
+	for _, c := range u.components {
+		pinned := c.pinned(cfg)
+		if pinned == "" {
+			continue
+		}
+		installed, err := c.installedVersion()
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, Status{Name: c.name, Pinned: pinned, Installed: installed})
+	}
+	return statuses, nil
+}
+
+// Upgrade installs the pinned versions of the components whose installed
+// versions differ, and restarts the running daemons of those that were
+// upgraded. The components are swapped as one, so that every file is restored
+// when any of them cannot be installed, reports another version once it is,
+// or its daemon does not run again; the restarted daemons are then restarted
+// on the previous versions.
+func (u *Upgrader) Upgrade(ctx context.Context, cfg *api.ComponentsOptions, daemonManager daemon.DaemonManager) ([]Status, error) {
+	statuses, err := u.Verify(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var publicKey crypto.PublicKey
+	var staged []stagedFile
+	var upgrades []int
+	for i := range statuses {
+		status := &statuses[i]
+		if status.Current() {
+			zap.L().Info("Component is at its pinned version", zap.String("name", status.Name), zap.String("version", status.Installed))
+			continue
+		}
+		if publicKey == nil {
+			if publicKey, err = signature.ParsePublicKey(cfg.PublicKey); err != nil {
+				return statuses, err
+			}
+		}
+		zap.L().Info("Downloading component..", zap.String("name", status.Name), zap.String("installed", status.Installed), zap.String("pinned", status.Pinned))
+		files, err := u.stage(ctx, u.component(status.Name), cfg.Repository, status.Pinned, publicKey)
+		if err != nil {
+			return statuses, fmt.Errorf("failed to download %s %s: %w", status.Name, status.Pinned, err)
+		}
+		staged = append(staged, files...)
+		upgrades = append(upgrades, i)
+	}
+	if len(upgrades) == 0 {
+		return statuses, nil
+	}
+
+	var s swap
+	for _, file := range staged {
+		zap.L().Info("Installing file..", zap.String("path", file.path))
+		if err := s.install(file); err != nil {
+			return statuses, errors.Join(err, s.rollback())
+		}
+	}
+	installed := make([]string, len(upgrades))
+	for j, i := range upgrades {
+		status := &statuses[i]
+		version, err := u.component(status.Name).installedVersion()
+		if err == nil && coreVersion(version) != coreVersion(status.Pinned) {
+			err = fmt.Errorf("%s reports version %q rather than %q once it is installed", status.Name, version, status.Pinned)
+		}
+		if err != nil {
+			zap.L().Error("Rolling back components..", zap.Error(err))
+			return statuses, errors.Join(err, s.rollback())
+		}
+		installed[j] = version
+	}
+	// the daemons are restarted before the swap is committed, so that a
+	// version that does not run is rolled back while its backup remains
+	var restarted []string
+	for _, i := range upgrades {
+		name := u.component(statuses[i].Name).daemon
+		if name == "" {
+			continue
+		}
+		wasRestarted, err := restartDaemon(daemonManager, name)
+		if wasRestarted {
+			restarted = append(restarted, name)
+		}
+		if err != nil {
+			zap.L().Error("Rolling back components..", zap.Error(err))
+			err = errors.Join(err, s.rollback())
+			for _, name := range restarted {
+				zap.L().Info("Restarting daemon to run the previous version..", zap.String("name", name))
+				err = errors.Join(err, daemonManager.RestartDaemon(name))
+			}
+			return statuses, err
+		}
+	}
+	for j, i := range upgrades {
+		statuses[i].Installed = installed[j]
+		statuses[i].Upgraded = true
+	}
+	return statuses, s.commit()
+}
+
+// restartDaemon restarts a running daemon so that it runs the installed
+// version, and returns whether it was restarted. Daemons that are not running
+// use the installed version once they are started.
+func restartDaemon(daemonManager daemon.DaemonManager, name string) (bool, error) {
+	status, err := daemonManager.GetDaemonStatus(name)
+	if err != nil {
+		return false, err
+	}
+	if status != daemon.DaemonStatusRunning {
+		return false, nil
+	}
+	zap.L().Info("Restarting daemon to run the upgraded version..", zap.String("name", name))
+	if err := daemonManager.RestartDaemon(name); err != nil {
+		return true, fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+	if status, err := daemonManager.GetDaemonStatus(name); err != nil {
+		return true, err
+	} else if status != daemon.DaemonStatusRunning {
+		return true, fmt.Errorf("%s is %s rather than running once it is restarted", name, status)
+	}
+	return true, nil
+}
+
+func (u *Upgrader) component(name string) *component {
+	for i := range u.components {
+		if u.components[i].name == name {
+			return &u.components[i]
+		}
+	}
+	return nil
+}
+
+// stage downloads the artifacts of a component and verifies their signatures.
+func (u *Upgrader) stage(ctx context.Context, c *component, repository string, version string, publicKey crypto.PublicKey) ([]stagedFile, error) {
+	var files []stagedFile
+	for _, a := range c.artifacts {
+		url := strings.Join([]string{strings.TrimSuffix(repository, "/"), c.name, version, u.arch, a.name}, "/")
+		data, err := u.fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := u.fetch(ctx, url+signatureSuffix)
+		if err != nil {
+			return nil, err
+		}
+		if err := signature.Verify(publicKey, data, sig); err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+		if !a.archive {
+			files = append(files, stagedFile{path: a.path, data: data})
+			continue
+		}
+		extracted, err := extract(data, a.path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+		files = append(files, extracted...)
+	}
+	return files, nil
+}
+
+// extract returns the regular files of a gzipped tarball, which are installed
+// in dir. The tarball must not have subdirectories.
+func extract(data []byte, dir string) ([]stagedFile, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var files []stagedFile
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(header.Name, "./")
+		if name == "" || strings.Contains(name, "/") || name == ".." {
+			return nil, fmt.Errorf("unexpected path %q in archive", header.Name)
+		}
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, stagedFile{path: filepath.Join(dir, name), data: content})
+	}
+}
+
+// installedVersion returns the version that the component reports, or an
+// empty string when it is not installed.
+func (c *component) installedVersion() (string, error) {
+	out, err := exec.Command(c.versionCommand[0], c.versionCommand[1:]...).CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if version := versionPattern.FindString(string(out)); version != "" {
+		return version, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get the version of %s: %w", c.name, err)
+	}
+	return "", fmt.Errorf("failed to parse the version of %s from %q", c.name, strings.TrimSpace(string(out)))
+}
+
+// coreVersion returns the major, minor and patch versions of a version,
+// without a `v` prefix.
+func coreVersion(version string) string {
+	return strings.TrimPrefix(versionPattern.FindString(version), "v")
+}
@@ -0,0 +1,214 @@
+package components
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func newSigner(t *testing.T) (string, func([]byte) []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	return publicKey, func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		assert.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+	}
+}
+
+// versionScript is an executable that prints a version, in place of a binary.
+func versionScript(version string) []byte {
+	return []byte(fmt.Sprintf("#!/bin/sh\necho tool version %s\n", version))
+}
+
+// newTestUpgrader upgrades a single component, whose binary is installed in a
+// temporary directory, from a repository in memory.
+func newTestUpgrader(t *testing.T, installed string) (*Upgrader, string, map[string][]byte) {
+	path := filepath.Join(t.TempDir(), "tool")
+	if installed != "" {
+		assert.NoError(t, os.WriteFile(path, versionScript(installed), 0755))
+	}
+	repository := map[string][]byte{}
+	upgrader := &Upgrader{
+		components: []component{{
+			name:           "runc",
+			artifacts:      []artifact{{name: "runc", path: path}},
+			versionCommand: []string{path},
+			daemon:         "tool",
+			pinned:         func(c *api.ComponentsOptions) string { return c.Runc },
+		}},
+		arch: "amd64",
+		fetch: func(ctx context.Context, url string) ([]byte, error) {
+			if data, ok := repository[url]; ok {
+				return data, nil
+			}
+			return nil, fmt.Errorf("%s not found", url)
+		},
+	}
+	return upgrader, path, repository
+}
+
+func TestUpgrade(t *testing.T) {
+	publicKey, sign := newSigner(t)
+	const url = "https://example.com/components/runc/v1.3.0/amd64/runc"
+	cfg := &api.ComponentsOptions{Repository: "https://example.com/components/", PublicKey: publicKey, Runc: "v1.3.0"}
+
+	t.Run("current", func(t *testing.T) {
+		upgrader, _, _ := newTestUpgrader(t, "1.3.0")
+		statuses, err := upgrader.Upgrade(context.Background(), cfg, daemontest.NewFakeManager())
+		assert.NoError(t, err)
+		assert.Equal(t, []Status{{Name: "runc", Pinned: "v1.3.0", Installed: "1.3.0"}}, statuses)
+	})
+
+	t.Run("upgraded", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "1.2.6")
+		repository[url] = versionScript("1.3.0")
+		repository[url+signatureSuffix] = sign(repository[url])
+		statuses, err := upgrader.Upgrade(context.Background(), cfg, daemontest.NewFakeManager())
+		assert.NoError(t, err)
+		assert.Equal(t, []Status{{Name: "runc", Pinned: "v1.3.0", Installed: "1.3.0", Upgraded: true}}, statuses)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, versionScript("1.3.0"), data)
+		assert.NoFileExists(t, path+backupSuffix)
+		assert.NoFileExists(t, path+newSuffix)
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "")
+		repository[url] = versionScript("1.3.0")
+		repository[url+signatureSuffix] = sign(repository[url])
+		statuses, err := upgrader.Upgrade(context.Background(), cfg, daemontest.NewFakeManager())
+		assert.NoError(t, err)
+		assert.Equal(t, []Status{{Name: "runc", Pinned: "v1.3.0", Installed: "1.3.0", Upgraded: true}}, statuses)
+		assert.FileExists(t, path)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "1.2.6")
+		repository[url] = versionScript("1.3.0")
+		repository[url+signatureSuffix] = sign([]byte("another artifact"))
+		_, err := upgrader.Upgrade(context.Background(), cfg, daemontest.NewFakeManager())
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, versionScript("1.2.6"), data)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "1.2.6")
+		// the artifact reports another version than the one it was published as
+		repository[url] = versionScript("1.2.7")
+		repository[url+signatureSuffix] = sign(repository[url])
+		_, err := upgrader.Upgrade(context.Background(), cfg, daemontest.NewFakeManager())
+		assert.ErrorContains(t, err, `reports version "1.2.7"`)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, versionScript("1.2.6"), data)
+		assert.NoFileExists(t, path+backupSuffix)
+	})
+
+	t.Run("restarted", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "1.2.6")
+		repository[url] = versionScript("1.3.0")
+		repository[url+signatureSuffix] = sign(repository[url])
+		manager := daemontest.NewFakeManager("tool")
+		statuses, err := upgrader.Upgrade(context.Background(), cfg, manager)
+		assert.NoError(t, err)
+		assert.True(t, statuses[0].Upgraded)
+		assert.Equal(t, []string{"status tool", "restart tool", "status tool"}, manager.Calls())
+		assert.NoFileExists(t, path+backupSuffix)
+	})
+
+	t.Run("rolled back on restart", func(t *testing.T) {
+		upgrader, path, repository := newTestUpgrader(t, "1.2.6")
+		repository[url] = versionScript("1.3.0")
+		repository[url+signatureSuffix] = sign(repository[url])
+		manager := daemontest.NewFakeManager("tool")
+		// the daemon stops once it is restarted on the upgraded version
+		statuses, err := upgrader.Upgrade(context.Background(), cfg, &crashingManager{FakeManager: manager, name: "tool"})
+		assert.ErrorContains(t, err, "tool is stopped rather than running once it is restarted")
+		assert.False(t, statuses[0].Upgraded)
+		assert.Equal(t, "1.2.6", statuses[0].Installed)
+		assert.Equal(t, []string{"status tool", "restart tool", "status tool", "restart tool"}, manager.Calls())
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, versionScript("1.2.6"), data)
+		assert.NoFileExists(t, path+backupSuffix)
+	})
+}
+
+// crashingManager reports a daemon as stopped once it was restarted for the
+// first time.
+type crashingManager struct {
+	*daemontest.FakeManager
+	name     string
+	restarts int
+}
+
+func (m *crashingManager) RestartDaemon(name string) error {
+	if name == m.name {
+		m.restarts++
+	}
+	return m.FakeManager.RestartDaemon(name)
+}
+
+func (m *crashingManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	status, err := m.FakeManager.GetDaemonStatus(name)
+	if name == m.name && m.restarts == 1 {
+		return daemon.DaemonStatusStopped, err
+	}
+	return status, err
+}
+
+func TestExtract(t *testing.T) {
+	archive := func(entries map[string]string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755}))
+		for name, content := range entries {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tw.Close())
+		assert.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	files, err := extract(archive(map[string]string{"./loopback": "loopback"}), "/opt/cni/bin")
+	assert.NoError(t, err)
+	assert.Equal(t, []stagedFile{{path: "/opt/cni/bin/loopback", data: []byte("loopback")}}, files)
+
+	_, err = extract(archive(map[string]string{"../../usr/bin/kubelet": "kubelet"}), "/opt/cni/bin")
+	assert.ErrorContains(t, err, "unexpected path")
+}
+
+func TestCoreVersion(t *testing.T) {
+	assert.Equal(t, "1.33.1", coreVersion("Kubernetes v1.33.1-eks-b9c7f4f"))
+	assert.Equal(t, "2.0.2", coreVersion("containerd github.com/containerd/containerd/v2 v2.0.2 c507a02"))
+	assert.Equal(t, "1.2.6", coreVersion("runc version 1.2.6\ncommit: v1.2.6-0-ge89a299\nspec: 1.2.0"))
+}
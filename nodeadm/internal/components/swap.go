@@ -0,0 +1,96 @@
+package components
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+const (
+	binaryPerm = 0755
+	// the suffixes of the files next to an installed file while it is swapped
+	newSuffix    = ".nodeadm-new"
+	backupSuffix = ".nodeadm-backup"
+)
+
+// stagedFile is a file of an artifact, which is installed at path.
+type stagedFile struct {
+	path string
+	data []byte
+}
+
+// swap installs files in place of those at their paths. The replaced files
+// are kept until the swap is committed, so that it can be rolled back.
+type swap struct {
+	installed []installedFile
+}
+
+type installedFile struct {
+	path     string
+	backedUp bool
+}
+
+// install replaces the file at the path of the staged file. The previous file
+// is hard linked to a backup and then replaced with a rename, so that the path
+// always holds either the previous or the new file.
+func (s *swap) install(file stagedFile) error {
+	if err := os.MkdirAll(filepath.Dir(file.path), binaryPerm); err != nil {
+		return err
+	}
+	newPath := file.path + newSuffix
+	if err := os.WriteFile(newPath, file.data, binaryPerm); err != nil {
+		return err
+	}
+	backupPath := file.path + backupSuffix
+	if err := os.Remove(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	installed := installedFile{path: file.path}
+	if err := os.Link(file.path, backupPath); err == nil {
+		installed.backedUp = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(newPath, file.path); err != nil {
+		if installed.backedUp {
+			os.Remove(backupPath)
+		}
+		return err
+	}
+	s.installed = append(s.installed, installed)
+	return nil
+}
+
+// rollback restores the files that were replaced, in the reverse order they
+// were installed.
+func (s *swap) rollback() error {
+	var errs []error
+	for i := len(s.installed) - 1; i >= 0; i-- {
+		file := s.installed[i]
+		zap.L().Info("Restoring file..", zap.String("path", file.path))
+		if file.backedUp {
+			errs = append(errs, os.Rename(file.path+backupSuffix, file.path))
+		} else if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.installed = nil
+	return errors.Join(errs...)
+}
+
+// commit removes the backups of the files that were replaced.
+func (s *swap) commit() error {
+	var errs []error
+	for _, file := range s.installed {
+		if !file.backedUp {
+			continue
+		}
+		if err := os.Remove(file.path + backupSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.installed = nil
+	return errors.Join(errs...)
+}
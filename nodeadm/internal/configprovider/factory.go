@@ -9,6 +9,8 @@ import (
 // The source URL must have a scheme, and the supported schemes are:
 // - `file`. To use configuration from the filesystem: `file:///path/to/file/or/directory`.
 // - `imds`. To use configuration from the instance's user data: `imds://user-data`.
+// - `s3`. To use configuration from an object in S3: `s3://bucket/key`.
+// - `https`. To use configuration from a web server: `https://example.com/path`.
 func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	parsedURL, err := url.Parse(rawConfigSourceURL)
 	if err != nil {
@@ -20,6 +22,8 @@ func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	case "file":
 		source := getURLWithoutScheme(parsedURL)
 		return NewFileConfigProvider(source), nil
+	case "s3", "https":
+		return NewRemoteConfigProvider(rawConfigSourceURL), nil
 	default:
 		return nil, fmt.Errorf("unsupported scheme: %s", parsedURL.Scheme)
 	}
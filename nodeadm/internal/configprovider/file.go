@@ -35,5 +35,7 @@ func (fcs *fileConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ParseMaybeMultipart(data)
+	return parseNodeConfig(data, func() ([]byte, error) {
+		return os.ReadFile(fcs.path + signatureSuffix)
+	})
 }
@@ -32,41 +32,68 @@ func ParseMaybeMultipart(data []byte) (*internalapi.NodeConfig, error) {
 }
 
 func ParseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig, error) {
-	var nodeConfigs []*internalapi.NodeConfig
+	nodeConfigParts, _, err := readMultipart(userDataReader)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNodeConfigParts(nodeConfigParts)
+}
+
+// readMultipart reads the decoded NodeConfig parts of a multipart document,
+// along with its signature part, if any.
+func readMultipart(userDataReader *multipart.Reader) ([][]byte, []byte, error) {
+	var nodeConfigParts [][]byte
+	var signature []byte
 	for {
 		part, err := userDataReader.NextPart()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if partHeader := part.Header.Get(contentTypeHeader); len(partHeader) > 0 {
 			mediaType, _, err := mime.ParseMediaType(partHeader)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			if mediaType == nodeConfigMediaType {
+			switch mediaType {
+			case nodeConfigMediaType:
 				nodeConfigPart, err := io.ReadAll(part)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				nodeConfigPart, err = decodeIfBase64(nodeConfigPart)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				nodeConfigPart, err = decompressIfGZIP(nodeConfigPart)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
-				decodedConfig, err := apibridge.DecodeNodeConfig(nodeConfigPart)
-				if err != nil {
-					return nil, err
+				nodeConfigParts = append(nodeConfigParts, nodeConfigPart)
+			case nodeConfigSignatureMediaType:
+				if signature != nil {
+					return nil, nil, fmt.Errorf("only one %s part may be provided in UserData", nodeConfigSignatureMediaType)
+				}
+				if signature, err = io.ReadAll(part); err != nil {
+					return nil, nil, err
 				}
-				nodeConfigs = append(nodeConfigs, decodedConfig)
 			}
 		}
 	}
+	return nodeConfigParts, signature, nil
+}
+
+func decodeNodeConfigParts(nodeConfigParts [][]byte) (*internalapi.NodeConfig, error) {
+	var nodeConfigs []*internalapi.NodeConfig
+	for _, nodeConfigPart := range nodeConfigParts {
+		decodedConfig, err := apibridge.DecodeNodeConfig(nodeConfigPart)
+		if err != nil {
+			return nil, err
+		}
+		nodeConfigs = append(nodeConfigs, decodedConfig)
+	}
 	if len(nodeConfigs) > 0 {
 		var config = nodeConfigs[0]
 		for _, nodeConfig := range nodeConfigs[1:] {
@@ -0,0 +1,38 @@
+package configprovider
+
+import (
+	"context"
+	"time"
+
+	internalapi "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+)
+
+// fetchTimeout bounds the download of the configuration together with that
+// of its signature.
+const fetchTimeout = 5 * time.Minute
+
+type remoteConfigProvider struct {
+	url string
+}
+
+// NewRemoteConfigProvider provides the configuration at an `s3://` or
+// `https://` URL. The region of the instance is used for S3, unless a region
+// is configured in the environment.
+func NewRemoteConfigProvider(url string) ConfigProvider {
+	return &remoteConfigProvider{
+		url: url,
+	}
+}
+
+func (rcs *remoteConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), fetchTimeout)
+	defer cancel()
+	data, err := download.Fetch(ctx, rcs.url)
+	if err != nil {
+		return nil, err
+	}
+	return parseNodeConfig(data, func() ([]byte, error) {
+		return download.Fetch(ctx, rcs.url+signatureSuffix)
+	})
+}
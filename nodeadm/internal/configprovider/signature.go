@@ -0,0 +1,90 @@
+package configprovider
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	internalapi "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	apibridge "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/signature"
+)
+
+// signatureSuffix is appended to the source of a NodeConfig that is not a
+// multipart document to locate its detached signature.
+const signatureSuffix = ".sig"
+
+// signingKeyPath is the PEM-encoded public key that NodeConfigs are verified
+// with. The key is baked into the AMI; when it exists, only the NodeConfigs
+// that are signed by its private key are applied.
+var signingKeyPath = "/etc/eks/nodeadm/config-signing-key.pem"
+
+// LoadSigningKey returns the public key that NodeConfigs, and the other
+// configuration that nodeadm fetches for the node, are verified with, or nil
+// if there is none.
+func LoadSigningKey() (crypto.PublicKey, error) {
+	data, err := os.ReadFile(signingKeyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	publicKey, err := signature.ParsePublicKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", signingKeyPath, err)
+	}
+	return publicKey, nil
+}
+
+// parseNodeConfig parses the NodeConfig of the data like ParseMaybeMultipart,
+// but verifies its signature first when a signing key is installed. The
+// signature of a multipart document is its signature part, which covers its
+// only NodeConfig part. The signature of any other document is returned by
+// fetchSignature, which is nil when the source has no detached signature.
+func parseNodeConfig(data []byte, fetchSignature func() ([]byte, error)) (*internalapi.NodeConfig, error) {
+	publicKey, err := LoadSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	if publicKey == nil {
+		return ParseMaybeMultipart(data)
+	}
+	if multipartReader, err := getMultipartReader(data); err == nil {
+		nodeConfigParts, sig, err := readMultipart(multipartReader)
+		if err != nil {
+			return nil, err
+		}
+		if sig == nil {
+			return nil, fmt.Errorf("NodeConfig is not signed, UserData must have a %s part", nodeConfigSignatureMediaType)
+		}
+		if len(nodeConfigParts) != 1 {
+			return nil, fmt.Errorf("signed UserData must have exactly one %s part, found %d", nodeConfigMediaType, len(nodeConfigParts))
+		}
+		if err := verifyNodeConfig(publicKey, nodeConfigParts[0], sig); err != nil {
+			return nil, err
+		}
+		return decodeNodeConfigParts(nodeConfigParts)
+	}
+	if fetchSignature == nil {
+		return nil, fmt.Errorf("NodeConfig is not signed, UserData must be a multipart document with a %s part", nodeConfigSignatureMediaType)
+	}
+	sig, err := fetchSignature()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signature of NodeConfig: %w", err)
+	}
+	if err := verifyNodeConfig(publicKey, data, sig); err != nil {
+		return nil, err
+	}
+	return apibridge.DecodeNodeConfig(data)
+}
+
+func verifyNodeConfig(publicKey crypto.PublicKey, data []byte, sig []byte) error {
+	if err := signature.Verify(publicKey, data, sig); err != nil {
+		return fmt.Errorf("failed to verify signature of NodeConfig: %w", err)
+	}
+	zap.L().Info("Verified signature of NodeConfig", zap.String("key", signingKeyPath))
+	return nil
+}
@@ -0,0 +1,136 @@
+package configprovider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/signature"
+)
+
+// withSigningKey installs a signing key for the test, and returns a function
+// that signs data with its private key.
+func withSigningKey(t *testing.T) func([]byte) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config-signing-key.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	original := signingKeyPath
+	signingKeyPath = path
+	t.Cleanup(func() {
+		signingKeyPath = original
+	})
+	return func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		assert.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(sig))
+	}
+}
+
+var signedNodeConfig = linesToBytes(
+	"---",
+	"apiVersion: node.eks.aws/v1alpha1",
+	"kind: NodeConfig",
+	"spec:",
+	"  cluster:",
+	"    name: my-cluster",
+	"    apiServerEndpoint: https://example.com",
+	"    certificateAuthority: Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+)
+
+func signedUserData(nodeConfig []byte, sig []byte) []byte {
+	return appendByteSlices(
+		linesToBytes(
+			"MIME-Version: 1.0",
+			`Content-Type: multipart/mixed; boundary="BOUNDARY"`,
+			"",
+			"--BOUNDARY",
+			"Content-Type: application/node.eks.aws",
+			"",
+			"",
+		),
+		nodeConfig,
+		linesToBytes(
+			"",
+			"--BOUNDARY",
+			"Content-Type: application/node.eks.aws.signature",
+			"",
+			"",
+		),
+		sig,
+		linesToBytes(
+			"",
+			"--BOUNDARY--",
+		),
+	)
+}
+
+func TestParseNodeConfigWithoutSigningKey(t *testing.T) {
+	original := signingKeyPath
+	signingKeyPath = filepath.Join(t.TempDir(), "config-signing-key.pem")
+	t.Cleanup(func() {
+		signingKeyPath = original
+	})
+	config, err := parseNodeConfig(signedNodeConfig, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.Spec.Cluster.Name)
+}
+
+func TestParseNodeConfigSignedUserData(t *testing.T) {
+	sign := withSigningKey(t)
+
+	config, err := parseNodeConfig(signedUserData(signedNodeConfig, sign(signedNodeConfig)), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.Spec.Cluster.Name)
+
+	tampered := append([]byte{}, signedNodeConfig...)
+	tampered = append(tampered, []byte("\n  instance:\n    localStorage:\n      strategy: RAID0")...)
+	_, err = parseNodeConfig(signedUserData(tampered, sign(signedNodeConfig)), nil)
+	assert.ErrorIs(t, err, signature.ErrInvalidSignature)
+
+	_, err = parseNodeConfig(signedNodeConfig, nil)
+	assert.ErrorContains(t, err, "NodeConfig is not signed")
+}
+
+func TestParseNodeConfigDetachedSignature(t *testing.T) {
+	sign := withSigningKey(t)
+	fetch := func(sig []byte) func() ([]byte, error) {
+		return func() ([]byte, error) { return sig, nil }
+	}
+
+	config, err := parseNodeConfig(signedNodeConfig, fetch(sign(signedNodeConfig)))
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.Spec.Cluster.Name)
+
+	_, err = parseNodeConfig(signedNodeConfig, fetch(sign([]byte("another config"))))
+	assert.ErrorIs(t, err, signature.ErrInvalidSignature)
+
+	_, err = parseNodeConfig(signedNodeConfig, func() ([]byte, error) { return nil, os.ErrNotExist })
+	assert.ErrorContains(t, err, "failed to fetch signature of NodeConfig")
+}
+
+func TestFileConfigProviderSigned(t *testing.T) {
+	sign := withSigningKey(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, signedNodeConfig, 0644))
+
+	_, err := NewFileConfigProvider(path).Provide()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	assert.NoError(t, os.WriteFile(path+signatureSuffix, sign(signedNodeConfig), 0644))
+	config, err := NewFileConfigProvider(path).Provide()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.Spec.Cluster.Name)
+}
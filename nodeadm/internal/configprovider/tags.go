@@ -0,0 +1,116 @@
+package configprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	internalapi "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	apibridge "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge"
+)
+
+// DefaultInstanceTagsPrefix is the prefix of the keys of the tags that are
+// mapped into the NodeConfig, unless another is configured.
+const DefaultInstanceTagsPrefix = "eks:nodeconfig"
+
+// allowedTagPaths are the only paths that can be set from tags. They change
+// how pods are scheduled onto the node, but not how the node is bootstrapped,
+// since the permission to tag the instance must not grant root on it, and
+// tags are not covered by the signature of the NodeConfig.
+var allowedTagPaths = []string{nodeLabelsTagPath, nodeTaintsTagPath}
+
+const (
+	nodeLabelsTagPath = "kubelet.nodeLabels"
+	nodeTaintsTagPath = "kubelet.nodeTaints"
+)
+
+// ParseInstanceTags returns a NodeConfig with the fields set by the tags whose
+// keys start with the prefix. The rest of such a key is the path of a field
+// beneath the spec, with a '/' or '.' between its parts. Tags are rejected
+// when a signing key is installed, since only a signed NodeConfig may be
+// applied then.
+func ParseInstanceTags(tags map[string]string, prefix string) (*internalapi.NodeConfig, error) {
+	publicKey, err := LoadSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	if publicKey != nil {
+		return nil, fmt.Errorf("instance tags cannot be applied when NodeConfigs must be signed by %s", signingKeyPath)
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	spec := make(map[string]interface{})
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok || len(rest) < 2 || (rest[0] != '/' && rest[0] != '.') {
+			continue
+		}
+		separator := rest[:1]
+		path := strings.Split(rest[1:], separator)
+		fieldPath := strings.Join(path, ".")
+		if !isAllowedTagPath(fieldPath) {
+			return nil, fmt.Errorf("tag %s: %s cannot be set from instance tags, only %s", key, fieldPath, strings.Join(allowedTagPaths, " and "))
+		}
+		kubelet, _ := spec["kubelet"].(map[string]interface{})
+		if kubelet == nil {
+			kubelet = make(map[string]interface{})
+			spec["kubelet"] = kubelet
+		}
+		if fieldPath == nodeTaintsTagPath {
+			taints, err := parseTaintsTag(tags[key])
+			if err != nil {
+				return nil, fmt.Errorf("tag %s: %w", key, err)
+			}
+			kubelet["nodeTaints"] = taints
+			continue
+		}
+		nodeLabels, _ := kubelet["nodeLabels"].(map[string]interface{})
+		if nodeLabels == nil {
+			nodeLabels = make(map[string]interface{})
+			kubelet["nodeLabels"] = nodeLabels
+		}
+		// the keys of node labels may contain the separator
+		nodeLabels[strings.Join(path[2:], separator)] = tags[key]
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"apiVersion": v1alpha1.GroupVersion.String(),
+		"kind":       api.KindNodeConfig,
+		"spec":       spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apibridge.DecodeNodeConfig(data)
+}
+
+// isAllowedTagPath returns whether the path is, or is beneath, an allowed
+// path. Taints are a list, so they are only set as a whole.
+func isAllowedTagPath(fieldPath string) bool {
+	return fieldPath == nodeTaintsTagPath || strings.HasPrefix(fieldPath, nodeLabelsTagPath+".")
+}
+
+// parseTaintsTag returns the taints of a tag, separated by commas in the
+// format of the --register-with-taints flag of kubelet, `key=value:Effect`.
+func parseTaintsTag(value string) ([]interface{}, error) {
+	var taints []interface{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		keyValue, effect, ok := strings.Cut(item, ":")
+		if !ok || effect == "" {
+			return nil, fmt.Errorf("taint %q has no effect, it must be key=value:Effect", item)
+		}
+		key, val, _ := strings.Cut(keyValue, "=")
+		taint := map[string]interface{}{"key": key, "effect": effect}
+		if val != "" {
+			taint["value"] = val
+		}
+		taints = append(taints, taint)
+	}
+	return taints, nil
+}
@@ -0,0 +1,116 @@
+package configprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestParseInstanceTags(t *testing.T) {
+	var tests = []struct {
+		name           string
+		tags           map[string]string
+		expectedConfig *api.NodeConfig
+		expectErr      string
+	}{
+		{
+			name: "node labels",
+			tags: map[string]string{
+				"Name": "worker",
+				"eks:nodeconfig/kubelet/nodeLabels/example.com/team": "payments",
+				"eks:nodeconfigs/kubelet/nodeLabels/ignored":         "true",
+			},
+			expectedConfig: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: api.KubeletOptions{
+						NodeLabels: map[string]string{"example.com/team": "payments"},
+					},
+				},
+			},
+		},
+		{
+			name: "keys separated by dots from IMDS",
+			tags: map[string]string{
+				"eks:nodeconfig.kubelet.nodeLabels.team":  "payments",
+				"eks:nodeconfig.kubelet.nodeLabels.stage": "prod",
+			},
+			expectedConfig: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: api.KubeletOptions{
+						NodeLabels: map[string]string{"team": "payments", "stage": "prod"},
+					},
+				},
+			},
+		},
+		{
+			name: "node taints",
+			tags: map[string]string{
+				"eks:nodeconfig.kubelet.nodeTaints": "dedicated=gpu:NoSchedule, spot:PreferNoSchedule",
+			},
+			expectedConfig: &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: api.KubeletOptions{
+						NodeTaints: []api.Taint{
+							{Key: "dedicated", Value: "gpu", Effect: api.TaintEffectNoSchedule},
+							{Key: "spot", Effect: api.TaintEffectPreferNoSchedule},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "taint without effect",
+			tags:      map[string]string{"eks:nodeconfig/kubelet/nodeTaints": "dedicated=gpu"},
+			expectErr: `taint "dedicated=gpu" has no effect`,
+		},
+		{
+			name:      "node labels without key",
+			tags:      map[string]string{"eks:nodeconfig/kubelet/nodeLabels": "payments"},
+			expectErr: "kubelet.nodeLabels cannot be set from instance tags",
+		},
+		{
+			name:      "kubelet flags",
+			tags:      map[string]string{"eks:nodeconfig/kubelet/flags": "--v=2"},
+			expectErr: "kubelet.flags cannot be set from instance tags",
+		},
+		{
+			name:      "kubelet config",
+			tags:      map[string]string{"eks:nodeconfig.kubelet.config.maxPods": "110"},
+			expectErr: "kubelet.config.maxPods cannot be set from instance tags",
+		},
+		{
+			name:      "cluster discovery",
+			tags:      map[string]string{"eks:nodeconfig.cluster.discovery.source": "ssm"},
+			expectErr: "cluster.discovery.source cannot be set from instance tags",
+		},
+		{
+			name:      "proxy",
+			tags:      map[string]string{"eks:nodeconfig/proxy/httpsProxy": "http://proxy.example.com:3128"},
+			expectErr: "proxy.httpsProxy cannot be set from instance tags",
+		},
+		{
+			name:      "hooks",
+			tags:      map[string]string{"eks:nodeconfig/hooks/preInit": "reboot"},
+			expectErr: "hooks.preInit cannot be set from instance tags",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := ParseInstanceTags(test.tags, DefaultInstanceTagsPrefix)
+			if test.expectErr != "" {
+				assert.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedConfig.Spec, config.Spec)
+		})
+	}
+}
+
+func TestParseInstanceTagsSigned(t *testing.T) {
+	withSigningKey(t)
+	_, err := ParseInstanceTags(map[string]string{"eks:nodeconfig.kubelet.nodeLabels.team": "payments"}, DefaultInstanceTagsPrefix)
+	assert.ErrorContains(t, err, "instance tags cannot be applied when NodeConfigs must be signed")
+}
@@ -14,6 +14,9 @@ const (
 	mimeBoundaryParam          = "boundary"
 	multipartContentTypePrefix = "multipart/"
 	nodeConfigMediaType        = "application/" + api.GroupName
+	// nodeConfigSignatureMediaType is the media type of the part that holds
+	// the detached signature of the NodeConfig parts.
+	nodeConfigSignatureMediaType = nodeConfigMediaType + ".signature"
 )
 
 type userDataProvider interface {
@@ -49,5 +52,7 @@ func (p *userDataConfigProvider) Provide() (*internalapi.NodeConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to decompress user data: %v", err)
 	}
-	return ParseMaybeMultipart(userData)
+	// the user data is the only document of IMDS, so its signature must be a
+	// part of it rather than a detached signature.
+	return parseNodeConfig(userData, nil)
 }
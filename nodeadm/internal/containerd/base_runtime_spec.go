@@ -3,36 +3,130 @@ package containerd
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+	"github.com/pelletier/go-toml/v2"
 	"go.uber.org/zap"
 )
 
-const containerdBaseRuntimeSpecFile = "/etc/containerd/base-runtime-spec.json"
+const (
+	containerdBaseRuntimeSpecFile = "/etc/containerd/base-runtime-spec.json"
+	// the base runtime specs of individual runtimes, which are named after
+	// their handler
+	containerdBaseRuntimeSpecOverridesDir = "/etc/containerd/base-runtime-spec.d"
+)
 
 //go:embed base-runtime-spec.json
 var defaultBaseRuntimeSpecData string
 
 func writeBaseRuntimeSpec(cfg *api.NodeConfig) error {
 	zap.L().Info("Writing containerd base runtime spec...", zap.String("path", containerdBaseRuntimeSpecFile))
-	baseRuntimeSpecData := defaultBaseRuntimeSpecData
-	if len(cfg.Spec.Containerd.BaseRuntimeSpec) > 0 {
-		var defaultBaseRuntimeSpecMap api.InlineDocument
-		if err := json.Unmarshal([]byte(defaultBaseRuntimeSpecData), &defaultBaseRuntimeSpecMap); err != nil {
-			return fmt.Errorf("failed to unmarshal default base runtime spec: %v", err)
-		}
-		mergedBaseRuntimeSpecMap, err := util.Merge(defaultBaseRuntimeSpecMap, cfg.Spec.Containerd.BaseRuntimeSpec, json.Marshal, json.Unmarshal)
+	baseRuntimeSpecData, err := generateBaseRuntimeSpec(cfg.Spec.Containerd.BaseRuntimeSpec)
+	if err != nil {
+		return err
+	}
+	if err := util.WriteFileWithDir(containerdBaseRuntimeSpecFile, baseRuntimeSpecData, containerdConfigPerm); err != nil {
+		return err
+	}
+	return writeBaseRuntimeSpecOverrides(containerdBaseRuntimeSpecOverridesDir, cfg)
+}
+
+// writeBaseRuntimeSpecOverrides writes the base runtime spec of each runtime
+// that has overrides, and removes those of runtimes that no longer do.
+func writeBaseRuntimeSpecOverrides(dir string, cfg *api.NodeConfig) error {
+	overrides := cfg.Spec.Containerd.BaseRuntimeSpecOverrides
+	var paths []string
+	for handler, override := range overrides {
+		path := getBaseRuntimeSpecOverridePath(dir, handler)
+		paths = append(paths, path)
+		zap.L().Info("Writing containerd base runtime spec of runtime...", zap.String("handler", handler), zap.String("path", path))
+		// overrides are applied on top of the spec shared by every runtime
+		data, err := generateBaseRuntimeSpec(cfg.Spec.Containerd.BaseRuntimeSpec, override)
 		if err != nil {
+			return fmt.Errorf("failed to generate base runtime spec of runtime %s: %w", handler, err)
+		}
+		if err := util.WriteFileWithDir(path, data, containerdConfigPerm); err != nil {
 			return err
 		}
-		mergedBaseRuntimeSpecData, err := json.MarshalIndent(mergedBaseRuntimeSpecMap, "", strings.Repeat(" ", 4))
-		if err != nil {
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		if slices.Contains(paths, path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return err
 		}
-		baseRuntimeSpecData = string(mergedBaseRuntimeSpecData)
 	}
-	return util.WriteFileWithDir(containerdBaseRuntimeSpecFile, []byte(baseRuntimeSpecData), containerdConfigPerm)
+	return nil
+}
+
+func getBaseRuntimeSpecOverridePath(dir string, handler string) string {
+	return filepath.Join(dir, handler+".json")
+}
+
+// generateBaseRuntimeSpec merges each of the specs, in order, with the default
+// base runtime spec.
+func generateBaseRuntimeSpec(specs ...api.InlineDocument) ([]byte, error) {
+	specs = slices.DeleteFunc(specs, func(spec api.InlineDocument) bool { return len(spec) == 0 })
+	if len(specs) == 0 {
+		return []byte(defaultBaseRuntimeSpecData), nil
+	}
+	var baseRuntimeSpecMap api.InlineDocument
+	if err := json.Unmarshal([]byte(defaultBaseRuntimeSpecData), &baseRuntimeSpecMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal default base runtime spec: %v", err)
+	}
+	var mergedBaseRuntimeSpecMap any = baseRuntimeSpecMap
+	for _, spec := range specs {
+		var err error
+		if mergedBaseRuntimeSpecMap, err = util.Merge(mergedBaseRuntimeSpecMap, spec, json.Marshal, json.Unmarshal); err != nil {
+			return nil, err
+		}
+	}
+	return json.MarshalIndent(mergedBaseRuntimeSpecMap, "", strings.Repeat(" ", 4))
+}
+
+// withBaseRuntimeSpecOverrides points each runtime that has overrides at its
+// own base runtime spec. Runtimes may be configured by nodeadm or in the
+// user's containerd config, so this is applied to the merged config.
+func withBaseRuntimeSpecOverrides(containerdConfig []byte, cfg *api.NodeConfig) ([]byte, error) {
+	overrides := cfg.Spec.Containerd.BaseRuntimeSpecOverrides
+	if len(overrides) == 0 {
+		return containerdConfig, nil
+	}
+	var configMap map[string]any
+	if err := toml.Unmarshal(containerdConfig, &configMap); err != nil {
+		return nil, err
+	}
+	runtimes, _ := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes")
+	for handler := range overrides {
+		runtime, ok := getTable(runtimes, handler)
+		if !ok {
+			return nil, fmt.Errorf("base runtime spec overrides are defined for runtime %s, which is not configured in containerd", handler)
+		}
+		runtime["base_runtime_spec"] = getBaseRuntimeSpecOverridePath(containerdBaseRuntimeSpecOverridesDir, handler)
+	}
+	return toml.Marshal(configMap)
+}
+
+// getTable returns the table at the path of keys beneath a TOML document.
+func getTable(table map[string]any, keys ...string) (map[string]any, bool) {
+	for _, key := range keys {
+		child, ok := table[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		table = child
+	}
+	return table, true
 }
@@ -0,0 +1,85 @@
+package containerd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGenerateBaseRuntimeSpec(t *testing.T) {
+	data, err := generateBaseRuntimeSpec(nil, api.InlineDocument{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBaseRuntimeSpecData, string(data))
+
+	data, err = generateBaseRuntimeSpec(
+		api.InlineDocument{"ociVersion": runtime.RawExtension{Raw: []byte(`"1.1.0"`)}},
+		api.InlineDocument{"linux": runtime.RawExtension{Raw: []byte(`{"maskedPaths": ["/proc/kcore"]}`)}},
+	)
+	assert.NoError(t, err)
+	var spec struct {
+		OCIVersion string `json:"ociVersion"`
+		Linux      struct {
+			MaskedPaths []string `json:"maskedPaths"`
+		} `json:"linux"`
+		Process struct {
+			Rlimits []any `json:"rlimits"`
+		} `json:"process"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, "1.1.0", spec.OCIVersion)
+	assert.Equal(t, []string{"/proc/kcore"}, spec.Linux.MaskedPaths)
+	// the rest of the default spec is kept
+	assert.NotEmpty(t, spec.Process.Rlimits)
+}
+
+func TestWriteBaseRuntimeSpecOverrides(t *testing.T) {
+	dir := t.TempDir()
+	stalePath := filepath.Join(dir, "crun.json")
+	assert.NoError(t, os.WriteFile(stalePath, []byte("{}"), 0644))
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				BaseRuntimeSpecOverrides: map[string]api.InlineDocument{
+					"runsc": {"ociVersion": runtime.RawExtension{Raw: []byte(`"1.1.0"`)}},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, writeBaseRuntimeSpecOverrides(dir, &cfg))
+	assert.FileExists(t, filepath.Join(dir, "runsc.json"))
+	assert.NoFileExists(t, stalePath)
+}
+
+func TestContainerdConfigBaseRuntimeSpecOverrides(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runsc: &api.RunscOptions{},
+				BaseRuntimeSpecOverrides: map[string]api.InlineDocument{
+					"runsc": {},
+				},
+			},
+		},
+	}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	containerdConfig, err = withBaseRuntimeSpecOverrides(containerdConfig, &cfg)
+	assert.NoError(t, err)
+	var configMap map[string]any
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &configMap))
+	runtimes, _ := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes")
+	assert.Equal(t, "/etc/containerd/base-runtime-spec.d/runsc.json", runtimes["runsc"].(map[string]any)["base_runtime_spec"])
+	assert.Equal(t, containerdBaseRuntimeSpecFile, runtimes["runc"].(map[string]any)["base_runtime_spec"])
+
+	cfg.Spec.Containerd.BaseRuntimeSpecOverrides = map[string]api.InlineDocument{"kata": {}}
+	_, err = withBaseRuntimeSpecOverrides(containerdConfig, &cfg)
+	assert.Error(t, err)
+}
@@ -2,7 +2,14 @@ package containerd
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"text/template"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
@@ -10,33 +17,177 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
+	"k8s.io/utils/ptr"
 )
 
-const ContainerRuntimeEndpoint = "unix:///run/containerd/containerd.sock"
+// SOCISnapshotter is the snapshotter of the CRI when FastContainerImagePull is
+// enabled.
+const SOCISnapshotter = "soci"
 
-const (
-	containerdConfigFile = "/etc/containerd/config.toml"
-	containerdConfigPerm = 0644
-)
+const containerdConfigPerm = 0644
 
 var (
 	//go:embed config.template.toml
 	containerdConfigTemplateData string
-	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigTemplateData))
+	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Funcs(template.FuncMap{
+		// paths are quoted, since those of Windows contain backslashes
+		"quote": strconv.Quote,
+	}).Parse(containerdConfigTemplateData))
 )
 
+// containerdPaths are the locations of the files of containerd, which differ
+// between platforms.
+type containerdPaths struct {
+	Root    string
+	State   string
+	Address string
+	// RegistryConfigPath is a list of directories of registry hosts, joined
+	// with the path list separator of the platform.
+	RegistryConfigPath string
+	CNIBinDir          string
+	CNIConfDir         string
+}
+
 type containerdTemplateVars struct {
+	Paths             containerdPaths
 	EnableCDI         bool
+	EnableSOCI        bool
 	SandboxImage      string
 	RuntimeName       string
 	RuntimeBinaryName string
+	SystemdCgroup     bool
+	EnableRunsc       bool
+	RunscConfigPath   string
+	IPPreference      string
+	// EnableUserNamespaces requires the layers of pods in user namespaces to
+	// be mapped with idmap mounts, rather than copied and chowned for each
+	// pod.
+	EnableUserNamespaces  bool
+	DiscardUnpackedLayers bool
+	// UnsetSeccompProfile is the seccomp profile of the containers that do
+	// not set one, in the legacy format of containerd.
+	UnsetSeccompProfile string
+	// GarbageCollection is nil unless the garbage collection of containerd
+	// is configured.
+	GarbageCollection *gcSchedulerVars
+	// Metrics and Debug are nil unless their sections of the config are set.
+	Metrics *api.ContainerdMetricsOptions
+	Debug   *api.ContainerdDebugOptions
+	// NRI is nil unless the Node Resource Interface is configured.
+	NRI *nriVars
+	// ImagePulls is nil unless the image pulls of containerd are configured.
+	ImagePulls *imagePullVars
 }
 
-func writeContainerdConfig(cfg *api.NodeConfig) error {
-	containerdConfig, err := generateContainerdConfig(cfg)
+type imagePullVars struct {
+	MaxConcurrentDownloads int
+	ProgressTimeout        string
+	// Transfer limits the downloads of the transfer service, which the CRI
+	// plugin pulls images with since containerd 2.0.
+	Transfer bool
+}
+
+type nriVars struct {
+	// Disable is empty when the default of containerd is used.
+	Disable                   string
+	SocketPath                string
+	PluginRegistrationTimeout string
+	PluginRequestTimeout      string
+}
+
+type gcSchedulerVars struct {
+	// PauseThreshold is a fraction, rather than the percentage of the
+	// NodeConfig.
+	PauseThreshold    string
+	DeletionThreshold int
+	MutationThreshold int
+	ScheduleDelay     string
+}
+
+// writeContainerdConfig writes the settings of nodeadm to their own file in
+// the config directory, and a config that imports every file of the directory,
+// so that the files that users add to the directory are preserved. containerd
+// merges the imports in the lexical order of their names, and a later file
+// replaces the sections of a plugin that an earlier file set.
+func writeContainerdConfig(ctx context.Context, cfg *api.NodeConfig) error {
+	containerdConfig, err := GenerateConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	perm := fs.FileMode(containerdConfigPerm)
+	if len(cfg.Spec.Containerd.RegistryCredentials) > 0 {
+		perm = containerdSecretConfigPerm
+	}
+	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdNodeadmConfigFile))
+	if err := util.WriteFileWithDir(containerdNodeadmConfigFile, containerdConfig, perm); err != nil {
+		return err
+	}
+	// the mode of a config that already exists is not changed by the write
+	if err := os.Chmod(containerdNodeadmConfigFile, perm); err != nil {
+		return err
+	}
+	userConfigFiles, err := getUserConfigFiles(containerdConfigDir, filepath.Base(containerdNodeadmConfigFile))
 	if err != nil {
 		return err
 	}
+	for _, file := range userConfigFiles {
+		if filepath.Base(file) < filepath.Base(containerdNodeadmConfigFile) {
+			zap.L().Warn("containerd config file is imported before the config of nodeadm, which takes precedence over it", zap.String("path", file))
+		} else {
+			zap.L().Info("Preserving containerd config file", zap.String("path", file))
+		}
+	}
+	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdConfigFile))
+	if err := util.WriteFileWithDir(containerdConfigFile, generateRootConfig(), containerdConfigPerm); err != nil {
+		return err
+	}
+	return os.Chmod(containerdConfigFile, containerdConfigPerm)
+}
+
+// generateRootConfig returns the config that containerd loads, which only
+// imports the files of the config directory.
+func generateRootConfig() []byte {
+	imports := strconv.Quote(filepath.Join(containerdConfigDir, "*.toml"))
+	return []byte(fmt.Sprintf("version = 2\nimports = [%s]\n", imports))
+}
+
+// getUserConfigFiles returns the files of the config directory that were not
+// written by nodeadm, in the order that containerd imports them.
+func getUserConfigFiles(dir string, nodeadmConfigFile string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	var userFiles []string
+	for _, file := range files {
+		if filepath.Base(file) != nodeadmConfigFile {
+			userFiles = append(userFiles, file)
+		}
+	}
+	// containerd sorts the matches of a glob, as filepath.Glob does
+	sort.Strings(userFiles)
+	return userFiles, nil
+}
+
+// GenerateConfig returns the config of containerd for an enriched NodeConfig,
+// including the config of the NodeConfig that is merged over the defaults.
+func GenerateConfig(ctx context.Context, cfg *api.NodeConfig) ([]byte, error) {
+	containerdConfig, err := generateContainerdConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	containerdConfig, err = withRuntimes(containerdConfig, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentials := cfg.Spec.Containerd.RegistryCredentials; len(credentials) > 0 {
+		containerdConfig, err = withRegistryCredentials(ctx, containerdConfig, credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// because the logic in containerd's import merge decides to completely
 	// overwrite entire sections, we want to implement this merging ourselves.
@@ -44,26 +195,45 @@ func writeContainerdConfig(cfg *api.NodeConfig) error {
 	if len(cfg.Spec.Containerd.Config) > 0 {
 		containerdConfigMap, err := util.Merge(containerdConfig, []byte(cfg.Spec.Containerd.Config), toml.Marshal, toml.Unmarshal)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		containerdConfig, err = toml.Marshal(containerdConfigMap)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdConfigFile))
-	return util.WriteFileWithDir(containerdConfigFile, containerdConfig, containerdConfigPerm)
+	return withBaseRuntimeSpecOverrides(containerdConfig, cfg)
 }
 
 func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
 	runtimeOptions := getRuntimeOptions(cfg)
 
 	configVars := containerdTemplateVars{
-		SandboxImage:      cfg.Status.Defaults.SandboxImage,
-		RuntimeBinaryName: runtimeOptions.RuntimeBinaryPath,
-		RuntimeName:       runtimeOptions.RuntimeName,
-		EnableCDI:         semver.Compare(cfg.Status.KubeletVersion, "v1.32.0") >= 0,
+		Paths:                 platformPaths,
+		SandboxImage:          cfg.Status.Defaults.SandboxImage,
+		RuntimeBinaryName:     runtimeOptions.RuntimeBinaryPath,
+		RuntimeName:           runtimeOptions.RuntimeName,
+		EnableCDI:             semver.Compare(cfg.Status.KubeletVersion, "v1.32.0") >= 0,
+		EnableSOCI:            api.IsFeatureEnabled(api.FastContainerImagePull, cfg.Spec.FeatureGates),
+		SystemdCgroup:         cfg.GetCgroupDriver() == api.CgroupDriverSystemd,
+		EnableRunsc:           cfg.Spec.Containerd.Runsc != nil,
+		RunscConfigPath:       runscConfigPath,
+		IPPreference:          getIPPreference(cfg),
+		EnableUserNamespaces:  cfg.Spec.Security.UserNamespaces.Enabled,
+		DiscardUnpackedLayers: ptr.Deref(cfg.Spec.Containerd.DiscardUnpackedLayers, true),
+		GarbageCollection:     getGCSchedulerVars(cfg.Spec.Containerd.GarbageCollection),
+		NRI:                   getNRIVars(cfg.Spec.Containerd.NRI),
+		ImagePulls:            getImagePullVars(cfg.Spec.Containerd.ImagePulls, cfg.Status.ContainerdVersion),
+	}
+	if path := cfg.Spec.Security.SeccompDefault.GetDefaultProfilePath(); path != "" {
+		configVars.UnsetSeccompProfile = "localhost/" + path
+	}
+	if metrics := cfg.Spec.Containerd.Metrics; metrics.Address != "" {
+		configVars.Metrics = &metrics
+	}
+	if debug := cfg.Spec.Containerd.Debug; debug != (api.ContainerdDebugOptions{}) {
+		configVars.Debug = &debug
 	}
 	var buf bytes.Buffer
 	if err := containerdConfigTemplate.Execute(&buf, configVars); err != nil {
@@ -71,3 +241,66 @@ func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
 	}
 	return buf.Bytes(), nil
 }
+
+func getGCSchedulerVars(gc api.ContainerdGarbageCollectionOptions) *gcSchedulerVars {
+	if gc == (api.ContainerdGarbageCollectionOptions{}) {
+		return nil
+	}
+	vars := gcSchedulerVars{
+		DeletionThreshold: gc.DeletionThreshold,
+		MutationThreshold: gc.MutationThreshold,
+	}
+	if gc.PauseThresholdPercent != 0 {
+		vars.PauseThreshold = strconv.FormatFloat(float64(gc.PauseThresholdPercent)/100, 'f', -1, 64)
+	}
+	if gc.ScheduleDelay != nil {
+		vars.ScheduleDelay = gc.ScheduleDelay.Duration.String()
+	}
+	return &vars
+}
+
+func getNRIVars(nri api.ContainerdNRIOptions) *nriVars {
+	if nri.Enabled == nil && nri.SocketPath == "" && nri.PluginRegistrationTimeout == nil && nri.PluginRequestTimeout == nil {
+		return nil
+	}
+	vars := nriVars{SocketPath: nri.SocketPath}
+	if nri.Enabled != nil {
+		vars.Disable = strconv.FormatBool(!*nri.Enabled)
+	}
+	if nri.PluginRegistrationTimeout != nil {
+		vars.PluginRegistrationTimeout = nri.PluginRegistrationTimeout.Duration.String()
+	}
+	if nri.PluginRequestTimeout != nil {
+		vars.PluginRequestTimeout = nri.PluginRequestTimeout.Duration.String()
+	}
+	return &vars
+}
+
+// getImagePullVars returns the limits of the image pulls of a version of
+// containerd. The version is empty when it is unknown, in which case the
+// limits are written for every version, since containerd ignores the config
+// of plugins that it does not have. There are no limits per registry, since
+// containerd has none.
+func getImagePullVars(pulls api.ContainerdImagePullOptions, containerdVersion string) *imagePullVars {
+	if pulls.MaxConcurrentDownloads == 0 && pulls.ProgressTimeout == nil {
+		return nil
+	}
+	vars := imagePullVars{
+		MaxConcurrentDownloads: pulls.MaxConcurrentDownloads,
+		Transfer:               containerdVersion == "" || semver.Compare(containerdVersion, "v2.0.0") >= 0,
+	}
+	if pulls.ProgressTimeout != nil {
+		vars.ProgressTimeout = pulls.ProgressTimeout.Duration.String()
+	}
+	return &vars
+}
+
+// getIPPreference reports the IPv6 address of pods as their IP in IPv6
+// clusters, rather than the IPv4 address that the VPC CNI assigns to pods for
+// egress to IPv4 destinations.
+func getIPPreference(cfg *api.NodeConfig) string {
+	if ipFamilies, err := cfg.Spec.Cluster.GetIPFamilies(); err == nil && ipFamilies[0] == api.IPFamilyIPv6 {
+		return "ipv6"
+	}
+	return ""
+}
@@ -0,0 +1,390 @@
+package containerd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestContainerdConfigCgroupDriver(t *testing.T) {
+	var tests = []struct {
+		driver   api.CgroupDriver
+		expected string
+	}{
+		{driver: "", expected: "SystemdCgroup = true\n"},
+		{driver: api.CgroupDriverSystemd, expected: "SystemdCgroup = true\n"},
+		{driver: api.CgroupDriverCgroupfs, expected: "SystemdCgroup = false\n"},
+	}
+
+	for _, test := range tests {
+		cfg := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Instance: api.InstanceOptions{Cgroup: api.CgroupOptions{Driver: test.driver}},
+			},
+		}
+		containerdConfig, err := generateContainerdConfig(&cfg)
+		assert.NoError(t, err)
+		assert.Contains(t, string(containerdConfig), test.expected)
+	}
+}
+
+func TestContainerdConfigWindowsPaths(t *testing.T) {
+	linuxPaths := platformPaths
+	defer func() { platformPaths = linuxPaths }()
+	platformPaths = containerdPaths{
+		Root:               `C:\ProgramData\containerd\root`,
+		State:              `C:\ProgramData\containerd\state`,
+		Address:            `\\.\pipe\containerd-containerd`,
+		RegistryConfigPath: `C:\Program Files\containerd\certs.d`,
+		CNIBinDir:          `C:\Program Files\Amazon\EKS\cni`,
+		CNIConfDir:         `C:\ProgramData\Amazon\EKS\cni\config`,
+	}
+
+	containerdConfig, err := generateContainerdConfig(&api.NodeConfig{})
+	assert.NoError(t, err)
+	var parsed struct {
+		Root  string `toml:"root"`
+		State string `toml:"state"`
+		GRPC  struct {
+			Address string `toml:"address"`
+		} `toml:"grpc"`
+	}
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+	assert.Equal(t, platformPaths.Root, parsed.Root)
+	assert.Equal(t, platformPaths.State, parsed.State)
+	assert.Equal(t, platformPaths.Address, parsed.GRPC.Address)
+}
+
+func TestContainerdConfigIPPreference(t *testing.T) {
+	var tests = []struct {
+		cidr     string
+		expected string
+	}{
+		{cidr: "10.100.0.0/16"},
+		{cidr: "10.100.0.0/16,fd30:1c53:5f8a::/108"},
+		{cidr: "fd30:1c53:5f8a::/108", expected: "ipv6"},
+		{cidr: "fd30:1c53:5f8a::/108,10.100.0.0/16", expected: "ipv6"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.cidr, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{CIDR: test.cidr},
+				},
+			}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Plugins struct {
+					CRI struct {
+						CNI struct {
+							IPPref string `toml:"ip_pref"`
+						} `toml:"cni"`
+					} `toml:"io.containerd.grpc.v1.cri"`
+				} `toml:"plugins"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			assert.Equal(t, test.expected, parsed.Plugins.CRI.CNI.IPPref)
+		})
+	}
+}
+
+func TestContainerdConfigUserNamespaces(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Security: api.SecurityOptions{UserNamespaces: api.UserNamespacesOptions{Enabled: true}},
+		},
+	}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	var parsed struct {
+		Plugins map[string]map[string]any `toml:"plugins"`
+	}
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+	assert.Equal(t, false, parsed.Plugins["io.containerd.snapshotter.v1.overlayfs"]["slow_chown"])
+}
+
+func TestContainerdConfigSeccompDefault(t *testing.T) {
+	var tests = []struct {
+		name     string
+		seccomp  api.SeccompDefaultOptions
+		expected any
+	}{
+		{name: "disabled"},
+		{name: "runtime default", seccomp: api.SeccompDefaultOptions{Enabled: true}},
+		{
+			name:     "default profile",
+			seccomp:  api.SeccompDefaultOptions{Enabled: true, DefaultProfile: "audit", Profiles: []api.SeccompProfile{{Name: "audit", Profile: "{}"}}},
+			expected: "localhost//var/lib/kubelet/seccomp/profiles/audit.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Security: api.SecurityOptions{SeccompDefault: test.seccomp},
+				},
+			}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Plugins map[string]map[string]any `toml:"plugins"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			assert.Equal(t, test.expected, parsed.Plugins["io.containerd.grpc.v1.cri"]["unset_seccomp_profile"])
+		})
+	}
+}
+
+func TestContainerdConfigGarbageCollection(t *testing.T) {
+	var tests = []struct {
+		name                     string
+		containerd               api.ContainerdOptions
+		expectedDiscard          bool
+		expectedSchedulerOptions map[string]any
+	}{
+		{
+			name:            "defaults",
+			expectedDiscard: true,
+		},
+		{
+			name: "keep unpacked layers",
+			containerd: api.ContainerdOptions{
+				DiscardUnpackedLayers: ptr.To(false),
+			},
+			expectedDiscard: false,
+		},
+		{
+			name: "scheduler",
+			containerd: api.ContainerdOptions{
+				GarbageCollection: api.ContainerdGarbageCollectionOptions{
+					PauseThresholdPercent: 5,
+					DeletionThreshold:     10,
+					ScheduleDelay:         &metav1.Duration{Duration: 5 * time.Second},
+				},
+			},
+			expectedDiscard: true,
+			expectedSchedulerOptions: map[string]any{
+				"pause_threshold":    0.05,
+				"deletion_threshold": int64(10),
+				"schedule_delay":     "5s",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: test.containerd}}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Plugins map[string]map[string]any `toml:"plugins"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			cri := parsed.Plugins["io.containerd.grpc.v1.cri"]["containerd"].(map[string]any)
+			assert.Equal(t, test.expectedDiscard, cri["discard_unpacked_layers"])
+			scheduler, ok := parsed.Plugins["io.containerd.gc.v1.scheduler"]
+			assert.Equal(t, test.expectedSchedulerOptions != nil, ok)
+			if ok {
+				assert.Equal(t, test.expectedSchedulerOptions, scheduler)
+			}
+		})
+	}
+}
+
+func TestContainerdConfigNRI(t *testing.T) {
+	var tests = []struct {
+		name        string
+		nri         api.ContainerdNRIOptions
+		expectedNRI map[string]any
+	}{
+		{name: "defaults"},
+		{
+			name:        "enabled",
+			nri:         api.ContainerdNRIOptions{Enabled: ptr.To(true)},
+			expectedNRI: map[string]any{"disable": false},
+		},
+		{
+			name:        "disabled",
+			nri:         api.ContainerdNRIOptions{Enabled: ptr.To(false)},
+			expectedNRI: map[string]any{"disable": true},
+		},
+		{
+			name: "socket and timeouts",
+			nri: api.ContainerdNRIOptions{
+				Enabled:                   ptr.To(true),
+				SocketPath:                "/run/nri/nri.sock",
+				PluginRegistrationTimeout: &metav1.Duration{Duration: 10 * time.Second},
+				PluginRequestTimeout:      &metav1.Duration{Duration: 500 * time.Millisecond},
+			},
+			expectedNRI: map[string]any{
+				"disable":                     false,
+				"socket_path":                 "/run/nri/nri.sock",
+				"plugin_registration_timeout": "10s",
+				"plugin_request_timeout":      "500ms",
+			},
+		},
+		{
+			name:        "default of containerd",
+			nri:         api.ContainerdNRIOptions{SocketPath: "/run/nri/nri.sock"},
+			expectedNRI: map[string]any{"socket_path": "/run/nri/nri.sock"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: api.ContainerdOptions{NRI: test.nri}}}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Plugins map[string]map[string]any `toml:"plugins"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			nri, ok := parsed.Plugins["io.containerd.nri.v1.nri"]
+			assert.Equal(t, test.expectedNRI != nil, ok)
+			if ok {
+				assert.Equal(t, test.expectedNRI, nri)
+			}
+		})
+	}
+}
+
+func TestContainerdConfigImagePulls(t *testing.T) {
+	var tests = []struct {
+		name              string
+		pulls             api.ContainerdImagePullOptions
+		containerdVersion string
+		expectedCRI       map[string]any
+		expectedTransfer  map[string]any
+	}{
+		{name: "defaults", containerdVersion: "v2.0.5"},
+		{
+			name:              "containerd 1.7",
+			pulls:             api.ContainerdImagePullOptions{MaxConcurrentDownloads: 10, ProgressTimeout: &metav1.Duration{Duration: 10 * time.Minute}},
+			containerdVersion: "v1.7.27",
+			expectedCRI:       map[string]any{"max_concurrent_downloads": int64(10), "image_pull_progress_timeout": "10m0s"},
+		},
+		{
+			name:              "containerd 2",
+			pulls:             api.ContainerdImagePullOptions{MaxConcurrentDownloads: 10},
+			containerdVersion: "v2.0.5",
+			expectedCRI:       map[string]any{"max_concurrent_downloads": int64(10)},
+			expectedTransfer:  map[string]any{"max_concurrent_downloads": int64(10)},
+		},
+		{
+			name:             "unknown version",
+			pulls:            api.ContainerdImagePullOptions{MaxConcurrentDownloads: 10},
+			expectedCRI:      map[string]any{"max_concurrent_downloads": int64(10)},
+			expectedTransfer: map[string]any{"max_concurrent_downloads": int64(10)},
+		},
+		{
+			name:              "progress timeout only",
+			pulls:             api.ContainerdImagePullOptions{ProgressTimeout: &metav1.Duration{Duration: time.Minute}},
+			containerdVersion: "v2.0.5",
+			expectedCRI:       map[string]any{"image_pull_progress_timeout": "1m0s"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec:   api.NodeConfigSpec{Containerd: api.ContainerdOptions{ImagePulls: test.pulls}},
+				Status: api.NodeConfigStatus{ContainerdVersion: test.containerdVersion},
+			}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Plugins map[string]map[string]any `toml:"plugins"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			for _, key := range []string{"max_concurrent_downloads", "image_pull_progress_timeout"} {
+				value, ok := parsed.Plugins["io.containerd.grpc.v1.cri"][key]
+				expected, expectedOK := test.expectedCRI[key]
+				assert.Equal(t, expectedOK, ok, key)
+				assert.Equal(t, expected, value, key)
+			}
+			transfer, ok := parsed.Plugins["io.containerd.transfer.v1.local"]
+			assert.Equal(t, test.expectedTransfer != nil, ok)
+			if ok {
+				assert.Equal(t, test.expectedTransfer, transfer)
+			}
+		})
+	}
+}
+
+func TestContainerdConfigMetricsAndDebug(t *testing.T) {
+	var tests = []struct {
+		name            string
+		containerd      api.ContainerdOptions
+		expectedMetrics map[string]any
+		expectedDebug   map[string]any
+	}{
+		{name: "defaults"},
+		{
+			name: "metrics",
+			containerd: api.ContainerdOptions{
+				Metrics: api.ContainerdMetricsOptions{Address: "127.0.0.1:1338", GRPCHistogram: true},
+			},
+			expectedMetrics: map[string]any{"address": "127.0.0.1:1338", "grpc_histogram": true},
+		},
+		{
+			name: "debug socket and level",
+			containerd: api.ContainerdOptions{
+				Debug: api.ContainerdDebugOptions{Address: "/run/containerd/debug.sock", Level: api.ContainerdLogLevelDebug},
+			},
+			expectedDebug: map[string]any{"address": "/run/containerd/debug.sock", "level": "debug"},
+		},
+		{
+			name: "level only",
+			containerd: api.ContainerdOptions{
+				Debug: api.ContainerdDebugOptions{Level: api.ContainerdLogLevelWarn},
+			},
+			expectedDebug: map[string]any{"level": "warn"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: test.containerd}}
+			containerdConfig, err := generateContainerdConfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Metrics map[string]any `toml:"metrics"`
+				Debug   map[string]any `toml:"debug"`
+			}
+			assert.NoError(t, toml.Unmarshal(containerdConfig, &parsed))
+			assert.Equal(t, test.expectedMetrics, parsed.Metrics)
+			assert.Equal(t, test.expectedDebug, parsed.Debug)
+		})
+	}
+}
+
+func TestRootConfig(t *testing.T) {
+	rootConfig := generateRootConfig()
+	assert.Equal(t, "version = 2\nimports = [\"/etc/containerd/config.d/*.toml\"]\n", string(rootConfig))
+	var parsed map[string]any
+	assert.NoError(t, toml.Unmarshal(rootConfig, &parsed))
+}
+
+func TestGetUserConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"50-registry.toml", "00-nodeadm.toml", "00-override.toml", "README.md", "10-gc.toml"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	files, err := getUserConfigFiles(dir, "00-nodeadm.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "00-override.toml"),
+		filepath.Join(dir, "10-gc.toml"),
+		filepath.Join(dir, "50-registry.toml"),
+	}, files)
+
+	files, err = getUserConfigFiles(filepath.Join(dir, "missing"), "00-nodeadm.toml")
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
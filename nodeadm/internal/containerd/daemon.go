@@ -1,13 +1,18 @@
 package containerd
 
 import (
+	"context"
+
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
 )
 
 const ContainerdDaemonName = "containerd"
 
-var _ daemon.Daemon = &containerd{}
+var (
+	_ daemon.Daemon      = &containerd{}
+	_ daemon.Restartable = &containerd{}
+)
 
 type containerd struct {
 	daemonManager daemon.DaemonManager
@@ -19,18 +24,37 @@ func NewContainerdDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
 	}
 }
 
-func (cd *containerd) Configure(c *api.NodeConfig) error {
+func (cd *containerd) Configure(ctx context.Context, c *api.NodeConfig) error {
+	if err := preflightRuntimeOptions(c); err != nil {
+		return err
+	}
+	if err := preflightUserNamespaces(c); err != nil {
+		return err
+	}
+	if err := preflightUntrustedWorkloadRuntime(c); err != nil {
+		return err
+	}
 	if err := writeBaseRuntimeSpec(c); err != nil {
 		return err
 	}
-	return writeContainerdConfig(c)
+	if err := writeRunscConfig(c); err != nil {
+		return err
+	}
+	if err := writeContainerdConfig(ctx, c); err != nil {
+		return err
+	}
+	return writeRegistryCredentialsState(c.Spec.Containerd.RegistryCredentials)
 }
 
 func (cd *containerd) EnsureRunning() error {
 	return cd.daemonManager.StartDaemon(ContainerdDaemonName)
 }
 
-func (cd *containerd) PostLaunch(c *api.NodeConfig) error {
+func (cd *containerd) Restart() error {
+	return cd.daemonManager.RestartDaemon(ContainerdDaemonName)
+}
+
+func (cd *containerd) PostLaunch(_ context.Context, c *api.NodeConfig) error {
 	return nil
 }
 
@@ -0,0 +1,26 @@
+package containerd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/golden"
+)
+
+func TestGolden(t *testing.T) {
+	// the runtimes that are installed on the host are not part of the golden
+	// files
+	previousMixins := mixins
+	mixins = nil
+	t.Cleanup(func() { mixins = previousMixins })
+
+	for _, c := range golden.Cases() {
+		t.Run(c.Name, func(t *testing.T) {
+			config, err := GenerateConfig(context.Background(), c.NodeConfig)
+			assert.NoError(t, err)
+			golden.Assert(t, c.Name+"/config.toml", config)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package containerd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const hostsAuthFilePerm = 0600
+
+// WriteAuthHostsDir writes a directory of registry hosts for `ctr --hosts-dir`
+// that only root can read, with the hosts of a registry in hostsDir and its
+// credentials as the Authorization header of its server, so that they are not
+// passed to ctr on the command line where any user of the node can read them.
+// The caller removes the directory once the image is pulled.
+func WriteAuthHostsDir(hostsDir string, registry string, username string, password string) (string, error) {
+	dir, err := os.MkdirTemp("", "nodeadm-hosts-")
+	if err != nil {
+		return "", err
+	}
+	if err := writeAuthHosts(filepath.Join(hostsDir, registry), filepath.Join(dir, registry), registry, username, password); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeAuthHosts copies the files of the hosts of a registry, such as its
+// certificate authorities, and adds the header to its hosts.toml.
+func writeAuthHosts(srcDir string, dstDir string, registry string, username string, password string) error {
+	if err := os.MkdirAll(dstDir, 0700); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(srcDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	hosts := map[string]any{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(srcDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if entry.Name() == "hosts.toml" {
+			if err := toml.Unmarshal(data, &hosts); err != nil {
+				return fmt.Errorf("failed to parse hosts of registry %s: %w", registry, err)
+			}
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dstDir, entry.Name()), data, hostsAuthFilePerm); err != nil {
+			return err
+		}
+	}
+	if _, ok := hosts["server"]; !ok {
+		hosts["server"] = "https://" + registry
+	}
+	header, _ := hosts["header"].(map[string]any)
+	if header == nil {
+		header = map[string]any{}
+	}
+	header["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	hosts["header"] = header
+	data, err := toml.Marshal(hosts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dstDir, "hosts.toml"), data, hostsAuthFilePerm)
+}
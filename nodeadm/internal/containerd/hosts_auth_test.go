@@ -0,0 +1,68 @@
+package containerd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAuthHostsDir(t *testing.T) {
+	const registry = "123456789012.dkr.ecr.us-west-2.amazonaws.com"
+	hostsDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(hostsDir, registry), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(hostsDir, registry, "hosts.toml"), []byte(`server = "https://`+registry+`"
+
+[host."https://mirror.example.com"]
+  capabilities = ["pull", "resolve"]
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(hostsDir, registry, "ca.crt"), []byte("certificate"), 0644))
+
+	var tests = []struct {
+		name           string
+		registry       string
+		expectedServer string
+		expectedFiles  []string
+	}{
+		{name: "with hosts", registry: registry, expectedServer: "https://" + registry, expectedFiles: []string{"ca.crt", "hosts.toml"}},
+		{name: "without hosts", registry: "public.ecr.aws", expectedServer: "https://public.ecr.aws", expectedFiles: []string{"hosts.toml"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := WriteAuthHostsDir(hostsDir, test.registry, "AWS", "password")
+			assert.NoError(t, err)
+			t.Cleanup(func() { os.RemoveAll(dir) })
+
+			entries, err := os.ReadDir(filepath.Join(dir, test.registry))
+			assert.NoError(t, err)
+			var files []string
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+				info, err := entry.Info()
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(hostsAuthFilePerm), info.Mode().Perm())
+			}
+			assert.Equal(t, test.expectedFiles, files)
+
+			data, err := os.ReadFile(filepath.Join(dir, test.registry, "hosts.toml"))
+			assert.NoError(t, err)
+			var hosts map[string]any
+			assert.NoError(t, toml.Unmarshal(data, &hosts))
+			assert.Equal(t, test.expectedServer, hosts["server"])
+			// base64 of AWS:password
+			assert.Equal(t, map[string]any{"Authorization": "Basic QVdTOnBhc3N3b3Jk"}, hosts["header"])
+		})
+	}
+
+	// the mirrors of the registry are kept, without the credentials
+	dir, err := WriteAuthHostsDir(hostsDir, registry, "AWS", "password")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	data, err := os.ReadFile(filepath.Join(dir, registry, "hosts.toml"))
+	assert.NoError(t, err)
+	var hosts map[string]any
+	assert.NoError(t, toml.Unmarshal(data, &hosts))
+	assert.Equal(t, map[string]any{"capabilities": []any{"pull", "resolve"}}, hosts["host"].(map[string]any)["https://mirror.example.com"])
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package containerd
+
+const ContainerRuntimeEndpoint = "unix:///run/containerd/containerd.sock"
+
+const (
+	// containerdConfigFile is the config that containerd loads, which imports
+	// the files of containerdConfigDir.
+	containerdConfigFile = "/etc/containerd/config.toml"
+	containerdConfigDir  = "/etc/containerd/config.d"
+	// containerdNodeadmConfigFile sorts before the files that users add to
+	// containerdConfigDir, so that theirs take precedence.
+	containerdNodeadmConfigFile = "/etc/containerd/config.d/00-nodeadm.toml"
+)
+
+// RegistryHostsDir is the directory of the registry hosts of containerd,
+// which ctr reads with --hosts-dir.
+const RegistryHostsDir = "/etc/containerd/certs.d"
+
+var platformPaths = containerdPaths{
+	Root:               "/var/lib/containerd",
+	State:              "/run/containerd",
+	Address:            "/run/containerd/containerd.sock",
+	RegistryConfigPath: "/etc/containerd/certs.d:/etc/docker/certs.d",
+	CNIBinDir:          "/opt/cni/bin",
+	CNIConfDir:         "/etc/cni/net.d",
+}
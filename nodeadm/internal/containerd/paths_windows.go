@@ -0,0 +1,23 @@
+package containerd
+
+const ContainerRuntimeEndpoint = "npipe:////./pipe/containerd-containerd"
+
+const (
+	containerdConfigFile        = `C:\Program Files\containerd\config.toml`
+	containerdConfigDir         = `C:\Program Files\containerd\config.d`
+	containerdNodeadmConfigFile = `C:\Program Files\containerd\config.d\00-nodeadm.toml`
+)
+
+// RegistryHostsDir is the directory of the registry hosts of containerd,
+// which ctr reads with --hosts-dir.
+const RegistryHostsDir = `C:\Program Files\containerd\certs.d`
+
+// platformPaths follow the layout of the EKS optimized Windows AMIs.
+var platformPaths = containerdPaths{
+	Root:               `C:\ProgramData\containerd\root`,
+	State:              `C:\ProgramData\containerd\state`,
+	Address:            `\\.\pipe\containerd-containerd`,
+	RegistryConfigPath: `C:\Program Files\containerd\certs.d`,
+	CNIBinDir:          `C:\Program Files\Amazon\EKS\cni`,
+	CNIConfDir:         `C:\ProgramData\Amazon\EKS\cni\config`,
+}
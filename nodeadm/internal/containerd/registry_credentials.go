@@ -0,0 +1,173 @@
+package containerd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/pelletier/go-toml/v2"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// RegistryCredentialsStatePath records the secrets of the registry
+	// credentials, so that they can be refreshed without the NodeConfig.
+	RegistryCredentialsStatePath = "/var/lib/nodeadm/registry-credentials.json"
+	registryCredentialsStatePerm = 0600
+	// the config holds the passwords of the registries once they are
+	// configured
+	containerdSecretConfigPerm = 0600
+	secretFetchTimeout         = 30 * time.Second
+)
+
+// registrySecret is the value of a secret of a registry credential.
+type registrySecret struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// secretsClient is the subset of the Secrets Manager API that is used to
+// fetch the secrets of registry credentials.
+type secretsClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// newSecretsClient is replaced in tests, which do not call AWS APIs.
+var newSecretsClient = func(ctx context.Context, region string) (secretsClient, error) {
+	awsConfig, err := awsconfig.Load(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return secretsmanager.NewFromConfig(awsConfig), nil
+}
+
+// withRegistryCredentials adds the auth of each registry credential to the
+// registry configs of the CRI plugin, replacing the auth they had before.
+func withRegistryCredentials(ctx context.Context, containerdConfig []byte, credentials []api.RegistryCredential) ([]byte, error) {
+	registryConfigs := map[string]any{}
+	for _, credential := range credentials {
+		zap.L().Info("Fetching registry credential..", zap.String("registry", credential.Registry), zap.String("secretArn", credential.SecretARN))
+		secret, err := fetchRegistrySecret(ctx, credential.SecretARN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the credential of registry %s: %w", credential.Registry, err)
+		}
+		registryConfigs[credential.Registry] = map[string]any{
+			"auth": map[string]any{
+				"username": secret.Username,
+				"password": secret.Password,
+			},
+		}
+	}
+	authConfig, err := toml.Marshal(map[string]any{
+		"plugins": map[string]any{
+			"io.containerd.grpc.v1.cri": map[string]any{
+				"registry": map[string]any{
+					"configs": registryConfigs,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	containerdConfigMap, err := util.Merge(containerdConfig, authConfig, toml.Marshal, toml.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return toml.Marshal(containerdConfigMap)
+}
+
+// fetchRegistrySecret fetches a secret from the region of its ARN, retrying
+// transient failures.
+func fetchRegistrySecret(ctx context.Context, secretARN string) (*registrySecret, error) {
+	parsedARN, err := arn.Parse(secretARN)
+	if err != nil {
+		return nil, err
+	}
+	var secretString string
+	err = util.NewRetrier(util.WithRetryCount(5)).Retry(ctx, func() error {
+		ctx, cancel := context.WithTimeout(ctx, secretFetchTimeout)
+		defer cancel()
+		client, err := newSecretsClient(ctx, parsedARN.Region)
+		if err != nil {
+			return err
+		}
+		out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secretARN),
+		})
+		if err != nil {
+			zap.L().Warn("Failed to fetch secret", zap.String("secretArn", secretARN), zap.Error(err))
+			return err
+		}
+		secretString = aws.ToString(out.SecretString)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var secret registrySecret
+	if err := json.Unmarshal([]byte(secretString), &secret); err != nil {
+		// the error would include the value of the secret
+		return nil, fmt.Errorf("secret is not a JSON object with username and password fields")
+	}
+	if secret.Username == "" || secret.Password == "" {
+		return nil, fmt.Errorf("secret must have username and password fields")
+	}
+	return &secret, nil
+}
+
+// writeRegistryCredentialsState records the registry credentials for
+// RefreshRegistryCredentials, or removes the record when there are none.
+func writeRegistryCredentialsState(credentials []api.RegistryCredential) error {
+	if len(credentials) == 0 {
+		if err := os.Remove(RegistryCredentialsStatePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileWithDir(RegistryCredentialsStatePath, data, registryCredentialsStatePerm)
+}
+
+// RefreshRegistryCredentials fetches the secrets of the registry credentials
+// that were configured by init again, so that rotated secrets are picked up,
+// and returns whether the containerd config was changed. containerd must be
+// restarted to use the changed config.
+func RefreshRegistryCredentials(ctx context.Context) (bool, error) {
+	data, err := os.ReadFile(RegistryCredentialsStatePath)
+	if err != nil {
+		return false, err
+	}
+	var credentials []api.RegistryCredential
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s: %w", RegistryCredentialsStatePath, err)
+	}
+	containerdConfig, err := os.ReadFile(containerdNodeadmConfigFile)
+	if err != nil {
+		return false, err
+	}
+	refreshedConfig, err := withRegistryCredentials(ctx, containerdConfig, credentials)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(containerdConfig, refreshedConfig) {
+		return false, nil
+	}
+	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdNodeadmConfigFile))
+	return true, util.WriteFileWithDir(containerdNodeadmConfigFile, refreshedConfig, containerdSecretConfigPerm)
+}
@@ -0,0 +1,83 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const registrySecretARN = "arn:aws:secretsmanager:eu-west-1:123456789012:secret:registry-AbCdEf"
+
+type fakeSecretsClient struct {
+	secrets map[string]string
+}
+
+func (c *fakeSecretsClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	secret, ok := c.secrets[aws.ToString(params.SecretId)]
+	if !ok {
+		return nil, fmt.Errorf("secret %s not found", aws.ToString(params.SecretId))
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(secret)}, nil
+}
+
+func withFakeSecrets(t *testing.T, secrets map[string]string) {
+	original := newSecretsClient
+	newSecretsClient = func(ctx context.Context, region string) (secretsClient, error) {
+		assert.Equal(t, "eu-west-1", region)
+		return &fakeSecretsClient{secrets: secrets}, nil
+	}
+	t.Cleanup(func() { newSecretsClient = original })
+}
+
+func TestWithRegistryCredentials(t *testing.T) {
+	withFakeSecrets(t, map[string]string{registrySecretARN: `{"username":"robot","password":"hunter2"}`})
+	cfg := api.NodeConfig{}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	credentials := []api.RegistryCredential{{Registry: "registry.example.com:5000", SecretARN: registrySecretARN}}
+	containerdConfig, err = withRegistryCredentials(context.Background(), containerdConfig, credentials)
+	assert.NoError(t, err)
+
+	var configMap map[string]any
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &configMap))
+	registry, _ := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "registry")
+	assert.Equal(t, map[string]any{
+		"registry.example.com:5000": map[string]any{
+			"auth": map[string]any{"username": "robot", "password": "hunter2"},
+		},
+	}, registry["configs"])
+	// the hosts of the registries are still configured in certs.d
+	assert.Equal(t, platformPaths.RegistryConfigPath, registry["config_path"])
+
+	// a config that already has the credentials is unchanged, so that a
+	// refresh does not restart containerd unless a secret was rotated
+	refreshedConfig, err := withRegistryCredentials(context.Background(), containerdConfig, credentials)
+	assert.NoError(t, err)
+	assert.Equal(t, string(containerdConfig), string(refreshedConfig))
+}
+
+func TestFetchRegistrySecretInvalid(t *testing.T) {
+	var tests = []struct {
+		name   string
+		secret string
+	}{
+		{name: "not JSON", secret: "robot:hunter2"},
+		{name: "missing password", secret: `{"username":"robot"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withFakeSecrets(t, map[string]string{registrySecretARN: test.secret})
+			_, err := fetchRegistrySecret(context.Background(), registrySecretARN)
+			assert.Error(t, err)
+			assert.NotContains(t, err.Error(), "hunter2")
+		})
+	}
+}
@@ -12,13 +12,24 @@ type runtimeConfigMixin interface {
 	Matches(*api.NodeConfig) bool
 }
 
+// runtimeConfigPreflight is implemented by mixins that depend on the node
+// being set up for the runtime they apply.
+type runtimeConfigPreflight interface {
+	Preflight() error
+}
+
 const (
 	defaultRuntimeName       = "runc"
 	defaultRuntimeBinaryPath = "/usr/sbin/runc"
 )
 
+// mixins are applied in order, so the NVIDIA and Neuron runtimes take
+// precedence over the default runtime binary on the instances that require
+// them.
 var mixins = []runtimeConfigMixin{
+	NewCrunRuntimeConfigMixin(),
 	NewNvidiaRuntimeConfigMixin(),
+	NewNeuronRuntimeConfigMixin(),
 }
 
 // getRuntimeOptions adds the needed OCI hook options to containerd config.toml
@@ -35,3 +46,16 @@ func getRuntimeOptions(cfg *api.NodeConfig) runtimeConfig {
 	}
 	return options
 }
+
+// preflightRuntimeOptions runs the preflight checks of the mixins that apply
+// to the node.
+func preflightRuntimeOptions(cfg *api.NodeConfig) error {
+	for _, mixin := range mixins {
+		if preflight, ok := mixin.(runtimeConfigPreflight); ok && mixin.Matches(cfg) {
+			if err := preflight.Preflight(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
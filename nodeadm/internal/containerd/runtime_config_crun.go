@@ -0,0 +1,43 @@
+package containerd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"go.uber.org/zap"
+)
+
+const (
+	crunRuntimeName       = "crun"
+	crunRuntimeBinaryPath = "/usr/bin/crun"
+)
+
+func NewCrunRuntimeConfigMixin() *crunRuntimeConfigMixin {
+	return &crunRuntimeConfigMixin{
+		runtimeBinaryPath: crunRuntimeBinaryPath,
+	}
+}
+
+type crunRuntimeConfigMixin struct {
+	runtimeBinaryPath string
+}
+
+func (m *crunRuntimeConfigMixin) Matches(cfg *api.NodeConfig) bool {
+	return cfg.Spec.Containerd.DefaultRuntimeBinary == api.RuntimeBinaryCrun
+}
+
+func (m *crunRuntimeConfigMixin) Apply(opts *runtimeConfig) {
+	zap.L().Info("Configuring crun runtime..")
+	opts.RuntimeName = crunRuntimeName
+	opts.RuntimeBinaryPath = m.runtimeBinaryPath
+}
+
+// Preflight ensures that crun is installed, as containerd would otherwise
+// start successfully and only fail once pods are scheduled to the node.
+func (m *crunRuntimeConfigMixin) Preflight() error {
+	if _, err := os.Stat(m.runtimeBinaryPath); err != nil {
+		return fmt.Errorf("crun is the default runtime binary, but it is not installed: %w", err)
+	}
+	return nil
+}
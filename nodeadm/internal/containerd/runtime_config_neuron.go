@@ -0,0 +1,49 @@
+package containerd
+
+import (
+	"os"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"go.uber.org/zap"
+)
+
+const (
+	neuronRuntimeName = "neuron"
+	// the wrapper of the OCI hook installed by aws-neuronx-oci-hook, which
+	// gives a container the devices in its AWS_NEURON_VISIBLE_DEVICES
+	neuronRuntimeBinaryPath = "/opt/aws/neuron/bin/oci_neuron_hook_wrapper.sh"
+)
+
+func NewNeuronRuntimeConfigMixin() *neuronRuntimeConfigMixin {
+	return &neuronRuntimeConfigMixin{
+		runtimeBinaryPath: neuronRuntimeBinaryPath,
+		countDevices:      system.CountNeuronDevices,
+	}
+}
+
+type neuronRuntimeConfigMixin struct {
+	runtimeBinaryPath string
+	countDevices      func() (int, error)
+}
+
+func (m *neuronRuntimeConfigMixin) Matches(cfg *api.NodeConfig) bool {
+	if !cfg.Spec.Instance.Neuron.Enabled {
+		return false
+	}
+	if _, err := os.Stat(m.runtimeBinaryPath); err != nil {
+		return false
+	}
+	devices, err := m.countDevices()
+	if err != nil {
+		zap.L().Warn("Failed to count Neuron devices", zap.Error(err))
+		return false
+	}
+	return devices > 0
+}
+
+func (m *neuronRuntimeConfigMixin) Apply(opts *runtimeConfig) {
+	zap.L().Info("Configuring Neuron runtime..")
+	opts.RuntimeName = neuronRuntimeName
+	opts.RuntimeBinaryPath = m.runtimeBinaryPath
+}
@@ -0,0 +1,170 @@
+package containerd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	runscBinaryPath           = "/usr/local/bin/runsc"
+	runscShimBinaryPath       = "/usr/local/bin/containerd-shim-runsc-v1"
+	runscConfigPath           = "/etc/containerd/runsc.toml"
+	runscKVMDevicePath        = "/dev/kvm"
+	runscReleaseVersionPrefix = "release-"
+)
+
+// runscFlag is a flag of runsc, which is passed to each sandbox through the
+// configuration file of the runsc shim.
+type runscFlag struct {
+	Name  string
+	Value string
+	// MinVersion is the oldest release of runsc that supports the value.
+	MinVersion string
+}
+
+// runscFlagMinVersions are the oldest releases of runsc that support each
+// value of a flag, for the values that have not always been supported.
+var runscFlagMinVersions = map[string]string{
+	"platform=" + string(api.RunscPlatformSystrap): "release-20230417.0",
+	"overlay2=" + string(api.RunscOverlayRootSelf): "release-20230605.0",
+}
+
+func newRunscFlag(name string, value string) runscFlag {
+	return runscFlag{
+		Name:       name,
+		Value:      value,
+		MinVersion: runscFlagMinVersions[name+"="+value],
+	}
+}
+
+// getRunscFlags returns the flags that are rendered from the runsc options,
+// omitting those that use the defaults of runsc.
+func getRunscFlags(cfg *api.NodeConfig) []runscFlag {
+	runsc := cfg.Spec.Containerd.Runsc
+	var flags []runscFlag
+	if runsc.Platform != "" {
+		flags = append(flags, newRunscFlag("platform", string(runsc.Platform)))
+	}
+	if runsc.Network != "" {
+		flags = append(flags, newRunscFlag("network", string(runsc.Network)))
+	}
+	if runsc.Overlay != "" {
+		flags = append(flags, newRunscFlag("overlay2", string(runsc.Overlay)))
+	}
+	// sandboxes are placed in the same cgroups as the containers of runc
+	flags = append(flags, newRunscFlag("systemd-cgroup", strconv.FormatBool(cfg.GetCgroupDriver() == api.CgroupDriverSystemd)))
+	return flags
+}
+
+func generateRunscConfig(flags []runscFlag) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("[runsc_config]\n")
+	for _, flag := range flags {
+		fmt.Fprintf(&buf, "%s = %q\n", flag.Name, flag.Value)
+	}
+	return buf.Bytes()
+}
+
+// writeRunscConfig writes the configuration of the runsc shim when a runsc
+// runtime is declared, and removes it otherwise.
+func writeRunscConfig(cfg *api.NodeConfig) error {
+	if cfg.Spec.Containerd.Runsc == nil {
+		if err := os.Remove(runscConfigPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	flags := getRunscFlags(cfg)
+	if err := preflightRunsc(runscBinaryPath, runscShimBinaryPath, runscKVMDevicePath, cfg.Spec.Containerd.Runsc, flags); err != nil {
+		return err
+	}
+	zap.L().Info("Writing runsc config to file..", zap.String("path", runscConfigPath))
+	return util.WriteFileWithDir(runscConfigPath, generateRunscConfig(flags), containerdConfigPerm)
+}
+
+// preflightRunsc ensures that runsc is installed and supports each of the
+// flags, as containerd would otherwise start successfully and only fail once
+// pods using the runtime are scheduled to the node.
+func preflightRunsc(binaryPath string, shimBinaryPath string, kvmDevicePath string, runsc *api.RunscOptions, flags []runscFlag) error {
+	for _, path := range []string{binaryPath, shimBinaryPath} {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("a runsc runtime is declared, but %s is not installed: %w", path, err)
+		}
+	}
+	if runsc.Platform == api.RunscPlatformKVM {
+		if _, err := os.Stat(kvmDevicePath); err != nil {
+			return fmt.Errorf("runsc platform %s requires %s, which is only available on bare metal instances: %w", api.RunscPlatformKVM, kvmDevicePath, err)
+		}
+	}
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command(binaryPath, "--version").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the version of runsc: %w", err)
+	}
+	version, ok := parseRunscVersion(string(out))
+	if !ok {
+		zap.L().Warn("Not checking the flags of runsc, since its version is not a release", zap.String("output", string(out)))
+		return nil
+	}
+	for _, flag := range flags {
+		if flag.MinVersion != "" && compareRunscVersions(version, flag.MinVersion) < 0 {
+			return fmt.Errorf("runsc %s does not support %s=%s, which requires %s or later", version, flag.Name, flag.Value, flag.MinVersion)
+		}
+	}
+	return nil
+}
+
+// parseRunscVersion returns the release from the output of `runsc --version`,
+// such as `release-20240401.0` from `runsc version release-20240401.0`.
+// Builds that are not releases have no comparable version.
+func parseRunscVersion(output string) (string, bool) {
+	firstLine, _, _ := strings.Cut(output, "\n")
+	fields := strings.Fields(firstLine)
+	if len(fields) != 3 || fields[0] != "runsc" || fields[1] != "version" {
+		return "", false
+	}
+	if _, _, ok := splitRunscVersion(fields[2]); !ok {
+		return "", false
+	}
+	return fields[2], true
+}
+
+// compareRunscVersions compares two releases of runsc, which are named after
+// the date they were cut and a patch number, such as `release-20240401.0`.
+func compareRunscVersions(a string, b string) int {
+	aDate, aPatch, _ := splitRunscVersion(a)
+	bDate, bPatch, _ := splitRunscVersion(b)
+	if aDate != bDate {
+		return aDate - bDate
+	}
+	return aPatch - bPatch
+}
+
+func splitRunscVersion(version string) (int, int, bool) {
+	release, found := strings.CutPrefix(version, runscReleaseVersionPrefix)
+	if !found {
+		return 0, 0, false
+	}
+	dateString, patchString, _ := strings.Cut(release, ".")
+	date, err := strconv.Atoi(dateString)
+	if err != nil {
+		return 0, 0, false
+	}
+	patch := 0
+	if patchString != "" {
+		if patch, err = strconv.Atoi(patchString); err != nil {
+			return 0, 0, false
+		}
+	}
+	return date, patch, true
+}
@@ -0,0 +1,106 @@
+package containerd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunscConfig(t *testing.T) {
+	var tests = []struct {
+		name     string
+		runsc    api.RunscOptions
+		driver   api.CgroupDriver
+		expected string
+	}{
+		{
+			name:     "defaults",
+			expected: "[runsc_config]\nsystemd-cgroup = \"true\"\n",
+		},
+		{
+			name:     "all flags",
+			runsc:    api.RunscOptions{Platform: api.RunscPlatformSystrap, Network: api.RunscNetworkHost, Overlay: api.RunscOverlayRootSelf},
+			driver:   api.CgroupDriverCgroupfs,
+			expected: "[runsc_config]\nplatform = \"systrap\"\nnetwork = \"host\"\noverlay2 = \"root:self\"\nsystemd-cgroup = \"false\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Containerd: api.ContainerdOptions{Runsc: &test.runsc},
+					Instance:   api.InstanceOptions{Cgroup: api.CgroupOptions{Driver: test.driver}},
+				},
+			}
+			assert.Equal(t, test.expected, string(generateRunscConfig(getRunscFlags(&cfg))))
+		})
+	}
+}
+
+func TestContainerdConfigRunsc(t *testing.T) {
+	cfg := api.NodeConfig{}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(containerdConfig), "runsc")
+
+	cfg.Spec.Containerd.Runsc = &api.RunscOptions{}
+	containerdConfig, err = generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(containerdConfig), `runtime_type = "io.containerd.runsc.v1"`)
+	assert.Contains(t, string(containerdConfig), `ConfigPath = "/etc/containerd/runsc.toml"`)
+}
+
+func TestParseRunscVersion(t *testing.T) {
+	var tests = []struct {
+		output   string
+		expected string
+		ok       bool
+	}{
+		{output: "runsc version release-20240401.0\nspec: 1.1.0-rc.1\n", expected: "release-20240401.0", ok: true},
+		{output: "runsc version release-20230417\n", expected: "release-20230417", ok: true},
+		{output: "runsc version VERSION_MISSING\nspec: 1.1.0-rc.1\n"},
+		{output: ""},
+	}
+
+	for _, test := range tests {
+		version, ok := parseRunscVersion(test.output)
+		assert.Equal(t, test.expected, version)
+		assert.Equal(t, test.ok, ok)
+	}
+}
+
+func TestPreflightRunsc(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "runsc")
+	shimBinaryPath := filepath.Join(dir, "containerd-shim-runsc-v1")
+	kvmDevicePath := filepath.Join(dir, "kvm")
+	writeRunsc := func(version string) {
+		script := "#!/bin/sh\necho 'runsc version " + version + "'\necho 'spec: 1.1.0-rc.1'\n"
+		assert.NoError(t, os.WriteFile(binaryPath, []byte(script), 0755))
+	}
+	preflight := func(runsc api.RunscOptions) error {
+		cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: api.ContainerdOptions{Runsc: &runsc}}}
+		return preflightRunsc(binaryPath, shimBinaryPath, kvmDevicePath, &runsc, getRunscFlags(&cfg))
+	}
+
+	assert.ErrorContains(t, preflight(api.RunscOptions{}), "is not installed")
+
+	writeRunsc("release-20230301.0")
+	assert.NoError(t, os.WriteFile(shimBinaryPath, nil, 0755))
+	assert.NoError(t, preflight(api.RunscOptions{}))
+	assert.ErrorContains(t, preflight(api.RunscOptions{Platform: api.RunscPlatformSystrap}), "requires release-20230417.0 or later")
+
+	writeRunsc("release-20240401.0")
+	assert.NoError(t, preflight(api.RunscOptions{Platform: api.RunscPlatformSystrap, Overlay: api.RunscOverlayRootSelf}))
+	assert.ErrorContains(t, preflight(api.RunscOptions{Platform: api.RunscPlatformKVM}), "only available on bare metal instances")
+	assert.NoError(t, os.WriteFile(kvmDevicePath, nil, 0644))
+	assert.NoError(t, preflight(api.RunscOptions{Platform: api.RunscPlatformKVM}))
+
+	// versions that are not releases cannot be checked
+	writeRunsc("VERSION_MISSING")
+	assert.NoError(t, preflight(api.RunscOptions{Platform: api.RunscPlatformSystrap}))
+}
@@ -36,3 +36,57 @@ func TestNvidiaRuntimeOptionsMixin(t *testing.T) {
 
 	assert.Equal(t, expectedRuntimeConfig, actualRuntimeConfig)
 }
+
+func TestNeuronRuntimeOptionsMixin(t *testing.T) {
+	mockHookPath := filepath.Join(t.TempDir(), "oci_neuron_hook_wrapper.sh")
+	var devices int
+	mixin := neuronRuntimeConfigMixin{
+		runtimeBinaryPath: mockHookPath,
+		countDevices:      func() (int, error) { return devices, nil },
+	}
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Instance: api.InstanceOptions{Neuron: api.NeuronOptions{Enabled: true}},
+		},
+	}
+
+	devices = 2
+	assert.False(t, mixin.Matches(&api.NodeConfig{}))
+	assert.False(t, mixin.Matches(&cfg), "hook is not installed")
+	_, err := os.Create(mockHookPath)
+	assert.NoError(t, err)
+	assert.True(t, mixin.Matches(&cfg))
+	devices = 0
+	assert.False(t, mixin.Matches(&cfg), "instance has no Neuron devices")
+
+	var actualRuntimeConfig runtimeConfig
+	mixin.Apply(&actualRuntimeConfig)
+	assert.Equal(t, runtimeConfig{RuntimeName: neuronRuntimeName, RuntimeBinaryPath: mockHookPath}, actualRuntimeConfig)
+}
+
+func TestCrunRuntimeOptionsMixin(t *testing.T) {
+	mockCrunPath := filepath.Join(t.TempDir(), "crun")
+	mixin := crunRuntimeConfigMixin{runtimeBinaryPath: mockCrunPath}
+
+	assert.False(t, mixin.Matches(&api.NodeConfig{}))
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{DefaultRuntimeBinary: api.RuntimeBinaryCrun},
+		},
+	}
+	assert.True(t, mixin.Matches(&cfg))
+
+	assert.Error(t, mixin.Preflight())
+	_, err := os.Create(mockCrunPath)
+	assert.NoError(t, err)
+	assert.NoError(t, mixin.Preflight())
+
+	expectedRuntimeConfig := runtimeConfig{
+		RuntimeName:       crunRuntimeName,
+		RuntimeBinaryPath: mockCrunPath,
+	}
+	var actualRuntimeConfig runtimeConfig
+	mixin.Apply(&actualRuntimeConfig)
+
+	assert.Equal(t, expectedRuntimeConfig, actualRuntimeConfig)
+}
@@ -0,0 +1,132 @@
+package containerd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/mod/semver"
+)
+
+// withRuntimes adds the runtimes of the NodeConfig as runtime handlers of the
+// CRI plugin, and routes pods to the default and untrusted workload runtimes.
+// They are added before the user's containerd config is merged, so that it
+// may still override them.
+func withRuntimes(containerdConfig []byte, cfg *api.NodeConfig) ([]byte, error) {
+	options := cfg.Spec.Containerd
+	if len(options.Runtimes) == 0 && options.DefaultRuntime == "" && options.UntrustedWorkloadRuntime == "" {
+		return containerdConfig, nil
+	}
+	var configMap map[string]any
+	if err := toml.Unmarshal(containerdConfig, &configMap); err != nil {
+		return nil, err
+	}
+	criContainerd, ok := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "containerd")
+	if !ok {
+		return nil, fmt.Errorf("containerd config does not have a CRI plugin")
+	}
+	runtimes, ok := getTable(criContainerd, "runtimes")
+	if !ok {
+		return nil, fmt.Errorf("containerd config does not have any runtimes")
+	}
+	for _, runtime := range cfg.Spec.Containerd.Runtimes {
+		if _, ok := runtimes[runtime.Name]; ok {
+			return nil, fmt.Errorf("runtime %s is already configured by nodeadm", runtime.Name)
+		}
+		table := map[string]any{
+			"runtime_type": runtime.RuntimeType,
+		}
+		if runtime.Snapshotter != "" {
+			table["snapshotter"] = runtime.Snapshotter
+		}
+		if runtime.PrivilegedWithoutHostDevices {
+			table["privileged_without_host_devices"] = true
+		}
+		if len(runtime.Options) > 0 {
+			options, err := getRuntimeShimOptions(runtime.Options)
+			if err != nil {
+				return nil, fmt.Errorf("invalid options of runtime %s: %w", runtime.Name, err)
+			}
+			table["options"] = options
+		}
+		runtimes[runtime.Name] = table
+	}
+	if name := options.DefaultRuntime; name != "" {
+		if _, ok := runtimes[name]; !ok {
+			return nil, fmt.Errorf("default runtime %s is not a runtime of containerd", name)
+		}
+		criContainerd["default_runtime_name"] = name
+	}
+	if name := options.UntrustedWorkloadRuntime; name != "" {
+		runtime, ok := runtimes[name]
+		if !ok {
+			return nil, fmt.Errorf("untrusted workload runtime %s is not a runtime of containerd", name)
+		}
+		// untrusted workloads predate named runtime handlers, so the runtime
+		// is copied rather than referenced by its name.
+		criContainerd["untrusted_workload_runtime"] = runtime
+	}
+	return toml.Marshal(configMap)
+}
+
+// preflightUntrustedWorkloadRuntime ensures that the installed containerd
+// still supports untrusted workloads, which were removed in containerd 2.0.
+func preflightUntrustedWorkloadRuntime(cfg *api.NodeConfig) error {
+	if cfg.Spec.Containerd.UntrustedWorkloadRuntime == "" {
+		return nil
+	}
+	version, err := GetContainerdVersion()
+	if err != nil {
+		return err
+	}
+	return checkUntrustedWorkloadSupport(version)
+}
+
+func checkUntrustedWorkloadSupport(version string) error {
+	if semver.Compare(version, "v2.0.0") >= 0 {
+		return fmt.Errorf("untrusted workload runtime requires containerd 1.x, found %s, use a RuntimeClass instead", version)
+	}
+	return nil
+}
+
+// getRuntimeShimOptions decodes the options of a runtime into TOML values.
+// Numbers are decoded as they were written, since shims reject a float where
+// they expect an integer.
+func getRuntimeShimOptions(options api.InlineDocument) (map[string]any, error) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded map[string]any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return toTOMLValue(decoded).(map[string]any), nil
+}
+
+func toTOMLValue(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]any:
+		for key, child := range value {
+			value[key] = toTOMLValue(child)
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = toTOMLValue(child)
+		}
+		return value
+	default:
+		return value
+	}
+}
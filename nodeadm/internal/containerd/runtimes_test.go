@@ -0,0 +1,97 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestContainerdConfigRuntimes(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runtimes: []api.ContainerdRuntime{
+					{
+						Name:        "kata-qemu",
+						RuntimeType: "io.containerd.kata.v2",
+						Options: api.InlineDocument{
+							"ConfigPath": runtime.RawExtension{Raw: []byte(`"/opt/kata/share/defaults/kata-containers/configuration-qemu.toml"`)},
+							"Timeout":    runtime.RawExtension{Raw: []byte(`30`)},
+						},
+						Snapshotter:                  "devmapper",
+						PrivilegedWithoutHostDevices: true,
+					},
+					{
+						Name:        "runhcs",
+						RuntimeType: "io.containerd.runhcs.v1",
+					},
+				},
+			},
+		},
+	}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	containerdConfig, err = withRuntimes(containerdConfig, &cfg)
+	assert.NoError(t, err)
+	var configMap map[string]any
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &configMap))
+	runtimes, _ := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes")
+	assert.Equal(t, map[string]any{
+		"runtime_type":                    "io.containerd.kata.v2",
+		"snapshotter":                     "devmapper",
+		"privileged_without_host_devices": true,
+		"options": map[string]any{
+			"ConfigPath": "/opt/kata/share/defaults/kata-containers/configuration-qemu.toml",
+			"Timeout":    int64(30),
+		},
+	}, runtimes["kata-qemu"])
+	assert.Equal(t, map[string]any{"runtime_type": "io.containerd.runhcs.v1"}, runtimes["runhcs"])
+	assert.Contains(t, runtimes, "runc")
+
+	cfg.Spec.Containerd.Runtimes = []api.ContainerdRuntime{{Name: "runc", RuntimeType: "io.containerd.runc.v2"}}
+	_, err = withRuntimes(containerdConfig, &cfg)
+	assert.ErrorContains(t, err, "runtime runc is already configured by nodeadm")
+}
+
+func TestContainerdConfigRuntimeRouting(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runsc: &api.RunscOptions{},
+				Runtimes: []api.ContainerdRuntime{
+					{Name: "kata-qemu", RuntimeType: "io.containerd.kata.v2"},
+				},
+				DefaultRuntime:           "runsc",
+				UntrustedWorkloadRuntime: "kata-qemu",
+			},
+		},
+	}
+	containerdConfig, err := generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	containerdConfig, err = withRuntimes(containerdConfig, &cfg)
+	assert.NoError(t, err)
+	var configMap map[string]any
+	assert.NoError(t, toml.Unmarshal(containerdConfig, &configMap))
+	criContainerd, _ := getTable(configMap, "plugins", "io.containerd.grpc.v1.cri", "containerd")
+	assert.Equal(t, "runsc", criContainerd["default_runtime_name"])
+	assert.Equal(t, map[string]any{"runtime_type": "io.containerd.kata.v2"}, criContainerd["untrusted_workload_runtime"])
+
+	cfg.Spec.Containerd.Runtimes = nil
+	containerdConfig, err = generateContainerdConfig(&cfg)
+	assert.NoError(t, err)
+	_, err = withRuntimes(containerdConfig, &cfg)
+	assert.ErrorContains(t, err, "untrusted workload runtime kata-qemu is not a runtime of containerd")
+
+	cfg.Spec.Containerd.DefaultRuntime = "kata-qemu"
+	_, err = withRuntimes(containerdConfig, &cfg)
+	assert.ErrorContains(t, err, "default runtime kata-qemu is not a runtime of containerd")
+}
+
+func TestCheckUntrustedWorkloadSupport(t *testing.T) {
+	assert.NoError(t, checkUntrustedWorkloadSupport("v1.7.27"))
+	assert.ErrorContains(t, checkUntrustedWorkloadSupport("v2.0.2"), "requires containerd 1.x")
+}
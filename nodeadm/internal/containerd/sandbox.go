@@ -0,0 +1,44 @@
+package containerd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
+)
+
+// defaultSandboxImage is the pause image that is cached and pinned on the AMI
+// when it is built, so that it never has to be pulled.
+const defaultSandboxImage = "localhost/kubernetes/pause"
+
+// GetSandboxImage returns the reference of the sandbox (pause) image. An image
+// without a registry, such as `eks/pause:3.10`, is pulled from the EKS
+// registry in the node's region.
+func GetSandboxImage(cfg *api.NodeConfig) (string, error) {
+	image := cfg.Spec.Containerd.SandboxImage
+	if image == "" {
+		return defaultSandboxImage, nil
+	}
+	if hasRegistry(image) {
+		return image, nil
+	}
+	if cfg.Spec.Offline {
+		return "", fmt.Errorf("sandbox image %s must include a registry in offline mode, since it would be pulled from the EKS registry of the region", image)
+	}
+	registry, err := ecr.GetEKSRegistry(cfg.Status.Instance.Region)
+	if err != nil {
+		return "", err
+	}
+	return registry.GetImageReference(image), nil
+}
+
+// hasRegistry follows the convention of container image references, where the
+// first component is a registry if it is `localhost` or looks like a host name.
+func hasRegistry(image string) bool {
+	first, _, found := strings.Cut(image, "/")
+	if !found {
+		return false
+	}
+	return first == "localhost" || strings.ContainsAny(first, ".:")
+}
@@ -0,0 +1,60 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSandboxImage(t *testing.T) {
+	var tests = []struct {
+		name          string
+		sandboxImage  string
+		expectedImage string
+	}{
+		{name: "default", expectedImage: defaultSandboxImage},
+		{name: "localhost", sandboxImage: "localhost/pause:3.10", expectedImage: "localhost/pause:3.10"},
+		{name: "registry", sandboxImage: "registry.example.com/pause:3.10", expectedImage: "registry.example.com/pause:3.10"},
+		{name: "registry with port", sandboxImage: "registry:5000/pause:3.10", expectedImage: "registry:5000/pause:3.10"},
+		{name: "eks registry", sandboxImage: "eks/pause:3.10", expectedImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10"},
+		{name: "eks registry with digest", sandboxImage: "eks/pause@sha256:ee6521f290b2168b6e0935a181d4cff9be1ac3f505666ef0e3c98fae8199917a", expectedImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause@sha256:ee6521f290b2168b6e0935a181d4cff9be1ac3f505666ef0e3c98fae8199917a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Containerd: api.ContainerdOptions{SandboxImage: test.sandboxImage},
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Region: "us-west-2"},
+				},
+			}
+			image, err := GetSandboxImage(&cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedImage, image)
+		})
+	}
+}
+
+func TestGetSandboxImageOffline(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{Offline: true},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{Region: "us-west-2"},
+		},
+	}
+	image, err := GetSandboxImage(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSandboxImage, image)
+
+	cfg.Spec.Containerd.SandboxImage = "registry.example.com/pause:3.10"
+	image, err = GetSandboxImage(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/pause:3.10", image)
+
+	cfg.Spec.Containerd.SandboxImage = "eks/pause:3.10"
+	_, err = GetSandboxImage(&cfg)
+	assert.ErrorContains(t, err, "must include a registry in offline mode")
+}
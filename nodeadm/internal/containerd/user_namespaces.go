@@ -0,0 +1,98 @@
+package containerd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	osReleasePath         = "/proc/sys/kernel/osrelease"
+	maxUserNamespacesPath = "/proc/sys/user/max_user_namespaces"
+	// tmpfs supports idmap mounts since 6.3, which every pod needs for its
+	// projected service account token.
+	minUserNamespacesKernelVersion     = "v6.3"
+	minUserNamespacesContainerdVersion = "v2.0.0"
+)
+
+var kernelReleasePattern = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// runtimeFeatures is the part of the output of `runc features` that reports
+// the support of idmap mounts.
+// see: https://github.com/opencontainers/runtime-spec/blob/main/features.md
+type runtimeFeatures struct {
+	Linux struct {
+		MountExtensions struct {
+			IDMap struct {
+				Enabled *bool `json:"enabled"`
+			} `json:"idmap"`
+		} `json:"mountExtensions"`
+	} `json:"linux"`
+}
+
+// preflightUserNamespaces ensures that the kernel, containerd and the OCI
+// runtime support the idmap mounts of pods in user namespaces, as pods that
+// are not in user namespaces would otherwise run normally while those that
+// are fail to start.
+func preflightUserNamespaces(cfg *api.NodeConfig) error {
+	if !cfg.Spec.Security.UserNamespaces.Enabled {
+		return nil
+	}
+	zap.L().Info("Checking that the node supports user namespaces..")
+	osRelease, err := os.ReadFile(osReleasePath)
+	if err != nil {
+		return err
+	}
+	maxUserNamespaces, err := os.ReadFile(maxUserNamespacesPath)
+	if err != nil {
+		return fmt.Errorf("user namespaces are not supported by the kernel: %w", err)
+	}
+	containerdVersion, err := getContainerdVersionRaw()
+	if err != nil {
+		return err
+	}
+	runtimeBinaryPath := getRuntimeOptions(cfg).RuntimeBinaryPath
+	// #nosec G204 Subprocess launched with variable
+	features, err := exec.Command(runtimeBinaryPath, "features").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the features of %s, which must support idmap mounts: %w", runtimeBinaryPath, err)
+	}
+	return checkUserNamespacesSupport(string(osRelease), string(maxUserNamespaces), string(containerdVersion), runtimeBinaryPath, features)
+}
+
+func checkUserNamespacesSupport(osRelease string, maxUserNamespaces string, containerdVersion string, runtimeBinaryPath string, features []byte) error {
+	match := kernelReleasePattern.FindStringSubmatch(strings.TrimSpace(osRelease))
+	if match == nil {
+		return fmt.Errorf("failed to parse the kernel release %q", osRelease)
+	}
+	if kernelVersion := fmt.Sprintf("v%s.%s", match[1], match[2]); semver.Compare(kernelVersion, minUserNamespacesKernelVersion) < 0 {
+		return fmt.Errorf("user namespaces require Linux %s or later for idmap mounts, found %s", strings.TrimPrefix(minUserNamespacesKernelVersion, "v"), strings.TrimSpace(osRelease))
+	}
+	if max, err := strconv.Atoi(strings.TrimSpace(maxUserNamespaces)); err != nil || max == 0 {
+		return fmt.Errorf("user namespaces are disabled by the kernel, since %s is %q", maxUserNamespacesPath, strings.TrimSpace(maxUserNamespaces))
+	}
+	version, err := parseContainerdVersion(containerdVersion)
+	if err != nil {
+		return err
+	}
+	if semver.Compare(version, minUserNamespacesContainerdVersion) < 0 {
+		return fmt.Errorf("user namespaces require containerd %s or later for idmap mounts, found %s", minUserNamespacesContainerdVersion, version)
+	}
+	var runtime runtimeFeatures
+	if err := json.Unmarshal(features, &runtime); err != nil {
+		return fmt.Errorf("failed to parse the features of %s: %w", runtimeBinaryPath, err)
+	}
+	if enabled := runtime.Linux.MountExtensions.IDMap.Enabled; enabled == nil || !*enabled {
+		return fmt.Errorf("user namespaces require an OCI runtime with idmap mounts, such as runc 1.2 or later, which %s does not support", runtimeBinaryPath)
+	}
+	return nil
+}
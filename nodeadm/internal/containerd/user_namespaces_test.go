@@ -0,0 +1,40 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUserNamespacesSupport(t *testing.T) {
+	const (
+		supportedRelease    = "6.12.37-61.105.amzn2023.x86_64\n"
+		supportedContainerd = "containerd github.com/containerd/containerd/v2 v2.0.5 fb4c30d4ede3531652d86197bf3fc9515e5276d9\n"
+		idmapFeatures       = `{"ociVersionMin":"1.0.0","linux":{"mountExtensions":{"idmap":{"enabled":true}}}}`
+	)
+	var tests = []struct {
+		name              string
+		osRelease         string
+		maxUserNamespaces string
+		containerdVersion string
+		features          string
+		expectErr         string
+	}{
+		{name: "supported", osRelease: supportedRelease, maxUserNamespaces: "63431\n", containerdVersion: supportedContainerd, features: idmapFeatures},
+		{name: "old kernel", osRelease: "6.1.141-155.222.amzn2023.x86_64\n", maxUserNamespaces: "63431\n", containerdVersion: supportedContainerd, features: idmapFeatures, expectErr: "Linux 6.3 or later"},
+		{name: "disabled", osRelease: supportedRelease, maxUserNamespaces: "0\n", containerdVersion: supportedContainerd, features: idmapFeatures, expectErr: "disabled by the kernel"},
+		{name: "old containerd", osRelease: supportedRelease, maxUserNamespaces: "63431\n", containerdVersion: "containerd github.com/containerd/containerd v1.7.27 05044ec0a9a75232cad458027ca83437aae3f4da\n", features: idmapFeatures, expectErr: "containerd v2.0.0 or later"},
+		{name: "runtime without idmap", osRelease: supportedRelease, maxUserNamespaces: "63431\n", containerdVersion: supportedContainerd, features: `{"ociVersionMin":"1.0.0","linux":{}}`, expectErr: "OCI runtime with idmap mounts"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkUserNamespacesSupport(test.osRelease, test.maxUserNamespaces, test.containerdVersion, "/usr/sbin/runc", []byte(test.features))
+			if test.expectErr != "" {
+				assert.ErrorContains(t, err, test.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
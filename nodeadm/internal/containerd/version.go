@@ -0,0 +1,37 @@
+package containerd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// GetContainerdVersion returns the version of the installed containerd, such
+// as `v1.7.27`.
+func GetContainerdVersion() (string, error) {
+	rawVersion, err := getContainerdVersionRaw()
+	if err != nil {
+		return "", err
+	}
+	return parseContainerdVersion(rawVersion)
+}
+
+func getContainerdVersionRaw() (string, error) {
+	output, err := exec.Command("containerd", "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the version of containerd: %w", err)
+	}
+	return string(output), nil
+}
+
+// parseContainerdVersion parses the output of `containerd --version`, such as
+// `containerd github.com/containerd/containerd/v2 v2.0.2 c507a02`.
+func parseContainerdVersion(rawVersion string) (string, error) {
+	fields := strings.Fields(rawVersion)
+	if len(fields) < 3 || !semver.IsValid(fields[2]) {
+		return "", fmt.Errorf("failed to parse the version of containerd from %q", strings.TrimSpace(rawVersion))
+	}
+	return fields[2], nil
+}
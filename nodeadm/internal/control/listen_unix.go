@@ -0,0 +1,18 @@
+//go:build !windows
+
+package control
+
+import (
+	"net"
+	"syscall"
+)
+
+// listen listens on a unix socket that is created with the permissions of
+// socketPerm, so that it is never reachable by other users, not even between
+// its creation and a chmod. The umask is process-wide, so it is only changed
+// while the socket is created.
+func listen(socketPath string) (net.Listener, error) {
+	umask := syscall.Umask(0777 &^ socketPerm)
+	defer syscall.Umask(umask)
+	return net.Listen("unix", socketPath)
+}
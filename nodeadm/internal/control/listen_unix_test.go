@@ -0,0 +1,22 @@
+//go:build !windows
+
+package control
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListen(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "control.sock")
+	listener, err := listen(socketPath)
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	info, err := os.Stat(socketPath)
+	assert.NoError(t, err)
+	// the socket is private before its permissions are set
+	assert.Equal(t, os.FileMode(socketPerm), info.Mode().Perm())
+}
@@ -0,0 +1,9 @@
+package control
+
+import "net"
+
+// listen listens on a unix socket, whose permissions are set once it is
+// created, since there is no umask on windows.
+func listen(socketPath string) (net.Listener, error) {
+	return net.Listen("unix", socketPath)
+}
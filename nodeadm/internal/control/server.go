@@ -0,0 +1,288 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+const (
+	// DefaultSocketPath is the well-known location of the socket of the
+	// control API of the agent.
+	DefaultSocketPath = "/run/nodeadm/agent.sock"
+	// the socket is only accessible to root, since the API can change the node
+	socketPerm = 0600
+)
+
+var errOperationInProgress = errors.New("another operation is in progress")
+
+// commandFunc runs a nodeadm command with the given arguments and returns its
+// JSON result.
+type commandFunc func(ctx context.Context, args ...string) (*cli.Result, error)
+
+// Hooks let the agent take part in the operations of the API.
+type Hooks struct {
+	// Status returns the state of the agent.
+	Status func() any
+	// Reconfiguring is called with true before the node is reconfigured and
+	// with false after, since the files managed by nodeadm are rewritten.
+	Reconfiguring func(bool)
+}
+
+// Server is the control API of the agent, which lets node management tools
+// operate on the node without re-invoking the CLI. Operations that change the
+// node are run one at a time.
+type Server struct {
+	configSource  string
+	hooks         Hooks
+	runCommand    commandFunc
+	newNodeClient func() (kubernetes.Interface, error)
+	mu            sync.Mutex
+}
+
+// NewServer creates the control API. Init is run with the config source to
+// reconfigure the node, and the kubeconfig is used to drain it.
+func NewServer(configSource string, kubeconfig string, hooks Hooks) *Server {
+	return &Server{
+		configSource: configSource,
+		hooks:        hooks,
+		runCommand:   runNodeadm,
+		newNodeClient: func() (kubernetes.Interface, error) {
+			return node.NewClient(kubeconfig)
+		},
+	}
+}
+
+// Handler returns the routes of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("POST /v1/reconfigure", s.exclusive(s.handleReconfigure))
+	mux.HandleFunc("POST /v1/drain", s.exclusive(s.handleDrain))
+	mux.HandleFunc("POST /v1/bundle", s.exclusive(s.handleBundle))
+	return mux
+}
+
+// Serve serves the API on a unix socket until the context is done.
+func (s *Server) Serve(ctx context.Context, socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0755); err != nil {
+		return err
+	}
+	// a socket left behind by a previous agent prevents listening
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	listener, err := listen(socketPath)
+	if err != nil {
+		return err
+	}
+	// the permissions are also set on platforms without a umask
+	if err := os.Chmod(socketPath, socketPerm); err != nil {
+		listener.Close()
+		return err
+	}
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// exclusive rejects a request while another operation that changes the node
+// is running, rather than queueing it behind an operation that can take
+// minutes.
+func (s *Server) exclusive(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.mu.TryLock() {
+			writeError(w, http.StatusConflict, errOperationInProgress)
+			return
+		}
+		defer s.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+type statusResponse struct {
+	Agent any `json:"agent"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse{Agent: s.hooks.Status()})
+}
+
+// handleReconfigure runs init with the configuration of the agent, which
+// re-renders the configuration of the node and restarts the daemons whose
+// configuration changed.
+func (s *Server) handleReconfigure(w http.ResponseWriter, r *http.Request) {
+	zap.L().Info("Reconfiguring node..")
+	s.hooks.Reconfiguring(true)
+	defer s.hooks.Reconfiguring(false)
+	s.writeCommandResult(w, r, "--config-source", s.configSource, "init")
+}
+
+type bundleRequest struct {
+	S3Bucket string `json:"s3Bucket,omitempty"`
+	S3Prefix string `json:"s3Prefix,omitempty"`
+}
+
+// handleBundle collects a debug bundle, and uploads it when a bucket is given.
+func (s *Server) handleBundle(w http.ResponseWriter, r *http.Request) {
+	var req bundleRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	args := []string{"debug", "bundle"}
+	if req.S3Bucket != "" {
+		args = append(args, "--s3-bucket", req.S3Bucket, "--s3-prefix", req.S3Prefix)
+	}
+	zap.L().Info("Collecting debug bundle..")
+	s.writeCommandResult(w, r, args...)
+}
+
+func (s *Server) writeCommandResult(w http.ResponseWriter, r *http.Request, args ...string) {
+	// the command is not interrupted when the client disconnects, so that
+	// the node is not left half configured
+	result, err := s.runCommand(context.WithoutCancel(r.Context()), args...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	status := http.StatusOK
+	if result.Status != cli.ResultStatusSuccess {
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, result)
+}
+
+type drainRequest struct {
+	NodeName string `json:"nodeName"`
+	// Timeout is the maximum amount of time to wait for pods to be evicted,
+	// such as `5m`.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type drainResponse struct {
+	Node     string `json:"node"`
+	Cordoned bool   `json:"cordoned"`
+	Drained  bool   `json:"drained"`
+	Error    string `json:"error,omitempty"`
+}
+
+// handleDrain cordons the node and evicts its pods, leaving the node in the
+// cluster, such as before maintenance that does not replace the instance.
+func (s *Server) handleDrain(w http.ResponseWriter, r *http.Request) {
+	var req drainRequest
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.NodeName == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("nodeName is required"))
+		return
+	}
+	timeout := deregister.DefaultDrainTimeout
+	if req.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(req.Timeout); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid timeout: %w", err))
+			return
+		}
+	}
+	client, err := s.newNodeClient()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	res := drainResponse{Node: req.NodeName}
+	nodeField := zap.String("node", req.NodeName)
+
+	zap.L().Info("Cordoning node..", nodeField)
+	if err := node.Cordon(r.Context(), client, req.NodeName); err != nil {
+		res.Error = err.Error()
+		writeJSON(w, http.StatusInternalServerError, res)
+		return
+	}
+	res.Cordoned = true
+
+	zap.L().Info("Draining node..", nodeField, zap.Duration("timeout", timeout))
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	if err := node.Drain(ctx, client, req.NodeName); err != nil {
+		res.Error = err.Error()
+		writeJSON(w, http.StatusInternalServerError, res)
+		return
+	}
+	res.Drained = true
+	zap.L().Info("Drained node", nodeField)
+	writeJSON(w, http.StatusOK, res)
+}
+
+// runNodeadm runs the nodeadm executable of the agent. Commands run in their
+// own process, as they replace the global logger and can exit the process
+// when they fail.
+func runNodeadm(ctx context.Context, args ...string) (*cli.Result, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.CommandContext(ctx, executable, append([]string{"--output", string(cli.OutputJSON)}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	var result cli.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		// the command did not get as far as writing its result
+		return nil, errors.Join(runErr, fmt.Errorf("failed to read result of nodeadm %v: %w", args, err))
+	}
+	return &result, nil
+}
+
+func decodeRequest(r *http.Request, v any) error {
+	if r.ContentLength == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		zap.L().Warn("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,103 @@
+package control
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func newTestServer(run commandFunc, client kubernetes.Interface) (*Server, *[]bool) {
+	var reconfiguring []bool
+	return &Server{
+		configSource: "file:///etc/eks/nodeadm/config.yaml",
+		hooks: Hooks{
+			Status:        func() any { return map[string]int{"watchedFiles": 3} },
+			Reconfiguring: func(b bool) { reconfiguring = append(reconfiguring, b) },
+		},
+		runCommand: run,
+		newNodeClient: func() (kubernetes.Interface, error) {
+			return client, nil
+		},
+	}, &reconfiguring
+}
+
+func serve(s *Server, method string, path string, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestStatus(t *testing.T) {
+	s, _ := newTestServer(nil, nil)
+	rec := serve(s, http.MethodGet, "/v1/status", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"agent":{"watchedFiles":3}}`, rec.Body.String())
+
+	rec = serve(s, http.MethodPost, "/v1/status", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestReconfigure(t *testing.T) {
+	var args []string
+	s, reconfiguring := newTestServer(func(_ context.Context, a ...string) (*cli.Result, error) {
+		args = a
+		return &cli.Result{Version: cli.ResultVersion, Command: "init", Status: cli.ResultStatusFailure, Error: "invalid configuration"}, nil
+	}, nil)
+	rec := serve(s, http.MethodPost, "/v1/reconfigure", "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid configuration")
+	assert.Equal(t, []string{"--config-source", "file:///etc/eks/nodeadm/config.yaml", "init"}, args)
+	assert.Equal(t, []bool{true, false}, *reconfiguring)
+}
+
+func TestBundle(t *testing.T) {
+	var args []string
+	s, _ := newTestServer(func(_ context.Context, a ...string) (*cli.Result, error) {
+		args = a
+		return &cli.Result{Status: cli.ResultStatusSuccess}, nil
+	}, nil)
+	rec := serve(s, http.MethodPost, "/v1/bundle", `{"s3Bucket":"my-bucket","s3Prefix":"nodes"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"debug", "bundle", "--s3-bucket", "my-bucket", "--s3-prefix", "nodes"}, args)
+
+	rec = serve(s, http.MethodPost, "/v1/bundle", `{"bucket":"my-bucket"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDrain(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "my-node"}})
+	s, _ := newTestServer(nil, client)
+
+	rec := serve(s, http.MethodPost, "/v1/drain", `{"timeout":"1m"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(s, http.MethodPost, "/v1/drain", `{"nodeName":"my-node","timeout":"soon"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(s, http.MethodPost, "/v1/drain", `{"nodeName":"my-node","timeout":"1m"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"node":"my-node","cordoned":true,"drained":true}`, rec.Body.String())
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "my-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+}
+
+func TestOperationInProgress(t *testing.T) {
+	s, _ := newTestServer(nil, nil)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := serve(s, http.MethodPost, "/v1/reconfigure", "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	// the status is served while an operation is running
+	rec = serve(s, http.MethodGet, "/v1/status", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"strings"
+)
+
+// getServiceExecutable returns the executable of the command line of a
+// Windows service, which is quoted when its path has spaces.
+func getServiceExecutable(commandLine string) string {
+	commandLine = strings.TrimSpace(commandLine)
+	if strings.HasPrefix(commandLine, `"`) {
+		if end := strings.Index(commandLine[1:], `"`); end >= 0 {
+			return commandLine[1 : end+1]
+		}
+		return commandLine[1:]
+	}
+	executable, _, _ := strings.Cut(commandLine, " ")
+	return executable
+}
+
+// getServiceCommandLine returns the command line of a Windows service that
+// runs an executable with arguments.
+func getServiceCommandLine(executable string, args []string) string {
+	quoted := []string{escapeArg(executable)}
+	for _, arg := range args {
+		quoted = append(quoted, escapeArg(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// escapeArg quotes an argument by the rules of CommandLineToArgvW, like
+// windows.EscapeArg, which is only built on Windows.
+func escapeArg(arg string) string {
+	if arg == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	slashes := 0
+	for _, c := range []byte(arg) {
+		switch c {
+		case '\\':
+			slashes++
+		case '"':
+			// the backslashes before a quote are escaped, along with the quote
+			b.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		b.WriteByte(c)
+	}
+	// the backslashes before the closing quote are escaped
+	b.WriteString(strings.Repeat(`\`, slashes))
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServiceExecutable(t *testing.T) {
+	var tests = []struct {
+		commandLine string
+		expected    string
+	}{
+		{commandLine: `"C:\Program Files\Kubernetes\kubelet.exe" --v=2`, expected: `C:\Program Files\Kubernetes\kubelet.exe`},
+		{commandLine: `"C:\Program Files\Kubernetes\kubelet.exe"`, expected: `C:\Program Files\Kubernetes\kubelet.exe`},
+		{commandLine: `C:\k\kubelet.exe --v=2`, expected: `C:\k\kubelet.exe`},
+		{commandLine: `C:\k\kubelet.exe`, expected: `C:\k\kubelet.exe`},
+	}
+	for _, test := range tests {
+		t.Run(test.commandLine, func(t *testing.T) {
+			assert.Equal(t, test.expected, getServiceExecutable(test.commandLine))
+		})
+	}
+}
+
+func TestGetServiceCommandLine(t *testing.T) {
+	commandLine := getServiceCommandLine(`C:\Program Files\Kubernetes\kubelet.exe`, []string{
+		"--config=C:\\ProgramData\\kubernetes\\kubelet\\config.json",
+		"--node-labels=a=b,c=d",
+		`--pod-infra-container-image=`,
+		`--register-with-taints=a="b c"`,
+		`--root-dir=C:\var\lib\kubelet dir\`,
+	})
+	assert.Equal(t, `"C:\Program Files\Kubernetes\kubelet.exe" --config=C:\ProgramData\kubernetes\kubelet\config.json --node-labels=a=b,c=d --pod-infra-container-image= "--register-with-taints=a=\"b c\"" "--root-dir=C:\var\lib\kubelet dir\\"`, commandLine)
+	assert.Equal(t, `C:\Program Files\Kubernetes\kubelet.exe`, getServiceExecutable(commandLine))
+}
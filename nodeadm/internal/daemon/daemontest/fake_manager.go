@@ -0,0 +1,131 @@
+// Package daemontest provides a fake daemon.DaemonManager, so that the
+// orchestration of daemons can be tested without systemd.
+package daemontest
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+// FakeManager records the calls made to it, and keeps the status of each
+// daemon in memory. Daemons that were never started are stopped.
+type FakeManager struct {
+	mu       sync.Mutex
+	statuses map[string]daemon.DaemonStatus
+	enabled  map[string]bool
+	calls    []string
+	errors   map[string][]error
+}
+
+var _ daemon.DaemonManager = &FakeManager{}
+
+// NewFakeManager returns a FakeManager on which the given daemons are running.
+func NewFakeManager(running ...string) *FakeManager {
+	m := &FakeManager{
+		statuses: map[string]daemon.DaemonStatus{},
+		enabled:  map[string]bool{},
+		errors:   map[string][]error{},
+	}
+	for _, name := range running {
+		m.statuses[name] = daemon.DaemonStatusRunning
+	}
+	return m
+}
+
+// FailOn scripts the errors returned by the next calls to a method for a
+// daemon, such as `FailOn("start", "kubelet", err)`, one error per call. A nil
+// error lets its call succeed. The operations are start, stop, restart,
+// status, enable, disable and daemon-reload, whose name is empty.
+func (m *FakeManager) FailOn(operation string, name string, errs ...error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := callKey(operation, name)
+	m.errors[key] = append(m.errors[key], errs...)
+}
+
+// Calls returns the calls that were made, such as `start kubelet`, in order.
+func (m *FakeManager) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// SetStatus sets the status of a daemon, such as one that failed after it
+// was started.
+func (m *FakeManager) SetStatus(name string, status daemon.DaemonStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[name] = status
+}
+
+// IsEnabled returns whether a daemon was enabled.
+func (m *FakeManager) IsEnabled(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.enabled[name]
+}
+
+func (m *FakeManager) StartDaemon(name string) error {
+	return m.call("start", name, func() { m.statuses[name] = daemon.DaemonStatusRunning })
+}
+
+func (m *FakeManager) StopDaemon(name string) error {
+	return m.call("stop", name, func() { m.statuses[name] = daemon.DaemonStatusStopped })
+}
+
+func (m *FakeManager) RestartDaemon(name string) error {
+	return m.call("restart", name, func() { m.statuses[name] = daemon.DaemonStatusRunning })
+}
+
+func (m *FakeManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	status := daemon.DaemonStatusStopped
+	err := m.call("status", name, func() {
+		if s, ok := m.statuses[name]; ok {
+			status = s
+		}
+	})
+	if err != nil {
+		return daemon.DaemonStatusUnknown, err
+	}
+	return status, nil
+}
+
+func (m *FakeManager) EnableDaemon(name string) error {
+	return m.call("enable", name, func() { m.enabled[name] = true })
+}
+
+func (m *FakeManager) DisableDaemon(name string) error {
+	return m.call("disable", name, func() { m.enabled[name] = false })
+}
+
+func (m *FakeManager) DaemonReload() error {
+	return m.call("daemon-reload", "", func() {})
+}
+
+func (m *FakeManager) Close() {}
+
+// call records a call, and applies its effect unless an error was scripted
+// for it.
+func (m *FakeManager) call(operation string, name string, effect func()) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := callKey(operation, name)
+	m.calls = append(m.calls, key)
+	if errs := m.errors[key]; len(errs) > 0 {
+		m.errors[key] = errs[1:]
+		if errs[0] != nil {
+			return errs[0]
+		}
+	}
+	effect()
+	return nil
+}
+
+func callKey(operation string, name string) string {
+	if name == "" {
+		return operation
+	}
+	return operation + " " + name
+}
@@ -0,0 +1,44 @@
+package daemontest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+func TestFakeManager(t *testing.T) {
+	m := NewFakeManager("containerd")
+	errFailed := errors.New("kubelet.service failed")
+	m.FailOn("start", "kubelet", errFailed, nil)
+
+	status, err := m.GetDaemonStatus("containerd")
+	assert.NoError(t, err)
+	assert.Equal(t, daemon.DaemonStatusRunning, status)
+
+	assert.ErrorIs(t, m.StartDaemon("kubelet"), errFailed)
+	status, err = m.GetDaemonStatus("kubelet")
+	assert.NoError(t, err)
+	assert.Equal(t, daemon.DaemonStatusStopped, status)
+
+	assert.NoError(t, m.DaemonReload())
+	assert.NoError(t, m.StartDaemon("kubelet"))
+	status, err = m.GetDaemonStatus("kubelet")
+	assert.NoError(t, err)
+	assert.Equal(t, daemon.DaemonStatusRunning, status)
+
+	assert.NoError(t, m.EnableDaemon("kubelet"))
+	assert.True(t, m.IsEnabled("kubelet"))
+
+	assert.Equal(t, []string{
+		"status containerd",
+		"start kubelet",
+		"status kubelet",
+		"daemon-reload",
+		"start kubelet",
+		"status kubelet",
+		"enable kubelet",
+	}, m.Calls())
+}
@@ -0,0 +1,92 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const environmentFilePerm = 0644
+
+var _ Restartable = &EnvironmentUnit{}
+
+// EnvironmentUnit manages a unit that is started with the arguments of an
+// environment file, and is only enabled while that file exists, since the unit
+// is conditioned on it. It is embedded by the daemons of such units, which
+// write or remove the file when they are configured. The unit is restarted
+// when the file changes while it is running, and stopped once it is removed.
+type EnvironmentUnit struct {
+	daemonManager       DaemonManager
+	name                string
+	environmentFilePath string
+	argsEnvironmentName string
+}
+
+func NewEnvironmentUnit(daemonManager DaemonManager, name string, environmentFilePath string, argsEnvironmentName string) EnvironmentUnit {
+	return EnvironmentUnit{
+		daemonManager:       daemonManager,
+		name:                name,
+		environmentFilePath: environmentFilePath,
+		argsEnvironmentName: argsEnvironmentName,
+	}
+}
+
+// Enable writes the arguments that the unit is started with.
+func (u *EnvironmentUnit) Enable(args string) error {
+	return util.WriteFileWithDir(u.environmentFilePath, []byte(fmt.Sprintf("%s=%s", u.argsEnvironmentName, args)), environmentFilePerm)
+}
+
+// Disable removes the environment of the unit, so that it is stopped and no
+// longer started.
+func (u *EnvironmentUnit) Disable() error {
+	return util.RemoveFile(u.environmentFilePath)
+}
+
+// IsEnabled returns whether the environment of the unit exists.
+func (u *EnvironmentUnit) IsEnabled() (bool, error) {
+	return util.IsFilePathExists(u.environmentFilePath)
+}
+
+func (u *EnvironmentUnit) EnsureRunning() error {
+	if enabled, err := u.IsEnabled(); err != nil {
+		return err
+	} else if !enabled {
+		zap.L().Info("Daemon is not enabled", zap.String("name", u.name))
+		return u.stop()
+	}
+	return u.daemonManager.StartDaemon(u.name)
+}
+
+func (u *EnvironmentUnit) Restart() error {
+	if enabled, err := u.IsEnabled(); err != nil {
+		return err
+	} else if !enabled {
+		return u.stop()
+	}
+	return u.daemonManager.RestartDaemon(u.name)
+}
+
+// stop stops the unit if it is still running after it was disabled.
+func (u *EnvironmentUnit) stop() error {
+	status, err := u.daemonManager.GetDaemonStatus(u.name)
+	if err != nil {
+		return err
+	}
+	if status != DaemonStatusRunning {
+		return nil
+	}
+	zap.L().Info("Stopping disabled daemon..", zap.String("name", u.name))
+	return u.daemonManager.StopDaemon(u.name)
+}
+
+func (u *EnvironmentUnit) PostLaunch(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (u *EnvironmentUnit) Name() string {
+	return u.name
+}
@@ -0,0 +1,46 @@
+package daemon_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func TestEnvironmentUnit(t *testing.T) {
+	environmentFilePath := filepath.Join(t.TempDir(), "monitor", "environment")
+	daemonManager := daemontest.NewFakeManager()
+	unit := daemon.NewEnvironmentUnit(daemonManager, "monitor", environmentFilePath, "MONITOR_ARGS")
+
+	// a disabled unit that is not running is left alone
+	assert.NoError(t, unit.Disable())
+	assert.NoError(t, unit.EnsureRunning())
+	assert.Equal(t, []string{"status monitor"}, daemonManager.Calls())
+
+	assert.NoError(t, unit.Enable("--interval=1m"))
+	content, err := os.ReadFile(environmentFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "MONITOR_ARGS=--interval=1m", string(content))
+	assert.NoError(t, unit.EnsureRunning())
+	assert.Equal(t, []string{"start monitor"}, daemonManager.Calls()[1:])
+
+	// the unit is restarted when its environment changes
+	assert.NoError(t, unit.Enable("--interval=5m"))
+	assert.NoError(t, unit.Restart())
+	assert.Equal(t, []string{"restart monitor"}, daemonManager.Calls()[2:])
+
+	// and stopped once it is disabled, whether its change is applied by a
+	// restart or it is only ensured to be running
+	assert.NoError(t, unit.Disable())
+	assert.NoFileExists(t, environmentFilePath)
+	assert.NoError(t, unit.Restart())
+	assert.NoError(t, unit.EnsureRunning())
+	assert.Equal(t, []string{"status monitor", "stop monitor", "status monitor"}, daemonManager.Calls()[3:])
+	status, err := daemonManager.GetDaemonStatus("monitor")
+	assert.NoError(t, err)
+	assert.Equal(t, daemon.DaemonStatusStopped, status)
+}
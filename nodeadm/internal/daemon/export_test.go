@@ -0,0 +1,10 @@
+package daemon
+
+// NewTestTransaction returns a transaction that journals to a path of the
+// test, and does not record the files it restores in the manifest.
+func NewTestTransaction(daemonManager DaemonManager, daemons []Daemon, journalPath string) *Transaction {
+	transaction := NewTransaction(daemonManager, daemons)
+	transaction.journalPath = journalPath
+	transaction.recordFiles = func([]string) error { return nil }
+	return transaction
+}
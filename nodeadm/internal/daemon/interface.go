@@ -1,12 +1,14 @@
 package daemon
 
 import (
+	"context"
+
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 )
 
 type Daemon interface {
 	// Configure configures the daemon.
-	Configure(*api.NodeConfig) error
+	Configure(context.Context, *api.NodeConfig) error
 	// EnsureRunning ensures that the daemon is running by either
 	// starting/restarting the daemon, then blocking until the status of the
 	// daemon reflects that it is running.
@@ -15,7 +17,14 @@ type Daemon interface {
 	EnsureRunning() error
 	// PostLaunch runs any additional step that needs to occur after the service
 	// daemon as been started
-	PostLaunch(*api.NodeConfig) error
+	PostLaunch(context.Context, *api.NodeConfig) error
 	// Name returns the name of the daemon.
 	Name() string
 }
+
+// Unitless is implemented by daemons that have no unit or service of their
+// own, and are only daemons so that their tasks are ordered among those of
+// the others. There is nothing to stop when the node is reset.
+type Unitless interface {
+	Unitless()
+}
@@ -26,6 +26,18 @@ type DaemonManager interface {
 	// DisableDaemon disables the daemon with the given name.
 	// If the daemon is not enabled, this is a no-op.
 	DisableDaemon(name string) error
+	// DaemonReload reloads the unit files of every daemon, such that changes
+	// to units and their drop-ins take effect when the daemon is next started.
+	DaemonReload() error
 	// Close cleans up any underlying resources used by the daemon manager.
 	Close()
 }
+
+// ArgumentSetter is implemented by the daemon managers whose daemons are
+// started with the arguments of their service rather than those of an
+// environment file, such as the Service Control Manager of Windows.
+type ArgumentSetter interface {
+	// SetDaemonArguments replaces the arguments that the daemon with the
+	// given name is started with.
+	SetDaemonArguments(name string, args []string) error
+}
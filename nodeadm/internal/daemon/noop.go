@@ -1,4 +1,4 @@
-//go:build !linux
+//go:build !linux && !windows
 
 package daemon
 
@@ -34,4 +34,8 @@ func (m *noopDaemonManager) DisableDaemon(name string) error {
 	return nil
 }
 
+func (m *noopDaemonManager) DaemonReload() error {
+	return nil
+}
+
 func (m *noopDaemonManager) Close() {}
@@ -0,0 +1,172 @@
+//go:build windows
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+var (
+	_ DaemonManager  = &scmDaemonManager{}
+	_ ArgumentSetter = &scmDaemonManager{}
+)
+
+// scmDaemonManager manages daemons as services of the Windows Service Control
+// Manager, which are named the same as their daemons.
+type scmDaemonManager struct {
+	mgr *mgr.Mgr
+}
+
+func NewDaemonManager() (DaemonManager, error) {
+	m, err := mgr.Connect()
+	if err != nil {
+		return nil, err
+	}
+	return &scmDaemonManager{
+		mgr: m,
+	}, nil
+}
+
+func (m *scmDaemonManager) StartDaemon(name string) error {
+	status, err := m.GetDaemonStatus(name)
+	if err != nil {
+		return err
+	}
+	if status == DaemonStatusRunning {
+		return nil
+	}
+	s, err := m.mgr.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	if err := s.Start(); err != nil && !errors.Is(err, windows.ERROR_SERVICE_ALREADY_RUNNING) {
+		return err
+	}
+	return m.waitForStatus(context.TODO(), name, DaemonStatusRunning)
+}
+
+func (m *scmDaemonManager) StopDaemon(name string) error {
+	s, err := m.mgr.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	if _, err := s.Control(svc.Stop); err != nil && !errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+		return err
+	}
+	return m.waitForStatus(context.TODO(), name, DaemonStatusStopped)
+}
+
+// RestartDaemon stops and starts the service, since the Service Control
+// Manager cannot restart a service in one step.
+func (m *scmDaemonManager) RestartDaemon(name string) error {
+	if err := m.StopDaemon(name); err != nil {
+		return err
+	}
+	return m.StartDaemon(name)
+}
+
+func (m *scmDaemonManager) GetDaemonStatus(name string) (DaemonStatus, error) {
+	s, err := m.mgr.OpenService(name)
+	if err != nil {
+		return DaemonStatusUnknown, err
+	}
+	defer s.Close()
+	status, err := s.Query()
+	if err != nil {
+		return DaemonStatusUnknown, err
+	}
+	switch status.State {
+	case svc.Running:
+		return DaemonStatusRunning, nil
+	case svc.Stopped:
+		return DaemonStatusStopped, nil
+	default:
+		return DaemonStatusUnknown, nil
+	}
+}
+
+// EnableDaemon starts the service when Windows boots.
+func (m *scmDaemonManager) EnableDaemon(name string) error {
+	return m.setStartType(name, mgr.StartAutomatic)
+}
+
+// DisableDaemon leaves the service to be started on demand, like a systemd
+// unit that is not enabled.
+func (m *scmDaemonManager) DisableDaemon(name string) error {
+	return m.setStartType(name, mgr.StartManual)
+}
+
+// DaemonReload is a no-op, since the Service Control Manager reads the
+// configuration of a service when it is started.
+func (m *scmDaemonManager) DaemonReload() error {
+	return nil
+}
+
+// SetDaemonArguments replaces the arguments on the command line of the
+// service, keeping its executable, since services of the Service Control
+// Manager do not read environment files.
+func (m *scmDaemonManager) SetDaemonArguments(name string, args []string) error {
+	s, err := m.mgr.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	config, err := s.Config()
+	if err != nil {
+		return err
+	}
+	commandLine := getServiceCommandLine(getServiceExecutable(config.BinaryPathName), args)
+	if config.BinaryPathName == commandLine {
+		return nil
+	}
+	config.BinaryPathName = commandLine
+	return s.UpdateConfig(config)
+}
+
+func (m *scmDaemonManager) Close() {
+	m.mgr.Disconnect()
+}
+
+func (m *scmDaemonManager) setStartType(name string, startType uint32) error {
+	s, err := m.mgr.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	config, err := s.Config()
+	if err != nil {
+		return err
+	}
+	if config.StartType == startType {
+		return nil
+	}
+	config.StartType = startType
+	return s.UpdateConfig(config)
+}
+
+func (m *scmDaemonManager) waitForStatus(ctx context.Context, name string, targetStatus DaemonStatus) error {
+	return util.NewRetrier(
+		util.WithRetryAlways(),
+		util.WithBackoffFixed(250*time.Millisecond),
+	).Retry(ctx, func() error {
+		status, err := m.GetDaemonStatus(name)
+		if err != nil {
+			return err
+		}
+		if status != targetStatus {
+			return fmt.Errorf("%s status is not %q", name, targetStatus)
+		}
+		return nil
+	})
+}
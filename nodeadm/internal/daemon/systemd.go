@@ -100,6 +100,10 @@ func (m *systemdDaemonManager) DisableDaemon(name string) error {
 	return nil
 }
 
+func (m *systemdDaemonManager) DaemonReload() error {
+	return m.conn.ReloadContext(context.TODO())
+}
+
 func (m *systemdDaemonManager) Close() {
 	m.conn.Close()
 }
@@ -0,0 +1,308 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/tracing"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// TransactionJournalPath is the well-known location of the record of the
+	// files changed by the last configuration of the daemons, which is kept
+	// until the daemons are running with that configuration.
+	TransactionJournalPath = "/var/lib/nodeadm/transaction.json"
+	transactionJournalPerm = 0600
+)
+
+// Restartable is implemented by daemons that can safely be restarted to apply
+// a new configuration while they are running.
+type Restartable interface {
+	Restart() error
+}
+
+// PreLauncher is implemented by daemons that check that the node can run them
+// before they are started, so that a failure is reported with its cause
+// instead of the daemon failing once it is running.
+type PreLauncher interface {
+	PreLaunch(context.Context, *api.NodeConfig) error
+}
+
+// Transaction applies the configuration of several daemons as a unit, because
+// a single setting can affect more than one daemon. If any daemon cannot be
+// configured, or fails to run with its new configuration, the files of every
+// daemon in the transaction are restored and the daemons that were already
+// started are restarted, in order, with their previous configuration.
+//
+// The configuration and run phases of init may run in separate processes, so
+// the original state of the changed files is journaled between them.
+type Transaction struct {
+	daemonManager DaemonManager
+	daemons       []Daemon
+	journalPath   string
+	recordFiles   func([]string) error
+	// journal is the record of the last Configure or EnsureRunning
+	journal *journal
+}
+
+type journal struct {
+	Daemons []journalEntry `json:"daemons"`
+}
+
+type journalEntry struct {
+	Name    string              `json:"name"`
+	Changed bool                `json:"changed"`
+	Files   []util.FileSnapshot `json:"files"`
+}
+
+func NewTransaction(daemonManager DaemonManager, daemons []Daemon) *Transaction {
+	return &Transaction{
+		daemonManager: daemonManager,
+		daemons:       daemons,
+		journalPath:   TransactionJournalPath,
+		recordFiles:   manifest.RecordFiles,
+	}
+}
+
+// Configure configures each daemon in order. If a daemon fails to be
+// configured, the files written by every daemon are restored.
+func (t *Transaction) Configure(ctx context.Context, cfg *api.NodeConfig) error {
+	var j journal
+	t.journal = &j
+	for _, daemon := range t.daemons {
+		nameField := zap.String("name", daemon.Name())
+		zap.L().Info("Configuring daemon...", nameField)
+		before := util.WrittenFiles()
+		spanCtx, span := tracing.Start(ctx, "configure daemon", tracing.String("daemon.name", daemon.Name()))
+		err := daemon.Configure(spanCtx, cfg)
+		span.End(err)
+		entry, snapshotErr := newJournalEntry(daemon.Name(), before)
+		if snapshotErr != nil {
+			err = errors.Join(err, snapshotErr)
+		}
+		j.Daemons = append(j.Daemons, entry)
+		if err != nil {
+			if restoreErr := t.restore(&j); restoreErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to restore daemon configuration: %w", restoreErr))
+			}
+			return fmt.Errorf("failed to configure daemon %s: %w", daemon.Name(), err)
+		}
+		zap.L().Info("Configured daemon", nameField, zap.Bool("changed", entry.Changed))
+	}
+	return j.write(t.journalPath)
+}
+
+// EnsureRunning ensures each daemon is running in order, restarting those
+// whose configuration was changed, and runs their pre-launch checks and
+// post-launch tasks. If a daemon fails, the configuration journaled by
+// Configure is rolled back.
+func (t *Transaction) EnsureRunning(ctx context.Context, cfg *api.NodeConfig) error {
+	j, err := loadJournal(t.journalPath)
+	if err != nil {
+		return err
+	}
+	t.journal = j
+	for i, daemon := range t.daemons {
+		nameField := zap.String("name", daemon.Name())
+
+		if preLauncher, ok := daemon.(PreLauncher); ok {
+			zap.L().Info("Running pre-launch checks..", nameField)
+			spanCtx, span := tracing.Start(ctx, "run pre-launch checks", tracing.String("daemon.name", daemon.Name()))
+			err := preLauncher.PreLaunch(spanCtx, cfg)
+			span.End(err)
+			if err != nil {
+				return t.rollback(j, t.daemons[:i], fmt.Errorf("failed pre-launch checks of daemon %s: %w", daemon.Name(), err))
+			}
+		}
+
+		zap.L().Info("Ensuring daemon is running..", nameField)
+		_, span := tracing.Start(ctx, "start daemon", tracing.String("daemon.name", daemon.Name()))
+		err := t.ensureRunning(daemon, j.changed(daemon.Name()))
+		span.End(err)
+		if err != nil {
+			return t.rollback(j, t.daemons[:i+1], fmt.Errorf("failed to run daemon %s: %w", daemon.Name(), err))
+		}
+		zap.L().Info("Daemon is running", nameField)
+
+		zap.L().Info("Running post-launch tasks..", nameField)
+		spanCtx, span := tracing.Start(ctx, "run post-launch tasks", tracing.String("daemon.name", daemon.Name()))
+		err = daemon.PostLaunch(spanCtx, cfg)
+		span.End(err)
+		if err != nil {
+			return t.rollback(j, t.daemons[:i+1], fmt.Errorf("failed post-launch tasks of daemon %s: %w", daemon.Name(), err))
+		}
+		zap.L().Info("Finished post-launch tasks", nameField)
+	}
+	return removeJournal(t.journalPath)
+}
+
+// Changed returns whether the configuration of the named daemon was changed
+// by the transaction.
+func (t *Transaction) Changed(name string) bool {
+	return t.journal != nil && t.journal.changed(name)
+}
+
+func (t *Transaction) ensureRunning(daemon Daemon, changed bool) error {
+	if restartable, ok := daemon.(Restartable); ok && changed {
+		status, err := t.daemonManager.GetDaemonStatus(daemon.Name())
+		if err != nil {
+			return err
+		}
+		if status == DaemonStatusRunning {
+			zap.L().Info("Restarting daemon to apply its new configuration..", zap.String("name", daemon.Name()))
+			return restartable.Restart()
+		}
+	}
+	return daemon.EnsureRunning()
+}
+
+// rollback restores the journaled files and restarts the daemons that were
+// started with their new configuration.
+func (t *Transaction) rollback(j *journal, started []Daemon, cause error) error {
+	if !j.hasPreviousConfiguration() {
+		// there is nothing to return to on the first configuration of a node
+		return cause
+	}
+	zap.L().Warn("Rolling back daemon configuration..", zap.Error(cause))
+	if err := t.restore(j); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to restore daemon configuration: %w", err))
+	}
+	if err := t.daemonManager.DaemonReload(); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to reload daemons: %w", err))
+	}
+	var errs []error
+	for _, daemon := range started {
+		if !j.changed(daemon.Name()) {
+			continue
+		}
+		zap.L().Info("Restarting daemon with its previous configuration..", zap.String("name", daemon.Name()))
+		var err error
+		if restartable, ok := daemon.(Restartable); ok {
+			err = restartable.Restart()
+		} else {
+			err = daemon.EnsureRunning()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restart daemon %s: %w", daemon.Name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(append([]error{cause}, errs...)...)
+	}
+	return fmt.Errorf("rolled back daemon configuration: %w", cause)
+}
+
+// restore returns every journaled file to its original state, updates the
+// manifest of managed files to match, and discards the journal.
+func (t *Transaction) restore(j *journal) error {
+	var paths []string
+	for _, entry := range j.Daemons {
+		for _, snapshot := range entry.Files {
+			if err := snapshot.Restore(); err != nil {
+				return err
+			}
+			paths = append(paths, snapshot.Path)
+		}
+	}
+	if err := t.recordFiles(paths); err != nil {
+		return err
+	}
+	return removeJournal(t.journalPath)
+}
+
+// newJournalEntry records the files written by a daemon since the given
+// paths were written, and whether their content changed.
+func newJournalEntry(name string, before []string) (journalEntry, error) {
+	entry := journalEntry{Name: name}
+	var paths []string
+	for _, filePath := range util.WrittenFiles() {
+		if !slices.Contains(before, filePath) {
+			paths = append(paths, filePath)
+		}
+	}
+	entry.Files = util.FileSnapshots(paths)
+	for _, snapshot := range entry.Files {
+		changed, err := fileChanged(snapshot)
+		if err != nil {
+			return entry, err
+		}
+		entry.Changed = entry.Changed || changed
+	}
+	return entry, nil
+}
+
+func fileChanged(snapshot util.FileSnapshot) (bool, error) {
+	content, err := os.ReadFile(snapshot.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot.Existed, nil
+	} else if err != nil {
+		return false, err
+	}
+	return !snapshot.Existed || !bytes.Equal(content, snapshot.Content), nil
+}
+
+func (j *journal) changed(name string) bool {
+	for _, entry := range j.Daemons {
+		if entry.Name == name {
+			return entry.Changed
+		}
+	}
+	return false
+}
+
+func (j *journal) hasPreviousConfiguration() bool {
+	for _, entry := range j.Daemons {
+		for _, snapshot := range entry.Files {
+			if snapshot.Existed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (j *journal) write(journalPath string) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(journalPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(journalPath, data, transactionJournalPerm)
+}
+
+// loadJournal reads the journal at the given path. An empty journal is
+// returned if the path does not exist, such as when the daemons were not
+// configured by nodeadm.
+func loadJournal(journalPath string) (*journal, error) {
+	data, err := os.ReadFile(journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &journal{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var j journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction journal %s: %w", journalPath, err)
+	}
+	return &j, nil
+}
+
+func removeJournal(journalPath string) error {
+	if err := os.Remove(journalPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
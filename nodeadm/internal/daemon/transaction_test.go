@@ -0,0 +1,195 @@
+package daemon_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// fakeDaemon writes its content to a file when configured, and fails to start
+// while that file contains failContent.
+type fakeDaemon struct {
+	name          string
+	path          string
+	content       string
+	failContent   string
+	daemonManager *daemontest.FakeManager
+}
+
+func (d *fakeDaemon) Configure(context.Context, *api.NodeConfig) error {
+	return util.WriteFileWithDir(d.path, []byte(d.content), 0644)
+}
+
+func (d *fakeDaemon) EnsureRunning() error {
+	if err := d.failIfBroken(); err != nil {
+		return err
+	}
+	return d.daemonManager.StartDaemon(d.name)
+}
+
+func (d *fakeDaemon) Restart() error {
+	if err := d.failIfBroken(); err != nil {
+		return err
+	}
+	return d.daemonManager.RestartDaemon(d.name)
+}
+
+func (d *fakeDaemon) failIfBroken() error {
+	content, err := os.ReadFile(d.path)
+	if err != nil {
+		return err
+	}
+	if d.failContent != "" && string(content) == d.failContent {
+		return errors.New("daemon failed to start")
+	}
+	return nil
+}
+
+func (d *fakeDaemon) PostLaunch(context.Context, *api.NodeConfig) error { return nil }
+func (d *fakeDaemon) Name() string                                      { return d.name }
+
+func newTestTransaction(t *testing.T, daemonManager *daemontest.FakeManager, daemons ...daemon.Daemon) (*daemon.Transaction, string) {
+	journalPath := filepath.Join(t.TempDir(), "transaction.json")
+	return daemon.NewTestTransaction(daemonManager, daemons, journalPath), journalPath
+}
+
+func TestTransaction(t *testing.T) {
+	dir := t.TempDir()
+	containerdPath := filepath.Join(dir, "containerd", "config.toml")
+	kubeletPath := filepath.Join(dir, "kubelet", "config.json")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(containerdPath), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(kubeletPath), 0755))
+	assert.NoError(t, os.WriteFile(containerdPath, []byte("old"), 0644))
+	assert.NoError(t, os.WriteFile(kubeletPath, []byte("old"), 0644))
+
+	daemonManager := daemontest.NewFakeManager("containerd", "kubelet")
+	containerd := &fakeDaemon{name: "containerd", path: containerdPath, content: "new", daemonManager: daemonManager}
+	kubelet := &fakeDaemon{name: "kubelet", path: kubeletPath, content: "new", failContent: "new", daemonManager: daemonManager}
+	transaction, journalPath := newTestTransaction(t, daemonManager, containerd, kubelet)
+
+	assert.NoError(t, transaction.Configure(context.Background(), &api.NodeConfig{}))
+	assert.FileExists(t, journalPath)
+	assert.True(t, transaction.Changed("containerd"))
+	assert.True(t, transaction.Changed("kubelet"))
+
+	err := transaction.EnsureRunning(context.Background(), &api.NodeConfig{})
+	assert.ErrorContains(t, err, "rolled back daemon configuration")
+	assert.ErrorContains(t, err, "failed to run daemon kubelet")
+	assert.Equal(t, []string{
+		"status containerd",
+		"restart containerd",
+		"status kubelet",
+		"daemon-reload",
+		"restart containerd",
+		"restart kubelet",
+	}, daemonManager.Calls())
+	for _, filePath := range []string{containerdPath, kubeletPath} {
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+	}
+	assert.NoFileExists(t, journalPath)
+}
+
+func TestTransactionUnchanged(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config")
+	assert.NoError(t, os.WriteFile(filePath, []byte("same"), 0644))
+
+	daemonManager := daemontest.NewFakeManager("containerd")
+	containerd := &fakeDaemon{name: "containerd", path: filePath, content: "same", daemonManager: daemonManager}
+	transaction, journalPath := newTestTransaction(t, daemonManager, containerd)
+
+	assert.NoError(t, transaction.Configure(context.Background(), &api.NodeConfig{}))
+	assert.NoError(t, transaction.EnsureRunning(context.Background(), &api.NodeConfig{}))
+	assert.Equal(t, []string{"start containerd"}, daemonManager.Calls())
+	assert.False(t, transaction.Changed("containerd"))
+	assert.NoFileExists(t, journalPath)
+}
+
+func TestTransactionFirstConfiguration(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config")
+
+	daemonManager := daemontest.NewFakeManager()
+	kubelet := &fakeDaemon{name: "kubelet", path: filePath, content: "new", failContent: "new", daemonManager: daemonManager}
+	transaction, _ := newTestTransaction(t, daemonManager, kubelet)
+
+	assert.NoError(t, transaction.Configure(context.Background(), &api.NodeConfig{}))
+	err := transaction.EnsureRunning(context.Background(), &api.NodeConfig{})
+	assert.ErrorContains(t, err, "failed to run daemon kubelet")
+	assert.NotContains(t, err.Error(), "rolled back")
+	// there is no previous configuration to restore
+	assert.FileExists(t, filePath)
+	assert.Equal(t, []string{"status kubelet"}, daemonManager.Calls())
+}
+
+type failingDaemon struct {
+	fakeDaemon
+}
+
+func (d *failingDaemon) Configure(ctx context.Context, cfg *api.NodeConfig) error {
+	if err := d.fakeDaemon.Configure(ctx, cfg); err != nil {
+		return err
+	}
+	return errors.New("invalid configuration")
+}
+
+func TestTransactionConfigureFailure(t *testing.T) {
+	dir := t.TempDir()
+	containerdPath := filepath.Join(dir, "containerd")
+	kubeletPath := filepath.Join(dir, "kubelet")
+	assert.NoError(t, os.WriteFile(containerdPath, []byte("old"), 0644))
+
+	daemonManager := daemontest.NewFakeManager()
+	containerd := &fakeDaemon{name: "containerd", path: containerdPath, content: "new", daemonManager: daemonManager}
+	kubelet := &failingDaemon{fakeDaemon{name: "kubelet", path: kubeletPath, content: "new", daemonManager: daemonManager}}
+	transaction, journalPath := newTestTransaction(t, daemonManager, containerd, kubelet)
+
+	assert.ErrorContains(t, transaction.Configure(context.Background(), &api.NodeConfig{}), "failed to configure daemon kubelet")
+	content, err := os.ReadFile(containerdPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+	assert.NoFileExists(t, kubeletPath)
+	assert.NoFileExists(t, journalPath)
+}
+
+type unreachableDaemon struct {
+	fakeDaemon
+}
+
+func (d *unreachableDaemon) PreLaunch(context.Context, *api.NodeConfig) error {
+	return errors.New("endpoint is unreachable")
+}
+
+func TestTransactionPreLaunchFailure(t *testing.T) {
+	dir := t.TempDir()
+	containerdPath := filepath.Join(dir, "containerd")
+	kubeletPath := filepath.Join(dir, "kubelet")
+	assert.NoError(t, os.WriteFile(containerdPath, []byte("old"), 0644))
+	assert.NoError(t, os.WriteFile(kubeletPath, []byte("old"), 0644))
+
+	daemonManager := daemontest.NewFakeManager("containerd", "kubelet")
+	containerd := &fakeDaemon{name: "containerd", path: containerdPath, content: "new", daemonManager: daemonManager}
+	kubelet := &unreachableDaemon{fakeDaemon{name: "kubelet", path: kubeletPath, content: "new", daemonManager: daemonManager}}
+	transaction, _ := newTestTransaction(t, daemonManager, containerd, kubelet)
+
+	assert.NoError(t, transaction.Configure(context.Background(), &api.NodeConfig{}))
+	err := transaction.EnsureRunning(context.Background(), &api.NodeConfig{})
+	assert.ErrorContains(t, err, "failed pre-launch checks of daemon kubelet")
+	// kubelet is not restarted, since it was not started with the new
+	// configuration
+	assert.Equal(t, []string{
+		"status containerd",
+		"restart containerd",
+		"daemon-reload",
+		"restart containerd",
+	}, daemonManager.Calls())
+}
@@ -0,0 +1,88 @@
+package deregister
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	DeregisterDaemonName = "nodeadm-deregister"
+	// DefaultDrainTimeout is used when a drain timeout is not configured.
+	DefaultDrainTimeout = time.Minute
+
+	environmentFilePath = "/etc/eks/nodeadm/deregister/environment"
+	environmentFilePerm = 0644
+	argsEnvironmentName = "NODEADM_DEREGISTER_ARGS"
+)
+
+var _ daemon.Daemon = &deregister{}
+
+// deregister manages a unit that has no work to do when it is started, but
+// deregisters the node when it is stopped as the instance shuts down.
+type deregister struct {
+	daemonManager daemon.DaemonManager
+}
+
+func NewDeregisterDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &deregister{
+		daemonManager: daemonManager,
+	}
+}
+
+func (d *deregister) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	shutdown := cfg.Spec.Instance.Shutdown
+	if !shutdown.DeregisterNode {
+		return Disable()
+	}
+	drainTimeout := DefaultDrainTimeout
+	if shutdown.DrainTimeout != nil {
+		drainTimeout = shutdown.DrainTimeout.Duration
+	}
+	args := fmt.Sprintf("--node-name=%s --drain-timeout=%s", kubelet.GetNodeName(cfg), drainTimeout)
+	return util.WriteFileWithDir(environmentFilePath, []byte(fmt.Sprintf("%s=%s", argsEnvironmentName, args)), environmentFilePerm)
+}
+
+func (d *deregister) EnsureRunning() error {
+	if enabled, err := IsEnabled(); err != nil {
+		return err
+	} else if !enabled {
+		zap.L().Info("Node deregistration on shutdown is not enabled")
+		return nil
+	}
+	return d.daemonManager.StartDaemon(DeregisterDaemonName)
+}
+
+func (d *deregister) PostLaunch(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (d *deregister) Name() string {
+	return DeregisterDaemonName
+}
+
+// IsEnabled returns whether the node is deregistered when the instance shuts
+// down.
+func IsEnabled() (bool, error) {
+	return util.IsFilePathExists(environmentFilePath)
+}
+
+// Disable stops the node from being deregistered when the instance shuts
+// down, including by a unit that is already running, since the unit is only
+// started when its environment exists and only deregisters the node while it
+// does.
+func Disable() error {
+	if err := os.Remove(environmentFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
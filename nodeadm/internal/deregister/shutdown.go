@@ -0,0 +1,16 @@
+package deregister
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// IsRebooting returns whether the system is shutting down in order to reboot,
+// in which case the node will rejoin the cluster and should not be deregistered.
+func IsRebooting() (bool, error) {
+	out, err := exec.Command("systemctl", "list-jobs", "--no-legend", "reboot.target", "kexec.target", "soft-reboot.target").Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) != "", nil
+}
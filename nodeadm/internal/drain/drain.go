@@ -0,0 +1,50 @@
+// Package drain cordons and drains the node before its instance is
+// interrupted, terminated or stopped, for the handlers of those events.
+package drain
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+// Result is how far the node was drained, for the results of the handlers.
+type Result struct {
+	Cordoned bool `json:"cordoned"`
+	Drained  bool `json:"drained"`
+}
+
+// Node cordons the node with the credentials of a kubeconfig, and evicts its
+// pods until they are gone or the timeout passes.
+func Node(ctx context.Context, kubeconfig string, nodeName string, timeout time.Duration) (Result, error) {
+	client, err := node.NewClient(kubeconfig)
+	if err != nil {
+		return Result{}, err
+	}
+	return drainNode(ctx, client, nodeName, timeout)
+}
+
+func drainNode(ctx context.Context, client kubernetes.Interface, nodeName string, timeout time.Duration) (Result, error) {
+	var result Result
+	nodeField := zap.String("node", nodeName)
+
+	zap.L().Info("Cordoning node..", nodeField)
+	if err := node.Cordon(ctx, client, nodeName); err != nil {
+		return result, err
+	}
+	result.Cordoned = true
+
+	zap.L().Info("Draining node..", nodeField, zap.Duration("timeout", timeout))
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := node.Drain(drainCtx, client, nodeName); err != nil {
+		return result, err
+	}
+	result.Drained = true
+	zap.L().Info("Drained node", nodeField)
+	return result, nil
+}
@@ -0,0 +1,26 @@
+package drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDrainNode(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "my-node"}})
+	result, err := drainNode(context.Background(), client, "my-node", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Cordoned: true, Drained: true}, result)
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "my-node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+
+	result, err = drainNode(context.Background(), client, "another-node", time.Minute)
+	assert.Error(t, err)
+	assert.Equal(t, Result{}, result)
+}
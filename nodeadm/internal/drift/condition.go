@@ -0,0 +1,34 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+// ConfigDriftConditionType is the type of the Node condition that reports
+// whether files managed by nodeadm were modified outside of it. The condition
+// is True while a modified file has not been restored.
+const ConfigDriftConditionType corev1.NodeConditionType = "NodeadmConfigDrift"
+
+// ReportModifiedFiles sets the drift condition of the node from the managed
+// files that are modified and were not restored.
+func ReportModifiedFiles(ctx context.Context, client kubernetes.Interface, nodeName string, modifiedFiles []string) error {
+	condition := corev1.NodeCondition{
+		Type:    ConfigDriftConditionType,
+		Status:  corev1.ConditionFalse,
+		Reason:  "FilesUnmodified",
+		Message: "managed files match the content rendered by nodeadm",
+	}
+	if len(modifiedFiles) > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "FilesModified"
+		condition.Message = fmt.Sprintf("managed files were modified outside of nodeadm: %s", strings.Join(modifiedFiles, ", "))
+	}
+	return node.SetCondition(ctx, client, nodeName, condition)
+}
@@ -0,0 +1,43 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReportModifiedFiles(t *testing.T) {
+	const nodeName = "ip-10-0-0-1.ec2.internal"
+	client := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+	ctx := context.Background()
+
+	getCondition := func() *corev1.NodeCondition {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == ConfigDriftConditionType {
+				return &condition
+			}
+		}
+		return nil
+	}
+
+	assert.NoError(t, ReportModifiedFiles(ctx, client, nodeName, []string{"/etc/kubernetes/kubelet/config.json"}))
+	if condition := getCondition(); assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, "FilesModified", condition.Reason)
+		assert.Contains(t, condition.Message, "/etc/kubernetes/kubelet/config.json")
+	}
+
+	assert.NoError(t, ReportModifiedFiles(ctx, client, nodeName, nil))
+	if condition := getCondition(); assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, "FilesUnmodified", condition.Reason)
+	}
+
+	assert.Error(t, ReportModifiedFiles(ctx, client, "missing", nil))
+}
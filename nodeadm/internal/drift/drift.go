@@ -0,0 +1,177 @@
+// Package drift compares the running node against the configuration it was
+// bootstrapped with and the release of the AMI it is expected to run.
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+)
+
+const (
+	SourceNodeConfig = "nodeConfig"
+	SourceRelease    = "release"
+	SourceManifest   = "manifest"
+)
+
+// Drift is a component of the node that does not match what is expected.
+type Drift struct {
+	// Component is the drifted component, such as `kubelet` or the path of a
+	// rendered file
+	Component string `json:"component"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+	// Source is what the expected value comes from, one of nodeConfig,
+	// release or manifest
+	Source string `json:"source"`
+}
+
+// Node is the state of the running node.
+type Node struct {
+	// ImageID is empty when the instance metadata is not read, such as on
+	// hybrid nodes
+	ImageID           string
+	KubeletVersion    string
+	ContainerdVersion string
+	// KubeletFeatureGates are those the running kubelet was started with
+	KubeletFeatureGates map[string]bool
+}
+
+// GetNode collects the state of the running node.
+func GetNode(ctx context.Context, cfg *api.NodeConfig) (*Node, error) {
+	var node Node
+	var err error
+	if cfg.HasInstanceMetadata() {
+		identity, err := imds.GetInstanceIdentityDocument(ctx)
+		if err != nil {
+			return nil, err
+		}
+		node.ImageID = identity.ImageID
+	}
+	if node.KubeletVersion, err = kubelet.GetKubeletVersion(); err != nil {
+		return nil, err
+	}
+	if node.ContainerdVersion, err = containerd.GetContainerdVersion(); err != nil {
+		return nil, err
+	}
+	if node.KubeletFeatureGates, err = kubelet.GetRunningFeatureGates(); err != nil {
+		return nil, fmt.Errorf("failed to read feature gates of running kubelet: %w", err)
+	}
+	return &node, nil
+}
+
+// Detect returns the drifted components of the node. The release is optional,
+// and the versions of the node are only compared when it is provided.
+func Detect(cfg *api.NodeConfig, release *ReleaseManifest, node *Node, files *manifest.Manifest) ([]Drift, error) {
+	drifts := []Drift{}
+	if release != nil {
+		drifts = append(drifts, detectRelease(release, node)...)
+	}
+	featureGates, err := expectedFeatureGates(cfg)
+	if err != nil {
+		return nil, err
+	}
+	drifts = append(drifts, detectFeatureGates(featureGates, node.KubeletFeatureGates)...)
+	for i := range files.Files {
+		file := &files.Files[i]
+		drifted, err := file.Drifted()
+		if err != nil {
+			return nil, err
+		}
+		if drifted {
+			drifts = append(drifts, Drift{
+				Component: file.Path,
+				Expected:  file.SHA256,
+				Actual:    "modified",
+				Source:    SourceManifest,
+			})
+		}
+	}
+	return drifts, nil
+}
+
+func detectRelease(release *ReleaseManifest, node *Node) []Drift {
+	var drifts []Drift
+	if release.ImageID != "" && node.ImageID != "" && release.ImageID != node.ImageID {
+		drifts = append(drifts, Drift{Component: "image", Expected: release.ImageID, Actual: node.ImageID, Source: SourceRelease})
+	}
+	for _, version := range []struct {
+		component, expected, actual string
+	}{
+		{"kubelet", release.KubeletVersion, node.KubeletVersion},
+		{"containerd", release.ContainerdVersion, node.ContainerdVersion},
+	} {
+		if version.expected == "" {
+			continue
+		}
+		expected := normalizeVersion(version.expected)
+		if expected != normalizeVersion(version.actual) {
+			drifts = append(drifts, Drift{Component: version.component, Expected: expected, Actual: version.actual, Source: SourceRelease})
+		}
+	}
+	return drifts
+}
+
+// normalizeVersion drops the suffix of a version of a package, such as the
+// `-1.amzn2023.0.1` of `1.7.27-1.amzn2023.0.1`, and adds the `v` prefix of
+// the versions reported by the binaries.
+func normalizeVersion(version string) string {
+	version, _, _ = strings.Cut(version, "-")
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
+
+// expectedFeatureGates returns the feature gates of the NodeConfig, with those
+// of kubelet.config taking precedence as they do when the config is merged.
+func expectedFeatureGates(cfg *api.NodeConfig) (map[string]bool, error) {
+	featureGates := make(map[string]bool)
+	for gate, enabled := range cfg.Spec.Kubelet.FeatureGates {
+		featureGates[gate] = enabled
+	}
+	if raw, ok := cfg.Spec.Kubelet.Config["featureGates"]; ok {
+		var configFeatureGates map[string]bool
+		if err := json.Unmarshal(raw.Raw, &configFeatureGates); err != nil {
+			return nil, fmt.Errorf("failed to parse featureGates of kubelet.config: %w", err)
+		}
+		for gate, enabled := range configFeatureGates {
+			featureGates[gate] = enabled
+		}
+	}
+	return featureGates, nil
+}
+
+func detectFeatureGates(expected, actual map[string]bool) []Drift {
+	gates := make([]string, 0, len(expected))
+	for gate := range expected {
+		gates = append(gates, gate)
+	}
+	sort.Strings(gates)
+	var drifts []Drift
+	for _, gate := range gates {
+		actualValue := "unset"
+		if enabled, ok := actual[gate]; ok {
+			if enabled == expected[gate] {
+				continue
+			}
+			actualValue = strconv.FormatBool(enabled)
+		}
+		drifts = append(drifts, Drift{
+			Component: "featureGates." + gate,
+			Expected:  strconv.FormatBool(expected[gate]),
+			Actual:    actualValue,
+			Source:    SourceNodeConfig,
+		})
+	}
+	return drifts
+}
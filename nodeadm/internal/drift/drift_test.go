@@ -0,0 +1,149 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+)
+
+func TestDetect(t *testing.T) {
+	node := &Node{
+		ImageID:             "ami-0123456789abcdef0",
+		KubeletVersion:      "v1.33.1",
+		ContainerdVersion:   "v1.7.27",
+		KubeletFeatureGates: map[string]bool{"InPlacePodVerticalScaling": true, "UserNamespacesSupport": false},
+	}
+	var tests = []struct {
+		name           string
+		release        *ReleaseManifest
+		featureGates   map[string]bool
+		kubeletConfig  api.InlineDocument
+		expectedDrifts []Drift
+	}{
+		{
+			name:           "no release",
+			expectedDrifts: []Drift{},
+		},
+		{
+			name: "release matches",
+			release: &ReleaseManifest{
+				ImageID:           "ami-0123456789abcdef0",
+				KubeletVersion:    "1.33.1",
+				ContainerdVersion: "1.7.27-1.amzn2023.0.1",
+			},
+			expectedDrifts: []Drift{},
+		},
+		{
+			name: "release drifted",
+			release: &ReleaseManifest{
+				ImageID:           "ami-0fedcba9876543210",
+				KubeletVersion:    "1.33.3",
+				ContainerdVersion: "1.7.27",
+			},
+			expectedDrifts: []Drift{
+				{Component: "image", Expected: "ami-0fedcba9876543210", Actual: "ami-0123456789abcdef0", Source: SourceRelease},
+				{Component: "kubelet", Expected: "v1.33.3", Actual: "v1.33.1", Source: SourceRelease},
+			},
+		},
+		{
+			name:           "feature gates match",
+			featureGates:   map[string]bool{"InPlacePodVerticalScaling": true},
+			kubeletConfig:  api.InlineDocument{"featureGates": runtime.RawExtension{Raw: []byte(`{"UserNamespacesSupport":false}`)}},
+			expectedDrifts: []Drift{},
+		},
+		{
+			name:          "feature gates drifted",
+			featureGates:  map[string]bool{"InPlacePodVerticalScaling": false, "UserNamespacesSupport": true},
+			kubeletConfig: api.InlineDocument{"featureGates": runtime.RawExtension{Raw: []byte(`{"UserNamespacesSupport":false,"DRAResourceHealthStatus":true}`)}},
+			expectedDrifts: []Drift{
+				{Component: "featureGates.DRAResourceHealthStatus", Expected: "true", Actual: "unset", Source: SourceNodeConfig},
+				{Component: "featureGates.InPlacePodVerticalScaling", Expected: "false", Actual: "true", Source: SourceNodeConfig},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &api.NodeConfig{}
+			cfg.Spec.Kubelet.FeatureGates = test.featureGates
+			cfg.Spec.Kubelet.Config = test.kubeletConfig
+			drifts, err := Detect(cfg, test.release, node, &manifest.Manifest{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedDrifts, drifts)
+		})
+	}
+}
+
+func TestDetectManagedFiles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(filePath, []byte("{}"), 0644))
+	files, err := manifest.Build([]string{filePath})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filePath, []byte(`{"foo":"bar"}`), 0644))
+
+	drifts, err := Detect(&api.NodeConfig{}, nil, &Node{}, files)
+	assert.NoError(t, err)
+	assert.Equal(t, []Drift{{Component: filePath, Expected: files.Files[0].SHA256, Actual: "modified", Source: SourceManifest}}, drifts)
+}
+
+func TestParseReleaseManifest(t *testing.T) {
+	release, err := parseReleaseManifest([]byte(`{"image_id":"ami-0123456789abcdef0","image_name":"amazon-eks-node-al2023-x86_64-standard-1.33-v20250715","release_version":"1.33.1-20250715","kubelet_version":"1.33.1"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseManifest{
+		ImageID:        "ami-0123456789abcdef0",
+		ImageName:      "amazon-eks-node-al2023-x86_64-standard-1.33-v20250715",
+		ReleaseVersion: "1.33.1-20250715",
+		KubeletVersion: "1.33.1",
+	}, release)
+
+	release, err = parseReleaseManifest([]byte("ami-0123456789abcdef0\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleaseManifest{ImageID: "ami-0123456789abcdef0"}, release)
+
+	_, err = parseReleaseManifest([]byte("not a manifest"))
+	assert.Error(t, err)
+}
+
+func TestSetVersionsFromDescription(t *testing.T) {
+	var tests = []struct {
+		name        string
+		release     ReleaseManifest
+		description string
+		expected    ReleaseManifest
+	}{
+		{
+			name:        "al2023",
+			description: "EKS-optimized Kubernetes node based on Amazon Linux 2023, (k8s: 1.33.1, containerd: 1.7.27-1.amzn2023.0.1)",
+			expected:    ReleaseManifest{KubeletVersion: "1.33.1", ContainerdVersion: "1.7.27-1.amzn2023.0.1"},
+		},
+		{
+			name:        "al2 with docker",
+			description: "EKS Kubernetes Worker AMI with AmazonLinux2 image, (k8s: 1.23.17, docker: 20.10.25-1.amzn2.0.4, containerd: 1.6.19-1.amzn2.0.5)",
+			expected:    ReleaseManifest{KubeletVersion: "1.23.17", ContainerdVersion: "1.6.19-1.amzn2.0.5"},
+		},
+		{
+			name:        "versions of release take precedence",
+			release:     ReleaseManifest{KubeletVersion: "1.33.3"},
+			description: "EKS-optimized Kubernetes node based on Amazon Linux 2023, (k8s: 1.33.1, containerd: 1.7.27)",
+			expected:    ReleaseManifest{KubeletVersion: "1.33.3", ContainerdVersion: "1.7.27"},
+		},
+		{
+			name:        "custom image",
+			description: "my custom image",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release := test.release
+			release.setVersionsFromDescription(test.description)
+			assert.Equal(t, test.expected, release)
+		})
+	}
+}
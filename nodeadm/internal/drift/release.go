@@ -0,0 +1,145 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	ec2extra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/offline"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+)
+
+// ReleaseManifest describes a published release of the AMI, in the format of
+// the SSM parameters of the EKS optimized AMIs, such as
+// `/aws/service/eks/optimized-ami/1.33/amazon-linux-2023/x86_64/standard/recommended`.
+// Those parameters do not hold the versions of kubelet and containerd, which
+// are resolved from the description of the image when they are missing,
+// unless offline mode is enabled.
+type ReleaseManifest struct {
+	ImageID           string `json:"image_id"`
+	ImageName         string `json:"image_name,omitempty"`
+	ReleaseVersion    string `json:"release_version,omitempty"`
+	KubeletVersion    string `json:"kubelet_version,omitempty"`
+	ContainerdVersion string `json:"containerd_version,omitempty"`
+}
+
+// LoadReleaseManifest loads the release manifest from an SSM parameter, such
+// as `ssm:///aws/service/eks/optimized-ami/1.33/amazon-linux-2023/x86_64/standard/recommended`,
+// a local file, or a `https://` or `s3://` URL.
+func LoadReleaseManifest(ctx context.Context, rawURL string) (*ReleaseManifest, error) {
+	release, err := loadReleaseManifest(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	// the image cannot be described in offline mode, so the versions that
+	// are missing from the release are not compared
+	if !offline.Enabled() && release.ImageID != "" && (release.KubeletVersion == "" || release.ContainerdVersion == "") {
+		description, err := getImageDescription(ctx, release.ImageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe image %s of release manifest: %w", release.ImageID, err)
+		}
+		release.setVersionsFromDescription(description)
+	}
+	return release, nil
+}
+
+func loadReleaseManifest(ctx context.Context, rawURL string) (*ReleaseManifest, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	switch u.Scheme {
+	case "ssm":
+		data, err = getParameter(ctx, u.Host+u.Path)
+	case "file", "":
+		data, err = os.ReadFile(u.Path)
+	default:
+		data, err = download.Fetch(ctx, rawURL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release manifest from %s: %w", rawURL, err)
+	}
+	return parseReleaseManifest(data)
+}
+
+// parseReleaseManifest parses a release manifest, or the ID of an image such as
+// the value of the `image_id` SSM parameter of a release.
+func parseReleaseManifest(data []byte) (*ReleaseManifest, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "ami-") {
+		return &ReleaseManifest{ImageID: trimmed}, nil
+	}
+	var release ReleaseManifest
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+	return &release, nil
+}
+
+// imageComponentPattern matches the versions of the components in the
+// description of the EKS optimized AMIs, such as
+// `(k8s: 1.33.1, containerd: 1.7.27-1.amzn2023.0.1)`.
+var imageComponentPattern = regexp.MustCompile(`\b(k8s|containerd): ([^,)\s]+)`)
+
+// setVersionsFromDescription sets the versions of kubelet and containerd that
+// are missing from the release to those of the description of its image. The
+// versions are left empty, and so are not compared, for images whose
+// description does not list them.
+func (r *ReleaseManifest) setVersionsFromDescription(description string) {
+	for _, match := range imageComponentPattern.FindAllStringSubmatch(description, -1) {
+		switch match[1] {
+		case "k8s":
+			if r.KubeletVersion == "" {
+				r.KubeletVersion = match[2]
+			}
+		case "containerd":
+			if r.ContainerdVersion == "" {
+				r.ContainerdVersion = match[2]
+			}
+		}
+	}
+}
+
+func getImageDescription(ctx context.Context, imageID string) (string, error) {
+	awsConfig, err := awsconfig.Load(ctx)
+	if err != nil {
+		return "", err
+	}
+	output, err := ec2extra.NewClient(awsConfig).DescribeImages(ctx, &ec2.DescribeImagesInput{
+		ImageIds: []string{imageID},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(output.Images) == 0 {
+		return "", fmt.Errorf("image not found")
+	}
+	return aws.ToString(output.Images[0].Description), nil
+}
+
+func getParameter(ctx context.Context, name string) ([]byte, error) {
+	// awsconfig.Load includes offline.WithOfflineGuard, so that the call
+	// fails in offline mode rather than waiting on the network
+	awsConfig, err := awsconfig.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output, err := ssm.NewFromConfig(awsConfig).GetParameter(ctx, &ssm.GetParameterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(aws.ToString(output.Parameter.Value)), nil
+}
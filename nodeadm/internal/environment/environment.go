@@ -0,0 +1,32 @@
+// Package environment describes the platform that nodeadm runs on, which the
+// architecture-specific defaults of the node are resolved for.
+package environment
+
+import (
+	"runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// Environment is the platform of the node. nodeadm is built for the
+// architecture of the AMI it is installed on, so it is that of the node.
+type Environment struct {
+	OS           string
+	Architecture api.Architecture
+}
+
+var current = Environment{
+	OS:           runtime.GOOS,
+	Architecture: api.Architecture(runtime.GOARCH),
+}
+
+// Current returns the platform of the node.
+func Current() Environment {
+	return current
+}
+
+// Platform returns the platform of the node in the format of container
+// images, such as `linux/arm64`.
+func (e Environment) Platform() string {
+	return e.OS + "/" + string(e.Architecture)
+}
@@ -0,0 +1,21 @@
+package environment
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestCurrent(t *testing.T) {
+	env := Current()
+	assert.Equal(t, api.Architecture(runtime.GOARCH), env.Architecture)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, env.Platform())
+}
+
+func TestPlatform(t *testing.T) {
+	env := Environment{OS: "linux", Architecture: api.ArchitectureARM64}
+	assert.Equal(t, "linux/arm64", env.Platform())
+}
@@ -0,0 +1,281 @@
+package hardening
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// ReportPath is where the controls of the hardening profile that were applied
+// or skipped on the node are reported.
+const ReportPath = "/var/lib/nodeadm/hardening-report.json"
+
+// IDs of the controls that are applied outside of this package.
+const (
+	ControlKubeletStreamingIdleTimeout = "kubelet-streaming-connection-idle-timeout"
+	ControlKubeletIPTablesUtilChains   = "kubelet-make-iptables-util-chains"
+	ControlKubeletEventRateLimit       = "kubelet-event-rate-limit"
+	ControlKubeletStrongCiphers        = "kubelet-strong-cipher-suites"
+	ControlKubeletFilePermissions      = "kubelet-file-permissions"
+)
+
+// Control is a recommendation of the CIS Amazon EKS Benchmark.
+type Control struct {
+	ID          string
+	Description string
+	// Level is the lowest level of the benchmark that includes the control.
+	Level int
+	// KubeletField is the field of the kubelet configuration that the
+	// control sets, which is left to the user when set in kubelet.config.
+	KubeletField string
+	// Sysctls are the kernel parameters that the control sets.
+	Sysctls map[string]string
+	// SkipReason is why the control is never applied, when it conflicts with
+	// Kubernetes or with the way EKS nodes operate.
+	SkipReason string
+}
+
+var controls = []Control{
+	{
+		ID:           "kubelet-anonymous-auth",
+		Description:  "Disable anonymous requests to kubelet",
+		Level:        1,
+		KubeletField: "authentication",
+	},
+	{
+		ID:           "kubelet-authorization-mode",
+		Description:  "Authorize requests to kubelet with the API server",
+		Level:        1,
+		KubeletField: "authorization",
+	},
+	{
+		ID:           "kubelet-read-only-port",
+		Description:  "Disable the read-only port of kubelet",
+		Level:        1,
+		KubeletField: "readOnlyPort",
+	},
+	{
+		ID:           "kubelet-protect-kernel-defaults",
+		Description:  "Fail kubelet when the kernel parameters differ from its defaults",
+		Level:        1,
+		KubeletField: "protectKernelDefaults",
+	},
+	{
+		ID:           ControlKubeletStreamingIdleTimeout,
+		Description:  "Close idle streaming connections to kubelet",
+		Level:        1,
+		KubeletField: "streamingConnectionIdleTimeout",
+	},
+	{
+		ID:           ControlKubeletIPTablesUtilChains,
+		Description:  "Let kubelet manage its iptables chains",
+		Level:        1,
+		KubeletField: "makeIPTablesUtilChains",
+	},
+	{
+		ID:           "kubelet-rotate-certificates",
+		Description:  "Rotate the client certificate of kubelet",
+		Level:        1,
+		KubeletField: "rotateCertificates",
+		SkipReason:   "kubelet authenticates to the cluster with IAM rather than a client certificate",
+	},
+	{
+		ID:           ControlKubeletEventRateLimit,
+		Description:  "Limit the rate at which kubelet records events",
+		Level:        2,
+		KubeletField: "eventRecordQPS",
+	},
+	{
+		ID:           ControlKubeletStrongCiphers,
+		Description:  "Only serve TLS cipher suites with forward secrecy",
+		Level:        2,
+		KubeletField: "tlsCipherSuites",
+	},
+	{
+		ID:          ControlKubeletFilePermissions,
+		Description: "Restrict the kubeconfig and configuration of kubelet to root",
+		Level:       2,
+	},
+	{
+		ID:          "sysctl-kubelet-kernel-defaults",
+		Description: "Set the kernel parameters that kubelet protects",
+		Level:       1,
+		Sysctls: map[string]string{
+			"vm.overcommit_memory": "1",
+			"vm.panic_on_oom":      "0",
+			"kernel.panic":         "10",
+			"kernel.panic_on_oops": "1",
+		},
+	},
+	{
+		ID:          "sysctl-icmp-redirects",
+		Description: "Ignore and do not send ICMP redirects",
+		Level:       1,
+		Sysctls: map[string]string{
+			"net.ipv4.conf.all.accept_redirects":     "0",
+			"net.ipv4.conf.default.accept_redirects": "0",
+			"net.ipv4.conf.all.secure_redirects":     "0",
+			"net.ipv4.conf.default.secure_redirects": "0",
+			"net.ipv4.conf.all.send_redirects":       "0",
+			"net.ipv4.conf.default.send_redirects":   "0",
+		},
+	},
+	{
+		ID:          "sysctl-log-martians",
+		Description: "Log packets with impossible addresses",
+		Level:       1,
+		Sysctls: map[string]string{
+			"net.ipv4.conf.all.log_martians":     "1",
+			"net.ipv4.conf.default.log_martians": "1",
+		},
+	},
+	{
+		ID:          "sysctl-icmp-broadcasts",
+		Description: "Ignore broadcast ICMP echo requests",
+		Level:       1,
+		Sysctls: map[string]string{
+			"net.ipv4.icmp_echo_ignore_broadcasts": "1",
+		},
+	},
+	{
+		ID:          "sysctl-tcp-syncookies",
+		Description: "Enable TCP SYN cookies",
+		Level:       1,
+		Sysctls: map[string]string{
+			"net.ipv4.tcp_syncookies": "1",
+		},
+	},
+	{
+		ID:          "sysctl-aslr",
+		Description: "Randomize the layout of virtual address space",
+		Level:       1,
+		Sysctls: map[string]string{
+			"kernel.randomize_va_space": "2",
+		},
+	},
+	{
+		ID:          "sysctl-ip-forward",
+		Description: "Disable IP forwarding",
+		Level:       1,
+		SkipReason:  "the traffic of pods is forwarded by the node",
+	},
+	{
+		ID:          "sysctl-reverse-path-filter",
+		Description: "Enable strict reverse path filtering",
+		Level:       1,
+		SkipReason:  "the VPC CNI routes the traffic of pods through secondary network interfaces",
+	},
+	{
+		ID:          "sysctl-suid-dumpable",
+		Description: "Disable core dumps of setuid programs",
+		Level:       2,
+		Sysctls: map[string]string{
+			"fs.suid_dumpable": "0",
+		},
+	},
+	{
+		ID:          "sysctl-ptrace-scope",
+		Description: "Restrict ptrace to descendant processes",
+		Level:       2,
+		Sysctls: map[string]string{
+			"kernel.yama.ptrace_scope": "1",
+		},
+	},
+	{
+		ID:          "sysctl-dmesg-restrict",
+		Description: "Restrict the kernel log to privileged users",
+		Level:       2,
+		Sysctls: map[string]string{
+			"kernel.dmesg_restrict": "1",
+		},
+	},
+}
+
+func profileLevel(profile api.HardeningProfile) int {
+	switch profile {
+	case api.HardeningProfileCISLevel1:
+		return 1
+	case api.HardeningProfileCISLevel2:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// ControlStatus is whether a control was applied to the node.
+type ControlStatus string
+
+const (
+	ControlStatusApplied ControlStatus = "applied"
+	ControlStatusSkipped ControlStatus = "skipped"
+)
+
+// ControlResult is the outcome of a control on the node.
+type ControlResult struct {
+	ID          string        `json:"id"`
+	Description string        `json:"description"`
+	Status      ControlStatus `json:"status"`
+	Reason      string        `json:"reason,omitempty"`
+}
+
+// Report lists the controls of the hardening profile of the node.
+type Report struct {
+	Profile  api.HardeningProfile `json:"profile"`
+	Controls []ControlResult      `json:"controls"`
+}
+
+// Evaluate decides which controls of the hardening profile are applied to the
+// node.
+func Evaluate(cfg *api.NodeConfig) Report {
+	report := Report{
+		Profile:  cfg.Spec.Security.HardeningProfile,
+		Controls: []ControlResult{},
+	}
+	level := profileLevel(cfg.Spec.Security.HardeningProfile)
+	for _, control := range controls {
+		if control.Level > level {
+			continue
+		}
+		result := ControlResult{
+			ID:          control.ID,
+			Description: control.Description,
+			Status:      ControlStatusApplied,
+		}
+		if control.SkipReason != "" {
+			result.Status = ControlStatusSkipped
+			result.Reason = control.SkipReason
+		} else if _, ok := cfg.Spec.Kubelet.Config[control.KubeletField]; ok && control.KubeletField != "" {
+			result.Status = ControlStatusSkipped
+			result.Reason = "overridden by kubelet.config." + control.KubeletField
+		}
+		report.Controls = append(report.Controls, result)
+	}
+	return report
+}
+
+// Applied returns whether the control is applied to the node.
+func Applied(cfg *api.NodeConfig, id string) bool {
+	for _, result := range Evaluate(cfg).Controls {
+		if result.ID == id {
+			return result.Status == ControlStatusApplied
+		}
+	}
+	return false
+}
+
+// Sysctls returns the kernel parameters of the controls that are applied to
+// the node.
+func Sysctls(cfg *api.NodeConfig) map[string]string {
+	applied := map[string]bool{}
+	for _, result := range Evaluate(cfg).Controls {
+		applied[result.ID] = result.Status == ControlStatusApplied
+	}
+	sysctls := map[string]string{}
+	for _, control := range controls {
+		if !applied[control.ID] {
+			continue
+		}
+		for key, value := range control.Sysctls {
+			sysctls[key] = value
+		}
+	}
+	return sysctls
+}
@@ -0,0 +1,65 @@
+package hardening
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func configWithProfile(profile api.HardeningProfile) *api.NodeConfig {
+	cfg := &api.NodeConfig{}
+	cfg.Spec.Security.HardeningProfile = profile
+	return cfg
+}
+
+func findResult(report Report, id string) *ControlResult {
+	for i := range report.Controls {
+		if report.Controls[i].ID == id {
+			return &report.Controls[i]
+		}
+	}
+	return nil
+}
+
+func TestEvaluate(t *testing.T) {
+	assert.Empty(t, Evaluate(configWithProfile("")).Controls)
+	assert.Empty(t, Evaluate(configWithProfile(api.HardeningProfileNone)).Controls)
+
+	level1 := Evaluate(configWithProfile(api.HardeningProfileCISLevel1))
+	level2 := Evaluate(configWithProfile(api.HardeningProfileCISLevel2))
+	assert.Less(t, len(level1.Controls), len(level2.Controls))
+	assert.Nil(t, findResult(level1, ControlKubeletEventRateLimit))
+	assert.Equal(t, ControlStatusApplied, findResult(level2, ControlKubeletEventRateLimit).Status)
+
+	ipForward := findResult(level1, "sysctl-ip-forward")
+	assert.Equal(t, ControlStatusSkipped, ipForward.Status)
+	assert.NotEmpty(t, ipForward.Reason)
+}
+
+func TestEvaluateKubeletOverride(t *testing.T) {
+	cfg := configWithProfile(api.HardeningProfileCISLevel2)
+	cfg.Spec.Kubelet.Config = api.InlineDocument{
+		"eventRecordQPS": runtime.RawExtension{Raw: []byte("50")},
+	}
+	result := findResult(Evaluate(cfg), ControlKubeletEventRateLimit)
+	assert.Equal(t, ControlStatusSkipped, result.Status)
+	assert.Equal(t, "overridden by kubelet.config.eventRecordQPS", result.Reason)
+	assert.False(t, Applied(cfg, ControlKubeletEventRateLimit))
+	assert.True(t, Applied(cfg, ControlKubeletFilePermissions))
+}
+
+func TestSysctls(t *testing.T) {
+	assert.Empty(t, Sysctls(configWithProfile(api.HardeningProfileNone)))
+
+	level1 := Sysctls(configWithProfile(api.HardeningProfileCISLevel1))
+	assert.Equal(t, "0", level1["net.ipv4.conf.all.send_redirects"])
+	assert.NotContains(t, level1, "fs.suid_dumpable")
+	assert.NotContains(t, level1, "net.ipv4.ip_forward")
+
+	level2 := Sysctls(configWithProfile(api.HardeningProfileCISLevel2))
+	assert.Equal(t, "0", level2["fs.suid_dumpable"])
+	assert.Equal(t, "2", level2["kernel.randomize_va_space"])
+}
@@ -0,0 +1,53 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+var (
+	_ daemon.Daemon   = &hookDaemon{}
+	_ daemon.Unitless = &hookDaemon{}
+)
+
+// hookDaemon runs the hooks of a point of the bootstrap. It has no unit of
+// its own, and is only a daemon so that its post-launch task is ordered after
+// the daemon it follows, and is rolled back with it.
+type hookDaemon struct {
+	point string
+	name  string
+}
+
+// NewPostContainerdDaemon runs the postContainerd hooks.
+func NewPostContainerdDaemon() daemon.Daemon {
+	return &hookDaemon{point: PointPostContainerd, name: "hooks-post-containerd"}
+}
+
+// NewPostKubeletDaemon runs the postKubelet hooks.
+func NewPostKubeletDaemon() daemon.Daemon {
+	return &hookDaemon{point: PointPostKubelet, name: "hooks-post-kubelet"}
+}
+
+func (h *hookDaemon) Configure(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (h *hookDaemon) EnsureRunning() error {
+	return nil
+}
+
+func (h *hookDaemon) PostLaunch(ctx context.Context, cfg *api.NodeConfig) error {
+	hooks := cfg.Spec.Hooks.PostContainerd
+	if h.point == PointPostKubelet {
+		hooks = cfg.Spec.Hooks.PostKubelet
+	}
+	return Run(ctx, h.point, hooks)
+}
+
+func (h *hookDaemon) Name() string {
+	return h.name
+}
+
+func (h *hookDaemon) Unitless() {}
@@ -0,0 +1,136 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+)
+
+// the points of the bootstrap at which hooks are run
+const (
+	PointPreInit        = "preInit"
+	PointPostContainerd = "postContainerd"
+	PointPostKubelet    = "postKubelet"
+)
+
+const (
+	DefaultTimeout = 5 * time.Minute
+
+	// the processes started by a hook that was killed may hold its output
+	// open, which is abandoned after this delay.
+	outputWaitDelay = time.Second
+)
+
+// Run runs the hooks of a point of the bootstrap one at a time, in order. A
+// hook that fails fails the point, unless its failure policy is Ignore.
+func Run(ctx context.Context, point string, hooks []api.Hook) error {
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("%s[%d]", point, i)
+		}
+		log := zap.L().With(zap.String("point", point), zap.String("hook", name))
+		log.Info("Running hook..")
+		start := time.Now()
+		if err := runHook(ctx, log, point, name, hook); err != nil {
+			if hook.FailurePolicy == api.HookFailurePolicyIgnore {
+				log.Warn("Ignoring failure of hook", zap.Error(err))
+				continue
+			}
+			return fmt.Errorf("hook %s failed: %w", name, err)
+		}
+		log.Info("Finished hook", zap.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
+
+func runHook(ctx context.Context, log *zap.Logger, point string, name string, hook api.Hook) error {
+	timeout := DefaultTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	script := hook.Script
+	if hook.URL != "" {
+		// the download counts towards the timeout of the hook
+		log.Info("Downloading hook script..", zap.String("url", hook.URL))
+		data, err := download.Fetch(ctx, hook.URL, download.WithSHA256(hook.SHA256))
+		if err != nil {
+			return err
+		}
+		script = string(data)
+	}
+	var cmd *exec.Cmd
+	if len(hook.Command) > 0 {
+		// #nosec G204 Subprocess launched with variable
+		cmd = exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+	} else {
+		cmd = scriptCommand(ctx, script)
+	}
+	cmd.Env = append(os.Environ(), "NODEADM_HOOK_POINT="+point, "NODEADM_HOOK_NAME="+name)
+	stdout := newLineWriter(func(line string) {
+		log.Info("Hook output", zap.String("stream", "stdout"), zap.String("line", line))
+	})
+	stderr := newLineWriter(func(line string) {
+		log.Info("Hook output", zap.String("stream", "stderr"), zap.String("line", line))
+	})
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = outputWaitDelay
+	err := cmd.Run()
+	stdout.Flush()
+	stderr.Flush()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
+
+// lineWriter calls emit with each line that is written to it, so that the
+// output of a hook is logged a line at a time.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	emit func(line string)
+}
+
+func newLineWriter(emit func(line string)) *lineWriter {
+	return &lineWriter{emit: emit}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf.Next(i + 1)
+		w.emit(string(bytes.TrimRight(line, "\r\n")))
+	}
+	return len(p), nil
+}
+
+// Flush emits the last line, if it did not end with a newline.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.emit(w.buf.String())
+		w.buf.Reset()
+	}
+}
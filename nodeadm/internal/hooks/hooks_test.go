@@ -0,0 +1,19 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := newLineWriter(func(line string) {
+		lines = append(lines, line)
+	})
+	_, _ = w.Write([]byte("first\nsec"))
+	_, _ = w.Write([]byte("ond\r\nlast"))
+	assert.Equal(t, []string{"first", "second"}, lines)
+	w.Flush()
+	assert.Equal(t, []string{"first", "second", "last"}, lines)
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"context"
+	"os/exec"
+)
+
+// scriptCommand runs a script with the shell.
+func scriptCommand(ctx context.Context, script string) *exec.Cmd {
+	// #nosec G204 Subprocess launched with variable
+	return exec.CommandContext(ctx, "/bin/sh", "-c", script)
+}
@@ -0,0 +1,61 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	var tests = []struct {
+		name      string
+		hooks     []api.Hook
+		expectErr string
+	}{
+		{name: "none"},
+		{
+			name:  "command",
+			hooks: []api.Hook{{Command: []string{"/bin/sh", "-c", `echo "$NODEADM_HOOK_POINT $NODEADM_HOOK_NAME" > ` + out}}},
+		},
+		{
+			name:      "failed script",
+			hooks:     []api.Hook{{Name: "fails", Script: "echo failing >&2; exit 3"}},
+			expectErr: "hook fails failed: exit status 3",
+		},
+		{
+			name: "ignored failure",
+			hooks: []api.Hook{
+				{Script: "exit 1", FailurePolicy: api.HookFailurePolicyIgnore},
+				{Script: "true"},
+			},
+		},
+		{
+			name:      "timeout",
+			hooks:     []api.Hook{{Script: "sleep 5", Timeout: &metav1.Duration{Duration: 100 * time.Millisecond}}},
+			expectErr: "hook preInit[0] failed: timed out after 100ms",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Run(context.Background(), PointPreInit, test.hooks)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	written, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "preInit preInit[0]\n", string(written))
+}
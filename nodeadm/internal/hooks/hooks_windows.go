@@ -0,0 +1,12 @@
+package hooks
+
+import (
+	"context"
+	"os/exec"
+)
+
+// scriptCommand runs a script with PowerShell, since Windows has no /bin/sh.
+func scriptCommand(ctx context.Context, script string) *exec.Cmd {
+	// #nosec G204 Subprocess launched with variable
+	return exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-Command", script)
+}
@@ -0,0 +1,44 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestRunWindows(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	var tests = []struct {
+		name      string
+		hooks     []api.Hook
+		expectErr string
+	}{
+		{
+			name:  "script",
+			hooks: []api.Hook{{Script: `Set-Content -NoNewline -Path '` + out + `' -Value "$env:NODEADM_HOOK_POINT $env:NODEADM_HOOK_NAME"`}},
+		},
+		{
+			name:      "failed script",
+			hooks:     []api.Hook{{Name: "fails", Script: "exit 3"}},
+			expectErr: "hook fails failed: exit status 3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Run(context.Background(), PointPreInit, test.hooks)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	written, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "preInit preInit[0]", string(written))
+}
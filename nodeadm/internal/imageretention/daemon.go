@@ -0,0 +1,49 @@
+package imageretention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const (
+	ImageRetentionDaemonName = "nodeadm-image-retention"
+
+	environmentFilePath = "/etc/eks/nodeadm/image-retention/environment"
+	argsEnvironmentName = "NODEADM_IMAGE_RETENTION_ARGS"
+)
+
+var (
+	_ daemon.Daemon      = &imageRetention{}
+	_ daemon.Restartable = &imageRetention{}
+)
+
+// imageRetention manages the unit that runs `nodeadm images retain`.
+type imageRetention struct {
+	daemon.EnvironmentUnit
+}
+
+func NewImageRetentionDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &imageRetention{
+		EnvironmentUnit: daemon.NewEnvironmentUnit(daemonManager, ImageRetentionDaemonName, environmentFilePath, argsEnvironmentName),
+	}
+}
+
+func (r *imageRetention) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	retention := cfg.Spec.Containerd.ImageRetention
+	if len(retention.PinnedImages) == 0 && retention.KeepLast == 0 {
+		return r.Disable()
+	}
+	return r.Enable(generateArgs(retention))
+}
+
+func generateArgs(retention api.ImageRetentionOptions) string {
+	args := []string{fmt.Sprintf("--keep-last=%d", retention.KeepLast)}
+	for _, image := range retention.PinnedImages {
+		args = append(args, fmt.Sprintf("--pinned-image=%s", image))
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,284 @@
+package imageretention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	DefaultInterval = 5 * time.Minute
+
+	// containerdNamespace is the namespace of the images of the CRI.
+	containerdNamespace = "k8s.io"
+	// pinnedLabel marks the images that the CRI reports as pinned, which
+	// kubelet never garbage collects.
+	pinnedLabel      = "io.cri-containerd.pinned"
+	pinnedLabelValue = "pinned"
+
+	statePath = "/var/lib/nodeadm/image-retention.json"
+	statePerm = 0644
+	// defaultRegistry is the registry of references without a host, such as
+	// `busybox:latest`.
+	defaultRegistry = "docker.io"
+)
+
+type Config struct {
+	// PinnedImages are the references of the images that are always retained.
+	PinnedImages []string
+	// KeepLast is the number of the most recently pulled images that are
+	// retained.
+	KeepLast int
+}
+
+// image is a reference to an image in the content store of containerd.
+type image struct {
+	Ref    string
+	Digest string
+	Pinned bool
+}
+
+// state is persisted between runs, since containerd does not record when an
+// image was pulled.
+type state struct {
+	// FirstSeen is when each digest was first listed.
+	FirstSeen map[string]time.Time `json:"firstSeen"`
+	// Pinned are the references that were pinned by nodeadm, which are the
+	// only ones it unpins.
+	Pinned []string `json:"pinned"`
+}
+
+// Retainer pins the images to retain, so that they are skipped by the image
+// garbage collection of kubelet, and unpins them once they are no longer to
+// be retained.
+type Retainer struct {
+	config Config
+
+	listImages func() ([]image, error)
+	setPinned  func(ref string, pinned bool) error
+	now        func() time.Time
+	statePath  string
+}
+
+func NewRetainer(config Config) *Retainer {
+	return &Retainer{
+		config:     config,
+		listImages: listImages,
+		setPinned:  setPinned,
+		now:        time.Now,
+		statePath:  statePath,
+	}
+}
+
+// Run retains the images at each interval until the context is cancelled.
+func (r *Retainer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Retain(); err != nil {
+			// the images are retained again at the next interval
+			zap.L().Error("Failed to retain images", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Retain pins the images that are to be retained, and unpins those that were
+// pinned by nodeadm and no longer are.
+func (r *Retainer) Retain() error {
+	s, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	images, err := r.listImages()
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	digests := map[string]bool{}
+	for _, image := range images {
+		digests[image.Digest] = true
+		if _, ok := s.FirstSeen[image.Digest]; !ok {
+			s.FirstSeen[image.Digest] = now
+		}
+	}
+	for digest := range s.FirstSeen {
+		if !digests[digest] {
+			delete(s.FirstSeen, digest)
+		}
+	}
+
+	retained := selectRetained(images, s.FirstSeen, r.config)
+	// the state is saved even when an image fails to be pinned or unpinned, so
+	// that the pins of nodeadm are not forgotten and the next run retries
+	var errs []error
+	var pinned []string
+	for _, image := range images {
+		ownPin := slices.Contains(s.Pinned, image.Ref)
+		switch {
+		case retained[image.Ref] && !image.Pinned:
+			zap.L().Info("Pinning image..", zap.String("ref", image.Ref))
+			if err := r.setPinned(image.Ref, true); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			pinned = append(pinned, image.Ref)
+		case retained[image.Ref] && ownPin:
+			pinned = append(pinned, image.Ref)
+		case !retained[image.Ref] && ownPin && image.Pinned:
+			// images pinned by others, such as the sandbox image, are never
+			// unpinned
+			zap.L().Info("Unpinning image..", zap.String("ref", image.Ref))
+			if err := r.setPinned(image.Ref, false); err != nil {
+				errs = append(errs, err)
+				pinned = append(pinned, image.Ref)
+			}
+		}
+	}
+	s.Pinned = pinned
+	return errors.Join(append(errs, r.saveState(s))...)
+}
+
+// selectRetained returns the references of the images that are pinned by the
+// configuration, and of the last images to have been pulled. All references
+// to a retained digest are retained.
+func selectRetained(images []image, firstSeen map[string]time.Time, config Config) map[string]bool {
+	var pinnedImages []string
+	for _, ref := range config.PinnedImages {
+		pinnedImages = append(pinnedImages, normalizeReference(ref))
+	}
+	retainedDigests := map[string]bool{}
+	var digests []string
+	for _, image := range images {
+		if slices.Contains(pinnedImages, image.Ref) || slices.Contains(pinnedImages, image.Digest) {
+			retainedDigests[image.Digest] = true
+		}
+		if !slices.Contains(digests, image.Digest) {
+			digests = append(digests, image.Digest)
+		}
+	}
+	sort.SliceStable(digests, func(i, j int) bool {
+		return firstSeen[digests[i]].After(firstSeen[digests[j]])
+	})
+	for _, digest := range digests[:min(config.KeepLast, len(digests))] {
+		retainedDigests[digest] = true
+	}
+	retained := map[string]bool{}
+	for _, image := range images {
+		if retainedDigests[image.Digest] {
+			retained[image.Ref] = true
+		}
+	}
+	return retained
+}
+
+// normalizeReference returns the fully qualified form of an image reference,
+// which is how containerd names the images of the CRI.
+func normalizeReference(ref string) string {
+	if strings.HasPrefix(ref, "sha256:") {
+		return ref
+	}
+	first, _, found := strings.Cut(ref, "/")
+	if !found || !(strings.ContainsAny(first, ".:") || first == "localhost") {
+		if !found {
+			ref = "library/" + ref
+		}
+		ref = defaultRegistry + "/" + ref
+	}
+	if strings.Contains(ref, "@") {
+		return ref
+	}
+	// the tag follows the last component, as the host may have a port
+	if name := ref[strings.LastIndex(ref, "/")+1:]; !strings.Contains(name, ":") {
+		ref += ":latest"
+	}
+	return ref
+}
+
+// listImages lists the images of the CRI with ctr.
+func listImages() ([]image, error) {
+	out, err := exec.Command("ctr", "--namespace", containerdNamespace, "images", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	return parseImages(string(out))
+}
+
+// parseImages parses the output of `ctr images list`, in which the labels are
+// the last column.
+func parseImages(out string) ([]image, error) {
+	var images []image
+	for i, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) == 0 {
+			// the header
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("failed to parse image %q", line)
+		}
+		var pinned bool
+		for _, label := range strings.Split(fields[len(fields)-1], ",") {
+			if label == pinnedLabel+"="+pinnedLabelValue {
+				pinned = true
+			}
+		}
+		images = append(images, image{Ref: fields[0], Digest: fields[2], Pinned: pinned})
+	}
+	return images, nil
+}
+
+// setPinned labels an image as pinned, or removes the label, which the CRI
+// picks up from the events of containerd.
+func setPinned(ref string, pinned bool) error {
+	label := pinnedLabel + "="
+	if pinned {
+		label += pinnedLabelValue
+	}
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command("ctr", "--namespace", containerdNamespace, "images", "label", ref, label).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to label image %s: %w: %s", ref, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (r *Retainer) loadState() (*state, error) {
+	s := state{FirstSeen: map[string]time.Time{}}
+	data, err := os.ReadFile(r.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", r.statePath, err)
+	}
+	if s.FirstSeen == nil {
+		s.FirstSeen = map[string]time.Time{}
+	}
+	return &s, nil
+}
+
+func (r *Retainer) saveState(s *state) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileWithDir(r.statePath, data, statePerm)
+}
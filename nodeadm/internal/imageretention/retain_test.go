@@ -0,0 +1,136 @@
+package imageretention
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImages(t *testing.T) {
+	out := `REF                                                                 TYPE                                                 DIGEST                                                                  SIZE      PLATFORMS   LABELS
+docker.io/library/busybox:latest                                    application/vnd.oci.image.index.v1+json              sha256:aaa                                                              2.1 MiB   linux/amd64 io.cri-containerd.image=managed
+registry.k8s.io/pause:3.9                                           application/vnd.docker.distribution.manifest.list.v2 sha256:bbb                                                              311.6 KiB linux/amd64 io.cri-containerd.image=managed,io.cri-containerd.pinned=pinned
+sha256:ccc                                                          application/vnd.oci.image.index.v1+json              sha256:aaa                                                              2.1 MiB   linux/amd64 -
+`
+	images, err := parseImages(out)
+	assert.NoError(t, err)
+	assert.Equal(t, []image{
+		{Ref: "docker.io/library/busybox:latest", Digest: "sha256:aaa"},
+		{Ref: "registry.k8s.io/pause:3.9", Digest: "sha256:bbb", Pinned: true},
+		{Ref: "sha256:ccc", Digest: "sha256:aaa"},
+	}, images)
+}
+
+func TestNormalizeReference(t *testing.T) {
+	var tests = []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "busybox", expected: "docker.io/library/busybox:latest"},
+		{ref: "example/app:v1", expected: "docker.io/example/app:v1"},
+		{ref: "localhost:5000/app", expected: "localhost:5000/app:latest"},
+		{ref: "public.ecr.aws/eks-distro/kubernetes/pause:3.9", expected: "public.ecr.aws/eks-distro/kubernetes/pause:3.9"},
+		{ref: "public.ecr.aws/app@sha256:aaa", expected: "public.ecr.aws/app@sha256:aaa"},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizeReference(test.ref))
+		})
+	}
+}
+
+func TestSelectRetained(t *testing.T) {
+	now := time.Now()
+	images := []image{
+		{Ref: "docker.io/library/busybox:latest", Digest: "sha256:aaa"},
+		{Ref: "sha256:ccc", Digest: "sha256:aaa"},
+		{Ref: "docker.io/library/nginx:latest", Digest: "sha256:bbb"},
+		{Ref: "public.ecr.aws/app:v1", Digest: "sha256:ddd"},
+	}
+	firstSeen := map[string]time.Time{
+		"sha256:aaa": now.Add(-time.Hour),
+		"sha256:bbb": now,
+		"sha256:ddd": now.Add(-2 * time.Hour),
+	}
+	var tests = []struct {
+		name     string
+		config   Config
+		expected map[string]bool
+	}{
+		{name: "none", expected: map[string]bool{}},
+		{
+			name:     "pinned",
+			config:   Config{PinnedImages: []string{"busybox"}},
+			expected: map[string]bool{"docker.io/library/busybox:latest": true, "sha256:ccc": true},
+		},
+		{
+			name:     "keep last",
+			config:   Config{KeepLast: 2},
+			expected: map[string]bool{"docker.io/library/nginx:latest": true, "docker.io/library/busybox:latest": true, "sha256:ccc": true},
+		},
+		{
+			name:     "pinned and keep last",
+			config:   Config{PinnedImages: []string{"public.ecr.aws/app:v1"}, KeepLast: 1},
+			expected: map[string]bool{"docker.io/library/nginx:latest": true, "public.ecr.aws/app:v1": true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, selectRetained(images, firstSeen, test.config))
+		})
+	}
+}
+
+func TestRetain(t *testing.T) {
+	images := []image{
+		{Ref: "docker.io/library/busybox:latest", Digest: "sha256:aaa"},
+		{Ref: "docker.io/library/nginx:latest", Digest: "sha256:bbb", Pinned: true},
+		{Ref: "registry.k8s.io/pause:3.9", Digest: "sha256:eee", Pinned: true},
+	}
+	pinned := map[string]bool{}
+	r := NewRetainer(Config{PinnedImages: []string{"busybox"}})
+	r.statePath = filepath.Join(t.TempDir(), "image-retention.json")
+	r.listImages = func() ([]image, error) { return images, nil }
+	r.setPinned = func(ref string, p bool) error {
+		pinned[ref] = p
+		return nil
+	}
+	// nginx was pinned by nodeadm before it was removed from the configuration
+	assert.NoError(t, r.saveState(&state{Pinned: []string{"docker.io/library/nginx:latest"}}))
+
+	assert.NoError(t, r.Retain())
+	// the sandbox image was pinned by the CRI, and is left pinned
+	assert.Equal(t, map[string]bool{"docker.io/library/busybox:latest": true, "docker.io/library/nginx:latest": false}, pinned)
+	s, err := r.loadState()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docker.io/library/busybox:latest"}, s.Pinned)
+	assert.Len(t, s.FirstSeen, 3)
+}
+
+func TestRetainSavesStateOnError(t *testing.T) {
+	images := []image{
+		{Ref: "docker.io/library/busybox:latest", Digest: "sha256:aaa"},
+		{Ref: "docker.io/library/alpine:latest", Digest: "sha256:bbb"},
+		{Ref: "docker.io/library/nginx:latest", Digest: "sha256:ccc", Pinned: true},
+	}
+	r := NewRetainer(Config{PinnedImages: []string{"busybox", "alpine"}})
+	r.statePath = filepath.Join(t.TempDir(), "image-retention.json")
+	r.listImages = func() ([]image, error) { return images, nil }
+	r.setPinned = func(ref string, p bool) error {
+		if ref == "docker.io/library/busybox:latest" {
+			return nil
+		}
+		return errors.New("containerd is not running")
+	}
+	assert.NoError(t, r.saveState(&state{Pinned: []string{"docker.io/library/nginx:latest"}}))
+
+	assert.ErrorContains(t, r.Retain(), "containerd is not running")
+	s, err := r.loadState()
+	assert.NoError(t, err)
+	// the pin that was made, and the one that failed to be removed, are kept
+	assert.Equal(t, []string{"docker.io/library/busybox:latest", "docker.io/library/nginx:latest"}, s.Pinned)
+	assert.Len(t, s.FirstSeen, 3)
+}
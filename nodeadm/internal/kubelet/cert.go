@@ -1,13 +1,59 @@
 package kubelet
 
 import (
+	"bytes"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
-const caCertificatePath = "/etc/kubernetes/pki/ca.crt"
+const defaultServingCertificateTimeout = 5 * time.Minute
 
 // Write the cluster certifcate authority to the filesystem where
 // both kubelet and kubeconfig can read it
-func writeClusterCaCert(caCert []byte) error {
-	return util.WriteFileWithDir(caCertificatePath, caCert, kubeletConfigPerm)
+func writeClusterCaCert(cfg *api.NodeConfig) error {
+	caCert := cfg.Spec.Cluster.CertificateAuthority
+	if err := util.WriteFileWithDir(caCertificatePath, caCert, kubeletConfigPerm); err != nil {
+		return err
+	}
+	if trustStoreCAs := cfg.Spec.Instance.TrustStore.CertificateAuthorities; trustStoreCAs != "" {
+		bundle := bytes.Join([][]byte{bytes.TrimSpace(caCert), bytes.TrimSpace([]byte(trustStoreCAs))}, []byte("\n"))
+		return util.WriteFileWithDir(caBundlePath, append(bundle, '\n'), kubeletConfigPerm)
+	}
+	return nil
+}
+
+// getKubeconfigCaPath returns the certificate authority used to verify the
+// kube-apiserver.
+func getKubeconfigCaPath(cfg *api.NodeConfig) string {
+	if cfg.Spec.Instance.TrustStore.CertificateAuthorities != "" {
+		return caBundlePath
+	}
+	return caCertificatePath
+}
+
+// waitForServingCertificate waits for the serving certificate that kubelet
+// requests once it is started, which is needed for the API server to reach
+// kubelet for logs and exec.
+func waitForServingCertificate(ctx context.Context, cfg *api.NodeConfig) error {
+	timeout := defaultServingCertificateTimeout
+	if configured := cfg.Spec.Kubelet.ServingCertificate.Timeout; configured != nil {
+		timeout = configured.Duration
+	}
+	client, err := node.NewClient(KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	nodeName := GetNodeName(cfg)
+	zap.L().Info("Waiting for kubelet serving certificate to be issued..", zap.String("node", nodeName), zap.Duration("timeout", timeout))
+	if err := node.WaitForServingCertificate(ctx, client, nodeName, timeout); err != nil {
+		return err
+	}
+	zap.L().Info("Kubelet serving certificate was issued", zap.String("node", nodeName))
+	return nil
 }
@@ -0,0 +1,110 @@
+package kubelet
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const certificatePinTimeout = 10 * time.Second
+
+var errCertificatePinMismatch = errors.New("no certificate presented by the kube-apiserver matches the certificate pins")
+
+// getCertificatePin returns the hash of the Subject Public Key Info of a
+// certificate, in the same format as the pins of the cluster configuration.
+func getCertificatePin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+// checkCertificatePins succeeds when any certificate of the verified chains
+// matches one of the pins.
+func checkCertificatePins(chains [][]*x509.Certificate, pins []string) error {
+	var presented []string
+	for _, chain := range chains {
+		for _, cert := range chain {
+			pin := getCertificatePin(cert)
+			if slices.Contains(pins, pin) {
+				return nil
+			}
+			presented = append(presented, pin)
+		}
+	}
+	return fmt.Errorf("%w, presented: %v", errCertificatePinMismatch, presented)
+}
+
+// newAPIServerCertPool returns the certificate authorities that the
+// kube-apiserver is verified with.
+func newAPIServerCertPool(cfg *api.NodeConfig) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(cfg.Spec.Cluster.CertificateAuthority) {
+		return nil, fmt.Errorf("no certificates found in cluster certificate authority")
+	}
+	if cas := cfg.Spec.Instance.TrustStore.CertificateAuthorities; cas != "" {
+		// the kube-apiserver is verified with the same bundle as kubelet
+		roots.AppendCertsFromPEM([]byte(cas))
+	}
+	return roots, nil
+}
+
+// verifyCertificatePins connects to the kube-apiserver and checks that it
+// presents a pinned certificate, so that the kubeconfig is not written for an
+// endpoint whose DNS name has been hijacked. Connection failures are retried,
+// since the endpoint may not be reachable as soon as the node boots, but a
+// mismatch is not.
+func verifyCertificatePins(ctx context.Context, cfg *api.NodeConfig) error {
+	pins := cfg.Spec.Cluster.CertificatePins
+	if len(pins) == 0 {
+		return nil
+	}
+	roots, err := newAPIServerCertPool(cfg)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Timeout: certificatePinTimeout,
+		Transport: &http.Transport{
+			Proxy: util.ProxyFromEnvironment(),
+			TLSClientConfig: &tls.Config{
+				RootCAs: roots,
+				VerifyConnection: func(state tls.ConnectionState) error {
+					return checkCertificatePins(state.VerifiedChains, pins)
+				},
+			},
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	zap.L().Info("Verifying the certificate pins of the kube-apiserver..", zap.String("endpoint", cfg.Spec.Cluster.APIServerEndpoint))
+	var mismatch error
+	err = util.NewRetrier().Retry(ctx, func() error {
+		// the response is not needed, as the certificate is verified as part
+		// of the handshake
+		resp, err := client.Get(cfg.Spec.Cluster.APIServerEndpoint + "/healthz")
+		if errors.Is(err, errCertificatePinMismatch) {
+			mismatch = err
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	})
+	if mismatch != nil {
+		return mismatch
+	} else if err != nil {
+		return fmt.Errorf("failed to verify the certificate pins of the kube-apiserver: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package kubelet
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestVerifyCertificatePins(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	cert := server.Certificate()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+
+	var tests = []struct {
+		name      string
+		pins      []string
+		expectErr bool
+	}{
+		{name: "no pins"},
+		{name: "matching pin", pins: []string{"sha256:0000000000000000000000000000000000000000000000000000000000000000", getCertificatePin(cert)}},
+		{name: "mismatched pin", pins: []string{"sha256:0000000000000000000000000000000000000000000000000000000000000000"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						APIServerEndpoint:    server.URL,
+						CertificateAuthority: ca,
+						CertificatePins:      test.pins,
+					},
+				},
+			}
+			err := verifyCertificatePins(context.Background(), &cfg)
+			if test.expectErr {
+				assert.ErrorIs(t, err, errCertificatePinMismatch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// CgroupAccountingConditionType is the type of the Node condition that
+	// reports whether the controllers required to enforce pod resource limits
+	// are enabled. The condition is True when accounting is degraded.
+	CgroupAccountingConditionType corev1.NodeConditionType = "CgroupAccountingDegraded"
+
+	cgroupMountPath           = "/sys/fs/cgroup"
+	cgroupVerificationTimeout = 2 * time.Minute
+	cgroupPollInterval        = time.Second
+)
+
+// the controllers that kubelet depends on to enforce the cpu, memory and pid
+// limits of pods.
+var requiredCgroupControllers = []string{"cpu", "memory", "pids"}
+
+// the cgroup beneath which kubelet creates pod cgroups, for the systemd and
+// cgroupfs drivers respectively.
+var kubepodsCgroups = []string{"kubepods.slice", "kubepods"}
+
+// verifyCgroupAccounting checks that the required controllers are enabled for
+// the pod cgroups beneath the kubepods cgroup, and reports the result as a
+// condition of the Node. When controllers are not delegated to the cgroup,
+// kubelet starts successfully but pod limits are silently not enforced.
+func verifyCgroupAccounting(ctx context.Context, cfg *api.NodeConfig) error {
+	if exists, err := util.IsFilePathExists(filepath.Join(cgroupMountPath, "cgroup.controllers")); err != nil {
+		return err
+	} else if !exists {
+		zap.L().Info("Skipping cgroup accounting verification, unified cgroup hierarchy is not mounted")
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, cgroupVerificationTimeout)
+	defer cancel()
+
+	cgroupPath, err := waitForKubepodsCgroup(ctx, cgroupMountPath)
+	if err != nil {
+		return err
+	}
+	missing, err := getMissingCgroupControllers(cgroupPath)
+	if err != nil {
+		return err
+	}
+	condition := corev1.NodeCondition{
+		Type:    CgroupAccountingConditionType,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ControllersEnabled",
+		Message: fmt.Sprintf("cgroup controllers %s are enabled for pods", strings.Join(requiredCgroupControllers, ", ")),
+	}
+	if len(missing) > 0 {
+		zap.L().Warn("Required cgroup controllers are not enabled for pods", zap.String("cgroup", cgroupPath), zap.Strings("controllers", missing))
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "ControllersNotEnabled"
+		condition.Message = fmt.Sprintf("cgroup controllers %s are not enabled in %s, pod resource limits are not enforced", strings.Join(missing, ", "), cgroupPath)
+	}
+
+	client, err := node.NewClient(KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	nodeName := GetNodeName(cfg)
+	zap.L().Info("Waiting for node registration to report cgroup accounting..", zap.String("node", nodeName))
+	if _, err := node.WaitForNode(ctx, client, nodeName); err != nil {
+		return err
+	}
+	return node.SetCondition(ctx, client, nodeName, condition)
+}
+
+// waitForKubepodsCgroup returns the path of the kubepods cgroup once kubelet
+// has created it.
+func waitForKubepodsCgroup(ctx context.Context, mountPath string) (string, error) {
+	for {
+		for _, cgroup := range kubepodsCgroups {
+			cgroupPath := filepath.Join(mountPath, cgroup)
+			if exists, err := util.IsFilePathExists(cgroupPath); err != nil {
+				return "", err
+			} else if exists {
+				return cgroupPath, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("kubepods cgroup was not created: %w", ctx.Err())
+		case <-time.After(cgroupPollInterval):
+		}
+	}
+}
+
+// getMissingCgroupControllers returns the required controllers that are not
+// enabled for the children of the given cgroup.
+func getMissingCgroupControllers(cgroupPath string) ([]string, error) {
+	subtreeControl, err := os.ReadFile(filepath.Join(cgroupPath, "cgroup.subtree_control"))
+	if err != nil {
+		return nil, err
+	}
+	enabled := strings.Fields(string(subtreeControl))
+	var missing []string
+	for _, controller := range requiredCgroupControllers {
+		if !slices.Contains(enabled, controller) {
+			missing = append(missing, controller)
+		}
+	}
+	return missing, nil
+}
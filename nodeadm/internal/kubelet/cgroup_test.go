@@ -0,0 +1,38 @@
+package kubelet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMissingCgroupControllers(t *testing.T) {
+	var tests = []struct {
+		subtreeControl string
+		missing        []string
+	}{
+		{subtreeControl: "cpuset cpu io memory hugetlb pids rdma misc\n", missing: nil},
+		{subtreeControl: "cpuset cpu io memory\n", missing: []string{"pids"}},
+		{subtreeControl: "\n", missing: []string{"cpu", "memory", "pids"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.subtreeControl, func(t *testing.T) {
+			mountPath := t.TempDir()
+			cgroupPath := filepath.Join(mountPath, "kubepods.slice")
+			assert.NoError(t, os.Mkdir(cgroupPath, 0755))
+			assert.NoError(t, os.WriteFile(filepath.Join(cgroupPath, "cgroup.subtree_control"), []byte(test.subtreeControl), 0644))
+
+			found, err := waitForKubepodsCgroup(context.Background(), mountPath)
+			assert.NoError(t, err)
+			assert.Equal(t, cgroupPath, found)
+
+			missing, err := getMissingCgroupControllers(found)
+			assert.NoError(t, err)
+			assert.Equal(t, test.missing, missing)
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
-	"path"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,33 +19,60 @@ import (
 	"golang.org/x/mod/semver"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8skubelet "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/cpuset"
 
 	"github.com/aws/smithy-go/ptr"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/hardening"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
 const (
-	kubeletConfigRoot = "/etc/kubernetes/kubelet"
 	kubeletConfigFile = "config.json"
 	kubeletConfigDir  = "config.json.d"
 	kubeletConfigPerm = 0644
 )
 
-func (k *kubelet) writeKubeletConfig(cfg *api.NodeConfig) error {
+// RemoteConfigDropInPath is the drop-in of the kubelet config that is fetched
+// from kubelet.remoteConfig. It is written by `nodeadm kubelet-config` rather
+// than by init, and sorts after the drop-in of the NodeConfig so that it
+// takes precedence.
+var RemoteConfigDropInPath = filepath.Join(kubeletConfigRoot, kubeletConfigDir, "50-nodeadm-remote.conf")
+
+// hasEFAInterfaces, countNeuronDevices and getNUMANodes are replaced in tests,
+// which run on hosts without EFA or Neuron devices, and with any topology
+var (
+	hasEFAInterfaces   = system.HasEFAInterfaces
+	countNeuronDevices = system.CountNeuronDevices
+	getNUMANodes       = system.GetNUMANodes
+)
+
+func (k *kubelet) writeKubeletConfig(ctx context.Context, cfg *api.NodeConfig) error {
+	kubeletConfig, err := k.GenerateKubeletConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
 	// tracking: https://github.com/kubernetes/enhancements/issues/3983
 	// for enabling drop-in configuration
 	if semver.Compare(cfg.Status.KubeletVersion, "v1.29.0") < 0 {
-		return k.writeKubeletConfigToFile(cfg)
+		if cfg.Spec.Kubelet.RemoteConfig.Source != "" {
+			return fmt.Errorf("kubelet.remoteConfig requires kubelet v1.29 or later, which reads the drop-ins of its config")
+		}
+		err = k.writeKubeletConfigToFile(cfg, kubeletConfig)
 	} else {
-		return k.writeKubeletConfigToDir(cfg)
+		err = k.writeKubeletConfigToDir(cfg, kubeletConfig)
+	}
+	if err != nil {
+		return err
 	}
+	return checkDisk(cfg, kubeletConfig)
 }
 
 // kubeletConfig is an internal-only representation of the kubelet configuration
@@ -50,38 +80,64 @@ func (k *kubelet) writeKubeletConfig(cfg *api.NodeConfig) error {
 // KubeletConfiguration types:
 // https://pkg.go.dev/k8s.io/kubelet/config/v1beta1#KubeletConfiguration
 type kubeletConfig struct {
-	Address                  string                           `json:"address"`
-	Authentication           k8skubelet.KubeletAuthentication `json:"authentication"`
-	Authorization            k8skubelet.KubeletAuthorization  `json:"authorization"`
-	CgroupDriver             string                           `json:"cgroupDriver"`
-	CgroupRoot               string                           `json:"cgroupRoot"`
-	ClusterDNS               []string                         `json:"clusterDNS"`
-	ClusterDomain            string                           `json:"clusterDomain"`
-	ContainerRuntimeEndpoint string                           `json:"containerRuntimeEndpoint"`
-	EvictionHard             map[string]string                `json:"evictionHard,omitempty"`
-	FeatureGates             map[string]bool                  `json:"featureGates"`
-	HairpinMode              string                           `json:"hairpinMode"`
-	KubeAPIBurst             *int                             `json:"kubeAPIBurst,omitempty"`
-	KubeAPIQPS               *int                             `json:"kubeAPIQPS,omitempty"`
-	KubeReserved             map[string]string                `json:"kubeReserved,omitempty"`
-	KubeReservedCgroup       *string                          `json:"kubeReservedCgroup,omitempty"`
-	Logging                  loggingConfiguration             `json:"logging"`
-	MaxPods                  int32                            `json:"maxPods,omitempty"`
-	ProtectKernelDefaults    bool                             `json:"protectKernelDefaults"`
-	ProviderID               *string                          `json:"providerID,omitempty"`
-	ReadOnlyPort             int                              `json:"readOnlyPort"`
-	RegisterWithTaints       []v1.Taint                       `json:"registerWithTaints,omitempty"`
-	SerializeImagePulls      bool                             `json:"serializeImagePulls"`
-	ServerTLSBootstrap       bool                             `json:"serverTLSBootstrap"`
-	SystemReservedCgroup     *string                          `json:"systemReservedCgroup,omitempty"`
-	TLSCipherSuites          []string                         `json:"tlsCipherSuites"`
-	metav1.TypeMeta          `json:",inline"`
+	Address                         string                           `json:"address"`
+	Authentication                  k8skubelet.KubeletAuthentication `json:"authentication"`
+	Authorization                   k8skubelet.KubeletAuthorization  `json:"authorization"`
+	CgroupDriver                    string                           `json:"cgroupDriver"`
+	CgroupRoot                      string                           `json:"cgroupRoot"`
+	ClusterDNS                      []string                         `json:"clusterDNS"`
+	ClusterDomain                   string                           `json:"clusterDomain"`
+	ContainerLogMaxFiles            *int32                           `json:"containerLogMaxFiles,omitempty"`
+	ContainerLogMaxSize             string                           `json:"containerLogMaxSize,omitempty"`
+	ContainerRuntimeEndpoint        string                           `json:"containerRuntimeEndpoint"`
+	CPUManagerPolicy                string                           `json:"cpuManagerPolicy,omitempty"`
+	EventBurst                      *int32                           `json:"eventBurst,omitempty"`
+	EventRecordQPS                  *int32                           `json:"eventRecordQPS,omitempty"`
+	EvictionHard                    map[string]string                `json:"evictionHard,omitempty"`
+	FailSwapOn                      *bool                            `json:"failSwapOn,omitempty"`
+	FeatureGates                    map[string]bool                  `json:"featureGates"`
+	HairpinMode                     string                           `json:"hairpinMode"`
+	ImageGCHighThresholdPercent     *int32                           `json:"imageGCHighThresholdPercent,omitempty"`
+	ImageGCLowThresholdPercent      *int32                           `json:"imageGCLowThresholdPercent,omitempty"`
+	ImageMaximumGCAge               *metav1.Duration                 `json:"imageMaximumGCAge,omitempty"`
+	ImageMinimumGCAge               *metav1.Duration                 `json:"imageMinimumGCAge,omitempty"`
+	KubeAPIBurst                    *int                             `json:"kubeAPIBurst,omitempty"`
+	KubeAPIQPS                      *int                             `json:"kubeAPIQPS,omitempty"`
+	KubeReserved                    map[string]string                `json:"kubeReserved,omitempty"`
+	KubeReservedCgroup              *string                          `json:"kubeReservedCgroup,omitempty"`
+	Logging                         loggingConfiguration             `json:"logging"`
+	MakeIPTablesUtilChains          *bool                            `json:"makeIPTablesUtilChains,omitempty"`
+	MaxPods                         int32                            `json:"maxPods,omitempty"`
+	MemoryManagerPolicy             string                           `json:"memoryManagerPolicy,omitempty"`
+	MemorySwap                      *memorySwapConfiguration         `json:"memorySwap,omitempty"`
+	ProtectKernelDefaults           bool                             `json:"protectKernelDefaults"`
+	ProviderID                      *string                          `json:"providerID,omitempty"`
+	ReadOnlyPort                    int                              `json:"readOnlyPort"`
+	RegisterWithTaints              []v1.Taint                       `json:"registerWithTaints,omitempty"`
+	ReservedMemory                  []k8skubelet.MemoryReservation   `json:"reservedMemory,omitempty"`
+	ReservedSystemCPUs              string                           `json:"reservedSystemCPUs,omitempty"`
+	SeccompDefault                  *bool                            `json:"seccompDefault,omitempty"`
+	SerializeImagePulls             bool                             `json:"serializeImagePulls"`
+	ServerTLSBootstrap              bool                             `json:"serverTLSBootstrap"`
+	ShutdownGracePeriod             *metav1.Duration                 `json:"shutdownGracePeriod,omitempty"`
+	ShutdownGracePeriodCriticalPods *metav1.Duration                 `json:"shutdownGracePeriodCriticalPods,omitempty"`
+	StaticPodPath                   string                           `json:"staticPodPath,omitempty"`
+	StreamingConnectionIdleTimeout  *metav1.Duration                 `json:"streamingConnectionIdleTimeout,omitempty"`
+	SystemReserved                  map[string]string                `json:"systemReserved,omitempty"`
+	SystemReservedCgroup            *string                          `json:"systemReservedCgroup,omitempty"`
+	TLSCipherSuites                 []string                         `json:"tlsCipherSuites"`
+	TopologyManagerPolicy           string                           `json:"topologyManagerPolicy,omitempty"`
+	metav1.TypeMeta                 `json:",inline"`
 }
 
 type loggingConfiguration struct {
 	Verbosity int `json:"verbosity"`
 }
 
+type memorySwapConfiguration struct {
+	SwapBehavior string `json:"swapBehavior,omitempty"`
+}
+
 // Creates an internal kubelet configuration from the public facing bootstrap
 // kubelet configuration with additional sane defaults.
 func defaultKubeletSubConfig() kubeletConfig {
@@ -112,7 +168,7 @@ func defaultKubeletSubConfig() kubeletConfig {
 		},
 		CgroupDriver:             "systemd",
 		CgroupRoot:               "/",
-		ClusterDomain:            "cluster.local",
+		ClusterDomain:            defaultClusterDomain,
 		ContainerRuntimeEndpoint: containerd.ContainerRuntimeEndpoint,
 		EvictionHard: map[string]string{
 			"memory.available":  "100Mi",
@@ -143,17 +199,6 @@ func defaultKubeletSubConfig() kubeletConfig {
 	}
 }
 
-// Update the ClusterDNS of the internal kubelet config using a heuristic based
-// on the cluster service IP CIDR address.
-func (ksc *kubeletConfig) withFallbackClusterDns(cluster *api.ClusterDetails) error {
-	clusterDns, err := cluster.GetClusterDns()
-	if err != nil {
-		return err
-	}
-	ksc.ClusterDNS = []string{clusterDns}
-	return nil
-}
-
 // To support worker nodes to continue to communicate and connect to local cluster even when the Outpost
 // is disconnected from the parent AWS Region, the following specific setup are required:
 //   - append entries to /etc/hosts with the mappings of control plane host IP address and API server
@@ -197,8 +242,17 @@ func (ksc *kubeletConfig) withOutpostSetup(cfg *api.NodeConfig) error {
 	return nil
 }
 
-func (ksc *kubeletConfig) withNodeIp(cfg *api.NodeConfig, flags map[string]string) error {
-	nodeIp, err := getNodeIp(context.TODO(), cfg)
+func (ksc *kubeletConfig) withNodeIp(ctx context.Context, cfg *api.NodeConfig, flags map[string]string) error {
+	if cfg.IsHybrid() {
+		// kubelet will detect the address of the default interface if none is
+		// provided, since there is no metadata service to query.
+		if nodeIp := cfg.Spec.Hybrid.NodeIP; nodeIp != "" {
+			flags["node-ip"] = nodeIp
+			zap.L().Info("Setup IP for node", zap.String("ip", nodeIp))
+		}
+		return nil
+	}
+	nodeIp, err := getNodeIp(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -237,42 +291,432 @@ func (ksc *kubeletConfig) withVersionToggles(cfg *api.NodeConfig, flags map[stri
 }
 
 func (ksc *kubeletConfig) withCloudProvider(cfg *api.NodeConfig, flags map[string]string) {
+	if cfg.IsHybrid() {
+		// hybrid nodes are not managed by a cloud provider, so no provider ID
+		// is set and the name of the Node object comes from the config.
+		flags["hostname-override"] = GetNodeName(cfg)
+		return
+	}
 	if semver.Compare(cfg.Status.KubeletVersion, "v1.26.0") >= 0 {
 		// ref: https://github.com/kubernetes/kubernetes/pull/121367
 		flags["cloud-provider"] = "external"
 		// provider ID needs to be specified when the cloud provider is external
 		ksc.ProviderID = ptr.String(getProviderId(cfg.Status.Instance.AvailabilityZone, cfg.Status.Instance.ID))
-		var nodeName string
 		if api.IsFeatureEnabled(api.InstanceIdNodeName, cfg.Spec.FeatureGates) {
 			zap.L().Info("Opt-in Instance Id naming strategy")
-			nodeName = cfg.Status.Instance.ID
-		} else {
-			// the name of the Node object default to EC2 PrivateDnsName
-			// see: https://github.com/awslabs/amazon-eks-ami/pull/1264
-			nodeName = cfg.Status.Instance.PrivateDNSName
 		}
-		flags["hostname-override"] = nodeName
+		flags["hostname-override"] = GetNodeName(cfg)
 	} else {
 		flags["cloud-provider"] = "aws"
 	}
 }
 
+// GetNodeName returns the name of the Node object that kubelet registers.
+func GetNodeName(cfg *api.NodeConfig) string {
+	if cfg.IsHybrid() {
+		return cfg.Spec.Hybrid.NodeName
+	}
+	if semver.Compare(cfg.Status.KubeletVersion, "v1.26.0") >= 0 && api.IsFeatureEnabled(api.InstanceIdNodeName, cfg.Spec.FeatureGates) {
+		return cfg.Status.Instance.ID
+	}
+	// the name of the Node object default to EC2 PrivateDnsName
+	// see: https://github.com/awslabs/amazon-eks-ami/pull/1264
+	return cfg.Status.Instance.PrivateDNSName
+}
+
 // When the DefaultReservedResources flag is enabled, override the kubelet
 // config with reserved cgroup values on behalf of the user
-func (ksc *kubeletConfig) withDefaultReservedResources(cfg *api.NodeConfig) {
+func (ksc *kubeletConfig) withDefaultReservedResources(ctx context.Context, cfg *api.NodeConfig) {
 	ksc.SystemReservedCgroup = ptr.String("/system")
 	ksc.KubeReservedCgroup = ptr.String("/runtime")
-	if maxPods, ok := MaxPodsPerInstanceType[cfg.Status.Instance.Type]; ok {
+	if cfg.IsHybrid() {
+		// there is no instance type to derive a limit from
+		ksc.MaxPods = defaultMaxPods
+	} else if maxPods, ok := MaxPodsPerInstanceType[cfg.Status.Instance.Type]; ok {
 		// #nosec G115 // known source from ec2 apis within int32 range
 		ksc.MaxPods = int32(maxPods)
+	} else if cfg.Spec.Offline {
+		// the instance type cannot be described, so resources are reserved
+		// for the maxPods of kubelet.config, which is required in offline mode
+		// for the instance types that are not in the table
+		ksc.MaxPods = getUserMaxPods(cfg)
 	} else {
-		ksc.MaxPods = CalcMaxPods(cfg.Status.Instance.Region, cfg.Status.Instance.Type)
+		ksc.MaxPods = CalcMaxPods(ctx, cfg.Status.Instance.Region, cfg.Status.Instance.Type)
+	}
+	if _, ok := cfg.Spec.Kubelet.Config["maxPods"]; !ok && !cfg.IsHybrid() && len(cfg.Spec.Networking.ExcludeInterfaces) > 0 {
+		ksc.MaxPods -= getExcludedInterfacePods(ctx, cfg)
 	}
+	policy := cfg.Spec.Kubelet.ReservedResourcesPolicy
+	formula, ok := reservedResourcesFormulas[policy]
+	if !ok {
+		// the policy is validated, so this is the default
+		policy, formula = api.ReservedResourcesPolicyEKS, eksReservedResources
+	}
+	cpuMillicores, memoryMebibytes := formula(getNodeCapacity(policy, ksc.MaxPods))
+	zap.L().Info("Reserving resources for kubelet and the container runtime..",
+		zap.String("policy", string(policy)),
+		zap.Int("cpuMillicores", cpuMillicores),
+		zap.Int("memoryMebibytes", memoryMebibytes))
 	ksc.KubeReserved = map[string]string{
-		"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
+		"cpu":               fmt.Sprintf("%dm", cpuMillicores),
 		"ephemeral-storage": "1Gi",
-		"memory":            fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(ksc.MaxPods)),
+		"memory":            fmt.Sprintf("%dMi", memoryMebibytes),
+	}
+}
+
+// withCgroupDriver sets the cgroup driver, which must agree with the driver
+// of containerd. The systemd driver maps the reserved cgroups to the slices of
+// the same name, which must be named in full for the cgroupfs driver.
+func (ksc *kubeletConfig) withCgroupDriver(cfg *api.NodeConfig) {
+	driver := cfg.GetCgroupDriver()
+	ksc.CgroupDriver = string(driver)
+	if driver != api.CgroupDriverCgroupfs {
+		return
+	}
+	if ksc.SystemReservedCgroup != nil {
+		ksc.SystemReservedCgroup = ptr.String(*ksc.SystemReservedCgroup + ".slice")
+	}
+	if ksc.KubeReservedCgroup != nil {
+		ksc.KubeReservedCgroup = ptr.String(*ksc.KubeReservedCgroup + ".slice")
+	}
+}
+
+// withSwap allows kubelet to start on a node with swap, which it refuses by
+// default, and determines whether pods may use it.
+func (ksc *kubeletConfig) withSwap(cfg *api.NodeConfig) error {
+	behavior := cfg.Spec.Kubelet.SwapBehavior
+	if behavior == "" && cfg.Spec.Instance.Swap.Device == "" {
+		return nil
+	}
+	// NoSwap replaced the UnlimitedSwap behavior in 1.30
+	if semver.Compare(cfg.Status.KubeletVersion, "v1.30.0") < 0 {
+		return fmt.Errorf("swap requires kubelet v1.30 or later, found %s", cfg.Status.KubeletVersion)
+	}
+	if behavior == "" {
+		// the swap of the node is only used by processes outside of pods
+		behavior = api.SwapBehaviorNoSwap
+	}
+	ksc.FailSwapOn = ptr.Bool(false)
+	ksc.MemorySwap = &memorySwapConfiguration{SwapBehavior: string(behavior)}
+	// NodeSwap is locked to enabled once it is GA in 1.34
+	if semver.Compare(cfg.Status.KubeletVersion, "v1.34.0") < 0 {
+		ksc.FeatureGates["NodeSwap"] = true
+	}
+	return nil
+}
+
+// withUserNamespaces allows pods to run in user namespaces. Before 1.30,
+// user namespaces were only supported for pods without volumes.
+func (ksc *kubeletConfig) withUserNamespaces(cfg *api.NodeConfig) error {
+	if !cfg.Spec.Security.UserNamespaces.Enabled {
+		return nil
+	}
+	if semver.Compare(cfg.Status.KubeletVersion, "v1.30.0") < 0 {
+		return fmt.Errorf("user namespaces require kubelet v1.30 or later, found %s", cfg.Status.KubeletVersion)
+	}
+	ksc.FeatureGates["UserNamespacesSupport"] = true
+	return nil
+}
+
+// withImageRetention sets the disk usage thresholds and the ages at which
+// kubelet garbage collects unused images.
+func (ksc *kubeletConfig) withImageRetention(cfg *api.NodeConfig) error {
+	retention := cfg.Spec.Containerd.ImageRetention
+	if retention.HighThresholdPercent != 0 {
+		ksc.ImageGCHighThresholdPercent = ptr.Int32(int32(retention.HighThresholdPercent))
+	}
+	if retention.LowThresholdPercent != 0 {
+		ksc.ImageGCLowThresholdPercent = ptr.Int32(int32(retention.LowThresholdPercent))
+	}
+	ksc.ImageMinimumGCAge = retention.MinimumAge
+	if retention.MaximumAge != nil {
+		// ImageMaximumGCAge is enabled by default once it is beta in 1.30
+		if semver.Compare(cfg.Status.KubeletVersion, "v1.30.0") < 0 {
+			return fmt.Errorf("containerd.imageRetention.maximumAge requires kubelet v1.30 or later, found %s", cfg.Status.KubeletVersion)
+		}
+		ksc.ImageMaximumGCAge = retention.MaximumAge
+	}
+	return nil
+}
+
+// withStaticPods sets the directory of the static pod manifests, which is only
+// watched by kubelet when static pods are configured.
+func (ksc *kubeletConfig) withStaticPods(cfg *api.NodeConfig) {
+	if len(cfg.Spec.Kubelet.StaticPods) > 0 {
+		ksc.StaticPodPath = StaticPodPath
+	}
+}
+
+// withHardening applies the controls of the hardening profile that are set in
+// the kubelet configuration. Controls that are already met by the defaults,
+// such as the disabled read-only port, are only reported.
+func (ksc *kubeletConfig) withHardening(cfg *api.NodeConfig) {
+	if hardening.Applied(cfg, hardening.ControlKubeletStreamingIdleTimeout) {
+		ksc.StreamingConnectionIdleTimeout = &metav1.Duration{Duration: 4 * time.Hour}
+	}
+	if hardening.Applied(cfg, hardening.ControlKubeletIPTablesUtilChains) {
+		ksc.MakeIPTablesUtilChains = ptr.Bool(true)
+	}
+	if hardening.Applied(cfg, hardening.ControlKubeletEventRateLimit) {
+		ksc.EventRecordQPS = ptr.Int32(5)
+		ksc.EventBurst = ptr.Int32(10)
+	}
+	if hardening.Applied(cfg, hardening.ControlKubeletStrongCiphers) {
+		// the RSA key exchange does not provide forward secrecy
+		ksc.TLSCipherSuites = slices.DeleteFunc(ksc.TLSCipherSuites, func(suite string) bool {
+			return strings.HasPrefix(suite, "TLS_RSA_")
+		})
+	}
+}
+
+// writeKubeletFile writes the kubeconfig or configuration of kubelet, which is
+// restricted to root by the hardening profile. The permissions of an existing
+// file are not changed by writing it, so they are set afterwards.
+func writeKubeletFile(cfg *api.NodeConfig, filePath string, data []byte, perm os.FileMode) error {
+	if err := util.WriteFileWithDir(filePath, data, perm); err != nil {
+		return err
+	}
+	if hardening.Applied(cfg, hardening.ControlKubeletFilePermissions) {
+		return os.Chmod(filePath, 0600)
+	}
+	return nil
+}
+
+const (
+	// podLogsMaxFiles is the number of log files kept for each container when
+	// pod logs are stored in memory, the minimum that kubelet allows.
+	podLogsMaxFiles     = 2
+	podLogsMinFileBytes = mebibyte
+	podLogsMaxFileBytes = 10 * mebibyte
+
+	mebibyte = 1024 * 1024
+)
+
+// withPodLogs sets the rotation of container logs. The tmpfs that holds pod
+// logs when they are stored in memory is reserved from the node's allocatable
+// memory, since its pages are charged to the runtime rather than to pods, and
+// the rotation is sized so that the logs of a full node fit within it.
+func (ksc *kubeletConfig) withPodLogs(cfg *api.NodeConfig) {
+	podLogs := &cfg.Spec.Instance.PodLogs
+	if podLogs.MaxFiles != nil {
+		ksc.ContainerLogMaxFiles = podLogs.MaxFiles
+	}
+	if podLogs.MaxFileSize != nil {
+		ksc.ContainerLogMaxSize = podLogs.MaxFileSize.String()
+	}
+	if !podLogs.IsMemoryBacked() {
+		return
+	}
+	limit := podLogs.GetMemoryLimit()
+	ksc.addKubeReservedMemory(limit)
+	if podLogs.MaxFiles == nil {
+		ksc.ContainerLogMaxFiles = ptr.Int32(podLogsMaxFiles)
+	}
+	if podLogs.MaxFileSize == nil {
+		ksc.ContainerLogMaxSize = getContainerLogMaxSize(limit.Value(), ksc.MaxPods, *ksc.ContainerLogMaxFiles)
+	}
+}
+
+// withShutdown enables the graceful node shutdown of kubelet, which holds a
+// systemd-logind inhibitor lock to delay the shutdown while pods terminate.
+func (ksc *kubeletConfig) withShutdown(cfg *api.NodeConfig) {
+	shutdown := cfg.Spec.Instance.Shutdown
+	if shutdown.GracePeriod == nil {
+		return
+	}
+	ksc.ShutdownGracePeriod = shutdown.GracePeriod
+	ksc.ShutdownGracePeriodCriticalPods = shutdown.CriticalPodsGracePeriod
+}
+
+// withEFA reserves the memory that the EFA driver pins outside of the cgroups
+// of pods, on instances with EFA interfaces.
+func (ksc *kubeletConfig) withEFA(cfg *api.NodeConfig) error {
+	efa := cfg.Spec.Instance.EFA
+	if !efa.Enabled || efa.ReservedMemory == nil {
+		return nil
+	}
+	if present, err := hasEFAInterfaces(); err != nil {
+		return err
+	} else if present {
+		ksc.addKubeReservedMemory(*efa.ReservedMemory)
+	}
+	return nil
+}
+
+// withNeuron reserves the memory that the Neuron driver allocates outside of
+// the cgroups of pods, on instances with Neuron devices.
+func (ksc *kubeletConfig) withNeuron(cfg *api.NodeConfig) error {
+	neuron := cfg.Spec.Instance.Neuron
+	if !neuron.Enabled || neuron.ReservedMemory == nil {
+		return nil
+	}
+	if devices, err := countNeuronDevices(); err != nil {
+		return err
+	} else if devices > 0 {
+		ksc.addKubeReservedMemory(*neuron.ReservedMemory)
+	}
+	return nil
+}
+
+// withResourceManagers sets the policies of the CPU, memory and topology
+// managers of kubelet. The static policies need resources that are reserved
+// for system daemons and kubelet, which are placed on the first NUMA node of
+// the instance unless the reserved CPUs are given.
+func (ksc *kubeletConfig) withResourceManagers(cfg *api.NodeConfig) error {
+	options := &cfg.Spec.Kubelet
+	ksc.CPUManagerPolicy = string(options.CPUManagerPolicy)
+	ksc.TopologyManagerPolicy = string(options.TopologyManagerPolicy)
+	ksc.MemoryManagerPolicy = string(options.MemoryManagerPolicy)
+	ksc.ReservedSystemCPUs = options.ReservedCPUs
+	reserveCPUs := options.CPUManagerPolicy == api.CPUManagerPolicyStatic && options.ReservedCPUs == ""
+	reserveMemory := options.MemoryManagerPolicy == api.MemoryManagerPolicyStatic
+	if !reserveCPUs && !reserveMemory {
+		return nil
+	}
+	nodes, err := getNUMANodes()
+	if err != nil {
+		return err
+	}
+	if reserveCPUs {
+		if cpus := getReservedCPUs(nodes, ksc.KubeReserved["cpu"]); cpus.Size() > 0 {
+			zap.L().Info("Reserving CPUs for system daemons and kubelet..", zap.String("cpus", cpus.String()))
+			ksc.ReservedSystemCPUs = cpus.String()
+		}
+	}
+	if reserveMemory {
+		memory, err := ksc.getReservedMemory(cfg)
+		if err != nil {
+			return err
+		}
+		numaNode := 0
+		if len(nodes) > 0 {
+			numaNode = nodes[0].ID
+		}
+		ksc.ReservedMemory = []k8skubelet.MemoryReservation{{
+			// #nosec G115 // NUMA node IDs are small
+			NumaNode: int32(numaNode),
+			Limits:   v1.ResourceList{v1.ResourceMemory: memory},
+		}}
+	}
+	return nil
+}
+
+// getReservedCPUs returns the whole cores of the first NUMA node that cover
+// the reserved CPU, of at least one CPU, which the static CPU manager policy
+// requires. The hyperthreads of a core are reserved together, so that they
+// are not shared with the exclusive CPUs of pods. No CPUs are returned when the
+// topology is not available, in which case kubelet reserves CPUs by itself.
+func getReservedCPUs(nodes []system.NUMANode, reservedCPU string) cpuset.CPUSet {
+	count := int64(1)
+	if quantity, err := resource.ParseQuantity(reservedCPU); err == nil {
+		count = max(count, (quantity.MilliValue()+999)/1000)
+	}
+	var cpus []int
+	if len(nodes) > 0 {
+		for _, core := range nodes[0].Cores {
+			if int64(len(cpus)) >= count {
+				break
+			}
+			cpus = append(cpus, core...)
+		}
 	}
+	return cpuset.New(cpus...)
+}
+
+// getReservedMemory returns the memory that the static memory manager policy
+// requires to be reserved on NUMA nodes, which is the memory reserved for
+// kubelet and system daemons and the threshold of hard eviction, as they are
+// once the config of the NodeConfig is merged over that of nodeadm.
+func (ksc *kubeletConfig) getReservedMemory(cfg *api.NodeConfig) (resource.Quantity, error) {
+	userConfig, err := getUserConfigMap(cfg.Spec.Kubelet.Config)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	var reserved resource.Quantity
+	for _, value := range []string{
+		getUserConfigValue(userConfig, "kubeReserved", "memory", ksc.KubeReserved["memory"]),
+		getUserConfigValue(userConfig, "systemReserved", "memory", ksc.SystemReserved["memory"]),
+		getUserConfigValue(userConfig, "evictionHard", "memory.available", ksc.EvictionHard["memory.available"]),
+	} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("memory manager policy %q requires an absolute amount of reserved memory, found %q: %w", api.MemoryManagerPolicyStatic, value, err)
+		}
+		reserved.Add(quantity)
+	}
+	return reserved, nil
+}
+
+// getUserConfigValue returns the value of a key of a map field of the user's
+// kubelet config, which overrides the value of nodeadm when it is set.
+func getUserConfigValue(userConfig map[string]any, field string, key string, value string) string {
+	if values, ok := userConfig[field].(map[string]any); ok {
+		if userValue, ok := values[key].(string); ok {
+			return userValue
+		}
+	}
+	return value
+}
+
+// addKubeReservedMemory adds to the memory that is reserved from pods.
+func (ksc *kubeletConfig) addKubeReservedMemory(quantity resource.Quantity) {
+	reserved := quantity.DeepCopy()
+	if memory, err := resource.ParseQuantity(ksc.KubeReserved["memory"]); err == nil {
+		reserved.Add(memory)
+	}
+	if ksc.KubeReserved == nil {
+		ksc.KubeReserved = map[string]string{}
+	}
+	// rounded up to whole mebibytes, which is the unit of the default reservation
+	ksc.KubeReserved["memory"] = fmt.Sprintf("%dMi", (reserved.Value()+mebibyte-1)/mebibyte)
+}
+
+// getContainerLogMaxSize divides the tmpfs between the log files of a full
+// node, assuming one container per pod.
+func getContainerLogMaxSize(limitBytes int64, maxPods int32, maxFiles int32) string {
+	fileBytes := int64(podLogsMaxFileBytes)
+	if maxPods > 0 {
+		fileBytes = limitBytes / (int64(maxPods) * int64(maxFiles))
+	}
+	// the files of a single container must still fit in a tmpfs that is
+	// smaller than they would be at the floor
+	floorBytes := min(int64(podLogsMinFileBytes), limitBytes/int64(maxFiles))
+	fileBytes = max(floorBytes, min(fileBytes, podLogsMaxFileBytes))
+	return fmt.Sprintf("%dKi", max(fileBytes/1024, 1))
+}
+
+// withNodeLabelsAndTaints registers the node with the labels and taints of the
+// config, after expanding their values with the details of the instance. Labels
+// given with `--node-labels` in the user's flags are merged with these, since
+// every instance of the flag is applied.
+func (ksc *kubeletConfig) withNodeLabelsAndTaints(cfg *api.NodeConfig, flags map[string]string) error {
+	labels, err := cfg.GetNodeLabels()
+	if err != nil {
+		return err
+	}
+	if len(labels) > 0 {
+		var pairs []string
+		for key, value := range labels {
+			pairs = append(pairs, key+"="+value)
+		}
+		sort.Strings(pairs)
+		flags["node-labels"] = strings.Join(pairs, ",")
+	}
+	taints, err := cfg.GetNodeTaints()
+	if err != nil {
+		return err
+	}
+	for _, taint := range taints {
+		ksc.RegisterWithTaints = append(ksc.RegisterWithTaints, v1.Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: v1.TaintEffect(taint.Effect),
+		})
+	}
+	return nil
 }
 
 // withPodInfraContainerImage determines whether to add the
@@ -291,16 +735,16 @@ func (ksc *kubeletConfig) withPodInfraContainerImage(cfg *api.NodeConfig, flags
 	return nil
 }
 
-func (k *kubelet) GenerateKubeletConfig(cfg *api.NodeConfig) (*kubeletConfig, error) {
+func (k *kubelet) GenerateKubeletConfig(ctx context.Context, cfg *api.NodeConfig) (*kubeletConfig, error) {
 	kubeletConfig := defaultKubeletSubConfig()
 
-	if err := kubeletConfig.withFallbackClusterDns(&cfg.Spec.Cluster); err != nil {
+	if err := kubeletConfig.withClusterDns(cfg); err != nil {
 		return nil, err
 	}
 	if err := kubeletConfig.withOutpostSetup(cfg); err != nil {
 		return nil, err
 	}
-	if err := kubeletConfig.withNodeIp(cfg, k.flags); err != nil {
+	if err := kubeletConfig.withNodeIp(ctx, cfg, k.flags); err != nil {
 		return nil, err
 	}
 	if err := kubeletConfig.withPodInfraContainerImage(cfg, k.flags); err != nil {
@@ -309,85 +753,140 @@ func (k *kubelet) GenerateKubeletConfig(cfg *api.NodeConfig) (*kubeletConfig, er
 
 	kubeletConfig.withVersionToggles(cfg, k.flags)
 	kubeletConfig.withCloudProvider(cfg, k.flags)
-	kubeletConfig.withDefaultReservedResources(cfg)
+	kubeletConfig.withClusterDomain(cfg)
+	if err := checkOfflineMaxPods(cfg); err != nil {
+		return nil, err
+	}
+	kubeletConfig.withDefaultReservedResources(ctx, cfg)
+	kubeletConfig.withCgroupDriver(cfg)
+	if err := kubeletConfig.withSwap(cfg); err != nil {
+		return nil, err
+	}
+	if err := kubeletConfig.withUserNamespaces(cfg); err != nil {
+		return nil, err
+	}
+	if err := kubeletConfig.withImageRetention(cfg); err != nil {
+		return nil, err
+	}
+	kubeletConfig.withPodLogs(cfg)
+	if err := kubeletConfig.withEFA(cfg); err != nil {
+		return nil, err
+	}
+	if err := kubeletConfig.withNeuron(cfg); err != nil {
+		return nil, err
+	}
+	// applied once the reserved memory is final, since the memory manager
+	// reserves it on a NUMA node
+	if err := kubeletConfig.withResourceManagers(cfg); err != nil {
+		return nil, err
+	}
+	kubeletConfig.withShutdown(cfg)
+	kubeletConfig.withStaticPods(cfg)
+	kubeletConfig.withSeccompDefault(cfg)
+	kubeletConfig.withHardening(cfg)
+	if err := kubeletConfig.withNodeLabelsAndTaints(cfg, k.flags); err != nil {
+		return nil, err
+	}
+
+	// applied after the version toggles so that user-provided feature gates
+	// take precedence over the defaults
+	if err := kubeletConfig.withFeatureGates(cfg); err != nil {
+		return nil, err
+	}
 
 	return &kubeletConfig, nil
 }
 
+// GenerateConfig returns the config of kubelet for an enriched NodeConfig,
+// with the config of the NodeConfig merged over the config of nodeadm, as it
+// is read by kubelet.
+func GenerateConfig(ctx context.Context, cfg *api.NodeConfig) ([]byte, error) {
+	k := &kubelet{
+		environment: make(map[string]string),
+		flags:       make(map[string]string),
+	}
+	kubeletConfig, err := k.GenerateKubeletConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return generateMergedKubeletConfig(cfg, kubeletConfig)
+}
+
+func generateMergedKubeletConfig(cfg *api.NodeConfig, kubeletConfig *kubeletConfig) ([]byte, error) {
+	if len(cfg.Spec.Kubelet.Config) == 0 {
+		return json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4))
+	}
+	userKubeletConfig, err := getUserKubeletConfig(&cfg.Spec.Kubelet)
+	if err != nil {
+		return nil, err
+	}
+	mergedMap, err := util.Merge(kubeletConfig, userKubeletConfig, json.Marshal, json.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(mergedMap, "", strings.Repeat(" ", 4))
+}
+
 // WriteConfig writes the kubelet config to a file.
 // This should only be used for kubelet versions < 1.28.
-func (k *kubelet) writeKubeletConfigToFile(cfg *api.NodeConfig) error {
-	kubeletConfig, err := k.GenerateKubeletConfig(cfg)
+func (k *kubelet) writeKubeletConfigToFile(cfg *api.NodeConfig, kubeletConfig *kubeletConfig) error {
+	kubeletConfigBytes, err := generateMergedKubeletConfig(cfg, kubeletConfig)
 	if err != nil {
 		return err
 	}
 
-	var kubeletConfigBytes []byte
-	if cfg.Spec.Kubelet.Config != nil && len(cfg.Spec.Kubelet.Config) > 0 {
-		mergedMap, err := util.Merge(kubeletConfig, cfg.Spec.Kubelet.Config, json.Marshal, json.Unmarshal)
-		if err != nil {
-			return err
-		}
-		if kubeletConfigBytes, err = json.MarshalIndent(mergedMap, "", strings.Repeat(" ", 4)); err != nil {
-			return err
-		}
-	} else {
-		var err error
-		if kubeletConfigBytes, err = json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4)); err != nil {
-			return err
-		}
-	}
-
-	configPath := path.Join(kubeletConfigRoot, kubeletConfigFile)
+	configPath := filepath.Join(kubeletConfigRoot, kubeletConfigFile)
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file..", zap.String("path", configPath))
-	return util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm)
+	return writeKubeletFile(cfg, configPath, kubeletConfigBytes, kubeletConfigPerm)
 }
 
 // WriteKubeletConfigToDir writes nodeadm's generated kubelet config to the
 // standard config file and writes the user's provided config to a directory for
 // drop-in support. This is only supported on kubelet versions >= 1.28. see:
 // https://kubernetes.io/docs/tasks/administer-cluster/kubelet-config-file/#kubelet-conf-d
-func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig) error {
-	kubeletConfig, err := k.GenerateKubeletConfig(cfg)
-	if err != nil {
-		return err
-	}
+func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig, kubeletConfig *kubeletConfig) error {
 	kubeletConfigBytes, err := json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4))
 	if err != nil {
 		return err
 	}
 
-	configPath := path.Join(kubeletConfigRoot, kubeletConfigFile)
+	configPath := filepath.Join(kubeletConfigRoot, kubeletConfigFile)
 	k.flags["config"] = configPath
 
 	zap.L().Info("Writing kubelet config to file..", zap.String("path", configPath))
-	if err := util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
+	if err := writeKubeletFile(cfg, configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
 		return err
 	}
 
-	if cfg.Spec.Kubelet.Config != nil && len(cfg.Spec.Kubelet.Config) > 0 {
-		dirPath := path.Join(kubeletConfigRoot, kubeletConfigDir)
+	hasUserConfig := cfg.Spec.Kubelet.Config != nil && len(cfg.Spec.Kubelet.Config) > 0
+	hasRemoteConfig := cfg.Spec.Kubelet.RemoteConfig.Source != ""
+	if hasUserConfig || hasRemoteConfig {
+		dirPath := filepath.Join(kubeletConfigRoot, kubeletConfigDir)
 		k.flags["config-dir"] = dirPath
 
 		zap.L().Info("Enabling kubelet config drop-in dir..")
 		k.environment["KUBELET_CONFIG_DROPIN_DIR_ALPHA"] = "on"
-		filePath := path.Join(dirPath, "40-nodeadm.conf")
-
-		// merge in default type metadata like kind and apiVersion in case the
-		// user has not specified this, as it is required to qualify a drop-in
-		// config as a valid KubeletConfiguration
-		userKubeletConfigMap, err := util.Merge(defaultKubeletSubConfig().TypeMeta, cfg.Spec.Kubelet.Config, json.Marshal, json.Unmarshal)
+	}
+	if hasUserConfig {
+		filePath := filepath.Join(kubeletConfigRoot, kubeletConfigDir, "40-nodeadm.conf")
+		userKubeletConfig, err := getUserKubeletConfig(&cfg.Spec.Kubelet)
 		if err != nil {
 			return err
 		}
-		userKubeletConfigBytes, err := json.MarshalIndent(userKubeletConfigMap, "", strings.Repeat(" ", 4))
+		userKubeletConfigBytes, err := GenerateDropIn(userKubeletConfig)
 		if err != nil {
 			return err
 		}
 		zap.L().Info("Writing user kubelet config to drop-in file..", zap.String("path", filePath))
-		if err := util.WriteFileWithDir(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
+		if err := writeKubeletFile(cfg, filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
+			return err
+		}
+	}
+	if !hasRemoteConfig {
+		// the overlay of a remote config that was disabled must not outlive it
+		if err := os.Remove(RemoteConfigDropInPath); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return err
 		}
 	}
@@ -395,16 +894,62 @@ func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig) error {
 	return nil
 }
 
+// GenerateDropIn returns a drop-in of the kubelet config. The default type
+// metadata, like kind and apiVersion, is merged in case the config does not
+// specify it, as it is required to qualify a drop-in config as a valid
+// KubeletConfiguration.
+func GenerateDropIn(config map[string]any) ([]byte, error) {
+	configMap, err := util.Merge(defaultKubeletSubConfig().TypeMeta, config, json.Marshal, json.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(configMap, "", strings.Repeat(" ", 4))
+}
+
 func getProviderId(availabilityZone, instanceId string) string {
 	return fmt.Sprintf("aws:///%s/%s", availabilityZone, instanceId)
 }
 
-// Get the IP of the node depending on the ipFamily configured for the cluster
+// Get the IPs of the node for the IP families of the cluster, primary first,
+// so that the node of a dual-stack cluster is registered with both. The
+// policy of kubelet.nodeIP selects the interface and the order of the families.
 func getNodeIp(ctx context.Context, cfg *api.NodeConfig) (string, error) {
-	ipFamily, err := api.GetCIDRIpFamily(cfg.Spec.Cluster.CIDR)
+	ipFamilies, err := cfg.Spec.Cluster.GetIPFamilies()
 	if err != nil {
 		return "", err
 	}
+	switch nodeIP := cfg.Spec.Kubelet.NodeIP; nodeIP.Policy {
+	case api.NodeIPPolicyExplicit:
+		return strings.Join(nodeIP.Addresses, ","), nil
+	case api.NodeIPPolicySecondaryENI:
+		nodeIps, err := getSecondaryInterfaceIps(ctx, cfg.Status.Instance.MAC, ipFamilies, cfg.Spec.Networking.ExcludeInterfaces)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(nodeIps, ","), nil
+	case api.NodeIPPolicyIPv6:
+		// the family of the first address is the primary family of the node
+		dualStack := len(ipFamilies) > 1
+		ipFamilies = []api.IPFamily{api.IPFamilyIPv6}
+		if dualStack {
+			ipFamilies = append(ipFamilies, api.IPFamilyIPv4)
+		}
+	}
+	if err := checkPrimaryInterface(cfg.Status.Instance.MAC, cfg.Spec.Networking.ExcludeInterfaces); err != nil {
+		return "", err
+	}
+	var nodeIps []string
+	for _, ipFamily := range ipFamilies {
+		nodeIp, err := getInstanceIp(ctx, cfg, ipFamily)
+		if err != nil {
+			return "", err
+		}
+		nodeIps = append(nodeIps, nodeIp)
+	}
+	return strings.Join(nodeIps, ","), nil
+}
+
+func getInstanceIp(ctx context.Context, cfg *api.NodeConfig, ipFamily api.IPFamily) (string, error) {
 	switch ipFamily {
 	case api.IPFamilyIPv4:
 		ipv4, err := imds.GetProperty(ctx, "local-ipv4")
@@ -413,47 +958,50 @@ func getNodeIp(ctx context.Context, cfg *api.NodeConfig) (string, error) {
 		}
 		return ipv4, nil
 	case api.IPFamilyIPv6:
-		ipv6, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/ipv6s", cfg.Status.Instance.MAC)))
+		ipv6s, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/ipv6s", cfg.Status.Instance.MAC)))
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("failed to get IPv6 address of instance, which is required by the cluster's service CIDR %s: %w", cfg.Spec.Cluster.CIDR, err)
 		}
-		return ipv6, nil
+		return getPrimaryIpv6(ipv6s)
 	default:
 		return "", fmt.Errorf("invalid ip-family. %s is not one of %v", ipFamily, []api.IPFamily{api.IPFamilyIPv4, api.IPFamilyIPv6})
 	}
 }
 
-func getCPUMillicoresToReserve() int {
-	totalCPUMillicores, err := system.GetMilliNumCores()
-	if err != nil {
-		zap.L().Error("Error found when GetMilliNumCores", zap.Error(err))
-		return 0
+// getPrimaryIpv6 returns the first of the IPv6 addresses of an interface, which
+// are listed one per line when prefixes or several addresses are assigned.
+func getPrimaryIpv6(ipv6s string) (string, error) {
+	addresses := strings.Fields(ipv6s)
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("instance has no IPv6 address, which is required by IPv6 clusters")
 	}
-	cpuRanges := []int{0, 1000, 2000, 4000, totalCPUMillicores}
-	cpuPercentageReservedForRanges := []int{600, 100, 50, 25}
-	cpuToReserve := 0
-
-	for i, percentageToReserveForRange := range cpuPercentageReservedForRanges {
-		startRange := cpuRanges[i]
-		endRange := cpuRanges[i+1]
-		cpuToReserve += getResourceToReserveInRange(totalCPUMillicores, startRange, endRange, percentageToReserveForRange)
-	}
-
-	return cpuToReserve
+	return addresses[0], nil
 }
 
-// getResourceToReserveInRange calculates the CPU resources to reserve for a given range.
-func getResourceToReserveInRange(totalCPU, startRange, endRange, percentage int) int {
-	if totalCPU <= startRange {
-		return 0
+// checkOfflineMaxPods returns an error in offline mode, where the instance type
+// cannot be described, when its limit of pods is not in the table and maxPods
+// is not set in kubelet.config.
+func checkOfflineMaxPods(cfg *api.NodeConfig) error {
+	if !cfg.Spec.Offline {
+		return nil
 	}
-	reserved := totalCPU
-	if reserved > endRange {
-		reserved = endRange
+	if _, ok := MaxPodsPerInstanceType[cfg.Status.Instance.Type]; ok {
+		return nil
 	}
-	return (reserved - startRange) * percentage / 10000
+	if _, ok := cfg.Spec.Kubelet.Config["maxPods"]; ok {
+		return nil
+	}
+	return fmt.Errorf("maxPods must be set in kubelet.config in offline mode, since the limit of pods of instance type %q is not known", cfg.Status.Instance.Type)
 }
 
-func getMemoryMebibytesToReserve(maxPods int32) int32 {
-	return 11*maxPods + 255
+// getUserMaxPods returns the maxPods of kubelet.config, or the default of
+// kubelet if it is not set.
+func getUserMaxPods(cfg *api.NodeConfig) int32 {
+	var maxPods int32
+	if raw, ok := cfg.Spec.Kubelet.Config["maxPods"]; ok {
+		if err := json.Unmarshal(raw.Raw, &maxPods); err == nil && maxPods > 0 {
+			return maxPods
+		}
+	}
+	return defaultMaxPods
 }
@@ -1,12 +1,24 @@
 package kubelet
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8skubelet "k8s.io/kubelet/config/v1beta1"
 )
 
 func TestKubeletCredentialProvidersFeatureFlag(t *testing.T) {
@@ -132,3 +144,868 @@ func TestProviderID(t *testing.T) {
 		}
 	}
 }
+
+func TestHybridNode(t *testing.T) {
+	var tests = []struct {
+		nodeIP         string
+		expectedNodeIP *string
+	}{
+		{nodeIP: "", expectedNodeIP: nil},
+		{nodeIP: "10.0.0.1", expectedNodeIP: ptr.String("10.0.0.1")},
+	}
+
+	for _, test := range tests {
+		kubeletAruments := make(map[string]string)
+		kubetConfig := defaultKubeletSubConfig()
+		nodeConfig := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				NodeProvider: api.NodeProviderHybrid,
+				Hybrid: api.HybridOptions{
+					NodeName: "my-node",
+					NodeIP:   test.nodeIP,
+				},
+			},
+			Status: api.NodeConfigStatus{
+				KubeletVersion: "v1.30.0",
+			},
+		}
+		assert.NoError(t, kubetConfig.withNodeIp(context.Background(), &nodeConfig, kubeletAruments))
+		kubetConfig.withCloudProvider(&nodeConfig, kubeletAruments)
+		kubetConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+		nodeIP, present := kubeletAruments["node-ip"]
+		if test.expectedNodeIP == nil {
+			assert.False(t, present)
+		} else {
+			assert.Equal(t, *test.expectedNodeIP, nodeIP)
+		}
+		assert.Equal(t, "my-node", kubeletAruments["hostname-override"])
+		assert.NotContains(t, kubeletAruments, "cloud-provider")
+		assert.Nil(t, kubetConfig.ProviderID)
+		assert.Equal(t, int32(defaultMaxPods), kubetConfig.MaxPods)
+	}
+}
+
+func TestFeatureGates(t *testing.T) {
+	var tests = []struct {
+		kubeletVersion string
+		featureGates   map[string]bool
+		expectErr      bool
+	}{
+		{kubeletVersion: "v1.27.0", featureGates: map[string]bool{"KubeletCredentialProviders": true}},
+		{kubeletVersion: "v1.28.0", featureGates: map[string]bool{"KubeletCredentialProviders": true}, expectErr: true},
+		{kubeletVersion: "v1.30.4", featureGates: map[string]bool{"SeccompDefault": true, "DynamicResourceAllocation": true}, expectErr: true},
+		{kubeletVersion: "v1.30.4", featureGates: map[string]bool{"RotateKubeletServerCertificate": false, "DynamicResourceAllocation": true}},
+	}
+
+	for _, test := range tests {
+		kubetConfig := defaultKubeletSubConfig()
+		nodeConfig := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Kubelet: api.KubeletOptions{FeatureGates: test.featureGates},
+			},
+			Status: api.NodeConfigStatus{
+				KubeletVersion: test.kubeletVersion,
+			},
+		}
+		err := kubetConfig.withFeatureGates(&nodeConfig)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		for gate, enabled := range test.featureGates {
+			assert.Equal(t, enabled, kubetConfig.FeatureGates[gate])
+		}
+	}
+}
+
+func TestGetRunningFeatureGates(t *testing.T) {
+	procRoot = t.TempDir()
+	t.Cleanup(func() { procRoot = "/proc" })
+	configRoot := t.TempDir()
+	configPath := filepath.Join(configRoot, "config.json")
+	configDir := filepath.Join(configRoot, "config.json.d")
+	assert.NoError(t, os.MkdirAll(configDir, 0755))
+	assert.NoError(t, os.WriteFile(configPath, []byte(`{"featureGates":{"RotateKubeletServerCertificate":true,"UserNamespacesSupport":false}}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(configDir, "40-nodeadm.conf"), []byte(`{"featureGates":{"UserNamespacesSupport":true}}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(configDir, "50-user.conf"), []byte("featureGates:\n  InPlacePodVerticalScaling: true\n"), 0644))
+
+	_, err := GetRunningFeatureGates()
+	assert.Error(t, err)
+
+	for pid, process := range map[string][]string{
+		"1":   {"systemd", "/usr/lib/systemd/systemd"},
+		"123": {"kubelet", "/usr/bin/kubelet", "--config", configPath, "--config-dir=" + configDir, "--feature-gates=InPlacePodVerticalScaling=false,DRAResourceHealthStatus=true", "--hostname-override=i-1234567890abcdef0"},
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(procRoot, pid), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(procRoot, pid, "comm"), []byte(process[0]+"\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(procRoot, pid, "cmdline"), []byte(strings.Join(process[1:], "\x00")+"\x00"), 0644))
+	}
+	featureGates, err := GetRunningFeatureGates()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"DRAResourceHealthStatus":        true,
+		"InPlacePodVerticalScaling":      false,
+		"RotateKubeletServerCertificate": true,
+		"UserNamespacesSupport":          true,
+	}, featureGates)
+
+	nodeName, err := GetRunningNodeName()
+	assert.NoError(t, err)
+	assert.Equal(t, "i-1234567890abcdef0", nodeName)
+}
+
+func TestPodLogs(t *testing.T) {
+	limit := resource.MustParse("64Mi")
+	smallLimit := resource.MustParse("16Mi")
+	tinyLimit := resource.MustParse("1Mi")
+	fileSize := resource.MustParse("50Mi")
+	var tests = []struct {
+		name             string
+		podLogs          api.PodLogsOptions
+		expectedMemory   string
+		expectedMaxSize  string
+		expectedMaxFiles *int32
+	}{
+		{
+			name:           "disk",
+			expectedMemory: "574Mi",
+		},
+		{
+			name:             "memory",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory},
+			expectedMemory:   "1086Mi",
+			expectedMaxSize:  "9039Ki",
+			expectedMaxFiles: ptr.Int32(2),
+		},
+		{
+			name:             "memory with limit",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory, MemoryLimit: &limit},
+			expectedMemory:   "638Mi",
+			expectedMaxSize:  "1129Ki",
+			expectedMaxFiles: ptr.Int32(2),
+		},
+		{
+			name:             "disk with rotation",
+			podLogs:          api.PodLogsOptions{MaxFileSize: &fileSize, MaxFiles: ptr.Int32(3)},
+			expectedMemory:   "574Mi",
+			expectedMaxSize:  "50Mi",
+			expectedMaxFiles: ptr.Int32(3),
+		},
+		{
+			name:             "memory with max files",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory, MaxFiles: ptr.Int32(4)},
+			expectedMemory:   "1086Mi",
+			expectedMaxSize:  "4519Ki",
+			expectedMaxFiles: ptr.Int32(4),
+		},
+		{
+			name:             "memory with max file size",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory, MaxFileSize: &fileSize},
+			expectedMemory:   "1086Mi",
+			expectedMaxSize:  "50Mi",
+			expectedMaxFiles: ptr.Int32(2),
+		},
+		{
+			name:             "memory with small limit",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory, MemoryLimit: &smallLimit},
+			expectedMemory:   "590Mi",
+			expectedMaxSize:  "1024Ki",
+			expectedMaxFiles: ptr.Int32(2),
+		},
+		{
+			name:             "memory with limit below the floor",
+			podLogs:          api.PodLogsOptions{Storage: api.PodLogsStorageMemory, MemoryLimit: &tinyLimit},
+			expectedMemory:   "575Mi",
+			expectedMaxSize:  "512Ki",
+			expectedMaxFiles: ptr.Int32(2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Instance: api.InstanceOptions{PodLogs: test.podLogs},
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: "m5.large"},
+				},
+			}
+			kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+			kubeletConfig.withPodLogs(&nodeConfig)
+			assert.Equal(t, test.expectedMemory, kubeletConfig.KubeReserved["memory"])
+			assert.Equal(t, test.expectedMaxSize, kubeletConfig.ContainerLogMaxSize)
+			assert.Equal(t, test.expectedMaxFiles, kubeletConfig.ContainerLogMaxFiles)
+		})
+	}
+}
+
+func TestEFA(t *testing.T) {
+	reserved := resource.MustParse("512Mi")
+	var tests = []struct {
+		name           string
+		efa            api.EFAOptions
+		present        bool
+		expectedMemory string
+	}{
+		{name: "disabled", efa: api.EFAOptions{ReservedMemory: &reserved}, present: true, expectedMemory: "574Mi"},
+		{name: "without reserved memory", efa: api.EFAOptions{Enabled: true}, present: true, expectedMemory: "574Mi"},
+		{name: "reserved memory", efa: api.EFAOptions{Enabled: true, ReservedMemory: &reserved}, present: true, expectedMemory: "1086Mi"},
+		{name: "no EFA interfaces", efa: api.EFAOptions{Enabled: true, ReservedMemory: &reserved}, expectedMemory: "574Mi"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hasEFAInterfaces = func() (bool, error) { return test.present, nil }
+			t.Cleanup(func() { hasEFAInterfaces = system.HasEFAInterfaces })
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Instance: api.InstanceOptions{EFA: test.efa},
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: "m5.large"},
+				},
+			}
+			kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+			assert.NoError(t, kubeletConfig.withEFA(&nodeConfig))
+			assert.Equal(t, test.expectedMemory, kubeletConfig.KubeReserved["memory"])
+		})
+	}
+}
+
+func TestNeuron(t *testing.T) {
+	reserved := resource.MustParse("1Gi")
+	var tests = []struct {
+		name           string
+		neuron         api.NeuronOptions
+		devices        int
+		expectedMemory string
+	}{
+		{name: "disabled", neuron: api.NeuronOptions{ReservedMemory: &reserved}, devices: 2, expectedMemory: "574Mi"},
+		{name: "without reserved memory", neuron: api.NeuronOptions{Enabled: true}, devices: 2, expectedMemory: "574Mi"},
+		{name: "reserved memory", neuron: api.NeuronOptions{Enabled: true, ReservedMemory: &reserved}, devices: 2, expectedMemory: "1598Mi"},
+		{name: "no Neuron devices", neuron: api.NeuronOptions{Enabled: true, ReservedMemory: &reserved}, expectedMemory: "574Mi"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countNeuronDevices = func() (int, error) { return test.devices, nil }
+			t.Cleanup(func() { countNeuronDevices = system.CountNeuronDevices })
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Instance: api.InstanceOptions{Neuron: test.neuron},
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: "m5.large"},
+				},
+			}
+			kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+			assert.NoError(t, kubeletConfig.withNeuron(&nodeConfig))
+			assert.Equal(t, test.expectedMemory, kubeletConfig.KubeReserved["memory"])
+		})
+	}
+}
+
+func TestResourceManagers(t *testing.T) {
+	// two NUMA nodes of two cores with two hyperthreads each
+	nodes := []system.NUMANode{
+		{ID: 0, Cores: [][]int{{0, 4}, {1, 5}}},
+		{ID: 1, Cores: [][]int{{2, 6}, {3, 7}}},
+	}
+	var tests = []struct {
+		name                   string
+		kubelet                api.KubeletOptions
+		nodes                  []system.NUMANode
+		expectedReservedCPUs   string
+		expectedReservedMemory []k8skubelet.MemoryReservation
+	}{
+		{name: "defaults", nodes: nodes},
+		{
+			name:                 "static cpu manager",
+			kubelet:              api.KubeletOptions{CPUManagerPolicy: api.CPUManagerPolicyStatic},
+			nodes:                nodes,
+			expectedReservedCPUs: "0,4",
+		},
+		{
+			name:                 "reserved cpus",
+			kubelet:              api.KubeletOptions{CPUManagerPolicy: api.CPUManagerPolicyStatic, ReservedCPUs: "3,7"},
+			nodes:                nodes,
+			expectedReservedCPUs: "3,7",
+		},
+		{
+			name:    "static cpu manager without topology",
+			kubelet: api.KubeletOptions{CPUManagerPolicy: api.CPUManagerPolicyStatic},
+		},
+		{
+			name:    "static memory manager",
+			kubelet: api.KubeletOptions{MemoryManagerPolicy: api.MemoryManagerPolicyStatic},
+			nodes:   nodes,
+			expectedReservedMemory: []k8skubelet.MemoryReservation{
+				{NumaNode: 0, Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("674Mi")}},
+			},
+		},
+		{
+			name: "static memory manager with system reserved memory",
+			kubelet: api.KubeletOptions{
+				MemoryManagerPolicy: api.MemoryManagerPolicyStatic,
+				Config:              api.InlineDocument{"systemReserved": runtime.RawExtension{Raw: []byte(`{"memory": "1Gi"}`)}},
+			},
+			nodes: nodes,
+			expectedReservedMemory: []k8skubelet.MemoryReservation{
+				{NumaNode: 0, Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1698Mi")}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getNUMANodes = func() ([]system.NUMANode, error) { return test.nodes, nil }
+			t.Cleanup(func() { getNUMANodes = system.GetNUMANodes })
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: test.kubelet,
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: "m5.large"},
+				},
+			}
+			kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+			assert.NoError(t, kubeletConfig.withResourceManagers(&nodeConfig))
+			assert.Equal(t, string(test.kubelet.CPUManagerPolicy), kubeletConfig.CPUManagerPolicy)
+			assert.Equal(t, string(test.kubelet.MemoryManagerPolicy), kubeletConfig.MemoryManagerPolicy)
+			assert.Equal(t, test.expectedReservedCPUs, kubeletConfig.ReservedSystemCPUs)
+			assert.Equal(t, len(test.expectedReservedMemory), len(kubeletConfig.ReservedMemory))
+			for i, reservation := range test.expectedReservedMemory {
+				assert.Equal(t, reservation.NumaNode, kubeletConfig.ReservedMemory[i].NumaNode)
+				assert.True(t, reservation.Limits.Memory().Equal(*kubeletConfig.ReservedMemory[i].Limits.Memory()))
+			}
+		})
+	}
+}
+
+func TestGetReservedCPUs(t *testing.T) {
+	nodes := []system.NUMANode{
+		{ID: 0, Cores: [][]int{{0, 4}, {1, 5}, {2, 6}}},
+		{ID: 1, Cores: [][]int{{3, 7}}},
+	}
+	assert.Equal(t, "0,4", getReservedCPUs(nodes, "80m").String())
+	assert.Equal(t, "0,4", getReservedCPUs(nodes, "2").String())
+	assert.Equal(t, "0-1,4-5", getReservedCPUs(nodes, "2500m").String())
+	// at least one CPU is reserved, which the static policy requires
+	assert.Equal(t, "0,4", getReservedCPUs(nodes, "").String())
+	assert.Equal(t, "", getReservedCPUs(nil, "80m").String())
+}
+
+func TestGetReservedMemoryRequiresAbsoluteThreshold(t *testing.T) {
+	kubeletConfig := defaultKubeletSubConfig()
+	kubeletConfig.EvictionHard["memory.available"] = "5%"
+	_, err := kubeletConfig.getReservedMemory(&api.NodeConfig{})
+	assert.ErrorContains(t, err, "requires an absolute amount of reserved memory")
+}
+
+func TestShutdown(t *testing.T) {
+	var tests = []struct {
+		name                        string
+		shutdown                    api.ShutdownOptions
+		expectedGracePeriod         *metav1.Duration
+		expectedCriticalGracePeriod *metav1.Duration
+	}{
+		{name: "disabled"},
+		{
+			name:                "grace period",
+			shutdown:            api.ShutdownOptions{GracePeriod: &metav1.Duration{Duration: 90 * time.Second}},
+			expectedGracePeriod: &metav1.Duration{Duration: 90 * time.Second},
+		},
+		{
+			name: "critical pods grace period",
+			shutdown: api.ShutdownOptions{
+				GracePeriod:             &metav1.Duration{Duration: 90 * time.Second},
+				CriticalPodsGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			expectedGracePeriod:         &metav1.Duration{Duration: 90 * time.Second},
+			expectedCriticalGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Instance: api.InstanceOptions{Shutdown: test.shutdown},
+				},
+			}
+			kubeletConfig.withShutdown(&nodeConfig)
+			assert.Equal(t, test.expectedGracePeriod, kubeletConfig.ShutdownGracePeriod)
+			assert.Equal(t, test.expectedCriticalGracePeriod, kubeletConfig.ShutdownGracePeriodCriticalPods)
+		})
+	}
+}
+
+func TestCgroupDriver(t *testing.T) {
+	var tests = []struct {
+		driver                       api.CgroupDriver
+		expectedDriver               string
+		expectedSystemReservedCgroup string
+		expectedKubeReservedCgroup   string
+	}{
+		{driver: "", expectedDriver: "systemd", expectedSystemReservedCgroup: "/system", expectedKubeReservedCgroup: "/runtime"},
+		{driver: api.CgroupDriverSystemd, expectedDriver: "systemd", expectedSystemReservedCgroup: "/system", expectedKubeReservedCgroup: "/runtime"},
+		{driver: api.CgroupDriverCgroupfs, expectedDriver: "cgroupfs", expectedSystemReservedCgroup: "/system.slice", expectedKubeReservedCgroup: "/runtime.slice"},
+	}
+
+	for _, test := range tests {
+		kubeletConfig := defaultKubeletSubConfig()
+		nodeConfig := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Instance: api.InstanceOptions{Cgroup: api.CgroupOptions{Driver: test.driver}},
+			},
+			Status: api.NodeConfigStatus{
+				Instance: api.InstanceDetails{Type: "m5.large"},
+			},
+		}
+		kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+		kubeletConfig.withCgroupDriver(&nodeConfig)
+		assert.Equal(t, test.expectedDriver, kubeletConfig.CgroupDriver)
+		assert.Equal(t, test.expectedSystemReservedCgroup, *kubeletConfig.SystemReservedCgroup)
+		assert.Equal(t, test.expectedKubeReservedCgroup, *kubeletConfig.KubeReservedCgroup)
+	}
+}
+
+func TestDefaultReservedResourcesOffline(t *testing.T) {
+	kubeletConfig := defaultKubeletSubConfig()
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				Config: api.InlineDocument{"maxPods": runtime.RawExtension{Raw: []byte("58")}},
+			},
+			Offline: true,
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{Type: "unknown.large"},
+		},
+	}
+	// the instance type is not described, since AWS APIs are not called
+	kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+	assert.Equal(t, int32(58), kubeletConfig.MaxPods)
+	assert.Equal(t, "893Mi", kubeletConfig.KubeReserved["memory"])
+}
+
+func TestCheckOfflineMaxPods(t *testing.T) {
+	var tests = []struct {
+		name         string
+		offline      bool
+		instanceType string
+		config       api.InlineDocument
+		expectErr    bool
+	}{
+		{name: "online", instanceType: "unknown.large"},
+		{name: "instance type in table", offline: true, instanceType: "m5.large"},
+		{name: "maxPods in kubelet config", offline: true, instanceType: "unknown.large", config: api.InlineDocument{"maxPods": runtime.RawExtension{Raw: []byte("58")}}},
+		{name: "unknown instance type", offline: true, instanceType: "unknown.large", expectErr: true},
+		{name: "no instance type", offline: true, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: api.KubeletOptions{Config: test.config},
+					Offline: test.offline,
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: test.instanceType},
+				},
+			}
+			err := checkOfflineMaxPods(&cfg)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	size := resource.MustParse("2Gi")
+	var tests = []struct {
+		name                 string
+		kubeletVersion       string
+		swapBehavior         api.SwapBehavior
+		swap                 api.SwapOptions
+		expectedErr          bool
+		expectedSwapBehavior string
+		expectedNodeSwap     bool
+	}{
+		{name: "no swap", kubeletVersion: "v1.30.0"},
+		{name: "swap file", kubeletVersion: "v1.30.0", swap: api.SwapOptions{Device: api.SwapDeviceFile, Size: &size}, expectedSwapBehavior: "NoSwap", expectedNodeSwap: true},
+		{name: "limited swap", kubeletVersion: "v1.31.0", swapBehavior: api.SwapBehaviorLimitedSwap, swap: api.SwapOptions{Device: api.SwapDeviceZram, Size: &size}, expectedSwapBehavior: "LimitedSwap", expectedNodeSwap: true},
+		{name: "limited swap after GA", kubeletVersion: "v1.34.0", swapBehavior: api.SwapBehaviorLimitedSwap, expectedSwapBehavior: "LimitedSwap"},
+		{name: "unsupported version", kubeletVersion: "v1.29.0", swapBehavior: api.SwapBehaviorLimitedSwap, expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet:  api.KubeletOptions{SwapBehavior: test.swapBehavior},
+					Instance: api.InstanceOptions{Swap: test.swap},
+				},
+				Status: api.NodeConfigStatus{
+					KubeletVersion: test.kubeletVersion,
+				},
+			}
+			err := kubeletConfig.withSwap(&nodeConfig)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expectedSwapBehavior == "" {
+				assert.Nil(t, kubeletConfig.FailSwapOn)
+				assert.Nil(t, kubeletConfig.MemorySwap)
+			} else {
+				assert.Equal(t, ptr.Bool(false), kubeletConfig.FailSwapOn)
+				assert.Equal(t, test.expectedSwapBehavior, kubeletConfig.MemorySwap.SwapBehavior)
+			}
+			assert.Equal(t, test.expectedNodeSwap, kubeletConfig.FeatureGates["NodeSwap"])
+		})
+	}
+}
+
+func TestUserNamespaces(t *testing.T) {
+	var tests = []struct {
+		name           string
+		kubeletVersion string
+		enabled        bool
+		expectedErr    bool
+		expectedGate   bool
+	}{
+		{name: "disabled", kubeletVersion: "v1.29.0"},
+		{name: "enabled", kubeletVersion: "v1.30.0", enabled: true, expectedGate: true},
+		{name: "unsupported version", kubeletVersion: "v1.29.0", enabled: true, expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Security: api.SecurityOptions{
+						UserNamespaces: api.UserNamespacesOptions{Enabled: test.enabled},
+					},
+				},
+				Status: api.NodeConfigStatus{
+					KubeletVersion: test.kubeletVersion,
+				},
+			}
+			err := kubeletConfig.withUserNamespaces(&nodeConfig)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedGate, kubeletConfig.FeatureGates["UserNamespacesSupport"])
+		})
+	}
+}
+
+func TestNodeIpExplicit(t *testing.T) {
+	flags := make(map[string]string)
+	kubeletConfig := defaultKubeletSubConfig()
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{CIDR: "10.100.0.0/16"},
+			Kubelet: api.KubeletOptions{
+				NodeIP: api.NodeIPOptions{Policy: api.NodeIPPolicyExplicit, Addresses: []string{"2600:1f14::10", "10.0.1.10"}},
+			},
+		},
+	}
+	assert.NoError(t, kubeletConfig.withNodeIp(context.Background(), &nodeConfig, flags))
+	assert.Equal(t, "2600:1f14::10,10.0.1.10", flags["node-ip"])
+}
+
+func TestSelectSecondaryInterface(t *testing.T) {
+	var tests = []struct {
+		name        string
+		interfaces  []networkInterface
+		excludes    []string
+		expectedMAC string
+		expectedErr bool
+	}{
+		{
+			name: "secondary",
+			interfaces: []networkInterface{
+				{MAC: "0e:00:00:00:00:03", DeviceNumber: 2},
+				{MAC: "0e:00:00:00:00:01", DeviceNumber: 0},
+				{MAC: "0e:00:00:00:00:02", DeviceNumber: 1},
+			},
+			expectedMAC: "0e:00:00:00:00:02",
+		},
+		{
+			name:        "primary only",
+			interfaces:  []networkInterface{{MAC: "0e:00:00:00:00:01", DeviceNumber: 0}},
+			expectedErr: true,
+		},
+		{
+			name: "excluded by name",
+			interfaces: []networkInterface{
+				{MAC: "0e:00:00:00:00:01", DeviceNumber: 0, Name: "ens5"},
+				{MAC: "0e:00:00:00:00:02", DeviceNumber: 1, Name: "ens6"},
+				{MAC: "0e:00:00:00:00:03", DeviceNumber: 2, Name: "ens7"},
+			},
+			excludes:    []string{"ens6"},
+			expectedMAC: "0e:00:00:00:00:03",
+		},
+		{
+			name: "excluded by mac",
+			interfaces: []networkInterface{
+				{MAC: "0e:00:00:00:00:01", DeviceNumber: 0},
+				{MAC: "0e:00:00:00:00:02", DeviceNumber: 1},
+				{MAC: "0e:00:00:00:00:03", DeviceNumber: 2},
+			},
+			excludes:    []string{"0e:00:00:00:00:0[23]"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secondary, err := selectSecondaryInterface(test.interfaces, "0e:00:00:00:00:01", test.excludes)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedMAC, secondary.MAC)
+		})
+	}
+}
+
+func TestExcludedInterfacePods(t *testing.T) {
+	interfaces := []networkInterface{
+		{MAC: "0e:00:00:00:00:01", DeviceNumber: 0, Name: "ens5"},
+		{MAC: "0e:00:00:00:00:02", DeviceNumber: 1, Name: "ens6"},
+		{MAC: "0e:00:00:00:00:03", DeviceNumber: 2},
+	}
+	// an m5.large has interfaces of 10 IPv4 addresses, so each excluded
+	// interface takes 9 of its 29 pods
+	assert.Equal(t, int32(0), excludedInterfacePods(interfaces, "0e:00:00:00:00:01", nil, 10))
+	assert.Equal(t, int32(9), excludedInterfacePods(interfaces, "0e:00:00:00:00:01", []string{"ens6"}, 10))
+	assert.Equal(t, int32(18), excludedInterfacePods(interfaces, "0e:00:00:00:00:01", []string{"0e:00:00:00:00:0[23]"}, 10))
+	// the primary interface still hosts pods when it is excluded from the node IP
+	assert.Equal(t, int32(18), excludedInterfacePods(interfaces, "0e:00:00:00:00:01", []string{"ens*", "0e:00:*"}, 10))
+}
+
+func TestDefaultReservedResourcesExcludedInterfaces(t *testing.T) {
+	kubeletConfig := defaultKubeletSubConfig()
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				Config: api.InlineDocument{"maxPods": runtime.RawExtension{Raw: []byte("20")}},
+			},
+			Networking: api.NetworkingOptions{ExcludeInterfaces: []string{"ens6"}},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{Type: "m5.large"},
+		},
+	}
+	// the interfaces are not listed when maxPods is set in kubelet.config
+	kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+	assert.Equal(t, int32(29), kubeletConfig.MaxPods)
+}
+
+func TestCheckPrimaryInterface(t *testing.T) {
+	assert.NoError(t, checkPrimaryInterface("0e:00:00:00:00:01", nil))
+	assert.NoError(t, checkPrimaryInterface("0e:00:00:00:00:01", []string{"0e:00:00:00:00:02"}))
+	assert.ErrorContains(t, checkPrimaryInterface("0e:00:00:00:00:01", []string{"0e:00:*"}), "must be Explicit")
+}
+
+func TestImageRetention(t *testing.T) {
+	var tests = []struct {
+		name           string
+		kubeletVersion string
+		retention      api.ImageRetentionOptions
+		expectedErr    bool
+		expectedConfig kubeletConfig
+	}{
+		{name: "defaults", kubeletVersion: "v1.29.0"},
+		{
+			name:           "thresholds and minimum age",
+			kubeletVersion: "v1.29.0",
+			retention: api.ImageRetentionOptions{
+				HighThresholdPercent: 80,
+				LowThresholdPercent:  60,
+				MinimumAge:           &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			expectedConfig: kubeletConfig{
+				ImageGCHighThresholdPercent: ptr.Int32(80),
+				ImageGCLowThresholdPercent:  ptr.Int32(60),
+				ImageMinimumGCAge:           &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		{
+			name:           "maximum age",
+			kubeletVersion: "v1.30.0",
+			retention:      api.ImageRetentionOptions{MaximumAge: &metav1.Duration{Duration: 168 * time.Hour}},
+			expectedConfig: kubeletConfig{ImageMaximumGCAge: &metav1.Duration{Duration: 168 * time.Hour}},
+		},
+		{
+			name:           "maximum age unsupported version",
+			kubeletVersion: "v1.29.0",
+			retention:      api.ImageRetentionOptions{MaximumAge: &metav1.Duration{Duration: 168 * time.Hour}},
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var kubeletConfig kubeletConfig
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Containerd: api.ContainerdOptions{ImageRetention: test.retention},
+				},
+				Status: api.NodeConfigStatus{
+					KubeletVersion: test.kubeletVersion,
+				},
+			}
+			err := kubeletConfig.withImageRetention(&nodeConfig)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedConfig, kubeletConfig)
+		})
+	}
+}
+
+func TestHardening(t *testing.T) {
+	var tests = []struct {
+		name          string
+		profile       api.HardeningProfile
+		kubeletConfig api.InlineDocument
+		expectedQPS   *int32
+		expectedRSA   bool
+	}{
+		{name: "none", profile: api.HardeningProfileNone, expectedRSA: true},
+		{name: "level 1", profile: api.HardeningProfileCISLevel1, expectedRSA: true},
+		{name: "level 2", profile: api.HardeningProfileCISLevel2, expectedQPS: ptr.Int32(5)},
+		{
+			name:          "level 2 overridden by user",
+			profile:       api.HardeningProfileCISLevel2,
+			kubeletConfig: api.InlineDocument{"eventRecordQPS": runtime.RawExtension{Raw: []byte("50")}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet:  api.KubeletOptions{Config: test.kubeletConfig},
+					Security: api.SecurityOptions{HardeningProfile: test.profile},
+				},
+			}
+			kubeletConfig.withHardening(&nodeConfig)
+			assert.Equal(t, test.expectedQPS, kubeletConfig.EventRecordQPS)
+			assert.Equal(t, test.expectedRSA, slices.Contains(kubeletConfig.TLSCipherSuites, "TLS_RSA_WITH_AES_128_GCM_SHA256"))
+			if test.profile == api.HardeningProfileNone {
+				assert.Nil(t, kubeletConfig.StreamingConnectionIdleTimeout)
+			} else {
+				assert.Equal(t, 4*time.Hour, kubeletConfig.StreamingConnectionIdleTimeout.Duration)
+			}
+		})
+	}
+}
+
+func TestNodeLabelsAndTaints(t *testing.T) {
+	kubeletConfig := defaultKubeletSubConfig()
+	flags := map[string]string{}
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				NodeLabels: map[string]string{
+					"nodegroup":                   "example",
+					"example.com/instance-type":   "{{ .InstanceType }}",
+					"example.com/zone-and-region": "{{ .AvailabilityZone }}.{{ .Region }}",
+				},
+				NodeTaints: []api.Taint{
+					{Key: "example.com/instance", Value: "{{ .InstanceID }}", Effect: api.TaintEffectNoSchedule},
+					{Key: "dedicated", Effect: api.TaintEffectNoExecute},
+				},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{
+				ID:               "i-1234567890abcdef0",
+				Type:             "m5.large",
+				Region:           "us-west-2",
+				AvailabilityZone: "us-west-2a",
+			},
+		},
+	}
+	assert.NoError(t, kubeletConfig.withNodeLabelsAndTaints(&nodeConfig, flags))
+	assert.Equal(t, "example.com/instance-type=m5.large,example.com/zone-and-region=us-west-2a.us-west-2,nodegroup=example", flags["node-labels"])
+	assert.Equal(t, []v1.Taint{
+		{Key: "example.com/instance", Value: "i-1234567890abcdef0", Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Effect: v1.TaintEffectNoExecute},
+	}, kubeletConfig.RegisterWithTaints)
+
+	nodeConfig.Spec.Kubelet.NodeLabels["example.com/unknown"] = "{{ .Unknown }}"
+	assert.Error(t, kubeletConfig.withNodeLabelsAndTaints(&nodeConfig, flags))
+}
+
+func TestNodeAutoLabels(t *testing.T) {
+	kubeletConfig := defaultKubeletSubConfig()
+	flags := map[string]string{}
+	nodeConfig := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				AutoLabels: api.AutoLabelsOptions{Enabled: true},
+				NodeLabels: map[string]string{"eks.amazonaws.com/capacityType": "SPOT"},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{
+				Topology: api.InstanceTopology{
+					AvailabilityZoneID:    "usw2-az1",
+					Lifecycle:             "capacity-block",
+					PlacementGroup:        "training",
+					PlacementPartition:    "2",
+					CapacityReservationID: "cr-1234567890abcdef0",
+				},
+			},
+		},
+	}
+	assert.NoError(t, kubeletConfig.withNodeLabelsAndTaints(&nodeConfig, flags))
+	// node labels take precedence over auto labels
+	assert.Equal(t, "eks.amazonaws.com/capacityType=SPOT,node.eks.aws/capacity-reservation-id=cr-1234567890abcdef0,node.eks.aws/placement-group=training,node.eks.aws/placement-partition=2,topology.k8s.aws/zone-id=usw2-az1", flags["node-labels"])
+
+	flags = map[string]string{}
+	nodeConfig.Spec.Kubelet.NodeLabels = nil
+	nodeConfig.Status.Instance.Topology = api.InstanceTopology{AvailabilityZoneID: "usw2-az1", Lifecycle: "on-demand"}
+	assert.NoError(t, kubeletConfig.withNodeLabelsAndTaints(&nodeConfig, flags))
+	assert.Equal(t, "eks.amazonaws.com/capacityType=ON_DEMAND,topology.k8s.aws/zone-id=usw2-az1", flags["node-labels"])
+
+	flags = map[string]string{}
+	nodeConfig.Spec.Kubelet.AutoLabels.Enabled = false
+	assert.NoError(t, kubeletConfig.withNodeLabelsAndTaints(&nodeConfig, flags))
+	assert.NotContains(t, flags, "node-labels")
+}
+
+func TestGetPrimaryIpv6(t *testing.T) {
+	ipv6, err := getPrimaryIpv6("2600:1f14:abc:100::1\n2600:1f14:abc:100::2\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "2600:1f14:abc:100::1", ipv6)
+
+	_, err = getPrimaryIpv6("")
+	assert.Error(t, err)
+}
@@ -1,13 +1,21 @@
 package kubelet
 
 import (
+	"context"
+
+	"go.uber.org/zap"
+
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
 )
 
 const KubeletDaemonName = "kubelet"
 
-var _ daemon.Daemon = &kubelet{}
+var (
+	_ daemon.Daemon      = &kubelet{}
+	_ daemon.Restartable = &kubelet{}
+	_ daemon.PreLauncher = &kubelet{}
+)
 
 type kubelet struct {
 	daemonManager daemon.DaemonManager
@@ -25,17 +33,29 @@ func NewKubeletDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
 	}
 }
 
-func (k *kubelet) Configure(cfg *api.NodeConfig) error {
-	if err := k.writeKubeletConfig(cfg); err != nil {
+func (k *kubelet) Configure(ctx context.Context, cfg *api.NodeConfig) error {
+	if err := validateResolvConf(cfg); err != nil {
+		return err
+	}
+	if err := validateFlags(cfg); err != nil {
 		return err
 	}
-	if err := k.writeKubeconfig(cfg); err != nil {
+	if err := k.writeKubeletConfig(ctx, cfg); err != nil {
+		return err
+	}
+	if err := k.writeKubeconfig(ctx, cfg); err != nil {
 		return err
 	}
 	if err := k.writeImageCredentialProviderConfig(cfg); err != nil {
 		return err
 	}
-	if err := writeClusterCaCert(cfg.Spec.Cluster.CertificateAuthority); err != nil {
+	if err := writeClusterCaCert(cfg); err != nil {
+		return err
+	}
+	if err := writeStaticPods(ctx, cfg); err != nil {
+		return err
+	}
+	if err := writeSeccompProfiles(ctx, cfg); err != nil {
 		return err
 	}
 	if err := k.writeKubeletEnvironment(cfg); err != nil {
@@ -44,11 +64,37 @@ func (k *kubelet) Configure(cfg *api.NodeConfig) error {
 	return nil
 }
 
+func (k *kubelet) PreLaunch(ctx context.Context, cfg *api.NodeConfig) error {
+	if !api.IsFeatureEnabled(api.APIServerEndpointCheck, cfg.Spec.FeatureGates) {
+		return nil
+	}
+	return checkEndpoint(ctx, cfg)
+}
+
 func (k *kubelet) EnsureRunning() error {
 	return k.daemonManager.StartDaemon(KubeletDaemonName)
 }
 
-func (k *kubelet) PostLaunch(_ *api.NodeConfig) error {
+func (k *kubelet) Restart() error {
+	return k.daemonManager.RestartDaemon(KubeletDaemonName)
+}
+
+func (k *kubelet) PostLaunch(ctx context.Context, cfg *api.NodeConfig) error {
+	if cfg.Spec.Kubelet.ServingCertificate.WaitForIssuance {
+		if err := waitForServingCertificate(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Spec.Kubelet.Readiness.WaitForReady {
+		if err := waitForNodeReady(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	// verification is best-effort, and should not fail the bootstrap of a node
+	// that is otherwise healthy.
+	if err := verifyCgroupAccounting(ctx, cfg); err != nil {
+		zap.L().Warn("Failed to verify cgroup accounting", zap.Error(err))
+	}
 	return nil
 }
 
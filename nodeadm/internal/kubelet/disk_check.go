@@ -0,0 +1,149 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	// defaultImageGCHighThresholdPercent is the threshold of kubelet when it
+	// is not configured.
+	defaultImageGCHighThresholdPercent = 85
+	ephemeralStorage                   = "ephemeral-storage"
+	nodefsAvailable                    = "nodefs.available"
+)
+
+// checkDisk checks that the filesystem of kubelet has room for the disk usage
+// that kubelet is configured for, with the config of the NodeConfig merged
+// over the config of nodeadm. The problems that are found fail the bootstrap
+// or are logged, by the failure policy of the check.
+func checkDisk(cfg *api.NodeConfig, kubeletConfig *kubeletConfig) error {
+	capacity, used, err := getFilesystemUsage(kubeletRootDir)
+	if err != nil {
+		zap.L().Warn("Skipping the disk check of kubelet", zap.String("path", kubeletRootDir), zap.Error(err))
+		return nil
+	}
+	merged, err := mergeUserKubeletConfig(cfg, kubeletConfig)
+	if err != nil {
+		zap.L().Warn("Skipping the disk check of kubelet", zap.Error(err))
+		return nil
+	}
+	var footprint int64
+	if cfg.Spec.Kubelet.DiskCheck.DaemonSetFootprint != nil {
+		footprint = cfg.Spec.Kubelet.DiskCheck.DaemonSetFootprint.Value()
+	}
+	problems, err := getDiskProblems(capacity, used, footprint, merged)
+	if err != nil {
+		zap.L().Warn("Skipping the disk check of kubelet", zap.Error(err))
+		return nil
+	}
+	if len(problems) == 0 {
+		zap.L().Info("Filesystem of kubelet has room for its disk usage",
+			zap.String("path", kubeletRootDir),
+			zap.String("capacity", formatBytes(capacity)),
+			zap.String("used", formatBytes(used)))
+		return nil
+	}
+	if cfg.Spec.Kubelet.DiskCheck.FailurePolicy == api.DiskCheckFailurePolicyFail {
+		return fmt.Errorf("filesystem of kubelet at %s is too small: %s", kubeletRootDir, strings.Join(problems, "; "))
+	}
+	for _, problem := range problems {
+		zap.L().Warn("Filesystem of kubelet is too small", zap.String("path", kubeletRootDir), zap.String("problem", problem))
+	}
+	return nil
+}
+
+// mergeUserKubeletConfig returns a copy of the config of kubelet with the
+// config of the NodeConfig merged over it, whose maps are merged by key like
+// the drop-ins of kubelet.
+func mergeUserKubeletConfig(cfg *api.NodeConfig, generated *kubeletConfig) (*kubeletConfig, error) {
+	var merged kubeletConfig
+	for _, config := range []any{generated, cfg.Spec.Kubelet.Config} {
+		data, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &merged); err != nil {
+			return nil, fmt.Errorf("failed to read kubelet config: %w", err)
+		}
+	}
+	return &merged, nil
+}
+
+// getDiskProblems returns why a filesystem of a capacity, of which some is
+// already used, does not have room for the DaemonSets of the cluster within
+// the thresholds and reservations of kubelet.
+func getDiskProblems(capacity int64, used int64, footprint int64, config *kubeletConfig) ([]string, error) {
+	evictionThreshold, err := getEvictionThreshold(config.EvictionHard[nodefsAvailable], capacity)
+	if err != nil {
+		return nil, err
+	}
+	var reserved int64
+	for _, reservation := range []map[string]string{config.KubeReserved, config.SystemReserved} {
+		if value, ok := reservation[ephemeralStorage]; ok {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("reserved %s %q is not a quantity: %w", ephemeralStorage, value, err)
+			}
+			reserved += quantity.Value()
+		}
+	}
+	highThresholdPercent := int64(defaultImageGCHighThresholdPercent)
+	if config.ImageGCHighThresholdPercent != nil {
+		highThresholdPercent = int64(*config.ImageGCHighThresholdPercent)
+	}
+	// pods are evicted once less than the threshold is available, and
+	// images are garbage collected once the usage exceeds the high threshold
+	evictionUsage := capacity - evictionThreshold
+	gcUsage := capacity * highThresholdPercent / 100
+
+	var problems []string
+	if gcUsage >= evictionUsage {
+		problems = append(problems, fmt.Sprintf("images are garbage collected at %d%% usage, after pods are evicted with less than %s available",
+			highThresholdPercent, formatBytes(evictionThreshold)))
+	}
+	if allocatable := capacity - reserved - evictionThreshold; footprint > allocatable {
+		problems = append(problems, fmt.Sprintf("the DaemonSet footprint of %s is more than the %s of allocatable ephemeral storage",
+			formatBytes(footprint), formatBytes(max(allocatable, 0))))
+	}
+	if used+footprint >= evictionUsage {
+		problems = append(problems, fmt.Sprintf("%s of %s is used, which with the DaemonSet footprint of %s leaves less than the %s that pods are evicted below",
+			formatBytes(used), formatBytes(capacity), formatBytes(footprint), formatBytes(evictionThreshold)))
+	} else if used+footprint >= gcUsage {
+		problems = append(problems, fmt.Sprintf("%s of %s is used, which with the DaemonSet footprint of %s is above the %d%% usage that images are garbage collected at",
+			formatBytes(used), formatBytes(capacity), formatBytes(footprint), highThresholdPercent))
+	}
+	return problems, nil
+}
+
+// getEvictionThreshold returns the bytes that must be available on the
+// filesystem for pods not to be evicted, from a threshold of kubelet that is
+// either a percentage of the capacity or a quantity.
+func getEvictionThreshold(value string, capacity int64) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if percentage, ok := strings.CutSuffix(value, "%"); ok {
+		var percent float64
+		if _, err := fmt.Sscanf(percentage, "%g", &percent); err != nil {
+			return 0, fmt.Errorf("eviction threshold %s %q is not a percentage: %w", nodefsAvailable, value, err)
+		}
+		return int64(float64(capacity) * percent / 100), nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("eviction threshold %s %q is not a quantity: %w", nodefsAvailable, value, err)
+	}
+	return quantity.Value(), nil
+}
+
+// formatBytes formats bytes in gibibytes, which is the unit of volumes.
+func formatBytes(bytes int64) string {
+	return fmt.Sprintf("%.1fGi", float64(bytes)/(1024*1024*1024))
+}
@@ -0,0 +1,114 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const gibibyte = 1024 * 1024 * 1024
+
+func TestGetDiskProblems(t *testing.T) {
+	defaults := defaultKubeletSubConfig()
+	defaults.KubeReserved = map[string]string{"ephemeral-storage": "1Gi"}
+	var tests = []struct {
+		name      string
+		capacity  int64
+		used      int64
+		footprint int64
+		config    kubeletConfig
+		expected  []string
+	}{
+		{
+			name:      "room for daemonsets",
+			capacity:  80 * gibibyte,
+			used:      10 * gibibyte,
+			footprint: 5 * gibibyte,
+			config:    defaults,
+		},
+		{
+			name:      "garbage collection after eviction",
+			capacity:  80 * gibibyte,
+			used:      10 * gibibyte,
+			footprint: 5 * gibibyte,
+			config: kubeletConfig{
+				EvictionHard:                map[string]string{"nodefs.available": "15%"},
+				ImageGCHighThresholdPercent: ptr.To[int32](90),
+			},
+			expected: []string{"images are garbage collected at 90% usage, after pods are evicted with less than 12.0Gi available"},
+		},
+		{
+			name:      "footprint above allocatable",
+			capacity:  20 * gibibyte,
+			used:      2 * gibibyte,
+			footprint: 18 * gibibyte,
+			config:    defaults,
+			expected: []string{
+				"the DaemonSet footprint of 18.0Gi is more than the 17.0Gi of allocatable ephemeral storage",
+				"2.0Gi of 20.0Gi is used, which with the DaemonSet footprint of 18.0Gi leaves less than the 2.0Gi that pods are evicted below",
+			},
+		},
+		{
+			name:      "footprint above garbage collection",
+			capacity:  20 * gibibyte,
+			used:      12 * gibibyte,
+			footprint: 5 * gibibyte,
+			config:    defaults,
+			expected:  []string{"12.0Gi of 20.0Gi is used, which with the DaemonSet footprint of 5.0Gi is above the 85% usage that images are garbage collected at"},
+		},
+		{
+			name:      "absolute eviction threshold and system reserved",
+			capacity:  40 * gibibyte,
+			used:      2 * gibibyte,
+			footprint: 30 * gibibyte,
+			config: kubeletConfig{
+				EvictionHard:   map[string]string{"nodefs.available": "5Gi"},
+				KubeReserved:   map[string]string{"ephemeral-storage": "1Gi"},
+				SystemReserved: map[string]string{"ephemeral-storage": "5Gi"},
+			},
+			expected: []string{"the DaemonSet footprint of 30.0Gi is more than the 29.0Gi of allocatable ephemeral storage"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems, err := getDiskProblems(test.capacity, test.used, test.footprint, &test.config)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, problems)
+		})
+	}
+
+	_, err := getDiskProblems(20*gibibyte, 0, 0, &kubeletConfig{EvictionHard: map[string]string{"nodefs.available": "ten"}})
+	assert.Error(t, err)
+}
+
+func TestMergeUserKubeletConfig(t *testing.T) {
+	generated := defaultKubeletSubConfig()
+	generated.KubeReserved = map[string]string{"cpu": "70m", "ephemeral-storage": "1Gi"}
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				Config: api.InlineDocument{
+					"evictionHard":                {Raw: []byte(`{"nodefs.available": "15%"}`)},
+					"imageGCHighThresholdPercent": {Raw: []byte(`80`)},
+					"kubeReserved":                {Raw: []byte(`{"ephemeral-storage": "5Gi"}`)},
+				},
+			},
+		},
+	}
+	merged, err := mergeUserKubeletConfig(&cfg, &generated)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"memory.available": "100Mi", "nodefs.available": "15%", "nodefs.inodesFree": "5%"}, merged.EvictionHard)
+	assert.Equal(t, map[string]string{"cpu": "70m", "ephemeral-storage": "5Gi"}, merged.KubeReserved)
+	assert.Equal(t, ptr.To[int32](80), merged.ImageGCHighThresholdPercent)
+	// the generated config is not changed
+	assert.Equal(t, "10%", generated.EvictionHard["nodefs.available"])
+	assert.Equal(t, "1Gi", generated.KubeReserved["ephemeral-storage"])
+
+	cfg.Spec.Kubelet.Config = api.InlineDocument{"imageGCHighThresholdPercent": runtime.RawExtension{Raw: []byte(`"high"`)}}
+	_, err = mergeUserKubeletConfig(&cfg, &generated)
+	assert.Error(t, err)
+}
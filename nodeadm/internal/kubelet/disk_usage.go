@@ -0,0 +1,17 @@
+//go:build !windows
+
+package kubelet
+
+import (
+	"syscall"
+)
+
+// getFilesystemUsage returns the capacity of the filesystem of a path, and how
+// much of it is used, in bytes.
+func getFilesystemUsage(path string) (capacity int64, used int64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	return int64(stat.Blocks) * int64(stat.Bsize), int64(stat.Blocks-stat.Bfree) * int64(stat.Bsize), nil
+}
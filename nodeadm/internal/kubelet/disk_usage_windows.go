@@ -0,0 +1,11 @@
+package kubelet
+
+import (
+	"errors"
+)
+
+// getFilesystemUsage is not implemented on Windows, where the disk check of
+// kubelet is skipped.
+func getFilesystemUsage(path string) (capacity int64, used int64, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
@@ -0,0 +1,124 @@
+package kubelet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	defaultClusterDomain = "cluster.local"
+	// the resolver configuration kubelet passes the search domains of to pods
+	resolvConfPath = "/etc/resolv.conf"
+)
+
+// withClusterDomain overrides kubelet's cluster domain when the cluster's DNS
+// is configured with a domain other than the default.
+func (ksc *kubeletConfig) withClusterDomain(cfg *api.NodeConfig) {
+	if cfg.Spec.Cluster.DNSDomain != "" {
+		ksc.ClusterDomain = cfg.Spec.Cluster.DNSDomain
+	}
+}
+
+// nodeLocalDNSCacheAddress is the link-local address that NodeLocal DNSCache
+// listens on in the manifests of its documentation.
+const nodeLocalDNSCacheAddress = "169.254.20.10"
+
+// withClusterDns sets the DNS servers of pods. The servers of the config take
+// precedence, otherwise the address of the cluster DNS service is inferred
+// from the service CIDR.
+func (ksc *kubeletConfig) withClusterDns(cfg *api.NodeConfig) error {
+	if len(cfg.Spec.Kubelet.ClusterDNS) > 0 {
+		ksc.ClusterDNS = cfg.Spec.Kubelet.ClusterDNS
+		return nil
+	}
+	clusterDns, err := cfg.Spec.Cluster.GetClusterDns()
+	if err != nil {
+		return err
+	}
+	ksc.ClusterDNS = []string{clusterDns}
+	if api.IsFeatureEnabled(api.NodeLocalDNSCache, cfg.Spec.FeatureGates) {
+		if ipFamilies, _ := cfg.Spec.Cluster.GetIPFamilies(); ipFamilies[0] != api.IPFamilyIPv4 {
+			zap.L().Warn("Not using NodeLocal DNSCache, its link-local address is only known for IPv4 clusters")
+			return nil
+		}
+		// pods fall back to the cluster DNS service while the cache is not
+		// running on the node, such as before its DaemonSet is scheduled
+		ksc.ClusterDNS = []string{nodeLocalDNSCacheAddress, clusterDns}
+	}
+	return nil
+}
+
+func getClusterDomain(cfg *api.NodeConfig) string {
+	if cfg.Spec.Cluster.DNSDomain != "" {
+		return cfg.Spec.Cluster.DNSDomain
+	}
+	return defaultClusterDomain
+}
+
+// validateResolvConf checks the search domains of the node, which are usually
+// set by the DHCP options of the VPC, against the cluster domain. Pods are
+// given the cluster's search domains followed by those of the node.
+func validateResolvConf(cfg *api.NodeConfig) error {
+	resolvConf, err := os.ReadFile(resolvConfPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return validateDNSSearchList(cfg.Status.KubeletVersion, getClusterDomain(cfg), getSearchDomains(resolvConf))
+}
+
+func validateDNSSearchList(kubeletVersion string, clusterDomain string, searchDomains []string) error {
+	for _, domain := range searchDomains {
+		domain = strings.TrimSuffix(domain, ".")
+		// a search domain within the cluster domain would be answered by the
+		// cluster's DNS, rather than the resolver of the node.
+		if domain == clusterDomain || strings.HasSuffix(domain, "."+clusterDomain) {
+			return fmt.Errorf("search domain %q in %s conflicts with the cluster DNS domain %q", domain, resolvConfPath, clusterDomain)
+		}
+	}
+	// kubelet omits the search domains beyond its limits from the resolver
+	// configuration of pods. The limits were raised in 1.28.
+	maxPaths, maxChars := 6, 256
+	if semver.Compare(kubeletVersion, "v1.28.0") >= 0 {
+		maxPaths, maxChars = 32, 2048
+	}
+	// namespaces vary in length, so the namespace's search domain is not
+	// included in the length of the search list.
+	podSearchDomains := append([]string{"svc." + clusterDomain, clusterDomain}, searchDomains...)
+	if paths, chars := len(podSearchDomains)+1, len(strings.Join(podSearchDomains, " ")); paths > maxPaths || chars > maxChars {
+		zap.L().Warn("Search domains of pods exceed the limits of kubelet, and will be truncated",
+			zap.Strings("searchDomains", searchDomains),
+			zap.Int("maxPaths", maxPaths),
+			zap.Int("maxChars", maxChars),
+		)
+	}
+	return nil
+}
+
+// getSearchDomains returns the search list of a resolver configuration, where
+// the last search or domain keyword takes precedence.
+func getSearchDomains(resolvConf []byte) []string {
+	var searchDomains []string
+	scanner := bufio.NewScanner(bytes.NewReader(resolvConf))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "search", "domain":
+			searchDomains = fields[1:]
+		}
+	}
+	return searchDomains
+}
@@ -0,0 +1,96 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterDomain(t *testing.T) {
+	var tests = []struct {
+		dnsDomain             string
+		expectedClusterDomain string
+	}{
+		{dnsDomain: "", expectedClusterDomain: "cluster.local"},
+		{dnsDomain: "k8s.example.com", expectedClusterDomain: "k8s.example.com"},
+	}
+
+	for _, test := range tests {
+		kubeletConfig := defaultKubeletSubConfig()
+		nodeConfig := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Cluster: api.ClusterDetails{DNSDomain: test.dnsDomain},
+			},
+		}
+		kubeletConfig.withClusterDomain(&nodeConfig)
+		assert.Equal(t, test.expectedClusterDomain, kubeletConfig.ClusterDomain)
+	}
+}
+
+func TestClusterDns(t *testing.T) {
+	var tests = []struct {
+		name               string
+		cidr               string
+		clusterDNS         []string
+		nodeLocalDNSCache  bool
+		expectedClusterDNS []string
+	}{
+		{name: "service CIDR", cidr: "10.100.0.0/16", expectedClusterDNS: []string{"10.100.0.10"}},
+		{name: "dual-stack", cidr: "fd30:1c53:5f8a::/108,172.20.0.0/16", expectedClusterDNS: []string{"fd30:1c53:5f8a::a"}},
+		{name: "override", cidr: "10.100.0.0/16", clusterDNS: []string{"10.0.0.2"}, nodeLocalDNSCache: true, expectedClusterDNS: []string{"10.0.0.2"}},
+		{name: "node local cache", cidr: "10.100.0.0/16", nodeLocalDNSCache: true, expectedClusterDNS: []string{"169.254.20.10", "10.100.0.10"}},
+		{name: "node local cache in IPv6 cluster", cidr: "fd30:1c53:5f8a::/108", nodeLocalDNSCache: true, expectedClusterDNS: []string{"fd30:1c53:5f8a::a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			nodeConfig := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster:      api.ClusterDetails{CIDR: test.cidr},
+					Kubelet:      api.KubeletOptions{ClusterDNS: test.clusterDNS},
+					FeatureGates: map[api.Feature]bool{api.NodeLocalDNSCache: test.nodeLocalDNSCache},
+				},
+			}
+			assert.NoError(t, kubeletConfig.withClusterDns(&nodeConfig))
+			assert.Equal(t, test.expectedClusterDNS, kubeletConfig.ClusterDNS)
+		})
+	}
+}
+
+func TestGetSearchDomains(t *testing.T) {
+	resolvConf := []byte(`# This is /run/systemd/resolve/stub-resolv.conf managed by man:systemd-resolved(8).
+nameserver 127.0.0.53
+options edns0 trust-ad
+domain example.com
+search us-west-2.compute.internal corp.example.com
+`)
+	assert.Equal(t, []string{"us-west-2.compute.internal", "corp.example.com"}, getSearchDomains(resolvConf))
+	assert.Nil(t, getSearchDomains([]byte("nameserver 10.0.0.2\n")))
+}
+
+func TestValidateDNSSearchList(t *testing.T) {
+	var tests = []struct {
+		name          string
+		clusterDomain string
+		searchDomains []string
+		expectErr     bool
+	}{
+		{name: "default", clusterDomain: "cluster.local", searchDomains: []string{"us-west-2.compute.internal"}},
+		{name: "custom", clusterDomain: "k8s.example.com", searchDomains: []string{"example.com", "corp.example.com"}},
+		{name: "equal", clusterDomain: "example.com", searchDomains: []string{"example.com"}, expectErr: true},
+		{name: "subdomain", clusterDomain: "example.com", searchDomains: []string{"corp.example.com."}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDNSSearchList("v1.30.0", test.clusterDomain, test.searchDomains)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package kubelet
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	endpointCheckTimeout = 10 * time.Second
+	// nodes are allowed to list nodes by the Node authorizer, while
+	// identities that are authenticated without being nodes are not.
+	endpointCheckPath = "/api/v1/nodes?limit=1"
+)
+
+// EndpointFailure classifies why the node cannot use the kube-apiserver.
+type EndpointFailure string
+
+const (
+	EndpointFailureDNS          EndpointFailure = "dns"
+	EndpointFailureTimeout      EndpointFailure = "tcp-timeout"
+	EndpointFailureConnection   EndpointFailure = "connection"
+	EndpointFailureTLS          EndpointFailure = "tls"
+	EndpointFailureUnauthorized EndpointFailure = "unauthorized"
+	EndpointFailureForbidden    EndpointFailure = "forbidden"
+)
+
+// the exit codes of nodeadm when it fails because of each class of failure
+var endpointFailureExitCodes = map[EndpointFailure]int{
+	EndpointFailureDNS:          10,
+	EndpointFailureTimeout:      11,
+	EndpointFailureConnection:   11,
+	EndpointFailureTLS:          12,
+	EndpointFailureUnauthorized: 13,
+	EndpointFailureForbidden:    14,
+}
+
+var endpointFailureRemediations = map[EndpointFailure]string{
+	EndpointFailureDNS: "check that cluster.apiServerEndpoint is the endpoint of the cluster, and that DNS resolution " +
+		"and DNS hostnames are enabled in the VPC of the node when the endpoint is private",
+	EndpointFailureTimeout: "check that the cluster security group allows HTTPS from the node, that the public or private " +
+		"endpoint access of the cluster allows the node, and that the subnet of the node has a route to the endpoint",
+	EndpointFailureConnection: "check that cluster.apiServerEndpoint is the endpoint of the cluster, and that no proxy or " +
+		"firewall rejects connections from the node to it",
+	EndpointFailureTLS: "check that cluster.certificateAuthority is the certificate authority of the cluster, and that no " +
+		"proxy intercepts TLS connections from the node to the endpoint",
+	EndpointFailureUnauthorized: "check that the IAM role of the node is mapped in the aws-auth ConfigMap or has an access " +
+		"entry of type EC2_LINUX, EC2_WINDOWS or HYBRID_LINUX, and that cluster.name is the name of the cluster",
+	EndpointFailureForbidden: "check that the IAM role of the node is mapped to the system:nodes group in the aws-auth " +
+		"ConfigMap, or that its access entry is of type EC2_LINUX, EC2_WINDOWS or HYBRID_LINUX rather than STANDARD",
+}
+
+// EndpointError is returned when the node cannot use the kube-apiserver,
+// with the remediation of its class of failure. The exit code of nodeadm
+// identifies the class.
+type EndpointError struct {
+	Failure  EndpointFailure
+	Endpoint string
+	Err      error
+}
+
+func (e *EndpointError) Error() string {
+	return fmt.Sprintf("kube-apiserver %s cannot be used by the node (%s): %v; %s", e.Endpoint, e.Failure, e.Err, endpointFailureRemediations[e.Failure])
+}
+
+func (e *EndpointError) Unwrap() error {
+	return e.Err
+}
+
+func (e *EndpointError) ExitCode() int {
+	return endpointFailureExitCodes[e.Failure]
+}
+
+// checkEndpoint makes a request to the kube-apiserver as the node, so that a
+// node that cannot join the cluster fails before kubelet is started, with the
+// cause of the failure. The node authenticates with the credentials of the
+// kubeconfig of kubelet, with its client certificate or by running its exec
+// credential plugin. Failures other than of TLS are retried, since the
+// endpoint and the access of the node may not be ready as soon as it boots.
+func checkEndpoint(ctx context.Context, cfg *api.NodeConfig) error {
+	endpoint := cfg.Spec.Cluster.APIServerEndpoint
+	roots, err := newAPIServerCertPool(cfg)
+	if err != nil {
+		return err
+	}
+	tlsConfig := &tls.Config{RootCAs: roots}
+	client := &http.Client{
+		Timeout: endpointCheckTimeout,
+		Transport: &http.Transport{
+			Proxy:           util.ProxyFromEnvironment(),
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	zap.L().Info("Checking that the kube-apiserver can be used by the node..", zap.String("endpoint", endpoint))
+	var bearerToken string
+	authenticated := true
+	if cfg.Spec.Kubelet.AuthenticationMode == api.KubeletAuthenticationModeClientCertificate {
+		authentication := cfg.Spec.Kubelet.Authentication
+		certificate, err := tls.LoadX509KeyPair(authentication.ClientCertificate, authentication.ClientKey)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate of kubelet: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	} else if bearerToken, err = getExecToken(ctx, cfg); err != nil {
+		// the exec credential plugin is run again by kubelet, which may
+		// succeed once the credentials of the node are available, so only
+		// the connection is checked.
+		zap.L().Warn("Failed to get a token from the exec credential plugin, the authentication of the node is not checked", zap.Error(err))
+		authenticated = false
+	}
+	var tlsErr error
+	err = util.NewRetrier().Retry(ctx, func() error {
+		err := probeEndpoint(ctx, client, endpoint, bearerToken, authenticated)
+		var endpointErr *EndpointError
+		if errors.As(err, &endpointErr) && endpointErr.Failure == EndpointFailureTLS {
+			tlsErr = err
+			return nil
+		} else if err != nil {
+			zap.L().Warn("Failed to use the kube-apiserver", zap.Error(err))
+		}
+		return err
+	})
+	if tlsErr != nil {
+		return tlsErr
+	}
+	return err
+}
+
+// getExecToken runs the exec credential plugin of the kubeconfig of kubelet,
+// with the environment that kubelet runs it with, and returns its token.
+func getExecToken(ctx context.Context, cfg *api.NodeConfig) (string, error) {
+	data, err := generateKubeconfig(cfg)
+	if err != nil {
+		return "", err
+	}
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		return "", err
+	}
+	authInfo, ok := kubeconfig.AuthInfos[kubeconfigUser]
+	if !ok || authInfo.Exec == nil {
+		return "", fmt.Errorf("kubeconfig of kubelet has no exec credential plugin")
+	}
+	execInfo, err := json.Marshal(map[string]any{
+		"apiVersion": authInfo.Exec.APIVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]any{"interactive": false},
+	})
+	if err != nil {
+		return "", err
+	}
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.CommandContext(ctx, authInfo.Exec.Command, authInfo.Exec.Args...)
+	cmd.Env = append(os.Environ(), "KUBERNETES_EXEC_INFO="+string(execInfo))
+	for key, value := range getExecEnvironment(cfg) {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run %s: %w", authInfo.Exec.Command, err)
+	}
+	var credential struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return "", fmt.Errorf("invalid ExecCredential from %s: %w", authInfo.Exec.Command, err)
+	}
+	if credential.Status.Token == "" {
+		return "", fmt.Errorf("ExecCredential from %s has no token", authInfo.Exec.Command)
+	}
+	return credential.Status.Token, nil
+}
+
+// probeEndpoint makes a single request to the kube-apiserver, and classifies
+// its failure. An unauthenticated request only checks that the kube-apiserver
+// can be reached, since /healthz may not be allowed for anonymous requests.
+func probeEndpoint(ctx context.Context, client *http.Client, endpoint string, bearerToken string, authenticated bool) error {
+	path := endpointCheckPath
+	if !authenticated {
+		path = "/healthz"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if failure := classifyEndpointError(err); failure != "" {
+			return &EndpointError{Failure: failure, Endpoint: endpoint, Err: err}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if !authenticated && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		return &EndpointError{Failure: EndpointFailureUnauthorized, Endpoint: endpoint, Err: errors.New(resp.Status)}
+	case http.StatusForbidden:
+		return &EndpointError{Failure: EndpointFailureForbidden, Endpoint: endpoint, Err: errors.New(resp.Status)}
+	default:
+		return fmt.Errorf("unexpected response from kube-apiserver %s: %s", endpoint, resp.Status)
+	}
+}
+
+// classifyEndpointError returns the class of an error of a request to the
+// kube-apiserver, or an empty class if it has none.
+func classifyEndpointError(err error) EndpointFailure {
+	var dnsErr *net.DNSError
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var netErr net.Error
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &dnsErr):
+		return EndpointFailureDNS
+	case errors.As(err, &verificationErr), errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return EndpointFailureTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return EndpointFailureTimeout
+	case errors.As(err, &opErr):
+		return EndpointFailureConnection
+	}
+	return ""
+}
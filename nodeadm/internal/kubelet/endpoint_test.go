@@ -0,0 +1,121 @@
+package kubelet
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func TestProbeEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer k8s-aws-v1.node":
+			w.WriteHeader(http.StatusOK)
+		case "":
+			// anonymous requests are not allowed in the cluster, which still
+			// shows that the kube-apiserver can be reached
+			w.WriteHeader(http.StatusUnauthorized)
+		case "Bearer k8s-aws-v1.unmapped":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	var tests = []struct {
+		name            string
+		client          *http.Client
+		bearerToken     string
+		expectedFailure EndpointFailure
+		expectedCode    int
+	}{
+		{name: "node", client: client, bearerToken: "k8s-aws-v1.node"},
+		{name: "without token", client: client},
+		{name: "unauthorized", client: client, bearerToken: "k8s-aws-v1.unmapped", expectedFailure: EndpointFailureUnauthorized, expectedCode: 13},
+		{name: "forbidden", client: client, bearerToken: "k8s-aws-v1.standard", expectedFailure: EndpointFailureForbidden, expectedCode: 14},
+		{name: "wrong certificate authority", client: &http.Client{}, bearerToken: "k8s-aws-v1.node", expectedFailure: EndpointFailureTLS, expectedCode: 12},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := probeEndpoint(context.TODO(), test.client, server.URL, test.bearerToken, test.bearerToken != "")
+			if test.expectedFailure == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var endpointErr *EndpointError
+			assert.ErrorAs(t, err, &endpointErr)
+			assert.Equal(t, test.expectedFailure, endpointErr.Failure)
+			assert.Equal(t, test.expectedCode, cli.ExitCode(err))
+		})
+	}
+}
+
+func TestGetExecToken(t *testing.T) {
+	// a fake AWS CLI that reports the arguments, and the environment that
+	// kubelet would run it with, in its token
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '{\"apiVersion\":\"client.authentication.k8s.io/v1beta1\",\"kind\":\"ExecCredential\",\"status\":{\"token\":\"'\"$*;$AWS_ENDPOINT_URL_STS\"'\"}}'\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "aws"), []byte(script), 0755))
+	t.Setenv("PATH", dir)
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Name:              "my-cluster",
+				APIServerEndpoint: "https://example.com",
+				EndpointOverrides: api.EndpointOverrides{STS: "https://sts.example.com"},
+			},
+			Kubelet: api.KubeletOptions{
+				Authentication: api.KubeletAuthenticationOptions{RoleARN: "arn:aws:iam::123456789012:role/node"},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{Region: "us-west-2"},
+		},
+	}
+	token, err := getExecToken(context.TODO(), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "eks get-token --cluster-name my-cluster --region us-west-2 --role-arn arn:aws:iam::123456789012:role/node;https://sts.example.com", token)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyEndpointError(t *testing.T) {
+	var tests = []struct {
+		name            string
+		err             error
+		expectedFailure EndpointFailure
+	}{
+		{name: "dns", err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.eks.amazonaws.com", IsNotFound: true}}, expectedFailure: EndpointFailureDNS},
+		{name: "timeout", err: &net.OpError{Op: "dial", Err: timeoutError{}}, expectedFailure: EndpointFailureTimeout},
+		{name: "refused", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expectedFailure: EndpointFailureConnection},
+		{name: "unknown authority", err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}, expectedFailure: EndpointFailureTLS},
+		{name: "unclassified", err: errors.New("unexpected EOF")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedFailure, classifyEndpointError(test.err))
+		})
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	ec2extra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 	"go.uber.org/zap"
 )
@@ -50,18 +51,23 @@ func init() {
 // The behavior should align with AL2, which essentially is:
 //
 //	# of ENI * (# of IPv4 per ENI - 1) + 2
-func CalcMaxPods(awsRegion string, instanceType string) int32 {
+func CalcMaxPods(ctx context.Context, awsRegion string, instanceType string) int32 {
 	zap.L().Info("calculate the max pod for instance type", zap.String("instanceType", instanceType))
-	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(awsRegion))
+	eniInfo, err := getEniInfo(ctx, awsRegion, instanceType)
 	if err != nil {
-		zap.L().Warn("error loading AWS SDK config when calculating the max pod, setting it to default value", zap.Error(err))
+		zap.L().Warn("cannot find the max pod for input instance type, setting it to default value", zap.Error(err))
 		return defaultMaxPods
 	}
-	ec2Client := &util.EC2Client{Client: ec2.NewFromConfig(cfg)}
-	eniInfo, err := util.GetEniInfoForInstanceType(ec2Client, instanceType)
+	return eniInfo.EniCount*(eniInfo.PodsPerEniCount-1) + 2
+}
+
+// getEniInfo describes the network interfaces and the IPv4 addresses per
+// interface that the instance type supports.
+func getEniInfo(ctx context.Context, awsRegion string, instanceType string) (util.EniInfo, error) {
+	cfg, err := awsconfig.Load(ctx, config.WithRegion(awsRegion))
 	if err != nil {
-		zap.L().Warn("cannot find the max pod for input instance type, setting it to default value")
-		return defaultMaxPods
+		return util.EniInfo{}, err
 	}
-	return eniInfo.EniCount*(eniInfo.PodsPerEniCount-1) + 2
+	ec2Client := &util.EC2Client{Client: ec2extra.NewClient(cfg)}
+	return util.GetEniInfoForInstanceType(ctx, ec2Client, instanceType)
 }
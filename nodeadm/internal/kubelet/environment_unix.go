@@ -0,0 +1,26 @@
+//go:build !windows
+
+package kubelet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	kubeletEnvironmentFilePath = "/etc/eks/kubelet/environment"
+	kubeletArgsEnvironmentName = "NODEADM_KUBELET_ARGS"
+)
+
+// writeEnvironment writes the environment file that the kubelet unit reads,
+// with the flags of kubelet in a variable scoped to nodeadm.
+func (k *kubelet) writeEnvironment(kubeletFlags []string) error {
+	k.environment[kubeletArgsEnvironmentName] = strings.Join(kubeletFlags, " ")
+	var kubeletEnvironment []string
+	for eKey, eValue := range k.environment {
+		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s=%s`, eKey, eValue))
+	}
+	return util.WriteFileWithDir(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
+}
@@ -0,0 +1,24 @@
+package kubelet
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+// writeEnvironment sets the flags of kubelet on the command line of its
+// service, since the Service Control Manager does not read environment files.
+// Variables of the environment cannot be set on a service by nodeadm, so the
+// features that need them are not supported on Windows.
+func (k *kubelet) writeEnvironment(kubeletFlags []string) error {
+	setter, ok := k.daemonManager.(daemon.ArgumentSetter)
+	if !ok {
+		return fmt.Errorf("daemon manager cannot set the arguments of %s", KubeletDaemonName)
+	}
+	for name := range k.environment {
+		zap.L().Warn("Environment variable of kubelet is not set on Windows", zap.String("name", name))
+	}
+	return setter.SetDaemonArguments(KubeletDaemonName, kubeletFlags)
+}
@@ -0,0 +1,181 @@
+package kubelet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// removedFeatureGates maps kubelet feature gates to the first minor version of
+// kubelet that no longer recognizes them. kubelet fails to start when an
+// unrecognized feature gate is set, so these are rejected before the config is
+// rendered.
+// see: https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates-removed/
+var removedFeatureGates = map[string]string{
+	"IPv6DualStack":                 "v1.25.0",
+	"DynamicKubeletConfig":          "v1.26.0",
+	"PodOverhead":                   "v1.26.0",
+	"CSIMigration":                  "v1.27.0",
+	"CSIMigrationAWS":               "v1.27.0",
+	"EphemeralContainers":           "v1.27.0",
+	"ExpandCSIVolumes":              "v1.27.0",
+	"ExpandInUsePersistentVolumes":  "v1.27.0",
+	"ExpandPersistentVolumes":       "v1.27.0",
+	"LocalStorageCapacityIsolation": "v1.27.0",
+	"DelegateFSGroupToCSIDriver":    "v1.28.0",
+	"DevicePlugins":                 "v1.28.0",
+	"KubeletCredentialProviders":    "v1.28.0",
+	"DownwardAPIHugePages":          "v1.29.0",
+	"SeccompDefault":                "v1.29.0",
+	"TopologyManager":               "v1.29.0",
+}
+
+// validateFeatureGates returns an error if any of the feature gates are not
+// recognized by the given version of kubelet.
+func validateFeatureGates(kubeletVersion string, featureGates map[string]bool) error {
+	var removed []string
+	for gate := range featureGates {
+		if version, ok := removedFeatureGates[gate]; ok && semver.Compare(kubeletVersion, version) >= 0 {
+			removed = append(removed, fmt.Sprintf("%s (removed in %s)", gate, semver.MajorMinor(version)))
+		}
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		return fmt.Errorf("kubelet %s does not support feature gates: %v", kubeletVersion, removed)
+	}
+	return nil
+}
+
+// withFeatureGates merges the feature gates provided by the user into the
+// defaults, giving them precedence.
+func (ksc *kubeletConfig) withFeatureGates(cfg *api.NodeConfig) error {
+	if err := validateFeatureGates(cfg.Status.KubeletVersion, cfg.Spec.Kubelet.FeatureGates); err != nil {
+		return err
+	}
+	for gate, enabled := range cfg.Spec.Kubelet.FeatureGates {
+		ksc.FeatureGates[gate] = enabled
+	}
+	return nil
+}
+
+// procRoot is where the processes of the node are read from.
+var procRoot = "/proc"
+
+// GetRunningFeatureGates returns the feature gates that the running kubelet
+// was started with. They are merged from its config file, the drop-ins of its
+// config dir in lexical order and its --feature-gates flag, in the order of
+// precedence that kubelet merges them in, so that changes that kubelet has not
+// been restarted with, or that were not made by nodeadm, are reflected.
+func GetRunningFeatureGates() (map[string]bool, error) {
+	args, err := getRunningKubeletArgs()
+	if err != nil {
+		return nil, err
+	}
+	var configPaths []string
+	if configPath := getFlagValue(args, "config"); configPath != "" {
+		configPaths = append(configPaths, configPath)
+	}
+	if configDir := getFlagValue(args, "config-dir"); configDir != "" {
+		dropInPaths, err := filepath.Glob(filepath.Join(configDir, "*.conf"))
+		if err != nil {
+			return nil, err
+		}
+		configPaths = append(configPaths, dropInPaths...)
+	}
+	featureGates := make(map[string]bool)
+	for _, configPath := range configPaths {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		var config struct {
+			FeatureGates map[string]bool `json:"featureGates"`
+		}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		for gate, enabled := range config.FeatureGates {
+			featureGates[gate] = enabled
+		}
+	}
+	if flag := getFlagValue(args, "feature-gates"); flag != "" {
+		for _, pair := range strings.Split(flag, ",") {
+			gate, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of feature gate %s in --feature-gates of kubelet: %w", gate, err)
+			}
+			featureGates[gate] = enabled
+		}
+	}
+	return featureGates, nil
+}
+
+// GetRunningNodeName returns the name of the Node object that the running
+// kubelet registers, from its --hostname-override flag.
+func GetRunningNodeName() (string, error) {
+	args, err := getRunningKubeletArgs()
+	if err != nil {
+		return "", err
+	}
+	nodeName := getFlagValue(args, "hostname-override")
+	if nodeName == "" {
+		return "", errors.New("kubelet was not started with --hostname-override")
+	}
+	return nodeName, nil
+}
+
+// getRunningKubeletArgs returns the command line arguments of the running
+// kubelet process.
+func getRunningKubeletArgs() ([]string, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		// the process may exit while it is read, so it is skipped on errors
+		comm, err := os.ReadFile(filepath.Join(procRoot, entry.Name(), "comm"))
+		if err != nil || strings.TrimSpace(string(comm)) != "kubelet" {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join(procRoot, entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		return strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"), nil
+	}
+	return nil, errors.New("kubelet is not running")
+}
+
+// getFlagValue returns the value of the last occurrence of a flag in the
+// arguments, in either the `--name=value` or the `--name value` form.
+func getFlagValue(args []string, name string) string {
+	var value string
+	for i := 0; i < len(args); i++ {
+		arg := strings.TrimLeft(args[i], "-")
+		if arg == args[i] {
+			continue
+		}
+		if flagName, flagValue, ok := strings.Cut(arg, "="); ok {
+			if flagName == name {
+				value = flagValue
+			}
+		} else if arg == name && i+1 < len(args) {
+			value = args[i+1]
+			i++
+		}
+	}
+	return value
+}
@@ -0,0 +1,157 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// flagConfigFields maps the flags of kubelet to the fields of its config that
+// they set, where the field is not the camel case of the flag. Nested fields
+// are separated by dots.
+var flagConfigFields = map[string]string{
+	"anonymous-auth":               "authentication.anonymous.enabled",
+	"authentication-token-webhook": "authentication.webhook.enabled",
+	"authorization-mode":           "authorization.mode",
+	"client-ca-file":               "authentication.x509.clientCAFile",
+	"cluster-dns":                  "clusterDNS",
+	"cpu-cfs-quota":                "cpuCFSQuota",
+	"cpu-cfs-quota-period":         "cpuCFSQuotaPeriod",
+	"event-qps":                    "eventRecordQPS",
+	"image-gc-high-threshold":      "imageGCHighThresholdPercent",
+	"image-gc-low-threshold":       "imageGCLowThresholdPercent",
+	"kube-api-burst":               "kubeAPIBurst",
+	"kube-api-content-type":        "kubeAPIContentType",
+	"kube-api-qps":                 "kubeAPIQPS",
+	"pod-cidr":                     "podCIDR",
+	"provider-id":                  "providerID",
+	"registry-qps":                 "registryPullQPS",
+	"rotate-server-certificates":   "serverTLSBootstrap",
+	"v":                            "logging.verbosity",
+}
+
+// FlagConflict is a field of kubelet.config that is also set by a flag of
+// kubelet.flags.
+type FlagConflict struct {
+	Flag  string `json:"flag"`
+	Field string `json:"field"`
+}
+
+func (c FlagConflict) String() string {
+	return fmt.Sprintf("kubelet.config.%s is overridden by --%s in kubelet.flags", c.Field, c.Flag)
+}
+
+// GetFlagConflicts returns the fields of kubelet.config that are also set by
+// kubelet.flags, sorted by field. The flags take precedence, as they do when
+// kubelet reads its config, so the fields are not written to the config.
+func GetFlagConflicts(kubelet *api.KubeletOptions) ([]FlagConflict, error) {
+	if len(kubelet.Flags) == 0 || len(kubelet.Config) == 0 {
+		return nil, nil
+	}
+	config, err := getUserConfigMap(kubelet.Config)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []FlagConflict
+	for _, flag := range kubelet.Flags {
+		name, ok := getFlagName(flag)
+		if !ok {
+			continue
+		}
+		field := getFlagConfigField(name)
+		if _, ok := lookupField(config, field); ok {
+			conflicts = append(conflicts, FlagConflict{Flag: name, Field: field})
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].Field < conflicts[j].Field
+	})
+	return conflicts, nil
+}
+
+// getUserKubeletConfig returns kubelet.config without the fields that are
+// overridden by kubelet.flags, warning about each of them.
+func getUserKubeletConfig(kubelet *api.KubeletOptions) (map[string]any, error) {
+	config, err := getUserConfigMap(kubelet.Config)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := GetFlagConflicts(kubelet)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		zap.L().Warn("Field of kubelet config is overridden by a kubelet flag", zap.String("field", conflict.Field), zap.String("flag", conflict.Flag))
+		deleteField(config, conflict.Field)
+	}
+	return config, nil
+}
+
+func getUserConfigMap(document api.InlineDocument) (map[string]any, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]any
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// getFlagName returns the name of a flag such as `--max-pods=110` or
+// `--max-pods 110`, or false if the argument is the value of the flag before
+// it.
+func getFlagName(flag string) (string, bool) {
+	if !strings.HasPrefix(flag, "-") {
+		return "", false
+	}
+	name := strings.TrimLeft(flag, "-")
+	if i := strings.IndexAny(name, "= "); i >= 0 {
+		name = name[:i]
+	}
+	return name, name != ""
+}
+
+func getFlagConfigField(name string) string {
+	if field, ok := flagConfigFields[name]; ok {
+		return field
+	}
+	words := strings.Split(name, "-")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func lookupField(config map[string]any, field string) (any, bool) {
+	keys := strings.Split(field, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := config[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		config = nested
+	}
+	value, ok := config[keys[len(keys)-1]]
+	return value, ok
+}
+
+func deleteField(config map[string]any, field string) {
+	keys := strings.Split(field, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := config[key].(map[string]any)
+		if !ok {
+			return
+		}
+		config = nested
+	}
+	delete(config, keys[len(keys)-1])
+}
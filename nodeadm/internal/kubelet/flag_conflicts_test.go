@@ -0,0 +1,63 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetFlagConflicts(t *testing.T) {
+	config := api.InlineDocument{
+		"maxPods":        runtime.RawExtension{Raw: []byte(`110`)},
+		"clusterDNS":     runtime.RawExtension{Raw: []byte(`["10.100.0.10"]`)},
+		"authentication": runtime.RawExtension{Raw: []byte(`{"anonymous": {"enabled": true}}`)},
+		"logging":        runtime.RawExtension{Raw: []byte(`{"format": "json"}`)},
+	}
+	var tests = []struct {
+		name              string
+		flags             []string
+		expectedConflicts []FlagConflict
+	}{
+		{name: "no flags"},
+		{name: "unrelated flags", flags: []string{"--node-labels=foo=bar", "--v=2"}},
+		{
+			name:  "overridden fields",
+			flags: []string{"--max-pods=58", "--cluster-dns 10.100.0.20", "--anonymous-auth=false"},
+			expectedConflicts: []FlagConflict{
+				{Flag: "anonymous-auth", Field: "authentication.anonymous.enabled"},
+				{Flag: "cluster-dns", Field: "clusterDNS"},
+				{Flag: "max-pods", Field: "maxPods"},
+			},
+		},
+		{name: "separate value", flags: []string{"--max-pods", "58"}, expectedConflicts: []FlagConflict{{Flag: "max-pods", Field: "maxPods"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conflicts, err := GetFlagConflicts(&api.KubeletOptions{Config: config, Flags: test.flags})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedConflicts, conflicts)
+		})
+	}
+}
+
+func TestGetUserKubeletConfig(t *testing.T) {
+	kubelet := api.KubeletOptions{
+		Config: api.InlineDocument{
+			"maxPods":        runtime.RawExtension{Raw: []byte(`110`)},
+			"authentication": runtime.RawExtension{Raw: []byte(`{"anonymous": {"enabled": true}, "webhook": {"enabled": true}}`)},
+		},
+		Flags: []string{"--max-pods=58", "--anonymous-auth=false"},
+	}
+	config, err := getUserKubeletConfig(&kubelet)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"authentication": map[string]any{
+			"anonymous": map[string]any{},
+			"webhook":   map[string]any{"enabled": true},
+		},
+	}, config)
+}
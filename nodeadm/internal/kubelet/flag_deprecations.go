@@ -0,0 +1,122 @@
+package kubelet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// flagLifecycle is the first minor version of kubelet that deprecates a flag,
+// and the first that no longer recognizes it, if it was removed.
+type flagLifecycle struct {
+	deprecated  string
+	removed     string
+	replacement string
+}
+
+// flagLifecycles maps the flags of kubelet that were deprecated or removed to
+// their lifecycle. kubelet fails to start when an unrecognized flag is set, so
+// removed flags are rejected before the config is rendered, as they would be
+// after the kubelet of the AMI is upgraded.
+// see: https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/
+var flagLifecycles = map[string]flagLifecycle{
+	"cni-bin-dir":                     {deprecated: "v1.20.0", removed: "v1.24.0", replacement: "the config of the CNI plugin of containerd"},
+	"cni-cache-dir":                   {deprecated: "v1.20.0", removed: "v1.24.0", replacement: "the config of the CNI plugin of containerd"},
+	"cni-conf-dir":                    {deprecated: "v1.20.0", removed: "v1.24.0", replacement: "the config of the CNI plugin of containerd"},
+	"docker-endpoint":                 {deprecated: "v1.20.0", removed: "v1.24.0"},
+	"dynamic-config-dir":              {deprecated: "v1.22.0", removed: "v1.24.0"},
+	"image-pull-progress-deadline":    {deprecated: "v1.20.0", removed: "v1.24.0"},
+	"network-plugin":                  {deprecated: "v1.20.0", removed: "v1.24.0"},
+	"network-plugin-mtu":              {deprecated: "v1.20.0", removed: "v1.24.0"},
+	"non-masquerade-cidr":             {deprecated: "v1.20.0", removed: "v1.24.0"},
+	"container-runtime":               {deprecated: "v1.24.0", removed: "v1.27.0", replacement: "--container-runtime-endpoint"},
+	"azure-container-registry-config": {deprecated: "v1.26.0", removed: "v1.30.0", replacement: "--image-credential-provider-config"},
+	"keep-terminated-pod-volumes":     {deprecated: "v1.20.0", removed: "v1.31.0"},
+	"pod-infra-container-image":       {deprecated: "v1.27.0", replacement: "containerd.sandboxImage"},
+}
+
+// FlagDeprecation is a flag of kubelet.flags that is deprecated or removed in
+// the version of kubelet.
+type FlagDeprecation struct {
+	Flag string `json:"flag"`
+	// Removed is the minor version of kubelet that no longer recognizes the
+	// flag, if the version of kubelet is at least that version.
+	Removed     string `json:"removed,omitempty"`
+	Deprecated  string `json:"deprecated"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+func (d FlagDeprecation) String() string {
+	var s string
+	if d.Removed != "" {
+		s = fmt.Sprintf("--%s in kubelet.flags was removed in kubelet %s", d.Flag, d.Removed)
+	} else {
+		s = fmt.Sprintf("--%s in kubelet.flags is deprecated since kubelet %s", d.Flag, d.Deprecated)
+	}
+	if d.Replacement != "" {
+		s += fmt.Sprintf(", use %s instead", d.Replacement)
+	}
+	return s
+}
+
+// GetFlagDeprecations returns the flags of kubelet.flags that are deprecated
+// or removed in the given version of kubelet, sorted by flag.
+func GetFlagDeprecations(kubeletVersion string, flags []string) []FlagDeprecation {
+	var deprecations []FlagDeprecation
+	for _, flag := range flags {
+		name, ok := getFlagName(flag)
+		if !ok {
+			continue
+		}
+		lifecycle, ok := flagLifecycles[name]
+		if !ok || semver.Compare(kubeletVersion, lifecycle.deprecated) < 0 {
+			continue
+		}
+		deprecation := FlagDeprecation{Flag: name, Deprecated: semver.MajorMinor(lifecycle.deprecated), Replacement: lifecycle.replacement}
+		if lifecycle.removed != "" && semver.Compare(kubeletVersion, lifecycle.removed) >= 0 {
+			deprecation.Removed = semver.MajorMinor(lifecycle.removed)
+		}
+		deprecations = append(deprecations, deprecation)
+	}
+	sort.SliceStable(deprecations, func(i, j int) bool {
+		return deprecations[i].Flag < deprecations[j].Flag
+	})
+	return deprecations
+}
+
+// CheckFlags returns the flags of kubelet.flags that are deprecated in the
+// given version of kubelet, or an error if any of them are no longer
+// recognized by it.
+func CheckFlags(kubeletVersion string, flags []string) ([]FlagDeprecation, error) {
+	var deprecated []FlagDeprecation
+	var removed []string
+	for _, deprecation := range GetFlagDeprecations(kubeletVersion, flags) {
+		if deprecation.Removed != "" {
+			removed = append(removed, deprecation.String())
+		} else {
+			deprecated = append(deprecated, deprecation)
+		}
+	}
+	if len(removed) > 0 {
+		return nil, fmt.Errorf("kubelet %s would fail to start: %s", kubeletVersion, strings.Join(removed, "; "))
+	}
+	return deprecated, nil
+}
+
+// validateFlags returns an error if any of kubelet.flags are not recognized by
+// the version of kubelet, and warns about those that are deprecated.
+func validateFlags(cfg *api.NodeConfig) error {
+	deprecated, err := CheckFlags(cfg.Status.KubeletVersion, cfg.Spec.Kubelet.Flags)
+	if err != nil {
+		return err
+	}
+	for _, deprecation := range deprecated {
+		zap.L().Warn("Kubelet flag is deprecated", zap.String("flag", deprecation.Flag), zap.String("deprecated", deprecation.Deprecated), zap.String("replacement", deprecation.Replacement))
+	}
+	return nil
+}
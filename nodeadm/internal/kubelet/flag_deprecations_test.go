@@ -0,0 +1,57 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetFlagDeprecations(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		kubeletVersion       string
+		flags                []string
+		expectedDeprecations []FlagDeprecation
+	}{
+		{name: "no flags", kubeletVersion: "v1.30.0"},
+		{name: "current flags", kubeletVersion: "v1.30.0", flags: []string{"--node-labels=foo=bar", "--v=2"}},
+		{
+			name:                 "deprecated",
+			kubeletVersion:       "v1.26.3",
+			flags:                []string{"--container-runtime=remote"},
+			expectedDeprecations: []FlagDeprecation{{Flag: "container-runtime", Deprecated: "v1.24", Replacement: "--container-runtime-endpoint"}},
+		},
+		{
+			name:           "removed",
+			kubeletVersion: "v1.27.0",
+			flags:          []string{"--pod-infra-container-image", "example.com/pause:3.9", "--container-runtime=remote"},
+			expectedDeprecations: []FlagDeprecation{
+				{Flag: "container-runtime", Deprecated: "v1.24", Removed: "v1.27", Replacement: "--container-runtime-endpoint"},
+				{Flag: "pod-infra-container-image", Deprecated: "v1.27", Replacement: "containerd.sandboxImage"},
+			},
+		},
+		{name: "not yet deprecated", kubeletVersion: "v1.26.0", flags: []string{"--pod-infra-container-image=example.com/pause:3.9"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedDeprecations, GetFlagDeprecations(test.kubeletVersion, test.flags))
+		})
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	newConfig := func(kubeletVersion string, flags ...string) *api.NodeConfig {
+		return &api.NodeConfig{
+			Spec:   api.NodeConfigSpec{Kubelet: api.KubeletOptions{Flags: flags}},
+			Status: api.NodeConfigStatus{KubeletVersion: kubeletVersion},
+		}
+	}
+	assert.NoError(t, validateFlags(newConfig("v1.26.0", "--container-runtime=remote")))
+	assert.NoError(t, validateFlags(newConfig("v1.29.0", "--pod-infra-container-image=example.com/pause:3.9")))
+	err := validateFlags(newConfig("v1.29.0", "--container-runtime=remote"))
+	assert.ErrorContains(t, err, "kubelet v1.29.0 would fail to start")
+	assert.ErrorContains(t, err, "--container-runtime in kubelet.flags was removed in kubelet v1.27, use --container-runtime-endpoint instead")
+}
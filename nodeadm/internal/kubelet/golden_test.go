@@ -0,0 +1,44 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/golden"
+)
+
+func TestGolden(t *testing.T) {
+	previousCores := getMilliNumCores
+	t.Cleanup(func() { getMilliNumCores = previousCores })
+
+	for _, c := range golden.Cases() {
+		t.Run(c.Name, func(t *testing.T) {
+			getMilliNumCores = func() (int, error) { return c.CPUMillicores, nil }
+			k := &kubelet{
+				environment: make(map[string]string),
+				flags:       make(map[string]string),
+			}
+			kubeletConfig, err := k.GenerateKubeletConfig(context.Background(), c.NodeConfig)
+			assert.NoError(t, err)
+			config, err := generateMergedKubeletConfig(c.NodeConfig, kubeletConfig)
+			assert.NoError(t, err)
+			golden.Assert(t, c.Name+"/config.json", append(config, '\n'))
+
+			var flags strings.Builder
+			for _, name := range slices.Sorted(maps.Keys(k.flags)) {
+				fmt.Fprintf(&flags, "--%s=%s\n", name, k.flags[name])
+			}
+			golden.Assert(t, c.Name+"/flags", []byte(flags.String()))
+
+			kubeconfig, err := generateKubeconfig(c.NodeConfig)
+			assert.NoError(t, err)
+			golden.Assert(t, c.Name+"/kubeconfig", kubeconfig)
+		})
+	}
+}
@@ -3,13 +3,16 @@ package kubelet
 import (
 	"bytes"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"text/template"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
@@ -33,13 +36,8 @@ var (
 )
 
 func (k *kubelet) writeImageCredentialProviderConfig(cfg *api.NodeConfig) error {
-	// fallback default for image credential provider binary if not overridden
-	ecrCredentialProviderBinPath := path.Join(imageCredentialProviderRoot, "ecr-credential-provider")
-	if binPath, set := os.LookupEnv(ecrCredentialProviderBinPathEnvironmentName); set {
-		zap.L().Info("picked up image credential provider binary path from environment", zap.String("bin-path", binPath))
-		ecrCredentialProviderBinPath = binPath
-	}
-	if err := ensureCredentialProviderBinaryExists(ecrCredentialProviderBinPath); err != nil {
+	ecrCredentialProviderBinPath, err := GetECRCredentialProviderBinPath()
+	if err != nil {
 		return err
 	}
 
@@ -54,22 +52,104 @@ func (k *kubelet) writeImageCredentialProviderConfig(cfg *api.NodeConfig) error
 	return util.WriteFileWithDir(imageCredentialProviderConfigPath, config, imageCredentialProviderPerm)
 }
 
+// GetECRCredentialProviderBinPath returns the path of the ECR credential
+// provider binary, which may be overridden by the environment.
+func GetECRCredentialProviderBinPath() (string, error) {
+	// fallback default for image credential provider binary if not overridden
+	ecrCredentialProviderBinPath := path.Join(imageCredentialProviderRoot, "ecr-credential-provider")
+	if binPath, set := os.LookupEnv(ecrCredentialProviderBinPathEnvironmentName); set {
+		zap.L().Info("picked up image credential provider binary path from environment", zap.String("bin-path", binPath))
+		ecrCredentialProviderBinPath = binPath
+	}
+	if err := ensureCredentialProviderBinaryExists(ecrCredentialProviderBinPath); err != nil {
+		return "", err
+	}
+	return ecrCredentialProviderBinPath, nil
+}
+
+// GetCredentialProviderAPIVersion returns the version of the credential
+// provider API that is used with the given version of kubelet.
+func GetCredentialProviderAPIVersion(kubeletVersion string) string {
+	if semver.Compare(kubeletVersion, "v1.27.0") < 0 {
+		return "credentialprovider.kubelet.k8s.io/v1alpha1"
+	}
+	return "credentialprovider.kubelet.k8s.io/v1"
+}
+
+type credentialProviderRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Image      string `json:"image"`
+}
+
+type credentialProviderResponse struct {
+	Auth map[string]RegistryCredentials `json:"auth"`
+}
+
+// RegistryCredentials are the credentials of a registry returned by the ECR
+// credential provider.
+type RegistryCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// FetchRegistryCredentials runs the ECR credential provider that is used by
+// kubelet, so that credentials are obtained in the same way for every image
+// pull. The credentials are keyed by the registry they apply to.
+func FetchRegistryCredentials(kubeletVersion string, registry string) (map[string]RegistryCredentials, error) {
+	providerPath, err := GetECRCredentialProviderBinPath()
+	if err != nil {
+		return nil, err
+	}
+	return fetchRegistryCredentials(providerPath, GetCredentialProviderAPIVersion(kubeletVersion), registry)
+}
+
+func fetchRegistryCredentials(providerPath string, apiVersion string, registry string) (map[string]RegistryCredentials, error) {
+	request, err := json.Marshal(credentialProviderRequest{
+		APIVersion: apiVersion,
+		Kind:       "CredentialProviderRequest",
+		Image:      registry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.Command(providerPath)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	var response credentialProviderResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential provider response: %w", err)
+	}
+	if len(response.Auth) == 0 {
+		return nil, fmt.Errorf("credential provider returned no credentials")
+	}
+	return response.Auth, nil
+}
+
 type imageCredentialProviderTemplateVars struct {
 	ConfigApiVersion   string
 	ProviderApiVersion string
 	EcrProviderName    string
+	// MatchImages are the globs of the ECR registries of every partition
+	MatchImages []string
 }
 
 func generateImageCredentialProviderConfig(cfg *api.NodeConfig, ecrCredentialProviderBinPath string) ([]byte, error) {
 	templateVars := imageCredentialProviderTemplateVars{
-		EcrProviderName: filepath.Base(ecrCredentialProviderBinPath),
+		EcrProviderName:    filepath.Base(ecrCredentialProviderBinPath),
+		ProviderApiVersion: GetCredentialProviderAPIVersion(cfg.Status.KubeletVersion),
+		MatchImages:        ecr.RegistryHostGlobs(),
 	}
 	if semver.Compare(cfg.Status.KubeletVersion, "v1.27.0") < 0 {
 		templateVars.ConfigApiVersion = "kubelet.config.k8s.io/v1alpha1"
-		templateVars.ProviderApiVersion = "credentialprovider.kubelet.k8s.io/v1alpha1"
 	} else {
 		templateVars.ConfigApiVersion = "kubelet.config.k8s.io/v1"
-		templateVars.ProviderApiVersion = "credentialprovider.kubelet.k8s.io/v1"
 	}
 	var buf bytes.Buffer
 	if err := imageCredentialProviderTemplate.Execute(&buf, templateVars); err != nil {
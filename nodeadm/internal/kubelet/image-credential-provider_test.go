@@ -0,0 +1,54 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGenerateImageCredentialProviderConfig(t *testing.T) {
+	cfg := api.NodeConfig{Status: api.NodeConfigStatus{KubeletVersion: "v1.31.0"}}
+	data, err := generateImageCredentialProviderConfig(&cfg, "/etc/eks/image-credential-provider/ecr-credential-provider")
+	assert.NoError(t, err)
+	var config struct {
+		APIVersion string `json:"apiVersion"`
+		Providers  []struct {
+			Name        string   `json:"name"`
+			MatchImages []string `json:"matchImages"`
+		} `json:"providers"`
+	}
+	if assert.NoError(t, json.Unmarshal(data, &config)) && assert.Len(t, config.Providers, 1) {
+		assert.Equal(t, "kubelet.config.k8s.io/v1", config.APIVersion)
+		assert.Equal(t, "ecr-credential-provider", config.Providers[0].Name)
+		assert.Contains(t, config.Providers[0].MatchImages, "*.dkr.ecr.*.amazonaws.com.cn")
+		assert.Contains(t, config.Providers[0].MatchImages, "*.dkr-ecr-fips.*.on.aws")
+		assert.Contains(t, config.Providers[0].MatchImages, "*.dkr.ecr.*.sc2s.sgov.gov")
+	}
+}
+
+func TestFetchRegistryCredentials(t *testing.T) {
+	providerPath := filepath.Join(t.TempDir(), "ecr-credential-provider")
+	assert.NoError(t, os.WriteFile(providerPath, []byte(`#!/usr/bin/env bash
+cat > /dev/null
+echo '{"kind":"CredentialProviderResponse","apiVersion":"credentialprovider.kubelet.k8s.io/v1","cacheKeyType":"Registry","auth":{"123456789012.dkr.ecr.us-west-2.amazonaws.com":{"username":"AWS","password":"token"}}}'
+`), 0755))
+
+	credentials, err := fetchRegistryCredentials(providerPath, "credentialprovider.kubelet.k8s.io/v1", "123456789012.dkr.ecr.us-west-2.amazonaws.com")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]RegistryCredentials{
+		"123456789012.dkr.ecr.us-west-2.amazonaws.com": {Username: "AWS", Password: "token"},
+	}, credentials)
+}
+
+func TestFetchRegistryCredentialsFailure(t *testing.T) {
+	providerPath := filepath.Join(t.TempDir(), "ecr-credential-provider")
+	assert.NoError(t, os.WriteFile(providerPath, []byte("#!/usr/bin/env bash\nexit 1\n"), 0755))
+
+	_, err := fetchRegistryCredentials(providerPath, "credentialprovider.kubelet.k8s.io/v1", "123456789012.dkr.ecr.us-west-2.amazonaws.com")
+	assert.Error(t, err)
+}
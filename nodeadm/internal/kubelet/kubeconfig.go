@@ -2,51 +2,99 @@ package kubelet
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
-	"path"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"text/template"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/endpoints"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/partitions"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/token"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
 )
 
 const (
-	kubeconfigRoot          = "/var/lib/kubelet"
 	kubeconfigFile          = "kubeconfig"
 	kubeconfigBootstrapFile = "bootstrap-kubeconfig"
 	kubeconfigPerm          = 0644
+	// kubeconfigUser is the name of the user of the kubeconfig template
+	kubeconfigUser = "kubelet"
 )
 
 var (
 	//go:embed kubeconfig.template.yaml
 	kubeconfigTemplateData  string
 	kubeconfigTemplate      = template.Must(template.New(kubeconfigFile).Parse(kubeconfigTemplateData))
-	kubeconfigPath          = path.Join(kubeconfigRoot, kubeconfigFile)
-	kubeconfigBootstrapPath = path.Join(kubeconfigRoot, kubeconfigBootstrapFile)
+	kubeconfigBootstrapPath = filepath.Join(kubeconfigRoot, kubeconfigBootstrapFile)
+	// KubeconfigPath is the kubeconfig kubelet uses to authenticate to the
+	// cluster, which is also used by nodeadm to act on behalf of the node.
+	KubeconfigPath = filepath.Join(kubeconfigRoot, kubeconfigFile)
 )
 
-func (k *kubelet) writeKubeconfig(cfg *api.NodeConfig) error {
+func (k *kubelet) writeKubeconfig(ctx context.Context, cfg *api.NodeConfig) error {
+	if err := verifyCertificatePins(ctx, cfg); err != nil {
+		return err
+	}
+	if cfg.Spec.Kubelet.AuthenticationMode == api.KubeletAuthenticationModeClientCertificate {
+		// the certificate is provisioned ahead of nodeadm, so a missing file
+		// fails here rather than every time kubelet starts
+		for _, path := range []string{cfg.Spec.Kubelet.Authentication.ClientCertificate, cfg.Spec.Kubelet.Authentication.ClientKey} {
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("client certificate of kubelet is not provisioned: %w", err)
+			}
+		}
+	}
 	kubeconfig, err := generateKubeconfig(cfg)
 	if err != nil {
 		return err
 	}
+	// the exec credential plugin and the ECR credential provider inherit the
+	// environment of kubelet
+	maps.Copy(k.environment, getExecEnvironment(cfg))
 	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
 		// kubelet bootstrap kubeconfig uses aws-iam-authenticator with cluster id to authenticate to cluster
 		//   - if "aws eks describe-cluster" is bypassed, for local outpost, the value of CLUSTER_NAME parameter will be cluster id.
 		//   - otherwise, the cluster id will use the id returned by "aws eks describe-cluster".
 		k.flags["bootstrap-kubeconfig"] = kubeconfigBootstrapPath
-		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
+		return writeKubeletFile(cfg, kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
 	} else {
-		k.flags["kubeconfig"] = kubeconfigPath
-		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, kubeconfigPerm)
+		k.flags["kubeconfig"] = KubeconfigPath
+		return writeKubeletFile(cfg, KubeconfigPath, kubeconfig, kubeconfigPerm)
 	}
 }
 
+// getExecEnvironment returns the environment that the exec credential plugin
+// of the kubeconfig needs on top of that of nodeadm.
+func getExecEnvironment(cfg *api.NodeConfig) map[string]string {
+	environment := endpoints.GetEnvironment(cfg.Spec.Cluster.EndpointOverrides)
+	if cfg.IsHybrid() && cfg.Spec.Hybrid.IAMRolesAnywhere.RoleARN != "" {
+		// the exec credential plugin sources credentials from IAM Roles Anywhere
+		environment["AWS_CONFIG_FILE"] = system.HybridAWSConfigPath
+	}
+	if cfg.Spec.Instance.TrustStore.CertificateAuthorities != "" {
+		// the AWS CLI does not use the operating system's trust store by default
+		environment["AWS_CA_BUNDLE"] = system.TrustStoreBundlePath
+	}
+	return environment
+}
+
 type kubeconfigTemplateVars struct {
-	Cluster           string
-	Region            string
-	APIServerEndpoint string
-	CaCertPath        string
+	Cluster            string
+	Region             string
+	APIServerEndpoint  string
+	CaCertPath         string
+	TokenCache         *token.Options
+	AuthenticationMode api.KubeletAuthenticationMode
+	RoleARN            string
+	SessionName        string
+	ClientCertificate  string
+	ClientKey          string
 }
 
 func generateKubeconfig(cfg *api.NodeConfig) ([]byte, error) {
@@ -55,11 +103,35 @@ func generateKubeconfig(cfg *api.NodeConfig) ([]byte, error) {
 		cluster = cfg.Spec.Cluster.ID
 	}
 
+	authentication := cfg.Spec.Kubelet.Authentication
 	config := kubeconfigTemplateVars{
-		Cluster:           cluster,
-		Region:            cfg.Status.Instance.Region,
-		APIServerEndpoint: cfg.Spec.Cluster.APIServerEndpoint,
-		CaCertPath:        caCertificatePath,
+		Cluster:            cluster,
+		Region:             cfg.Status.Instance.Region,
+		APIServerEndpoint:  cfg.Spec.Cluster.APIServerEndpoint,
+		CaCertPath:         getKubeconfigCaPath(cfg),
+		AuthenticationMode: cfg.Spec.Kubelet.AuthenticationMode,
+		RoleARN:            authentication.RoleARN,
+		SessionName:        authentication.SessionName,
+		ClientCertificate:  authentication.ClientCertificate,
+		ClientKey:          authentication.ClientKey,
+	}
+	if config.AuthenticationMode == "" {
+		config.AuthenticationMode = api.KubeletAuthenticationModeAWSCLI
+	}
+	if config.RoleARN != "" {
+		// the role is assumed with the regional STS endpoint, which only
+		// accepts the roles of its own partition
+		roleARN, err := arn.Parse(config.RoleARN)
+		if err != nil {
+			return nil, err
+		}
+		if partition := partitions.ForRegion(config.Region).ID; roleARN.Partition != partition {
+			return nil, fmt.Errorf("role %s of kubelet is not in the partition %s of region %s", config.RoleARN, partition, config.Region)
+		}
+	}
+	if cfg.Spec.Kubelet.TokenCache.Enabled {
+		tokenOptions := token.NewOptions(cfg)
+		config.TokenCache = &tokenOptions
 	}
 
 	var buf bytes.Buffer
@@ -0,0 +1,89 @@
+package kubelet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestKubeconfigTokenCache(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Name:              "my-cluster",
+				APIServerEndpoint: "https://example.com",
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{Region: "us-west-2"},
+		},
+	}
+	kubeconfig, err := generateKubeconfig(&cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), "command: aws\n")
+	assert.Contains(t, string(kubeconfig), "- \"get-token\"\n")
+
+	cfg.Spec.Kubelet.TokenCache = api.TokenCacheOptions{
+		Enabled:  true,
+		LeadTime: &metav1.Duration{Duration: 3 * time.Minute},
+	}
+	kubeconfig, err = generateKubeconfig(&cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), "command: /usr/bin/nodeadm\n")
+	assert.Contains(t, string(kubeconfig), "- \"--lead-time\"\n          - \"3m0s\"\n")
+	assert.Contains(t, string(kubeconfig), "- \"--max-attempts\"\n          - \"10\"\n")
+	assert.NotContains(t, string(kubeconfig), "get-token")
+}
+
+func TestKubeconfigAuthenticationMode(t *testing.T) {
+	newConfig := func(region string, mode api.KubeletAuthenticationMode, authentication api.KubeletAuthenticationOptions) *api.NodeConfig {
+		return &api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Cluster: api.ClusterDetails{
+					Name:              "my-cluster",
+					APIServerEndpoint: "https://example.com",
+				},
+				Kubelet: api.KubeletOptions{AuthenticationMode: mode, Authentication: authentication},
+			},
+			Status: api.NodeConfigStatus{
+				Instance: api.InstanceDetails{Region: region},
+			},
+		}
+	}
+
+	kubeconfig, err := generateKubeconfig(newConfig("us-west-2", api.KubeletAuthenticationModeAWSCLI, api.KubeletAuthenticationOptions{
+		RoleARN: "arn:aws:iam::123456789012:role/kubelet",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), "command: aws\n")
+	assert.Contains(t, string(kubeconfig), "- \"--role-arn\"\n          - \"arn:aws:iam::123456789012:role/kubelet\"\n")
+
+	kubeconfig, err = generateKubeconfig(newConfig("cn-north-1", api.KubeletAuthenticationModeAWSIAMAuthenticator, api.KubeletAuthenticationOptions{
+		RoleARN:     "arn:aws-cn:iam::123456789012:role/kubelet",
+		SessionName: "i-1234567890abcdef0",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), "command: aws-iam-authenticator\n")
+	assert.Contains(t, string(kubeconfig), "- \"--cluster-id\"\n          - \"my-cluster\"\n")
+	assert.Contains(t, string(kubeconfig), "- \"--region\"\n          - \"cn-north-1\"\n")
+	assert.Contains(t, string(kubeconfig), "- \"--role\"\n          - \"arn:aws-cn:iam::123456789012:role/kubelet\"\n")
+	assert.Contains(t, string(kubeconfig), "- \"--session-name\"\n          - \"i-1234567890abcdef0\"\n")
+
+	// the role must be in the partition of the region
+	_, err = generateKubeconfig(newConfig("cn-north-1", api.KubeletAuthenticationModeAWSIAMAuthenticator, api.KubeletAuthenticationOptions{
+		RoleARN: "arn:aws:iam::123456789012:role/kubelet",
+	}))
+	assert.ErrorContains(t, err, "not in the partition aws-cn")
+
+	kubeconfig, err = generateKubeconfig(newConfig("us-west-2", api.KubeletAuthenticationModeClientCertificate, api.KubeletAuthenticationOptions{
+		ClientCertificate: "/etc/kubernetes/pki/kubelet.crt",
+		ClientKey:         "/etc/kubernetes/pki/kubelet.key",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), "    user:\n      client-certificate: /etc/kubernetes/pki/kubelet.crt\n      client-key: /etc/kubernetes/pki/kubelet.key\n")
+	assert.NotContains(t, string(kubeconfig), "exec:")
+}
@@ -0,0 +1,188 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"go.uber.org/zap"
+)
+
+// networkInterface is a network interface of the instance, as listed by IMDS.
+type networkInterface struct {
+	MAC          string
+	DeviceNumber int
+	// Name is the name of the interface on the node, which is empty when the
+	// interface has not been brought up by the OS yet.
+	Name string
+}
+
+// isExcluded returns whether the name or the MAC address of the interface
+// matches one of the patterns of networking.excludeInterfaces.
+func (iface networkInterface) isExcluded(patterns []string) bool {
+	for _, pattern := range patterns {
+		if iface.Name != "" {
+			if matched, _ := path.Match(pattern, iface.Name); matched {
+				return true
+			}
+		}
+		if matched, _ := path.Match(pattern, iface.MAC); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getSecondaryInterfaceIps returns the primary addresses of the secondary
+// network interface of the instance, for each IP family.
+func getSecondaryInterfaceIps(ctx context.Context, primaryMAC string, ipFamilies []api.IPFamily, excludes []string) ([]string, error) {
+	interfaces, err := getNetworkInterfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := selectSecondaryInterface(interfaces, primaryMAC, excludes)
+	if err != nil {
+		return nil, err
+	}
+	var nodeIps []string
+	for _, ipFamily := range ipFamilies {
+		property := "local-ipv4s"
+		if ipFamily == api.IPFamilyIPv6 {
+			property = "ipv6s"
+		}
+		addresses, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/%s", secondary.MAC, property)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s address of network interface %s: %w", ipFamily, secondary.MAC, err)
+		}
+		// the primary address is listed first
+		fields := strings.Fields(addresses)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("network interface %s has no %s address", secondary.MAC, ipFamily)
+		}
+		nodeIps = append(nodeIps, fields[0])
+	}
+	return nodeIps, nil
+}
+
+// checkPrimaryInterface fails when the primary network interface, whose
+// addresses are the node IP unless it is given explicitly, is excluded.
+func checkPrimaryInterface(primaryMAC string, excludes []string) error {
+	if len(excludes) == 0 {
+		return nil
+	}
+	primary := networkInterface{MAC: primaryMAC, Name: getInterfaceNames()[primaryMAC]}
+	if primary.isExcluded(excludes) {
+		return fmt.Errorf("the primary network interface %s is excluded by networking.excludeInterfaces, so kubelet.nodeIP.policy must be %s", primaryMAC, api.NodeIPPolicyExplicit)
+	}
+	return nil
+}
+
+// getNetworkInterfaces lists the network interfaces that are attached to the
+// instance, with their device numbers and their names on the node.
+func getNetworkInterfaces(ctx context.Context) ([]networkInterface, error) {
+	macs, err := imds.GetProperty(ctx, "network/interfaces/macs/")
+	if err != nil {
+		return nil, err
+	}
+	names := getInterfaceNames()
+	var interfaces []networkInterface
+	for _, mac := range strings.Fields(macs) {
+		mac = strings.TrimSuffix(mac, "/")
+		deviceNumber, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/device-number", mac)))
+		if err != nil {
+			return nil, err
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(deviceNumber))
+		if err != nil {
+			return nil, fmt.Errorf("invalid device number %q of network interface %s: %w", deviceNumber, mac, err)
+		}
+		interfaces = append(interfaces, networkInterface{MAC: mac, DeviceNumber: number, Name: names[mac]})
+	}
+	return interfaces, nil
+}
+
+// getInterfaceNames returns the names of the network interfaces of the node by
+// their MAC addresses. Interfaces are only matched by their MAC addresses when
+// their names cannot be listed.
+func getInterfaceNames() map[string]string {
+	names := make(map[string]string)
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		zap.L().Warn("Failed to list the network interfaces of the node", zap.Error(err))
+		return names
+	}
+	for _, iface := range ifaces {
+		if mac := iface.HardwareAddr.String(); mac != "" {
+			names[mac] = iface.Name
+		}
+	}
+	return names
+}
+
+// getExcludedInterfacePods returns the pods that the network interfaces
+// excluded by networking.excludeInterfaces take from the maxPods calculated for
+// the instance type, since pods are not given their addresses. Failures are
+// logged, and no pods are subtracted.
+func getExcludedInterfacePods(ctx context.Context, cfg *api.NodeConfig) int32 {
+	if cfg.Spec.Offline {
+		zap.L().Warn("Network interfaces excluded by networking.excludeInterfaces are not subtracted from maxPods in offline mode, since the instance type cannot be described")
+		return 0
+	}
+	interfaces, err := getNetworkInterfaces(ctx)
+	if err != nil {
+		zap.L().Warn("Failed to list network interfaces excluded from maxPods", zap.Error(err))
+		return 0
+	}
+	eniInfo, err := getEniInfo(ctx, cfg.Status.Instance.Region, cfg.Status.Instance.Type)
+	if err != nil {
+		zap.L().Warn("Failed to describe the IPv4 addresses per network interface of the instance type", zap.Error(err))
+		return 0
+	}
+	return excludedInterfacePods(interfaces, cfg.Status.Instance.MAC, cfg.Spec.Networking.ExcludeInterfaces, eniInfo.PodsPerEniCount)
+}
+
+// excludedInterfacePods returns the pods of the excluded secondary interfaces,
+// one for each of their IPv4 addresses but the primary, as counted by
+// CalcMaxPods. The primary interface is never excluded from pods.
+func excludedInterfacePods(interfaces []networkInterface, primaryMAC string, excludes []string, ipv4PerInterface int32) int32 {
+	var pods int32
+	for _, iface := range interfaces {
+		if iface.MAC != primaryMAC && iface.isExcluded(excludes) {
+			zap.L().Info("Excluding network interface from maxPods", zap.String("mac", iface.MAC), zap.String("name", iface.Name))
+			pods += ipv4PerInterface - 1
+		}
+	}
+	return pods
+}
+
+// selectSecondaryInterface returns the network interface with the lowest
+// device number other than the primary interface and the excluded interfaces.
+func selectSecondaryInterface(interfaces []networkInterface, primaryMAC string, excludes []string) (networkInterface, error) {
+	var candidates []networkInterface
+	for _, iface := range interfaces {
+		if iface.MAC == primaryMAC {
+			continue
+		}
+		if iface.isExcluded(excludes) {
+			zap.L().Info("Excluding network interface from the node IP", zap.String("mac", iface.MAC), zap.String("name", iface.Name))
+			continue
+		}
+		candidates = append(candidates, iface)
+	}
+	if len(candidates) == 0 {
+		return networkInterface{}, fmt.Errorf("kubelet.nodeIP.policy is %s, but no network interface is attached besides the primary %s that is not excluded by networking.excludeInterfaces", api.NodeIPPolicySecondaryENI, primaryMAC)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].DeviceNumber != candidates[j].DeviceNumber {
+			return candidates[i].DeviceNumber < candidates[j].DeviceNumber
+		}
+		return candidates[i].MAC < candidates[j].MAC
+	})
+	return candidates[0], nil
+}
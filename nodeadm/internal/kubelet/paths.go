@@ -0,0 +1,18 @@
+//go:build !windows
+
+package kubelet
+
+const (
+	kubeletConfigRoot = "/etc/kubernetes/kubelet"
+	kubeconfigRoot    = "/var/lib/kubelet"
+	// kubeletRootDir is on the filesystem whose usage kubelet evicts pods by.
+	kubeletRootDir    = "/var/lib/kubelet"
+	caCertificatePath = "/etc/kubernetes/pki/ca.crt"
+	// caBundlePath holds the cluster certificate authority along with the
+	// additional certificate authorities of the trust store, and is only used
+	// to verify the kube-apiserver. kubelet continues to authenticate clients
+	// with the cluster certificate authority alone.
+	caBundlePath = "/etc/kubernetes/pki/ca-bundle.crt"
+	// CertificateDir holds the client and serving certificates of kubelet.
+	CertificateDir = "/var/lib/kubelet/pki"
+)
@@ -0,0 +1,16 @@
+package kubelet
+
+// the paths follow the layout of the EKS optimized Windows AMIs.
+const (
+	kubeletConfigRoot = `C:\ProgramData\kubernetes\kubelet`
+	kubeconfigRoot    = `C:\ProgramData\kubernetes`
+	// kubeletRootDir is on the filesystem whose usage kubelet evicts pods by.
+	kubeletRootDir    = `C:\var\lib\kubelet`
+	caCertificatePath = `C:\ProgramData\kubernetes\pki\ca.crt`
+	// caBundlePath holds the cluster certificate authority along with the
+	// additional certificate authorities of the trust store, and is only used
+	// to verify the kube-apiserver.
+	caBundlePath = `C:\ProgramData\kubernetes\pki\ca-bundle.crt`
+	// CertificateDir holds the client and serving certificates of kubelet.
+	CertificateDir = `C:\var\lib\kubelet\pki`
+)
@@ -0,0 +1,33 @@
+package kubelet
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+const defaultReadinessTimeout = 10 * time.Minute
+
+// waitForNodeReady waits for the node to register and report Ready, so that
+// a successful exit of nodeadm confirms that the node joined the cluster.
+func waitForNodeReady(ctx context.Context, cfg *api.NodeConfig) error {
+	timeout := defaultReadinessTimeout
+	if configured := cfg.Spec.Kubelet.Readiness.Timeout; configured != nil {
+		timeout = configured.Duration
+	}
+	client, err := node.NewClient(KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	nodeName := GetNodeName(cfg)
+	zap.L().Info("Waiting for node to be Ready..", zap.String("node", nodeName), zap.Duration("timeout", timeout))
+	if err := node.WaitForReady(ctx, client, nodeName, timeout); err != nil {
+		return err
+	}
+	zap.L().Info("Node is Ready", zap.String("node", nodeName))
+	return nil
+}
@@ -0,0 +1,104 @@
+package kubelet
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+// getMilliNumCores and getMemoryMebibytes are replaced in tests, which run on
+// hosts of any size
+var (
+	getMilliNumCores   = system.GetMilliNumCores
+	getMemoryMebibytes = system.GetMemoryMebibytes
+)
+
+// nodeCapacity is what the resources that are reserved for kubelet and the
+// container runtime are computed from.
+type nodeCapacity struct {
+	CPUMillicores   int
+	MemoryMebibytes int
+	MaxPods         int32
+}
+
+// reservedResourcesFormula returns the CPU in millicores and the memory in
+// mebibytes that are reserved for kubelet and the container runtime.
+type reservedResourcesFormula func(capacity nodeCapacity) (cpuMillicores int, memoryMebibytes int)
+
+var reservedResourcesFormulas = map[api.ReservedResourcesPolicy]reservedResourcesFormula{
+	api.ReservedResourcesPolicyEKS: eksReservedResources,
+	api.ReservedResourcesPolicyGKE: gkeReservedResources,
+}
+
+// getNodeCapacity reads the capacity of the node that the formula of the
+// policy needs. A resource that cannot be read is logged, and counted as none.
+func getNodeCapacity(policy api.ReservedResourcesPolicy, maxPods int32) nodeCapacity {
+	capacity := nodeCapacity{MaxPods: maxPods}
+	var err error
+	if capacity.CPUMillicores, err = getMilliNumCores(); err != nil {
+		zap.L().Error("Error found when GetMilliNumCores", zap.Error(err))
+	}
+	if policy == api.ReservedResourcesPolicyGKE {
+		if capacity.MemoryMebibytes, err = getMemoryMebibytes(); err != nil {
+			zap.L().Error("Error found when GetMemoryMebibytes", zap.Error(err))
+		}
+	}
+	return capacity
+}
+
+// eksReservedResources reserves memory by the maximum number of pods of the
+// node, which the memory that kubelet and the container runtime use grows
+// with.
+func eksReservedResources(capacity nodeCapacity) (int, int) {
+	return getCPUMillicoresToReserve(capacity.CPUMillicores), 11*int(capacity.MaxPods) + 255
+}
+
+// gkeReservedResources reserves memory by the memory of the node, as GKE does.
+// It falls back to the formula of EKS when the memory is not known.
+func gkeReservedResources(capacity nodeCapacity) (int, int) {
+	cpuMillicores, memoryMebibytes := eksReservedResources(capacity)
+	total := capacity.MemoryMebibytes
+	if total == 0 {
+		return cpuMillicores, memoryMebibytes
+	}
+	if total < 1024 {
+		return cpuMillicores, 255
+	}
+	memoryRanges := []int{0, 4096, 8192, 16384, 131072, max(total, 131072)}
+	memoryPercentageReservedForRanges := []int{2500, 2000, 1000, 600, 200}
+	memoryMebibytes = 0
+	for i, percentage := range memoryPercentageReservedForRanges {
+		memoryMebibytes += getResourceToReserveInRange(total, memoryRanges[i], memoryRanges[i+1], percentage)
+	}
+	return cpuMillicores, memoryMebibytes
+}
+
+// getCPUMillicoresToReserve reserves 6% of the first CPU, 1% of the second,
+// 0.5% of the third and fourth, and 0.25% of the others.
+func getCPUMillicoresToReserve(totalCPUMillicores int) int {
+	cpuRanges := []int{0, 1000, 2000, 4000, max(totalCPUMillicores, 4000)}
+	cpuPercentageReservedForRanges := []int{600, 100, 50, 25}
+	cpuToReserve := 0
+
+	for i, percentageToReserveForRange := range cpuPercentageReservedForRanges {
+		startRange := cpuRanges[i]
+		endRange := cpuRanges[i+1]
+		cpuToReserve += getResourceToReserveInRange(totalCPUMillicores, startRange, endRange, percentageToReserveForRange)
+	}
+
+	return cpuToReserve
+}
+
+// getResourceToReserveInRange calculates the resources to reserve for a given
+// range, with the percentage in hundredths of a percent.
+func getResourceToReserveInRange(total, startRange, endRange, percentage int) int {
+	if total <= startRange {
+		return 0
+	}
+	reserved := total
+	if reserved > endRange {
+		reserved = endRange
+	}
+	return (reserved - startRange) * percentage / 10000
+}
@@ -0,0 +1,103 @@
+package kubelet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestReservedResourcesFormulas(t *testing.T) {
+	var tests = []struct {
+		name                    string
+		policy                  api.ReservedResourcesPolicy
+		capacity                nodeCapacity
+		expectedCPUMillicores   int
+		expectedMemoryMebibytes int
+	}{
+		{
+			name:                    "eks m5.large",
+			policy:                  api.ReservedResourcesPolicyEKS,
+			capacity:                nodeCapacity{CPUMillicores: 2000, MemoryMebibytes: 7680, MaxPods: 29},
+			expectedCPUMillicores:   70,
+			expectedMemoryMebibytes: 574,
+		},
+		{
+			name:                    "eks m5.24xlarge",
+			policy:                  api.ReservedResourcesPolicyEKS,
+			capacity:                nodeCapacity{CPUMillicores: 96000, MemoryMebibytes: 393216, MaxPods: 737},
+			expectedCPUMillicores:   310,
+			expectedMemoryMebibytes: 8362,
+		},
+		{
+			name:                    "gke m5.large",
+			policy:                  api.ReservedResourcesPolicyGKE,
+			capacity:                nodeCapacity{CPUMillicores: 2000, MemoryMebibytes: 7680, MaxPods: 29},
+			expectedCPUMillicores:   70,
+			expectedMemoryMebibytes: 1740,
+		},
+		{
+			name:                    "gke m5.24xlarge",
+			policy:                  api.ReservedResourcesPolicyGKE,
+			capacity:                nodeCapacity{CPUMillicores: 96000, MemoryMebibytes: 393216, MaxPods: 737},
+			expectedCPUMillicores:   310,
+			expectedMemoryMebibytes: 14785,
+		},
+		{
+			name:                    "gke below 1Gi",
+			policy:                  api.ReservedResourcesPolicyGKE,
+			capacity:                nodeCapacity{CPUMillicores: 1000, MemoryMebibytes: 512, MaxPods: 4},
+			expectedCPUMillicores:   60,
+			expectedMemoryMebibytes: 255,
+		},
+		{
+			name:                    "gke without memory",
+			policy:                  api.ReservedResourcesPolicyGKE,
+			capacity:                nodeCapacity{CPUMillicores: 2000, MaxPods: 29},
+			expectedCPUMillicores:   70,
+			expectedMemoryMebibytes: 574,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cpuMillicores, memoryMebibytes := reservedResourcesFormulas[test.policy](test.capacity)
+			assert.Equal(t, test.expectedCPUMillicores, cpuMillicores)
+			assert.Equal(t, test.expectedMemoryMebibytes, memoryMebibytes)
+		})
+	}
+}
+
+func TestDefaultReservedResourcesPolicy(t *testing.T) {
+	previousCores, previousMemory := getMilliNumCores, getMemoryMebibytes
+	getMilliNumCores = func() (int, error) { return 2000, nil }
+	getMemoryMebibytes = func() (int, error) { return 7680, nil }
+	t.Cleanup(func() { getMilliNumCores, getMemoryMebibytes = previousCores, previousMemory })
+
+	for policy, expectedMemory := range map[api.ReservedResourcesPolicy]string{
+		"":                             "574Mi",
+		api.ReservedResourcesPolicyEKS: "574Mi",
+		api.ReservedResourcesPolicyGKE: "1740Mi",
+	} {
+		kubeletConfig := defaultKubeletSubConfig()
+		nodeConfig := api.NodeConfig{
+			Spec:   api.NodeConfigSpec{Kubelet: api.KubeletOptions{ReservedResourcesPolicy: policy}},
+			Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Type: "m5.large"}},
+		}
+		kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+		assert.Equal(t, "70m", kubeletConfig.KubeReserved["cpu"], policy)
+		assert.Equal(t, expectedMemory, kubeletConfig.KubeReserved["memory"], policy)
+	}
+
+	// the memory is not known, so the formula of EKS is used
+	getMemoryMebibytes = func() (int, error) { return 0, errors.New("no meminfo") }
+	kubeletConfig := defaultKubeletSubConfig()
+	nodeConfig := api.NodeConfig{
+		Spec:   api.NodeConfigSpec{Kubelet: api.KubeletOptions{ReservedResourcesPolicy: api.ReservedResourcesPolicyGKE}},
+		Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Type: "m5.large"}},
+	}
+	kubeletConfig.withDefaultReservedResources(context.Background(), &nodeConfig)
+	assert.Equal(t, "574Mi", kubeletConfig.KubeReserved["memory"])
+}
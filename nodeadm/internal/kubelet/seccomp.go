@@ -0,0 +1,105 @@
+package kubelet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const seccompProfilePerm = 0644
+
+// withSeccompDefault runs the containers that do not set a seccomp profile
+// with the RuntimeDefault profile. It is set along with a default profile of
+// the config, which containerd applies to the containers that reach it without
+// a profile, so that pods are never unconfined when that profile is missing.
+func (ksc *kubeletConfig) withSeccompDefault(cfg *api.NodeConfig) {
+	if cfg.Spec.Security.SeccompDefault.Enabled {
+		ksc.SeccompDefault = ptr.To(true)
+	}
+}
+
+// writeSeccompProfiles writes the seccomp profiles of the config, which must
+// exist before containers that use them are created, and removes those that
+// are no longer in it.
+func writeSeccompProfiles(ctx context.Context, cfg *api.NodeConfig) error {
+	var paths []string
+	for _, profile := range cfg.Spec.Security.SeccompDefault.Profiles {
+		paths = append(paths, profile.GetPath())
+	}
+	if err := removeStaleSeccompProfiles(api.SeccompProfileDir, paths); err != nil {
+		return err
+	}
+	for _, profile := range cfg.Spec.Security.SeccompDefault.Profiles {
+		nameField := zap.String("name", profile.Name)
+		data := []byte(profile.Profile)
+		if profile.URL != "" {
+			zap.L().Info("Downloading seccomp profile..", nameField, zap.String("url", profile.URL))
+			var err error
+			if data, err = fetchArtifact(ctx, cfg, profile.URL); err != nil {
+				return fmt.Errorf("failed to download seccomp profile %s: %w", profile.Name, err)
+			}
+		}
+		if err := verifySeccompProfile(profile, data); err != nil {
+			return err
+		}
+		path := profile.GetPath()
+		zap.L().Info("Writing seccomp profile..", nameField, zap.String("path", path))
+		if err := util.WriteFileWithDir(path, data, seccompProfilePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleSeccompProfiles removes the profiles of the directory that are
+// not at one of the paths, with util.RemoveFile so that they are restored if
+// the configuration of the daemons is rolled back.
+func removeStaleSeccompProfiles(dir string, paths []string) error {
+	stale, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if slices.Contains(paths, path) {
+			continue
+		}
+		zap.L().Info("Removing stale seccomp profile..", zap.String("path", path))
+		if err := util.RemoveFile(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySeccompProfile checks the checksum of a profile, when it has one, and
+// that it is a seccomp profile, which the OCI runtime would otherwise reject
+// when a container is created with it.
+func verifySeccompProfile(profile api.SeccompProfile, data []byte) error {
+	if profile.SHA256 != "" {
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, profile.SHA256) {
+			return fmt.Errorf("checksum of seccomp profile %s is %s, expected %s", profile.Name, actual, profile.SHA256)
+		}
+	}
+	var object struct {
+		DefaultAction string `json:"defaultAction"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("seccomp profile %s is invalid: %w", profile.Name, err)
+	}
+	if !strings.HasPrefix(object.DefaultAction, "SCMP_ACT_") {
+		return fmt.Errorf("seccomp profile %s has no defaultAction", profile.Name)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package kubelet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+func TestVerifySeccompProfile(t *testing.T) {
+	const profile = `{"defaultAction": "SCMP_ACT_LOG", "architectures": ["SCMP_ARCH_X86_64"]}`
+	sum := sha256.Sum256([]byte(profile))
+	checksum := hex.EncodeToString(sum[:])
+
+	var tests = []struct {
+		name      string
+		profile   api.SeccompProfile
+		data      string
+		expectErr bool
+	}{
+		{name: "without checksum", profile: api.SeccompProfile{Name: "audit"}, data: profile},
+		{name: "with checksum", profile: api.SeccompProfile{Name: "audit", SHA256: checksum}, data: profile},
+		{name: "checksum mismatch", profile: api.SeccompProfile{Name: "audit", SHA256: checksum}, data: `{"defaultAction": "SCMP_ACT_ALLOW"}`, expectErr: true},
+		{name: "no default action", profile: api.SeccompProfile{Name: "audit"}, data: `{"syscalls": []}`, expectErr: true},
+		{name: "invalid json", profile: api.SeccompProfile{Name: "audit"}, data: `{"defaultAction": `, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifySeccompProfile(test.profile, []byte(test.data))
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithSeccompDefault(t *testing.T) {
+	var tests = []struct {
+		name     string
+		seccomp  api.SeccompDefaultOptions
+		expected *bool
+	}{
+		{name: "disabled"},
+		{name: "enabled", seccomp: api.SeccompDefaultOptions{Enabled: true}, expected: ptr.To(true)},
+		// containerd applies the default profile to containers that reach it
+		// without one, but pods are never left unconfined
+		{name: "default profile", seccomp: api.SeccompDefaultOptions{Enabled: true, DefaultProfile: "audit"}, expected: ptr.To(true)},
+		{name: "profiles only", seccomp: api.SeccompDefaultOptions{Profiles: []api.SeccompProfile{{Name: "audit"}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ksc kubeletConfig
+			ksc.withSeccompDefault(&api.NodeConfig{Spec: api.NodeConfigSpec{Security: api.SecurityOptions{SeccompDefault: test.seccomp}}})
+			assert.Equal(t, test.expected, ksc.SeccompDefault)
+		})
+	}
+}
+
+func TestRemoveStaleSeccompProfiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"audit.json", "stale.json", "README"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644))
+	}
+	assert.NoError(t, removeStaleSeccompProfiles(dir, []string{filepath.Join(dir, "audit.json")}))
+	assert.FileExists(t, filepath.Join(dir, "audit.json"))
+	assert.FileExists(t, filepath.Join(dir, "README"))
+	assert.NoFileExists(t, filepath.Join(dir, "stale.json"))
+
+	// the removed profile is recorded so that it can be restored
+	snapshots := util.FileSnapshots([]string{filepath.Join(dir, "stale.json")})
+	if assert.Len(t, snapshots, 1) {
+		assert.True(t, snapshots[0].Existed)
+		assert.NoError(t, snapshots[0].Restore())
+		assert.FileExists(t, filepath.Join(dir, "stale.json"))
+	}
+}
@@ -0,0 +1,136 @@
+package kubelet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+)
+
+const (
+	// StaticPodPath is the directory of the manifests of the static pods that
+	// kubelet runs.
+	StaticPodPath = "/etc/kubernetes/manifests"
+	staticPodPerm = 0644
+	// staticPodsStatePath records the manifests that nodeadm wrote, so that
+	// those of the static pods that are removed from the config are deleted,
+	// while the manifests that others put in the directory are left alone.
+	staticPodsStatePath = "/var/lib/nodeadm/static-pods.json"
+	staticPodsStatePerm = 0600
+)
+
+// writeStaticPods writes the manifests of the static pods of the config, so
+// that kubelet starts them as soon as it is running, before the CNI is ready,
+// and removes those that nodeadm wrote for static pods that are no longer in
+// it.
+func writeStaticPods(ctx context.Context, cfg *api.NodeConfig) error {
+	var paths []string
+	for _, pod := range cfg.Spec.Kubelet.StaticPods {
+		paths = append(paths, filepath.Join(StaticPodPath, pod.Name+".yaml"))
+	}
+	if err := removeStaleStaticPods(staticPodsStatePath, paths); err != nil {
+		return err
+	}
+	for _, pod := range cfg.Spec.Kubelet.StaticPods {
+		nameField := zap.String("name", pod.Name)
+		manifest := []byte(pod.Manifest)
+		if pod.URL != "" {
+			zap.L().Info("Downloading static pod manifest..", nameField, zap.String("url", pod.URL))
+			var err error
+			if manifest, err = fetchArtifact(ctx, cfg, pod.URL); err != nil {
+				return fmt.Errorf("failed to download manifest of static pod %s: %w", pod.Name, err)
+			}
+		}
+		if err := verifyStaticPodManifest(pod, manifest); err != nil {
+			return err
+		}
+		path := filepath.Join(StaticPodPath, pod.Name+".yaml")
+		zap.L().Info("Writing static pod manifest..", nameField, zap.String("path", path))
+		if err := util.WriteFileWithDir(path, manifest, staticPodPerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleStaticPods removes the manifests that were recorded in the state
+// and are not at one of the paths, with util.RemoveFile so that they are
+// restored if the configuration of the daemons is rolled back, and records
+// the paths in their place.
+func removeStaleStaticPods(statePath string, paths []string) error {
+	var written []string
+	data, err := os.ReadFile(statePath)
+	if err == nil {
+		if err := json.Unmarshal(data, &written); err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", statePath, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, path := range written {
+		if slices.Contains(paths, path) {
+			continue
+		}
+		zap.L().Info("Removing stale static pod manifest..", zap.String("path", path))
+		if err := util.RemoveFile(path); err != nil {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		return util.RemoveFile(statePath)
+	}
+	if data, err = json.Marshal(paths); err != nil {
+		return err
+	}
+	return util.WriteFileWithDir(statePath, data, staticPodsStatePerm)
+}
+
+// verifyStaticPodManifest checks the checksum of a manifest, when it has one,
+// and that it is the manifest of a pod.
+func verifyStaticPodManifest(pod api.StaticPod, manifest []byte) error {
+	if pod.SHA256 != "" {
+		sum := sha256.Sum256(manifest)
+		if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, pod.SHA256) {
+			return fmt.Errorf("checksum of the manifest of static pod %s is %s, expected %s", pod.Name, actual, pod.SHA256)
+		}
+	}
+	var object v1.Pod
+	if err := yaml.Unmarshal(manifest, &object); err != nil {
+		return fmt.Errorf("manifest of static pod %s is invalid: %w", pod.Name, err)
+	}
+	if object.Kind != "Pod" {
+		return fmt.Errorf("manifest of static pod %s is a %q, not a Pod", pod.Name, object.Kind)
+	}
+	return nil
+}
+
+// fetchArtifact downloads a static pod manifest or a seccomp profile from S3
+// or over HTTPS, retrying transient failures.
+func fetchArtifact(ctx context.Context, cfg *api.NodeConfig, artifactURL string) ([]byte, error) {
+	opts := []download.Option{download.WithRegion(cfg.Status.Instance.Region)}
+	if cfg.Spec.Instance.TrustStore.CertificateAuthorities != "" {
+		// the trust store is not updated until the run phase, so the
+		// certificate authorities are added to the client directly.
+		roots, err := system.NewCertPool(&cfg.Spec.Instance.TrustStore)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, download.WithRootCAs(roots))
+	}
+	return download.Fetch(ctx, artifactURL, opts...)
+}
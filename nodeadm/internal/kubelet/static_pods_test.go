@@ -0,0 +1,86 @@
+package kubelet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestVerifyStaticPodManifest(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: agent
+spec:
+  hostNetwork: true
+  containers:
+  - name: agent
+    image: public.ecr.aws/example/agent:v1
+`
+	sum := sha256.Sum256([]byte(manifest))
+	checksum := hex.EncodeToString(sum[:])
+
+	var tests = []struct {
+		name      string
+		pod       api.StaticPod
+		manifest  string
+		expectErr bool
+	}{
+		{name: "without checksum", pod: api.StaticPod{Name: "agent"}, manifest: manifest},
+		{name: "with checksum", pod: api.StaticPod{Name: "agent", SHA256: checksum}, manifest: manifest},
+		{name: "uppercase checksum", pod: api.StaticPod{Name: "agent", SHA256: strings.ToUpper(checksum)}, manifest: manifest},
+		{name: "checksum mismatch", pod: api.StaticPod{Name: "agent", SHA256: checksum}, manifest: manifest + "  hostPID: true\n", expectErr: true},
+		{name: "not a pod", pod: api.StaticPod{Name: "agent"}, manifest: "apiVersion: apps/v1\nkind: DaemonSet\n", expectErr: true},
+		{name: "invalid yaml", pod: api.StaticPod{Name: "agent"}, manifest: "kind: [Pod", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyStaticPodManifest(test.pod, []byte(test.manifest))
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithStaticPods(t *testing.T) {
+	var ksc kubeletConfig
+	ksc.withStaticPods(&api.NodeConfig{})
+	assert.Empty(t, ksc.StaticPodPath)
+
+	ksc.withStaticPods(&api.NodeConfig{Spec: api.NodeConfigSpec{Kubelet: api.KubeletOptions{
+		StaticPods: []api.StaticPod{{Name: "agent", Manifest: "kind: Pod"}},
+	}}})
+	assert.Equal(t, StaticPodPath, ksc.StaticPodPath)
+}
+
+func TestRemoveStaleStaticPods(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state", "static-pods.json")
+	agent, proxy, other := filepath.Join(dir, "agent.yaml"), filepath.Join(dir, "proxy.yaml"), filepath.Join(dir, "other.yaml")
+	for _, path := range []string{agent, proxy, other} {
+		assert.NoError(t, os.WriteFile(path, []byte("kind: Pod"), 0644))
+	}
+
+	assert.NoError(t, removeStaleStaticPods(statePath, []string{agent, proxy}))
+	assert.FileExists(t, proxy)
+	// proxy is no longer in the config, and other was not written by nodeadm
+	assert.NoError(t, removeStaleStaticPods(statePath, []string{agent}))
+	assert.FileExists(t, agent)
+	assert.NoFileExists(t, proxy)
+	assert.FileExists(t, other)
+	assert.NoError(t, removeStaleStaticPods(statePath, nil))
+	assert.NoFileExists(t, agent)
+	assert.NoFileExists(t, statePath)
+	assert.FileExists(t, other)
+}
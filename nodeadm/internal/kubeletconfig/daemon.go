@@ -0,0 +1,51 @@
+package kubeletconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const (
+	KubeletConfigDaemonName = "nodeadm-kubelet-config"
+
+	environmentFilePath = "/etc/eks/nodeadm/kubelet-config/environment"
+	argsEnvironmentName = "NODEADM_KUBELET_CONFIG_ARGS"
+)
+
+var (
+	_ daemon.Daemon      = &kubeletConfig{}
+	_ daemon.Restartable = &kubeletConfig{}
+)
+
+// kubeletConfig manages the unit that runs `nodeadm kubelet-config`.
+type kubeletConfig struct {
+	daemon.EnvironmentUnit
+}
+
+func NewKubeletConfigDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &kubeletConfig{
+		EnvironmentUnit: daemon.NewEnvironmentUnit(daemonManager, KubeletConfigDaemonName, environmentFilePath, argsEnvironmentName),
+	}
+}
+
+func (k *kubeletConfig) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	remoteConfig := cfg.Spec.Kubelet.RemoteConfig
+	if remoteConfig.Source == "" {
+		return k.Disable()
+	}
+	return k.Enable(generateArgs(remoteConfig))
+}
+
+func generateArgs(remoteConfig api.KubeletRemoteConfigOptions) string {
+	args := fmt.Sprintf("--source=%s", remoteConfig.Source)
+	if remoteConfig.Interval != nil {
+		args += fmt.Sprintf(" --interval=%s", remoteConfig.Interval.Duration)
+	}
+	if remoteConfig.MinRestartInterval != nil {
+		args += fmt.Sprintf(" --min-restart-interval=%s", remoteConfig.MinRestartInterval.Duration)
+	}
+	return args
+}
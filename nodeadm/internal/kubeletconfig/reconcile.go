@@ -0,0 +1,315 @@
+package kubeletconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/signature"
+)
+
+const (
+	DefaultInterval           = 5 * time.Minute
+	DefaultMinRestartInterval = 15 * time.Minute
+
+	// kubelet is watched for this long after it is restarted with a new
+	// overlay, and is crash looping when it is seen not running at least
+	// crashLoopThreshold times.
+	stabilizationWindow = 2 * time.Minute
+	statusPollInterval  = 5 * time.Second
+	crashLoopThreshold  = 3
+
+	kubeletConfigKind = "KubeletConfiguration"
+	dropInPerm        = 0644
+
+	// the overlay is fetched up to this fraction of the interval later, so
+	// that the nodes of a fleet do not fetch it and restart kubelet in step
+	intervalJitter = 0.1
+
+	// signatureSuffix is appended to the source of the overlay to locate its
+	// detached signature, as for NodeConfigs.
+	signatureSuffix = ".sig"
+
+	// rejectedDigestPath is where the digest of the overlay that was rolled
+	// back is kept, so that it is not applied again after a restart of the
+	// reconciler or a reboot.
+	rejectedDigestPath = "/var/lib/nodeadm/kubelet-config/rejected-digest"
+	rejectedDigestPerm = 0644
+)
+
+type Config struct {
+	// Source is the `s3://` or `https://` URL of the overlay.
+	Source string
+	// MinRestartInterval is the minimum amount of time between two restarts
+	// of kubelet for changes of the overlay.
+	MinRestartInterval time.Duration
+}
+
+// Status summarizes what the reconciler did, for the result of the command.
+type Status struct {
+	// Digest is the SHA-256 digest of the drop-in that is applied.
+	Digest     string    `json:"digest,omitempty"`
+	Applied    int       `json:"applied"`
+	RolledBack int       `json:"rolledBack"`
+	LastFetch  time.Time `json:"lastFetch,omitempty"`
+}
+
+// Reconciler keeps the kubelet config overlay at a URL applied to the node as
+// a drop-in of the kubelet config. kubelet reads its config when it starts, so
+// it is restarted for each change of the overlay, which is rate limited so
+// that a fleet is not restarted by every edit of the overlay. An overlay that
+// kubelet cannot run with is rolled back, and is not applied again until it
+// changes. When a signing key is installed for NodeConfigs, the overlay must
+// be signed by it too.
+type Reconciler struct {
+	config             Config
+	daemonManager      daemon.DaemonManager
+	dropInPath         string
+	rejectedDigestPath string
+
+	fetch          func(ctx context.Context, url string) ([]byte, error)
+	loadSigningKey func() (crypto.PublicKey, error)
+	now            func() time.Time
+	sleep          func(ctx context.Context, d time.Duration) error
+
+	status      Status
+	lastRestart time.Time
+}
+
+func NewReconciler(daemonManager daemon.DaemonManager, config Config) *Reconciler {
+	if config.MinRestartInterval == 0 {
+		config.MinRestartInterval = DefaultMinRestartInterval
+	}
+	return &Reconciler{
+		config:             config,
+		daemonManager:      daemonManager,
+		dropInPath:         kubelet.RemoteConfigDropInPath,
+		rejectedDigestPath: rejectedDigestPath,
+		fetch: func(ctx context.Context, url string) ([]byte, error) {
+			return download.Fetch(ctx, url)
+		},
+		loadSigningKey: configprovider.LoadSigningKey,
+		now:            time.Now,
+		sleep:          sleep,
+	}
+}
+
+// Status returns what the reconciler did so far.
+func (r *Reconciler) Status() Status {
+	return r.status
+}
+
+// Run reconciles the overlay on a jittered interval until the context is
+// done. A failure to reconcile is logged, and retried at the next interval.
+func (r *Reconciler) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := r.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Warn("Failed to reconcile kubelet config overlay", zap.Error(err))
+		}
+		if err := r.sleep(ctx, interval+rand.N(time.Duration(float64(interval)*intervalJitter)+1)); err != nil {
+			return nil
+		}
+	}
+}
+
+// Reconcile fetches the overlay, and applies it when it differs from the
+// drop-in that kubelet runs with.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	data, err := r.fetch(ctx, r.config.Source)
+	if err != nil {
+		return fmt.Errorf("failed to fetch kubelet config overlay: %w", err)
+	}
+	r.status.LastFetch = r.now()
+	if err := r.verify(ctx, data); err != nil {
+		return err
+	}
+	dropIn, err := generateDropIn(data)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(r.dropInPath)
+	if errors.Is(err, os.ErrNotExist) {
+		current = nil
+	} else if err != nil {
+		return err
+	}
+	digest := getDigest(dropIn)
+	if bytes.Equal(current, dropIn) {
+		r.status.Digest = digest
+		return nil
+	}
+	digestField := zap.String("digest", digest)
+	rejected, err := r.readRejectedDigest()
+	if err != nil {
+		return err
+	}
+	if digest == rejected {
+		zap.L().Debug("Kubelet config overlay was rolled back, waiting for it to change", digestField)
+		return nil
+	}
+	if !r.lastRestart.IsZero() {
+		if next := r.lastRestart.Add(r.config.MinRestartInterval); r.now().Before(next) {
+			zap.L().Info("Deferring change of kubelet config overlay until kubelet can be restarted again", digestField, zap.Time("after", next))
+			return nil
+		}
+	}
+
+	zap.L().Info("Applying changed kubelet config overlay..", zap.String("path", r.dropInPath), digestField)
+	if err := util.WriteFileWithDir(r.dropInPath, dropIn, dropInPerm); err != nil {
+		return err
+	}
+	if err := r.restartKubelet(); err != nil {
+		return errors.Join(err, r.rollback(current))
+	}
+	stable, err := r.waitForStableKubelet(ctx)
+	if err != nil {
+		return err
+	}
+	if !stable {
+		zap.L().Error("Kubelet is crash looping with the changed kubelet config overlay, rolling back..", digestField)
+		if err := r.writeRejectedDigest(digest); err != nil {
+			zap.L().Warn("Failed to record rejected kubelet config overlay", digestField, zap.Error(err))
+		}
+		r.status.RolledBack++
+		if err := r.rollback(current); err != nil {
+			return err
+		}
+		return fmt.Errorf("kubelet config overlay %s was rolled back", digest)
+	}
+	r.status.Applied++
+	r.status.Digest = digest
+	zap.L().Info("Applied kubelet config overlay", digestField)
+	return nil
+}
+
+// verify verifies the detached signature of the overlay when a signing key is
+// installed.
+func (r *Reconciler) verify(ctx context.Context, data []byte) error {
+	publicKey, err := r.loadSigningKey()
+	if err != nil {
+		return err
+	} else if publicKey == nil {
+		return nil
+	}
+	sig, err := r.fetch(ctx, r.config.Source+signatureSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signature of kubelet config overlay: %w", err)
+	}
+	if err := signature.Verify(publicKey, data, sig); err != nil {
+		return fmt.Errorf("failed to verify signature of kubelet config overlay: %w", err)
+	}
+	return nil
+}
+
+// readRejectedDigest returns the digest of the drop-in that was rolled back,
+// or an empty digest if none was.
+func (r *Reconciler) readRejectedDigest() (string, error) {
+	data, err := os.ReadFile(r.rejectedDigestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeRejectedDigest records the digest of a drop-in that was rolled back. It
+// is state of nodeadm rather than configuration, so it is not recorded in the
+// manifest of managed files.
+func (r *Reconciler) writeRejectedDigest(digest string) error {
+	if err := os.MkdirAll(filepath.Dir(r.rejectedDigestPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.rejectedDigestPath, []byte(digest+"\n"), rejectedDigestPerm)
+}
+
+func (r *Reconciler) restartKubelet() error {
+	zap.L().Info("Restarting daemon..", zap.String("name", kubelet.KubeletDaemonName))
+	r.lastRestart = r.now()
+	return r.daemonManager.RestartDaemon(kubelet.KubeletDaemonName)
+}
+
+// rollback restores the drop-in that kubelet ran with before, which is nil
+// when there was none, and restarts kubelet.
+func (r *Reconciler) rollback(previous []byte) error {
+	if previous == nil {
+		if err := os.Remove(r.dropInPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else if err := util.WriteFileWithDir(r.dropInPath, previous, dropInPerm); err != nil {
+		return err
+	}
+	return r.restartKubelet()
+}
+
+// waitForStableKubelet watches kubelet during the stabilization window, and
+// returns whether it kept running. systemd restarts kubelet when it exits, so
+// a kubelet that cannot start with its config is seen activating or failed
+// between its restarts.
+func (r *Reconciler) waitForStableKubelet(ctx context.Context) (bool, error) {
+	notRunning := 0
+	for elapsed := time.Duration(0); elapsed < stabilizationWindow; elapsed += statusPollInterval {
+		if err := r.sleep(ctx, statusPollInterval); err != nil {
+			return false, err
+		}
+		status, err := r.daemonManager.GetDaemonStatus(kubelet.KubeletDaemonName)
+		if err != nil {
+			zap.L().Warn("Failed to get status of daemon", zap.String("name", kubelet.KubeletDaemonName), zap.Error(err))
+			continue
+		}
+		if status != daemon.DaemonStatusRunning {
+			notRunning++
+			if notRunning >= crashLoopThreshold {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// generateDropIn parses a YAML or JSON overlay and returns the drop-in that
+// kubelet reads it from.
+func generateDropIn(data []byte) ([]byte, error) {
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet config overlay: %w", err)
+	}
+	if len(config) == 0 {
+		return nil, fmt.Errorf("kubelet config overlay is empty")
+	}
+	if kind, ok := config["kind"]; ok && kind != kubeletConfigKind {
+		return nil, fmt.Errorf("kubelet config overlay must be a %s, not %v", kubeletConfigKind, kind)
+	}
+	return kubelet.GenerateDropIn(config)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func getDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
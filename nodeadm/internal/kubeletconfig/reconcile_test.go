@@ -0,0 +1,190 @@
+package kubeletconfig
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+func newTestReconciler(t *testing.T, overlay *string, crashing *bool) (*Reconciler, *daemontest.FakeManager, *time.Time) {
+	manager := daemontest.NewFakeManager(kubelet.KubeletDaemonName)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := NewReconciler(manager, Config{Source: "s3://bucket/kubelet.yaml", MinRestartInterval: 10 * time.Minute})
+	dir := t.TempDir()
+	r.dropInPath = filepath.Join(dir, "50-nodeadm-remote.conf")
+	r.rejectedDigestPath = filepath.Join(dir, "rejected-digest")
+	r.fetch = func(_ context.Context, url string) ([]byte, error) {
+		assert.Equal(t, "s3://bucket/kubelet.yaml", url)
+		return []byte(*overlay), nil
+	}
+	r.loadSigningKey = func() (crypto.PublicKey, error) { return nil, nil }
+	r.now = func() time.Time { return now }
+	r.sleep = func(_ context.Context, d time.Duration) error {
+		if *crashing {
+			manager.SetStatus(kubelet.KubeletDaemonName, daemon.DaemonStatusUnknown)
+		}
+		return nil
+	}
+	return r, manager, &now
+}
+
+func countRestarts(manager *daemontest.FakeManager) int {
+	restarts := 0
+	for _, call := range manager.Calls() {
+		if call == "restart kubelet" {
+			restarts++
+		}
+	}
+	return restarts
+}
+
+func TestReconcile(t *testing.T) {
+	overlay := "maxPods: 110\n"
+	crashing := false
+	r, manager, now := newTestReconciler(t, &overlay, &crashing)
+	ctx := context.Background()
+
+	assert.NoError(t, r.Reconcile(ctx))
+	dropIn, err := os.ReadFile(r.dropInPath)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"apiVersion": "kubelet.config.k8s.io/v1beta1", "kind": "KubeletConfiguration", "maxPods": 110}`, string(dropIn))
+	assert.Equal(t, 1, countRestarts(manager))
+	assert.Equal(t, 1, r.Status().Applied)
+	assert.Equal(t, getDigest(dropIn), r.Status().Digest)
+
+	// kubelet is not restarted when the overlay is unchanged
+	assert.NoError(t, r.Reconcile(ctx))
+	assert.Equal(t, 1, countRestarts(manager))
+
+	// changes are deferred until the minimum restart interval has passed
+	overlay = "maxPods: 58\n"
+	*now = now.Add(5 * time.Minute)
+	assert.NoError(t, r.Reconcile(ctx))
+	assert.Equal(t, 1, countRestarts(manager))
+	*now = now.Add(5 * time.Minute)
+	assert.NoError(t, r.Reconcile(ctx))
+	assert.Equal(t, 2, countRestarts(manager))
+	dropIn, err = os.ReadFile(r.dropInPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(dropIn), `"maxPods": 58`)
+	assert.Equal(t, 2, r.Status().Applied)
+}
+
+func TestReconcileRollsBackCrashLoop(t *testing.T) {
+	overlay := "maxPods: 110\n"
+	crashing := false
+	r, manager, now := newTestReconciler(t, &overlay, &crashing)
+	ctx := context.Background()
+	assert.NoError(t, r.Reconcile(ctx))
+	applied, err := os.ReadFile(r.dropInPath)
+	assert.NoError(t, err)
+
+	overlay = "maxPods: -1\n"
+	crashing = true
+	*now = now.Add(time.Hour)
+	assert.ErrorContains(t, r.Reconcile(ctx), "was rolled back")
+	dropIn, err := os.ReadFile(r.dropInPath)
+	assert.NoError(t, err)
+	assert.Equal(t, applied, dropIn)
+	// kubelet was restarted with the overlay, then with the previous drop-in
+	assert.Equal(t, 3, countRestarts(manager))
+	assert.Equal(t, 1, r.Status().RolledBack)
+	assert.Equal(t, getDigest(applied), r.Status().Digest)
+
+	// the overlay that was rolled back is not applied again, even by another
+	// reconciler
+	crashing = false
+	*now = now.Add(time.Hour)
+	assert.NoError(t, r.Reconcile(ctx))
+	assert.Equal(t, 3, countRestarts(manager))
+	restarted, restartedManager, _ := newTestReconciler(t, &overlay, &crashing)
+	restarted.dropInPath, restarted.rejectedDigestPath = r.dropInPath, r.rejectedDigestPath
+	assert.NoError(t, restarted.Reconcile(ctx))
+	assert.Equal(t, 0, countRestarts(restartedManager))
+
+	// until it changes
+	overlay = "maxPods: 58\n"
+	assert.NoError(t, r.Reconcile(ctx))
+	assert.Equal(t, 4, countRestarts(manager))
+}
+
+func TestReconcileRemovesDropInOnRollback(t *testing.T) {
+	overlay := "maxPods: -1\n"
+	crashing := true
+	r, manager, _ := newTestReconciler(t, &overlay, &crashing)
+	assert.ErrorContains(t, r.Reconcile(context.Background()), "was rolled back")
+	assert.NoFileExists(t, r.dropInPath)
+	assert.Equal(t, 2, countRestarts(manager))
+}
+
+func TestReconcileVerifiesSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	overlay := []byte("maxPods: 110\n")
+	digest := sha256.Sum256(overlay)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	assert.NoError(t, err)
+	var tests = []struct {
+		name      string
+		signature []byte
+		expectErr bool
+	}{
+		{name: "signed", signature: []byte(base64.StdEncoding.EncodeToString(sig))},
+		{name: "signed by another key", signature: []byte(base64.StdEncoding.EncodeToString([]byte("not a signature"))), expectErr: true},
+		{name: "not signed", expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			crashing := false
+			r, manager, _ := newTestReconciler(t, nil, &crashing)
+			r.loadSigningKey = func() (crypto.PublicKey, error) { return key.Public(), nil }
+			r.fetch = func(_ context.Context, url string) ([]byte, error) {
+				if strings.HasSuffix(url, signatureSuffix) {
+					if test.signature == nil {
+						return nil, errors.New("not found")
+					}
+					return test.signature, nil
+				}
+				return overlay, nil
+			}
+			err := r.Reconcile(context.Background())
+			if test.expectErr {
+				assert.Error(t, err)
+				assert.NoFileExists(t, r.dropInPath)
+				assert.Equal(t, 0, countRestarts(manager))
+			} else {
+				assert.NoError(t, err)
+				assert.FileExists(t, r.dropInPath)
+			}
+		})
+	}
+}
+
+func TestGenerateDropIn(t *testing.T) {
+	dropIn, err := generateDropIn([]byte(`{"kind": "KubeletConfiguration", "apiVersion": "kubelet.config.k8s.io/v1beta1", "registryPullQPS": 10}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"apiVersion": "kubelet.config.k8s.io/v1beta1", "kind": "KubeletConfiguration", "registryPullQPS": 10}`, string(dropIn))
+
+	_, err = generateDropIn([]byte("kind: ConfigMap\ndata: {}\n"))
+	assert.ErrorContains(t, err, "must be a KubeletConfiguration")
+	_, err = generateDropIn([]byte(""))
+	assert.ErrorContains(t, err, "is empty")
+	_, err = generateDropIn([]byte("- maxPods"))
+	assert.ErrorContains(t, err, "failed to parse")
+}
@@ -0,0 +1,72 @@
+package logstream
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const (
+	LogStreamDaemonName = "nodeadm-log-stream"
+
+	// DefaultDuration is how long the journals are streamed for after the
+	// node boots.
+	DefaultDuration = 10 * time.Minute
+
+	environmentFilePath = "/etc/eks/nodeadm/log-stream/environment"
+	argsEnvironmentName = "NODEADM_LOG_STREAM_ARGS"
+)
+
+var (
+	_ daemon.Daemon      = &logStream{}
+	_ daemon.Restartable = &logStream{}
+)
+
+// logStream manages the unit that runs `nodeadm debug stream-logs`, which
+// streams the journals of units to CloudWatch Logs for a while after the node
+// boots.
+type logStream struct {
+	daemon.EnvironmentUnit
+}
+
+func NewLogStreamDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &logStream{
+		EnvironmentUnit: daemon.NewEnvironmentUnit(daemonManager, LogStreamDaemonName, environmentFilePath, argsEnvironmentName),
+	}
+}
+
+func (l *logStream) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	args, enabled := generateArgs(cfg)
+	if !enabled {
+		return l.Disable()
+	}
+	return l.Enable(args)
+}
+
+// generateArgs returns the arguments of the unit, or false if no journal is
+// streamed.
+func generateArgs(cfg *api.NodeConfig) (string, bool) {
+	logging := cfg.Spec.Debug.Logging.CloudWatch
+	if !logging.Enabled || len(logging.Units) == 0 {
+		return "", false
+	}
+	duration := DefaultDuration
+	if logging.Duration != nil {
+		duration = logging.Duration.Duration
+	}
+	return fmt.Sprintf("--region=%s --log-group=%s --instance-id=%s --units=%s --duration=%s",
+		cfg.Status.Instance.Region, GetLogGroupName(cfg), cfg.Status.Instance.ID, strings.Join(logging.Units, ","), duration), true
+}
+
+// GetLogGroupName returns the log group that the logs of the node are
+// streamed to.
+func GetLogGroupName(cfg *api.NodeConfig) string {
+	if name := cfg.Spec.Debug.Logging.CloudWatch.LogGroupName; name != "" {
+		return name
+	}
+	return fmt.Sprintf("/aws/eks/%s/nodeadm", cfg.Spec.Cluster.Name)
+}
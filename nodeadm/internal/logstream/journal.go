@@ -0,0 +1,150 @@
+package logstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+)
+
+var uptimePath = "/proc/uptime"
+
+// GetBootTime returns the time that the node booted, from how long it has
+// been up.
+func GetBootTime() (time.Time, error) {
+	data, err := os.ReadFile(uptimePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("failed to parse %s", uptimePath)
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse %s: %w", uptimePath, err)
+	}
+	return time.Now().Add(-time.Duration(uptime * float64(time.Second))), nil
+}
+
+// journalEntry is the subset of an entry in the JSON output of journalctl
+// that is streamed.
+type journalEntry struct {
+	// RealtimeTimestamp is in microseconds since the epoch
+	RealtimeTimestamp string `json:"__REALTIME_TIMESTAMP"`
+	Unit              string `json:"_SYSTEMD_UNIT"`
+	// Message is a string, or an array of bytes when it is not valid UTF-8
+	Message json.RawMessage `json:"MESSAGE"`
+}
+
+// GetUnitName returns the full name of a unit, which journalctl assumes to be
+// a service when it has no type suffix.
+func GetUnitName(unit string) string {
+	if strings.Contains(unit, ".") {
+		return unit
+	}
+	return unit + ".service"
+}
+
+// FollowJournal puts the entries of the journals of the units since the boot
+// to the stream of each unit, keyed by its full name, until the context is
+// done.
+func FollowJournal(ctx context.Context, streams map[string]*Stream) error {
+	args := []string{"--boot", "--follow", "--output=json", "--no-pager"}
+	for unit := range streams {
+		args = append(args, "--unit="+unit)
+	}
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.CommandContext(ctx, "journalctl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start journalctl: %w", err)
+	}
+	entries := make(chan []byte)
+	go func() {
+		defer close(entries)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 4*maxMessageBytes)
+		for scanner.Scan() {
+			entries <- append([]byte{}, scanner.Bytes()...)
+		}
+	}()
+
+	pending := map[string][]logs.Event{}
+	flush := func(ctx context.Context) {
+		for unit, events := range pending {
+			if err := streams[unit].Put(ctx, events); err != nil {
+				zap.L().Warn("Failed to stream journal to CloudWatch Logs", zap.String("unit", unit), zap.Error(err))
+				continue
+			}
+			delete(pending, unit)
+		}
+	}
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case line, ok := <-entries:
+			if !ok {
+				// the final entries are put even though the context is done
+				flushCtx, cancel := context.WithTimeout(context.Background(), putTimeout)
+				flush(flushCtx)
+				cancel()
+				if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+					return fmt.Errorf("journalctl exited: %w", err)
+				}
+				return nil
+			}
+			unit, event, err := parseJournalEntry(line)
+			if err != nil {
+				zap.L().Warn("Failed to parse journal entry", zap.Error(err))
+				continue
+			}
+			if _, ok := streams[unit]; ok && len(pending[unit]) < maxBufferedEvents {
+				pending[unit] = append(pending[unit], event)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// parseJournalEntry returns the unit and the event of a line of the JSON
+// output of journalctl.
+func parseJournalEntry(line []byte) (string, logs.Event, error) {
+	var entry journalEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return "", logs.Event{}, err
+	}
+	micros, err := strconv.ParseInt(entry.RealtimeTimestamp, 10, 64)
+	if err != nil {
+		return "", logs.Event{}, fmt.Errorf("invalid timestamp %q: %w", entry.RealtimeTimestamp, err)
+	}
+	var message string
+	if err := json.Unmarshal(entry.Message, &message); err != nil {
+		var data []byte
+		// an array of bytes does not unmarshal into []byte, which expects
+		// base64, so it is read as integers
+		var ints []int
+		if err := json.Unmarshal(entry.Message, &ints); err != nil {
+			return "", logs.Event{}, fmt.Errorf("invalid message: %w", err)
+		}
+		for _, b := range ints {
+			data = append(data, byte(b))
+		}
+		message = strings.ToValidUTF8(string(data), "�")
+	}
+	return entry.Unit, logs.Event{Timestamp: time.UnixMicro(micros), Message: message}, nil
+}
@@ -0,0 +1,62 @@
+package logstream
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestParseJournalEntry(t *testing.T) {
+	unit, event, err := parseJournalEntry([]byte(`{"__REALTIME_TIMESTAMP":"1735689600123456","_SYSTEMD_UNIT":"kubelet.service","MESSAGE":"Starting kubelet"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "kubelet.service", unit)
+	assert.Equal(t, time.UnixMicro(1735689600123456), event.Timestamp)
+	assert.Equal(t, "Starting kubelet", event.Message)
+
+	_, event, err = parseJournalEntry([]byte(`{"__REALTIME_TIMESTAMP":"1735689600123456","_SYSTEMD_UNIT":"containerd.service","MESSAGE":[104,105,255]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "hi�", event.Message)
+
+	_, _, err = parseJournalEntry([]byte(`{"_SYSTEMD_UNIT":"kubelet.service","MESSAGE":"no timestamp"}`))
+	assert.Error(t, err)
+}
+
+func TestGetUnitName(t *testing.T) {
+	assert.Equal(t, "kubelet.service", GetUnitName("kubelet"))
+	assert.Equal(t, "kubepods.slice", GetUnitName("kubepods.slice"))
+}
+
+func TestGetBootTime(t *testing.T) {
+	uptimePath = filepath.Join(t.TempDir(), "uptime")
+	t.Cleanup(func() { uptimePath = "/proc/uptime" })
+	assert.NoError(t, os.WriteFile(uptimePath, []byte("120.50 200.00\n"), 0644))
+	bootTime, err := GetBootTime()
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-120500*time.Millisecond), bootTime, time.Second)
+}
+
+func TestGenerateEnvironment(t *testing.T) {
+	cfg := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "test"},
+			Debug: api.DebugOptions{Logging: api.LoggingOptions{CloudWatch: api.CloudWatchLoggingOptions{
+				Enabled: true,
+			}}},
+		},
+		Status: api.NodeConfigStatus{Instance: api.InstanceDetails{ID: "i-1234567890abcdef0", Region: "us-west-2"}},
+	}
+	_, enabled := generateArgs(cfg)
+	assert.False(t, enabled, "no journal is streamed without units")
+
+	cfg.Spec.Debug.Logging.CloudWatch.Units = []string{"kubelet", "containerd"}
+	cfg.Spec.Debug.Logging.CloudWatch.Duration = &metav1.Duration{Duration: 15 * time.Minute}
+	args, enabled := generateArgs(cfg)
+	assert.True(t, enabled)
+	assert.Equal(t, "--region=us-west-2 --log-group=/aws/eks/test/nodeadm --instance-id=i-1234567890abcdef0 --units=kubelet,containerd --duration=15m0s", args)
+}
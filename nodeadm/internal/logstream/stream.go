@@ -0,0 +1,98 @@
+// Package logstream streams the logs of the node to CloudWatch Logs while it
+// is bootstrapped: the logs of `nodeadm init` as they are written, and the
+// journals of units such as kubelet for a while after the node boots.
+package logstream
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+)
+
+const (
+	// the limits of a batch of PutLogEvents, where each event counts its
+	// message and 26 bytes towards the size of the batch
+	maxBatchEvents   = 10000
+	maxBatchBytes    = 1048576
+	eventOverhead    = 26
+	maxMessageBytes  = 262144 - eventOverhead
+	truncationSuffix = "...[truncated]"
+	// the events of a batch must not span more than a day
+	maxBatchSpan = 24 * time.Hour
+	putTimeout   = 30 * time.Second
+)
+
+// Client is the subset of the CloudWatch Logs API that a stream is put with.
+type Client interface {
+	CreateLogGroup(ctx context.Context, group string) error
+	CreateLogStream(ctx context.Context, group string, stream string) error
+	PutLogEvents(ctx context.Context, group string, stream string, events []logs.Event) error
+}
+
+// Stream is a log stream of a log group.
+type Stream struct {
+	client Client
+	group  string
+	name   string
+}
+
+// NewStream creates the log group and the log stream, unless they exist.
+func NewStream(ctx context.Context, client Client, group string, name string) (*Stream, error) {
+	if err := client.CreateLogGroup(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to create log group %s: %w", group, err)
+	}
+	if err := client.CreateLogStream(ctx, group, name); err != nil {
+		return nil, fmt.Errorf("failed to create log stream %s in log group %s: %w", name, group, err)
+	}
+	return &Stream{client: client, group: group, name: name}, nil
+}
+
+// Put puts the events in as many batches as the limits of the API require.
+func (s *Stream) Put(ctx context.Context, events []logs.Event) error {
+	for _, batch := range batchEvents(events) {
+		putCtx, cancel := context.WithTimeout(ctx, putTimeout)
+		err := s.client.PutLogEvents(putCtx, s.group, s.name, batch)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to put %d events to log stream %s: %w", len(batch), s.name, err)
+		}
+	}
+	return nil
+}
+
+// batchEvents sorts the events into chronological order, and splits them into
+// batches within the limits of PutLogEvents. Messages that are too large for
+// an event are truncated.
+func batchEvents(events []logs.Event) [][]logs.Event {
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b logs.Event) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	var batches [][]logs.Event
+	var batch []logs.Event
+	size := 0
+	for _, event := range sorted {
+		if event.Message == "" {
+			// the API rejects events without a message
+			continue
+		}
+		if len(event.Message) > maxMessageBytes {
+			event.Message = event.Message[:maxMessageBytes-len(truncationSuffix)] + truncationSuffix
+		}
+		eventSize := len(event.Message) + eventOverhead
+		if len(batch) > 0 && (len(batch) == maxBatchEvents || size+eventSize > maxBatchBytes || event.Timestamp.Sub(batch[0].Timestamp) > maxBatchSpan) {
+			batches = append(batches, batch)
+			batch = nil
+			size = 0
+		}
+		batch = append(batch, event)
+		size += eventSize
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
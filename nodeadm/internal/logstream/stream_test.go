@@ -0,0 +1,126 @@
+package logstream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+)
+
+type fakeClient struct {
+	mu      sync.Mutex
+	batches [][]logs.Event
+	err     error
+}
+
+func (c *fakeClient) CreateLogGroup(ctx context.Context, group string) error {
+	return nil
+}
+
+func (c *fakeClient) CreateLogStream(ctx context.Context, group string, stream string) error {
+	return nil
+}
+
+func (c *fakeClient) PutLogEvents(ctx context.Context, group string, stream string, events []logs.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.batches = append(c.batches, events)
+	return nil
+}
+
+func (c *fakeClient) messages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var messages []string
+	for _, batch := range c.batches {
+		for _, event := range batch {
+			messages = append(messages, event.Message)
+		}
+	}
+	return messages
+}
+
+func TestBatchEvents(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []logs.Event{
+		{Timestamp: start.Add(time.Second), Message: "second"},
+		{Timestamp: start, Message: "first"},
+		{Timestamp: start, Message: ""},
+		{Timestamp: start.Add(25 * time.Hour), Message: "next day"},
+	}
+	batches := batchEvents(events)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, "first", batches[0][0].Message)
+	assert.Equal(t, "second", batches[0][1].Message)
+	assert.Equal(t, "next day", batches[1][0].Message)
+
+	large := strings.Repeat("x", maxMessageBytes+1)
+	var many []logs.Event
+	for i := 0; i < 5; i++ {
+		many = append(many, logs.Event{Timestamp: start, Message: large})
+	}
+	batches = batchEvents(many)
+	// each event is truncated to the limit, so four fill a batch
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0], 4)
+	assert.Len(t, batches[0][0].Message, maxMessageBytes)
+	assert.True(t, strings.HasSuffix(batches[0][0].Message, truncationSuffix))
+}
+
+func TestWriter(t *testing.T) {
+	client := &fakeClient{}
+	stream, err := NewStream(context.TODO(), client, "group", "i-1234567890abcdef0")
+	assert.NoError(t, err)
+	w := NewWriter()
+	w.Write([]byte("before start\n"))
+	w.Start(context.TODO(), stream)
+	w.Write([]byte("after start\n"))
+	assert.NoError(t, w.Close(context.TODO()))
+	assert.Equal(t, []string{"before start", "after start"}, client.messages())
+}
+
+func TestWriterRetry(t *testing.T) {
+	client := &fakeClient{err: errors.New("throttled")}
+	stream, err := NewStream(context.TODO(), client, "group", "i-1234567890abcdef0")
+	assert.NoError(t, err)
+	w := NewWriter()
+	w.stream = stream
+	w.Write([]byte("entry\n"))
+	assert.Error(t, w.flush(context.TODO()))
+	client.err = nil
+	assert.NoError(t, w.flush(context.TODO()))
+	assert.Equal(t, []string{"entry"}, client.messages())
+}
+
+func TestWriterDiscard(t *testing.T) {
+	w := NewWriter()
+	w.Write([]byte("entry\n"))
+	w.Discard()
+	w.Write([]byte("entry\n"))
+	assert.Empty(t, w.events)
+	assert.NoError(t, w.Close(context.TODO()))
+}
+
+func TestWriterOverflow(t *testing.T) {
+	client := &fakeClient{}
+	stream, err := NewStream(context.TODO(), client, "group", "i-1234567890abcdef0")
+	assert.NoError(t, err)
+	w := NewWriter()
+	w.stream = stream
+	for i := 0; i < maxBufferedEvents+2; i++ {
+		w.Write([]byte("entry\n"))
+	}
+	assert.NoError(t, w.flush(context.TODO()))
+	messages := client.messages()
+	assert.Len(t, messages, maxBufferedEvents+1)
+	assert.Equal(t, "2 log entries were dropped", messages[len(messages)-1])
+}
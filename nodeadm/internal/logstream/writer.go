@@ -0,0 +1,174 @@
+package logstream
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+)
+
+const (
+	flushInterval = 5 * time.Second
+	// maxBufferedEvents bounds the events that are held before the stream is
+	// started, or while it cannot be put to
+	maxBufferedEvents = 10000
+)
+
+// Writer buffers the entries that a logger writes as events, and puts them to
+// a stream once it is started. Entries are buffered from the start of the
+// process, since the stream is only known once the config is enriched.
+type Writer struct {
+	mu        sync.Mutex
+	events    []logs.Event
+	dropped   int
+	discarded bool
+	stream    *Stream
+	stop      chan struct{}
+	done      chan struct{}
+	now       func() time.Time
+}
+
+var _ zapcore.WriteSyncer = &Writer{}
+
+func NewWriter() *Writer {
+	return &Writer{now: time.Now}
+}
+
+// Write buffers an entry of a zap core, which writes each entry with a single
+// call.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.discarded {
+		return len(p), nil
+	}
+	if len(w.events) >= maxBufferedEvents {
+		w.dropped++
+		return len(p), nil
+	}
+	w.events = append(w.events, logs.Event{Timestamp: w.now(), Message: strings.TrimSuffix(string(p), "\n")})
+	return len(p), nil
+}
+
+func (w *Writer) Sync() error {
+	return nil
+}
+
+// Start puts the buffered events to the stream, and the events written from
+// then on every flushInterval, until the writer is closed.
+func (w *Writer) Start(ctx context.Context, stream *Stream) {
+	w.mu.Lock()
+	w.stream = stream
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	w.mu.Unlock()
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		warned := false
+		for {
+			if err := w.flush(ctx); err != nil && !warned {
+				// the warning is itself streamed once the stream recovers
+				zap.L().Warn("Failed to stream logs to CloudWatch Logs", zap.Error(err))
+				warned = true
+			}
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Discard drops the buffered events and those written from then on, for a
+// config that does not stream its logs.
+func (w *Writer) Discard() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.discarded = true
+	w.events = nil
+}
+
+// Close stops the periodic puts of a started writer, and puts the remaining
+// events.
+func (w *Writer) Close(ctx context.Context) error {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	<-done
+	return w.flush(ctx)
+}
+
+func (w *Writer) flush(ctx context.Context) error {
+	w.mu.Lock()
+	events, dropped, stream := w.events, w.dropped, w.stream
+	w.events, w.dropped = nil, 0
+	w.mu.Unlock()
+	if dropped > 0 {
+		events = append(events, logs.Event{Timestamp: w.now(), Message: fmt.Sprintf("%d log entries were dropped", dropped)})
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	if err := stream.Put(ctx, events); err != nil {
+		// the events are put again with the next flush
+		w.mu.Lock()
+		w.events = append(events, w.events...)
+		if overflow := len(w.events) - maxBufferedEvents; overflow > 0 {
+			w.events = w.events[overflow:]
+			w.dropped += overflow
+		}
+		w.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+var (
+	defaultWriter = NewWriter()
+	// defaultCore writes the entries of the tee of a logger to the default
+	// writer
+	defaultCore zapcore.Core
+)
+
+// Tee returns a logger that also writes its entries to the default writer, as
+// the JSON of the production logger.
+func Tee(logger *zap.Logger) *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	defaultCore = zapcore.NewCore(encoder, defaultWriter, zapcore.LevelOf(logger.Core()))
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, defaultCore)
+	}))
+}
+
+// Start puts the entries of the tee to the stream.
+func Start(ctx context.Context, stream *Stream) {
+	defaultWriter.Start(ctx, stream)
+}
+
+// Discard drops the entries of the tee.
+func Discard() {
+	defaultWriter.Discard()
+}
+
+// Close puts the remaining entries of the tee to the stream, along with the
+// error that the command failed with, which is only logged once it has
+// returned.
+func Close(ctx context.Context, err error) error {
+	if err != nil && defaultCore != nil {
+		zap.New(defaultCore).Error("Command failed", zap.Error(err))
+	}
+	return defaultWriter.Close(ctx)
+}
@@ -0,0 +1,190 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"sigs.k8s.io/yaml"
+)
+
+type DiffStatus string
+
+const (
+	DiffStatusAdded   DiffStatus = "added"
+	DiffStatusRemoved DiffStatus = "removed"
+	DiffStatusChanged DiffStatus = "changed"
+)
+
+// FileDiff is the difference between the content a file was rendered with in
+// two manifests.
+type FileDiff struct {
+	Path   string     `json:"path"`
+	Status DiffStatus `json:"status"`
+	// Changes are the keys that differ in a structured file, or the lines
+	// that differ in any other file. Changes are only computed for changed
+	// files.
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Change is a single difference within a file. Key is the path to the value
+// in a structured file, such as `authentication.x509.clientCAFile`, and is
+// empty for the lines of other files. From is empty when the key or line was
+// added, and To is empty when it was removed.
+type Change struct {
+	Key  string `json:"key,omitempty"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// Diff compares the files rendered in two manifests, such as the manifests
+// recorded by two versions of nodeadm for the same NodeConfig. JSON, YAML and
+// TOML files are compared by the values of their keys, so that changes to
+// formatting or to the order of keys are not reported.
+func Diff(from *Manifest, to *Manifest) []FileDiff {
+	var diffs []FileDiff
+	for _, toFile := range to.Files {
+		fromFile, ok := from.Get(toFile.Path)
+		if !ok {
+			diffs = append(diffs, FileDiff{Path: toFile.Path, Status: DiffStatusAdded})
+			continue
+		}
+		if changes := diffFile(fromFile, &toFile); len(changes) > 0 {
+			diffs = append(diffs, FileDiff{Path: toFile.Path, Status: DiffStatusChanged, Changes: changes})
+		}
+	}
+	for _, fromFile := range from.Files {
+		if _, ok := to.Get(fromFile.Path); !ok {
+			diffs = append(diffs, FileDiff{Path: fromFile.Path, Status: DiffStatusRemoved})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+func diffFile(from *ManagedFile, to *ManagedFile) []Change {
+	var changes []Change
+	if from.Mode != to.Mode {
+		changes = append(changes, Change{Key: "(mode)", From: from.Mode.String(), To: to.Mode.String()})
+	}
+	if from.SHA256 == to.SHA256 {
+		return changes
+	}
+	if checksum(from.Content) != from.SHA256 || checksum(to.Content) != to.SHA256 {
+		// the content of secrets is not recorded, only their checksums
+		return append(changes, Change{Key: "(sha256)", From: from.SHA256, To: to.SHA256})
+	}
+	fromValues, fromErr := flattenDocument(from.Path, from.Content)
+	toValues, toErr := flattenDocument(to.Path, to.Content)
+	if fromErr != nil || toErr != nil || fromValues == nil {
+		return append(changes, diffLines(string(from.Content), string(to.Content))...)
+	}
+	var keys []string
+	for key := range fromValues {
+		keys = append(keys, key)
+	}
+	for key := range toValues {
+		if _, ok := fromValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if fromValues[key] != toValues[key] {
+			changes = append(changes, Change{Key: key, From: fromValues[key], To: toValues[key]})
+		}
+	}
+	return changes
+}
+
+// flattenDocument returns the values of a structured file by the path to each
+// of their keys. Lists are compared as a whole. A nil map is returned for
+// files that are not structured.
+func flattenDocument(filePath string, content []byte) (map[string]string, error) {
+	var document map[string]any
+	switch path.Ext(filePath) {
+	case ".json":
+		if err := json.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+	case ".toml":
+		if err := toml.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	values := make(map[string]string)
+	if err := flatten("", document, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func flatten(prefix string, value any, values map[string]string) error {
+	if object, ok := value.(map[string]any); ok && (len(object) > 0 || prefix == "") {
+		for key, child := range object {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := flatten(key, child, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", prefix, err)
+	}
+	values[prefix] = string(data)
+	return nil
+}
+
+// diffLines returns the lines that were removed and added between two files,
+// based on their longest common subsequence of lines. Blank lines are not
+// reported.
+func diffLines(from string, to string) []Change {
+	a := strings.Split(strings.TrimSuffix(from, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(to, "\n"), "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var changes []Change
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			if a[i] != "" {
+				changes = append(changes, Change{From: a[i]})
+			}
+			i++
+		default:
+			if b[j] != "" {
+				changes = append(changes, Change{To: b[j]})
+			}
+			j++
+		}
+	}
+	return changes
+}
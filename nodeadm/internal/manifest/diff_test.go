@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newManagedFile(path string, content string) ManagedFile {
+	return ManagedFile{Path: path, Mode: 0644, SHA256: checksum([]byte(content)), Content: []byte(content)}
+}
+
+func TestDiff(t *testing.T) {
+	from := &Manifest{Files: []ManagedFile{
+		newManagedFile("/etc/kubernetes/kubelet/config.json", `{"kubeAPIQPS": 5, "authentication": {"webhook": {"enabled": true}}, "clusterDNS": ["10.100.0.10"]}`),
+		newManagedFile("/etc/containerd/config.toml", "version = 2\n[plugins]\nsandbox_image = \"pause:3.5\"\n"),
+		newManagedFile("/etc/eks/kubelet/environment", "NODEADM_KUBELET_ARGS=--node-ip=10.0.0.1\nKUBELET_CONFIG_DROPIN_DIR_ALPHA=on\n"),
+		newManagedFile("/etc/removed.conf", "removed"),
+		newManagedFile("/etc/unchanged.yaml", "foo: bar\n"),
+	}}
+	to := &Manifest{Files: []ManagedFile{
+		// reordered and reformatted, with a changed, an added and a removed key
+		newManagedFile("/etc/kubernetes/kubelet/config.json", "{\n    \"clusterDNS\": [\"10.100.0.10\"],\n    \"kubeAPIQPS\": 10,\n    \"authentication\": {\"anonymous\": {\"enabled\": false}}\n}\n"),
+		newManagedFile("/etc/containerd/config.toml", "version = 2\n\n[plugins]\nsandbox_image = \"pause:3.10\"\n"),
+		newManagedFile("/etc/eks/kubelet/environment", "NODEADM_KUBELET_ARGS=--node-ip=10.0.0.1 --v=2\nKUBELET_CONFIG_DROPIN_DIR_ALPHA=on\n"),
+		newManagedFile("/etc/added.conf", "added"),
+		newManagedFile("/etc/unchanged.yaml", "foo:   bar\n"),
+	}}
+
+	assert.Equal(t, []FileDiff{
+		{Path: "/etc/added.conf", Status: DiffStatusAdded},
+		{Path: "/etc/containerd/config.toml", Status: DiffStatusChanged, Changes: []Change{
+			{Key: "plugins.sandbox_image", From: `"pause:3.5"`, To: `"pause:3.10"`},
+		}},
+		{Path: "/etc/eks/kubelet/environment", Status: DiffStatusChanged, Changes: []Change{
+			{From: "NODEADM_KUBELET_ARGS=--node-ip=10.0.0.1"},
+			{To: "NODEADM_KUBELET_ARGS=--node-ip=10.0.0.1 --v=2"},
+		}},
+		{Path: "/etc/kubernetes/kubelet/config.json", Status: DiffStatusChanged, Changes: []Change{
+			{Key: "authentication.anonymous.enabled", To: "false"},
+			{Key: "authentication.webhook.enabled", From: "true"},
+			{Key: "kubeAPIQPS", From: "5", To: "10"},
+		}},
+		{Path: "/etc/removed.conf", Status: DiffStatusRemoved},
+	}, Diff(from, to))
+}
+
+func TestDiffMode(t *testing.T) {
+	file := newManagedFile("/etc/eks/nodeadm/environment", "FOO=bar")
+	changed := file
+	changed.Mode = 0600
+
+	assert.Equal(t, []FileDiff{
+		{Path: file.Path, Status: DiffStatusChanged, Changes: []Change{{Key: "(mode)", From: "-rw-r--r--", To: "-rw-------"}}},
+	}, Diff(&Manifest{Files: []ManagedFile{file}}, &Manifest{Files: []ManagedFile{changed}}))
+}
+
+func TestDiffSecret(t *testing.T) {
+	from := ManagedFile{Path: "/etc/eks/credentials", Mode: 0600, SHA256: checksum([]byte("old"))}
+	to := ManagedFile{Path: "/etc/eks/credentials", Mode: 0600, SHA256: checksum([]byte("new"))}
+
+	assert.Equal(t, []FileDiff{
+		{Path: from.Path, Status: DiffStatusChanged, Changes: []Change{{Key: "(sha256)", From: from.SHA256, To: to.SHA256}}},
+	}, Diff(&Manifest{Files: []ManagedFile{from}}, &Manifest{Files: []ManagedFile{to}}))
+}
@@ -0,0 +1,221 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sort"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// ManifestPath is the well-known location of the manifest of files
+	// rendered by nodeadm.
+	ManifestPath = "/var/lib/nodeadm/manifest.json"
+	manifestPerm = 0600
+)
+
+// Manifest records the files rendered by nodeadm, and the content they were
+// rendered with, so that modifications made outside of nodeadm can be detected.
+type Manifest struct {
+	// Generation is incremented each time files are recorded, so that the
+	// content of a file is only restored while it is the latest rendered.
+	Generation int64         `json:"generation"`
+	Files      []ManagedFile `json:"files"`
+}
+
+// ManagedFile is a single file rendered by nodeadm.
+type ManagedFile struct {
+	Path   string      `json:"path"`
+	Mode   fs.FileMode `json:"mode"`
+	SHA256 string      `json:"sha256"`
+	// Content is not recorded for the files that only their owner can read,
+	// such as credentials and private keys, whose checksum is recorded alone.
+	Content []byte `json:"content,omitempty"`
+	// Created is whether the file did not exist before nodeadm first wrote
+	// it, rather than being shipped with the AMI or written by the user.
+	Created bool `json:"created,omitempty"`
+}
+
+// ErrContentNotRecorded is returned when a file whose content was not recorded
+// is restored.
+var ErrContentNotRecorded = errors.New("content of file is not recorded")
+
+// Build reads the current state of each of the given paths into a Manifest.
+func Build(paths []string) (*Manifest, error) {
+	var m Manifest
+	for _, filePath := range paths {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, NewManagedFile(filePath, info.Mode().Perm(), content))
+	}
+	return &m, nil
+}
+
+// NewManagedFile returns the ManagedFile of a file with the given content,
+// which is only recorded when the file is not a secret.
+func NewManagedFile(filePath string, mode fs.FileMode, content []byte) ManagedFile {
+	file := ManagedFile{
+		Path:   filePath,
+		Mode:   mode,
+		SHA256: checksum(content),
+	}
+	if !isSecret(file.Mode) {
+		file.Content = content
+	}
+	return file
+}
+
+// isSecret returns whether a file of the mode is readable by its owner alone.
+func isSecret(mode fs.FileMode) bool {
+	return mode&0044 == 0
+}
+
+// Load reads a Manifest from the given path. An empty Manifest is returned if
+// the path does not exist.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Write persists the Manifest to the given path.
+func (m *Manifest) Write(path string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, manifestPerm)
+}
+
+// Merge adds the files of another Manifest to this one, replacing files with
+// the same path.
+func (m *Manifest) Merge(other *Manifest) {
+	files := make(map[string]ManagedFile)
+	for _, file := range append(m.Files, other.Files...) {
+		files[file.Path] = file
+	}
+	m.Files = nil
+	for _, file := range files {
+		m.Files = append(m.Files, file)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+}
+
+// Get returns the ManagedFile for the given path, if it is in the Manifest.
+func (m *Manifest) Get(path string) (*ManagedFile, bool) {
+	for i := range m.Files {
+		if m.Files[i].Path == path {
+			return &m.Files[i], true
+		}
+	}
+	return nil, false
+}
+
+// Drifted returns whether the file on disk no longer matches the content
+// recorded in the manifest. Removed files are considered drifted.
+func (f *ManagedFile) Drifted() (bool, error) {
+	content, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return checksum(content) != f.SHA256, nil
+}
+
+// Restore writes the recorded content back to disk. Files whose content was
+// not recorded cannot be restored.
+func (f *ManagedFile) Restore() error {
+	if current, err := os.ReadFile(f.Path); err == nil && checksum(current) == f.SHA256 {
+		return nil
+	}
+	if checksum(f.Content) != f.SHA256 {
+		return fmt.Errorf("%w: %s", ErrContentNotRecorded, f.Path)
+	}
+	if err := util.WriteFileWithDir(f.Path, f.Content, f.Mode); err != nil {
+		return err
+	}
+	// WriteFile does not change the mode of an existing file
+	return os.Chmod(f.Path, f.Mode)
+}
+
+// RecordWrittenFiles adds every file written by this process to the manifest
+// at ManifestPath.
+func RecordWrittenFiles() error {
+	return RecordFiles(util.WrittenFiles())
+}
+
+// RecordFiles updates the manifest at ManifestPath with the current state of
+// each of the given paths. Paths that no longer exist are removed from the
+// manifest.
+func RecordFiles(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	var existing, removed []string
+	for _, filePath := range paths {
+		if exists, err := util.IsFilePathExists(filePath); err != nil {
+			return err
+		} else if exists {
+			existing = append(existing, filePath)
+		} else {
+			removed = append(removed, filePath)
+		}
+	}
+	written, err := Build(existing)
+	if err != nil {
+		return err
+	}
+	m, err := Load(ManifestPath)
+	if err != nil {
+		return err
+	}
+	snapshots := make(map[string]util.FileSnapshot)
+	for _, snapshot := range util.FileSnapshots(existing) {
+		snapshots[snapshot.Path] = snapshot
+	}
+	for i, file := range written.Files {
+		// a file is only created once, when nodeadm first writes it
+		if recorded, ok := m.Get(file.Path); ok {
+			written.Files[i].Created = recorded.Created
+		} else if snapshot, ok := snapshots[file.Path]; ok {
+			written.Files[i].Created = !snapshot.Existed
+		}
+	}
+	m.Merge(written)
+	m.Generation++
+	m.Files = slices.DeleteFunc(m.Files, func(file ManagedFile) bool {
+		return slices.Contains(removed, file.Path)
+	})
+	if err := os.MkdirAll(path.Dir(ManifestPath), 0755); err != nil {
+		return err
+	}
+	return m.Write(ManifestPath)
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrift(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(filePath, []byte("{}"), 0644))
+
+	m, err := Build([]string{filePath})
+	assert.NoError(t, err)
+	file, ok := m.Get(filePath)
+	assert.True(t, ok)
+
+	drifted, err := file.Drifted()
+	assert.NoError(t, err)
+	assert.False(t, drifted)
+
+	assert.NoError(t, os.WriteFile(filePath, []byte(`{"foo":"bar"}`), 0644))
+	drifted, err = file.Drifted()
+	assert.NoError(t, err)
+	assert.True(t, drifted)
+
+	assert.NoError(t, file.Restore())
+	drifted, err = file.Drifted()
+	assert.NoError(t, err)
+	assert.False(t, drifted)
+
+	assert.NoError(t, os.Remove(filePath))
+	drifted, err = file.Drifted()
+	assert.NoError(t, err)
+	assert.True(t, drifted)
+}
+
+func TestSecret(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials")
+	assert.NoError(t, os.WriteFile(filePath, []byte("secret"), 0600))
+
+	m, err := Build([]string{filePath})
+	assert.NoError(t, err)
+	file, ok := m.Get(filePath)
+	assert.True(t, ok)
+	assert.Nil(t, file.Content)
+	assert.Equal(t, checksum([]byte("secret")), file.SHA256)
+
+	// an unchanged secret is left alone, but a drifted one cannot be restored
+	assert.NoError(t, file.Restore())
+	assert.NoError(t, os.WriteFile(filePath, []byte("modified"), 0600))
+	drifted, err := file.Drifted()
+	assert.NoError(t, err)
+	assert.True(t, drifted)
+	assert.ErrorIs(t, file.Restore(), ErrContentNotRecorded)
+}
+
+func TestMerge(t *testing.T) {
+	m := &Manifest{Files: []ManagedFile{
+		{Path: "/b", SHA256: "old"},
+		{Path: "/c", SHA256: "c"},
+	}}
+	m.Merge(&Manifest{Files: []ManagedFile{
+		{Path: "/b", SHA256: "new"},
+		{Path: "/a", SHA256: "a"},
+	}})
+	assert.Equal(t, []ManagedFile{
+		{Path: "/a", SHA256: "a"},
+		{Path: "/b", SHA256: "new"},
+		{Path: "/c", SHA256: "c"},
+	}, m.Files)
+}
+
+func TestLoadMissing(t *testing.T) {
+	m, err := Load(filepath.Join(t.TempDir(), "manifest.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, m.Files)
+}
@@ -0,0 +1,100 @@
+//go:build linux
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+const watchMask = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_DELETE | unix.IN_ATTRIB | unix.IN_CREATE
+
+// Watch blocks until the context is cancelled, invoking onDrift whenever a file
+// in the manifest is modified outside of nodeadm.
+func Watch(ctx context.Context, m *Manifest, onDrift func(*ManagedFile)) error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+	defer unix.Close(fd)
+
+	// watch parent directories rather than the files themselves, so that
+	// files which are removed or replaced by a rename are still observed.
+	dirs := make(map[int]string)
+	watched := make(map[string]struct{})
+	for _, file := range m.Files {
+		dir := filepath.Dir(file.Path)
+		if _, ok := watched[dir]; ok {
+			continue
+		}
+		wd, err := unix.InotifyAddWatch(fd, dir, watchMask)
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		dirs[wd] = dir
+		watched[dir] = struct{}{}
+	}
+
+	epfd, err := unix.EpollCreate1(unix.EPOLL_CLOEXEC)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(epfd)
+	if err := unix.EpollCtl(epfd, unix.EPOLL_CTL_ADD, fd, &unix.EpollEvent{Events: unix.EPOLLIN, Fd: int32(fd)}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.PathMax))
+	events := make([]unix.EpollEvent, 1)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		// wake up periodically to observe context cancellation
+		n, err := unix.EpollWait(epfd, events, 1000)
+		if err == unix.EINTR || n == 0 {
+			continue
+		} else if err != nil {
+			return err
+		}
+		n, err = unix.Read(fd, buf)
+		if err == unix.EAGAIN {
+			continue
+		} else if err != nil {
+			return err
+		}
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			// #nosec G103 // the kernel guarantees the layout of inotify events
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(event.Len)]
+			offset += unix.SizeofInotifyEvent + int(event.Len)
+
+			dir, ok := dirs[int(event.Wd)]
+			if !ok {
+				continue
+			}
+			// names are padded with null bytes to an aligned boundary
+			name := strings.TrimRight(string(nameBytes), "\x00")
+			file, ok := m.Get(filepath.Join(dir, name))
+			if !ok {
+				continue
+			}
+			drifted, err := file.Drifted()
+			if err != nil {
+				zap.L().Warn("Failed to check managed file for drift", zap.String("path", file.Path), zap.Error(err))
+				continue
+			}
+			if drifted {
+				onDrift(file)
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+)
+
+// Watch is only supported on linux.
+func Watch(ctx context.Context, m *Manifest, onDrift func(*ManagedFile)) error {
+	return fmt.Errorf("watching managed files is not supported on this platform")
+}
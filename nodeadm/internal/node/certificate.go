@@ -0,0 +1,89 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+var (
+	servingCertificateMinDelay = 2 * time.Second
+	servingCertificateMaxDelay = 15 * time.Second
+)
+
+// WaitForServingCertificate blocks until the latest request of the node for a
+// kubelet serving certificate has been approved and signed. It fails as soon
+// as the request is denied, and explains what to check when the request is
+// still pending after the timeout.
+func WaitForServingCertificate(ctx context.Context, client kubernetes.Interface, nodeName string, timeout time.Duration) error {
+	var latest *certificatesv1.CertificateSigningRequest
+	err := util.Wait(ctx, func(ctx context.Context) (bool, error) {
+		csrs, err := client.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{
+			FieldSelector: "spec.signerName=" + certificatesv1.KubeletServingSignerName,
+		})
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			return false, fmt.Errorf("node %s cannot read its certificate signing requests: %w", nodeName, err)
+		} else if err != nil {
+			// the cluster may not be reachable yet while kubelet starts
+			zap.L().Warn("Failed to list certificate signing requests", zap.Error(err))
+			return false, nil
+		}
+		latest = latestServingRequest(csrs.Items, nodeName)
+		if latest == nil {
+			return false, nil
+		}
+		for _, condition := range latest.Status.Conditions {
+			switch condition.Type {
+			case certificatesv1.CertificateDenied:
+				return false, fmt.Errorf("serving certificate request %s was denied: %s", latest.Name, condition.Message)
+			case certificatesv1.CertificateFailed:
+				return false, fmt.Errorf("serving certificate request %s could not be signed: %s", latest.Name, condition.Message)
+			}
+		}
+		return len(latest.Status.Certificate) > 0, nil
+	}, servingCertificateMinDelay, servingCertificateMaxDelay, timeout)
+	if !errors.Is(err, util.ErrWaiterTimeout) {
+		return err
+	}
+	if latest == nil {
+		return fmt.Errorf("kubelet did not request a serving certificate within %s, check that serverTLSBootstrap is enabled and that kubelet can reach the cluster", timeout)
+	}
+	if isApproved(latest) {
+		return fmt.Errorf("serving certificate request %s was approved but not signed within %s, check the signer of %s", latest.Name, timeout, certificatesv1.KubeletServingSignerName)
+	}
+	return fmt.Errorf("serving certificate request %s was not approved within %s, approve it with `kubectl certificate approve %s` or check that an approver of %s is running", latest.Name, timeout, latest.Name, certificatesv1.KubeletServingSignerName)
+}
+
+// latestServingRequest returns the most recent serving certificate request of
+// the node, since kubelet requests a new certificate each time it starts.
+func latestServingRequest(csrs []certificatesv1.CertificateSigningRequest, nodeName string) *certificatesv1.CertificateSigningRequest {
+	var latest *certificatesv1.CertificateSigningRequest
+	for i := range csrs {
+		csr := &csrs[i]
+		if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || csr.Spec.Username != "system:node:"+nodeName {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&csr.CreationTimestamp) {
+			latest = csr
+		}
+	}
+	return latest
+}
+
+func isApproved(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved {
+			return true
+		}
+	}
+	return false
+}
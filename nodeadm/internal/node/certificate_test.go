@@ -0,0 +1,84 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func servingRequest(name string, age time.Duration, conditionType certificatesv1.RequestConditionType, certificate string) *certificatesv1.CertificateSigningRequest {
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Username:   "system:node:" + nodeName,
+		},
+		Status: certificatesv1.CertificateSigningRequestStatus{
+			Certificate: []byte(certificate),
+		},
+	}
+	if conditionType != "" {
+		csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{{Type: conditionType, Message: "by test"}}
+	}
+	return csr
+}
+
+func TestWaitForServingCertificate(t *testing.T) {
+	servingCertificateMinDelay = time.Millisecond
+	servingCertificateMaxDelay = time.Millisecond
+
+	var tests = []struct {
+		name          string
+		csrs          []runtime.Object
+		expectedError string
+	}{
+		{
+			name: "issued",
+			csrs: []runtime.Object{servingRequest("csr-1", 0, certificatesv1.CertificateApproved, "cert")},
+		},
+		{
+			name: "issued after an earlier request was denied",
+			csrs: []runtime.Object{
+				servingRequest("csr-1", time.Hour, certificatesv1.CertificateDenied, ""),
+				servingRequest("csr-2", 0, certificatesv1.CertificateApproved, "cert"),
+			},
+		},
+		{
+			name:          "denied",
+			csrs:          []runtime.Object{servingRequest("csr-1", 0, certificatesv1.CertificateDenied, "")},
+			expectedError: "serving certificate request csr-1 was denied: by test",
+		},
+		{
+			name:          "pending",
+			csrs:          []runtime.Object{servingRequest("csr-1", 0, "", "")},
+			expectedError: "approve it with `kubectl certificate approve csr-1`",
+		},
+		{
+			name:          "approved but not signed",
+			csrs:          []runtime.Object{servingRequest("csr-1", 0, certificatesv1.CertificateApproved, "")},
+			expectedError: "was approved but not signed",
+		},
+		{
+			name:          "not requested",
+			expectedError: "kubelet did not request a serving certificate",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientset(test.csrs...)
+			err := WaitForServingCertificate(context.Background(), client, nodeName, 20*time.Millisecond)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
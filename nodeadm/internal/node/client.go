@@ -0,0 +1,19 @@
+package node
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// NewClient builds a client for the cluster using the credentials of the
+// given kubeconfig.
+func NewClient(kubeconfigPath string) (kubernetes.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	config.Proxy = util.ProxyFromEnvironment()
+	return kubernetes.NewForConfig(config)
+}
@@ -0,0 +1,63 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const registrationPollInterval = 2 * time.Second
+
+// WaitForNode blocks until the node has been registered with the cluster, or
+// until the context is done.
+func WaitForNode(ctx context.Context, client kubernetes.Interface, nodeName string) (*corev1.Node, error) {
+	for {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err == nil {
+			return node, nil
+		} else if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("node %s was not registered: %w", nodeName, ctx.Err())
+		case <-time.After(registrationPollInterval):
+		}
+	}
+}
+
+// SetCondition adds the condition to the status of the node, or updates the
+// existing condition of the same type. Other conditions, including those owned
+// by kubelet, are left untouched.
+func SetCondition(ctx context.Context, client kubernetes.Interface, nodeName string, condition corev1.NodeCondition) error {
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	condition.LastHeartbeatTime = now
+	condition.LastTransitionTime = now
+	for _, existing := range node.Status.Conditions {
+		if existing.Type == condition.Type && existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+	}
+	// conditions are merged by type, so the patch only contains this condition
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"conditions": []corev1.NodeCondition{condition},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
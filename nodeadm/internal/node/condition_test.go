@@ -0,0 +1,56 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetCondition(t *testing.T) {
+	transitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	client := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: "Example", Status: corev1.ConditionFalse, LastTransitionTime: transitionTime},
+			},
+		},
+	})
+	ctx := context.Background()
+
+	getCondition := func(conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == conditionType {
+				return &condition
+			}
+		}
+		return nil
+	}
+
+	// unchanged status keeps the transition time
+	assert.NoError(t, SetCondition(ctx, client, nodeName, corev1.NodeCondition{Type: "Example", Status: corev1.ConditionFalse, Reason: "Unchanged"}))
+	if condition := getCondition("Example"); assert.NotNil(t, condition) {
+		assert.Equal(t, "Unchanged", condition.Reason)
+		assert.True(t, transitionTime.Equal(&condition.LastTransitionTime))
+	}
+
+	// changed status updates the transition time
+	assert.NoError(t, SetCondition(ctx, client, nodeName, corev1.NodeCondition{Type: "Example", Status: corev1.ConditionTrue, Reason: "Changed"}))
+	if condition := getCondition("Example"); assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.True(t, condition.LastTransitionTime.After(transitionTime.Time))
+	}
+
+	// new conditions are added alongside existing ones
+	assert.NoError(t, SetCondition(ctx, client, nodeName, corev1.NodeCondition{Type: "New", Status: corev1.ConditionTrue}))
+	assert.NotNil(t, getCondition("New"))
+	assert.NotNil(t, getCondition(corev1.NodeReady))
+}
@@ -0,0 +1,116 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	drainPollInterval   = 2 * time.Second
+)
+
+// Cordon marks the node as unschedulable.
+func Cordon(ctx context.Context, client kubernetes.Interface, nodeName string) error {
+	patch := []byte(`{"spec":{"unschedulable":true}}`)
+	_, err := client.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// Drain evicts the pods running on the node, and waits for them to be removed.
+// Pods managed by a DaemonSet and static pods are left in place, as they would
+// be recreated on the node. Drain returns when the context is done, so the
+// caller should bound it with a deadline.
+func Drain(ctx context.Context, client kubernetes.Interface, nodeName string) error {
+	for {
+		pods, err := GetDrainablePods(ctx, client, nodeName)
+		if err != nil {
+			return err
+		}
+		if len(pods) == 0 {
+			return nil
+		}
+		for _, pod := range pods {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			if err := Evict(ctx, client, pod); apierrors.IsForbidden(err) {
+				return err
+			} else if err != nil {
+				// evictions are rejected while they would violate a disruption
+				// budget, so these are retried until the deadline.
+				zap.L().Warn("Failed to evict pod", zap.String("namespace", pod.Namespace), zap.String("name", pod.Name), zap.Error(err))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d pods were not evicted from node %s: %w", len(pods), nodeName, ctx.Err())
+		case <-time.After(drainPollInterval):
+		}
+	}
+}
+
+// Delete deletes the Node object. A Node that does not exist is not an error.
+func Delete(ctx context.Context, client kubernetes.Interface, nodeName string) error {
+	err := client.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// GetDrainablePods returns the pods on the node that are evicted when it is
+// drained.
+func GetDrainablePods(ctx context.Context, client kubernetes.Interface, nodeName string) ([]corev1.Pod, error) {
+	podList, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if isDrainable(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+func isDrainable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if controller := metav1.GetControllerOf(pod); controller != nil && controller.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// Evict evicts the pod through the eviction API, which respects its disruption
+// budgets. A pod that does not exist is not an error.
+func Evict(ctx context.Context, client kubernetes.Interface, pod corev1.Pod) error {
+	err := client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
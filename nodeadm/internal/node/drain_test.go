@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+const nodeName = "my-node"
+
+func newPod(name string, mutate func(*corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+func TestIsDrainable(t *testing.T) {
+	var tests = []struct {
+		pod       *corev1.Pod
+		drainable bool
+	}{
+		{pod: newPod("running", nil), drainable: true},
+		{pod: newPod("succeeded", func(p *corev1.Pod) { p.Status.Phase = corev1.PodSucceeded }), drainable: false},
+		{pod: newPod("mirror", func(p *corev1.Pod) { p.Annotations = map[string]string{mirrorPodAnnotation: "hash"} }), drainable: false},
+		{pod: newPod("daemonset", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds", Controller: ptr.To(true)}}
+		}), drainable: false},
+		{pod: newPod("replicaset", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs", Controller: ptr.To(true)}}
+		}), drainable: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.drainable, isDrainable(test.pod), test.pod.Name)
+	}
+}
+
+func TestDeregister(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+		newPod("evicted", nil),
+		newPod("daemonset", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds", Controller: ptr.To(true)}}
+		}),
+	)
+	// the fake clientset does not remove evicted pods on its own
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(clienttesting.CreateAction).GetObject().(metav1.Object).GetName()
+		return true, nil, client.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "default", name)
+	})
+	ctx := context.Background()
+
+	assert.NoError(t, Cordon(ctx, client, nodeName))
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+
+	drainCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	assert.NoError(t, Drain(drainCtx, client, nodeName))
+	pods, err := client.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "daemonset", pods.Items[0].Name)
+
+	assert.NoError(t, Delete(ctx, client, nodeName))
+	_, err = client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	// deleting a node that no longer exists is a no-op
+	assert.NoError(t, Delete(ctx, client, nodeName))
+}
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+var (
+	nodeWaiterMinDelay = 2 * time.Second
+	nodeWaiterMaxDelay = 15 * time.Second
+)
+
+// WaitForReady blocks until the node has registered with the cluster and
+// reports Ready, and explains what the node is waiting on when it is not
+// Ready after the timeout. The node is waited for while the cluster cannot be
+// reached once it has registered, but not when it is not allowed to read
+// itself.
+func WaitForReady(ctx context.Context, client kubernetes.Interface, nodeName string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	registrationCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	node, err := WaitForNode(registrationCtx, client, nodeName)
+	if err != nil {
+		if registrationCtx.Err() != nil && ctx.Err() == nil {
+			return fmt.Errorf("node %s did not register with the cluster within %s, check that kubelet is running and that its role is allowed to join the cluster", nodeName, timeout)
+		}
+		return err
+	}
+	if isReady(node) {
+		return nil
+	}
+	err = util.ErrWaiterTimeout
+	if remaining := time.Until(deadline); remaining > 0 {
+		err = util.Wait(ctx, func(ctx context.Context) (bool, error) {
+			latest, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+			if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+				return false, fmt.Errorf("node %s cannot read itself: %w", nodeName, err)
+			} else if err != nil {
+				zap.L().Warn("Failed to get node", zap.String("node", nodeName), zap.Error(err))
+				return false, nil
+			}
+			node = latest
+			return isReady(node), nil
+		}, nodeWaiterMinDelay, nodeWaiterMaxDelay, remaining)
+	}
+	if !errors.Is(err, util.ErrWaiterTimeout) {
+		return err
+	}
+	if ready := getReadyCondition(node); ready != nil {
+		return fmt.Errorf("node %s was not Ready within %s: %s: %s", nodeName, timeout, ready.Reason, ready.Message)
+	}
+	return fmt.Errorf("node %s did not report whether it is Ready within %s", nodeName, timeout)
+}
+
+func isReady(node *corev1.Node) bool {
+	ready := getReadyCondition(node)
+	return ready != nil && ready.Status == corev1.ConditionTrue
+}
+
+func getReadyCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
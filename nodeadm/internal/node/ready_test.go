@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func nodeWithReady(status corev1.ConditionStatus, reason string, message string) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	if status != "" {
+		node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status, Reason: reason, Message: message}}
+	}
+	return node
+}
+
+func TestWaitForReady(t *testing.T) {
+	nodeWaiterMinDelay = time.Millisecond
+	nodeWaiterMaxDelay = time.Millisecond
+
+	var tests = []struct {
+		name          string
+		nodes         []runtime.Object
+		expectedError string
+	}{
+		{
+			name:  "ready",
+			nodes: []runtime.Object{nodeWithReady(corev1.ConditionTrue, "KubeletReady", "kubelet is posting ready status")},
+		},
+		{
+			name:          "not ready",
+			nodes:         []runtime.Object{nodeWithReady(corev1.ConditionFalse, "KubeletNotReady", "container runtime network not ready")},
+			expectedError: "was not Ready within 20ms: KubeletNotReady: container runtime network not ready",
+		},
+		{
+			name:          "not reported",
+			nodes:         []runtime.Object{nodeWithReady("", "", "")},
+			expectedError: "did not report whether it is Ready",
+		},
+		{
+			name:          "not registered",
+			expectedError: "did not register with the cluster within 20ms",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientset(test.nodes...)
+			err := WaitForReady(context.Background(), client, nodeName, 20*time.Millisecond)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestWaitForReadyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitForReady(ctx, fake.NewClientset(), nodeName, time.Minute)
+	assert.ErrorIs(t, err, context.Canceled)
+}
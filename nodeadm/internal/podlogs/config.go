@@ -0,0 +1,47 @@
+package podlogs
+
+import (
+	"bytes"
+	_ "embed"
+	"text/template"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+const (
+	// ForwarderConfigPath is the Fluent Bit configuration of the forwarder. The
+	// forwarder is only started when it exists.
+	ForwarderConfigPath = "/etc/eks/nodeadm/pod-logs/fluent-bit.conf"
+	forwarderConfigPerm = 0644
+	// the positions of the forwarder in each log file are kept on disk, so that
+	// logs are not forwarded twice when the forwarder is restarted.
+	forwarderDBPath = "/var/lib/fluent-bit/pod-logs.db"
+)
+
+var (
+	//go:embed fluent-bit.template.conf
+	forwarderConfigTemplateData string
+	forwarderConfigTemplate     = template.Must(template.New(ForwarderConfigPath).Parse(forwarderConfigTemplateData))
+)
+
+type forwarderTemplateVars struct {
+	DBPath   string
+	Region   string
+	LogGroup string
+	NodeName string
+}
+
+func generateForwarderConfig(cfg *api.NodeConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := forwarderConfigTemplate.Execute(&buf, forwarderTemplateVars{
+		DBPath:   forwarderDBPath,
+		Region:   cfg.Status.Instance.Region,
+		LogGroup: cfg.Spec.Instance.PodLogs.Forwarder.CloudWatchLogGroup,
+		// each log file is forwarded to a stream prefixed with the node name
+		NodeName: kubelet.GetNodeName(cfg),
+	}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
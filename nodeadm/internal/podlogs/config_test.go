@@ -0,0 +1,33 @@
+package podlogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGenerateForwarderConfig(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Instance: api.InstanceOptions{
+				PodLogs: api.PodLogsOptions{
+					Forwarder: api.PodLogsForwarderOptions{CloudWatchLogGroup: "/eks/ci-cluster/pods"},
+				},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{
+				Region:         "us-west-2",
+				PrivateDNSName: "ip-10-0-0-1.us-west-2.compute.internal",
+			},
+		},
+	}
+	config, err := generateForwarderConfig(&cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), "region             us-west-2\n")
+	assert.Contains(t, string(config), "log_group_name     /eks/ci-cluster/pods\n")
+	assert.Contains(t, string(config), "log_stream_prefix  ip-10-0-0-1.us-west-2.compute.internal.\n")
+	assert.Contains(t, string(config), "DB                /var/lib/fluent-bit/pod-logs.db\n")
+}
@@ -0,0 +1,88 @@
+package podlogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	ForwarderDaemonName = "pod-log-forwarder"
+	forwarderBinaryName = "fluent-bit"
+	// the fluent-bit package installs its binary outside of the PATH
+	forwarderBinaryPath = "/opt/fluent-bit/bin/fluent-bit"
+)
+
+var (
+	_ daemon.Daemon      = &forwarder{}
+	_ daemon.Restartable = &forwarder{}
+)
+
+// forwarder manages a Fluent Bit unit that ships pod logs to CloudWatch Logs,
+// which matters most when they are stored in memory and lost on reboot.
+type forwarder struct {
+	daemonManager daemon.DaemonManager
+}
+
+func NewForwarderDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &forwarder{
+		daemonManager: daemonManager,
+	}
+}
+
+func (f *forwarder) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	if cfg.Spec.Instance.PodLogs.Forwarder.CloudWatchLogGroup == "" {
+		// the unit is only started when its config exists
+		if err := os.Remove(ForwarderConfigPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if !isForwarderInstalled() {
+		return fmt.Errorf("%s must be installed to forward pod logs", forwarderBinaryName)
+	}
+	config, err := generateForwarderConfig(cfg)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Writing pod log forwarder config..", zap.String("path", ForwarderConfigPath))
+	return util.WriteFileWithDir(ForwarderConfigPath, config, forwarderConfigPerm)
+}
+
+func isForwarderInstalled() bool {
+	if _, err := exec.LookPath(forwarderBinaryName); err == nil {
+		return true
+	}
+	_, err := os.Stat(forwarderBinaryPath)
+	return err == nil
+}
+
+func (f *forwarder) EnsureRunning() error {
+	if configured, err := util.IsFilePathExists(ForwarderConfigPath); err != nil {
+		return err
+	} else if !configured {
+		zap.L().Info("Pod log forwarding is not enabled")
+		return nil
+	}
+	return f.daemonManager.StartDaemon(ForwarderDaemonName)
+}
+
+func (f *forwarder) Restart() error {
+	return f.daemonManager.RestartDaemon(ForwarderDaemonName)
+}
+
+func (f *forwarder) PostLaunch(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (f *forwarder) Name() string {
+	return ForwarderDaemonName
+}
@@ -0,0 +1,42 @@
+package prepull
+
+import (
+	"context"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const PrePullDaemonName = "image-prepull"
+
+var (
+	_ daemon.Daemon   = &prePull{}
+	_ daemon.Unitless = &prePull{}
+)
+
+// prePull pulls images once containerd is running. It has no unit of its
+// own, and is only a daemon so that its post-launch task is ordered between
+// the start of containerd and that of kubelet, and is rolled back with them.
+type prePull struct{}
+
+func NewPrePullDaemon() daemon.Daemon {
+	return &prePull{}
+}
+
+func (p *prePull) Configure(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (p *prePull) EnsureRunning() error {
+	return nil
+}
+
+func (p *prePull) PostLaunch(ctx context.Context, cfg *api.NodeConfig) error {
+	return PullImages(ctx, cfg)
+}
+
+func (p *prePull) Name() string {
+	return PrePullDaemonName
+}
+
+func (p *prePull) Unitless() {}
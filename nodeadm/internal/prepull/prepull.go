@@ -0,0 +1,211 @@
+package prepull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/environment"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util/download"
+)
+
+const (
+	pullTimeout = 10 * time.Minute
+	// containerdNamespace is the namespace of the images of the CRI.
+	containerdNamespace = "k8s.io"
+	// defaultRegistry is the registry of references without a host, such as
+	// `busybox:latest`.
+	defaultRegistry = "docker.io"
+)
+
+// pullFunc pulls an image, with the credentials of its registry if it has any.
+type pullFunc func(image string, credentials *kubelet.RegistryCredentials) error
+
+// PullImages pulls the images to pre-pull with ctr, so that they are stored
+// as if kubelet had pulled them.
+func PullImages(ctx context.Context, cfg *api.NodeConfig) error {
+	prePull := cfg.Spec.Containerd.PrePullImages
+	if prePull.ImageListURL != "" {
+		images, err := fetchImageList(ctx, prePull.ImageListURL, cfg.Status.Instance.Region)
+		if err != nil {
+			if !prePull.FailOpen {
+				return err
+			}
+			zap.L().Warn("Continuing without the images of the image list", zap.Error(err))
+		}
+		prePull.Images = append(slices.Clone(prePull.Images), images...)
+	}
+	if len(prePull.Images) == 0 {
+		return nil
+	}
+	var snapshotter string
+	if api.IsFeatureEnabled(api.FastContainerImagePull, cfg.Spec.FeatureGates) {
+		snapshotter = containerd.SOCISnapshotter
+	}
+	tuning := cfg.GetBootstrapTuning()
+	concurrency := prePull.Concurrency
+	if concurrency == 0 {
+		concurrency = tuning.PullConcurrency
+	}
+	credentials := fetchCredentials(prePull.Images, cfg.Status.KubeletVersion)
+	if err := pullImages(&prePull, concurrency, credentials, func(image string, credentials *kubelet.RegistryCredentials) error {
+		return PullImage(ctx, image, credentials, snapshotter, tuning.PullAttempts)
+	}); err != nil {
+		return err
+	}
+	if snapshotter == containerd.SOCISnapshotter {
+		reportSOCIIndexes(prePull.Images, hasSOCIIndex)
+	}
+	return nil
+}
+
+// fetchImageList downloads a list of images, with one reference per line.
+func fetchImageList(ctx context.Context, imageListURL string, region string) ([]string, error) {
+	zap.L().Info("Downloading image list..", zap.String("url", imageListURL))
+	data, err := download.Fetch(ctx, imageListURL, download.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return parseImageList(data)
+}
+
+// parseImageList returns the references of a list of images, skipping blank
+// lines and comments.
+func parseImageList(data []byte) ([]string, error) {
+	var images []string
+	for _, line := range strings.Split(string(data), "\n") {
+		image := strings.TrimSpace(line)
+		if image == "" || strings.HasPrefix(image, "#") {
+			continue
+		}
+		// the references are passed to ctr as arguments
+		if strings.HasPrefix(image, "-") || strings.ContainsAny(image, " \t") {
+			return nil, fmt.Errorf("image %q in image list is not a valid reference", image)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// fetchCredentials fetches the credentials of the ECR registries of the
+// images. Registries whose credentials cannot be fetched are omitted, so that
+// their images fail to be pulled like any other.
+func fetchCredentials(images []string, kubeletVersion string) map[string]kubelet.RegistryCredentials {
+	credentials := make(map[string]kubelet.RegistryCredentials)
+	for _, image := range images {
+		registry := getRegistry(image)
+		if _, ok := credentials[registry]; ok || !ecr.IsECRRegistry(registry) {
+			continue
+		}
+		zap.L().Info("Fetching ECR credentials..", zap.String("registry", registry))
+		auths, err := kubelet.FetchRegistryCredentials(kubeletVersion, registry)
+		if err != nil {
+			zap.L().Warn("Failed to fetch ECR credentials", zap.String("registry", registry), zap.Error(err))
+			continue
+		}
+		for host, auth := range auths {
+			credentials[host] = auth
+		}
+	}
+	return credentials
+}
+
+// pullImages pulls the images with a limited number of pulls at once. Unless
+// the options fail open, an error is returned if any image is not pulled.
+func pullImages(prePull *api.PrePullImagesOptions, concurrency int, credentials map[string]kubelet.RegistryCredentials, pull pullFunc) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, image := range prePull.Images {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var auth *kubelet.RegistryCredentials
+			if c, ok := credentials[getRegistry(image)]; ok {
+				auth = &c
+			}
+			zap.L().Info("Pulling image..", zap.String("image", image))
+			if err := pull(image, auth); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("failed to pull image %s: %w", image, err))
+				mu.Unlock()
+				return
+			}
+			zap.L().Info("Pulled image", zap.String("image", image))
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		if prePull.FailOpen {
+			zap.L().Warn("Continuing without images that could not be pre-pulled", zap.Error(err))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// PullImage pulls an image into the namespace of the CRI of containerd,
+// unpacked with the snapshotter of the CRI and resolved with its registry
+// hosts, retrying transient failures. The credentials are written to a copy of
+// the registry hosts that only root can read, rather than passed on the
+// command line.
+func PullImage(ctx context.Context, image string, credentials *kubelet.RegistryCredentials, snapshotter string, attempts int) error {
+	hostsDir := containerd.RegistryHostsDir
+	if credentials != nil {
+		authHostsDir, err := containerd.WriteAuthHostsDir(hostsDir, getRegistry(image), credentials.Username, credentials.Password)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(authHostsDir)
+		hostsDir = authHostsDir
+	}
+	args := []string{"--namespace", containerdNamespace, "images", "pull", "--hosts-dir", hostsDir, "--platform", environment.Current().Platform()}
+	if snapshotter != "" {
+		args = append(args, "--snapshotter", snapshotter)
+	}
+	args = append(args, "--", image)
+	return util.NewRetrier(util.WithRetryCount(attempts)).Retry(ctx, func() error {
+		ctx, cancel := context.WithTimeout(ctx, pullTimeout)
+		defer cancel()
+		// #nosec G204 Subprocess launched with variable
+		cmd := exec.CommandContext(ctx, "ctr", args...)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	})
+}
+
+// getRegistry returns the host of the registry of an image reference, using
+// the same rules as containerd to tell a host from the first component of a
+// repository.
+func getRegistry(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if !found {
+		return defaultRegistry
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return first
+	}
+	return defaultRegistry
+}
@@ -0,0 +1,143 @@
+package prepull
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+func TestGetRegistry(t *testing.T) {
+	var tests = []struct {
+		image    string
+		expected string
+	}{
+		{image: "busybox", expected: "docker.io"},
+		{image: "library/busybox:latest", expected: "docker.io"},
+		{image: "public.ecr.aws/eks-distro/kubernetes/pause:3.9", expected: "public.ecr.aws"},
+		{image: "602401143452.dkr.ecr.us-west-2.amazonaws.com/amazon-k8s-cni:v1.19.0", expected: "602401143452.dkr.ecr.us-west-2.amazonaws.com"},
+		{image: "localhost/my-image", expected: "localhost"},
+		{image: "registry:5000/my-image", expected: "registry:5000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			assert.Equal(t, test.expected, getRegistry(test.image))
+		})
+	}
+}
+
+func TestParseImageList(t *testing.T) {
+	images, err := parseImageList([]byte("# critical DaemonSets\npublic.ecr.aws/eks-distro/kubernetes/pause:3.9\n\n  busybox:latest  \r\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"public.ecr.aws/eks-distro/kubernetes/pause:3.9", "busybox:latest"}, images)
+
+	_, err = parseImageList([]byte("busybox --help\n"))
+	assert.Error(t, err)
+	_, err = parseImageList([]byte("--help\n"))
+	assert.Error(t, err)
+}
+
+func TestPullImages(t *testing.T) {
+	ecrImage := "602401143452.dkr.ecr.us-west-2.amazonaws.com/amazon-k8s-cni:v1.19.0"
+	credentials := map[string]kubelet.RegistryCredentials{
+		"602401143452.dkr.ecr.us-west-2.amazonaws.com": {Username: "AWS", Password: "token"},
+	}
+	prePull := api.PrePullImagesOptions{
+		Images: []string{ecrImage, "public.ecr.aws/eks-distro/kubernetes/pause:3.9", "busybox"},
+	}
+
+	var mu sync.Mutex
+	pulled := map[string]*kubelet.RegistryCredentials{}
+	var running, maxRunning atomic.Int32
+	err := pullImages(&prePull, 2, credentials, func(image string, credentials *kubelet.RegistryCredentials) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			if m := maxRunning.Load(); n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		pulled[image] = credentials
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pulled, 3)
+	assert.Equal(t, &kubelet.RegistryCredentials{Username: "AWS", Password: "token"}, pulled[ecrImage])
+	assert.Nil(t, pulled["busybox"])
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestPullImagesFailure(t *testing.T) {
+	prePull := api.PrePullImagesOptions{
+		Images: []string{"busybox", "public.ecr.aws/missing/image:latest"},
+	}
+	pull := func(image string, _ *kubelet.RegistryCredentials) error {
+		if image == "busybox" {
+			return nil
+		}
+		return fmt.Errorf("not found")
+	}
+	assert.ErrorContains(t, pullImages(&prePull, 1, nil, pull), "public.ecr.aws/missing/image:latest")
+
+	prePull.FailOpen = true
+	assert.NoError(t, pullImages(&prePull, 1, nil, pull))
+}
+
+func TestParseImageDigest(t *testing.T) {
+	out := "REF                                             TYPE                                    DIGEST                                                                  SIZE    PLATFORMS   LABELS\n" +
+		"public.ecr.aws/eks-distro/kubernetes/pause:3.9 application/vnd.oci.image.index.v1+json sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef 1.2 MiB linux/amd64 -\n"
+	digest, err := parseImageDigest(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", digest)
+
+	_, err = parseImageDigest("REF TYPE DIGEST SIZE PLATFORMS LABELS\n")
+	assert.Error(t, err)
+}
+
+func TestHasSOCIIndexAnnotation(t *testing.T) {
+	content := map[string]string{
+		"sha256:index": `{"manifests": [
+			{"digest": "sha256:amd64", "platform": {"os": "linux", "architecture": "amd64"}},
+			{"digest": "sha256:arm64", "platform": {"os": "linux", "architecture": "arm64"}, "annotations": {"com.amazon.soci.index-digest": "sha256:soci"}}
+		]}`,
+		"sha256:amd64":     `{"layers": []}`,
+		"sha256:indexed":   `{"layers": [], "annotations": {"com.amazon.soci.index-digest": "sha256:soci"}}`,
+		"sha256:converted": `{"manifests": [{"digest": "sha256:indexed", "platform": {"os": "linux", "architecture": "amd64"}}]}`,
+	}
+	get := func(digest string) ([]byte, error) {
+		if data, ok := content[digest]; ok {
+			return []byte(data), nil
+		}
+		return nil, fmt.Errorf("not found")
+	}
+	var tests = []struct {
+		name         string
+		digest       string
+		architecture string
+		expected     bool
+	}{
+		{name: "annotated descriptor", digest: "sha256:index", architecture: "arm64", expected: true},
+		{name: "without index", digest: "sha256:index", architecture: "amd64", expected: false},
+		{name: "annotated manifest", digest: "sha256:converted", architecture: "amd64", expected: true},
+		{name: "single manifest", digest: "sha256:indexed", architecture: "amd64", expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := hasSOCIIndexAnnotation(test.digest, test.architecture, get)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+	_, err := hasSOCIIndexAnnotation("sha256:missing", "amd64", get)
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package prepull
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/environment"
+)
+
+// sociIndexDigestAnnotation annotates the manifest of an image that was
+// converted with a SOCI index, which the soci-snapshotter lazily pulls.
+const sociIndexDigestAnnotation = "com.amazon.soci.index-digest"
+
+// indexCheckFunc returns whether a pulled image has a SOCI index.
+type indexCheckFunc func(image string) (bool, error)
+
+// contentGetFunc reads a blob of the content store of containerd.
+type contentGetFunc func(digest string) ([]byte, error)
+
+// ociContent is the subset of an OCI image index or image manifest that tells
+// whether the image has a SOCI index.
+type ociContent struct {
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform *struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+		} `json:"platform,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"manifests,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// reportSOCIIndexes counts the pre-pulled images that have a SOCI index, and
+// warns about the others, which the soci-snapshotter pulls with its fallback
+// rather than lazily wherever the node runs them. Without the warning, a
+// registry that serves images without indexes silently slows down the pulls
+// of the node.
+func reportSOCIIndexes(images []string, hasIndex indexCheckFunc) {
+	var indexed int
+	var withoutIndex, unknown []string
+	for _, image := range images {
+		ok, err := hasIndex(image)
+		if err != nil {
+			zap.L().Debug("Failed to check image for a SOCI index", zap.String("image", image), zap.Error(err))
+			unknown = append(unknown, image)
+		} else if ok {
+			indexed++
+		} else {
+			withoutIndex = append(withoutIndex, image)
+		}
+	}
+	zap.L().Info("Checked pre-pulled images for SOCI indexes",
+		zap.Int("indexed", indexed),
+		zap.Int("withoutIndex", len(withoutIndex)),
+		zap.Int("unknown", len(unknown)))
+	if len(withoutIndex) > 0 {
+		zap.L().Warn("Images without a SOCI index are pulled with the fallback of the soci-snapshotter rather than lazily", zap.Strings("images", withoutIndex))
+	}
+}
+
+// hasSOCIIndex returns whether an image that was pulled into the namespace of
+// the CRI has a SOCI index for the platform of the node.
+func hasSOCIIndex(image string) (bool, error) {
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command("ctr", "--namespace", containerdNamespace, "images", "list", "name=="+image).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to list image: %w", err)
+	}
+	digest, err := parseImageDigest(string(out))
+	if err != nil {
+		return false, err
+	}
+	return hasSOCIIndexAnnotation(digest, string(environment.Current().Architecture), getContent)
+}
+
+// parseImageDigest returns the digest of the only image in the output of
+// `ctr images list`.
+func parseImageDigest(out string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		return "", fmt.Errorf("image is not in the content store")
+	}
+	// the header is followed by the reference, the type and the digest
+	fields := strings.Fields(lines[1])
+	if len(fields) < 3 {
+		return "", fmt.Errorf("failed to parse image %q", lines[1])
+	}
+	return fields[2], nil
+}
+
+// hasSOCIIndexAnnotation returns whether the manifest of the image, or its
+// descriptor in the image index, is annotated with a SOCI index.
+func hasSOCIIndexAnnotation(digest string, architecture string, get contentGetFunc) (bool, error) {
+	content, err := readContent(digest, get)
+	if err != nil {
+		return false, err
+	}
+	if len(content.Manifests) == 0 {
+		_, ok := content.Annotations[sociIndexDigestAnnotation]
+		return ok, nil
+	}
+	for _, manifest := range content.Manifests {
+		if manifest.Platform == nil || manifest.Platform.OS != "linux" || manifest.Platform.Architecture != architecture {
+			continue
+		}
+		if _, ok := manifest.Annotations[sociIndexDigestAnnotation]; ok {
+			return true, nil
+		}
+		// only the manifest of the platform of the node is pulled
+		if manifest, err := readContent(manifest.Digest, get); err != nil {
+			return false, err
+		} else if _, ok := manifest.Annotations[sociIndexDigestAnnotation]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func readContent(digest string, get contentGetFunc) (*ociContent, error) {
+	data, err := get(digest)
+	if err != nil {
+		return nil, err
+	}
+	var content ociContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse content %s: %w", digest, err)
+	}
+	return &content, nil
+}
+
+// getContent reads a blob of the content store of containerd with ctr.
+func getContent(digest string) ([]byte, error) {
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command("ctr", "--namespace", containerdNamespace, "content", "get", digest).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content %s: %w", digest, err)
+	}
+	return out, nil
+}
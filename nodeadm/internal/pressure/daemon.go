@@ -0,0 +1,55 @@
+package pressure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+const (
+	PressureMonitorDaemonName = "nodeadm-pressure-monitor"
+
+	environmentFilePath = "/etc/eks/nodeadm/pressure-monitor/environment"
+	argsEnvironmentName = "NODEADM_PRESSURE_MONITOR_ARGS"
+)
+
+var (
+	_ daemon.Daemon      = &pressureMonitor{}
+	_ daemon.Restartable = &pressureMonitor{}
+)
+
+// pressureMonitor manages the unit that runs `nodeadm monitor`.
+type pressureMonitor struct {
+	daemon.EnvironmentUnit
+}
+
+func NewPressureMonitorDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &pressureMonitor{
+		EnvironmentUnit: daemon.NewEnvironmentUnit(daemonManager, PressureMonitorDaemonName, environmentFilePath, argsEnvironmentName),
+	}
+}
+
+func (p *pressureMonitor) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	monitor := cfg.Spec.Instance.PressureMonitor
+	if !monitor.Enabled {
+		return p.Disable()
+	}
+	return p.Enable(generateArgs(kubelet.GetNodeName(cfg), monitor))
+}
+
+func generateArgs(nodeName string, monitor api.PressureMonitorOptions) string {
+	args := fmt.Sprintf("--node-name=%s", nodeName)
+	if monitor.MemoryThreshold != 0 {
+		args += fmt.Sprintf(" --memory-threshold=%d", monitor.MemoryThreshold)
+	}
+	if monitor.Action != "" {
+		args += fmt.Sprintf(" --action=%s", monitor.Action)
+	}
+	if monitor.MetricsPort != 0 {
+		args += fmt.Sprintf(" --metrics-port=%d", monitor.MetricsPort)
+	}
+	return args
+}
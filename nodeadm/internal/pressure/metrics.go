@@ -0,0 +1,94 @@
+package pressure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "nodeadm"
+
+// Metrics are the Prometheus metrics of the monitor, which are only served
+// when a port is configured.
+type Metrics struct {
+	registry  *prometheus.Registry
+	stall     *prometheus.GaugeVec
+	stalled   prometheus.Gauge
+	oomKills  prometheus.Counter
+	evictions prometheus.Counter
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		stall: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "pressure_stall_avg10_ratio",
+			Help:      "Share of the last 10 seconds in which tasks were stalled on a resource.",
+		}, []string{"resource", "kind"}),
+		stalled: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "memory_pressure_stalled",
+			Help:      "Whether the node is under memory pressure.",
+		}),
+		oomKills: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "oom_kills_total",
+			Help:      "Number of processes killed by the kernel OOM killer while the monitor was running.",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pressure_evictions_total",
+			Help:      "Number of pods evicted to relieve memory pressure.",
+		}),
+	}
+	m.registry.MustRegister(m.stall, m.stalled, m.oomKills, m.evictions)
+	return m
+}
+
+func (m *Metrics) observePressure(pressures map[string]Pressure) {
+	for resource, pressure := range pressures {
+		m.stall.WithLabelValues(resource, "some").Set(pressure.Some.Avg10 / 100)
+		m.stall.WithLabelValues(resource, "full").Set(pressure.Full.Avg10 / 100)
+	}
+}
+
+func (m *Metrics) observeStalled(stalled bool) {
+	if stalled {
+		m.stalled.Set(1)
+	} else {
+		m.stalled.Set(0)
+	}
+}
+
+func (m *Metrics) observeOOMKills(count uint64) {
+	m.oomKills.Add(float64(count))
+}
+
+func (m *Metrics) observeEviction() {
+	m.evictions.Inc()
+}
+
+// Serve serves the metrics at /metrics until the context is done.
+func (m *Metrics) Serve(ctx context.Context, port int) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
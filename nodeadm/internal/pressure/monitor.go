@@ -0,0 +1,295 @@
+package pressure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+)
+
+const (
+	// MemoryPressureStallConditionType is the type of the Node condition that
+	// reports whether processes on the node are stalled waiting for memory. The
+	// condition is True while the node is under memory pressure.
+	MemoryPressureStallConditionType corev1.NodeConditionType = "MemoryPressureStall"
+
+	DefaultMemoryThreshold = 20
+	DefaultInterval        = 5 * time.Second
+
+	// the condition is updated at least this often, so that a stale condition
+	// can be told apart from a monitor that stopped.
+	conditionHeartbeatInterval = time.Minute
+	// pods are evicted one at a time, and the memory they release takes a
+	// while to be reflected in the averages.
+	evictionInterval = 30 * time.Second
+
+	// cordonedByAnnotation marks a node that was cordoned by the monitor, so
+	// that it only uncordons nodes it cordoned itself, even after a restart.
+	cordonedByAnnotation = "node.eks.aws/cordoned-by"
+	cordonedByValue      = "nodeadm-pressure-monitor"
+)
+
+type Config struct {
+	NodeName string
+	// MemoryThreshold is the percentage of time in which some processes were
+	// stalled waiting for memory, above which the node is under pressure.
+	MemoryThreshold int
+	Action          api.PressureAction
+}
+
+// Monitor watches the pressure stall information and OOM kills of the node,
+// and reports memory pressure before kubelet does. kubelet only evicts pods
+// once available memory falls below its thresholds, by which point the kernel
+// may already be reclaiming aggressively enough to stall containerd and
+// kubelet themselves.
+type Monitor struct {
+	client  kubernetes.Interface
+	config  Config
+	metrics *Metrics
+
+	readPressure func() (map[string]Pressure, error)
+	readOOMKills func() (uint64, error)
+	now          func() time.Time
+
+	underPressure    bool
+	conditionUpdated time.Time
+	lastEviction     time.Time
+	oomKills         *uint64
+	// cordoned is whether the node is cordoned by the monitor, which is only
+	// known once the node has been read.
+	cordoned *bool
+	// cordonHandled is whether the node was cordoned since it came under
+	// pressure, by the monitor or by someone else.
+	cordonHandled bool
+}
+
+func NewMonitor(client kubernetes.Interface, config Config, metrics *Metrics) *Monitor {
+	if config.MemoryThreshold == 0 {
+		config.MemoryThreshold = DefaultMemoryThreshold
+	}
+	if config.Action == "" {
+		config.Action = api.PressureActionReport
+	}
+	return &Monitor{
+		client:       client,
+		config:       config,
+		metrics:      metrics,
+		readPressure: ReadPressure,
+		readOOMKills: ReadOOMKills,
+		now:          time.Now,
+	}
+}
+
+// Run checks the node every interval until the context is done. Failures to
+// check the node are logged, and do not stop the monitor.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	if _, err := m.readPressure(); err != nil {
+		return fmt.Errorf("pressure stall information is not available: %w", err)
+	}
+	zap.L().Info("Waiting for node registration..", zap.String("node", m.config.NodeName))
+	if _, err := node.WaitForNode(ctx, m.client, m.config.NodeName); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := m.check(ctx); err != nil {
+			zap.L().Warn("Failed to check node pressure", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) error {
+	m.checkOOMKills()
+	pressures, err := m.readPressure()
+	if err != nil {
+		return err
+	}
+	m.metrics.observePressure(pressures)
+
+	memory := pressures["memory"].Some
+	threshold := float64(m.config.MemoryThreshold)
+	transitioned := false
+	if !m.underPressure && memory.Avg10 >= threshold {
+		zap.L().Warn("Node is under memory pressure", zap.Float64("avg10", memory.Avg10), zap.Int("threshold", m.config.MemoryThreshold))
+		m.underPressure, transitioned = true, true
+	} else if m.underPressure && memory.Avg10 < threshold && memory.Avg60 < threshold {
+		// the longer average must also recover, so that the node does not
+		// flap between states while the pressure is intermittent
+		zap.L().Info("Node is no longer under memory pressure", zap.Float64("avg10", memory.Avg10), zap.Float64("avg60", memory.Avg60))
+		m.underPressure, transitioned = false, true
+	}
+	m.metrics.observeStalled(m.underPressure)
+
+	if transitioned || m.now().Sub(m.conditionUpdated) >= conditionHeartbeatInterval {
+		if err := node.SetCondition(ctx, m.client, m.config.NodeName, m.condition(memory)); err != nil {
+			return fmt.Errorf("failed to set condition %s: %w", MemoryPressureStallConditionType, err)
+		}
+		m.conditionUpdated = m.now()
+	}
+
+	if !m.underPressure {
+		m.cordonHandled = false
+		return m.uncordon(ctx)
+	}
+	if m.config.Action == api.PressureActionReport {
+		return nil
+	}
+	if !m.cordonHandled {
+		if err := m.cordon(ctx); err != nil {
+			return err
+		}
+		m.cordonHandled = true
+	}
+	if m.config.Action == api.PressureActionEvict && m.now().Sub(m.lastEviction) >= evictionInterval {
+		return m.evict(ctx)
+	}
+	return nil
+}
+
+func (m *Monitor) condition(memory Stall) corev1.NodeCondition {
+	condition := corev1.NodeCondition{
+		Type:    MemoryPressureStallConditionType,
+		Status:  corev1.ConditionFalse,
+		Reason:  "MemoryNotStalled",
+		Message: fmt.Sprintf("processes were stalled waiting for memory %.2f%% of the last 10 seconds, below the threshold of %d%%", memory.Avg10, m.config.MemoryThreshold),
+	}
+	if m.underPressure {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "MemoryStalled"
+		condition.Message = fmt.Sprintf("processes were stalled waiting for memory %.2f%% of the last 10 seconds, above the threshold of %d%%", memory.Avg10, m.config.MemoryThreshold)
+	}
+	return condition
+}
+
+func (m *Monitor) checkOOMKills() {
+	kills, err := m.readOOMKills()
+	if err != nil {
+		zap.L().Warn("Failed to read OOM kills", zap.Error(err))
+		return
+	}
+	if m.oomKills == nil {
+		zap.L().Info("Processes killed by the kernel OOM killer since boot", zap.Uint64("count", kills))
+	} else if kills > *m.oomKills {
+		zap.L().Warn("Processes were killed by the kernel OOM killer", zap.Uint64("count", kills-*m.oomKills), zap.Uint64("total", kills))
+		m.metrics.observeOOMKills(kills - *m.oomKills)
+	}
+	m.oomKills = &kills
+}
+
+// cordon marks the node as unschedulable. Nodes are not allowed to taint
+// themselves, so the node lifecycle controller applies the
+// node.kubernetes.io/unschedulable taint on the monitor's behalf.
+func (m *Monitor) cordon(ctx context.Context) error {
+	node, err := m.client.CoreV1().Nodes().Get(ctx, m.config.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable {
+		// the node was cordoned by someone else, who is left to uncordon it
+		return nil
+	}
+	zap.L().Info("Cordoning node under memory pressure..", zap.String("node", m.config.NodeName))
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}},"spec":{"unschedulable":true}}`, cordonedByAnnotation, cordonedByValue))
+	if _, err := m.client.CoreV1().Nodes().Patch(ctx, m.config.NodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	cordoned := true
+	m.cordoned = &cordoned
+	return nil
+}
+
+// uncordon marks the node as schedulable, if it was cordoned by the monitor.
+func (m *Monitor) uncordon(ctx context.Context) error {
+	if m.cordoned == nil {
+		// the monitor may have been restarted while the node was cordoned
+		node, err := m.client.CoreV1().Nodes().Get(ctx, m.config.NodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cordoned := node.Annotations[cordonedByAnnotation] == cordonedByValue
+		m.cordoned = &cordoned
+	}
+	if !*m.cordoned {
+		return nil
+	}
+	zap.L().Info("Uncordoning node..", zap.String("node", m.config.NodeName))
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:null}},"spec":{"unschedulable":false}}`, cordonedByAnnotation))
+	if _, err := m.client.CoreV1().Nodes().Patch(ctx, m.config.NodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	*m.cordoned = false
+	return nil
+}
+
+// evict evicts the first candidate that can be evicted without violating a
+// disruption budget.
+func (m *Monitor) evict(ctx context.Context) error {
+	pods, err := node.GetDrainablePods(ctx, m.client, m.config.NodeName)
+	if err != nil {
+		return err
+	}
+	candidates := getEvictionCandidates(pods)
+	if len(candidates) == 0 {
+		zap.L().Warn("Node is under memory pressure, but no pods can be evicted")
+		return nil
+	}
+	for _, pod := range candidates {
+		podFields := []zap.Field{zap.String("namespace", pod.Namespace), zap.String("name", pod.Name), zap.String("qosClass", string(pod.Status.QOSClass))}
+		zap.L().Info("Evicting pod to relieve memory pressure..", podFields...)
+		if err := node.Evict(ctx, m.client, pod); err != nil {
+			zap.L().Warn("Failed to evict pod", append(podFields, zap.Error(err))...)
+			continue
+		}
+		m.lastEviction = m.now()
+		m.metrics.observeEviction()
+		return nil
+	}
+	return fmt.Errorf("none of the %d candidate pods could be evicted", len(candidates))
+}
+
+// getEvictionCandidates returns the pods that may be evicted under memory
+// pressure, in the order they should be evicted: lowest priority first, then
+// BestEffort before Burstable pods, then the most recently created. Guaranteed
+// pods are never evicted, as kubelet also only evicts them last.
+func getEvictionCandidates(pods []corev1.Pod) []corev1.Pod {
+	var candidates []corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.QOSClass == corev1.PodQOSGuaranteed {
+			continue
+		}
+		candidates = append(candidates, pod)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := &candidates[i], &candidates[j]
+		if getPriority(a) != getPriority(b) {
+			return getPriority(a) < getPriority(b)
+		}
+		if aBestEffort, bBestEffort := a.Status.QOSClass == corev1.PodQOSBestEffort, b.Status.QOSClass == corev1.PodQOSBestEffort; aBestEffort != bBestEffort {
+			return aBestEffort
+		}
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	})
+	return candidates
+}
+
+func getPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
@@ -0,0 +1,150 @@
+package pressure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const nodeName = "my-node"
+
+func newPod(name string, mutate func(*corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, QOSClass: corev1.PodQOSBurstable},
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+func TestGetEvictionCandidates(t *testing.T) {
+	now := time.Now()
+	pods := []corev1.Pod{
+		*newPod("guaranteed", func(p *corev1.Pod) { p.Status.QOSClass = corev1.PodQOSGuaranteed }),
+		*newPod("burstable", nil),
+		*newPod("best-effort", func(p *corev1.Pod) { p.Status.QOSClass = corev1.PodQOSBestEffort }),
+		*newPod("high-priority", func(p *corev1.Pod) {
+			p.Spec.Priority = ptr.To[int32](1000)
+			p.Status.QOSClass = corev1.PodQOSBestEffort
+		}),
+		*newPod("low-priority", func(p *corev1.Pod) { p.Spec.Priority = ptr.To[int32](-10) }),
+		*newPod("newer-burstable", func(p *corev1.Pod) { p.CreationTimestamp = metav1.NewTime(now) }),
+		*newPod("terminating", func(p *corev1.Pod) { p.DeletionTimestamp = ptr.To(metav1.NewTime(now)) }),
+	}
+
+	var names []string
+	for _, pod := range getEvictionCandidates(pods) {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"low-priority", "best-effort", "newer-burstable", "burstable", "high-priority"}, names)
+}
+
+func TestMonitor(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+		newPod("burstable", nil),
+		newPod("guaranteed", func(p *corev1.Pod) { p.Status.QOSClass = corev1.PodQOSGuaranteed }),
+	)
+	// the fake clientset does not remove evicted pods on its own
+	client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(clienttesting.CreateAction).GetObject().(metav1.Object).GetName()
+		return true, nil, client.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "default", name)
+	})
+	ctx := context.Background()
+
+	var memory Stall
+	oomKills := uint64(3)
+	monitor := NewMonitor(client, Config{NodeName: nodeName, Action: api.PressureActionEvict}, NewMetrics())
+	monitor.readPressure = func() (map[string]Pressure, error) {
+		return map[string]Pressure{"memory": {Some: memory}}, nil
+	}
+	monitor.readOOMKills = func() (uint64, error) { return oomKills, nil }
+
+	getNode := func() *corev1.Node {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return node
+	}
+	getConditionStatus := func() corev1.ConditionStatus {
+		for _, condition := range getNode().Status.Conditions {
+			if condition.Type == MemoryPressureStallConditionType {
+				return condition.Status
+			}
+		}
+		return corev1.ConditionUnknown
+	}
+	getPodNames := func() []string {
+		pods, err := client.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		var names []string
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	memory = Stall{Avg10: 5, Avg60: 5}
+	assert.NoError(t, monitor.check(ctx))
+	assert.Equal(t, corev1.ConditionFalse, getConditionStatus())
+	assert.False(t, getNode().Spec.Unschedulable)
+
+	memory = Stall{Avg10: 30, Avg60: 10}
+	oomKills = 4
+	assert.NoError(t, monitor.check(ctx))
+	assert.Equal(t, corev1.ConditionTrue, getConditionStatus())
+	assert.True(t, getNode().Spec.Unschedulable)
+	assert.Equal(t, cordonedByValue, getNode().Annotations[cordonedByAnnotation])
+	// guaranteed pods are never evicted
+	assert.Equal(t, []string{"guaranteed"}, getPodNames())
+
+	// the node stays under pressure until the longer average recovers
+	memory = Stall{Avg10: 10, Avg60: 25}
+	assert.NoError(t, monitor.check(ctx))
+	assert.Equal(t, corev1.ConditionTrue, getConditionStatus())
+
+	memory = Stall{Avg10: 10, Avg60: 15}
+	assert.NoError(t, monitor.check(ctx))
+	assert.Equal(t, corev1.ConditionFalse, getConditionStatus())
+	assert.False(t, getNode().Spec.Unschedulable)
+	assert.NotContains(t, getNode().Annotations, cordonedByAnnotation)
+}
+
+func TestMonitorLeavesNodesCordonedBySomeoneElse(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+	})
+	ctx := context.Background()
+
+	var memory Stall
+	monitor := NewMonitor(client, Config{NodeName: nodeName, Action: api.PressureActionCordon}, NewMetrics())
+	monitor.readPressure = func() (map[string]Pressure, error) {
+		return map[string]Pressure{"memory": {Some: memory}}, nil
+	}
+	monitor.readOOMKills = func() (uint64, error) { return 0, nil }
+
+	memory = Stall{Avg10: 50, Avg60: 50}
+	assert.NoError(t, monitor.check(ctx))
+	memory = Stall{}
+	assert.NoError(t, monitor.check(ctx))
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+}
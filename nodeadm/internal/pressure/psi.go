@@ -0,0 +1,127 @@
+package pressure
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	pressureDirPath = "/proc/pressure"
+	vmstatPath      = "/proc/vmstat"
+	oomKillCounter  = "oom_kill"
+)
+
+// the resources for which the kernel reports pressure stall information.
+var pressureResources = []string{"cpu", "memory", "io"}
+
+// Stall is the share of time in which tasks were stalled on a resource, as
+// percentages averaged over 10, 60 and 300 seconds.
+type Stall struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+}
+
+// Pressure is the pressure stall information of a resource. Some is the time in
+// which at least one task was stalled, and Full is the time in which all tasks
+// were stalled at once.
+type Pressure struct {
+	Some Stall
+	Full Stall
+}
+
+// ReadPressure reads the pressure stall information of each resource, which
+// requires a kernel with CONFIG_PSI enabled.
+func ReadPressure() (map[string]Pressure, error) {
+	pressures := make(map[string]Pressure)
+	for _, resource := range pressureResources {
+		file, err := os.Open(filepath.Join(pressureDirPath, resource))
+		if err != nil {
+			return nil, err
+		}
+		pressure, err := parsePressure(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s pressure: %w", resource, err)
+		}
+		pressures[resource] = pressure
+	}
+	return pressures, nil
+}
+
+// parsePressure parses a file such as /proc/pressure/memory:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// The cpu file of older kernels has no full line.
+func parsePressure(r io.Reader) (Pressure, error) {
+	var pressure Pressure
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var stall *Stall
+		switch fields[0] {
+		case "some":
+			stall = &pressure.Some
+		case "full":
+			stall = &pressure.Full
+		default:
+			return pressure, fmt.Errorf("unexpected line %q", scanner.Text())
+		}
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, "=")
+			var avg *float64
+			switch key {
+			case "avg10":
+				avg = &stall.Avg10
+			case "avg60":
+				avg = &stall.Avg60
+			case "avg300":
+				avg = &stall.Avg300
+			default:
+				continue
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return pressure, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			*avg = parsed
+		}
+	}
+	return pressure, scanner.Err()
+}
+
+// ReadOOMKills reads the number of processes that have been killed by the
+// kernel because the system or a cgroup ran out of memory since boot.
+func ReadOOMKills() (uint64, error) {
+	file, err := os.Open(vmstatPath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return parseOOMKills(file)
+}
+
+func parseOOMKills(r io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, value, _ := strings.Cut(scanner.Text(), " ")
+		if name == oomKillCounter {
+			return strconv.ParseUint(value, 10, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	// the counter was added in linux 4.13
+	return 0, fmt.Errorf("%s is not reported in %s", oomKillCounter, vmstatPath)
+}
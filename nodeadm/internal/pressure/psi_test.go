@@ -0,0 +1,55 @@
+package pressure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePressure(t *testing.T) {
+	var tests = []struct {
+		name      string
+		input     string
+		expected  Pressure
+		expectErr bool
+	}{
+		{
+			name: "memory",
+			input: "some avg10=25.50 avg60=10.00 avg300=2.25 total=123456\n" +
+				"full avg10=5.00 avg60=1.50 avg300=0.00 total=2345\n",
+			expected: Pressure{
+				Some: Stall{Avg10: 25.5, Avg60: 10, Avg300: 2.25},
+				Full: Stall{Avg10: 5, Avg60: 1.5},
+			},
+		},
+		{
+			name:     "cpu of older kernels",
+			input:    "some avg10=1.00 avg60=0.50 avg300=0.25 total=100\n",
+			expected: Pressure{Some: Stall{Avg10: 1, Avg60: 0.5, Avg300: 0.25}},
+		},
+		{name: "unknown line", input: "partial avg10=1.00\n", expectErr: true},
+		{name: "invalid average", input: "some avg10=high\n", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pressure, err := parsePressure(strings.NewReader(test.input))
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, pressure)
+			}
+		})
+	}
+}
+
+func TestParseOOMKills(t *testing.T) {
+	kills, err := parseOOMKills(strings.NewReader("nr_free_pages 12345\noom_kill 7\nnr_zone_active_anon 0\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), kills)
+
+	_, err = parseOOMKills(strings.NewReader("nr_free_pages 12345\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package smoketest
+
+import (
+	"context"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const SmokeTestDaemonName = "runtime-smoke-test"
+
+var (
+	_ daemon.Daemon   = &smokeTest{}
+	_ daemon.Unitless = &smokeTest{}
+)
+
+// smokeTest tests the container runtime once containerd is running. Like the
+// pre-pull of images, it has no unit of its own, and is only a daemon so that
+// its post-launch task is ordered between the start of containerd and that of
+// kubelet.
+type smokeTest struct{}
+
+func NewSmokeTestDaemon() daemon.Daemon {
+	return &smokeTest{}
+}
+
+func (s *smokeTest) Configure(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (s *smokeTest) EnsureRunning() error {
+	return nil
+}
+
+func (s *smokeTest) PostLaunch(_ context.Context, cfg *api.NodeConfig) error {
+	if !cfg.Spec.Containerd.SmokeTest.Enabled {
+		return nil
+	}
+	return Run(cfg)
+}
+
+func (s *smokeTest) Name() string {
+	return SmokeTestDaemonName
+}
+
+func (s *smokeTest) Unitless() {}
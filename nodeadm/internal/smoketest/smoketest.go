@@ -0,0 +1,256 @@
+package smoketest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/prepull"
+)
+
+const (
+	defaultTimeout = 2 * time.Minute
+
+	sandboxName      = "nodeadm-smoke-test"
+	sandboxNamespace = "kube-system"
+	// namespaceModeNode runs the sandbox in the network namespace of the node,
+	// since the CNI is only configured once the node has registered.
+	namespaceModeNode = 2
+	// networkReadyCondition is not met until the CNI is configured, which is
+	// expected before kubelet has started.
+	networkReadyCondition = "NetworkReady"
+
+	// smokeTestExitCode is the exit code of nodeadm when the container runtime
+	// fails the smoke test.
+	smokeTestExitCode = 20
+)
+
+// crictlFunc runs crictl against the CRI of containerd, and returns its
+// combined output.
+type crictlFunc func(ctx context.Context, args ...string) ([]byte, error)
+
+// pullFunc pulls an image with the credentials of its registry.
+type pullFunc func(ctx context.Context, image string, credentials *kubelet.RegistryCredentials) error
+
+// SmokeTestError is returned when a step of the smoke test fails, with the
+// output of the step and the conditions of the runtime that are not met.
+type SmokeTestError struct {
+	Step   string
+	Output string
+	// Conditions are the unmet conditions of the runtime, such as
+	// `RuntimeReady: ...`.
+	Conditions []string
+	Err        error
+}
+
+func (e *SmokeTestError) Error() string {
+	msg := fmt.Sprintf("container runtime failed the smoke test, could not %s: %v", e.Step, e.Err)
+	if e.Output != "" {
+		msg += fmt.Sprintf("; output: %s", e.Output)
+	}
+	if len(e.Conditions) > 0 {
+		msg += fmt.Sprintf("; unmet runtime conditions: %s", strings.Join(e.Conditions, ", "))
+	}
+	return msg
+}
+
+func (e *SmokeTestError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SmokeTestError) ExitCode() int {
+	return smokeTestExitCode
+}
+
+// Run tests the container runtime through its CRI, the way kubelet uses it:
+// the sandbox image is pulled if it is not cached, then a pod sandbox is run
+// with the default runtime handler, stopped and removed. This catches runtimes
+// and snapshotters that are misconfigured before kubelet registers a node that
+// cannot run pods.
+func Run(cfg *api.NodeConfig) error {
+	if _, err := exec.LookPath("crictl"); err != nil {
+		return fmt.Errorf("container runtime smoke test requires crictl: %w", err)
+	}
+	image, err := containerd.GetSandboxImage(cfg)
+	if err != nil {
+		return err
+	}
+	timeout := defaultTimeout
+	if cfg.Spec.Containerd.SmokeTest.Timeout != nil {
+		timeout = cfg.Spec.Containerd.SmokeTest.Timeout.Duration
+	}
+	var credentials *kubelet.RegistryCredentials
+	if registry, _, _ := strings.Cut(image, "/"); ecr.IsECRRegistry(registry) {
+		credentials = fetchCredentials(cfg.Status.KubeletVersion, registry)
+	}
+	configPath, err := writeSandboxConfig()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(configPath)
+	var snapshotter string
+	if api.IsFeatureEnabled(api.FastContainerImagePull, cfg.Spec.FeatureGates) {
+		snapshotter = containerd.SOCISnapshotter
+	}
+	pull := func(ctx context.Context, image string, credentials *kubelet.RegistryCredentials) error {
+		return prepull.PullImage(ctx, image, credentials, snapshotter, 1)
+	}
+	return run(image, credentials, configPath, timeout, runCrictl, pull)
+}
+
+func run(image string, credentials *kubelet.RegistryCredentials, configPath string, timeout time.Duration, crictl crictlFunc, pull pullFunc) error {
+	zap.L().Info("Running container runtime smoke test..", zap.String("sandboxImage", image))
+	step := func(name string, args ...string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		out, err := crictl(ctx, args...)
+		output := strings.TrimSpace(string(out))
+		if err != nil {
+			return "", &SmokeTestError{Step: name, Output: output, Err: err}
+		}
+		return output, nil
+	}
+	fail := func(err error) error {
+		var smokeTestErr *SmokeTestError
+		if errors.As(err, &smokeTestErr) {
+			smokeTestErr.Conditions = getUnmetConditions(crictl, timeout)
+		}
+		return err
+	}
+
+	if _, err := step("inspect sandbox image", "inspecti", image); err != nil {
+		zap.L().Info("Pulling sandbox image..", zap.String("image", image))
+		if credentials != nil {
+			// crictl only takes credentials on its command line, where any
+			// user of the node can read them, so the image is pulled with ctr
+			// into the namespace of the CRI instead
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			err := pull(ctx, image, credentials)
+			cancel()
+			if err != nil {
+				return fail(&SmokeTestError{Step: "pull sandbox image", Err: err})
+			}
+		} else if _, err := step("pull sandbox image", "pull", image); err != nil {
+			return fail(err)
+		}
+	}
+	podID, err := step("run pod sandbox", "runp", configPath)
+	if err != nil {
+		return fail(err)
+	}
+	if _, err := step("stop pod sandbox", "stopp", podID); err != nil {
+		// the sandbox must not be left behind for kubelet
+		if _, rmErr := step("remove pod sandbox", "rmp", "--force", podID); rmErr != nil {
+			zap.L().Warn("Failed to remove pod sandbox of smoke test", zap.String("id", podID), zap.Error(rmErr))
+		}
+		return fail(err)
+	}
+	if _, err := step("remove pod sandbox", "rmp", podID); err != nil {
+		return fail(err)
+	}
+	zap.L().Info("Container runtime passed the smoke test")
+	return nil
+}
+
+type runtimeInfo struct {
+	Status struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  bool   `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// getUnmetConditions returns the conditions of the runtime that are not met,
+// as diagnostics of a failed smoke test.
+func getUnmetConditions(crictl crictlFunc, timeout time.Duration) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := crictl(ctx, "info")
+	if err != nil {
+		zap.L().Warn("Failed to get info of container runtime", zap.Error(err))
+		return nil
+	}
+	var info runtimeInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		zap.L().Warn("Failed to parse info of container runtime", zap.Error(err))
+		return nil
+	}
+	var conditions []string
+	for _, condition := range info.Status.Conditions {
+		if condition.Status || condition.Type == networkReadyCondition {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("%s: %s %s", condition.Type, condition.Reason, condition.Message))
+	}
+	return conditions
+}
+
+func fetchCredentials(kubeletVersion string, registry string) *kubelet.RegistryCredentials {
+	zap.L().Info("Fetching ECR credentials..", zap.String("registry", registry))
+	auths, err := kubelet.FetchRegistryCredentials(kubeletVersion, registry)
+	if err != nil {
+		// the image may be cached, in which case it is not pulled
+		zap.L().Warn("Failed to fetch ECR credentials", zap.String("registry", registry), zap.Error(err))
+		return nil
+	}
+	if auth, ok := auths[registry]; ok {
+		return &auth
+	}
+	return nil
+}
+
+// writeSandboxConfig writes the config of the pod sandbox to a temporary
+// file. The sandbox has a unique UID, so that it never conflicts with one
+// left behind by an earlier smoke test.
+func writeSandboxConfig() (string, error) {
+	config := map[string]any{
+		"metadata": map[string]any{
+			"name":      sandboxName,
+			"namespace": sandboxNamespace,
+			"uid":       sandboxName + "-" + strconv.FormatUint(rand.Uint64(), 16),
+			"attempt":   0,
+		},
+		"linux": map[string]any{
+			"security_context": map[string]any{
+				"namespace_options": map[string]any{
+					"network": namespaceModeNode,
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp("", sandboxName+"-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+func runCrictl(ctx context.Context, args ...string) ([]byte, error) {
+	args = append([]string{"--runtime-endpoint", containerd.ContainerRuntimeEndpoint}, args...)
+	// #nosec G204 Subprocess launched with variable
+	return exec.CommandContext(ctx, "crictl", args...).CombinedOutput()
+}
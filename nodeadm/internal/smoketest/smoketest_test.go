@@ -0,0 +1,135 @@
+package smoketest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+const runtimeInfoOutput = `{
+  "status": {
+    "conditions": [
+      {"type": "RuntimeReady", "status": false, "reason": "RuntimeNotReady", "message": "runc not found"},
+      {"type": "NetworkReady", "status": false, "reason": "NetworkPluginNotReady", "message": "cni plugin not initialized"}
+    ]
+  }
+}`
+
+// fakeCrictl records the commands it runs, and fails the commands whose
+// subcommand has an error.
+type fakeCrictl struct {
+	calls  []string
+	errors map[string]error
+}
+
+func (f *fakeCrictl) run(_ context.Context, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	if err := f.errors[args[0]]; err != nil {
+		return []byte(args[0] + " failed"), err
+	}
+	switch args[0] {
+	case "runp":
+		return []byte("pod-id\n"), nil
+	case "info":
+		return []byte(runtimeInfoOutput), nil
+	}
+	return nil, nil
+}
+
+// noPull fails the tests that pull an image with credentials.
+func noPull(t *testing.T) pullFunc {
+	return func(_ context.Context, image string, _ *kubelet.RegistryCredentials) error {
+		t.Errorf("unexpected pull of image %s", image)
+		return nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	crictl := &fakeCrictl{}
+	assert.NoError(t, run("localhost/kubernetes/pause", nil, "/tmp/config.json", time.Minute, crictl.run, noPull(t)))
+	assert.Equal(t, []string{
+		"inspecti localhost/kubernetes/pause",
+		"runp /tmp/config.json",
+		"stopp pod-id",
+		"rmp pod-id",
+	}, crictl.calls)
+}
+
+func TestRunPullsSandboxImage(t *testing.T) {
+	crictl := &fakeCrictl{errors: map[string]error{"inspecti": errors.New("exit status 1")}}
+	image := "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10"
+	assert.NoError(t, run(image, nil, "/tmp/config.json", time.Minute, crictl.run, noPull(t)))
+	assert.Equal(t, "pull "+image, crictl.calls[1])
+}
+
+func TestRunPullsSandboxImageWithCredentials(t *testing.T) {
+	crictl := &fakeCrictl{errors: map[string]error{"inspecti": errors.New("exit status 1")}}
+	image := "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10"
+	credentials := &kubelet.RegistryCredentials{Username: "AWS", Password: "token"}
+	var pulled []string
+	pull := func(_ context.Context, image string, credentials *kubelet.RegistryCredentials) error {
+		pulled = append(pulled, image+" "+credentials.Username)
+		return nil
+	}
+	assert.NoError(t, run(image, credentials, "/tmp/config.json", time.Minute, crictl.run, pull))
+	assert.Equal(t, []string{image + " AWS"}, pulled)
+	// the credentials are never passed to crictl
+	for _, call := range crictl.calls {
+		assert.NotContains(t, call, "token")
+	}
+	assert.Equal(t, "runp /tmp/config.json", crictl.calls[1])
+}
+
+func TestRunFailure(t *testing.T) {
+	crictl := &fakeCrictl{errors: map[string]error{"runp": errors.New("exit status 1")}}
+	err := run("localhost/kubernetes/pause", nil, "/tmp/config.json", time.Minute, crictl.run, noPull(t))
+	var smokeTestErr *SmokeTestError
+	assert.ErrorAs(t, err, &smokeTestErr)
+	assert.Equal(t, "run pod sandbox", smokeTestErr.Step)
+	assert.Equal(t, "runp failed", smokeTestErr.Output)
+	// the network is not ready until the CNI is configured
+	assert.Equal(t, []string{"RuntimeReady: RuntimeNotReady runc not found"}, smokeTestErr.Conditions)
+	assert.Equal(t, smokeTestExitCode, cli.ExitCode(err))
+	assert.Equal(t, "container runtime failed the smoke test, could not run pod sandbox: exit status 1; output: runp failed; unmet runtime conditions: RuntimeReady: RuntimeNotReady runc not found", err.Error())
+}
+
+func TestRunRemovesSandboxThatCannotBeStopped(t *testing.T) {
+	crictl := &fakeCrictl{errors: map[string]error{"stopp": errors.New("exit status 1")}}
+	err := run("localhost/kubernetes/pause", nil, "/tmp/config.json", time.Minute, crictl.run, noPull(t))
+	assert.ErrorContains(t, err, "could not stop pod sandbox")
+	assert.Contains(t, crictl.calls, "rmp --force pod-id")
+}
+
+func TestWriteSandboxConfig(t *testing.T) {
+	path, err := writeSandboxConfig()
+	assert.NoError(t, err)
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var config struct {
+		Metadata struct {
+			Name string `json:"name"`
+			UID  string `json:"uid"`
+		} `json:"metadata"`
+		Linux struct {
+			SecurityContext struct {
+				NamespaceOptions struct {
+					Network int `json:"network"`
+				} `json:"namespace_options"`
+			} `json:"security_context"`
+		} `json:"linux"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &config))
+	assert.Equal(t, sandboxName, config.Metadata.Name)
+	assert.True(t, strings.HasPrefix(config.Metadata.UID, sandboxName+"-"))
+	assert.Equal(t, namespaceModeNode, config.Linux.SecurityContext.NamespaceOptions.Network)
+}
@@ -0,0 +1,68 @@
+package soci
+
+import (
+	"github.com/pelletier/go-toml/v2"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// SnapshotterConfigPath is the configuration file that the
+	// soci-snapshotter reads by default.
+	SnapshotterConfigPath = "/etc/soci-snapshotter-grpc/config.toml"
+	snapshotterConfigPerm = 0644
+)
+
+// snapshotterConfig is the subset of the soci-snapshotter's configuration
+// that can be set in the NodeConfig. Options that are omitted use the
+// defaults of the snapshotter.
+type snapshotterConfig struct {
+	ContentStore *contentStoreConfig `toml:"content_store,omitempty"`
+	PullModes    *pullModesConfig    `toml:"pull_modes,omitempty"`
+}
+
+type contentStoreConfig struct {
+	Type api.SOCIContentStore `toml:"type"`
+}
+
+type pullModesConfig struct {
+	ParallelPullUnpack parallelPullUnpackConfig `toml:"parallel_pull_unpack"`
+}
+
+type parallelPullUnpackConfig struct {
+	Enable                         bool `toml:"enable"`
+	MaxConcurrentDownloads         int  `toml:"max_concurrent_downloads,omitempty"`
+	MaxConcurrentDownloadsPerImage int  `toml:"max_concurrent_downloads_per_image,omitempty"`
+	MaxConcurrentUnpacksPerImage   int  `toml:"max_concurrent_unpacks_per_image,omitempty"`
+}
+
+func writeSnapshotterConfig(path string, cfg *api.NodeConfig) error {
+	config, err := generateSnapshotterConfig(&cfg.Spec.Containerd.SOCI)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Writing soci-snapshotter config to file..", zap.String("path", path))
+	return util.WriteFileWithDir(path, config, snapshotterConfigPerm)
+}
+
+func generateSnapshotterConfig(soci *api.SOCIOptions) ([]byte, error) {
+	var config snapshotterConfig
+	if soci.ContentStore != "" {
+		config.ContentStore = &contentStoreConfig{Type: soci.ContentStore}
+	}
+	// setting any of the download or unpack options enables parallel pulls,
+	// which are how the snapshotter pulls the images without a SOCI index
+	if soci.Fallback == api.SOCIFallbackParallel || soci.MaxConcurrentDownloads > 0 || soci.MaxConcurrentDownloadsPerImage > 0 || soci.MaxConcurrentUnpacksPerImage > 0 {
+		config.PullModes = &pullModesConfig{
+			ParallelPullUnpack: parallelPullUnpackConfig{
+				Enable:                         true,
+				MaxConcurrentDownloads:         soci.MaxConcurrentDownloads,
+				MaxConcurrentDownloadsPerImage: soci.MaxConcurrentDownloadsPerImage,
+				MaxConcurrentUnpacksPerImage:   soci.MaxConcurrentUnpacksPerImage,
+			},
+		}
+	}
+	return toml.Marshal(config)
+}
@@ -0,0 +1,52 @@
+package soci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGenerateSnapshotterConfig(t *testing.T) {
+	var tests = []struct {
+		name     string
+		soci     api.SOCIOptions
+		expected string
+	}{
+		{
+			name:     "defaults",
+			expected: "",
+		},
+		{
+			name:     "content store",
+			soci:     api.SOCIOptions{ContentStore: api.SOCIContentStoreContainerd},
+			expected: "[content_store]\ntype = 'containerd'\n",
+		},
+		{
+			name: "parallel pull",
+			soci: api.SOCIOptions{MaxConcurrentDownloadsPerImage: 5, MaxConcurrentUnpacksPerImage: 2},
+			expected: "[pull_modes]\n[pull_modes.parallel_pull_unpack]\nenable = true\n" +
+				"max_concurrent_downloads_per_image = 5\n" +
+				"max_concurrent_unpacks_per_image = 2\n",
+		},
+		{
+			name:     "parallel fallback",
+			soci:     api.SOCIOptions{Fallback: api.SOCIFallbackParallel},
+			expected: "[pull_modes]\n[pull_modes.parallel_pull_unpack]\nenable = true\n",
+		},
+		{
+			name:     "sequential fallback",
+			soci:     api.SOCIOptions{Fallback: api.SOCIFallbackSequential},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := generateSnapshotterConfig(&test.soci)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(config))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package soci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// DockerConfigPath is where the ECR credentials used by the
+	// soci-snapshotter to resolve images are cached.
+	// #nosec G101 //constant path, not credential
+	DockerConfigPath = "/etc/soci-snapshotter-grpc/docker/config.json"
+	dockerConfigPerm = 0600
+)
+
+type dockerConfig struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+type dockerAuth struct {
+	Auth string `json:"auth"`
+}
+
+// getRegistries returns the ECR registries that the node is expected to pull
+// from, which are the EKS registry and the registry of the node's account.
+func getRegistries(cfg *api.NodeConfig) ([]string, error) {
+	var registries []string
+	eksRegistry, err := ecr.GetEKSRegistry(cfg.Status.Instance.Region)
+	if err != nil {
+		return nil, err
+	}
+	registries = append(registries, eksRegistry.String())
+	// the account is not known on hybrid nodes
+	if accountID := cfg.Status.Instance.AccountID; accountID != "" {
+		accountRegistry, err := ecr.GetAccountRegistry(accountID, cfg.Status.Instance.Region)
+		if err != nil {
+			return nil, err
+		}
+		registries = append(registries, accountRegistry.String())
+	}
+	return registries, nil
+}
+
+// writeCredentials fetches credentials for each registry and caches them in a
+// docker config file.
+func writeCredentials(dockerConfigPath string, registries []string, kubeletVersion string) error {
+	config := dockerConfig{Auths: make(map[string]dockerAuth)}
+	for _, registry := range registries {
+		zap.L().Info("Fetching ECR credentials..", zap.String("registry", registry))
+		auths, err := kubelet.FetchRegistryCredentials(kubeletVersion, registry)
+		if err != nil {
+			return fmt.Errorf("failed to fetch credentials for registry %s: %w", registry, err)
+		}
+		for host, auth := range auths {
+			config.Auths[host] = dockerAuth{
+				Auth: base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+			}
+		}
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileWithDir(dockerConfigPath, data, dockerConfigPerm)
+}
+
+// RefreshCredentials fetches new credentials for the registries that are
+// cached in the docker config file, since ECR credentials expire after 12
+// hours.
+func RefreshCredentials(kubeletVersion string) error {
+	data, err := os.ReadFile(DockerConfigPath)
+	if err != nil {
+		return err
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", DockerConfigPath, err)
+	}
+	var registries []string
+	for registry := range config.Auths {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	return writeCredentials(DockerConfigPath, registries, kubeletVersion)
+}
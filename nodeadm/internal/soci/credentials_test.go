@@ -0,0 +1,55 @@
+package soci
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const fakeCredentialProvider = `#!/usr/bin/env bash
+cat > /dev/null
+echo '{"kind":"CredentialProviderResponse","apiVersion":"credentialprovider.kubelet.k8s.io/v1","cacheKeyType":"Registry","auth":{"123456789012.dkr.ecr.us-west-2.amazonaws.com":{"username":"AWS","password":"token"}}}'
+`
+
+func TestWriteCredentials(t *testing.T) {
+	providerPath := filepath.Join(t.TempDir(), "ecr-credential-provider")
+	assert.NoError(t, os.WriteFile(providerPath, []byte(fakeCredentialProvider), 0755))
+	t.Setenv("ECR_CREDENTIAL_PROVIDER_BIN_PATH", providerPath)
+
+	dockerConfigPath := filepath.Join(t.TempDir(), "config.json")
+	err := writeCredentials(dockerConfigPath, []string{"123456789012.dkr.ecr.us-west-2.amazonaws.com"}, "v1.30.0")
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(dockerConfigPath)
+	assert.NoError(t, err)
+	var config dockerConfig
+	assert.NoError(t, json.Unmarshal(data, &config))
+	assert.Equal(t, map[string]dockerAuth{
+		"123456789012.dkr.ecr.us-west-2.amazonaws.com": {Auth: "QVdTOnRva2Vu"},
+	}, config.Auths)
+}
+
+func TestGetRegistries(t *testing.T) {
+	cfg := api.NodeConfig{
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{AccountID: "123456789012", Region: "us-west-2"},
+		},
+	}
+	registries, err := getRegistries(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"602401143452.dkr.ecr.us-west-2.amazonaws.com",
+		"123456789012.dkr.ecr.us-west-2.amazonaws.com",
+	}, registries)
+
+	// the account is not known on hybrid nodes
+	cfg.Status.Instance.AccountID = ""
+	registries, err = getRegistries(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"602401143452.dkr.ecr.us-west-2.amazonaws.com"}, registries)
+}
@@ -0,0 +1,103 @@
+package soci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	SOCIDaemonName = "soci-snapshotter"
+	sociBinaryName = "soci-snapshotter-grpc"
+
+	credentialsDropInPath = "/etc/systemd/system/" + SOCIDaemonName + ".service.d/10-nodeadm-credentials.conf"
+	credentialsDropInPerm = 0644
+)
+
+var (
+	_ daemon.Daemon      = &soci{}
+	_ daemon.Restartable = &soci{}
+)
+
+// soci manages the soci-snapshotter, which lazily pulls the layers of images
+// for containerd when FastContainerImagePull is enabled. Lazy pulls happen
+// after kubelet has handed the image to containerd, so the snapshotter cannot
+// use kubelet's credential provider and is given its own ECR credentials.
+type soci struct {
+	daemonManager daemon.DaemonManager
+}
+
+func NewSOCIDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &soci{
+		daemonManager: daemonManager,
+	}
+}
+
+func (s *soci) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	if !api.IsFeatureEnabled(api.FastContainerImagePull, cfg.Spec.FeatureGates) {
+		// the snapshotter is only started when its drop-in exists
+		for _, path := range []string{credentialsDropInPath, DockerConfigPath, SnapshotterConfigPath} {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := exec.LookPath(sociBinaryName); err != nil {
+		return fmt.Errorf("%s must be installed to enable %s: %w", sociBinaryName, api.FastContainerImagePull, err)
+	}
+	if err := writeSnapshotterConfig(SnapshotterConfigPath, cfg); err != nil {
+		return err
+	}
+	registries, err := getRegistries(cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeCredentials(DockerConfigPath, registries, cfg.Status.KubeletVersion); err != nil {
+		return err
+	}
+	zap.L().Info("Writing soci-snapshotter credentials drop-in..", zap.String("path", credentialsDropInPath))
+	return util.WriteFileWithDir(credentialsDropInPath, generateCredentialsDropIn(), credentialsDropInPerm)
+}
+
+func generateCredentialsDropIn() []byte {
+	return []byte(fmt.Sprintf("[Service]\nEnvironment=DOCKER_CONFIG=%s\n", filepath.Dir(DockerConfigPath)))
+}
+
+func (s *soci) EnsureRunning() error {
+	if configured, err := util.IsFilePathExists(credentialsDropInPath); err != nil {
+		return err
+	} else if !configured {
+		zap.L().Info("Fast container image pull is not enabled")
+		return nil
+	}
+	// the drop-in is only picked up once the units are reloaded
+	if err := s.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	return s.daemonManager.StartDaemon(SOCIDaemonName)
+}
+
+func (s *soci) Restart() error {
+	if err := s.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	return s.daemonManager.RestartDaemon(SOCIDaemonName)
+}
+
+func (s *soci) PostLaunch(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (s *soci) Name() string {
+	return SOCIDaemonName
+}
@@ -0,0 +1,150 @@
+// Package status records the outcome of `nodeadm init` on the node, so that
+// the bootstrap of a fleet can be audited without reading the logs of each
+// node.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Path is where the status of the last `nodeadm init` since boot is written.
+const Path = "/run/nodeadm/status.json"
+
+const statusFilePerm = 0644
+
+// AnnotationPrefix is the prefix of the annotations that the status is added
+// to the node with.
+const AnnotationPrefix = "bootstrap.node.eks.aws/"
+
+const (
+	AnnotationPhases            = AnnotationPrefix + "phases"
+	AnnotationKubeletVersion    = AnnotationPrefix + "kubelet-version"
+	AnnotationContainerdVersion = AnnotationPrefix + "containerd-version"
+	AnnotationConfigHash        = AnnotationPrefix + "config-hash"
+	AnnotationStartTime         = AnnotationPrefix + "start-time"
+	AnnotationEndTime           = AnnotationPrefix + "end-time"
+)
+
+type PhaseResult string
+
+const (
+	PhaseSucceeded PhaseResult = "succeeded"
+	PhaseFailed    PhaseResult = "failed"
+	PhaseSkipped   PhaseResult = "skipped"
+)
+
+// Phase is the result of a phase of the bootstrap.
+type Phase struct {
+	Name   string      `json:"name"`
+	Result PhaseResult `json:"result"`
+	// Duration is empty for skipped phases
+	Duration string `json:"duration,omitempty"`
+}
+
+// Status is the outcome of `nodeadm init`.
+type Status struct {
+	Phases            []Phase `json:"phases"`
+	KubeletVersion    string  `json:"kubeletVersion,omitempty"`
+	ContainerdVersion string  `json:"containerdVersion,omitempty"`
+	// ConfigHash is the hash of the enriched NodeConfig, which tells the
+	// nodes that were bootstrapped with the same configuration apart
+	ConfigHash string    `json:"configHash,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	// Error is the error that the bootstrap failed with, if any
+	Error string `json:"error,omitempty"`
+}
+
+// RecordPhase adds the result of a phase that started at start and ended now.
+func (s *Status) RecordPhase(name string, start time.Time, err error) {
+	result := PhaseSucceeded
+	if err != nil {
+		result = PhaseFailed
+	}
+	s.Phases = append(s.Phases, Phase{Name: name, Result: result, Duration: time.Since(start).String()})
+}
+
+// SkipPhase adds a phase that was skipped.
+func (s *Status) SkipPhase(name string) {
+	s.Phases = append(s.Phases, Phase{Name: name, Result: PhaseSkipped})
+}
+
+// Write writes the status to a file, replacing the status of an earlier
+// bootstrap.
+func Write(path string, status *Status) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// the file is replaced at once, so that readers never see a partial status
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, statusFilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Read reads a status that was written with Write.
+func Read(path string) (*Status, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse status %s: %w", path, err)
+	}
+	return &status, nil
+}
+
+// Annotations returns the annotations that the status is added to the node
+// with. The phases are listed as `<name>=<result>` in the order they ran.
+func (s *Status) Annotations() map[string]string {
+	var phases []string
+	for _, phase := range s.Phases {
+		phases = append(phases, fmt.Sprintf("%s=%s", phase.Name, phase.Result))
+	}
+	annotations := map[string]string{
+		AnnotationPhases:    strings.Join(phases, ","),
+		AnnotationStartTime: s.StartTime.UTC().Format(time.RFC3339),
+		AnnotationEndTime:   s.EndTime.UTC().Format(time.RFC3339),
+	}
+	for key, value := range map[string]string{
+		AnnotationKubeletVersion:    s.KubeletVersion,
+		AnnotationContainerdVersion: s.ContainerdVersion,
+		AnnotationConfigHash:        s.ConfigHash,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// AnnotateNode adds the annotations of the status to the node, replacing those
+// of an earlier bootstrap. Other annotations are left untouched.
+func AnnotateNode(ctx context.Context, client kubernetes.Interface, nodeName string, status *Status) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": status.Annotations(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,80 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testStatus() *Status {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	status := &Status{
+		KubeletVersion:    "v1.30.0",
+		ContainerdVersion: "1.7.20",
+		ConfigHash:        "0123abcd",
+		StartTime:         start,
+		EndTime:           start.Add(time.Minute),
+	}
+	status.SkipPhase("config")
+	status.RecordPhase("run", time.Now(), nil)
+	return status
+}
+
+func TestRecordPhase(t *testing.T) {
+	var status Status
+	status.RecordPhase("config", time.Now(), nil)
+	status.RecordPhase("run", time.Now(), errors.New("kubelet failed"))
+	status.SkipPhase("hooks")
+	assert.Len(t, status.Phases, 3)
+	assert.Equal(t, PhaseSucceeded, status.Phases[0].Result)
+	assert.Equal(t, PhaseFailed, status.Phases[1].Result)
+	assert.NotEmpty(t, status.Phases[1].Duration)
+	assert.Equal(t, Phase{Name: "hooks", Result: PhaseSkipped}, status.Phases[2])
+}
+
+func TestWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nodeadm", "status.json")
+	status := testStatus()
+	assert.NoError(t, Write(path, status))
+	read, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, status, read)
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestAnnotations(t *testing.T) {
+	status := testStatus()
+	assert.Equal(t, map[string]string{
+		AnnotationPhases:            "config=skipped,run=succeeded",
+		AnnotationKubeletVersion:    "v1.30.0",
+		AnnotationContainerdVersion: "1.7.20",
+		AnnotationConfigHash:        "0123abcd",
+		AnnotationStartTime:         "2024-05-01T12:00:00Z",
+		AnnotationEndTime:           "2024-05-01T12:01:00Z",
+	}, status.Annotations())
+
+	status.ContainerdVersion = ""
+	assert.NotContains(t, status.Annotations(), AnnotationContainerdVersion)
+}
+
+func TestAnnotateNode(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node",
+			Annotations: map[string]string{"example.com/owner": "team"},
+		},
+	})
+	assert.NoError(t, AnnotateNode(context.Background(), client, "node", testStatus()))
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "team", node.Annotations["example.com/owner"])
+	assert.Equal(t, "config=skipped,run=succeeded", node.Annotations[AnnotationPhases])
+	assert.Equal(t, "0123abcd", node.Annotations[AnnotationConfigHash])
+}
@@ -1,8 +1,12 @@
 package system
 
-import "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+import (
+	"context"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
 
 type SystemAspect interface {
 	Name() string
-	Setup(*api.NodeConfig) error
+	Setup(context.Context, *api.NodeConfig) error
 }
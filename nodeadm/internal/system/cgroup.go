@@ -0,0 +1,28 @@
+package system
+
+import (
+	"path/filepath"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const cgroupMountPath = "/sys/fs/cgroup"
+
+// GetCgroupVersion returns the cgroup hierarchy that the node was booted
+// with. The hierarchy can only be changed by rebooting with different kernel
+// arguments, so it is detected before the daemons are configured rather than
+// set up by an aspect.
+func GetCgroupVersion() (api.CgroupVersion, error) {
+	return getCgroupVersion(cgroupMountPath)
+}
+
+func getCgroupVersion(mountPath string) (api.CgroupVersion, error) {
+	// only the root of the unified hierarchy lists its controllers
+	if unified, err := util.IsFilePathExists(filepath.Join(mountPath, "cgroup.controllers")); err != nil {
+		return "", err
+	} else if unified {
+		return api.CgroupVersionV2, nil
+	}
+	return api.CgroupVersionV1, nil
+}
@@ -0,0 +1,190 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	cgroupIOAspectName = "cgroup-io"
+	cgroupIODropInName = "10-nodeadm-io.conf"
+	cgroupIODropInPerm = 0644
+	runtimeSliceName   = "runtime.slice"
+	kubepodsSliceName  = "kubepods.slice"
+	mountInfoPath      = "/proc/self/mountinfo"
+	sysBlockDevicePath = "/sys/dev/block"
+)
+
+// the directories whose devices are contended by containerd, kubelet and pods
+var cgroupIOPaths = []string{"/", "/var/lib/containerd", "/var/lib/kubelet"}
+
+// NewCgroupIOAspect constructs new cgroupIOAspect.
+func NewCgroupIOAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &cgroupIOAspect{daemonManager: daemonManager}
+}
+
+// cgroupIOAspect sets the IO weights of the slices of the container runtime
+// and of pods. kubepods.slice is created by kubelet, so its weight is set with
+// a drop-in that systemd applies when the slice is started.
+type cgroupIOAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *cgroupIOAspect) Name() string {
+	return cgroupIOAspectName
+}
+
+func (a *cgroupIOAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	ioOptions := &cfg.Spec.Instance.Cgroup.IO
+	if !ioOptions.IsEnabled() {
+		return a.removeDropIns()
+	}
+	for _, slice := range []string{runtimeSliceName, kubepodsSliceName} {
+		weight := ioOptions.GetRuntimeWeight()
+		if slice == kubepodsSliceName {
+			weight = ioOptions.GetPodsWeight()
+		}
+		path := getCgroupIODropInPath(slice)
+		zap.L().Info("Writing slice IO weight drop-in..", zap.String("slice", slice), zap.Int("weight", weight), zap.String("path", path))
+		if err := util.WriteFileWithDir(path, generateCgroupIODropIn(weight), cgroupIODropInPerm); err != nil {
+			return err
+		}
+	}
+	if err := a.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	enableIOCost(cgroupIOPaths)
+	return nil
+}
+
+func generateCgroupIODropIn(weight int) []byte {
+	return []byte(fmt.Sprintf("[Slice]\nIOAccounting=true\nIOWeight=%d\n", weight))
+}
+
+func getCgroupIODropInPath(slice string) string {
+	return filepath.Join(systemdUnitDir, slice+".d", cgroupIODropInName)
+}
+
+// removeDropIns removes the drop-ins written by a previous configuration
+// that isolated IO.
+func (a *cgroupIOAspect) removeDropIns() error {
+	removed := false
+	for _, slice := range []string{runtimeSliceName, kubepodsSliceName} {
+		if err := os.Remove(getCgroupIODropInPath(slice)); err == nil {
+			removed = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if removed {
+		return a.daemonManager.DaemonReload()
+	}
+	return nil
+}
+
+// enableIOCost enables the io.cost controller for the disks that back each of
+// the paths. IO weights are only enforced by io.cost or the BFQ scheduler, and
+// NVMe volumes use no scheduler by default. Failures are logged rather than
+// returned, since the weights still apply on disks that use BFQ.
+func enableIOCost(paths []string) {
+	qosPath := filepath.Join(cgroupMountPath, "io.cost.qos")
+	if exists, err := util.IsFilePathExists(qosPath); err != nil || !exists {
+		zap.L().Warn("IO weights are only enforced on disks that use the BFQ scheduler, io.cost is not supported by the kernel", zap.Error(err))
+		return
+	}
+	mountInfo, err := os.Open(mountInfoPath)
+	if err != nil {
+		zap.L().Warn("Failed to read mounts", zap.Error(err))
+		return
+	}
+	defer mountInfo.Close()
+	mounts, err := parseMountInfo(mountInfo)
+	if err != nil {
+		zap.L().Warn("Failed to parse mounts", zap.Error(err))
+		return
+	}
+	var disks []string
+	for _, path := range paths {
+		disk, err := getDisk(sysBlockDevicePath, getMountDevice(mounts, path))
+		if err != nil {
+			zap.L().Warn("Failed to find the disk of path", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if !slices.Contains(disks, disk) {
+			disks = append(disks, disk)
+		}
+	}
+	for _, disk := range disks {
+		zap.L().Info("Enabling io.cost for disk..", zap.String("device", disk))
+		// writes to io.cost.qos apply to a single device, so each is written separately
+		if err := os.WriteFile(qosPath, []byte(disk+" enable=1"), 0644); err != nil {
+			zap.L().Warn("Failed to enable io.cost, IO weights are not enforced on disk", zap.String("device", disk), zap.Error(err))
+		}
+	}
+}
+
+// parseMountInfo returns the device number of each mount point in the format
+// of /proc/self/mountinfo.
+func parseMountInfo(r io.Reader) (map[string]string, error) {
+	mounts := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// 36 35 259:1 / / rw,noatime shared:1 - xfs /dev/nvme0n1p1 rw
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		// later mounts over the same mount point take precedence
+		mounts[fields[4]] = fields[2]
+	}
+	return mounts, scanner.Err()
+}
+
+// getMountDevice returns the device number of the mount that contains the
+// path, which is the mount with the longest matching mount point.
+func getMountDevice(mounts map[string]string, path string) string {
+	for {
+		if device, ok := mounts[path]; ok {
+			return device
+		}
+		if path == "/" {
+			return ""
+		}
+		path = filepath.Dir(path)
+	}
+}
+
+// getDisk returns the device number of the disk that a device is on, since
+// io.cost is configured for whole disks rather than partitions.
+func getDisk(sysBlockPath string, device string) (string, error) {
+	if device == "" {
+		return "", fmt.Errorf("path is not mounted from a block device")
+	}
+	devicePath, err := filepath.EvalSymlinks(filepath.Join(sysBlockPath, device))
+	if err != nil {
+		return "", err
+	}
+	if isPartition, err := util.IsFilePathExists(filepath.Join(devicePath, "partition")); err != nil {
+		return "", err
+	} else if !isPartition {
+		return device, nil
+	}
+	disk, err := os.ReadFile(filepath.Join(filepath.Dir(devicePath), "dev"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(disk)), nil
+}
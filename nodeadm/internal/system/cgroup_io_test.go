@@ -0,0 +1,53 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMountDevice(t *testing.T) {
+	mountInfo := `22 1 259:1 / / rw,noatime shared:1 - xfs /dev/nvme0n1p1 rw,attr2
+44 22 9:127 / /var/lib/kubelet rw,relatime shared:20 - xfs /dev/md127 rw
+45 22 0:40 / /var/log/pods rw,relatime shared:21 - tmpfs tmpfs rw,size=524288k
+`
+	mounts, err := parseMountInfo(strings.NewReader(mountInfo))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "259:1", getMountDevice(mounts, "/"))
+	assert.Equal(t, "259:1", getMountDevice(mounts, "/var/lib/containerd"))
+	assert.Equal(t, "9:127", getMountDevice(mounts, "/var/lib/kubelet"))
+	assert.Equal(t, "9:127", getMountDevice(mounts, "/var/lib/kubelet/pods"))
+}
+
+func TestGetDisk(t *testing.T) {
+	sysDir := t.TempDir()
+	diskDir := filepath.Join(sysDir, "devices", "nvme0n1")
+	partitionDir := filepath.Join(diskDir, "nvme0n1p1")
+	assert.NoError(t, os.MkdirAll(partitionDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(diskDir, "dev"), []byte("259:0\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(partitionDir, "dev"), []byte("259:1\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(partitionDir, "partition"), []byte("1\n"), 0644))
+	blockDir := filepath.Join(sysDir, "block")
+	assert.NoError(t, os.MkdirAll(blockDir, 0755))
+	assert.NoError(t, os.Symlink(diskDir, filepath.Join(blockDir, "259:0")))
+	assert.NoError(t, os.Symlink(partitionDir, filepath.Join(blockDir, "259:1")))
+
+	disk, err := getDisk(blockDir, "259:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "259:0", disk)
+
+	disk, err = getDisk(blockDir, "259:0")
+	assert.NoError(t, err)
+	assert.Equal(t, "259:0", disk)
+
+	_, err = getDisk(blockDir, "0:40")
+	assert.Error(t, err)
+}
+
+func TestGenerateCgroupIODropIn(t *testing.T) {
+	assert.Equal(t, "[Slice]\nIOAccounting=true\nIOWeight=1000\n", string(generateCgroupIODropIn(1000)))
+}
@@ -0,0 +1,25 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetCgroupVersion(t *testing.T) {
+	v1 := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(v1, "memory"), 0755))
+	version, err := getCgroupVersion(v1)
+	assert.NoError(t, err)
+	assert.Equal(t, api.CgroupVersionV1, version)
+
+	v2 := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(v2, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644))
+	version, err = getCgroupVersion(v2)
+	assert.NoError(t, err)
+	assert.Equal(t, api.CgroupVersionV2, version)
+}
@@ -0,0 +1,161 @@
+package system
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// AspectCheckpointPath is the well-known location of the record of the
+	// system aspects that were set up by init, which lets a later init skip
+	// the aspects that have already converged.
+	AspectCheckpointPath = "/var/lib/nodeadm/aspects.json"
+	aspectCheckpointPerm = 0600
+)
+
+var bootIDPath = "/proc/sys/kernel/random/boot_id"
+
+// AspectCheckpoint records the configuration that each system aspect was set
+// up with, and the checksums of the files it rendered. Aspects also change
+// state that does not survive a reboot, such as mounts and swap, so the
+// records of a previous boot are discarded.
+type AspectCheckpoint struct {
+	BootID  string                      `json:"bootID"`
+	Aspects map[string]aspectCheckpoint `json:"aspects"`
+	path    string
+}
+
+type aspectCheckpoint struct {
+	ConfigHash string `json:"configHash"`
+	// Files are the checksums of the files rendered by the aspect, keyed by
+	// their path. A file that does not exist has an empty checksum.
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// NewAspectCheckpoint returns an empty checkpoint for the current boot, which
+// is persisted to the given path.
+func NewAspectCheckpoint(checkpointPath string) *AspectCheckpoint {
+	return &AspectCheckpoint{
+		BootID:  getBootID(),
+		Aspects: map[string]aspectCheckpoint{},
+		path:    checkpointPath,
+	}
+}
+
+// LoadAspectCheckpoint reads the checkpoint at the given path. An empty
+// checkpoint is returned if the path does not exist, or if it was recorded
+// during a previous boot.
+func LoadAspectCheckpoint(checkpointPath string) (*AspectCheckpoint, error) {
+	checkpoint := NewAspectCheckpoint(checkpointPath)
+	data, err := os.ReadFile(checkpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var recorded AspectCheckpoint
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal aspect checkpoint %s, run init with --force to set up every aspect again: %w", checkpointPath, err)
+	}
+	if checkpoint.BootID != "" && recorded.BootID == checkpoint.BootID && recorded.Aspects != nil {
+		checkpoint.Aspects = recorded.Aspects
+	}
+	return checkpoint, nil
+}
+
+// Converged returns whether the aspect was set up with the same configuration
+// during this boot, and none of the files it rendered have changed since.
+func (c *AspectCheckpoint) Converged(name string, configHash string) bool {
+	record, ok := c.Aspects[name]
+	if !ok || record.ConfigHash != configHash {
+		return false
+	}
+	for filePath, sum := range record.Files {
+		if current, err := fileChecksum(filePath); err != nil || current != sum {
+			return false
+		}
+	}
+	return true
+}
+
+// Record marks the aspect as set up with the configuration, along with the
+// files written since the given paths were written, and persists the
+// checkpoint.
+func (c *AspectCheckpoint) Record(name string, configHash string, before []string) error {
+	record := aspectCheckpoint{ConfigHash: configHash, Files: map[string]string{}}
+	for _, filePath := range util.WrittenFiles() {
+		if slices.Contains(before, filePath) {
+			continue
+		}
+		sum, err := fileChecksum(filePath)
+		if err != nil {
+			return err
+		}
+		record.Files[filePath] = sum
+	}
+	c.Aspects[name] = record
+	return c.write()
+}
+
+// Forget removes the record of the aspect, such as when it failed part way
+// through its setup, and persists the checkpoint.
+func (c *AspectCheckpoint) Forget(name string) error {
+	if _, ok := c.Aspects[name]; !ok {
+		return nil
+	}
+	delete(c.Aspects, name)
+	return c.write()
+}
+
+func (c *AspectCheckpoint) write() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, aspectCheckpointPerm)
+}
+
+// HashNodeConfig returns the checksum of the configuration that aspects are
+// set up with, including the details of the node that were enriched.
+func HashNodeConfig(cfg *api.NodeConfig) (string, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func fileChecksum(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getBootID returns the identifier of the current boot, or an empty string
+// when it is not known, in which case no aspect is considered converged.
+func getBootID() string {
+	data, err := os.ReadFile(bootIDPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
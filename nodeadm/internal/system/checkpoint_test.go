@@ -0,0 +1,49 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+func TestAspectCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	bootIDPath = filepath.Join(dir, "boot_id")
+	assert.NoError(t, os.WriteFile(bootIDPath, []byte("boot-1\n"), 0644))
+	checkpointPath := filepath.Join(dir, "aspects.json")
+	renderedPath := filepath.Join(dir, "rendered.conf")
+
+	checkpoint, err := LoadAspectCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.False(t, checkpoint.Converged("example", "config-1"))
+
+	before := util.WrittenFiles()
+	assert.NoError(t, util.WriteFileWithDir(renderedPath, []byte("rendered"), 0644))
+	assert.NoError(t, checkpoint.Record("example", "config-1", before))
+
+	checkpoint, err = LoadAspectCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.True(t, checkpoint.Converged("example", "config-1"))
+	assert.False(t, checkpoint.Converged("example", "config-2"), "configuration changed")
+
+	assert.NoError(t, os.WriteFile(renderedPath, []byte("modified"), 0644))
+	assert.False(t, checkpoint.Converged("example", "config-1"), "rendered file changed")
+	assert.NoError(t, os.WriteFile(renderedPath, []byte("rendered"), 0644))
+
+	assert.NoError(t, os.WriteFile(bootIDPath, []byte("boot-2\n"), 0644))
+	checkpoint, err = LoadAspectCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.False(t, checkpoint.Converged("example", "config-1"), "rebooted")
+
+	assert.NoError(t, os.WriteFile(bootIDPath, []byte("boot-1\n"), 0644))
+	checkpoint, err = LoadAspectCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.NoError(t, checkpoint.Forget("example"))
+	checkpoint, err = LoadAspectCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.False(t, checkpoint.Converged("example", "config-1"), "forgotten")
+}
@@ -0,0 +1,215 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	cloudwatchAgentAspectName = "cloudwatch-agent"
+	cloudwatchAgentDaemonName = "amazon-cloudwatch-agent"
+	cloudwatchAgentBinaryPath = "/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent"
+	// CloudWatchAgentConfigPath is the config of the CloudWatch agent rendered
+	// by nodeadm, which the agent merges with the other configs of its
+	// directory when it starts.
+	CloudWatchAgentConfigPath = "/opt/aws/amazon-cloudwatch-agent/etc/amazon-cloudwatch-agent.d/nodeadm.json"
+	cloudwatchAgentConfigPerm = 0644
+
+	// JournalExportDir is where the journals of the daemons are exported to,
+	// since the CloudWatch agent only publishes the logs of files.
+	JournalExportDir                 = "/var/log/nodeadm/journal"
+	journalExportUnitName            = "nodeadm-journal-export"
+	journalExportUnitPath            = "/etc/systemd/system/" + journalExportUnitName + "@.service"
+	journalExportCursorDir           = "/var/lib/nodeadm/journal-export"
+	journalExportRotatePath          = "/etc/logrotate.d/" + journalExportUnitName
+	journalExportPerm                = 0644
+	defaultCloudWatchNamespace       = "CWAgent"
+	defaultMetricsCollectionInterval = time.Minute
+)
+
+// journalExportUnit exports the journal of the unit of its instance name to a
+// file. The cursor is kept across restarts of the unit, so that no lines are
+// exported twice.
+const journalExportUnit = `[Unit]
+Description=Export the journal of %i for the CloudWatch agent
+
+[Service]
+ExecStartPre=/usr/bin/mkdir -p ` + JournalExportDir + ` ` + journalExportCursorDir + `
+ExecStart=/usr/bin/journalctl --unit=%i.service --boot --follow --lines=all --output=short-iso --cursor-file=` + journalExportCursorDir + `/%i.cursor
+StandardOutput=append:` + JournalExportDir + `/%i.log
+Restart=always
+`
+
+// journalExportRotation keeps the exported journals from filling the disk.
+// The files are truncated in place, since journalctl keeps them open.
+const journalExportRotation = JournalExportDir + `/*.log {
+    size 100M
+    rotate 1
+    copytruncate
+    missingok
+    notifempty
+}
+`
+
+// exportedJournals are the units whose journals are published to CloudWatch.
+var exportedJournals = []string{"kubelet", "containerd"}
+
+// the measurements of each set of metrics.
+var cloudwatchAgentMeasurements = map[api.CloudWatchAgentMetric]map[string]any{
+	api.CloudWatchAgentMetricCPU: {
+		"measurement": []string{"cpu_usage_idle", "cpu_usage_iowait", "cpu_usage_user", "cpu_usage_system"},
+		"totalcpu":    true,
+	},
+	api.CloudWatchAgentMetricDisk: {
+		"measurement":              []string{"used_percent", "inodes_free"},
+		"resources":                []string{"*"},
+		"ignore_file_system_types": []string{"sysfs", "devtmpfs", "tmpfs", "overlay", "squashfs"},
+	},
+	api.CloudWatchAgentMetricDiskIO: {
+		"measurement": []string{"io_time", "reads", "writes", "read_bytes", "write_bytes"},
+		"resources":   []string{"*"},
+	},
+	api.CloudWatchAgentMetricMem: {
+		"measurement": []string{"mem_used_percent", "mem_available"},
+	},
+	// only the interfaces of the node, rather than the veths of its pods
+	api.CloudWatchAgentMetricNet: {
+		"measurement": []string{"bytes_sent", "bytes_recv", "drop_in", "drop_out"},
+		"resources":   []string{"eth*", "ens*"},
+	},
+}
+
+// NewCloudWatchAgentAspect constructs new cloudwatchAgentAspect.
+func NewCloudWatchAgentAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &cloudwatchAgentAspect{daemonManager: daemonManager}
+}
+
+// cloudwatchAgentAspect configures the CloudWatch agent to publish the metrics
+// of the node and the logs of its daemons, whose journals are exported to
+// files for the agent.
+type cloudwatchAgentAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *cloudwatchAgentAspect) Name() string {
+	return cloudwatchAgentAspectName
+}
+
+func (a *cloudwatchAgentAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	agent := &cfg.Spec.Monitoring.CloudWatchAgent
+	if !agent.Enabled {
+		return nil
+	}
+	if _, err := os.Stat(cloudwatchAgentBinaryPath); err != nil {
+		return fmt.Errorf("CloudWatch agent is not installed: %w", err)
+	}
+	if err := a.startJournalExport(); err != nil {
+		return err
+	}
+	agentConfig, err := getCloudWatchAgentConfig(cfg)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Writing CloudWatch agent configuration..", zap.String("path", CloudWatchAgentConfigPath))
+	if err := util.WriteFileWithDir(CloudWatchAgentConfigPath, agentConfig, cloudwatchAgentConfigPerm); err != nil {
+		return err
+	}
+	zap.L().Info("Restarting daemon..", zap.String("name", cloudwatchAgentDaemonName))
+	return a.daemonManager.RestartDaemon(cloudwatchAgentDaemonName)
+}
+
+func (a *cloudwatchAgentAspect) startJournalExport() error {
+	if err := util.WriteFileWithDir(journalExportUnitPath, []byte(journalExportUnit), journalExportPerm); err != nil {
+		return err
+	}
+	if err := util.WriteFileWithDir(journalExportRotatePath, []byte(journalExportRotation), journalExportPerm); err != nil {
+		return err
+	}
+	if err := a.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	for _, unit := range exportedJournals {
+		name := fmt.Sprintf("%s@%s", journalExportUnitName, unit)
+		zap.L().Info("Starting daemon..", zap.String("name", name))
+		if err := a.daemonManager.StartDaemon(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCloudWatchAgentConfig returns the config of the CloudWatch agent, in the
+// JSON schema that the agent translates when it starts.
+func getCloudWatchAgentConfig(cfg *api.NodeConfig) ([]byte, error) {
+	agent := &cfg.Spec.Monitoring.CloudWatchAgent
+	namespace := agent.Namespace
+	if namespace == "" {
+		namespace = defaultCloudWatchNamespace
+	}
+	interval := defaultMetricsCollectionInterval
+	if agent.MetricsCollectionInterval != nil {
+		interval = agent.MetricsCollectionInterval.Duration
+	}
+	metrics := agent.Metrics
+	if len(metrics) == 0 {
+		metrics = []api.CloudWatchAgentMetric{api.CloudWatchAgentMetricDisk, api.CloudWatchAgentMetricMem}
+	}
+	logGroupName := agent.LogGroupName
+	if logGroupName == "" {
+		logGroupName = fmt.Sprintf("/aws/eks/%s/nodes", cfg.Spec.Cluster.Name)
+	}
+
+	collected := map[string]any{}
+	for _, metric := range metrics {
+		measurements, ok := cloudwatchAgentMeasurements[metric]
+		if !ok {
+			return nil, fmt.Errorf("unknown CloudWatch agent metrics %q", metric)
+		}
+		plugin := map[string]any{
+			"append_dimensions": map[string]string{"ClusterName": cfg.Spec.Cluster.Name},
+		}
+		for key, value := range measurements {
+			plugin[key] = value
+		}
+		collected[string(metric)] = plugin
+	}
+	var files []map[string]string
+	for _, unit := range exportedJournals {
+		files = append(files, map[string]string{
+			"file_path":       fmt.Sprintf("%s/%s.log", JournalExportDir, unit),
+			"log_group_name":  logGroupName,
+			"log_stream_name": "{instance_id}/" + unit,
+		})
+	}
+	return json.MarshalIndent(map[string]any{
+		"agent": map[string]any{
+			"metrics_collection_interval": int64(math.Max(1, math.Round(interval.Seconds()))),
+			"run_as_user":                 "root",
+		},
+		"metrics": map[string]any{
+			"namespace": namespace,
+			"append_dimensions": map[string]string{
+				"InstanceId":   "${aws:InstanceId}",
+				"InstanceType": "${aws:InstanceType}",
+			},
+			"metrics_collected": collected,
+		},
+		"logs": map[string]any{
+			"logs_collected": map[string]any{
+				"files": map[string]any{
+					"collect_list": files,
+				},
+			},
+		},
+	}, "", "  ")
+}
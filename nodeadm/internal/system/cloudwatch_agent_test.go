@@ -0,0 +1,91 @@
+package system
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetCloudWatchAgentConfig(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "my-cluster"},
+			Monitoring: api.MonitoringOptions{
+				CloudWatchAgent: api.CloudWatchAgentOptions{Enabled: true},
+			},
+		},
+	}
+	data, err := getCloudWatchAgentConfig(&cfg)
+	assert.NoError(t, err)
+	var agentConfig map[string]any
+	assert.NoError(t, json.Unmarshal(data, &agentConfig))
+	assert.Equal(t, map[string]any{"metrics_collection_interval": float64(60), "run_as_user": "root"}, agentConfig["agent"])
+	metrics := agentConfig["metrics"].(map[string]any)
+	assert.Equal(t, "CWAgent", metrics["namespace"])
+	collected := metrics["metrics_collected"].(map[string]any)
+	assert.Len(t, collected, 2)
+	assert.Equal(t, map[string]any{
+		"append_dimensions": map[string]any{"ClusterName": "my-cluster"},
+		"measurement":       []any{"mem_used_percent", "mem_available"},
+	}, collected["mem"])
+	assert.Contains(t, collected, "disk")
+	assert.Equal(t, map[string]any{
+		"collect_list": []any{
+			map[string]any{"file_path": "/var/log/nodeadm/journal/kubelet.log", "log_group_name": "/aws/eks/my-cluster/nodes", "log_stream_name": "{instance_id}/kubelet"},
+			map[string]any{"file_path": "/var/log/nodeadm/journal/containerd.log", "log_group_name": "/aws/eks/my-cluster/nodes", "log_stream_name": "{instance_id}/containerd"},
+		},
+	}, agentConfig["logs"].(map[string]any)["logs_collected"].(map[string]any)["files"])
+}
+
+func TestGetCloudWatchAgentConfigOptions(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "my-cluster"},
+			Monitoring: api.MonitoringOptions{
+				CloudWatchAgent: api.CloudWatchAgentOptions{
+					Enabled:                   true,
+					Namespace:                 "EKS/Nodes",
+					Metrics:                   []api.CloudWatchAgentMetric{api.CloudWatchAgentMetricCPU, api.CloudWatchAgentMetricNet},
+					MetricsCollectionInterval: &metav1.Duration{Duration: 10 * time.Second},
+					LogGroupName:              "/nodes",
+				},
+			},
+		},
+	}
+	data, err := getCloudWatchAgentConfig(&cfg)
+	assert.NoError(t, err)
+	var agentConfig struct {
+		Agent struct {
+			MetricsCollectionInterval int `json:"metrics_collection_interval"`
+		} `json:"agent"`
+		Metrics struct {
+			Namespace        string                    `json:"namespace"`
+			MetricsCollected map[string]map[string]any `json:"metrics_collected"`
+		} `json:"metrics"`
+		Logs struct {
+			LogsCollected struct {
+				Files struct {
+					CollectList []map[string]string `json:"collect_list"`
+				} `json:"files"`
+			} `json:"logs_collected"`
+		} `json:"logs"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &agentConfig))
+	assert.Equal(t, 10, agentConfig.Agent.MetricsCollectionInterval)
+	assert.Equal(t, "EKS/Nodes", agentConfig.Metrics.Namespace)
+	assert.Len(t, agentConfig.Metrics.MetricsCollected, 2)
+	assert.Equal(t, true, agentConfig.Metrics.MetricsCollected["cpu"]["totalcpu"])
+	assert.Equal(t, []any{"eth*", "ens*"}, agentConfig.Metrics.MetricsCollected["net"]["resources"])
+	for _, file := range agentConfig.Logs.LogsCollected.Files.CollectList {
+		assert.Equal(t, "/nodes", file["log_group_name"])
+	}
+
+	cfg.Spec.Monitoring.CloudWatchAgent.Metrics = []api.CloudWatchAgentMetric{"gpu"}
+	_, err = getCloudWatchAgentConfig(&cfg)
+	assert.ErrorContains(t, err, `unknown CloudWatch agent metrics "gpu"`)
+}
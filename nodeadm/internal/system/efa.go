@@ -0,0 +1,202 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	efaAspectName = "efa"
+	// the modules are loaded again by systemd-modules-load when the node
+	// reboots
+	efaModulesLoadPath = "/etc/modules-load.d/nodeadm-efa.conf"
+	efaSysctlPath      = "/etc/sysctl.d/99-nodeadm-efa.conf"
+	efaDropInName      = "20-nodeadm-efa.conf"
+	efaFilePerm        = 0644
+	// the number of huge pages that libfabric is tuned for on EKS
+	defaultEFAHugePages = 5128
+	nrHugePagesPath     = "/proc/sys/vm/nr_hugepages"
+
+	pciDevicesDir = "/sys/bus/pci/devices"
+	// EFA interfaces are Amazon PCI devices whose IDs start with 0xefa, one
+	// for each generation of the adapter
+	amazonPCIVendorID    = "0x1d0f"
+	efaPCIDevicePrefix   = "0xefa"
+	containerdDaemonName = "containerd"
+)
+
+// ib_uverbs creates the devices in /dev/infiniband that the EFA device plugin
+// mounts into pods
+var efaModules = []string{"ib_uverbs", "efa"}
+
+// NewEFAAspect constructs new efaAspect.
+func NewEFAAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &efaAspect{daemonManager: daemonManager}
+}
+
+// efaAspect prepares an instance with Elastic Fabric Adapter interfaces for
+// the EFA device plugin and for libfabric in pods.
+type efaAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *efaAspect) Name() string {
+	return efaAspectName
+}
+
+func (a *efaAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	efa := cfg.Spec.Instance.EFA
+	if !efa.Enabled {
+		return a.removeEFAConfig()
+	}
+	present, err := HasEFAInterfaces()
+	if err != nil {
+		return err
+	}
+	if !present {
+		zap.L().Info("Not configuring EFA, the instance has no EFA interfaces")
+		return a.removeEFAConfig()
+	}
+
+	zap.L().Info("Loading EFA kernel modules..", zap.Strings("modules", efaModules))
+	if err := util.WriteFileWithDir(efaModulesLoadPath, []byte(strings.Join(efaModules, "\n")+"\n"), efaFilePerm); err != nil {
+		return err
+	}
+	for _, module := range efaModules {
+		if out, err := exec.Command("modprobe", module).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to load kernel module %s: %w: %s", module, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	hugePages := getEFAHugePages(&efa)
+	if hugePages > 0 {
+		if err := allocateHugePages(efaSysctlPath, "instance.efa", hugePages); err != nil {
+			return err
+		}
+	} else if err := os.Remove(efaSysctlPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// libfabric registers the memory of its buffers with the adapter, which
+	// locks it, and containers inherit the limit of containerd
+	zap.L().Info("Allowing containers to lock unlimited memory..")
+	if err := util.WriteFileWithDir(getEFADropInPath(), []byte("[Service]\nLimitMEMLOCK=infinity\n"), efaFilePerm); err != nil {
+		return err
+	}
+	if err := a.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	// containerd is started with the limit by its own daemon when it is not
+	// already running
+	if status, err := a.daemonManager.GetDaemonStatus(containerdDaemonName); err != nil {
+		return err
+	} else if status == daemon.DaemonStatusRunning {
+		zap.L().Info("Restarting daemon to raise its memory lock limit..", zap.String("name", containerdDaemonName))
+		return a.daemonManager.RestartDaemon(containerdDaemonName)
+	}
+	return nil
+}
+
+func getEFAHugePages(efa *api.EFAOptions) int32 {
+	if efa.HugePages != nil {
+		return *efa.HugePages
+	}
+	return defaultEFAHugePages
+}
+
+// allocateHugePages sets the number of huge pages, which the kernel may only
+// partly allocate once memory is fragmented.
+func allocateHugePages(sysctlPath string, field string, hugePages int32) error {
+	zap.L().Info("Allocating huge pages..", zap.Int32("count", hugePages), zap.String("path", sysctlPath))
+	sysctls := map[string]string{"vm.nr_hugepages": fmt.Sprint(hugePages)}
+	if err := util.WriteFileWithDir(sysctlPath, generateSysctlConf(field, sysctls), efaFilePerm); err != nil {
+		return err
+	}
+	cmd := exec.Command("sysctl", "--load", sysctlPath)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to allocate huge pages: %w", err)
+	}
+	data, err := os.ReadFile(nrHugePagesPath)
+	if err != nil {
+		return err
+	}
+	if allocated, err := strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+		return err
+	} else if allocated < int(hugePages) {
+		zap.L().Warn("Kernel allocated fewer huge pages than requested", zap.Int("allocated", allocated), zap.Int32("requested", hugePages))
+	}
+	return nil
+}
+
+// removeEFAConfig removes the files written by a previous configuration that
+// enabled EFA, which take effect when the node reboots.
+func (a *efaAspect) removeEFAConfig() error {
+	for _, path := range []string{efaModulesLoadPath, efaSysctlPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Remove(getEFADropInPath()); err == nil {
+		return a.daemonManager.DaemonReload()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func getEFADropInPath() string {
+	return filepath.Join(systemdUnitDir, containerdDaemonName+".service.d", efaDropInName)
+}
+
+// HasEFAInterfaces returns whether the instance has Elastic Fabric Adapter
+// interfaces, which are found by their PCI IDs so that they are found before
+// the efa kernel module is loaded.
+func HasEFAInterfaces() (bool, error) {
+	return hasEFADevices(pciDevicesDir)
+}
+
+func hasEFADevices(devicesDir string) (bool, error) {
+	count, err := countAmazonPCIDevices(devicesDir, func(id string) bool {
+		return strings.HasPrefix(id, efaPCIDevicePrefix)
+	})
+	return count > 0, err
+}
+
+// countAmazonPCIDevices counts the Amazon PCI devices whose device IDs match.
+func countAmazonPCIDevices(devicesDir string, matches func(id string) bool) (int, error) {
+	devices, err := os.ReadDir(devicesDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, device := range devices {
+		vendor, err := os.ReadFile(filepath.Join(devicesDir, device.Name(), "vendor"))
+		if err != nil {
+			continue
+		}
+		id, err := os.ReadFile(filepath.Join(devicesDir, device.Name(), "device"))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(vendor)) == amazonPCIVendorID && matches(strings.TrimSpace(string(id))) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,34 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasEFADevices(t *testing.T) {
+	writeDevice := func(dir string, address string, vendor string, device string) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, address), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, address, "vendor"), []byte(vendor+"\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, address, "device"), []byte(device+"\n"), 0644))
+	}
+
+	dir := t.TempDir()
+	// an ENA interface and an NVMe volume, which are also Amazon devices
+	writeDevice(dir, "0000:00:05.0", "0x1d0f", "0xec20")
+	writeDevice(dir, "0000:00:1f.0", "0x1d0f", "0x8061")
+	present, err := hasEFADevices(dir)
+	assert.NoError(t, err)
+	assert.False(t, present)
+
+	writeDevice(dir, "0000:00:06.0", "0x1d0f", "0xefa1")
+	present, err = hasEFADevices(dir)
+	assert.NoError(t, err)
+	assert.True(t, present)
+
+	present, err = hasEFADevices(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.False(t, present)
+}
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/endpoints"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	endpointsAspectName = "endpoints"
+	// EndpointsEnvironmentPath is the environment file that holds the
+	// endpoint overrides of AWS services for the systemd units of nodeadm,
+	// which call AWS APIs after init has exited.
+	EndpointsEnvironmentPath = "/etc/eks/nodeadm/endpoints/environment"
+	endpointsFilePerm        = 0644
+)
+
+// NewEndpointsAspect constructs new endpointsAspect.
+func NewEndpointsAspect() SystemAspect {
+	return &endpointsAspect{}
+}
+
+// endpointsAspect writes the endpoint overrides of the NodeConfig to an
+// environment file, since those that init sets for itself are lost when it
+// exits.
+type endpointsAspect struct{}
+
+func (a *endpointsAspect) Name() string {
+	return endpointsAspectName
+}
+
+func (a *endpointsAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	environment := endpoints.GetEnvironment(cfg.Spec.Cluster.EndpointOverrides)
+	if len(environment) == 0 {
+		// the units only read the environment file when it exists
+		if err := os.Remove(EndpointsEnvironmentPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	var buf strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(environment)) {
+		fmt.Fprintf(&buf, "%s=%s\n", key, environment[key])
+	}
+	zap.L().Info("Writing endpoints environment..", zap.String("path", EndpointsEnvironmentPath))
+	return util.WriteFileWithDir(EndpointsEnvironmentPath, []byte(buf.String()), endpointsFilePerm)
+}
@@ -0,0 +1,65 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	gracefulShutdownAspectName = "graceful-shutdown"
+	// the drop-in sorts before the one that kubelet writes when the delay of
+	// logind is shorter than its grace period, which then takes precedence
+	logindDropInPath = "/etc/systemd/logind.conf.d/50-nodeadm-shutdown.conf"
+	logindDropInPerm = 0644
+)
+
+// NewGracefulShutdownAspect constructs new gracefulShutdownAspect.
+func NewGracefulShutdownAspect() SystemAspect {
+	return &gracefulShutdownAspect{}
+}
+
+// gracefulShutdownAspect allows the inhibitor lock of kubelet to delay the
+// shutdown of the instance for the grace period, so that kubelet does not
+// have to reconfigure systemd-logind itself when it starts.
+type gracefulShutdownAspect struct{}
+
+func (a *gracefulShutdownAspect) Name() string {
+	return gracefulShutdownAspectName
+}
+
+func (a *gracefulShutdownAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	gracePeriod := cfg.Spec.Instance.Shutdown.GracePeriod
+	if gracePeriod == nil {
+		if err := os.Remove(logindDropInPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	zap.L().Info("Writing logind configuration..", zap.String("path", logindDropInPath))
+	if err := util.WriteFileWithDir(logindDropInPath, []byte(getLogindDropIn(gracePeriod.Duration)), logindDropInPerm); err != nil {
+		return err
+	}
+	// logind reads its configuration again on SIGHUP, which does not end the
+	// sessions that a restart would
+	if out, err := exec.Command("systemctl", "kill", "--signal=SIGHUP", "systemd-logind").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to reload logind configuration: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// getLogindDropIn returns the logind configuration that allows the shutdown
+// to be delayed for the grace period, rounded up to whole seconds.
+func getLogindDropIn(gracePeriod time.Duration) string {
+	return fmt.Sprintf("[Login]\nInhibitDelayMaxSec=%d\n", int64(math.Ceil(gracePeriod.Seconds())))
+}
@@ -0,0 +1,13 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogindDropIn(t *testing.T) {
+	assert.Equal(t, "[Login]\nInhibitDelayMaxSec=90\n", getLogindDropIn(90*time.Second))
+	assert.Equal(t, "[Login]\nInhibitDelayMaxSec=2\n", getLogindDropIn(1500*time.Millisecond))
+}
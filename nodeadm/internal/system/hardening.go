@@ -0,0 +1,91 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/hardening"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	hardeningAspectName = "hardening"
+	// the drop-in sorts after the defaults of the distribution, so that its
+	// parameters take precedence
+	hardeningSysctlPath = "/etc/sysctl.d/99-nodeadm-hardening.conf"
+	hardeningFilePerm   = 0644
+)
+
+// NewHardeningAspect constructs new hardeningAspect.
+func NewHardeningAspect() SystemAspect {
+	return &hardeningAspect{}
+}
+
+// hardeningAspect sets the kernel parameters of the hardening profile and
+// reports its controls. The controls on the configuration of kubelet are
+// applied when it is configured.
+type hardeningAspect struct{}
+
+func (a *hardeningAspect) Name() string {
+	return hardeningAspectName
+}
+
+func (a *hardeningAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	profile := cfg.Spec.Security.HardeningProfile
+	if profile == "" || profile == api.HardeningProfileNone {
+		// the parameters of a previous profile are not set on the next boot
+		if err := os.Remove(hardeningSysctlPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	report := hardening.Evaluate(cfg)
+	for _, control := range report.Controls {
+		zap.L().Info("Hardening control",
+			zap.String("id", control.ID),
+			zap.String("status", string(control.Status)),
+			zap.String("reason", control.Reason),
+		)
+	}
+	zap.L().Info("Writing kernel parameters of hardening profile..", zap.String("path", hardeningSysctlPath), zap.String("profile", string(profile)))
+	if err := util.WriteFileWithDir(hardeningSysctlPath, generateSysctlConf("security.hardeningProfile", hardening.Sysctls(cfg)), hardeningFilePerm); err != nil {
+		return err
+	}
+	cmd := exec.Command("sysctl", "--load", hardeningSysctlPath)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set kernel parameters of hardening profile: %w", err)
+	}
+	reportBytes, err := json.MarshalIndent(report, "", strings.Repeat(" ", 4))
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Writing hardening report..", zap.String("path", hardening.ReportPath))
+	return util.WriteFileWithDir(hardening.ReportPath, reportBytes, hardeningFilePerm)
+}
+
+// generateSysctlConf renders kernel parameters in the format of sysctl.d,
+// noting the field of the NodeConfig they were generated from.
+func generateSysctlConf(field string, sysctls map[string]string) []byte {
+	var conf strings.Builder
+	fmt.Fprintf(&conf, "# Generated by nodeadm from %s\n", field)
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&conf, "%s = %s\n", key, sysctls[key])
+	}
+	return []byte(conf.String())
+}
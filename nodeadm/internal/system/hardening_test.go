@@ -0,0 +1,21 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSysctlConf(t *testing.T) {
+	conf := generateSysctlConf("security.hardeningProfile", map[string]string{
+		"kernel.randomize_va_space":            "2",
+		"fs.suid_dumpable":                     "0",
+		"net.ipv4.icmp_echo_ignore_broadcasts": "1",
+	})
+	expected := `# Generated by nodeadm from security.hardeningProfile
+fs.suid_dumpable = 0
+kernel.randomize_va_space = 2
+net.ipv4.icmp_echo_ignore_broadcasts = 1
+`
+	assert.Equal(t, expected, string(conf))
+}
@@ -0,0 +1,113 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	hybridAspectName = "hybrid"
+	// HybridAWSConfigPath is the AWS shared config file that sources
+	// credentials from IAM Roles Anywhere on hybrid nodes.
+	HybridAWSConfigPath = "/etc/eks/hybrid/aws-config"
+	hybridAWSConfigPerm = 0644
+
+	ssmAgentDaemonName  = "amazon-ssm-agent"
+	ssmRegistrationPath = "/var/lib/amazon/ssm/registration"
+	// the SSM agent writes the credentials of a hybrid activation to the
+	// default shared credentials file of the root user
+	ssmCredentialsPath = "/root/.aws/credentials"
+	// time to wait for the SSM agent to vend credentials after registration
+	ssmCredentialsTimeout = 2 * time.Minute
+)
+
+// NewHybridAspect constructs new hybridAspect.
+func NewHybridAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &hybridAspect{daemonManager: daemonManager}
+}
+
+// hybridAspect sets up the source of AWS credentials on nodes that are not EC2
+// instances, where credentials can't be obtained from the instance metadata service.
+type hybridAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *hybridAspect) Name() string {
+	return hybridAspectName
+}
+
+func (a *hybridAspect) Setup(ctx context.Context, cfg *api.NodeConfig) error {
+	if !cfg.IsHybrid() {
+		return nil
+	}
+	if cfg.Spec.Hybrid.SSM.ActivationID != "" {
+		return a.registerSSM(ctx, cfg)
+	}
+	return a.writeIAMRolesAnywhereConfig(cfg)
+}
+
+func (a *hybridAspect) registerSSM(ctx context.Context, cfg *api.NodeConfig) error {
+	if registered, err := util.IsFilePathExists(ssmRegistrationPath); err != nil {
+		return err
+	} else if registered {
+		zap.L().Info("Instance is already registered with SSM, skipping registration")
+	} else {
+		if err := a.daemonManager.StopDaemon(ssmAgentDaemonName); err != nil {
+			return err
+		}
+		zap.L().Info("Registering instance with SSM hybrid activation..", zap.String("activationID", cfg.Spec.Hybrid.SSM.ActivationID))
+		// #nosec G204 Subprocess launched with variable
+		cmd := exec.Command(ssmAgentDaemonName, "-register", "-y",
+			"-code", cfg.Spec.Hybrid.SSM.ActivationCode,
+			"-id", cfg.Spec.Hybrid.SSM.ActivationID,
+			"-region", cfg.Spec.Hybrid.Region,
+		)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to register with SSM: %w", err)
+		}
+	}
+	if err := a.daemonManager.StartDaemon(ssmAgentDaemonName); err != nil {
+		return err
+	}
+	zap.L().Info("Waiting for SSM agent to provide credentials..", zap.String("path", ssmCredentialsPath))
+	ctx, cancel := context.WithTimeout(ctx, ssmCredentialsTimeout)
+	defer cancel()
+	return util.NewRetrier(util.WithRetryAlways(), util.WithBackoffFixed(2*time.Second)).Retry(ctx, func() error {
+		if exists, err := util.IsFilePathExists(ssmCredentialsPath); err != nil {
+			return err
+		} else if !exists {
+			return fmt.Errorf("credentials file %s does not exist", ssmCredentialsPath)
+		}
+		return nil
+	})
+}
+
+func (a *hybridAspect) writeIAMRolesAnywhereConfig(cfg *api.NodeConfig) error {
+	zap.L().Info("Writing IAM Roles Anywhere credential configuration..", zap.String("path", HybridAWSConfigPath))
+	return util.WriteFileWithDir(HybridAWSConfigPath, generateIAMRolesAnywhereConfig(cfg), hybridAWSConfigPerm)
+}
+
+func generateIAMRolesAnywhereConfig(cfg *api.NodeConfig) []byte {
+	rolesAnywhere := cfg.Spec.Hybrid.IAMRolesAnywhere
+	credentialProcess := strings.Join([]string{
+		"aws_signing_helper", "credential-process",
+		"--certificate", rolesAnywhere.CertificatePath,
+		"--private-key", rolesAnywhere.PrivateKeyPath,
+		"--trust-anchor-arn", rolesAnywhere.TrustAnchorARN,
+		"--profile-arn", rolesAnywhere.ProfileARN,
+		"--role-arn", rolesAnywhere.RoleARN,
+	}, " ")
+	return []byte(fmt.Sprintf("[default]\nregion = %s\ncredential_process = %s\n", cfg.Spec.Hybrid.Region, credentialProcess))
+}
@@ -0,0 +1,81 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	journaldAspectName = "journald"
+	journaldDropInPath = "/etc/systemd/journald.conf.d/99-nodeadm.conf"
+	journaldDropInPerm = 0644
+)
+
+// NewJournaldAspect constructs new journaldAspect.
+func NewJournaldAspect() SystemAspect {
+	return &journaldAspect{}
+}
+
+// journaldAspect writes the rate limits and disk usage of systemd-journald.
+type journaldAspect struct{}
+
+func (a *journaldAspect) Name() string {
+	return journaldAspectName
+}
+
+func (a *journaldAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	dropIn := getJournaldDropIn(&cfg.Spec.Instance.Journald)
+	current, err := os.ReadFile(journaldDropInPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if dropIn == "" {
+		if err := os.Remove(journaldDropInPath); errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	} else if bytes.Equal(current, []byte(dropIn)) {
+		return nil
+	} else {
+		zap.L().Info("Writing journald configuration..", zap.String("path", journaldDropInPath))
+		if err := util.WriteFileWithDir(journaldDropInPath, []byte(dropIn), journaldDropInPerm); err != nil {
+			return err
+		}
+	}
+	// journald keeps its open files across a restart, so no logs are lost
+	if out, err := exec.Command("systemctl", "restart", "systemd-journald").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restart journald: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// getJournaldDropIn returns the journald configuration of the options that are
+// set, or an empty string when none are.
+func getJournaldDropIn(journald *api.JournaldOptions) string {
+	var dropIn strings.Builder
+	if journald.RateLimitInterval != nil {
+		fmt.Fprintf(&dropIn, "RateLimitIntervalSec=%d\n", int64(math.Ceil(journald.RateLimitInterval.Seconds())))
+	}
+	if journald.RateLimitBurst != nil {
+		fmt.Fprintf(&dropIn, "RateLimitBurst=%d\n", *journald.RateLimitBurst)
+	}
+	if journald.SystemMaxUse != nil {
+		fmt.Fprintf(&dropIn, "SystemMaxUse=%d\n", journald.SystemMaxUse.Value())
+	}
+	if dropIn.Len() == 0 {
+		return ""
+	}
+	return "[Journal]\n" + dropIn.String()
+}
@@ -0,0 +1,45 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetJournaldDropIn(t *testing.T) {
+	maxUse := resource.MustParse("1Gi")
+	var tests = []struct {
+		name           string
+		journald       api.JournaldOptions
+		expectedDropIn string
+	}{
+		{name: "empty"},
+		{
+			name:           "rate limit",
+			journald:       api.JournaldOptions{RateLimitInterval: &metav1.Duration{Duration: 1500 * time.Millisecond}, RateLimitBurst: ptr.To[int32](10000)},
+			expectedDropIn: "[Journal]\nRateLimitIntervalSec=2\nRateLimitBurst=10000\n",
+		},
+		{
+			name:           "no rate limit",
+			journald:       api.JournaldOptions{RateLimitInterval: &metav1.Duration{}},
+			expectedDropIn: "[Journal]\nRateLimitIntervalSec=0\n",
+		},
+		{
+			name:           "max use",
+			journald:       api.JournaldOptions{SystemMaxUse: &maxUse},
+			expectedDropIn: "[Journal]\nSystemMaxUse=1073741824\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedDropIn, getJournaldDropIn(&test.journald))
+		})
+	}
+}
@@ -1,50 +1,405 @@
 package system
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
-const localDiskAspectName = "local-disk"
+const (
+	localDiskAspectName = "local-disk"
+	// DefaultLocalDiskMountPath is where the filesystems of local disks are
+	// mounted when no mount path is configured.
+	DefaultLocalDiskMountPath = "/mnt/k8s-disks"
+	// instanceStoreDiskPattern matches the links that udev creates for the NVMe
+	// instance store disks of an instance, and not for its EBS volumes.
+	instanceStoreDiskPattern = "/dev/disk/by-id/*NVMe_Instance_Storage_*"
+
+	raidName       = "kubernetes"
+	raidDevice     = "/dev/md/" + raidName
+	raidConfigPath = "/.aws/mdadm.conf"
+	minRAID10Disks = 4
+
+	mountUnitDir  = "/etc/systemd/system"
+	mountUnitPerm = 0644
+	localDiskPerm = 0755
+)
 
+// NewLocalDiskAspect constructs new localDiskAspect.
 func NewLocalDiskAspect() SystemAspect {
 	return &localDiskAspect{}
 }
 
+// localDiskAspect creates filesystems on the instance store disks of the
+// instance and mounts them with systemd mount units, so that they are mounted
+// again on every boot. The state directories of kubelet and containerd are
+// moved onto a RAID when one is created, so that they use the ephemeral
+// storage instead of the root volume. Every step is skipped if it has already
+// been done, so the aspect can be run more than once.
 type localDiskAspect struct{}
 
 func (a *localDiskAspect) Name() string {
 	return localDiskAspectName
 }
 
-func (a *localDiskAspect) Setup(cfg *api.NodeConfig) error {
-	if cfg.Spec.Instance.LocalStorage.Strategy == "" {
+func (a *localDiskAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	localStorage := &cfg.Spec.Instance.LocalStorage
+	if localStorage.Strategy == "" || localStorage.Strategy == api.LocalStorageNone {
 		zap.L().Info("Not configuring local disks!")
 		return nil
 	}
-	strategy := strings.ToLower(string(cfg.Spec.Instance.LocalStorage.Strategy))
-	args := []string{strategy}
+	disks, err := getInstanceStoreDisks(instanceStoreDiskPattern)
+	if err != nil {
+		return err
+	}
+	if len(disks) == 0 {
+		zap.L().Info("No NVMe instance store disks found!")
+		return nil
+	}
+	mountPath := localStorage.MountPath
+	if mountPath == "" {
+		mountPath = DefaultLocalDiskMountPath
+	}
+	switch localStorage.Strategy {
+	case api.LocalStorageRAID0:
+		err = setupRAID(disks, "0", mountPath, getBindMounts(cfg))
+	case api.LocalStorageRAID10:
+		if len(disks) < minRAID10Disks {
+			return fmt.Errorf("RAID10 requires at least %d disks, but only %d were found", minRAID10Disks, len(disks))
+		}
+		err = setupRAID(disks, "10", mountPath, getBindMounts(cfg))
+	case api.LocalStorageMount:
+		err = mountDisks(disks, mountPath)
+	default:
+		return fmt.Errorf("unknown local storage strategy: %s", localStorage.Strategy)
+	}
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Configured local disks", zap.String("strategy", string(localStorage.Strategy)), zap.Strings("disks", disks))
+	return nil
+}
 
-	if cfg.Spec.Instance.LocalStorage.MountPath != "" {
-		args = append(args, "--dir", cfg.Spec.Instance.LocalStorage.MountPath)
+// getInstanceStoreDisks returns the sorted device paths of the disks that are
+// linked by the paths matching pattern.
+func getInstanceStoreDisks(pattern string) ([]string, error) {
+	links, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	unique := make(map[string]struct{})
+	for _, link := range links {
+		device, err := filepath.EvalSymlinks(link)
+		if err != nil {
+			return nil, err
+		}
+		unique[device] = struct{}{}
+	}
+	disks := make([]string, 0, len(unique))
+	for device := range unique {
+		disks = append(disks, device)
 	}
+	sort.Strings(disks)
+	return disks, nil
+}
 
+// getBindMounts returns the directories that are moved onto a RAID. kubelet's
+// directory is always moved, since it holds the ephemeral storage of pods.
+func getBindMounts(cfg *api.NodeConfig) []string {
+	bindContainerd, bindPodLogs := true, true
 	for _, mount := range cfg.Spec.Instance.LocalStorage.DisabledMounts {
 		switch mount {
-		case api.DisabledMountPodLogs:
-			args = append(args, "--no-bind-pod-logs")
 		case api.DisabledMountContainerd:
-			args = append(args, "--no-bind-containerd")
+			bindContainerd = false
+		case api.DisabledMountPodLogs:
+			bindPodLogs = false
+		}
+	}
+	// pod logs stored in memory are mounted by the pod-logs aspect
+	if cfg.Spec.Instance.PodLogs.IsMemoryBacked() {
+		bindPodLogs = false
+	}
+	mounts := []string{"/var/lib/kubelet"}
+	if bindContainerd {
+		mounts = append(mounts, "/var/lib/containerd")
+	}
+	if bindPodLogs {
+		mounts = append(mounts, PodLogsPath)
+	}
+	return mounts
+}
+
+// setupRAID creates a RAID of the disks, formats it, mounts it in a directory
+// named `0` under mountPath, and moves each of the bindMounts onto it.
+//
+// The initial resync of the RAID is not waited for, since RAID0 has no
+// redundancy and RAID10 does not strictly need one, while it can take hours
+// for large disks.
+func setupRAID(disks []string, level string, mountPath string, bindMounts []string) error {
+	device, err := createRAID(disks, level)
+	if err != nil {
+		return err
+	}
+	// instances are delivered with their disks fully trimmed, so it is skipped
+	if err := formatDisk(device, "-K"); err != nil {
+		return err
+	}
+	out, err := exec.Command("blkid", "-s", "UUID", "-o", "value", device).Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the UUID of %s: %w", device, err)
+	}
+	arrayMountPath := filepath.Join(mountPath, "0")
+	if err := os.MkdirAll(arrayMountPath, localDiskPerm); err != nil {
+		return err
+	}
+	if err := enableMountUnit(mountUnit{
+		Description: fmt.Sprintf("Mount EC2 Instance Store NVMe disk RAID%s", level),
+		What:        "UUID=" + string(bytes.TrimSpace(out)),
+		Where:       arrayMountPath,
+		Type:        "xfs",
+		Options:     "defaults,noatime",
+	}); err != nil {
+		return err
+	}
+	return bindDirectories(arrayMountPath, bindMounts)
+}
+
+// createRAID creates the RAID unless its configuration was saved by a previous
+// run, and returns its device.
+func createRAID(disks []string, level string) (string, error) {
+	if info, err := os.Stat(raidConfigPath); err == nil && info.Size() > 0 {
+		zap.L().Info("RAID already exists", zap.String("config", raidConfigPath))
+	} else {
+		zap.L().Info("Creating RAID..", zap.String("level", level), zap.Strings("disks", disks))
+		args := []string{"--create", "--force", "--verbose", raidDevice,
+			"--level=" + level,
+			"--name=" + raidName,
+			fmt.Sprintf("--raid-devices=%d", len(disks)),
+		}
+		if err := runLocalDiskCommand("mdadm", append(args, disks...)...); err != nil {
+			return "", err
+		}
+		config, err := exec.Command("mdadm", "--detail", "--scan").Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to scan RAID: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(raidConfigPath), localDiskPerm); err != nil {
+			return "", err
+		}
+		if err := util.WriteFileWithDir(raidConfigPath, config, mountUnitPerm); err != nil {
+			return "", err
+		}
+	}
+	// the device is renamed with a homehost suffix when it is assembled on boot
+	links, err := filepath.Glob(raidDevice + "*")
+	if err != nil {
+		return "", err
+	}
+	return findRAIDDevice(links), nil
+}
+
+var raidDevicePattern = regexp.MustCompile("^" + regexp.QuoteMeta(raidDevice) + "_?[0-9a-z]*$")
+
+// findRAIDDevice returns the last of links that names the RAID, with or
+// without a homehost suffix, or the default device if there is none.
+func findRAIDDevice(links []string) string {
+	device := raidDevice
+	for _, link := range links {
+		if raidDevicePattern.MatchString(link) {
+			device = link
+		}
+	}
+	return device
+}
+
+// mountDisks formats each disk and mounts it in a numbered directory under
+// mountPath, starting from `1`.
+func mountDisks(disks []string, mountPath string) error {
+	for i, disk := range disks {
+		if err := formatDisk(disk); err != nil {
+			return err
+		}
+		out, err := exec.Command("lsblk", disk, "-o", "MOUNTPOINT", "--noheadings").Output()
+		if err != nil {
+			return fmt.Errorf("failed to get the mount point of %s: %w", disk, err)
+		}
+		if len(bytes.TrimSpace(out)) > 0 {
+			zap.L().Info("Disk is already mounted", zap.String("disk", disk))
+			continue
+		}
+		diskMountPath := filepath.Join(mountPath, fmt.Sprint(i+1))
+		if err := os.MkdirAll(diskMountPath, localDiskPerm); err != nil {
+			return err
+		}
+		if err := enableMountUnit(mountUnit{
+			Description: fmt.Sprintf("Mount EC2 Instance Store NVMe disk %d", i+1),
+			What:        disk,
+			Where:       diskMountPath,
+			Type:        "xfs",
+			Options:     "defaults,noatime",
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDisk creates an xfs filesystem on the device unless it already has a
+// filesystem.
+func formatDisk(device string, args ...string) error {
+	out, err := exec.Command("lsblk", device, "-o", "FSTYPE", "--noheadings").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the filesystem of %s: %w", device, err)
+	}
+	if len(bytes.TrimSpace(out)) > 0 {
+		return nil
+	}
+	zap.L().Info("Creating filesystem..", zap.String("device", device))
+	// mkfs.xfs would use the stripe unit of a RAID (512k) as the log stripe
+	// unit, which is larger than the maximum of 256k, so 32k (8 blocks) is used
+	args = append(args, "-l", "su=8b", device)
+	return runLocalDiskCommand("mkfs.xfs", args...)
+}
+
+// bindDirectories copies each directory onto the disk mounted at diskMountPath
+// and bind mounts the copy over it. The daemons that use a directory are
+// stopped while it is copied, and started again afterwards.
+func bindDirectories(diskMountPath string, dirs []string) error {
+	var stopped []string
+	var unbound []string
+	for _, dir := range dirs {
+		if isUnitActive(getMountUnitName(dir)) {
+			continue
+		}
+		unbound = append(unbound, dir)
+		unit := getBindMountDaemon(dir)
+		if isUnitActive(unit) && !slices.Contains(stopped, unit) {
+			stopped = append(stopped, unit)
+		}
+	}
+	if len(stopped) > 0 {
+		zap.L().Info("Stopping daemons to move their directories..", zap.Strings("daemons", stopped))
+		if err := runLocalDiskCommand("systemctl", append([]string{"stop"}, stopped...)...); err != nil {
+			return err
+		}
+	}
+	for _, dir := range unbound {
+		target := filepath.Join(diskMountPath, filepath.Base(dir))
+		if err := os.MkdirAll(dir, localDiskPerm); err != nil {
+			return err
+		}
+		zap.L().Info("Copying directory to local disk..", zap.String("source", dir), zap.String("target", target))
+		// cp preserves the extended attributes, such as SELinux labels
+		if err := runLocalDiskCommand("cp", "-a", dir+"/", target+"/"); err != nil {
+			return err
+		}
+		if err := enableMountUnit(mountUnit{
+			Description: fmt.Sprintf("Mount %s on EC2 Instance Store NVMe RAID", filepath.Base(dir)),
+			What:        target,
+			Where:       dir,
+			Type:        "none",
+			Options:     "bind",
+		}); err != nil {
+			return err
+		}
+	}
+	if len(stopped) > 0 {
+		return runLocalDiskCommand("systemctl", append([]string{"start"}, stopped...)...)
+	}
+	return nil
+}
+
+// getBindMountDaemon returns the daemon that uses a directory, which is named
+// after it except for pod logs, which are written by kubelet.
+func getBindMountDaemon(dir string) string {
+	if dir == PodLogsPath {
+		return "kubelet"
+	}
+	return filepath.Base(dir)
+}
+
+type mountUnit struct {
+	Description string
+	What        string
+	Where       string
+	Type        string
+	Options     string
+}
+
+func (u *mountUnit) generate() []byte {
+	return []byte(fmt.Sprintf(`[Unit]
+Description=%s
+
+[Mount]
+What=%s
+Where=%s
+Type=%s
+Options=%s
+
+[Install]
+WantedBy=multi-user.target
+`, u.Description, u.What, u.Where, u.Type, u.Options))
+}
+
+func enableMountUnit(u mountUnit) error {
+	name := getMountUnitName(u.Where)
+	path := filepath.Join(mountUnitDir, name)
+	zap.L().Info("Writing mount unit..", zap.String("path", path))
+	if err := util.WriteFileWithDir(path, u.generate(), mountUnitPerm); err != nil {
+		return err
+	}
+	return runLocalDiskCommand("systemctl", "enable", "--now", name)
+}
+
+// getMountUnitName returns the name of the mount unit for a path, which
+// systemd requires to be the escaped path, like `systemd-escape --path`.
+func getMountUnitName(path string) string {
+	path = strings.Trim(filepath.Clean(path), "/")
+	if path == "" {
+		return "-.mount"
+	}
+	var name strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '/':
+			name.WriteByte('-')
+		case c == '.' && i == 0, !isUnitNameChar(c):
+			fmt.Fprintf(&name, `\x%02x`, c)
+		default:
+			name.WriteByte(c)
 		}
 	}
+	return name.String() + ".mount"
+}
 
+func isUnitNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == ':' || c == '_' || c == '.'
+}
+
+func isUnitActive(unit string) bool {
+	return exec.Command("systemctl", "is-active", "--quiet", unit).Run() == nil
+}
+
+func runLocalDiskCommand(name string, args ...string) error {
 	// #nosec G204 Subprocess launched with variable
-	cmd := exec.Command("setup-local-disks", args...)
-	cmd.Stdout = os.Stdout
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return nil
 }
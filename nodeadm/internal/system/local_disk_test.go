@@ -0,0 +1,127 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetInstanceStoreDisks(t *testing.T) {
+	dir := t.TempDir()
+	for _, device := range []string{"nvme2n1", "nvme1n1", "nvme0n1"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, device), nil, 0644))
+	}
+	links := map[string]string{
+		"nvme-Amazon_EC2_NVMe_Instance_Storage_AWS1":   "nvme2n1",
+		"nvme-Amazon_EC2_NVMe_Instance_Storage_AWS1_1": "nvme2n1",
+		"nvme-Amazon_EC2_NVMe_Instance_Storage_AWS2":   "nvme1n1",
+		"nvme-Amazon_Elastic_Block_Store_vol0123":      "nvme0n1",
+	}
+	for link, device := range links {
+		assert.NoError(t, os.Symlink(filepath.Join(dir, device), filepath.Join(dir, link)))
+	}
+
+	disks, err := getInstanceStoreDisks(filepath.Join(dir, "*NVMe_Instance_Storage_*"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "nvme1n1"), filepath.Join(dir, "nvme2n1")}, disks)
+
+	disks, err = getInstanceStoreDisks(filepath.Join(t.TempDir(), "*NVMe_Instance_Storage_*"))
+	assert.NoError(t, err)
+	assert.Empty(t, disks)
+}
+
+func TestGetBindMounts(t *testing.T) {
+	var tests = []struct {
+		name     string
+		instance api.InstanceOptions
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"/var/lib/kubelet", "/var/lib/containerd", "/var/log/pods"},
+		},
+		{
+			name: "disabled mounts",
+			instance: api.InstanceOptions{
+				LocalStorage: api.LocalStorageOptions{
+					DisabledMounts: []api.DisabledMount{api.DisabledMountContainerd, api.DisabledMountPodLogs},
+				},
+			},
+			expected: []string{"/var/lib/kubelet"},
+		},
+		{
+			name: "pod logs in memory",
+			instance: api.InstanceOptions{
+				PodLogs: api.PodLogsOptions{Storage: api.PodLogsStorageMemory},
+			},
+			expected: []string{"/var/lib/kubelet", "/var/lib/containerd"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Instance: test.instance}}
+			assert.Equal(t, test.expected, getBindMounts(&cfg))
+		})
+	}
+}
+
+func TestFindRAIDDevice(t *testing.T) {
+	var tests = []struct {
+		links    []string
+		expected string
+	}{
+		{links: nil, expected: "/dev/md/kubernetes"},
+		{links: []string{"/dev/md/kubernetes"}, expected: "/dev/md/kubernetes"},
+		{links: []string{"/dev/md/kubernetes_0"}, expected: "/dev/md/kubernetes_0"},
+		{links: []string{"/dev/md/kubernetes-other"}, expected: "/dev/md/kubernetes"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, findRAIDDevice(test.links))
+	}
+}
+
+func TestGetMountUnitName(t *testing.T) {
+	var tests = []struct {
+		path     string
+		expected string
+	}{
+		{path: "/", expected: "-.mount"},
+		{path: "/var/lib/containerd", expected: "var-lib-containerd.mount"},
+		{path: "/mnt/k8s-disks/0", expected: `mnt-k8s\x2ddisks-0.mount`},
+		{path: "/mnt/k8s-disks/", expected: `mnt-k8s\x2ddisks.mount`},
+		{path: "/.hidden/dir", expected: `\x2ehidden-dir.mount`},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getMountUnitName(test.path))
+	}
+}
+
+func TestMountUnit(t *testing.T) {
+	unit := mountUnit{
+		Description: "Mount containerd on EC2 Instance Store NVMe RAID",
+		What:        "/mnt/k8s-disks/0/containerd",
+		Where:       "/var/lib/containerd",
+		Type:        "none",
+		Options:     "bind",
+	}
+	expected := `[Unit]
+Description=Mount containerd on EC2 Instance Store NVMe RAID
+
+[Mount]
+What=/mnt/k8s-disks/0/containerd
+Where=/var/lib/containerd
+Type=none
+Options=bind
+
+[Install]
+WantedBy=multi-user.target
+`
+	assert.Equal(t, expected, string(unit.generate()))
+}
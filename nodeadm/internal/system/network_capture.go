@@ -0,0 +1,105 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	networkCaptureAspectName = "network-capture"
+	// NetworkCaptureDir is where packet captures of the bootstrap window are
+	// written, so that they can be collected for troubleshooting.
+	NetworkCaptureDir           = "/var/log/nodeadm/network-capture"
+	networkCaptureDirPerms      = 0755
+	networkCaptureUnitName      = "nodeadm-network-capture"
+	defaultNetworkCaptureLength = 2 * time.Minute
+	// only headers are captured, since the payload of TLS traffic is not
+	// useful and would quickly grow the capture.
+	networkCaptureSnapLength = 256
+	defaultAPIServerPort     = "443"
+)
+
+// NewNetworkCaptureAspect constructs new networkCaptureAspect.
+func NewNetworkCaptureAspect() SystemAspect {
+	return &networkCaptureAspect{}
+}
+
+// networkCaptureAspect starts a time-bounded packet capture of the traffic to
+// the cluster endpoint and DNS. The capture is run as a transient systemd unit
+// so that it continues after nodeadm exits, while kubelet joins the cluster.
+type networkCaptureAspect struct{}
+
+func (a *networkCaptureAspect) Name() string {
+	return networkCaptureAspectName
+}
+
+func (a *networkCaptureAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	capture := cfg.Spec.Debug.NetworkCapture
+	if !capture.Enabled {
+		return nil
+	}
+	// diagnostics should never prevent the node from bootstrapping, so
+	// failures past this point are only logged.
+	if _, err := exec.LookPath("tcpdump"); err != nil {
+		zap.L().Warn("Not starting network capture, tcpdump is not installed", zap.Error(err))
+		return nil
+	}
+	duration := defaultNetworkCaptureLength
+	if capture.Duration != nil {
+		duration = capture.Duration.Duration
+	}
+	filter, err := getNetworkCaptureFilter(cfg)
+	if err != nil {
+		zap.L().Warn("Not starting network capture", zap.Error(err))
+		return nil
+	}
+	if err := os.MkdirAll(NetworkCaptureDir, networkCaptureDirPerms); err != nil {
+		zap.L().Warn("Failed to create network capture directory", zap.Error(err))
+		return nil
+	}
+	capturePath := path.Join(NetworkCaptureDir, fmt.Sprintf("bootstrap-%s.pcap", time.Now().UTC().Format("20060102T150405Z")))
+	zap.L().Info("Starting network capture..", zap.String("path", capturePath), zap.Duration("duration", duration), zap.String("filter", filter))
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.Command("systemd-run",
+		"--unit", networkCaptureUnitName,
+		"--collect",
+		"--property", fmt.Sprintf("RuntimeMaxSec=%d", int(duration.Seconds())),
+		"tcpdump", "-i", "any", "-nn", "-Z", "root",
+		"-s", fmt.Sprint(networkCaptureSnapLength),
+		"-w", capturePath,
+		filter,
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		zap.L().Warn("Failed to start network capture", zap.Error(err))
+	}
+	return nil
+}
+
+func getNetworkCaptureFilter(cfg *api.NodeConfig) (string, error) {
+	endpoint, err := url.Parse(cfg.Spec.Cluster.APIServerEndpoint)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.Hostname() == "" {
+		return "", fmt.Errorf("could not determine host of API server endpoint %q", cfg.Spec.Cluster.APIServerEndpoint)
+	}
+	// the filter is on ports rather than the host of the endpoint, since
+	// tcpdump would resolve the host before the capture starts, and a node
+	// that cannot resolve it is the one whose traffic needs to be captured.
+	port := endpoint.Port()
+	if port == "" {
+		port = defaultAPIServerPort
+	}
+	return fmt.Sprintf("port 53 or port %s", port), nil
+}
@@ -0,0 +1,37 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetNetworkCaptureFilter(t *testing.T) {
+	var tests = []struct {
+		endpoint       string
+		expectedFilter string
+		expectErr      bool
+	}{
+		{endpoint: "https://ABCDEF.gr7.us-west-2.eks.amazonaws.com", expectedFilter: "port 53 or port 443"},
+		{endpoint: "https://10.0.0.1:443", expectedFilter: "port 53 or port 443"},
+		{endpoint: "https://10.0.0.1:6443", expectedFilter: "port 53 or port 6443"},
+		{endpoint: "not-a-url", expectErr: true},
+	}
+
+	for _, test := range tests {
+		cfg := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Cluster: api.ClusterDetails{APIServerEndpoint: test.endpoint},
+			},
+		}
+		filter, err := getNetworkCaptureFilter(&cfg)
+		if test.expectErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFilter, filter)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package system
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"fmt"
 	"os"
@@ -47,7 +48,17 @@ func (a *networkingAspect) Name() string {
 }
 
 // Setup executes the logic of this aspect.
-func (a *networkingAspect) Setup(cfg *api.NodeConfig) error {
+func (a *networkingAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	if cfg.IsHybrid() {
+		zap.L().Info("Not configuring EC2 networking on hybrid node")
+		return nil
+	}
+	if !cfg.HasInstanceMetadata() {
+		// the primary network interface is identified by its MAC address,
+		// which is read from the instance metadata service
+		zap.L().Info("Not configuring EC2 networking without instance metadata")
+		return nil
+	}
 	if err := a.ensureEKSNetworkConfiguration(cfg); err != nil {
 		return fmt.Errorf("failed to ensure eks network configuration: %w", err)
 	}
@@ -111,7 +122,7 @@ func (a *networkingAspect) generateEKSPrimaryENIOnlyConfiguration(cfg *api.NodeC
 
 func (a *networkingAspect) reloadNetworkConfigurations() error {
 	cmd := exec.Command("networkctl", "reload")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
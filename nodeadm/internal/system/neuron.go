@@ -0,0 +1,120 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	neuronAspectName       = "neuron"
+	neuronModulesLoadPath  = "/etc/modules-load.d/nodeadm-neuron.conf"
+	neuronModule           = "neuron"
+	neuronFilePerm         = 0644
+	defaultNeuronHugePages = 256
+	// sorts after the sysctl file of EFA, so that the total number of huge
+	// pages takes effect when the node reboots
+	neuronSysctlPath = "/etc/sysctl.d/99-nodeadm-neuron.conf"
+)
+
+// neuronPCIDeviceIDs are the device IDs of the Inferentia and Trainium
+// accelerators, one for each generation
+var neuronPCIDeviceIDs = []string{"0x7064", "0x7065", "0x7066", "0x7067", "0x7164", "0x7264", "0x7364"}
+
+// NewNeuronAspect constructs new neuronAspect.
+func NewNeuronAspect() SystemAspect {
+	return &neuronAspect{}
+}
+
+// neuronAspect prepares an Inferentia or Trainium instance for the Neuron
+// device plugin and for the Neuron runtime in pods.
+type neuronAspect struct{}
+
+func (a *neuronAspect) Name() string {
+	return neuronAspectName
+}
+
+func (a *neuronAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	neuron := cfg.Spec.Instance.Neuron
+	if !neuron.Enabled {
+		return removeNeuronConfig()
+	}
+	devices, err := CountNeuronDevices()
+	if err != nil {
+		return err
+	}
+	if devices == 0 {
+		zap.L().Info("Not configuring Neuron, the instance has no Neuron devices")
+		return removeNeuronConfig()
+	}
+
+	zap.L().Info("Loading Neuron kernel module..", zap.Int("devices", devices))
+	if err := util.WriteFileWithDir(neuronModulesLoadPath, []byte(neuronModule+"\n"), neuronFilePerm); err != nil {
+		return err
+	}
+	if out, err := exec.Command("modprobe", neuronModule).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to load kernel module %s: %w: %s", neuronModule, err, strings.TrimSpace(string(out)))
+	}
+
+	hugePages, err := getNeuronHugePages(cfg, devices)
+	if err != nil {
+		return err
+	}
+	if hugePages > 0 {
+		return allocateHugePages(neuronSysctlPath, "instance.neuron", hugePages)
+	}
+	if err := os.Remove(neuronSysctlPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// getNeuronHugePages returns the huge pages of the Neuron devices, along with
+// those of EFA, since both set the same parameter.
+func getNeuronHugePages(cfg *api.NodeConfig, devices int) (int32, error) {
+	hugePages := int32(defaultNeuronHugePages * devices)
+	if cfg.Spec.Instance.Neuron.HugePages != nil {
+		hugePages = *cfg.Spec.Instance.Neuron.HugePages
+	}
+	if efa := cfg.Spec.Instance.EFA; efa.Enabled {
+		if present, err := HasEFAInterfaces(); err != nil {
+			return 0, err
+		} else if present {
+			hugePages += getEFAHugePages(&efa)
+		}
+	}
+	return hugePages, nil
+}
+
+// removeNeuronConfig removes the files written by a previous configuration
+// that enabled Neuron, which take effect when the node reboots.
+func removeNeuronConfig() error {
+	for _, path := range []string{neuronModulesLoadPath, neuronSysctlPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountNeuronDevices returns the number of Neuron devices of the instance,
+// which are found by their PCI IDs so that they are found before the neuron
+// kernel module is loaded.
+func CountNeuronDevices() (int, error) {
+	return countNeuronDevices(pciDevicesDir)
+}
+
+func countNeuronDevices(devicesDir string) (int, error) {
+	return countAmazonPCIDevices(devicesDir, func(id string) bool {
+		return slices.Contains(neuronPCIDeviceIDs, id)
+	})
+}
@@ -0,0 +1,37 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountNeuronDevices(t *testing.T) {
+	writeDevice := func(dir string, address string, vendor string, device string) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, address), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, address, "vendor"), []byte(vendor+"\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, address, "device"), []byte(device+"\n"), 0644))
+	}
+
+	dir := t.TempDir()
+	// an ENA interface and an EFA interface, which are also Amazon devices
+	writeDevice(dir, "0000:00:05.0", "0x1d0f", "0xec20")
+	writeDevice(dir, "0000:00:06.0", "0x1d0f", "0xefa1")
+	count, err := countNeuronDevices(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	writeDevice(dir, "0000:00:1c.0", "0x1d0f", "0x7264")
+	writeDevice(dir, "0000:00:1d.0", "0x1d0f", "0x7264")
+	// a device of another vendor with the same ID
+	writeDevice(dir, "0000:00:1e.0", "0x8086", "0x7264")
+	count, err = countNeuronDevices(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = countNeuronDevices(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,87 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	podLogsAspectName = "pod-logs"
+	// PodLogsPath is where containerd writes the logs of containers.
+	PodLogsPath = "/var/log/pods"
+	podLogsPerm = 0755
+)
+
+// NewPodLogsAspect constructs new podLogsAspect.
+func NewPodLogsAspect() SystemAspect {
+	return &podLogsAspect{}
+}
+
+// podLogsAspect mounts a tmpfs over the pod log directory when pod logs are
+// stored in memory, so that writing logs does not cause disk IO.
+type podLogsAspect struct{}
+
+func (a *podLogsAspect) Name() string {
+	return podLogsAspectName
+}
+
+func (a *podLogsAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	podLogs := &cfg.Spec.Instance.PodLogs
+	if !podLogs.IsMemoryBacked() {
+		return nil
+	}
+	if err := os.MkdirAll(PodLogsPath, podLogsPerm); err != nil {
+		return err
+	}
+	mounts, err := os.Open("/proc/mounts")
+	if err != nil {
+		return err
+	}
+	defer mounts.Close()
+	mounted, err := isTmpfsMounted(mounts, PodLogsPath)
+	if err != nil {
+		return err
+	}
+	limit := podLogs.GetMemoryLimit()
+	size := fmt.Sprintf("size=%d", limit.Value())
+	var args []string
+	if mounted {
+		// resize the existing tmpfs without losing the logs it holds
+		args = []string{"-o", "remount," + size, PodLogsPath}
+	} else {
+		args = []string{"-t", "tmpfs", "-o", fmt.Sprintf("%s,mode=%o", size, podLogsPerm), "tmpfs", PodLogsPath}
+	}
+	zap.L().Info("Mounting tmpfs for pod logs..", zap.String("path", PodLogsPath), zap.String("size", limit.String()))
+	cmd := exec.Command("mount", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to mount tmpfs for pod logs: %w", err)
+	}
+	return nil
+}
+
+// isTmpfsMounted returns whether a tmpfs is mounted at path, according to the
+// mount table in the format of /proc/mounts.
+func isTmpfsMounted(mounts io.Reader, path string) (bool, error) {
+	scanner := bufio.NewScanner(mounts)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		if fields[1] == path && fields[2] == "tmpfs" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,38 @@
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTmpfsMounted(t *testing.T) {
+	var tests = []struct {
+		name     string
+		mounts   string
+		expected bool
+	}{
+		{
+			name:   "not mounted",
+			mounts: "/dev/nvme0n1p1 / xfs rw,noatime 0 0\ntmpfs /run tmpfs rw,nosuid,nodev,size=1585328k,mode=755 0 0\n",
+		},
+		{
+			name:     "tmpfs",
+			mounts:   "/dev/nvme0n1p1 / xfs rw,noatime 0 0\ntmpfs /var/log/pods tmpfs rw,relatime,size=524288k,mode=755 0 0\n",
+			expected: true,
+		},
+		{
+			name:   "local disk",
+			mounts: "/dev/md127 /var/log/pods xfs rw,relatime 0 0\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mounted, err := isTmpfsMounted(strings.NewReader(test.mounts), PodLogsPath)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, mounted)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/partitions"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	proxyAspectName = "proxy"
+	// ProxyEnvironmentPath is the environment file that holds the proxy
+	// configuration for the systemd units of nodeadm, containerd, and kubelet.
+	ProxyEnvironmentPath = "/etc/eks/nodeadm/proxy/environment"
+	proxyFilePerm        = 0644
+	proxyDropInName      = "10-nodeadm-proxy.conf"
+	systemdUnitDir       = "/etc/systemd/system"
+)
+
+// destinations that are always reached directly, namely the loopback
+// interface and the IPv4 and IPv6 endpoints of the instance metadata service,
+// along with the DNS names of interface VPC endpoints of getVPCEndpointDomains.
+var defaultNoProxy = []string{
+	"localhost",
+	"127.0.0.1",
+	"169.254.169.254",
+	"fd00:ec2::254",
+}
+
+// the daemons that are configured to use the proxy
+var proxyDaemons = []string{"containerd", "kubelet"}
+
+const sociDaemonName = "soci-snapshotter"
+
+// NewProxyAspect constructs new proxyAspect.
+func NewProxyAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &proxyAspect{daemonManager: daemonManager}
+}
+
+// proxyAspect renders the proxy configuration for the daemons on the node,
+// which read it from a systemd drop-in when they are started.
+type proxyAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *proxyAspect) Name() string {
+	return proxyAspectName
+}
+
+func (a *proxyAspect) Setup(ctx context.Context, cfg *api.NodeConfig) error {
+	daemons := slices.Clone(proxyDaemons)
+	if cfg.IsHybrid() {
+		daemons = append(daemons, ssmAgentDaemonName)
+	}
+	if api.IsFeatureEnabled(api.FastContainerImagePull, cfg.Spec.FeatureGates) {
+		// layers are pulled by the snapshotter rather than containerd
+		daemons = append(daemons, sociDaemonName)
+	}
+	if !IsProxyEnabled(cfg) {
+		return a.removeProxyConfig(daemons)
+	}
+	vpcCIDRs, err := getVPCCIDRs(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	environment := getProxyEnvironment(cfg, vpcCIDRs)
+	var buf strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(environment)) {
+		fmt.Fprintf(&buf, "%s=%s\n", key, environment[key])
+	}
+	zap.L().Info("Writing proxy environment..", zap.String("path", ProxyEnvironmentPath))
+	if err := util.WriteFileWithDir(ProxyEnvironmentPath, []byte(buf.String()), proxyFilePerm); err != nil {
+		return err
+	}
+	dropIn := fmt.Sprintf("[Service]\nEnvironmentFile=%s\n", ProxyEnvironmentPath)
+	for _, name := range daemons {
+		if err := util.WriteFileWithDir(getProxyDropInPath(name), []byte(dropIn), proxyFilePerm); err != nil {
+			return err
+		}
+	}
+	if err := a.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	// daemons that are already running are restarted to pick up the proxy,
+	// the remainder are started with it by their own daemon or aspect.
+	for _, name := range daemons {
+		if status, err := a.daemonManager.GetDaemonStatus(name); err != nil {
+			return err
+		} else if status == daemon.DaemonStatusRunning {
+			zap.L().Info("Restarting daemon to use proxy..", zap.String("name", name))
+			if err := a.daemonManager.RestartDaemon(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeProxyConfig removes the drop-ins written by a previous
+// configuration that used a proxy.
+func (a *proxyAspect) removeProxyConfig(daemons []string) error {
+	paths := []string{ProxyEnvironmentPath}
+	for _, name := range daemons {
+		paths = append(paths, getProxyDropInPath(name))
+	}
+	removed := false
+	for _, path := range paths {
+		if err := os.Remove(path); err == nil {
+			removed = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if removed {
+		return a.daemonManager.DaemonReload()
+	}
+	return nil
+}
+
+func getProxyDropInPath(daemonName string) string {
+	return filepath.Join(systemdUnitDir, daemonName+".service.d", proxyDropInName)
+}
+
+// IsProxyEnabled returns whether the NodeConfig specifies a proxy.
+func IsProxyEnabled(cfg *api.NodeConfig) bool {
+	return cfg.Spec.Proxy.HTTPProxy != "" || cfg.Spec.Proxy.HTTPSProxy != ""
+}
+
+// SetProxyEnvironment sets the proxy environment variables of the current
+// process, which are inherited by the commands it runs.
+func SetProxyEnvironment(cfg *api.NodeConfig) error {
+	if !IsProxyEnabled(cfg) {
+		return nil
+	}
+	for key, value := range getProxyEnvironment(cfg, nil) {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getProxyEnvironment returns the proxy environment variables, in both upper
+// and lower case as tools differ in which they respect.
+func getProxyEnvironment(cfg *api.NodeConfig, vpcCIDRs []string) map[string]string {
+	environment := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			environment[key] = value
+			environment[strings.ToLower(key)] = value
+		}
+	}
+	set("HTTP_PROXY", cfg.Spec.Proxy.HTTPProxy)
+	set("HTTPS_PROXY", cfg.Spec.Proxy.HTTPSProxy)
+	set("NO_PROXY", strings.Join(getNoProxy(cfg, vpcCIDRs), ","))
+	return environment
+}
+
+// getNoProxy returns the destinations that bypass the proxy, which are the
+// defaults, the cluster's service CIDR, the VPC's CIDR blocks, and those of
+// the NodeConfig.
+func getNoProxy(cfg *api.NodeConfig, vpcCIDRs []string) []string {
+	noProxy := append(slices.Clone(defaultNoProxy), getVPCEndpointDomains()...)
+	if cfg.Spec.Cluster.CIDR != "" {
+		// the blocks of a dual-stack cluster are separated by a comma
+		for _, cidr := range strings.Split(cfg.Spec.Cluster.CIDR, ",") {
+			noProxy = append(noProxy, strings.TrimSpace(cidr))
+		}
+	}
+	noProxy = append(noProxy, vpcCIDRs...)
+	for _, entry := range cfg.Spec.Proxy.NoProxy {
+		if !slices.Contains(noProxy, entry) {
+			noProxy = append(noProxy, entry)
+		}
+	}
+	return noProxy
+}
+
+// getVPCEndpointDomains returns the domains of the DNS names of interface VPC
+// endpoints in every partition, such as `.vpce.amazonaws.com.cn`, since the
+// region of the node is not known until its NodeConfig is enriched.
+func getVPCEndpointDomains() []string {
+	var domains []string
+	for _, p := range partitions.All() {
+		domain := ".vpce." + p.DNSSuffix
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// getVPCCIDRs returns the IPv4 and IPv6 CIDR blocks of the VPC of the
+// instance's primary network interface, which are not known without the
+// instance metadata.
+func getVPCCIDRs(ctx context.Context, cfg *api.NodeConfig) ([]string, error) {
+	if !cfg.HasInstanceMetadata() {
+		return nil, nil
+	}
+	ipv4CIDRs, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/vpc-ipv4-cidr-blocks", cfg.Status.Instance.MAC)))
+	if err != nil {
+		return nil, err
+	}
+	cidrs := strings.Fields(ipv4CIDRs)
+	// the property is not present when the VPC has no IPv6 CIDR blocks
+	if ipv6CIDRs, err := imds.GetProperty(ctx, imds.IMDSProperty(fmt.Sprintf("network/interfaces/macs/%s/vpc-ipv6-cidr-blocks", cfg.Status.Instance.MAC))); err == nil {
+		cidrs = append(cidrs, strings.Fields(ipv6CIDRs)...)
+	}
+	return cidrs, nil
+}
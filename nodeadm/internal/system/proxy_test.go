@@ -0,0 +1,29 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGetProxyEnvironment(t *testing.T) {
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{CIDR: "172.20.0.0/16"},
+			Proxy: api.ProxyOptions{
+				HTTPSProxy: "http://proxy.example.com:3128",
+				NoProxy:    []string{".example.com", "localhost"},
+			},
+		},
+	}
+	noProxy := "localhost,127.0.0.1,169.254.169.254,fd00:ec2::254,.vpce.sc2s.sgov.gov,.vpce.csp.hci.ic.gov,.vpce.c2s.ic.gov,.vpce.cloud.adc-e.uk,.vpce.amazonaws.com,.vpce.amazonaws.com.cn,172.20.0.0/16,10.0.0.0/16,.example.com"
+	expected := map[string]string{
+		"HTTPS_PROXY": "http://proxy.example.com:3128",
+		"https_proxy": "http://proxy.example.com:3128",
+		"NO_PROXY":    noProxy,
+		"no_proxy":    noProxy,
+	}
+	assert.Equal(t, expected, getProxyEnvironment(&cfg, []string{"10.0.0.0/16"}))
+}
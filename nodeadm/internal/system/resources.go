@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,9 +13,11 @@ import (
 )
 
 var (
-	cpuDirRegExp = regexp.MustCompile(`/cpu(\d+)`)
-	nodeDir      = "/sys/devices/system/node"
-	cpusPath     = "/sys/devices/system/cpu"
+	cpuDirRegExp  = regexp.MustCompile(`/cpu(\d+)`)
+	nodeDirRegExp = regexp.MustCompile(`/node(\d+)$`)
+	nodeDir       = "/sys/devices/system/node"
+	cpusPath      = "/sys/devices/system/cpu"
+	memInfoPath   = "/proc/meminfo"
 )
 
 const (
@@ -32,6 +35,14 @@ type core struct {
 	SocketID int      `json:"socket_id"`
 }
 
+// NUMANode is a NUMA node of the instance.
+type NUMANode struct {
+	ID int
+	// Cores are the logical CPUs of each physical core of the node, in the
+	// order of their first logical CPU.
+	Cores [][]int
+}
+
 func init() {
 	//cannot copy file to /sys for doc build, use this as a hack for testing
 	cpuDirEnv := os.Getenv("CPU_DIR")
@@ -81,6 +92,69 @@ func GetMilliNumCores() (int, error) {
 
 }
 
+// GetNUMANodes returns the NUMA nodes of the instance with their online CPUs,
+// in the order of their IDs. It returns no nodes when the NUMA topology is not
+// available.
+func GetNUMANodes() ([]NUMANode, error) {
+	nodesDirs, err := getNodesPaths()
+	if err != nil {
+		return nil, err
+	}
+	var nodes []NUMANode
+	for _, dir := range nodesDirs {
+		matches := nodeDirRegExp.FindStringSubmatch(dir)
+		if len(matches) != 2 {
+			return nil, fmt.Errorf("unexpected format of node directory, nodeDirRegExp %s, nodeDir: %s", nodeDirRegExp, dir)
+		}
+		id, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, err
+		}
+		cpuDirs, err := getCPUsPaths(dir)
+		if err != nil {
+			return nil, err
+		}
+		cores, err := getCoresInfo(cpuDirs)
+		if err != nil {
+			return nil, err
+		}
+		node := NUMANode{ID: id}
+		for _, core := range cores {
+			cpus := make([]int, 0, len(core.Threads))
+			for _, thread := range core.Threads {
+				cpus = append(cpus, int(thread))
+			}
+			slices.Sort(cpus)
+			node.Cores = append(node.Cores, cpus)
+		}
+		slices.SortFunc(node.Cores, func(a, b []int) int { return a[0] - b[0] })
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b NUMANode) int { return a.ID - b.ID })
+	return nodes, nil
+}
+
+// GetMemoryMebibytes returns the total memory of the instance that is usable
+// by the kernel, in mebibytes.
+func GetMemoryMebibytes() (int, error) {
+	data, err := os.ReadFile(memInfoPath)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != "MemTotal:" || fields[2] != "kB" {
+			continue
+		}
+		kibibytes, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemTotal in %s: %w", memInfoPath, err)
+		}
+		return kibibytes / 1024, nil
+	}
+	return 0, fmt.Errorf("MemTotal not found in %s", memInfoPath)
+}
+
 func getCPUCount() (int, error) {
 	cpusPaths, err := getCPUsPaths(cpusPath)
 	if err != nil {
@@ -0,0 +1,61 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTopology writes the sysfs topology of CPUs, keyed by their NUMA node,
+// with the given core of each CPU.
+func writeTopology(t *testing.T, cpuNodes map[int]int, cpuCores map[int]int) {
+	root := t.TempDir()
+	for cpu, node := range cpuNodes {
+		dir := filepath.Join(root, "node", fmt.Sprintf("node%d", node), fmt.Sprintf("cpu%d", cpu), "topology")
+		assert.NoError(t, os.MkdirAll(dir, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "core_id"), []byte(fmt.Sprintf("%d\n", cpuCores[cpu])), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "physical_package_id"), []byte(fmt.Sprintf("%d\n", node)), 0644))
+	}
+	previousNodeDir, previousCPUsPath := nodeDir, cpusPath
+	nodeDir, cpusPath = filepath.Join(root, "node"), filepath.Join(root, "cpu")
+	t.Cleanup(func() { nodeDir, cpusPath = previousNodeDir, previousCPUsPath })
+}
+
+func TestGetNUMANodes(t *testing.T) {
+	writeTopology(t,
+		map[int]int{0: 0, 1: 0, 2: 1, 3: 1, 4: 0, 5: 0, 6: 1, 7: 1, 10: 1},
+		map[int]int{0: 0, 1: 1, 2: 0, 3: 1, 4: 0, 5: 1, 6: 0, 7: 1, 10: 2},
+	)
+	nodes, err := GetNUMANodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []NUMANode{
+		{ID: 0, Cores: [][]int{{0, 4}, {1, 5}}},
+		{ID: 1, Cores: [][]int{{2, 6}, {3, 7}, {10}}},
+	}, nodes)
+}
+
+func TestGetNUMANodesWithoutTopology(t *testing.T) {
+	writeTopology(t, nil, nil)
+	nodes, err := GetNUMANodes()
+	assert.NoError(t, err)
+	assert.Empty(t, nodes)
+}
+
+func TestGetMemoryMebibytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meminfo")
+	previous := memInfoPath
+	memInfoPath = path
+	t.Cleanup(func() { memInfoPath = previous })
+
+	assert.NoError(t, os.WriteFile(path, []byte("MemTotal:       16067792 kB\nMemFree:         9853464 kB\n"), 0644))
+	memory, err := GetMemoryMebibytes()
+	assert.NoError(t, err)
+	assert.Equal(t, 15691, memory)
+
+	assert.NoError(t, os.WriteFile(path, []byte("MemFree:         9853464 kB\n"), 0644))
+	_, err = GetMemoryMebibytes()
+	assert.ErrorContains(t, err, "MemTotal not found")
+}
@@ -0,0 +1,141 @@
+package system
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	swapAspectName = "swap"
+	// SwapFilePath is the swap file that is created for the File device.
+	SwapFilePath = "/swapfile"
+	swapFilePerm = 0600
+	zramPrefix   = "/dev/zram"
+	// zram is preferred over other swap space, since it is faster to access
+	zramSwapPriority = "100"
+)
+
+// NewSwapAspect constructs new swapAspect.
+func NewSwapAspect() SystemAspect {
+	return &swapAspect{}
+}
+
+// swapAspect creates and enables swap space before kubelet is started. Swap
+// space is not recorded in /etc/fstab, so it is enabled again on every boot.
+type swapAspect struct{}
+
+func (a *swapAspect) Name() string {
+	return swapAspectName
+}
+
+func (a *swapAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	swap := &cfg.Spec.Instance.Swap
+	if swap.Device == "" {
+		return nil
+	}
+	swaps, err := os.Open("/proc/swaps")
+	if err != nil {
+		return err
+	}
+	defer swaps.Close()
+	active, err := getActiveSwaps(swaps)
+	if err != nil {
+		return err
+	}
+	switch swap.Device {
+	case api.SwapDeviceFile:
+		return setupSwapFile(swap.Size.Value(), active)
+	case api.SwapDeviceZram:
+		return setupZramSwap(swap.Size.Value(), active)
+	}
+	return nil
+}
+
+func setupSwapFile(size int64, active []string) error {
+	for _, name := range active {
+		if name == SwapFilePath {
+			zap.L().Info("Swap file is already enabled", zap.String("path", SwapFilePath))
+			return nil
+		}
+	}
+	if exists, err := util.IsFilePathExists(SwapFilePath); err != nil {
+		return err
+	} else if !exists {
+		zap.L().Info("Creating swap file..", zap.String("path", SwapFilePath), zap.Int64("size", size))
+		// the file must be allocated, since swap space cannot have holes
+		if err := runSwapCommand("fallocate", "--length", strconv.FormatInt(size, 10), SwapFilePath); err != nil {
+			return err
+		}
+		if err := os.Chmod(SwapFilePath, swapFilePerm); err != nil {
+			return err
+		}
+		if err := runSwapCommand("mkswap", SwapFilePath); err != nil {
+			return err
+		}
+	}
+	zap.L().Info("Enabling swap file..", zap.String("path", SwapFilePath))
+	return runSwapCommand("swapon", SwapFilePath)
+}
+
+func setupZramSwap(size int64, active []string) error {
+	for _, name := range active {
+		if strings.HasPrefix(name, zramPrefix) {
+			zap.L().Info("zram swap is already enabled", zap.String("device", name))
+			return nil
+		}
+	}
+	if err := runSwapCommand("modprobe", "zram"); err != nil {
+		return err
+	}
+	zap.L().Info("Creating zram device..", zap.Int64("size", size))
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command("zramctl", "--find", "--size", strconv.FormatInt(size, 10), "--algorithm", "zstd").Output()
+	if err != nil {
+		return fmt.Errorf("failed to create zram device: %w", err)
+	}
+	device := string(bytes.TrimSpace(out))
+	if err := runSwapCommand("mkswap", device); err != nil {
+		return err
+	}
+	zap.L().Info("Enabling zram swap..", zap.String("device", device))
+	return runSwapCommand("swapon", "--priority", zramSwapPriority, device)
+}
+
+func runSwapCommand(name string, args ...string) error {
+	// #nosec G204 Subprocess launched with variable
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return nil
+}
+
+// getActiveSwaps returns the names of the swap space that is enabled,
+// according to a table in the format of /proc/swaps.
+func getActiveSwaps(swaps io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(swaps)
+	// the first line is a header
+	for first := true; scanner.Scan(); first = false {
+		fields := strings.Fields(scanner.Text())
+		if first || len(fields) == 0 {
+			continue
+		}
+		names = append(names, fields[0])
+	}
+	return names, scanner.Err()
+}
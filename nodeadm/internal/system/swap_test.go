@@ -0,0 +1,21 @@
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetActiveSwaps(t *testing.T) {
+	swaps := "Filename\t\t\t\tType\t\tSize\t\tUsed\t\tPriority\n" +
+		"/swapfile                               file\t\t2097148\t\t0\t\t-2\n" +
+		"/dev/zram0                              partition\t1048572\t\t0\t\t100\n"
+	active, err := getActiveSwaps(strings.NewReader(swaps))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/swapfile", "/dev/zram0"}, active)
+
+	active, err = getActiveSwaps(strings.NewReader("Filename\t\t\t\tType\t\tSize\t\tUsed\t\tPriority\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, active)
+}
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	sysctlAspectName = "sysctl"
+	// the drop-in sorts after that of the hardening profile, so that the
+	// parameters of the NodeConfig take precedence
+	sysctlPath     = "/etc/sysctl.d/99-nodeadm-sysctl.conf"
+	sysctlFilePerm = 0644
+)
+
+// NewSysctlAspect constructs new sysctlAspect.
+func NewSysctlAspect() SystemAspect {
+	return &sysctlAspect{}
+}
+
+// sysctlAspect sets the kernel parameters of the NodeConfig, and persists them
+// so that they are set again by systemd-sysctl when the node reboots.
+type sysctlAspect struct{}
+
+func (a *sysctlAspect) Name() string {
+	return sysctlAspectName
+}
+
+func (a *sysctlAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	if len(cfg.Spec.System.Sysctl) == 0 {
+		// the parameters of a previous configuration are not set on the next
+		// boot
+		if err := os.Remove(sysctlPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	zap.L().Info("Writing kernel parameters..", zap.String("path", sysctlPath))
+	if err := util.WriteFileWithDir(sysctlPath, generateSysctlConf("system.sysctl", cfg.Spec.System.Sysctl), sysctlFilePerm); err != nil {
+		return err
+	}
+	cmd := exec.Command("sysctl", "--load", sysctlPath)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set kernel parameters: %w", err)
+	}
+	return nil
+}
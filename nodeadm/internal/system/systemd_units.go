@@ -0,0 +1,146 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	systemdUnitsAspectName = "systemd-units"
+	systemdDropInPerm      = 0644
+	// systemdDropInsStatePath records the drop-ins written from the
+	// NodeConfig, so that those removed from it are removed from the node.
+	systemdDropInsStatePath = "/var/lib/nodeadm/systemd-drop-ins.json"
+)
+
+// NewSystemdUnitsAspect constructs new systemdUnitsAspect.
+func NewSystemdUnitsAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &systemdUnitsAspect{
+		daemonManager: daemonManager,
+		unitDir:       systemdUnitDir,
+		statePath:     systemdDropInsStatePath,
+	}
+}
+
+// systemdUnitsAspect writes the drop-ins of the systemd units of the
+// NodeConfig, before the units that nodeadm manages are started.
+type systemdUnitsAspect struct {
+	daemonManager daemon.DaemonManager
+	unitDir       string
+	statePath     string
+}
+
+func (a *systemdUnitsAspect) Name() string {
+	return systemdUnitsAspectName
+}
+
+func (a *systemdUnitsAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	previous, err := a.readState()
+	if err != nil {
+		return err
+	}
+	var written []string
+	// the units whose drop-ins were written, changed or removed
+	var changed []string
+	for _, unit := range cfg.Spec.Systemd.Units {
+		for _, dropIn := range unit.DropIns {
+			path := filepath.Join(a.unitDir, unit.Name+".d", dropIn.Name)
+			written = append(written, path)
+			if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, []byte(dropIn.Content)) {
+				continue
+			} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			zap.L().Info("Writing systemd drop-in..", zap.String("unit", unit.Name), zap.String("path", path))
+			if err := util.WriteFileWithDir(path, []byte(dropIn.Content), systemdDropInPerm); err != nil {
+				return err
+			}
+			changed = appendUnique(changed, unit.Name)
+		}
+	}
+	for _, path := range previous {
+		if slices.Contains(written, path) {
+			continue
+		}
+		zap.L().Info("Removing systemd drop-in..", zap.String("path", path))
+		if err := os.Remove(path); err == nil {
+			changed = appendUnique(changed, strings.TrimSuffix(filepath.Base(filepath.Dir(path)), ".d"))
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := a.writeState(written); err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := a.daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+	// services that are already running are restarted to apply their
+	// drop-ins, the remainder apply them when they are started.
+	for _, unit := range changed {
+		name, ok := strings.CutSuffix(unit, ".service")
+		if !ok {
+			continue
+		}
+		if status, err := a.daemonManager.GetDaemonStatus(name); err != nil {
+			return err
+		} else if status == daemon.DaemonStatusRunning {
+			zap.L().Info("Restarting daemon to apply drop-ins..", zap.String("name", name))
+			if err := a.daemonManager.RestartDaemon(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readState returns the paths of the drop-ins written by the previous setup.
+func (a *systemdUnitsAspect) readState() ([]string, error) {
+	data, err := os.ReadFile(a.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var paths []string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+func (a *systemdUnitsAspect) writeState(paths []string) error {
+	if len(paths) == 0 {
+		if err := os.Remove(a.statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(paths)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileWithDir(a.statePath, data, systemdDropInPerm)
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
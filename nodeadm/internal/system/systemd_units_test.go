@@ -0,0 +1,55 @@
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func TestSystemdUnitsAspect(t *testing.T) {
+	dir := t.TempDir()
+	manager := daemontest.NewFakeManager("containerd")
+	aspect := &systemdUnitsAspect{
+		daemonManager: manager,
+		unitDir:       filepath.Join(dir, "system"),
+		statePath:     filepath.Join(dir, "systemd-drop-ins.json"),
+	}
+	limits := "[Service]\nLimitNOFILE=1048576\n"
+	cfg := &api.NodeConfig{Spec: api.NodeConfigSpec{Systemd: api.SystemdOptions{Units: []api.SystemdUnit{
+		{Name: "containerd.service", DropIns: []api.SystemdDropIn{{Name: "50-limits.conf", Content: limits}}},
+		{Name: "kubepods.slice", DropIns: []api.SystemdDropIn{{Name: "50-memory.conf", Content: "[Slice]\nMemoryAccounting=yes\n"}}},
+	}}}}
+	containerdDropIn := filepath.Join(dir, "system", "containerd.service.d", "50-limits.conf")
+	sliceDropIn := filepath.Join(dir, "system", "kubepods.slice.d", "50-memory.conf")
+
+	assert.NoError(t, aspect.Setup(context.Background(), cfg))
+	content, err := os.ReadFile(containerdDropIn)
+	assert.NoError(t, err)
+	assert.Equal(t, limits, string(content))
+	assert.FileExists(t, sliceDropIn)
+	assert.Equal(t, []string{"daemon-reload", "status containerd", "restart containerd"}, manager.Calls())
+
+	// nothing is reloaded or restarted when the drop-ins have not changed
+	assert.NoError(t, aspect.Setup(context.Background(), cfg))
+	assert.Len(t, manager.Calls(), 3)
+
+	// drop-ins that were removed from the configuration are removed
+	cfg.Spec.Systemd.Units = cfg.Spec.Systemd.Units[:1]
+	assert.NoError(t, aspect.Setup(context.Background(), cfg))
+	assert.NoFileExists(t, sliceDropIn)
+	assert.FileExists(t, containerdDropIn)
+	assert.Equal(t, "daemon-reload", manager.Calls()[3])
+	assert.Len(t, manager.Calls(), 4)
+
+	cfg.Spec.Systemd.Units = nil
+	assert.NoError(t, aspect.Setup(context.Background(), cfg))
+	assert.NoFileExists(t, containerdDropIn)
+	assert.NoFileExists(t, aspect.statePath)
+	assert.Equal(t, []string{"daemon-reload", "status containerd", "restart containerd"}, manager.Calls()[4:])
+}
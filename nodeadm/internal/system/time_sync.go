@@ -0,0 +1,108 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	timeSyncAspectName = "time-sync"
+	// ChronySourcesPath is the sources file of chronyd rendered by nodeadm,
+	// which is read from the sourcedir of the chrony configuration.
+	ChronySourcesPath      = "/etc/chrony.d/eks-nodeadm.sources"
+	chronySourcesPerm      = 0644
+	chronyDaemonName       = "chronyd"
+	defaultTimeSyncTimeout = 2 * time.Minute
+	timeSyncCheckInterval  = time.Second
+)
+
+// the IPv4 and IPv6 link-local endpoints of the Amazon Time Sync Service, of
+// which the IPv6 endpoint is only reachable from Nitro instances.
+var amazonTimeSyncServers = []string{"169.254.169.123", "fd00:ec2::123"}
+
+// NewTimeSyncAspect constructs new timeSyncAspect.
+func NewTimeSyncAspect(daemonManager daemon.DaemonManager) SystemAspect {
+	return &timeSyncAspect{daemonManager: daemonManager}
+}
+
+// timeSyncAspect configures the time sources of chronyd, and waits for the
+// clock to be synchronized, since a clock that is off causes the credentials
+// and certificates of the node to be rejected.
+type timeSyncAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+func (a *timeSyncAspect) Name() string {
+	return timeSyncAspectName
+}
+
+func (a *timeSyncAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	timeSync := cfg.Spec.Instance.TimeSync
+	if cfg.IsHybrid() && len(timeSync.Servers) == 0 {
+		// the Amazon Time Sync Service cannot be reached outside of EC2
+		return nil
+	}
+	zap.L().Info("Writing chrony sources..", zap.String("path", ChronySourcesPath))
+	if err := util.WriteFileWithDir(ChronySourcesPath, []byte(getChronySources(timeSync.Servers)), chronySourcesPerm); err != nil {
+		return err
+	}
+	if status, err := a.daemonManager.GetDaemonStatus(chronyDaemonName); err != nil {
+		return err
+	} else if status == daemon.DaemonStatusRunning {
+		zap.L().Info("Reloading chrony sources..")
+		if out, err := exec.Command("chronyc", "reload", "sources").CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to reload chrony sources: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	} else {
+		zap.L().Info("Starting daemon..", zap.String("name", chronyDaemonName))
+		if err := a.daemonManager.StartDaemon(chronyDaemonName); err != nil {
+			return err
+		}
+	}
+	timeout := defaultTimeSyncTimeout
+	if timeSync.SyncTimeout != nil {
+		timeout = timeSync.SyncTimeout.Duration
+	}
+	zap.L().Info("Waiting for the clock to be synchronized..", zap.Duration("timeout", timeout))
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command("chronyc", getWaitSyncArgs(timeout)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("clock was not synchronized within %s: %w: %s", timeout, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// getChronySources returns the sources file of chronyd. The Amazon Time Sync
+// Service is polled every 16 seconds, as its endpoints are on the host of the
+// instance.
+func getChronySources(servers []string) string {
+	var sources strings.Builder
+	if len(servers) == 0 {
+		for _, server := range amazonTimeSyncServers {
+			fmt.Fprintf(&sources, "server %s prefer iburst minpoll 4 maxpoll 4\n", server)
+		}
+		return sources.String()
+	}
+	for _, server := range servers {
+		fmt.Fprintf(&sources, "server %s prefer iburst\n", server)
+	}
+	return sources.String()
+}
+
+// getWaitSyncArgs returns the arguments of `chronyc waitsync`, which checks
+// whether the clock is synchronized once each interval, for at most the
+// number of tries that fit in the timeout.
+func getWaitSyncArgs(timeout time.Duration) []string {
+	tries := int(math.Ceil(timeout.Seconds() / timeSyncCheckInterval.Seconds()))
+	return []string{"waitsync", fmt.Sprint(max(tries, 1)), "0", "0", fmt.Sprint(timeSyncCheckInterval.Seconds())}
+}
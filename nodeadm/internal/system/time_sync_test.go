@@ -0,0 +1,19 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChronySources(t *testing.T) {
+	assert.Equal(t, "server 169.254.169.123 prefer iburst minpoll 4 maxpoll 4\nserver fd00:ec2::123 prefer iburst minpoll 4 maxpoll 4\n", getChronySources(nil))
+	assert.Equal(t, "server ntp1.example.com prefer iburst\nserver 10.0.0.123 prefer iburst\n", getChronySources([]string{"ntp1.example.com", "10.0.0.123"}))
+}
+
+func TestGetWaitSyncArgs(t *testing.T) {
+	assert.Equal(t, []string{"waitsync", "120", "0", "0", "1"}, getWaitSyncArgs(2*time.Minute))
+	assert.Equal(t, []string{"waitsync", "2", "0", "0", "1"}, getWaitSyncArgs(1500*time.Millisecond))
+	assert.Equal(t, []string{"waitsync", "1", "0", "0", "1"}, getWaitSyncArgs(0))
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/token"
+)
+
+const tokenCacheAspectName = "token-cache"
+
+// NewTokenCacheAspect constructs new tokenCacheAspect.
+func NewTokenCacheAspect() SystemAspect {
+	return &tokenCacheAspect{}
+}
+
+// tokenCacheAspect pre-warms the cache of the token that kubelet uses to
+// authenticate to the cluster, so that the credentials of the node are
+// obtained, and retried if necessary, before kubelet is started rather than
+// when it first contacts the cluster.
+type tokenCacheAspect struct{}
+
+func (a *tokenCacheAspect) Name() string {
+	return tokenCacheAspectName
+}
+
+func (a *tokenCacheAspect) Setup(ctx context.Context, cfg *api.NodeConfig) error {
+	if !cfg.Spec.Kubelet.TokenCache.Enabled {
+		return nil
+	}
+	opts := token.NewOptions(cfg)
+	zap.L().Info("Pre-warming token cache..", zap.String("path", token.CachePath), zap.String("cluster", opts.ClusterName))
+	t, cached, err := token.Get(ctx, opts)
+	if err != nil {
+		// kubelet pre-signs a token itself when the cache is empty, so a
+		// failure to pre-warm should not fail the bootstrap of the node.
+		zap.L().Warn("Failed to pre-warm token cache", zap.Error(err))
+		return nil
+	}
+	zap.L().Info("Pre-warmed token cache", zap.Bool("cached", cached), zap.Time("expiration", t.Expiration))
+	return nil
+}
@@ -0,0 +1,80 @@
+package system
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	trustStoreAspectName = "trust-store"
+	trustStoreAnchorPath = "/etc/pki/ca-trust/source/anchors/nodeadm.pem"
+	trustStoreAnchorPerm = 0644
+	// TrustStoreBundlePath is the bundle of every certificate authority in the
+	// operating system's trust store, as extracted by update-ca-trust.
+	TrustStoreBundlePath = "/etc/pki/tls/certs/ca-bundle.crt"
+)
+
+// NewTrustStoreAspect constructs new trustStoreAspect.
+func NewTrustStoreAspect() SystemAspect {
+	return &trustStoreAspect{}
+}
+
+// trustStoreAspect installs additional certificate authorities into the
+// operating system's trust store, which is used by containerd when pulling
+// images and by the credential providers of kubelet.
+type trustStoreAspect struct{}
+
+func (a *trustStoreAspect) Name() string {
+	return trustStoreAspectName
+}
+
+func (a *trustStoreAspect) Setup(_ context.Context, cfg *api.NodeConfig) error {
+	certificateAuthorities := cfg.Spec.Instance.TrustStore.CertificateAuthorities
+	if certificateAuthorities == "" {
+		// remove certificate authorities installed by a previous configuration
+		if err := os.Remove(trustStoreAnchorPath); errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return updateTrustStore()
+	}
+	zap.L().Info("Installing certificate authorities into trust store..", zap.String("path", trustStoreAnchorPath))
+	if err := util.WriteFileWithDir(trustStoreAnchorPath, []byte(certificateAuthorities), trustStoreAnchorPerm); err != nil {
+		return err
+	}
+	return updateTrustStore()
+}
+
+func updateTrustStore() error {
+	cmd := exec.Command("update-ca-trust", "extract")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to update trust store: %w", err)
+	}
+	return nil
+}
+
+// NewCertPool returns the system's certificate pool with the certificate
+// authorities of the trust store options added, so that they are trusted
+// before they have been installed into the operating system's trust store.
+func NewCertPool(trustStore *api.TrustStoreOptions) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, err
+	}
+	if !pool.AppendCertsFromPEM([]byte(trustStore.CertificateAuthorities)) {
+		return nil, fmt.Errorf("no certificates found in trust store certificate authorities")
+	}
+	return pool, nil
+}
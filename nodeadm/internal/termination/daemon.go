@@ -0,0 +1,74 @@
+package termination
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+const (
+	TerminationHandlerDaemonName = "nodeadm-termination-handler"
+
+	environmentFilePath = "/etc/eks/nodeadm/termination-handler/environment"
+	argsEnvironmentName = "NODEADM_TERMINATION_HANDLER_ARGS"
+)
+
+var (
+	_ daemon.Daemon      = &terminationHandler{}
+	_ daemon.Restartable = &terminationHandler{}
+)
+
+// terminationHandler manages the unit that runs `nodeadm termination-handler`,
+// which cordons and drains the node when the instance is about to be
+// terminated or stopped.
+type terminationHandler struct {
+	daemon.EnvironmentUnit
+}
+
+func NewTerminationHandlerDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &terminationHandler{
+		EnvironmentUnit: daemon.NewEnvironmentUnit(daemonManager, TerminationHandlerDaemonName, environmentFilePath, argsEnvironmentName),
+	}
+}
+
+func (t *terminationHandler) Configure(_ context.Context, cfg *api.NodeConfig) error {
+	args, enabled := generateArgs(cfg)
+	if !enabled {
+		return t.Disable()
+	}
+	return t.Enable(args)
+}
+
+// generateArgs returns the arguments of the unit, or false if the
+// termination handler is not enabled. DrainOnSpotInterruption is handled by
+// the termination handler as well, so that only one service drains the node.
+func generateArgs(cfg *api.NodeConfig) (string, bool) {
+	shutdown := cfg.Spec.Instance.Shutdown
+	handler := shutdown.TerminationHandler
+	events := handler.Events
+	if shutdown.DrainOnSpotInterruption && !slices.Contains(events, api.TerminationEventSpotInterruption) {
+		events = append(slices.Clone(events), api.TerminationEventSpotInterruption)
+	}
+	if len(events) == 0 {
+		return "", false
+	}
+	drainTimeout := deregister.DefaultDrainTimeout
+	if shutdown.DrainTimeout != nil {
+		drainTimeout = shutdown.DrainTimeout.Duration
+	}
+	pollInterval := DefaultPollInterval
+	if handler.PollInterval != nil {
+		pollInterval = handler.PollInterval.Duration
+	}
+	var eventNames []string
+	for _, event := range events {
+		eventNames = append(eventNames, string(event))
+	}
+	return fmt.Sprintf("--node-name=%s --drain-timeout=%s --poll-interval=%s --events=%s", kubelet.GetNodeName(cfg), drainTimeout, pollInterval, strings.Join(eventNames, ",")), true
+}
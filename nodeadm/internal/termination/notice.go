@@ -0,0 +1,126 @@
+package termination
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+)
+
+// DefaultPollInterval is used when a poll interval is not configured. It is
+// how often the interruption notice of a Spot Instance is recommended to be
+// checked for, since the instance is interrupted two minutes after it.
+const DefaultPollInterval = 5 * time.Second
+
+// terminatingEventCodes are the codes of the scheduled events that stop or
+// reboot the instance, unlike system-maintenance, which does not.
+var terminatingEventCodes = []string{"instance-reboot", "system-reboot", "instance-retirement", "instance-stop"}
+
+// Notice is an event that terminates or stops the instance.
+type Notice struct {
+	Event api.TerminationEvent
+	// Reason describes the event, such as the action of a Spot Instance
+	// interruption.
+	Reason string
+}
+
+// checkFunc returns the notice of an event, or nil if it has not occurred.
+type checkFunc func(ctx context.Context) (*Notice, error)
+
+// WaitForNotice blocks until any of the events occurs, and returns its
+// notice.
+func WaitForNotice(ctx context.Context, events []api.TerminationEvent, interval time.Duration) (*Notice, error) {
+	var checks []checkFunc
+	for _, event := range events {
+		switch event {
+		case api.TerminationEventSpotInterruption:
+			checks = append(checks, checkSpotInterruption)
+		case api.TerminationEventLifecycleTermination:
+			checks = append(checks, checkLifecycleTermination)
+		case api.TerminationEventScheduledEvent:
+			checks = append(checks, checkScheduledEvents)
+		default:
+			return nil, fmt.Errorf("unknown termination event %q", event)
+		}
+	}
+	return waitForNotice(ctx, checks, interval)
+}
+
+func waitForNotice(ctx context.Context, checks []checkFunc, interval time.Duration) (*Notice, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, check := range checks {
+			notice, err := check(ctx)
+			if err != nil {
+				// a notice is not missed by skipping a check, since each
+				// event stays present until the instance is terminated
+				zap.L().Warn("Failed to check for a termination event of the instance", zap.Error(err))
+			} else if notice != nil {
+				return notice, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkSpotInterruption(ctx context.Context) (*Notice, error) {
+	interruption, err := imds.GetSpotInterruption(ctx)
+	if err != nil || interruption == nil {
+		return nil, err
+	}
+	return &Notice{
+		Event:  api.TerminationEventSpotInterruption,
+		Reason: fmt.Sprintf("%s at %s", interruption.Action, interruption.Time.Format(time.RFC3339)),
+	}, nil
+}
+
+func checkLifecycleTermination(ctx context.Context) (*Notice, error) {
+	state, err := imds.GetTargetLifecycleState(ctx)
+	if err != nil || !isTerminatingLifecycleState(state) {
+		return nil, err
+	}
+	return &Notice{Event: api.TerminationEventLifecycleTermination, Reason: state}, nil
+}
+
+// isTerminatingLifecycleState returns whether the Auto Scaling group of the
+// instance is terminating it, including from its warm pool.
+func isTerminatingLifecycleState(state string) bool {
+	return state == "Terminated" || strings.HasSuffix(state, ":Terminated")
+}
+
+func checkScheduledEvents(ctx context.Context) (*Notice, error) {
+	events, err := imds.GetScheduledEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	event := getTerminatingEvent(events)
+	if event == nil {
+		return nil, nil
+	}
+	return &Notice{
+		Event:  api.TerminationEventScheduledEvent,
+		Reason: fmt.Sprintf("%s %s not before %s", event.Code, event.EventID, event.NotBefore),
+	}, nil
+}
+
+// getTerminatingEvent returns the first active event that stops or reboots
+// the instance, or nil if there is none.
+func getTerminatingEvent(events []imds.ScheduledEvent) *imds.ScheduledEvent {
+	for i, event := range events {
+		if event.State == "active" && slices.Contains(terminatingEventCodes, event.Code) {
+			return &events[i]
+		}
+	}
+	return nil
+}
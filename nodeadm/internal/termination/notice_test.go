@@ -0,0 +1,96 @@
+package termination
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+)
+
+// notices returns a check that returns the notices in order, repeating the
+// last one.
+func notices(notices ...*Notice) (checkFunc, *int) {
+	var calls int
+	return func(_ context.Context) (*Notice, error) {
+		notice := notices[min(calls, len(notices)-1)]
+		calls++
+		return notice, nil
+	}, &calls
+}
+
+func failing(_ context.Context) (*Notice, error) {
+	return nil, fmt.Errorf("imds unavailable")
+}
+
+func TestWaitForNotice(t *testing.T) {
+	scheduled := &Notice{Event: api.TerminationEventScheduledEvent, Reason: "instance-retirement"}
+	spot, spotCalls := notices(nil)
+	events, eventCalls := notices(nil, nil, scheduled)
+	notice, err := waitForNotice(context.Background(), []checkFunc{failing, spot, events}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, scheduled, notice)
+	assert.Equal(t, 3, *spotCalls)
+	assert.Equal(t, 3, *eventCalls)
+}
+
+func TestWaitForNoticeCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	check, _ := notices(nil)
+	_, err := waitForNotice(ctx, []checkFunc{check}, time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestIsTerminatingLifecycleState(t *testing.T) {
+	assert.True(t, isTerminatingLifecycleState("Terminated"))
+	assert.True(t, isTerminatingLifecycleState("Warmed:Terminated"))
+	assert.False(t, isTerminatingLifecycleState("InService"))
+	assert.False(t, isTerminatingLifecycleState("Warmed:Stopped"))
+	assert.False(t, isTerminatingLifecycleState(""))
+}
+
+func TestGetTerminatingEvent(t *testing.T) {
+	maintenance := imds.ScheduledEvent{Code: "system-maintenance", State: "active", EventID: "instance-event-1"}
+	canceled := imds.ScheduledEvent{Code: "instance-stop", State: "canceled", EventID: "instance-event-2"}
+	retirement := imds.ScheduledEvent{Code: "instance-retirement", State: "active", EventID: "instance-event-3"}
+	assert.Nil(t, getTerminatingEvent(nil))
+	assert.Nil(t, getTerminatingEvent([]imds.ScheduledEvent{maintenance, canceled}))
+	assert.Equal(t, &retirement, getTerminatingEvent([]imds.ScheduledEvent{maintenance, canceled, retirement}))
+}
+
+func TestGenerateEnvironment(t *testing.T) {
+	cfg := &api.NodeConfig{}
+	cfg.Status.Instance.ID = "i-1234567890abcdef0"
+	cfg.Status.Instance.PrivateDNSName = "ip-10-0-0-1.us-west-2.compute.internal"
+	_, enabled := generateArgs(cfg)
+	assert.False(t, enabled)
+
+	cfg.Spec.Instance.Shutdown = api.ShutdownOptions{
+		DrainTimeout: &metav1.Duration{Duration: 90 * time.Second},
+		TerminationHandler: api.TerminationHandlerOptions{
+			Events: []api.TerminationEvent{api.TerminationEventLifecycleTermination, api.TerminationEventScheduledEvent},
+		},
+	}
+	args, enabled := generateArgs(cfg)
+	assert.True(t, enabled)
+	assert.Equal(t, "--node-name=ip-10-0-0-1.us-west-2.compute.internal --drain-timeout=1m30s --poll-interval=5s --events=LifecycleTermination,ScheduledEvent", args)
+
+	cfg.Spec.Instance.Shutdown.DrainOnSpotInterruption = true
+	args, _ = generateArgs(cfg)
+	assert.Equal(t, "--node-name=ip-10-0-0-1.us-west-2.compute.internal --drain-timeout=1m30s --poll-interval=5s --events=LifecycleTermination,ScheduledEvent,SpotInterruption", args)
+
+	cfg.Spec.Instance.Shutdown.TerminationHandler.Events = []api.TerminationEvent{api.TerminationEventSpotInterruption}
+	args, _ = generateArgs(cfg)
+	assert.Equal(t, "--node-name=ip-10-0-0-1.us-west-2.compute.internal --drain-timeout=1m30s --poll-interval=5s --events=SpotInterruption", args)
+
+	cfg.Spec.Instance.Shutdown.TerminationHandler = api.TerminationHandlerOptions{}
+	args, enabled = generateArgs(cfg)
+	assert.True(t, enabled)
+	assert.Equal(t, "--node-name=ip-10-0-0-1.us-west-2.compute.internal --drain-timeout=1m30s --poll-interval=5s --events=SpotInterruption", args)
+}
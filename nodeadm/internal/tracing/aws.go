@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// AddAWSMiddleware records a client span for each call to an AWS API, which
+// includes its retries. It is added to the API options of an AWS config.
+func AddAWSMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("NodeadmTracing", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		service := awsmiddleware.GetServiceID(ctx)
+		operation := awsmiddleware.GetOperationName(ctx)
+		ctx, span := defaultTracer.start(ctx, service+"."+operation, SpanKindClient,
+			String("rpc.system", "aws-api"),
+			String("rpc.service", service),
+			String("rpc.method", operation),
+		)
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		span.End(err)
+		return out, metadata, err
+	}), middleware.Before)
+}
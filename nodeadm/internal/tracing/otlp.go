@@ -0,0 +1,179 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	serviceName   = "nodeadm"
+	tracesPath    = "/v1/traces"
+	exportTimeout = 10 * time.Second
+	traceFilePerm = 0644
+)
+
+// the types below are the JSON encoding of an OTLP trace export request.
+// see: https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanData `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanData struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// the status of a span that succeeded is left unset
+const statusCodeError = 2
+
+// Export sends the spans that have ended to the collector, and appends them
+// to the trace file, of the tracing options. Nothing is exported when neither
+// is set.
+func Export(ctx context.Context, opts *api.TracingOptions) error {
+	if opts.Endpoint == "" && opts.File == "" {
+		return nil
+	}
+	data, err := json.Marshal(defaultTracer.exportRequest())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	if opts.Endpoint != "" {
+		errs = append(errs, send(ctx, opts.Endpoint, data))
+	}
+	if opts.File != "" {
+		errs = append(errs, appendToFile(opts.File, data))
+	}
+	return errors.Join(errs...)
+}
+
+func (t *tracer) exportRequest() exportRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attributes := []keyValue{newKeyValue(String("service.name", serviceName))}
+	for _, attribute := range t.resource {
+		attributes = append(attributes, newKeyValue(attribute))
+	}
+	var spans []spanData
+	for _, span := range t.ended {
+		data := spanData{
+			TraceID:           hex.EncodeToString(span.traceID[:]),
+			SpanID:            hex.EncodeToString(span.spanID[:]),
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		}
+		if span.parentID != [8]byte{} {
+			data.ParentSpanID = hex.EncodeToString(span.parentID[:])
+		}
+		for _, attribute := range span.attributes {
+			data.Attributes = append(data.Attributes, newKeyValue(attribute))
+		}
+		if span.err != nil {
+			data.Status = status{Code: statusCodeError, Message: span.err.Error()}
+		}
+		spans = append(spans, data)
+	}
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource:   resource{Attributes: attributes},
+			ScopeSpans: []scopeSpans{{Scope: scope{Name: serviceName}, Spans: spans}},
+		}},
+	}
+}
+
+func newKeyValue(attribute Attribute) keyValue {
+	return keyValue{Key: attribute.Key, Value: anyValue{StringValue: attribute.Value}}
+}
+
+func send(ctx context.Context, endpoint string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+tracesPath, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Transport: &http.Transport{Proxy: util.ProxyFromEnvironment()}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send trace to %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send trace to %s: %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// appendToFile appends the trace as a line, so that the file holds the trace
+// of every init in the format of the file exporter of the OpenTelemetry
+// collector.
+func appendToFile(filePath string, data []byte) error {
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, traceFilePerm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
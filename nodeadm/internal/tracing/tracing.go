@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// Attribute is a key and value that describes a span or the node, named after
+// the semantic conventions of OpenTelemetry where one applies.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// String returns an Attribute with the key and value.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanKind is the kind of a span in OTLP.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindClient   SpanKind = 3
+)
+
+// Span is a timed operation of a trace. The zero value of a pointer to a Span
+// is not valid, spans are only created with Start.
+type Span struct {
+	tracer     *tracer
+	name       string
+	kind       SpanKind
+	traceID    [16]byte
+	spanID     [8]byte
+	parentID   [8]byte
+	start      time.Time
+	end        time.Time
+	attributes []Attribute
+	err        error
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// End records the end of the span, and marks it as failed if err is not nil.
+// Only ended spans are exported.
+func (s *Span) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.end = time.Now()
+	s.err = err
+	s.tracer.ended = append(s.tracer.ended, s)
+}
+
+// tracer records the spans of a single trace. Every span of a process belongs
+// to the same trace, since each run of nodeadm is one operation on the node.
+type tracer struct {
+	mu       sync.Mutex
+	traceID  [16]byte
+	resource []Attribute
+	ended    []*Span
+}
+
+var defaultTracer = newTracer()
+
+func newTracer() *tracer {
+	t := &tracer{}
+	rand.Read(t.traceID[:])
+	return t
+}
+
+type spanContextKey struct{}
+
+// Start starts a span that is a child of the span of the context, if it has
+// one, and returns a context that carries the new span.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	return defaultTracer.start(ctx, name, SpanKindInternal, attributes...)
+}
+
+func (t *tracer) start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		traceID:    t.traceID,
+		start:      time.Now(),
+		attributes: attributes,
+	}
+	rand.Read(span.spanID[:])
+	if parent, ok := ctx.Value(spanContextKey{}).(*Span); ok {
+		span.parentID = parent.spanID
+	}
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// AddResourceAttributes adds attributes that describe the node to the trace,
+// such as its instance ID, so that traces can be compared across a fleet.
+func AddResourceAttributes(attributes ...Attribute) {
+	defaultTracer.mu.Lock()
+	defer defaultTracer.mu.Unlock()
+	defaultTracer.resource = append(defaultTracer.resource, attributes...)
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestExport(t *testing.T) {
+	defaultTracer = newTracer()
+	AddResourceAttributes(String("host.id", "i-1234567890abcdef0"))
+	ctx, root := Start(context.Background(), "init")
+	_, child := Start(ctx, "configure daemon", String("daemon.name", "kubelet"))
+	child.End(errors.New("kubelet is not installed"))
+	root.End(nil)
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	traceFile := filepath.Join(t.TempDir(), "traces", "init.jsonl")
+
+	opts := api.TracingOptions{Endpoint: server.URL + "/", File: traceFile}
+	assert.NoError(t, Export(context.Background(), &opts))
+	assert.NoError(t, Export(context.Background(), &opts))
+
+	written, err := os.ReadFile(traceFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(written)), "\n")
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, string(received), lines[0])
+
+	var request exportRequest
+	assert.NoError(t, json.Unmarshal(received, &request))
+	assert.Equal(t, []keyValue{
+		{Key: "service.name", Value: anyValue{StringValue: "nodeadm"}},
+		{Key: "host.id", Value: anyValue{StringValue: "i-1234567890abcdef0"}},
+	}, request.ResourceSpans[0].Resource.Attributes)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "configure daemon", spans[0].Name)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	assert.Equal(t, status{Code: statusCodeError, Message: "kubelet is not installed"}, spans[0].Status)
+	assert.Equal(t, "init", spans[1].Name)
+	assert.Empty(t, spans[1].ParentSpanID)
+	assert.Equal(t, status{}, spans[1].Status)
+}
+
+func TestExportFailure(t *testing.T) {
+	defaultTracer = newTracer()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	err := Export(context.Background(), &api.TracingOptions{Endpoint: server.URL})
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+}
@@ -0,0 +1,379 @@
+// Package download fetches artifacts of the NodeConfig, such as manifests,
+// scripts and lists of images, from S3 or over HTTPS.
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	defaultMaxWait        = 5 * time.Minute
+	responseHeaderTimeout = 30 * time.Second
+	// partialSuffix is appended to the path of a file while it is downloaded,
+	// so that a download that is interrupted is resumed from where it stopped
+	partialSuffix = ".partial"
+	// etagSuffix is appended to the path of the partial file for the ETag of
+	// the artifact it holds, so that it is only resumed from the same artifact
+	etagSuffix = ".etag"
+)
+
+// the delays between attempts are replaced in tests, which do not wait
+var (
+	minRetryDelay = time.Second
+	maxRetryDelay = 20 * time.Second
+)
+
+// retryables classifies errors the way the AWS SDK does for the API calls of
+// the waiters, and also retries throttling and the responses that were cut off.
+var retryables = retry.IsErrorRetryables(append([]retry.IsErrorRetryable{
+	retry.RetryableHTTPStatusCode{
+		Codes: map[int]struct{}{
+			http.StatusRequestTimeout:  {},
+			http.StatusTooManyRequests: {},
+		},
+	},
+	retry.IsErrorRetryableFunc(func(err error) aws.Ternary {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return aws.TrueTernary
+		}
+		return aws.UnknownTernary
+	}),
+}, retry.DefaultRetryables...))
+
+type options struct {
+	region         string
+	rootCAs        *x509.CertPool
+	sha256         string
+	bandwidthLimit int64
+	maxWait        time.Duration
+}
+
+type Option func(*options)
+
+// WithRegion is the region of the S3 client. The region of the instance is
+// used when it is not set.
+func WithRegion(region string) Option {
+	return func(o *options) { o.region = region }
+}
+
+// WithRootCAs are the certificate authorities that are trusted for HTTPS, in
+// place of those of the system.
+func WithRootCAs(rootCAs *x509.CertPool) Option {
+	return func(o *options) { o.rootCAs = rootCAs }
+}
+
+// WithSHA256 is the hex-encoded checksum that the artifact must match.
+func WithSHA256(checksum string) Option {
+	return func(o *options) { o.sha256 = checksum }
+}
+
+// WithBandwidthLimit limits the rate of the download, in bytes per second.
+func WithBandwidthLimit(bytesPerSecond int64) Option {
+	return func(o *options) { o.bandwidthLimit = bytesPerSecond }
+}
+
+// WithMaxWait bounds how long the download takes, including its retries.
+// Defaults to 5m.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(o *options) { o.maxWait = maxWait }
+}
+
+// Fetch downloads an artifact from an `s3://` or `https://` URL into memory.
+func Fetch(ctx context.Context, rawURL string, opts ...Option) ([]byte, error) {
+	var buf memorySink
+	if err := download(ctx, rawURL, &buf, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToFile downloads an artifact from an `s3://` or `https://` URL to a file.
+// The artifact is written next to the file until it is complete, so the file
+// is never partially written, and a download that was interrupted by a
+// previous call is resumed if the artifact still has the same ETag.
+func ToFile(ctx context.Context, rawURL string, path string, perm os.FileMode, opts ...Option) error {
+	partialPath := path + partialSuffix
+	etagPath := partialPath + etagSuffix
+	file, err := os.OpenFile(partialPath, os.O_RDWR|os.O_CREATE, perm)
+	if err != nil {
+		return err
+	}
+	sink, err := newFileSink(file, etagPath)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = download(ctx, rawURL, sink, opts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		var checksumErr *ChecksumError
+		if errors.As(err, &checksumErr) {
+			// the partial file is corrupt, so it is not resumed
+			os.Remove(partialPath)
+			os.Remove(etagPath)
+		}
+		return err
+	}
+	if err := os.Rename(partialPath, path); err != nil {
+		return err
+	}
+	if err := os.Remove(etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ChecksumError is returned when the artifact does not match its checksum.
+type ChecksumError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum is %s, expected %s", e.Actual, e.Expected)
+}
+
+// statusError is an unexpected response of an HTTPS server, which the
+// retryables classify by its status code.
+type statusError struct {
+	status string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected response: %s", e.status)
+}
+
+func (e *statusError) HTTPStatusCode() int {
+	return e.code
+}
+
+// IsRetryable returns whether a failed download is retried.
+func IsRetryable(err error) bool {
+	return retryables.IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// sink is where the artifact is written, which may already hold the start
+// of the artifact from an earlier attempt, along with the ETag of the
+// artifact it was downloaded from.
+type sink interface {
+	io.Writer
+	Size() (int64, error)
+	Reset() error
+	Contents() (io.Reader, error)
+	ETag() string
+	SetETag(etag string) error
+}
+
+func download(ctx context.Context, rawURL string, dst sink, opts []Option) error {
+	o := options{maxWait: defaultMaxWait}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	var open opener
+	switch u.Scheme {
+	case "s3":
+		open, err = newS3Opener(ctx, u, o.region)
+	case "https":
+		open, err = newHTTPSOpener(u, o.rootCAs)
+	default:
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return err
+	}
+	log := zap.L().With(zap.String("url", rawURL))
+	var lastErr error
+	err = util.Wait(ctx, func(ctx context.Context) (bool, error) {
+		lastErr = attempt(ctx, open, dst, o.bandwidthLimit)
+		if lastErr == nil {
+			return true, nil
+		}
+		if !IsRetryable(lastErr) {
+			return false, lastErr
+		}
+		log.Warn("Failed to download, retrying..", zap.Error(lastErr))
+		return false, nil
+	}, minRetryDelay, maxRetryDelay, o.maxWait)
+	if errors.Is(err, util.ErrWaiterTimeout) && lastErr != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, lastErr)
+	} else if err != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	if o.sha256 != "" {
+		return verifyChecksum(dst, o.sha256)
+	}
+	return nil
+}
+
+// attempt downloads the rest of the artifact, resuming from what the sink
+// already holds when it was downloaded from an artifact with the same ETag.
+func attempt(ctx context.Context, open opener, dst sink, bandwidthLimit int64) error {
+	size, err := dst.Size()
+	if err != nil {
+		return err
+	}
+	offset, etag := size, dst.ETag()
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		// without a strong ETag, what the sink holds may be part of another
+		// version of the artifact, so it is downloaded again from the start
+		offset = 0
+	}
+	artifact, err := open(ctx, offset, etag)
+	if err != nil {
+		return err
+	}
+	defer artifact.body.Close()
+	if !artifact.resumed {
+		// the source does not support ranges, or the artifact changed
+		if size > 0 {
+			if err := dst.Reset(); err != nil {
+				return err
+			}
+		}
+		if err := dst.SetETag(artifact.etag); err != nil {
+			return err
+		}
+	}
+	var src io.Reader = artifact.body
+	if bandwidthLimit > 0 {
+		src = newLimitedReader(ctx, artifact.body, bandwidthLimit)
+	}
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func verifyChecksum(dst sink, expected string) error {
+	contents, err := dst.Contents()
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, contents); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return &ChecksumError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// openedArtifact is the body of an artifact that was opened from an offset.
+type openedArtifact struct {
+	body io.ReadCloser
+	// resumed is whether the body starts at the offset rather than at the
+	// start of the artifact
+	resumed bool
+	etag    string
+}
+
+// opener opens the artifact from an offset, which is only resumed if the
+// artifact still has the ETag of what was downloaded before.
+type opener func(ctx context.Context, offset int64, etag string) (*openedArtifact, error)
+
+func newS3Opener(ctx context.Context, u *url.URL, region string) (opener, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(region))
+	}
+	awsConfig, err := awsconfig.Load(ctx, loadOpts...)
+	if err != nil {
+		return nil, err
+	}
+	// the requests are signed with SigV4 by the client
+	client := s3.NewFromConfig(awsConfig)
+	bucket, key := u.Host, strings.TrimPrefix(u.Path, "/")
+	return func(ctx context.Context, offset int64, etag string) (*openedArtifact, error) {
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+		if offset > 0 {
+			input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+			input.IfMatch = aws.String(etag)
+		}
+		out, err := client.GetObject(ctx, input)
+		var apiErr smithy.APIError
+		if offset > 0 && errors.As(err, &apiErr) && (apiErr.ErrorCode() == "InvalidRange" || apiErr.ErrorCode() == "PreconditionFailed") {
+			// the object changed, or is shorter than what was downloaded, so
+			// it is downloaded again from the start
+			out, err = client.GetObject(ctx, &s3.GetObjectInput{Bucket: input.Bucket, Key: input.Key})
+			offset = 0
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &openedArtifact{body: out.Body, resumed: offset > 0, etag: aws.ToString(out.ETag)}, nil
+	}, nil
+}
+
+func newHTTPSOpener(u *url.URL, rootCAs *x509.CertPool) (opener, error) {
+	transport := &http.Transport{
+		Proxy:                 util.ProxyFromEnvironment(),
+		ResponseHeaderTimeout: responseHeaderTimeout,
+	}
+	if rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+	client := &http.Client{Transport: transport}
+	rawURL := u.String()
+	var open opener
+	open = func(ctx context.Context, offset int64, etag string) (*openedArtifact, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if offset > 0 {
+			// the server sends the whole artifact instead of the range when
+			// its ETag changed
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", etag)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return &openedArtifact{body: resp.Body, etag: resp.Header.Get("ETag")}, nil
+		case resp.StatusCode == http.StatusPartialContent && offset > 0:
+			return &openedArtifact{body: resp.Body, resumed: true, etag: etag}, nil
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+			// the artifact is shorter than what was downloaded, so it is
+			// downloaded again from the start
+			return open(ctx, 0, "")
+		}
+		return nil, &statusError{status: resp.Status, code: resp.StatusCode}
+	}
+	return open, nil
+}
@@ -0,0 +1,181 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const artifact = "#!/bin/sh\necho hello from a downloaded script\n"
+
+func artifactChecksum() string {
+	sum := sha256.Sum256([]byte(artifact))
+	return hex.EncodeToString(sum[:])
+}
+
+func withoutRetryDelay(t *testing.T) {
+	originalMin, originalMax := minRetryDelay, maxRetryDelay
+	minRetryDelay, maxRetryDelay = time.Millisecond, time.Millisecond
+	t.Cleanup(func() { minRetryDelay, maxRetryDelay = originalMin, originalMax })
+}
+
+// newServer serves the artifact over HTTPS, after failing the first requests
+// with the given status codes.
+func newServer(t *testing.T, failures ...int) (*httptest.Server, *int) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(failures) {
+			w.WriteHeader(failures[requests-1])
+			return
+		}
+		http.ServeContent(w, r, "artifact", time.Time{}, strings.NewReader(artifact))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// trustServer trusts the self-signed certificate of the server.
+func trustServer(server *httptest.Server) Option {
+	return WithRootCAs(server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs)
+}
+
+func TestFetch(t *testing.T) {
+	withoutRetryDelay(t)
+	var tests = []struct {
+		name             string
+		failures         []int
+		expectedRequests int
+		expectErr        bool
+	}{
+		{name: "success", expectedRequests: 1},
+		{name: "retried", failures: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, expectedRequests: 3},
+		{name: "not found", failures: []int{http.StatusNotFound}, expectedRequests: 1, expectErr: true},
+		{name: "forbidden", failures: []int{http.StatusForbidden}, expectedRequests: 1, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newServer(t, test.failures...)
+			data, err := Fetch(context.Background(), server.URL+"/script.sh", trustServer(server))
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, artifact, string(data))
+			}
+			assert.Equal(t, test.expectedRequests, *requests)
+		})
+	}
+}
+
+func TestFetchChecksum(t *testing.T) {
+	server, _ := newServer(t)
+	rootCAs := trustServer(server)
+
+	data, err := Fetch(context.Background(), server.URL, rootCAs, WithSHA256(strings.ToUpper(artifactChecksum())))
+	assert.NoError(t, err)
+	assert.Equal(t, artifact, string(data))
+
+	_, err = Fetch(context.Background(), server.URL, rootCAs, WithSHA256(strings.Repeat("0", 64)))
+	var checksumErr *ChecksumError
+	assert.True(t, errors.As(err, &checksumErr))
+	assert.Equal(t, artifactChecksum(), checksumErr.Actual)
+}
+
+func TestFetchUnsupportedScheme(t *testing.T) {
+	_, err := Fetch(context.Background(), "http://example.com/script.sh")
+	assert.ErrorContains(t, err, "unsupported scheme")
+}
+
+func TestToFileResumes(t *testing.T) {
+	var tests = []struct {
+		name         string
+		etag         string
+		expectRanges []string
+	}{
+		{name: "same artifact", etag: `"v1"`, expectRanges: []string{"bytes=10-"}},
+		// the server ignores the range of an If-Range that does not match
+		{name: "changed artifact", etag: `"v0"`, expectRanges: []string{"bytes=10-"}},
+		{name: "unknown artifact", expectRanges: []string{""}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _ := newServer(t)
+			path := filepath.Join(t.TempDir(), "script.sh")
+			// the start of the artifact, from a download that was interrupted
+			partial := artifact[:10]
+			if test.etag != `"v1"` {
+				partial = "stale data"
+			}
+			assert.NoError(t, os.WriteFile(path+partialSuffix, []byte(partial), 0600))
+			if test.etag != "" {
+				assert.NoError(t, os.WriteFile(path+partialSuffix+etagSuffix, []byte(test.etag), 0600))
+			}
+			var ranges, ifRanges []string
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				ifRanges = append(ifRanges, r.Header.Get("If-Range"))
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "artifact", time.Time{}, strings.NewReader(artifact))
+			})
+
+			err := ToFile(context.Background(), server.URL, path, 0700, trustServer(server), WithSHA256(artifactChecksum()))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectRanges, ranges)
+			if test.etag != "" {
+				assert.Equal(t, []string{test.etag}, ifRanges)
+			}
+			data, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, artifact, string(data))
+			assert.NoFileExists(t, path+partialSuffix)
+			assert.NoFileExists(t, path+partialSuffix+etagSuffix)
+		})
+	}
+}
+
+func TestToFileRestartsWithoutRanges(t *testing.T) {
+	server, _ := newServer(t)
+	path := filepath.Join(t.TempDir(), "script.sh")
+	assert.NoError(t, os.WriteFile(path+partialSuffix, []byte("stale contents"), 0600))
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ranges are not supported, so the artifact is served in full
+		fmt.Fprint(w, artifact)
+	})
+
+	err := ToFile(context.Background(), server.URL, path, 0700, trustServer(server))
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, artifact, string(data))
+}
+
+func TestBandwidthLimit(t *testing.T) {
+	server, _ := newServer(t)
+	start := time.Now()
+	// the first second's worth is allowed at once
+	data, err := Fetch(context.Background(), server.URL, trustServer(server), WithBandwidthLimit(int64(len(artifact)/2)))
+	assert.NoError(t, err)
+	assert.Equal(t, artifact, string(data))
+	assert.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&statusError{code: http.StatusBadGateway}))
+	assert.True(t, IsRetryable(&statusError{code: http.StatusTooManyRequests}))
+	assert.False(t, IsRetryable(&statusError{code: http.StatusNotFound}))
+	assert.False(t, IsRetryable(&ChecksumError{}))
+	assert.False(t, IsRetryable(context.Canceled))
+}
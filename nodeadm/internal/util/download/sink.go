@@ -0,0 +1,124 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/time/rate"
+)
+
+// memorySink holds the artifact in memory.
+type memorySink struct {
+	bytes.Buffer
+	etag string
+}
+
+func (s *memorySink) Size() (int64, error) {
+	return int64(s.Len()), nil
+}
+
+func (s *memorySink) Reset() error {
+	s.Buffer.Reset()
+	return nil
+}
+
+func (s *memorySink) Contents() (io.Reader, error) {
+	return bytes.NewReader(s.Bytes()), nil
+}
+
+func (s *memorySink) ETag() string {
+	return s.etag
+}
+
+func (s *memorySink) SetETag(etag string) error {
+	s.etag = etag
+	return nil
+}
+
+// fileSink writes the artifact to the end of a file, and its ETag to another
+// file, so that both outlive the process.
+type fileSink struct {
+	file     *os.File
+	etagPath string
+	etag     string
+}
+
+func newFileSink(file *os.File, etagPath string) (*fileSink, error) {
+	etag, err := os.ReadFile(etagPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return &fileSink{file: file, etagPath: etagPath, etag: string(etag)}, nil
+}
+
+func (s *fileSink) Write(p []byte) (int, error) {
+	return s.file.Write(p)
+}
+
+func (s *fileSink) Size() (int64, error) {
+	// the next write continues from the end of the file
+	return s.file.Seek(0, io.SeekEnd)
+}
+
+func (s *fileSink) Reset() error {
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := s.file.Seek(0, io.SeekStart)
+	return err
+}
+
+func (s *fileSink) Contents() (io.Reader, error) {
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return s.file, nil
+}
+
+func (s *fileSink) ETag() string {
+	return s.etag
+}
+
+func (s *fileSink) SetETag(etag string) error {
+	s.etag = etag
+	if etag == "" {
+		if err := os.Remove(s.etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(s.etagPath, []byte(etag), 0600)
+}
+
+// limitedReader reads no faster than its limit, in bytes per second.
+type limitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func newLimitedReader(ctx context.Context, reader io.Reader, bytesPerSecond int64) io.Reader {
+	// reads are no larger than the burst, which is at most a second's worth
+	burst := int(min(bytesPerSecond, 32*1024))
+	return &limitedReader{
+		ctx:     ctx,
+		reader:  reader,
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+	}
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
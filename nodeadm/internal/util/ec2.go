@@ -25,8 +25,8 @@ func (c *EC2Client) DescribeInstanceTypes(ctx context.Context, params *ec2.Descr
 	return c.Client.DescribeInstanceTypes(ctx, params, optFns...)
 }
 
-func GetEniInfoForInstanceType(ec2API EC2API, instanceType string) (EniInfo, error) {
-	describeResp, err := ec2API.DescribeInstanceTypes(context.Background(), &ec2.DescribeInstanceTypesInput{
+func GetEniInfoForInstanceType(ctx context.Context, ec2API EC2API, instanceType string) (EniInfo, error) {
+	describeResp, err := ec2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
 		InstanceTypes: []types.InstanceType{types.InstanceType(instanceType)},
 	})
 
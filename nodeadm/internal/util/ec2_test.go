@@ -75,7 +75,7 @@ func TestGetEniInfoForInstanceType(t *testing.T) {
 		mockEC2 := &MockEC2Client{}
 		mockEC2.On("DescribeInstanceTypes", mock.Anything, mock.AnythingOfType("*ec2.DescribeInstanceTypesInput")).Return(&test.mockResponse, test.mockError)
 
-		result, err := ec2util.GetEniInfoForInstanceType(mockEC2, test.instanceType)
+		result, err := ec2util.GetEniInfoForInstanceType(context.Background(), mockEC2, test.instanceType)
 		assert.Equal(t, test.expectedError, err)
 		assert.Equal(t, test.expectedResult, result)
 	}
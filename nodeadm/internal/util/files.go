@@ -5,17 +5,124 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"sort"
+	"sync"
 )
 
+var (
+	writtenFilesLock sync.Mutex
+	writtenFiles     = make(map[string]FileSnapshot)
+)
+
+// FileSnapshot is the state of a file before it was first written by this
+// process.
+type FileSnapshot struct {
+	Path    string      `json:"path"`
+	Existed bool        `json:"existed"`
+	Mode    fs.FileMode `json:"mode,omitempty"`
+	Content []byte      `json:"content,omitempty"`
+}
+
 // Wraps os.WriteFile to automatically create parent directories such that the
 // caller does not need to ensure the existence of the file's directory
 func WriteFileWithDir(filePath string, data []byte, perm fs.FileMode) error {
+	writtenFilesLock.Lock()
+	defer writtenFilesLock.Unlock()
+	if _, ok := writtenFiles[filePath]; !ok {
+		snapshot, err := takeFileSnapshot(filePath)
+		if err != nil {
+			return err
+		}
+		writtenFiles[filePath] = snapshot
+	}
 	if err := os.MkdirAll(path.Dir(filePath), perm); err != nil {
 		return err
 	}
 	return os.WriteFile(filePath, data, perm)
 }
 
+// RemoveFile removes a file that was written by an earlier run, recording its
+// state like WriteFileWithDir, so that it is restored along with the files that
+// were written and dropped from the manifest of managed files.
+func RemoveFile(filePath string) error {
+	writtenFilesLock.Lock()
+	defer writtenFilesLock.Unlock()
+	if _, ok := writtenFiles[filePath]; !ok {
+		snapshot, err := takeFileSnapshot(filePath)
+		if err != nil {
+			return err
+		}
+		writtenFiles[filePath] = snapshot
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func takeFileSnapshot(filePath string) (FileSnapshot, error) {
+	snapshot := FileSnapshot{Path: filePath}
+	info, err := os.Stat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot, nil
+	} else if err != nil {
+		return snapshot, err
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.Existed = true
+	snapshot.Mode = info.Mode().Perm()
+	snapshot.Content = content
+	return snapshot, nil
+}
+
+// FileSnapshots returns the state of each of the given paths before they were
+// first written with WriteFileWithDir or removed with RemoveFile by this
+// process. Paths that have not
+// been written are omitted.
+func FileSnapshots(paths []string) []FileSnapshot {
+	writtenFilesLock.Lock()
+	defer writtenFilesLock.Unlock()
+	var snapshots []FileSnapshot
+	for _, filePath := range paths {
+		if snapshot, ok := writtenFiles[filePath]; ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+// Restore returns the file to the state of the snapshot, removing it if it
+// did not exist.
+func (s *FileSnapshot) Restore() error {
+	if !s.Existed {
+		if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.WriteFile(s.Path, s.Content, s.Mode); err != nil {
+		return err
+	}
+	// WriteFile does not change the mode of an existing file
+	return os.Chmod(s.Path, s.Mode)
+}
+
+// WrittenFiles returns the sorted paths of every file written with
+// WriteFileWithDir or removed with RemoveFile by this process.
+func WrittenFiles() []string {
+	writtenFilesLock.Lock()
+	defer writtenFilesLock.Unlock()
+	var paths []string
+	for filePath := range writtenFiles {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // IsFilePathExists checks whether specific file path exists
 func IsFilePathExists(filePath string) (bool, error) {
 	_, err := os.Stat(filePath)
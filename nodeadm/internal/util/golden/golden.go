@@ -0,0 +1,136 @@
+// Package golden compares the artifacts that nodeadm renders with golden files
+// that are checked in under the testdata directory of the tests, so that a
+// change to the generated config of a daemon is reviewed as a diff of its
+// golden files. The golden files are rewritten by running the tests with
+// `-update`:
+//
+//	go test ./internal/kubelet ./internal/containerd -run TestGolden -update
+package golden
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the artifacts that the tests render")
+
+// Case is a NodeConfig that is rendered by the golden tests, as it is once
+// nodeadm has enriched it.
+type Case struct {
+	Name       string
+	NodeConfig *api.NodeConfig
+	// CPUMillicores is the capacity of the instance type, which the tests
+	// use in place of the CPUs of the host.
+	CPUMillicores int
+}
+
+// Cases returns the matrix of NodeConfigs that the golden tests render, which
+// covers the feature gates, instance types and IP families that change the
+// generated configs.
+func Cases() []Case {
+	return []Case{
+		{Name: "default", NodeConfig: newNodeConfig("m5.large", "10.100.0.0/16", "192.168.10.20"), CPUMillicores: 2000},
+		{Name: "ipv6", NodeConfig: newNodeConfig("m5.large", "fd30:1c53:5f8a::/108", "2001:db8::20"), CPUMillicores: 2000},
+		{
+			Name:          "dual-stack",
+			NodeConfig:    newNodeConfig("m5.large", "10.100.0.0/16,fd30:1c53:5f8a::/108", "192.168.10.20", "2001:db8::20"),
+			CPUMillicores: 2000,
+		},
+		{Name: "large-instance", NodeConfig: newNodeConfig("m5.24xlarge", "10.100.0.0/16", "192.168.10.20"), CPUMillicores: 96000},
+		{Name: "kubelet-1.28", NodeConfig: withKubeletVersion(newNodeConfig("m5.large", "10.100.0.0/16", "192.168.10.20"), "v1.28.15"), CPUMillicores: 2000},
+		{
+			Name:          "feature-gates",
+			NodeConfig:    withFeatureGates(newNodeConfig("m5.large", "10.100.0.0/16", "192.168.10.20"), api.InstanceIdNodeName, api.FastContainerImagePull),
+			CPUMillicores: 2000,
+		},
+		{
+			Name: "user-config",
+			NodeConfig: withUserConfig(newNodeConfig("m5.large", "10.100.0.0/16", "192.168.10.20"), api.InlineDocument{
+				"maxPods":                     runtime.RawExtension{Raw: []byte("58")},
+				"imageGCHighThresholdPercent": runtime.RawExtension{Raw: []byte("75")},
+			}, "[plugins.\"io.containerd.grpc.v1.cri\"]\nmax_concurrent_downloads = 5\n"),
+			CPUMillicores: 2000,
+		},
+	}
+}
+
+func newNodeConfig(instanceType string, cidr string, nodeIPs ...string) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Name:                 "my-cluster",
+				APIServerEndpoint:    "https://example.com",
+				CertificateAuthority: []byte("certificateAuthority"),
+				CIDR:                 cidr,
+			},
+			Kubelet: api.KubeletOptions{
+				NodeIP: api.NodeIPOptions{Policy: api.NodeIPPolicyExplicit, Addresses: nodeIPs},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Instance: api.InstanceDetails{
+				ID:               "i-1234567890abcdef0",
+				AccountID:        "123456789012",
+				Region:           "us-west-2",
+				Type:             instanceType,
+				AvailabilityZone: "us-west-2a",
+				MAC:              "0e:00:00:00:00:01",
+				PrivateDNSName:   "ip-192-168-10-20.us-west-2.compute.internal",
+			},
+			Defaults: api.DefaultOptions{
+				SandboxImage: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5",
+			},
+			KubeletVersion: "v1.31.4",
+		},
+	}
+}
+
+func withKubeletVersion(cfg *api.NodeConfig, version string) *api.NodeConfig {
+	cfg.Status.KubeletVersion = version
+	return cfg
+}
+
+func withFeatureGates(cfg *api.NodeConfig, features ...api.Feature) *api.NodeConfig {
+	cfg.Spec.FeatureGates = map[api.Feature]bool{}
+	for _, feature := range features {
+		cfg.Spec.FeatureGates[feature] = true
+	}
+	return cfg
+}
+
+func withUserConfig(cfg *api.NodeConfig, kubeletConfig api.InlineDocument, containerdConfig api.ContainerdConfig) *api.NodeConfig {
+	cfg.Spec.Kubelet.Config = kubeletConfig
+	cfg.Spec.Containerd.Config = containerdConfig
+	return cfg
+}
+
+// Assert compares an artifact with its golden file under testdata, or
+// rewrites the golden file when the tests are run with -update.
+func Assert(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run the test with -update to create it", path)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(actual), "artifact differs from golden file %s, run the test with -update to accept the change", path)
+}
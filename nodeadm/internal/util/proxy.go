@@ -0,0 +1,19 @@
+package util
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyFromEnvironment returns a proxy function for an http.Transport that
+// uses the proxy environment variables at the time it is called. Unlike
+// http.ProxyFromEnvironment, the environment is not cached on first use, so
+// variables set by nodeadm after it has made requests are respected.
+func ProxyFromEnvironment() func(*http.Request) (*url.URL, error) {
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"math/rand/v2"
 	"time"
 )
 
@@ -71,3 +72,21 @@ func WithBackoffExponential() fnOpt {
 		r.BackoffFn = func(r *Retrier) time.Duration { return r.LastWait * 2 }
 	}
 }
+
+// WithBackoffExponentialJitter waits for a random duration of up to an
+// exponentially increasing ceiling, so that many nodes that fail at the same
+// time do not retry in lockstep.
+func WithBackoffExponentialJitter(initial time.Duration, ceiling time.Duration) fnOpt {
+	jitter := func(iter int) time.Duration {
+		limit := ceiling
+		if iter < 32 && initial<<iter < ceiling {
+			limit = initial << iter
+		}
+		// #nosec G404 jitter does not need to be cryptographically secure
+		return rand.N(limit)
+	}
+	return func(r *Retrier) {
+		r.LastWait = jitter(0)
+		r.BackoffFn = func(r *Retrier) time.Duration { return jitter(r.LastIter + 1) }
+	}
+}
@@ -0,0 +1,57 @@
+// Package signature verifies the detached signatures of artifacts and configs
+// with the public keys that nodeadm is configured with.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when data is not signed by the public key
+// that it is verified with.
+var ErrInvalidSignature = errors.New("signature of artifact is not valid")
+
+// ParsePublicKey parses a PEM-encoded PKIX public key.
+func ParsePublicKey(publicKeyPEM string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("public key is not PEM-encoded")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// Verify verifies the base64-encoded signature of the data, in the
+// format written by `cosign sign-blob` and `aws kms sign`: an ASN.1 ECDSA or a
+// PKCS #1 v1.5 RSA signature of the SHA-256 digest, or an Ed25519 signature of
+// the data.
+func Verify(publicKey crypto.PublicKey, data []byte, encodedSignature []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil {
+		return fmt.Errorf("signature is not base64-encoded: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	var valid bool
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, data, signature)
+	default:
+		return fmt.Errorf("unsupported type of public key %T", publicKey)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
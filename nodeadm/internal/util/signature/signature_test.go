@@ -0,0 +1,49 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSigner(t *testing.T) (string, func([]byte) []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	return publicKey, func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		assert.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := []byte("artifact")
+	publicKey, sign := newSigner(t)
+	key, err := ParsePublicKey(publicKey)
+	assert.NoError(t, err)
+	assert.NoError(t, Verify(key, data, sign(data)))
+	assert.ErrorIs(t, Verify(key, []byte("another artifact"), sign(data)), ErrInvalidSignature)
+	assert.Error(t, Verify(key, data, []byte("not base64!")))
+
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, data))
+	assert.NoError(t, Verify(edPublicKey, data, []byte(edSignature)))
+	assert.ErrorIs(t, Verify(edPublicKey, []byte("another artifact"), []byte(edSignature)), ErrInvalidSignature)
+
+	var unsupported crypto.PublicKey = "key"
+	assert.ErrorContains(t, Verify(unsupported, data, sign(data)), "unsupported type")
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+	smithywaiter "github.com/aws/smithy-go/waiter"
+)
+
+// ErrWaiterTimeout is returned by Wait when the condition was not met within
+// the maximum wait duration.
+var ErrWaiterTimeout = errors.New("exceeded max wait time")
+
+// ErrThrottled is wrapped by the error of a WaiterCondition whose poll was
+// throttled. The wait goes on, and the next poll is delayed by the maximum
+// delay rather than the exponential backoff.
+var ErrThrottled = errors.New("throttled")
+
+// WaiterCondition polls a resource and returns whether it reached the state
+// being waited for. An error stops the wait, unless it wraps ErrThrottled.
+type WaiterCondition func(ctx context.Context) (bool, error)
+
+// Wait polls the condition until it is met, using the same exponential backoff
+// between MinDelay and MaxDelay as the waiters of the AWS SDK. The maxWaitDur
+// is required and must be greater than zero.
+func Wait(ctx context.Context, condition WaiterCondition, minDelay time.Duration, maxDelay time.Duration, maxWaitDur time.Duration) error {
+	if maxWaitDur <= 0 {
+		return fmt.Errorf("maximum wait time for waiter must be greater than zero")
+	}
+	if minDelay > maxDelay {
+		return fmt.Errorf("minimum waiter delay %v must be lesser than or equal to maximum waiter delay of %v.", minDelay, maxDelay)
+	}
+
+	waitCtx, cancelFn := context.WithTimeout(ctx, maxWaitDur)
+	defer cancelFn()
+
+	remainingTime := maxWaitDur
+
+	var attempt int64
+	for {
+		attempt++
+		start := time.Now()
+
+		conditionMet, err := condition(waitCtx)
+		throttled := errors.Is(err, ErrThrottled)
+		if err != nil && !throttled {
+			return err
+		}
+		if conditionMet {
+			return nil
+		}
+
+		remainingTime -= time.Since(start)
+		if remainingTime < minDelay || remainingTime <= 0 {
+			break
+		}
+
+		// compute exponential backoff between waiter retries
+		delay, err := smithywaiter.ComputeDelay(
+			attempt, minDelay, maxDelay, remainingTime,
+		)
+		if err != nil {
+			return fmt.Errorf("error computing waiter delay, %w", err)
+		}
+		if throttled {
+			delay = min(maxDelay, remainingTime)
+		}
+
+		remainingTime -= delay
+		// sleep for the delay amount before invoking a request
+		if err := smithytime.SleepWithContext(waitCtx, delay); err != nil {
+			// the delay may overrun the maximum wait time, which is a timeout
+			// rather than a cancellation
+			if ctx.Err() == nil {
+				break
+			}
+			return fmt.Errorf("request cancelled while waiting, %w", err)
+		}
+	}
+	return ErrWaiterTimeout
+}
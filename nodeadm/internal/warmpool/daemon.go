@@ -0,0 +1,43 @@
+package warmpool
+
+import (
+	"context"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const WarmPoolDaemonName = "warm-pool"
+
+var (
+	_ daemon.Daemon   = &warmPool{}
+	_ daemon.Unitless = &warmPool{}
+)
+
+// warmPool holds the bootstrap of an instance in a warm pool once the node is
+// prepared. It has no unit of its own, and is only a daemon so that its
+// post-launch task is ordered after the images are pre-pulled and before
+// kubelet is started.
+type warmPool struct{}
+
+func NewWarmPoolDaemon() daemon.Daemon {
+	return &warmPool{}
+}
+
+func (w *warmPool) Configure(_ context.Context, _ *api.NodeConfig) error {
+	return nil
+}
+
+func (w *warmPool) EnsureRunning() error {
+	return nil
+}
+
+func (w *warmPool) PostLaunch(ctx context.Context, cfg *api.NodeConfig) error {
+	return WaitForService(ctx, cfg)
+}
+
+func (w *warmPool) Name() string {
+	return WarmPoolDaemonName
+}
+
+func (w *warmPool) Unitless() {}
@@ -0,0 +1,83 @@
+package warmpool
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+)
+
+const (
+	// warmedStatePrefix is the prefix of the lifecycle states of instances in
+	// a warm pool, such as `Warmed:Stopped` and `Warmed:Hibernated`.
+	warmedStatePrefix = "Warmed:"
+	inServiceState    = "InService"
+	pollInterval      = 5 * time.Second
+	// pollTimeout bounds each read of the lifecycle state, so that a read
+	// that hangs is retried on the next poll
+	pollTimeout = 5 * time.Second
+)
+
+// lifecycleStateFunc returns the target lifecycle state of the instance.
+type lifecycleStateFunc func(ctx context.Context) (string, error)
+
+// WaitForService blocks while the instance is in a warm pool of its Auto
+// Scaling group, so that the node is prepared but does not register with the
+// cluster until the instance is taken out of the pool.
+//
+// An instance in a `Warmed:Stopped` pool is stopped while it waits, and is
+// bootstrapped again when it is started. An instance in a `Warmed:Running` or
+// `Warmed:Hibernated` pool carries on from here when it enters service. The
+// wait ends with an error when the context is done.
+func WaitForService(ctx context.Context, cfg *api.NodeConfig) error {
+	if !cfg.HasInstanceMetadata() {
+		return nil
+	}
+	return waitForService(ctx, withPollTimeout(imds.GetTargetLifecycleState), pollInterval)
+}
+
+// withPollTimeout bounds each read of the lifecycle state with a deadline
+// from the context of the wait.
+func withPollTimeout(getState lifecycleStateFunc) lifecycleStateFunc {
+	return func(ctx context.Context) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+		defer cancel()
+		return getState(ctx)
+	}
+}
+
+func waitForService(ctx context.Context, getState lifecycleStateFunc, interval time.Duration) error {
+	state, err := getState(ctx)
+	if err != nil {
+		// the instance is bootstrapped as if it were not in a warm pool,
+		// rather than failing on a check it may not need.
+		zap.L().Warn("Failed to get the target lifecycle state of the instance", zap.Error(err))
+		return nil
+	}
+	if !strings.HasPrefix(state, warmedStatePrefix) {
+		return nil
+	}
+	zap.L().Info("Instance is in a warm pool, waiting for it to enter service..", zap.String("state", state))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		state, err = getState(ctx)
+		if err != nil {
+			zap.L().Warn("Failed to get the target lifecycle state of the instance", zap.Error(err))
+			continue
+		}
+		if state == inServiceState {
+			zap.L().Info("Instance is entering service")
+			return nil
+		}
+	}
+}
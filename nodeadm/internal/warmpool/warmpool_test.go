@@ -0,0 +1,64 @@
+package warmpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lifecycleStates returns the states in order, repeating the last one.
+func lifecycleStates(states ...string) (lifecycleStateFunc, *int) {
+	var calls int
+	return func(_ context.Context) (string, error) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		if state == "error" {
+			return "", fmt.Errorf("imds unavailable")
+		}
+		return state, nil
+	}, &calls
+}
+
+func TestWaitForService(t *testing.T) {
+	var tests = []struct {
+		name          string
+		states        []string
+		expectedCalls int
+	}{
+		{name: "not in an auto scaling group", states: []string{""}, expectedCalls: 1},
+		{name: "in service", states: []string{"InService"}, expectedCalls: 1},
+		{name: "lifecycle state unavailable", states: []string{"error"}, expectedCalls: 1},
+		{name: "warmed running", states: []string{"Warmed:Running", "Warmed:Running", "InService"}, expectedCalls: 3},
+		{name: "warmed hibernated", states: []string{"Warmed:Hibernated", "error", "InService"}, expectedCalls: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getState, calls := lifecycleStates(test.states...)
+			assert.NoError(t, waitForService(context.Background(), getState, time.Millisecond))
+			assert.Equal(t, test.expectedCalls, *calls)
+		})
+	}
+}
+
+func TestWaitForServiceCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	getState, _ := lifecycleStates("Warmed:Stopped")
+	assert.ErrorIs(t, waitForService(ctx, getState, time.Millisecond), context.DeadlineExceeded)
+}
+
+func TestWithPollTimeout(t *testing.T) {
+	getState := withPollTimeout(func(ctx context.Context) (string, error) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(pollTimeout), deadline, time.Second)
+		return inServiceState, nil
+	})
+	state, err := getState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, inServiceState, state)
+}
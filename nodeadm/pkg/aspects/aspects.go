@@ -0,0 +1,110 @@
+// Package aspects registers the system aspects of derivative builds of
+// nodeadm, such as the installation of an agent of an organization, which are
+// set up by `nodeadm init` along with the aspects of nodeadm. Like those, a
+// registered aspect is traced, logged and recorded in the result of init, and
+// it is skipped by a later init during the same boot once it has converged
+// with the same NodeConfig. There is no dry-run of init, so an aspect is only
+// ever called to set itself up.
+//
+// Aspects are compiled in by a package that registers them from its init
+// function, and which is imported by the main package of the build:
+//
+//	func init() {
+//		aspects.Register("corp-agent", &agentAspect{}, aspects.After("time-sync"))
+//	}
+//
+// The exported functions of this package keep their signatures across
+// versions of nodeadm, unlike the packages under internal/.
+package aspects
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+)
+
+// Aspect is a part of the node that is set up during the run phase of init,
+// before the daemons are started.
+type Aspect interface {
+	// Setup sets up the aspect with the NodeConfig, once it has been enriched
+	// with the details of the cluster. It is called again by the next init if
+	// it returns an error, which fails init.
+	Setup(ctx context.Context, log *zap.Logger, cfg *v1.NodeConfig) error
+}
+
+// Registration is an aspect that was registered, and where it is set up.
+type Registration struct {
+	Name   string
+	Aspect Aspect
+	// After is the name of the aspect that this aspect is set up right after.
+	After string
+	// Before is the name of the aspect that this aspect is set up right
+	// before. The aspect is set up after every aspect of nodeadm when neither
+	// After nor Before is set.
+	Before string
+}
+
+// Option sets where a registered aspect is set up.
+type Option func(*Registration)
+
+// After sets up the aspect right after the named aspect, which is either an
+// aspect of nodeadm, such as `networking` or `time-sync`, or an aspect that
+// was registered earlier.
+func After(name string) Option {
+	return func(r *Registration) {
+		r.After = name
+	}
+}
+
+// Before sets up the aspect right before the named aspect, which is either an
+// aspect of nodeadm, such as `token-cache`, or an aspect that was registered
+// earlier.
+func Before(name string) Option {
+	return func(r *Registration) {
+		r.Before = name
+	}
+}
+
+var (
+	mu            sync.Mutex
+	registrations []Registration
+)
+
+// Register registers an aspect under a name that is unique among the
+// registered aspects. It panics if the name is already registered, if the
+// aspect is nil, or if it is set up both after and before other aspects,
+// since these are mistakes of the build rather than of the node.
+func Register(name string, aspect Aspect, opts ...Option) {
+	mu.Lock()
+	defer mu.Unlock()
+	if name == "" {
+		panic("aspects: Register with an empty name")
+	}
+	if aspect == nil {
+		panic(fmt.Sprintf("aspects: Register of %q with a nil aspect", name))
+	}
+	if slices.ContainsFunc(registrations, func(r Registration) bool { return r.Name == name }) {
+		panic(fmt.Sprintf("aspects: Register called twice for %q", name))
+	}
+	registration := Registration{Name: name, Aspect: aspect}
+	for _, opt := range opts {
+		opt(&registration)
+	}
+	if registration.After != "" && registration.Before != "" {
+		panic(fmt.Sprintf("aspects: Register of %q with both After and Before", name))
+	}
+	registrations = append(registrations, registration)
+}
+
+// Registered returns the registered aspects, in the order they were
+// registered.
+func Registered() []Registration {
+	mu.Lock()
+	defer mu.Unlock()
+	return slices.Clone(registrations)
+}
@@ -0,0 +1,38 @@
+package aspects
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+)
+
+type noopAspect struct{}
+
+func (a *noopAspect) Setup(context.Context, *zap.Logger, *v1.NodeConfig) error {
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	previous := registrations
+	registrations = nil
+	t.Cleanup(func() { registrations = previous })
+
+	Register("corp-agent", &noopAspect{}, After("time-sync"))
+	Register("corp-audit", &noopAspect{}, Before("token-cache"))
+	Register("corp-motd", &noopAspect{})
+	assert.Equal(t, []Registration{
+		{Name: "corp-agent", Aspect: &noopAspect{}, After: "time-sync"},
+		{Name: "corp-audit", Aspect: &noopAspect{}, Before: "token-cache"},
+		{Name: "corp-motd", Aspect: &noopAspect{}},
+	}, Registered())
+
+	assert.PanicsWithValue(t, `aspects: Register called twice for "corp-agent"`, func() { Register("corp-agent", &noopAspect{}) })
+	assert.PanicsWithValue(t, `aspects: Register of "corp-nil" with a nil aspect`, func() { Register("corp-nil", nil) })
+	assert.Panics(t, func() { Register("", &noopAspect{}) })
+	assert.Panics(t, func() { Register("corp-both", &noopAspect{}, After("networking"), Before("time-sync")) })
+	assert.Len(t, Registered(), 3)
+}
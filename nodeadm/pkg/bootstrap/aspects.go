@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	bridgev1 "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/pkg/aspects"
+)
+
+// registeredAspect sets up an aspect that was registered by a derivative
+// build of nodeadm, with the NodeConfig of the stable API.
+type registeredAspect struct {
+	log          *zap.Logger
+	registration aspects.Registration
+}
+
+var _ system.SystemAspect = &registeredAspect{}
+
+func (a *registeredAspect) Name() string {
+	return a.registration.Name
+}
+
+func (a *registeredAspect) Setup(ctx context.Context, cfg *api.NodeConfig) error {
+	// the conversion shares memory with the NodeConfig, which the aspect must
+	// not change for the aspects and daemons after it
+	var nodeConfig v1.NodeConfig
+	if err := bridgev1.Convert_api_NodeConfig_To_v1_NodeConfig(cfg.DeepCopy(), &nodeConfig, nil); err != nil {
+		return err
+	}
+	nodeConfig.SetGroupVersionKind(v1.GroupVersion.WithKind("NodeConfig"))
+	return a.registration.Aspect.Setup(ctx, a.log.With(zap.String("aspect", a.registration.Name)), &nodeConfig)
+}
+
+// withRegisteredAspects places the aspects that were registered with
+// aspects.Register among the aspects of nodeadm.
+func withRegisteredAspects(log *zap.Logger, builtin []system.SystemAspect) ([]system.SystemAspect, error) {
+	return insertRegisteredAspects(log, builtin, aspects.Registered())
+}
+
+// insertRegisteredAspects places each registration right after or before the
+// aspect it names, or after every aspect of nodeadm. Aspects that are
+// registered after the same aspect are set up in the order they were
+// registered.
+func insertRegisteredAspects(log *zap.Logger, builtin []system.SystemAspect, registrations []aspects.Registration) ([]system.SystemAspect, error) {
+	all := slices.Clone(builtin)
+	index := func(name string) int {
+		return slices.IndexFunc(all, func(aspect system.SystemAspect) bool { return aspect.Name() == name })
+	}
+	for _, registration := range registrations {
+		if index(registration.Name) >= 0 {
+			return nil, fmt.Errorf("registered aspect %q has the name of an aspect of nodeadm", registration.Name)
+		}
+		position := len(all)
+		if after := registration.After; after != "" {
+			i := index(after)
+			if i < 0 {
+				return nil, fmt.Errorf("registered aspect %q is set up after unknown aspect %q", registration.Name, after)
+			}
+			position = i + 1
+			for position < len(all) {
+				if next, ok := all[position].(*registeredAspect); !ok || next.registration.After != after {
+					break
+				}
+				position++
+			}
+		} else if before := registration.Before; before != "" {
+			if position = index(before); position < 0 {
+				return nil, fmt.Errorf("registered aspect %q is set up before unknown aspect %q", registration.Name, before)
+			}
+		}
+		aspect := &registeredAspect{log: log, registration: registration}
+		all = slices.Insert(all, position, system.SystemAspect(aspect))
+	}
+	return all, nil
+}
@@ -0,0 +1,93 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/pkg/aspects"
+)
+
+type namedAspect string
+
+func (a namedAspect) Name() string                                       { return string(a) }
+func (a namedAspect) Setup(_ context.Context, cfg *api.NodeConfig) error { return nil }
+
+// recordingAspect records the NodeConfig that it is set up with.
+type recordingAspect struct {
+	cfg *v1.NodeConfig
+}
+
+func (a *recordingAspect) Setup(_ context.Context, _ *zap.Logger, cfg *v1.NodeConfig) error {
+	a.cfg = cfg
+	return nil
+}
+
+func aspectNames(list []system.SystemAspect) []string {
+	var names []string
+	for _, aspect := range list {
+		names = append(names, aspect.Name())
+	}
+	return names
+}
+
+func TestInsertRegisteredAspects(t *testing.T) {
+	builtin := []system.SystemAspect{namedAspect("networking"), namedAspect("time-sync"), namedAspect("token-cache")}
+	var tests = []struct {
+		name          string
+		registrations []aspects.Registration
+		expected      []string
+		expectErr     string
+	}{
+		{name: "none", expected: []string{"networking", "time-sync", "token-cache"}},
+		{
+			name: "ordered",
+			registrations: []aspects.Registration{
+				{Name: "last"},
+				{Name: "first-after-clock", After: "time-sync"},
+				{Name: "second-after-clock", After: "time-sync"},
+				{Name: "before-token", Before: "token-cache"},
+				{Name: "after-registered", After: "last"},
+			},
+			expected: []string{"networking", "time-sync", "first-after-clock", "second-after-clock", "before-token", "token-cache", "last", "after-registered"},
+		},
+		{
+			name:          "name of nodeadm aspect",
+			registrations: []aspects.Registration{{Name: "networking"}},
+			expectErr:     `registered aspect "networking" has the name of an aspect of nodeadm`,
+		},
+		{
+			name:          "unknown aspect",
+			registrations: []aspects.Registration{{Name: "corp-agent", Before: "clock"}},
+			expectErr:     `registered aspect "corp-agent" is set up before unknown aspect "clock"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			all, err := insertRegisteredAspects(zap.NewNop(), builtin, test.registrations)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, aspectNames(all))
+		})
+	}
+}
+
+func TestRegisteredAspectSetup(t *testing.T) {
+	recorder := &recordingAspect{}
+	aspect := &registeredAspect{log: zap.NewNop(), registration: aspects.Registration{Name: "corp-agent", Aspect: recorder}}
+	cfg := &api.NodeConfig{Spec: api.NodeConfigSpec{Cluster: api.ClusterDetails{Name: "my-cluster"}}}
+	assert.NoError(t, aspect.Setup(context.Background(), cfg))
+	assert.Equal(t, "my-cluster", recorder.cfg.Spec.Cluster.Name)
+	assert.Equal(t, "node.eks.aws/v1", recorder.cfg.APIVersion)
+	// the aspect is given a copy of the NodeConfig
+	recorder.cfg.Spec.Cluster.Name = "other-cluster"
+	assert.Equal(t, "my-cluster", cfg.Spec.Cluster.Name)
+}
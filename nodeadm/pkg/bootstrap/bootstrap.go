@@ -0,0 +1,313 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/hooks"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/manifest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/node"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/status"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/tracing"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// PhaseConfig writes the configuration of the daemons.
+	PhaseConfig = "config"
+	// PhaseRun sets up the system aspects and starts the daemons.
+	PhaseRun = "run"
+)
+
+// Options are the options of Init.
+type Options struct {
+	// Daemons are the names of the daemons to configure and start, such as
+	// `containerd` and `kubelet`. Every daemon is selected when it is empty.
+	Daemons []string
+	// SkipPhases are the phases of the bootstrap to skip.
+	SkipPhases []string
+	// Force sets up every system aspect again, including those that
+	// converged during an earlier bootstrap since the last boot.
+	Force bool
+	// Progress is called when each phase of the bootstrap begins, ends or is
+	// skipped.
+	Progress func(PhaseEvent)
+}
+
+// PhaseEvent is a phase of the bootstrap that began, ended or was skipped.
+type PhaseEvent struct {
+	Phase string
+	Type  PhaseEventType
+	// Duration and Err are those of a phase that ended.
+	Duration time.Duration
+	Err      error
+}
+
+type PhaseEventType string
+
+const (
+	PhaseBegin PhaseEventType = "begin"
+	PhaseEnd   PhaseEventType = "end"
+	PhaseSkip  PhaseEventType = "skip"
+)
+
+func (o Options) report(event PhaseEvent) {
+	if o.Progress != nil {
+		o.Progress(event)
+	}
+}
+
+// Result describes the changes that the bootstrap applied to the node.
+type Result struct {
+	// Phases are the phases of the bootstrap that were completed
+	Phases         []string       `json:"phases"`
+	KubeletVersion string         `json:"kubeletVersion,omitempty"`
+	Aspects        []string       `json:"aspects,omitempty"`
+	SkippedAspects []string       `json:"skippedAspects,omitempty"`
+	Daemons        []DaemonResult `json:"daemons,omitempty"`
+	// Files are the files that were written
+	Files    []string `json:"files,omitempty"`
+	Duration string   `json:"duration"`
+}
+
+type DaemonResult struct {
+	Name string `json:"name"`
+	// Changed is whether the configuration of the daemon was changed
+	Changed bool `json:"changed"`
+}
+
+// statusAnnotationTimeout is how long the node is waited for to be
+// registered before it is annotated with the status of the bootstrap.
+const statusAnnotationTimeout = 5 * time.Minute
+
+// Init bootstraps the node with an enriched Config, as `nodeadm init` does.
+// The result is returned even when the bootstrap fails, and describes the
+// changes applied until it failed. The status of the bootstrap is written to
+// status.Path either way.
+func Init(ctx context.Context, log *zap.Logger, cfg *Config, opts Options) (*Result, error) {
+	bootstrapStatus := &status.Status{
+		Phases:    []status.Phase{},
+		StartTime: time.Now(),
+	}
+	result, err := initNode(ctx, log, cfg, opts, bootstrapStatus)
+	recordStatus(ctx, log, cfg.nodeConfig, bootstrapStatus, result, err)
+	return result, err
+}
+
+func initNode(ctx context.Context, log *zap.Logger, cfg *Config, opts Options, bootstrapStatus *status.Status) (*Result, error) {
+	start := time.Now()
+	result := &Result{
+		Phases:         []string{},
+		KubeletVersion: cfg.nodeConfig.Status.KubeletVersion,
+	}
+	var transaction *daemon.Transaction
+	var selectedDaemons []daemon.Daemon
+	defer func() {
+		for _, daemon := range selectedDaemons {
+			result.Daemons = append(result.Daemons, DaemonResult{Name: daemon.Name(), Changed: transaction.Changed(daemon.Name())})
+		}
+		result.Files = util.WrittenFiles()
+		result.Duration = time.Since(start).String()
+	}()
+	nodeConfig := cfg.nodeConfig
+
+	zap.L().Info("Validating configuration..")
+	if err := api.ValidateNodeConfig(nodeConfig); err != nil {
+		return result, err
+	}
+
+	if preInit := nodeConfig.Spec.Hooks.PreInit; len(preInit) > 0 {
+		log.Info("Running pre-init hooks..")
+		hooksCtx, span := tracing.Start(ctx, "run hooks", tracing.String("hooks.point", hooks.PointPreInit))
+		err := hooks.Run(hooksCtx, hooks.PointPreInit, preInit)
+		span.End(err)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	log.Info("Creating daemon manager..")
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return result, err
+	}
+	defer daemonManager.Close()
+
+	systemAspects, err := withRegisteredAspects(log, newAspects(daemonManager))
+	if err != nil {
+		return result, err
+	}
+
+	for _, daemon := range newDaemons(daemonManager) {
+		if len(opts.Daemons) > 0 && !slices.Contains(opts.Daemons, daemon.Name()) {
+			continue
+		}
+		selectedDaemons = append(selectedDaemons, daemon)
+	}
+	// daemons are configured and started as one transaction, since a single
+	// setting can affect several of them
+	transaction = daemon.NewTransaction(daemonManager, selectedDaemons)
+
+	if !slices.Contains(opts.SkipPhases, PhaseConfig) {
+		log.Info("Configuring daemons...")
+		phaseStart := time.Now()
+		opts.report(PhaseEvent{Phase: PhaseConfig, Type: PhaseBegin})
+		phaseCtx, span := tracing.Start(ctx, "config phase")
+		err := transaction.Configure(phaseCtx, nodeConfig)
+		span.End(err)
+		bootstrapStatus.RecordPhase(PhaseConfig, phaseStart, err)
+		opts.report(PhaseEvent{Phase: PhaseConfig, Type: PhaseEnd, Duration: time.Since(phaseStart), Err: err})
+		if err != nil {
+			return result, err
+		}
+		result.Phases = append(result.Phases, PhaseConfig)
+	} else {
+		bootstrapStatus.SkipPhase(PhaseConfig)
+		opts.report(PhaseEvent{Phase: PhaseConfig, Type: PhaseSkip})
+	}
+
+	if !slices.Contains(opts.SkipPhases, PhaseRun) {
+		phaseStart := time.Now()
+		opts.report(PhaseEvent{Phase: PhaseRun, Type: PhaseBegin})
+		phaseCtx, span := tracing.Start(ctx, "run phase")
+		err := runPhase(phaseCtx, log, systemAspects, transaction, nodeConfig, opts.Force, result)
+		span.End(err)
+		bootstrapStatus.RecordPhase(PhaseRun, phaseStart, err)
+		opts.report(PhaseEvent{Phase: PhaseRun, Type: PhaseEnd, Duration: time.Since(phaseStart), Err: err})
+		if err != nil {
+			return result, err
+		}
+		result.Phases = append(result.Phases, PhaseRun)
+	} else {
+		bootstrapStatus.SkipPhase(PhaseRun)
+		opts.report(PhaseEvent{Phase: PhaseRun, Type: PhaseSkip})
+	}
+
+	log.Info("Recording managed files..", zap.String("path", manifest.ManifestPath))
+	if err := manifest.RecordWrittenFiles(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// UnitDaemonNames returns the names of the daemons that run as units, in the
+// order that init starts them.
+func UnitDaemonNames() []string {
+	var names []string
+	for _, d := range newDaemons(nil) {
+		if _, ok := d.(daemon.Unitless); !ok {
+			names = append(names, d.Name())
+		}
+	}
+	return names
+}
+
+// recordStatus writes the status of the bootstrap, and annotates the node with
+// it when that is enabled and kubelet was started. Failures are only logged,
+// since the node is bootstrapped regardless.
+func recordStatus(ctx context.Context, log *zap.Logger, cfg *api.NodeConfig, bootstrapStatus *status.Status, result *Result, initErr error) {
+	bootstrapStatus.EndTime = time.Now()
+	bootstrapStatus.KubeletVersion = cfg.Status.KubeletVersion
+	if initErr != nil {
+		bootstrapStatus.Error = initErr.Error()
+	}
+	if version, err := containerd.GetContainerdVersion(); err != nil {
+		log.Warn("Failed to get containerd version for bootstrap status", zap.Error(err))
+	} else {
+		bootstrapStatus.ContainerdVersion = version
+	}
+	if configHash, err := system.HashNodeConfig(cfg); err != nil {
+		log.Warn("Failed to hash configuration for bootstrap status", zap.Error(err))
+	} else {
+		bootstrapStatus.ConfigHash = configHash
+	}
+	log.Info("Writing bootstrap status..", zap.String("path", status.Path))
+	if err := status.Write(status.Path, bootstrapStatus); err != nil {
+		log.Warn("Failed to write bootstrap status", zap.Error(err))
+	}
+	if initErr != nil || !cfg.Spec.Kubelet.StatusAnnotations.Enabled || !slices.Contains(result.Phases, PhaseRun) {
+		return
+	}
+	nodeName := kubelet.GetNodeName(cfg)
+	log.Info("Annotating node with bootstrap status..", zap.String("node", nodeName))
+	if err := annotateNode(ctx, nodeName, bootstrapStatus); err != nil {
+		log.Warn("Failed to annotate node with bootstrap status", zap.String("node", nodeName), zap.Error(err))
+	}
+}
+
+// annotateNode waits for kubelet to register the node, and adds the status of
+// the bootstrap to it with the credentials of kubelet.
+func annotateNode(ctx context.Context, nodeName string, bootstrapStatus *status.Status) error {
+	client, err := node.NewClient(kubelet.KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, statusAnnotationTimeout)
+	defer cancel()
+	if _, err := node.WaitForNode(ctx, client, nodeName); err != nil {
+		return err
+	}
+	return status.AnnotateNode(ctx, client, nodeName, bootstrapStatus)
+}
+
+func runPhase(ctx context.Context, log *zap.Logger, aspects []system.SystemAspect, transaction *daemon.Transaction, cfg *api.NodeConfig, force bool, result *Result) error {
+	log.Info("Setting up system aspects...")
+	if err := setupAspects(ctx, log, aspects, cfg, force, result); err != nil {
+		return err
+	}
+	return transaction.EnsureRunning(ctx, cfg)
+}
+
+// setupAspects sets up each system aspect in order. An aspect that was already
+// set up with the same configuration during this boot, and whose files are
+// unchanged, is skipped, so that init can be run again after a failure
+// without repeating the aspects that converged.
+func setupAspects(ctx context.Context, log *zap.Logger, aspects []system.SystemAspect, cfg *api.NodeConfig, force bool, result *Result) error {
+	checkpoint := system.NewAspectCheckpoint(system.AspectCheckpointPath)
+	if !force {
+		var err error
+		if checkpoint, err = system.LoadAspectCheckpoint(system.AspectCheckpointPath); err != nil {
+			return err
+		}
+	}
+	configHash, err := system.HashNodeConfig(cfg)
+	if err != nil {
+		return err
+	}
+	for _, aspect := range aspects {
+		nameField := zap.String("name", aspect.Name())
+		if checkpoint.Converged(aspect.Name(), configHash) {
+			log.Info("Skipping system aspect that has converged..", nameField)
+			result.SkippedAspects = append(result.SkippedAspects, aspect.Name())
+			continue
+		}
+		log.Info("Setting up system aspect..", nameField)
+		before := util.WrittenFiles()
+		spanCtx, span := tracing.Start(ctx, "set up aspect", tracing.String("aspect.name", aspect.Name()))
+		err := aspect.Setup(spanCtx, cfg)
+		span.End(err)
+		if err != nil {
+			// the aspect may be partially set up, so it is set up again by
+			// the next init
+			if forgetErr := checkpoint.Forget(aspect.Name()); forgetErr != nil {
+				return errors.Join(err, forgetErr)
+			}
+			return err
+		}
+		if err := checkpoint.Record(aspect.Name(), configHash, before); err != nil {
+			return err
+		}
+		log.Info("Set up system aspect", nameField)
+		result.Aspects = append(result.Aspects, aspect.Name())
+	}
+	return nil
+}
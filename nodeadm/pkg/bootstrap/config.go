@@ -0,0 +1,444 @@
+// Package bootstrap embeds the bootstrap of nodeadm in other programs, such
+// as the pipelines that build custom AMIs and the operators that prepare
+// nodes, so that they do not have to run the nodeadm binary.
+//
+// The exported functions of this package keep their signatures across
+// versions of nodeadm, unlike the packages under internal/ that implement
+// them.
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge"
+	bridgev1 "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api/bridge/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/awsconfig"
+	ec2extra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ec2"
+	eksextra "github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/eks"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/endpoints"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/imds"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/logs"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/offline"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/rolesanywhere"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/environment"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/logstream"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/tracing"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// Config is a NodeConfig of any version of the API, which is resolved,
+// enriched and validated before the node is bootstrapped with it.
+type Config struct {
+	nodeConfig *api.NodeConfig
+}
+
+// LoadConfig resolves the NodeConfig of a config source, such as
+// `imds://user-data` or `file:///etc/eks/nodeadm/config.yaml`.
+func LoadConfig(source string) (*Config, error) {
+	provider, err := configprovider.BuildConfigProvider(source)
+	if err != nil {
+		return nil, err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return nil, err
+	}
+	return &Config{nodeConfig: nodeConfig}, nil
+}
+
+// DecodeConfig decodes a NodeConfig from JSON, YAML or the MIME multi-part
+// document of user data, whose NodeConfigs are merged.
+func DecodeConfig(data []byte) (*Config, error) {
+	nodeConfig, err := configprovider.ParseMaybeMultipart(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{nodeConfig: nodeConfig}, nil
+}
+
+// NewConfig returns the Config of a v1alpha1 or v1 NodeConfig.
+func NewConfig(obj runtime.Object) (*Config, error) {
+	var nodeConfig api.NodeConfig
+	var err error
+	switch in := obj.(type) {
+	case *v1alpha1.NodeConfig:
+		err = bridge.Convert_v1alpha1_NodeConfig_To_api_NodeConfig(in, &nodeConfig, nil)
+	case *v1.NodeConfig:
+		err = bridgev1.Convert_v1_NodeConfig_To_api_NodeConfig(in, &nodeConfig, nil)
+	default:
+		return nil, fmt.Errorf("unsupported type %T", obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Config{nodeConfig: &nodeConfig}, nil
+}
+
+// MarshalJSON encodes the NodeConfig, including the details that were
+// enriched, in the representation that the bootstrap works with.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.nodeConfig)
+}
+
+// Redacted returns a copy of the Config that can be logged, whose secrets,
+// such as the activation code of SSM, are redacted.
+func (c *Config) Redacted() *Config {
+	return &Config{nodeConfig: c.nodeConfig.Redacted()}
+}
+
+// Validate returns an error if the NodeConfig is invalid.
+func (c *Config) Validate() error {
+	return api.ValidateNodeConfig(c.nodeConfig)
+}
+
+// Enrich applies the tags of the instance, resolves the cluster when it is
+// discovered, and reads the details of the instance and the defaults of the
+// node, which the configs of the daemons are generated from. It must run on
+// the node that is bootstrapped.
+func (c *Config) Enrich(ctx context.Context, log *zap.Logger) error {
+	cfg := c.nodeConfig
+	if cfg.Spec.Offline {
+		log.Info("Offline mode is enabled, AWS APIs will not be called")
+		offline.Enable()
+	}
+	// the proxy is used by nodeadm and the commands it runs from here on
+	if err := system.SetProxyEnvironment(cfg); err != nil {
+		return err
+	}
+	// and so are the endpoints of AWS services, before any of them is called
+	if err := endpoints.SetEnvironment(cfg.Spec.Cluster.EndpointOverrides); err != nil {
+		return err
+	}
+	if cfg.Spec.Instance.Tags.Enabled && !cfg.IsHybrid() {
+		if err := applyInstanceTags(ctx, log, cfg); err != nil {
+			return err
+		}
+	}
+	// the tags may set the bootstrap profile, whose retries every client of
+	// AWS APIs that nodeadm creates from here on uses
+	awsconfig.SetBootstrapTuning(cfg.GetBootstrapTuning())
+	if cfg.Spec.Cluster.Discovery.Source != "" {
+		if err := discoverCluster(ctx, log, cfg); err != nil {
+			return err
+		}
+		// the details that were skipped when the config was validated
+		if err := api.ValidateClusterDetails(&cfg.Spec.Cluster); err != nil {
+			return fmt.Errorf("discovered cluster is invalid: %w", err)
+		}
+	}
+	if caFile := cfg.Spec.Cluster.CertificateAuthorityFile; caFile != "" {
+		if len(cfg.Spec.Cluster.CertificateAuthority) > 0 {
+			return fmt.Errorf("only one of certificateAuthority and certificateAuthorityFile may be provided in cluster configuration")
+		}
+		log.Info("Reading cluster certificate authority..", zap.String("path", caFile))
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		cfg.Spec.Cluster.CertificateAuthority = ca
+	}
+	log.Info("Fetching kubelet version..")
+	kubeletVersion, err := kubelet.GetKubeletVersion()
+	if err != nil {
+		return err
+	}
+	cfg.Status.KubeletVersion = kubeletVersion
+	log.Info("Fetched kubelet version", zap.String("version", kubeletVersion))
+	// the config of containerd is written for its version when it is known,
+	// and for any version otherwise
+	if containerdVersion, err := containerd.GetContainerdVersion(); err != nil {
+		log.Warn("Failed to detect containerd version", zap.Error(err))
+	} else {
+		cfg.Status.ContainerdVersion = containerdVersion
+		log.Info("Detected containerd version", zap.String("version", containerdVersion))
+	}
+	cgroupVersion, err := system.GetCgroupVersion()
+	if err != nil {
+		return err
+	}
+	cfg.Status.CgroupVersion = cgroupVersion
+	log.Info("Detected cgroup hierarchy", zap.String("version", string(cgroupVersion)))
+	cfg.Status.Architecture = environment.Current().Architecture
+	log.Info("Detected architecture", zap.String("architecture", string(cfg.Status.Architecture)))
+	if cfg.IsHybrid() {
+		// there is no instance metadata service outside of EC2, so the
+		// details of the node are taken from the config instead.
+		cfg.Status.Instance = api.InstanceDetails{
+			Region: cfg.Spec.Hybrid.Region,
+		}
+		log.Info("Instance details populated from hybrid configuration", zap.Reflect("details", cfg.Status.Instance))
+	} else if !cfg.HasInstanceMetadata() {
+		// the instance metadata service may not be reachable in offline
+		// mode, so the identity of the instance is taken from the config
+		identity := cfg.Spec.Instance.Identity
+		cfg.Status.Instance = api.InstanceDetails{
+			ID:               identity.InstanceID,
+			Region:           identity.Region,
+			Type:             identity.InstanceType,
+			AvailabilityZone: identity.AvailabilityZone,
+		}
+		log.Info("Instance details populated from instance identity", zap.Reflect("details", cfg.Status.Instance))
+	} else if err := enrichInstanceDetails(ctx, log, cfg); err != nil {
+		return err
+	}
+	log.Info("Fetching default options...")
+	sandboxImage, err := containerd.GetSandboxImage(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Status.Defaults = api.DefaultOptions{
+		SandboxImage: sandboxImage,
+	}
+	log.Info("Default options populated", zap.Reflect("defaults", cfg.Status.Defaults))
+	tracing.AddResourceAttributes(
+		tracing.String("host.id", cfg.Status.Instance.ID),
+		tracing.String("host.type", cfg.Status.Instance.Type),
+		tracing.String("host.arch", string(cfg.Status.Architecture)),
+		tracing.String("cloud.region", cfg.Status.Instance.Region),
+		tracing.String("cloud.availability_zone", cfg.Status.Instance.AvailabilityZone),
+		tracing.String("k8s.cluster.name", cfg.Spec.Cluster.Name),
+		tracing.String("k8s.kubelet.version", cfg.Status.KubeletVersion),
+	)
+	return nil
+}
+
+// StreamLogs starts streaming the logs of init to CloudWatch Logs when the
+// NodeConfig enables it, or discards the logs that were buffered for it
+// otherwise. It must be called once the NodeConfig is enriched.
+func (c *Config) StreamLogs(ctx context.Context) error {
+	cfg := c.nodeConfig
+	if !cfg.Spec.Debug.Logging.CloudWatch.Enabled || cfg.IsHybrid() || cfg.Spec.Offline {
+		logstream.Discard()
+		return nil
+	}
+	awsConfig, err := newAWSConfig(ctx, cfg)
+	if err != nil {
+		logstream.Discard()
+		return err
+	}
+	stream, err := logstream.NewStream(ctx, logs.NewClient(ctx, awsConfig), logstream.GetLogGroupName(cfg), cfg.Status.Instance.ID)
+	if err != nil {
+		logstream.Discard()
+		return err
+	}
+	logstream.Start(ctx, stream)
+	return nil
+}
+
+// ExportTrace exports the spans of the bootstrap to the tracing destinations
+// of the NodeConfig, if any.
+func (c *Config) ExportTrace(ctx context.Context) error {
+	return tracing.Export(ctx, &c.nodeConfig.Spec.Debug.Tracing)
+}
+
+// ContainerdConfig returns the config of containerd that the bootstrap
+// writes for the enriched NodeConfig.
+func (c *Config) ContainerdConfig() ([]byte, error) {
+	// the exported signature is kept, so there is no context of the caller
+	return containerd.GenerateConfig(context.TODO(), c.nodeConfig)
+}
+
+// KubeletConfig returns the config of kubelet that the bootstrap writes for
+// the enriched NodeConfig, including the fields of kubelet.config.
+func (c *Config) KubeletConfig() ([]byte, error) {
+	// the exported signature is kept, so there is no context of the caller
+	return kubelet.GenerateConfig(context.TODO(), c.nodeConfig)
+}
+
+func enrichInstanceDetails(ctx context.Context, log *zap.Logger, cfg *api.NodeConfig) error {
+	log.Info("Fetching instance details..")
+	awsConfig, err := newAWSConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	instanceDetails, err := api.GetInstanceDetails(ctx, cfg.Spec.FeatureGates, cfg.GetBootstrapTuning(), ec2extra.NewClient(awsConfig))
+	if err != nil {
+		return err
+	}
+	cfg.Status.Instance = *instanceDetails
+	if cfg.Spec.Kubelet.AutoLabels.Enabled {
+		topology, err := api.GetInstanceTopology(ctx, ec2extra.NewClient(awsConfig), instanceDetails.ID)
+		if err != nil {
+			return err
+		}
+		cfg.Status.Instance.Topology = *topology
+	}
+	log.Info("Instance details populated", zap.Reflect("details", cfg.Status.Instance))
+	return nil
+}
+
+// newAWSConfig returns the config of clients of AWS APIs, which trusts the
+// certificate authorities and uses the proxy of the NodeConfig.
+func newAWSConfig(ctx context.Context, cfg *api.NodeConfig) (aws.Config, error) {
+	awsConfigOpts := []func(*config.LoadOptions) error{
+		awsconfig.WithBootstrapTuning(cfg.GetBootstrapTuning()),
+	}
+	var transportOpts []func(*http.Transport)
+	if cfg.Spec.Instance.TrustStore.CertificateAuthorities != "" {
+		// the trust store is not updated until the run phase, so the
+		// certificate authorities are added to the client directly.
+		certPool, err := system.NewCertPool(&cfg.Spec.Instance.TrustStore)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		transportOpts = append(transportOpts, func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.RootCAs = certPool
+		})
+	}
+	if system.IsProxyEnabled(cfg) {
+		transportOpts = append(transportOpts, func(tr *http.Transport) {
+			tr.Proxy = util.ProxyFromEnvironment()
+		})
+	}
+	if len(transportOpts) > 0 {
+		awsConfigOpts = append(awsConfigOpts, config.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(transportOpts...)))
+	}
+	if cfg.IsHybrid() {
+		// there is no instance metadata service to resolve the region from
+		awsConfigOpts = append(awsConfigOpts, config.WithRegion(cfg.Spec.Hybrid.Region))
+	}
+	awsConfig, err := awsconfig.Load(ctx, awsConfigOpts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	if cfg.IsHybrid() && cfg.Spec.Hybrid.IAMRolesAnywhere.RoleARN != "" {
+		// the shared config that sources credentials from IAM Roles Anywhere
+		// is only written in the run phase, so they are sourced directly,
+		// through the proxy and trust store of the NodeConfig.
+		awsConfig.Credentials = aws.NewCredentialsCache(rolesanywhere.NewProvider(cfg.Spec.Hybrid.IAMRolesAnywhere, cfg.Spec.Hybrid.Region, awsConfig.HTTPClient))
+	}
+	return awsConfig, nil
+}
+
+// applyInstanceTags merges the fields set by the tags of the instance into
+// the NodeConfig, which they take precedence over.
+func applyInstanceTags(ctx context.Context, log *zap.Logger, cfg *api.NodeConfig) error {
+	opts := cfg.Spec.Instance.Tags
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = configprovider.DefaultInstanceTagsPrefix
+	}
+	log.Info("Reading instance tags..", zap.String("prefix", prefix), zap.String("source", string(opts.Source)))
+	var tags map[string]string
+	switch opts.Source {
+	case api.InstanceTagsSourceEC2:
+		identity, err := imds.GetInstanceIdentityDocument(ctx)
+		if err != nil {
+			return err
+		}
+		instanceID := identity.InstanceID
+		awsConfig, err := newAWSConfig(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if tags, err = ec2extra.GetInstanceTags(ctx, ec2extra.NewClient(awsConfig), instanceID, []string{prefix + "/*", prefix + ".*"}); err != nil {
+			return fmt.Errorf("failed to describe the tags of instance %s: %w", instanceID, err)
+		}
+	default:
+		var err error
+		if tags, err = imds.GetInstanceTags(ctx); err != nil {
+			return err
+		}
+	}
+	tagConfig, err := configprovider.ParseInstanceTags(tags, prefix)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Merge(tagConfig); err != nil {
+		return err
+	}
+	log.Info("Applied instance tags", zap.Reflect("spec", tagConfig.Redacted().Spec))
+	return nil
+}
+
+// defaultClusterNameTagKey is the tag of the instances of EKS managed node
+// groups and Karpenter, whose value is the name of their cluster.
+const defaultClusterNameTagKey = "eks:cluster-name"
+
+// discoverCluster resolves the name of the cluster from the rule of the
+// NodeConfig, unless it is set, and reads the details of the cluster that are
+// not set from the EKS API.
+func discoverCluster(ctx context.Context, log *zap.Logger, cfg *api.NodeConfig) error {
+	cluster := &cfg.Spec.Cluster
+	awsConfig, err := newAWSConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if cluster.Name == "" {
+		switch cluster.Discovery.Source {
+		case api.ClusterDiscoverySourceSSM:
+			parameter := cluster.Discovery.SSMParameter
+			log.Info("Reading cluster name from SSM parameter..", zap.String("parameter", parameter))
+			output, err := ssm.NewFromConfig(awsConfig).GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(parameter)})
+			if err != nil {
+				return fmt.Errorf("failed to get SSM parameter %s: %w", parameter, err)
+			}
+			cluster.Name = strings.TrimSpace(aws.ToString(output.Parameter.Value))
+		default:
+			tagKey := cluster.Discovery.TagKey
+			if tagKey == "" {
+				tagKey = defaultClusterNameTagKey
+			}
+			identity, err := imds.GetInstanceIdentityDocument(ctx)
+			if err != nil {
+				return err
+			}
+			log.Info("Reading cluster name from instance tag..", zap.String("key", tagKey))
+			tags, err := ec2extra.GetInstanceTags(ctx, ec2extra.NewClient(awsConfig), identity.InstanceID, []string{tagKey})
+			if err != nil {
+				return fmt.Errorf("failed to describe the tags of instance %s: %w", identity.InstanceID, err)
+			}
+			cluster.Name = tags[tagKey]
+		}
+		if cluster.Name == "" {
+			return fmt.Errorf("no cluster name was found by the %s rule of the cluster discovery configuration", cluster.Discovery.Source)
+		}
+	}
+	// the cluster is described until it is ready, since nodes may be launched
+	// with a cluster that is still being created
+	timeout := cluster.Discovery.GetTimeout()
+	log.Info("Describing cluster..", zap.String("name", cluster.Name), zap.Duration("timeout", timeout))
+	client := eks.NewFromConfig(awsConfig)
+	err = util.Wait(ctx, func(ctx context.Context) (bool, error) {
+		err := eksextra.EnrichClusterDetails(ctx, client, cluster)
+		if errors.Is(err, eksextra.ErrClusterNotReady) {
+			log.Info("Waiting for cluster to be ready..", zap.Error(err))
+			return false, nil
+		} else if ec2extra.IsThrottlingError(err) {
+			return false, fmt.Errorf("%w: %w", util.ErrThrottled, err)
+		}
+		return err == nil, err
+	}, cluster.Discovery.GetMinDelay(), cluster.Discovery.GetMaxDelay(), timeout)
+	if errors.Is(err, util.ErrWaiterTimeout) {
+		return fmt.Errorf("cluster %s was not ready within %v", cluster.Name, timeout)
+	} else if err != nil {
+		return err
+	}
+	log.Info("Discovered cluster", zap.String("name", cluster.Name), zap.String("apiServerEndpoint", cluster.APIServerEndpoint), zap.String("cidr", cluster.CIDR))
+	return nil
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+)
+
+func TestNewConfig(t *testing.T) {
+	cluster := v1alpha1.ClusterDetails{
+		Name:                 "my-cluster",
+		APIServerEndpoint:    "https://example.com",
+		CertificateAuthority: []byte("certificateAuthority"),
+		CIDR:                 "10.100.0.0/16",
+	}
+	config, err := NewConfig(&v1alpha1.NodeConfig{Spec: v1alpha1.NodeConfigSpec{Cluster: cluster}})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.nodeConfig.Spec.Cluster.Name)
+	assert.NoError(t, config.Validate())
+
+	config, err = NewConfig(&v1.NodeConfig{Spec: v1.NodeConfigSpec{Cluster: v1.ClusterDetails{Name: "my-cluster"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-cluster", config.nodeConfig.Spec.Cluster.Name)
+	assert.Error(t, config.Validate())
+
+	_, err = NewConfig(&metav1.Status{})
+	assert.ErrorContains(t, err, "unsupported type")
+}
+
+func TestDecodeConfig(t *testing.T) {
+	config, err := DecodeConfig([]byte(`
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    apiServerEndpoint: https://example.com
+    certificateAuthority: Y2VydGlmaWNhdGVBdXRob3JpdHk=
+    cidr: 10.100.0.0/16
+`))
+	assert.NoError(t, err)
+	assert.NoError(t, config.Validate())
+	data, err := config.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"name":"my-cluster"`)
+}
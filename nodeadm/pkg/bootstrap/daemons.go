@@ -0,0 +1,82 @@
+//go:build !windows
+
+package bootstrap
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/deregister"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/hooks"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/imageretention"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubeletconfig"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/logstream"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/podlogs"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/prepull"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/pressure"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/smoketest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/soci"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/termination"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/warmpool"
+)
+
+func newAspects(daemonManager daemon.DaemonManager) []system.SystemAspect {
+	return []system.SystemAspect{
+		system.NewNetworkCaptureAspect(),
+		system.NewTrustStoreAspect(),
+		system.NewProxyAspect(daemonManager),
+		system.NewEndpointsAspect(),
+		// before the aspects that start units, so that they are started with
+		// their drop-ins
+		system.NewSystemdUnitsAspect(daemonManager),
+		system.NewHybridAspect(daemonManager),
+		system.NewLocalDiskAspect(),
+		system.NewPodLogsAspect(),
+		system.NewJournaldAspect(),
+		system.NewSwapAspect(),
+		system.NewEFAAspect(daemonManager),
+		// after EFA, whose huge pages are allocated along with its own
+		system.NewNeuronAspect(),
+		system.NewCgroupIOAspect(daemonManager),
+		system.NewNetworkingAspect(),
+		// before the aspects and daemons that use the node's credentials,
+		// which are rejected by a clock that is off
+		system.NewTimeSyncAspect(daemonManager),
+		// after the clock is synchronized, since the agent signs its requests
+		system.NewCloudWatchAgentAspect(daemonManager),
+		system.NewGracefulShutdownAspect(),
+		system.NewHardeningAspect(),
+		// after the hardening profile, whose parameters it may override
+		system.NewSysctlAspect(),
+		// after the aspects that provide the node's credentials
+		system.NewTokenCacheAspect(),
+	}
+}
+
+func newDaemons(daemonManager daemon.DaemonManager) []daemon.Daemon {
+	return []daemon.Daemon{
+		// first, so that the journals of the daemons that fail to start are
+		// streamed
+		logstream.NewLogStreamDaemon(daemonManager),
+		soci.NewSOCIDaemon(daemonManager),
+		containerd.NewContainerdDaemon(daemonManager),
+		// before anything else runs on containerd, so that a broken runtime
+		// is reported as such
+		smoketest.NewSmokeTestDaemon(),
+		hooks.NewPostContainerdDaemon(),
+		// after containerd is running, and before kubelet registers the node
+		prepull.NewPrePullDaemon(),
+		// once the node is prepared, an instance in a warm pool waits here
+		// until it enters service
+		warmpool.NewWarmPoolDaemon(),
+		kubelet.NewKubeletDaemon(daemonManager),
+		hooks.NewPostKubeletDaemon(),
+		kubeletconfig.NewKubeletConfigDaemon(daemonManager),
+		imageretention.NewImageRetentionDaemon(daemonManager),
+		podlogs.NewForwarderDaemon(daemonManager),
+		deregister.NewDeregisterDaemon(daemonManager),
+		pressure.NewPressureMonitorDaemon(daemonManager),
+		termination.NewTerminationHandlerDaemon(daemonManager),
+	}
+}
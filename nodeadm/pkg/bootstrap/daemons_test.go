@@ -0,0 +1,23 @@
+//go:build !windows
+
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/smoketest"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/termination"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/warmpool"
+)
+
+func TestUnitDaemonNames(t *testing.T) {
+	names := UnitDaemonNames()
+	assert.Contains(t, names, kubelet.KubeletDaemonName)
+	assert.Contains(t, names, termination.TerminationHandlerDaemonName)
+	assert.NotContains(t, names, smoketest.SmokeTestDaemonName)
+	assert.NotContains(t, names, warmpool.WarmPoolDaemonName)
+	assert.NotContains(t, names, "hooks-post-kubelet")
+}
@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/hooks"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+// newAspects returns no aspects on Windows, since each of them sets up a part
+// of a Linux host, such as sysctls, systemd units and mounts.
+func newAspects(daemonManager daemon.DaemonManager) []system.SystemAspect {
+	return nil
+}
+
+// newDaemons returns the daemons that run as services of the Service Control
+// Manager on Windows. The others are systemd units of the Linux AMIs.
+func newDaemons(daemonManager daemon.DaemonManager) []daemon.Daemon {
+	return []daemon.Daemon{
+		containerd.NewContainerdDaemon(daemonManager),
+		hooks.NewPostContainerdDaemon(),
+		kubelet.NewKubeletDaemon(daemonManager),
+		hooks.NewPostKubeletDaemon(),
+	}
+}
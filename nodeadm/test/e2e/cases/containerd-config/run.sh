@@ -9,11 +9,17 @@ source /helpers.sh
 mock::aws
 wait::dbus-ready
 
+# files that users add to the config directory are preserved
+mkdir -p /etc/containerd/config.d
+echo '[grpc]' > /etc/containerd/config.d/50-user.toml
+
 mock::kubelet 1.31.0
 nodeadm init --skip run --config-source file://config.yaml
-assert::files-equal /etc/containerd/config.toml expected-containerd-config-pre-1.32.toml
+assert::files-equal /etc/containerd/config.d/00-nodeadm.toml expected-containerd-config-pre-1.32.toml
 
 # enable_cdi defaults to true in 1.32+
 mock::kubelet 1.32.0
 nodeadm init --skip run --config-source file://config.yaml
-assert::files-equal /etc/containerd/config.toml expected-containerd-config.toml
+assert::files-equal /etc/containerd/config.d/00-nodeadm.toml expected-containerd-config.toml
+assert::files-equal /etc/containerd/config.toml expected-containerd-root-config.toml
+assert::file-contains /etc/containerd/config.d/50-user.toml '\[grpc\]'
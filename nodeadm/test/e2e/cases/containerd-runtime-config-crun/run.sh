@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.27.0
+wait::dbus-ready
+
+# preflight fails when crun is not installed
+if nodeadm init --skip run --config-source file://config.yaml; then
+  echo "nodeadm init should fail when crun is not installed"
+  exit 1
+fi
+
+touch /usr/bin/crun
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::files-equal /etc/containerd/config.d/00-nodeadm.toml expected-containerd-config.toml
@@ -14,4 +14,4 @@ touch /usr/bin/nvidia-container-runtime
 
 nodeadm init --skip run --config-source file://config.yaml
 
-assert::files-equal /etc/containerd/config.toml expected-containerd-config.toml
+assert::files-equal /etc/containerd/config.d/00-nodeadm.toml expected-containerd-config.toml
@@ -0,0 +1,19 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+# the instance metadata service is intentionally not mocked, since it isn't
+# available to hybrid nodes.
+mock::kubelet 1.30.0
+wait::dbus-ready
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::file-contains /etc/eks/kubelet/environment '--hostname-override=my-hybrid-node'
+assert::file-contains /etc/eks/kubelet/environment '--node-ip=192.168.1.10'
+assert::file-contains /etc/eks/kubelet/environment 'AWS_CONFIG_FILE=/etc/eks/hybrid/aws-config'
+assert::file-contains /var/lib/kubelet/kubeconfig 'us-west-2'
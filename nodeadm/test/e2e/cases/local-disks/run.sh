@@ -10,8 +10,10 @@ mock::aws
 wait::dbus-ready
 mock::kubelet 1.29.0
 
-mock::setup-local-disks
-
 nodeadm init --daemon="" --config-source file://config.yaml
 
-assert::file-contains /var/log/setup-local-disks.log 'raid0'
+# the container has no instance store disks, so none are mounted
+if [ -f '/etc/systemd/system/mnt-k8s\x2ddisks-0.mount' ]; then
+  echo "local disks should not be mounted without instance store disks"
+  exit 1
+fi
@@ -16,3 +16,8 @@ assert::file-contains /etc/eks/kubelet/environment '--pod-infra-container-image=
 mock::kubelet 1.29.0
 nodeadm init --skip run --config-source file://config.yaml
 assert::file-not-contains /etc/eks/kubelet/environment 'pod-infra-container-image'
+
+mock::kubelet 1.28.0
+nodeadm init --skip run --config-source file://config-sandbox-image.yaml
+assert::file-contains /etc/eks/kubelet/environment '--pod-infra-container-image=602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10'
+assert::file-contains /etc/containerd/config.d/00-nodeadm.toml 'sandbox_image = "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.10"'
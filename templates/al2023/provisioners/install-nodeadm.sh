@@ -33,4 +33,4 @@ sudo chmod a+x $PROJECT_DIR/_bin/nodeadm
 sudo mv $PROJECT_DIR/_bin/nodeadm /usr/bin/
 
 # enable nodeadm bootstrap systemd units
-sudo systemctl enable nodeadm-config nodeadm-run
+sudo systemctl enable nodeadm-config nodeadm-run nodeadm-credentials-refresh.timer
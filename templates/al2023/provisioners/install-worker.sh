@@ -146,6 +146,9 @@ sudo systemctl enable ebs-initialize-bin@containerd
 
 sudo dnf install -y nerdctl
 
+# install cri-tools for crictl, needed to interact with containerd's CRI server
+sudo dnf install -y cri-tools
+
 # TODO: are these necessary? What do they do?
 sudo dnf install -y device-mapper-persistent-data lvm2
 